@@ -108,6 +108,31 @@ func (c *Client) UpgradeRelease(
 	)
 }
 
+// DiffUpgradeRelease renders an upgrade of a release without applying it, and returns the changes the upgrade
+// would make to the resources of the release
+func (c *Client) DiffUpgradeRelease(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+	req *types.UpgradeReleaseRequest,
+) (*types.ReleaseDiff, error) {
+	resp := &types.ReleaseDiff{}
+
+	req.DryRun = true
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/upgrade",
+			projID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 // DeleteRelease deletes a Porter release
 func (c *Client) DeleteRelease(
 	ctx context.Context,
@@ -32,3 +32,23 @@ func (c *Client) CreateDeploymentTarget(
 
 	return resp, err
 }
+
+// GetDeploymentTarget retrieves a deployment target for a given project and cluster by its id
+func (c *Client) GetDeploymentTarget(
+	ctx context.Context,
+	projectID, clusterID uint,
+	deploymentTargetID string,
+) (*deployment_target.GetDeploymentTargetResponse, error) {
+	resp := &deployment_target.GetDeploymentTargetResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/deployment-targets/%s",
+			projectID, clusterID, deploymentTargetID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
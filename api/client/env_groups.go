@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/porter-dev/porter/api/server/handlers/environment_groups"
+	"github.com/porter-dev/porter/api/types"
 )
 
 // GetLatestEnvGroupVariables gets the latest environment group variables for a given environment group
@@ -54,3 +55,154 @@ func (c *Client) UpdateEnvGroup(
 		nil,
 	)
 }
+
+// GetEnvGroupImportSource gets the import source of an environment group
+func (c *Client) GetEnvGroupImportSource(
+	ctx context.Context,
+	projID, clusterID uint,
+	envGroupName string,
+) (*types.EnvGroupImportSource, error) {
+	resp := &types.EnvGroupImportSource{}
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/clusters/%d/environment-groups/%s/import-source", projID, clusterID, envGroupName),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateEnvGroupImportSource creates or updates the import source of an environment group
+func (c *Client) UpdateEnvGroupImportSource(
+	ctx context.Context,
+	projID, clusterID uint,
+	envGroupName string,
+	req *types.UpdateEnvGroupImportSourceRequest,
+) (*types.EnvGroupImportSource, error) {
+	resp := &types.EnvGroupImportSource{}
+
+	err := c.postRequest(
+		fmt.Sprintf("/projects/%d/clusters/%d/environment-groups/%s/import-source", projID, clusterID, envGroupName),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteEnvGroupImportSource deletes the import source of an environment group
+func (c *Client) DeleteEnvGroupImportSource(
+	ctx context.Context,
+	projID, clusterID uint,
+	envGroupName string,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf("/projects/%d/clusters/%d/environment-groups/%s/import-source", projID, clusterID, envGroupName),
+		nil,
+		nil,
+	)
+}
+
+// SyncEnvGroupImportSource syncs an environment group with its import source
+func (c *Client) SyncEnvGroupImportSource(
+	ctx context.Context,
+	projID, clusterID uint,
+	envGroupName string,
+) (*types.EnvGroupImportSyncResult, error) {
+	resp := &types.EnvGroupImportSyncResult{}
+
+	err := c.postRequest(
+		fmt.Sprintf("/projects/%d/clusters/%d/environment-groups/%s/import-source/sync", projID, clusterID, envGroupName),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListEnvGroupVersions lists the versions of an environment group, from the most recent to the oldest
+func (c *Client) ListEnvGroupVersions(
+	ctx context.Context,
+	projID, clusterID uint,
+	envGroupName string,
+) (*environment_groups.ListEnvGroupVersionsResponse, error) {
+	resp := &environment_groups.ListEnvGroupVersionsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/clusters/%d/environment-groups/%s/versions", projID, clusterID, envGroupName),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DiffEnvGroupVersions returns the changes between two versions of an environment group. Secret values are redacted.
+func (c *Client) DiffEnvGroupVersions(
+	ctx context.Context,
+	projID, clusterID uint,
+	envGroupName string,
+	req *environment_groups.DiffEnvGroupVersionsRequest,
+) (*environment_groups.DiffEnvGroupVersionsResponse, error) {
+	resp := &environment_groups.DiffEnvGroupVersionsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/clusters/%d/environment-groups/%s/versions/diff", projID, clusterID, envGroupName),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RollbackEnvGroup rolls an environment group back to a previous version
+func (c *Client) RollbackEnvGroup(
+	ctx context.Context,
+	projID, clusterID uint,
+	envGroupName string,
+	req *environment_groups.RollbackEnvGroupRequest,
+) (*environment_groups.RollbackEnvGroupResponse, error) {
+	resp := &environment_groups.RollbackEnvGroupResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf("/projects/%d/clusters/%d/environment-groups/%s/rollback", projID, clusterID, envGroupName),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetEnvGroupRetentionPolicy returns the environment group retention policy of a project
+func (c *Client) GetEnvGroupRetentionPolicy(
+	ctx context.Context,
+	projectID uint,
+) (*types.EnvGroupRetentionPolicy, error) {
+	resp := &types.EnvGroupRetentionPolicy{}
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/env-group-retention-policy", projectID),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateEnvGroupRetentionPolicy creates or updates the environment group retention policy of a project
+func (c *Client) UpdateEnvGroupRetentionPolicy(
+	ctx context.Context,
+	projectID uint,
+	req *types.UpdateEnvGroupRetentionPolicyRequest,
+) (*types.EnvGroupRetentionPolicy, error) {
+	resp := &types.EnvGroupRetentionPolicy{}
+
+	err := c.postRequest(
+		fmt.Sprintf("/projects/%d/env-group-retention-policy", projectID),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/porter-dev/porter/api/server/handlers/porter_app"
 	"github.com/porter-dev/porter/internal/models"
@@ -202,6 +203,33 @@ func (c *Client) GetAppManifests(
 	return resp, err
 }
 
+// CheckAppPolicies evaluates the enforced policies of the project's OPA policy bundles against the manifests of a revision of an app
+func (c *Client) CheckAppPolicies(
+	ctx context.Context,
+	projectID, clusterID uint,
+	appName string,
+	deploymentTargetID string,
+	appRevisionID string,
+) (*porter_app.AppPolicyCheckResponse, error) {
+	resp := &porter_app.AppPolicyCheckResponse{}
+
+	req := &porter_app.AppPolicyCheckRequest{
+		DeploymentTargetID: deploymentTargetID,
+		AppRevisionID:      appRevisionID,
+	}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/apps/%s/policy-check",
+			projectID, clusterID, appName,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 // UpdateAppInput is the input struct to UpdateApp
 type UpdateAppInput struct {
 	ProjectID            uint
@@ -378,16 +406,19 @@ func (c *Client) CreatePorterAppDBEntry(
 	return err
 }
 
-// CreateSubdomain returns a subdomain for a given service that point to the ingress-nginx service in the cluster
+// CreateSubdomain returns a subdomain for a given service that point to the ingress-nginx service in the cluster.
+// If deploymentTargetID is empty, the subdomain is created for the default deployment target of the cluster.
 func (c *Client) CreateSubdomain(
 	ctx context.Context,
 	projectID uint, clusterID uint,
 	appName string, serviceName string,
+	deploymentTargetID string,
 ) (*porter_app.CreateSubdomainResponse, error) {
 	resp := &porter_app.CreateSubdomainResponse{}
 
 	req := &porter_app.CreateSubdomainRequest{
-		ServiceName: serviceName,
+		ServiceName:        serviceName,
+		DeploymentTargetID: deploymentTargetID,
 	}
 
 	err := c.postRequest(
@@ -784,3 +815,78 @@ func (c *Client) RunAppJobStatus(
 
 	return resp, err
 }
+
+// AppLogsInput is the input struct to AppLogs
+type AppLogsInput struct {
+	ProjectID uint
+	ClusterID uint
+	AppName   string
+
+	// DeploymentTargetID is the id of the deployment target to get logs for
+	DeploymentTargetID string
+	// ServiceName is the name of the service to get logs for, or "all" to get the logs of all services of the app
+	ServiceName string
+	// AppRevisionID optionally restricts the logs to the ones emitted by a specific revision
+	AppRevisionID string
+
+	// StartRange is the start of the time range of the logs. The range defaults to the last day if unset.
+	StartRange time.Time
+	// EndRange is the end of the time range of the logs. The range ends now if unset.
+	EndRange time.Time
+	// Limit is the maximum number of log lines to return
+	Limit uint
+	// Direction is either "forward" to return the oldest logs of the range first, or "backward" to return the newest logs first
+	Direction string
+	// SearchParam optionally restricts the logs to the lines containing it
+	SearchParam string
+}
+
+// appLogsQuery holds the query parameters of the /apps/{porter_app_name}/logs endpoint. Time ranges are encoded as
+// RFC 3339 strings, which the schema encoder does not do for time.Time fields.
+type appLogsQuery struct {
+	DeploymentTargetID string `schema:"deployment_target_id"`
+	ServiceName        string `schema:"service_name"`
+	AppRevisionID      string `schema:"app_revision_id,omitempty"`
+	StartRange         string `schema:"start_range,omitempty"`
+	EndRange           string `schema:"end_range,omitempty"`
+	Limit              uint   `schema:"limit,omitempty"`
+	Direction          string `schema:"direction,omitempty"`
+	SearchParam        string `schema:"search_param,omitempty"`
+}
+
+// AppLogs returns the logs of the services of an app within a time range
+func (c *Client) AppLogs(
+	ctx context.Context,
+	input AppLogsInput,
+) (*porter_app.AppLogsResponse, error) {
+	resp := &porter_app.AppLogsResponse{}
+
+	req := &appLogsQuery{
+		DeploymentTargetID: input.DeploymentTargetID,
+		ServiceName:        input.ServiceName,
+		AppRevisionID:      input.AppRevisionID,
+		Limit:              input.Limit,
+		Direction:          input.Direction,
+		SearchParam:        input.SearchParam,
+	}
+
+	if !input.StartRange.IsZero() {
+		req.StartRange = input.StartRange.UTC().Format(time.RFC3339Nano)
+	}
+
+	if !input.EndRange.IsZero() {
+		req.EndRange = input.EndRange.UTC().Format(time.RFC3339Nano)
+	}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/apps/%s/logs",
+			input.ProjectID, input.ClusterID,
+			input.AppName,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -315,3 +315,164 @@ func (c *Client) CreateRepository(
 		nil,
 	)
 }
+
+// CreateImageScan scans an image of a repository of a registry and stores the results for its digest
+func (c *Client) CreateImageScan(
+	ctx context.Context,
+	projectID, registryID uint,
+	req *types.CreateImageScanRequest,
+) (*types.ImageScan, error) {
+	resp := &types.ImageScan{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/scans",
+			projectID,
+			registryID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetImageScans returns the latest scan of an image of a repository of a registry by each scanner
+func (c *Client) GetImageScans(
+	ctx context.Context,
+	projectID, registryID uint,
+	req *types.GetImageScansRequest,
+) (*types.GetImageScansResponse, error) {
+	resp := &types.GetImageScansResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/scans",
+			projectID,
+			registryID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetImageScanPolicy returns the severity gate of a project
+func (c *Client) GetImageScanPolicy(
+	ctx context.Context,
+	projectID uint,
+) (*types.ImageScanPolicy, error) {
+	resp := &types.ImageScanPolicy{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/image-scan-policy",
+			projectID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateImageScanPolicy sets the severity gate of a project
+func (c *Client) UpdateImageScanPolicy(
+	ctx context.Context,
+	projectID uint,
+	req *types.ImageScanPolicy,
+) (*types.ImageScanPolicy, error) {
+	resp := &types.ImageScanPolicy{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/image-scan-policy",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListRegistryRetentionPolicies returns the retention policies of the image repositories of a registry
+func (c *Client) ListRegistryRetentionPolicies(
+	ctx context.Context,
+	projectID, registryID uint,
+) (*types.ListRegistryRetentionPoliciesResponse, error) {
+	resp := &types.ListRegistryRetentionPoliciesResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/retention-policies",
+			projectID,
+			registryID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateRegistryRetentionPolicy creates or updates the retention policy of an image repository of a registry
+func (c *Client) UpdateRegistryRetentionPolicy(
+	ctx context.Context,
+	projectID, registryID uint,
+	req *types.UpdateRegistryRetentionPolicyRequest,
+) (*types.RegistryRetentionPolicy, error) {
+	resp := &types.RegistryRetentionPolicy{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/retention-policies",
+			projectID,
+			registryID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteRegistryRetentionPolicy deletes the retention policy of an image repository of a registry
+func (c *Client) DeleteRegistryRetentionPolicy(
+	ctx context.Context,
+	projectID, registryID uint,
+	req *types.DeleteRegistryRetentionPolicyRequest,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/retention-policies",
+			projectID,
+			registryID,
+		),
+		req,
+		nil,
+	)
+}
+
+// GetRegistryRetentionReport returns which images of an image repository of a registry would be kept and deleted
+// by its retention policy, without deleting any image
+func (c *Client) GetRegistryRetentionReport(
+	ctx context.Context,
+	projectID, registryID uint,
+	req *types.GetRegistryRetentionReportRequest,
+) (*types.RegistryRetentionReport, error) {
+	resp := &types.RegistryRetentionReport{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/retention-policies/report",
+			projectID,
+			registryID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
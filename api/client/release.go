@@ -28,3 +28,86 @@ func (c *Client) ListReleases(
 
 	return resp, err
 }
+
+// ListArchivedReleaseRevisions lists the revisions of a release which were pruned from the cluster and archived
+func (c *Client) ListArchivedReleaseRevisions(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+) (*types.ListArchivedReleaseRevisionsResponse, error) {
+	resp := &types.ListArchivedReleaseRevisionsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/archived_revisions",
+			projectID, clusterID,
+			namespace, name,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RestoreArchivedReleaseRevision restores an archived revision of a release into the cluster
+func (c *Client) RestoreArchivedReleaseRevision(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+	req *types.RestoreArchivedReleaseRevisionRequest,
+) error {
+	return c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/archived_revisions/restore",
+			projectID, clusterID,
+			namespace, name,
+		),
+		req,
+		nil,
+	)
+}
+
+// GetResourceGraph exports the graph of the objects of the releases in a namespace
+func (c *Client) GetResourceGraph(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.GetResourceGraphRequest,
+) (*types.GetResourceGraphResponse, error) {
+	resp := &types.GetResourceGraphResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/resource_graph",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetResourceImpact lists the objects in a namespace affected by a change to an object
+func (c *Client) GetResourceImpact(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.GetResourceImpactRequest,
+) (*types.GetResourceImpactResponse, error) {
+	resp := &types.GetResourceImpactResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/resource_graph/impact",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
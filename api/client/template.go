@@ -45,3 +45,24 @@ func (c *Client) GetTemplate(
 
 	return resp, err
 }
+
+func (c *Client) GetTemplateUpgradeNotes(
+	ctx context.Context,
+	projectID uint,
+	name, version string,
+	req *types.GetTemplateUpgradeNotesRequest,
+) (*types.GetTemplateUpgradeNotesResponse, error) {
+	resp := &types.GetTemplateUpgradeNotesResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/v1/projects/%d/templates/%s/versions/%s/upgrade_notes",
+			projectID,
+			name, version,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
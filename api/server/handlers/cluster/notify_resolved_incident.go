@@ -16,6 +16,8 @@ import (
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/notifier/sendgrid"
 	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/teams"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
 	"gorm.io/gorm"
 )
 
@@ -50,6 +52,7 @@ func (c *NotifyResolvedIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http
 	}
 
 	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
+	webhookInts, _ := c.Repo().NotificationWebhookIntegration().ListNotificationWebhookIntegrationsByProjectID(cluster.ProjectID)
 
 	rel, err := c.Repo().Release().ReadRelease(cluster.ID, request.ReleaseName, request.ReleaseNamespace)
 
@@ -76,6 +79,12 @@ func (c *NotifyResolvedIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http
 		notifiers = append(notifiers, slack.NewIncidentNotifier(slackInts...))
 	}
 
+	notifiers = append(
+		notifiers,
+		webhook.NewIncidentNotifier(webhookInts...),
+		teams.NewIncidentNotifier(webhookInts...),
+	)
+
 	if sc := c.Config().ServerConf; sc.SendgridAPIKey != "" && sc.SendgridSenderEmail != "" && sc.SendgridIncidentAlertTemplateID != "" {
 		users, err := getUsersByProjectID(c.Repo(), cluster.ProjectID)
 		if err != nil {
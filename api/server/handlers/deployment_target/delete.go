@@ -1,9 +1,12 @@
 package deployment_target
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 
 	"connectrpc.com/connect"
+	"github.com/google/uuid"
 	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -13,6 +16,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
 	"github.com/porter-dev/porter/internal/telemetry"
 )
 
@@ -53,17 +57,81 @@ func (c *DeleteDeploymentTargetHandler) ServeHTTP(w http.ResponseWriter, r *http
 		return
 	}
 
+	// subdomains created before records were assigned to apps are found by the domains of the apps in the deployment target,
+	// which are read before the deployment target is deleted
+	hostnames, err := c.deploymentTargetHostnames(ctx, project.ID, deploymentTargetID)
+	if err != nil {
+		_ = telemetry.Error(ctx, span, err, "error getting deployment target hostnames")
+	}
+
 	deleteReq := connect.NewRequest(&porterv1.DeleteDeploymentTargetRequest{
 		ProjectId:          int64(project.ID),
 		DeploymentTargetId: deploymentTargetID,
 	})
 
-	_, err := c.Config().ClusterControlPlaneClient.DeleteDeploymentTarget(ctx, deleteReq)
+	_, err = c.Config().ClusterControlPlaneClient.DeleteDeploymentTarget(ctx, deleteReq)
 	if err != nil {
 		err := telemetry.Error(ctx, span, err, "error deleting deployment target")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
 		return
 	}
 
+	// subdomains created for apps in the deployment target, such as those of a preview environment, are deleted with it
+	err = c.deleteSubdomains(ctx, deploymentTargetID, hostnames)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error deleting deployment target subdomains")
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+
 	c.WriteResult(w, r, nil)
 }
+
+func (c *DeleteDeploymentTargetHandler) deleteSubdomains(ctx context.Context, deploymentTargetID string, hostnames []string) error {
+	id, err := uuid.Parse(deploymentTargetID)
+	if err != nil {
+		return fmt.Errorf("error parsing deployment target id: %w", err)
+	}
+
+	records, err := c.Repo().DNSRecord().ListDNSRecordsByDeploymentTargetID(id)
+	if err != nil {
+		return fmt.Errorf("error listing dns records: %w", err)
+	}
+
+	legacyRecords, err := c.Repo().DNSRecord().ListDNSRecordsWithoutAppByHostnames(hostnames)
+	if err != nil {
+		return fmt.Errorf("error listing dns records by hostname: %w", err)
+	}
+
+	records = append(records, legacyRecords...)
+
+	return porter_app.DeletePorterSubdomains(ctx, porter_app.DeletePorterSubdomainsInput{
+		Records:             records,
+		DNSClient:           c.Config().DNSClient,
+		DNSRecordRepository: c.Repo().DNSRecord(),
+	})
+}
+
+// deploymentTargetHostnames returns the domains of the apps in the deployment target
+func (c *DeleteDeploymentTargetHandler) deploymentTargetHostnames(ctx context.Context, projectID uint, deploymentTargetID string) ([]string, error) {
+	latestAppRevisionsResp, err := c.Config().ClusterControlPlaneClient.LatestAppRevisions(ctx, connect.NewRequest(&porterv1.LatestAppRevisionsRequest{
+		ProjectId: int64(projectID),
+		DeploymentTargetIdentifier: &porterv1.DeploymentTargetIdentifier{
+			Id: deploymentTargetID,
+		},
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest app revisions: %w", err)
+	}
+
+	if latestAppRevisionsResp == nil || latestAppRevisionsResp.Msg == nil {
+		return nil, nil
+	}
+
+	var hostnames []string
+
+	for _, revision := range latestAppRevisionsResp.Msg.AppRevisions {
+		hostnames = append(hostnames, porter_app.AppHostnames(revision.GetApp())...)
+	}
+
+	return hostnames, nil
+}
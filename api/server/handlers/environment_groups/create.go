@@ -1,6 +1,7 @@
 package environment_groups
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	environmentgroups "github.com/porter-dev/porter/internal/kubernetes/environment_groups"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/telemetry"
 )
@@ -110,6 +112,8 @@ func (c *UpdateEnvironmentGroupHandler) ServeHTTP(w http.ResponseWriter, r *http
 		}
 
 	default:
+		updatedAt := time.Now().UTC()
+
 		_, err := c.Config().ClusterControlPlaneClient.CreateOrUpdateEnvGroup(ctx, connect.NewRequest(&porterv1.CreateOrUpdateEnvGroupRequest{
 			ProjectId:            int64(cluster.ProjectID),
 			ClusterId:            int64(cluster.ID),
@@ -131,6 +135,15 @@ func (c *UpdateEnvironmentGroupHandler) ServeHTTP(w http.ResponseWriter, r *http
 			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
 			return
 		}
+
+		// the cluster control plane does not record the author of the new version, so it is recorded once the update is done.
+		// The update is not failed if the author cannot be recorded, since the environment group has already been updated.
+		c.recordAuthor(ctx, r, cluster, environmentgroups.RecordEnvironmentGroupAuthorInput{
+			Name:            request.Name,
+			UpdatedAt:       updatedAt,
+			Variables:       request.Variables,
+			SecretVariables: request.SecretVariables,
+		})
 	}
 
 	envGroupResponse := &UpdateEnvironmentGroupResponse{
@@ -139,3 +152,32 @@ func (c *UpdateEnvironmentGroupHandler) ServeHTTP(w http.ResponseWriter, r *http
 	}
 	c.WriteResult(w, r, envGroupResponse)
 }
+
+// recordAuthor records the user making the request as the author of the version of the environment group created by the update
+func (c *UpdateEnvironmentGroupHandler) recordAuthor(ctx context.Context, r *http.Request, cluster *models.Cluster, inp environmentgroups.RecordEnvironmentGroupAuthorInput) {
+	ctx, span := telemetry.NewSpan(ctx, "record-env-group-author")
+	defer span.End()
+
+	user, _ := ctx.Value(types.UserScope).(*models.User)
+	if user == nil {
+		return
+	}
+	inp.CreatedBy = user.Email
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		_ = telemetry.Error(ctx, span, err, "unable to connect to cluster")
+		return
+	}
+
+	version, recorded, err := environmentgroups.RecordEnvironmentGroupAuthor(ctx, agent, inp)
+	if err != nil {
+		_ = telemetry.Error(ctx, span, err, "unable to record author of environment group")
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "environment-group-version", Value: version},
+		telemetry.AttributeKV{Key: "author-recorded", Value: recorded},
+	)
+}
@@ -1,6 +1,7 @@
 package environment_groups
 
 import (
+	"errors"
 	"net/http"
 
 	"connectrpc.com/connect"
@@ -15,6 +16,7 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
 )
 
 // DeleteEnvironmentGroupHandler is the handler for the DELETE /environment-group endpoint
@@ -87,5 +89,21 @@ func (c *DeleteEnvironmentGroupHandler) ServeHTTP(w http.ResponseWriter, r *http
 			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
 			return
 		}
+
+		// the import source of the environment group is deleted with it, so that its next sync does not create the environment group again
+		source, err := c.Repo().EnvGroupImportSource().ReadEnvGroupImportSource(ctx, cluster.ProjectID, cluster.ID, request.Name)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			err := telemetry.Error(ctx, span, err, "unable to read import source of environment group")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		if err == nil {
+			if err := c.Repo().EnvGroupImportSource().DeleteEnvGroupImportSource(ctx, source); err != nil {
+				err := telemetry.Error(ctx, span, err, "unable to delete import source of environment group")
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+				return
+			}
+		}
 	}
 }
@@ -0,0 +1,402 @@
+package environment_groups
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"connectrpc.com/connect"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	environmentgroups "github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups/diff"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// EnvGroupVersion is a version of an environment group. Secret values are replaced with a dummy value.
+type EnvGroupVersion struct {
+	Version         int               `json:"version"`
+	CreatedAtUTC    time.Time         `json:"created_at"`
+	CreatedBy       string            `json:"created_by,omitempty"`
+	Variables       map[string]string `json:"variables,omitempty"`
+	SecretVariables map[string]string `json:"secret_variables,omitempty"`
+}
+
+// EnvGroupVariableChange is a change to a single key of an environment group. Secret values are replaced with a dummy value.
+type EnvGroupVariableChange struct {
+	Key string `json:"key"`
+	// Secret is whether the key is a secret after the change
+	Secret bool `json:"secret"`
+	// WasSecret is whether the key was a secret before the change
+	WasSecret bool   `json:"was_secret"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+}
+
+// EnvGroupChanges are the keys added, changed and removed between two versions of an environment group
+type EnvGroupChanges struct {
+	Added   []EnvGroupVariableChange `json:"added"`
+	Changed []EnvGroupVariableChange `json:"changed"`
+	Removed []EnvGroupVariableChange `json:"removed"`
+}
+
+// ListEnvGroupVersionsHandler is the handler for the /environment-groups/{env_group_name}/versions endpoint
+type ListEnvGroupVersionsHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewListEnvGroupVersionsHandler creates an instance of ListEnvGroupVersionsHandler
+func NewListEnvGroupVersionsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListEnvGroupVersionsHandler {
+	return &ListEnvGroupVersionsHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ListEnvGroupVersionsResponse is the response object for the /environment-groups/{env_group_name}/versions endpoint
+type ListEnvGroupVersionsResponse struct {
+	// Versions are the versions of the environment group, from the most recent to the oldest
+	Versions []EnvGroupVersion `json:"versions"`
+}
+
+// ServeHTTP lists all versions of an environment group along with their author and creation time
+func (c *ListEnvGroupVersionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-env-group-versions")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	envGroupName, reqErr := requestutils.GetURLParamString(r, types.URLParamEnvGroupName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing env group name from url")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "env-group-name", Value: envGroupName})
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to connect to cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusServiceUnavailable))
+		return
+	}
+
+	versions, err := environmentgroups.ListEnvironmentGroupVersions(ctx, agent, envGroupName)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to list env group versions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	if len(versions) == 0 {
+		err = telemetry.Error(ctx, span, nil, "env group does not exist")
+		c.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+		return
+	}
+
+	res := &ListEnvGroupVersionsResponse{
+		Versions: make([]EnvGroupVersion, 0, len(versions)),
+	}
+
+	for _, version := range versions {
+		res.Versions = append(res.Versions, EnvGroupVersion{
+			Version:         version.Version,
+			CreatedAtUTC:    version.CreatedAtUTC,
+			CreatedBy:       version.CreatedBy,
+			Variables:       version.Variables,
+			SecretVariables: version.SecretVariables,
+		})
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// DiffEnvGroupVersionsHandler is the handler for the /environment-groups/{env_group_name}/versions/diff endpoint
+type DiffEnvGroupVersionsHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewDiffEnvGroupVersionsHandler creates an instance of DiffEnvGroupVersionsHandler
+func NewDiffEnvGroupVersionsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DiffEnvGroupVersionsHandler {
+	return &DiffEnvGroupVersionsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// DiffEnvGroupVersionsRequest is the request object for the /environment-groups/{env_group_name}/versions/diff endpoint
+type DiffEnvGroupVersionsRequest struct {
+	// FromVersion is the version the changes are computed from. Defaults to the version before ToVersion.
+	FromVersion int `schema:"from"`
+	// ToVersion is the version the changes are computed to. Defaults to the latest version.
+	ToVersion int `schema:"to"`
+}
+
+// DiffEnvGroupVersionsResponse is the response object for the /environment-groups/{env_group_name}/versions/diff endpoint
+type DiffEnvGroupVersionsResponse struct {
+	FromVersion int `json:"from_version"`
+	ToVersion   int `json:"to_version"`
+	EnvGroupChanges
+}
+
+// ServeHTTP returns the keys added, changed and removed between two versions of an environment group
+func (c *DiffEnvGroupVersionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-diff-env-group-versions")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	envGroupName, reqErr := requestutils.GetURLParamString(r, types.URLParamEnvGroupName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing env group name from url")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &DiffEnvGroupVersionsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to connect to cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusServiceUnavailable))
+		return
+	}
+
+	toVersion := request.ToVersion
+	if toVersion == 0 {
+		latest, err := environmentgroups.LatestBaseEnvironmentGroup(ctx, agent, envGroupName)
+		if err != nil {
+			err = telemetry.Error(ctx, span, err, "unable to get latest env group version")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		toVersion = latest.Version
+	}
+
+	fromVersion := request.FromVersion
+	if fromVersion == 0 {
+		fromVersion = toVersion - 1
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "env-group-name", Value: envGroupName},
+		telemetry.AttributeKV{Key: "from-version", Value: fromVersion},
+		telemetry.AttributeKV{Key: "to-version", Value: toVersion},
+	)
+
+	d, err := environmentgroups.DiffEnvironmentGroupVersions(ctx, agent, environmentgroups.DiffEnvironmentGroupVersionsInput{
+		Name:        envGroupName,
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+	})
+	if err != nil {
+		if errors.Is(err, environmentgroups.ErrEnvironmentGroupVersionNotFound) {
+			err = telemetry.Error(ctx, span, err, "env group version does not exist")
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, err, "unable to diff env group versions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, &DiffEnvGroupVersionsResponse{
+		FromVersion:     fromVersion,
+		ToVersion:       toVersion,
+		EnvGroupChanges: toEnvGroupChanges(d),
+	})
+}
+
+// RollbackEnvGroupHandler is the handler for the /environment-groups/{env_group_name}/rollback endpoint
+type RollbackEnvGroupHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewRollbackEnvGroupHandler creates an instance of RollbackEnvGroupHandler
+func NewRollbackEnvGroupHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RollbackEnvGroupHandler {
+	return &RollbackEnvGroupHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// RollbackEnvGroupRequest is the request object for the /environment-groups/{env_group_name}/rollback endpoint
+type RollbackEnvGroupRequest struct {
+	// Version is the version whose variables are restored
+	Version int `json:"version" form:"required,min=1"`
+	// SkipRedeploys skips re-deploying the apps linked to the environment group
+	SkipRedeploys bool `json:"skip_redeploys"`
+}
+
+// RollbackEnvGroupResponse is the response object for the /environment-groups/{env_group_name}/rollback endpoint
+type RollbackEnvGroupResponse struct {
+	// Version is the latest version of the environment group after the rollback
+	Version int `json:"version"`
+	// RolledBackTo is the version whose variables were restored
+	RolledBackTo int `json:"rolled_back_to"`
+	// Changed is whether the rollback created a new version
+	Changed bool `json:"changed"`
+	EnvGroupChanges
+	// LinkedApplications are the apps linked to the environment group
+	LinkedApplications []string `json:"linked_applications"`
+	// Redeployed is whether the linked apps were re-deployed with the restored variables
+	Redeployed bool `json:"redeployed"`
+}
+
+// ServeHTTP rolls an environment group back to a previous version, and re-deploys the apps linked to the environment group
+func (c *RollbackEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-rollback-env-group")
+	defer span.End()
+
+	user, _ := ctx.Value(types.UserScope).(*models.User)
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	envGroupName, reqErr := requestutils.GetURLParamString(r, types.URLParamEnvGroupName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing env group name from url")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &RollbackEnvGroupRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "env-group-name", Value: envGroupName},
+		telemetry.AttributeKV{Key: "target-version", Value: request.Version},
+		telemetry.AttributeKV{Key: "skip-redeploys", Value: request.SkipRedeploys},
+	)
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to connect to cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusServiceUnavailable))
+		return
+	}
+
+	var createdBy string
+	if user != nil {
+		createdBy = user.Email
+	}
+
+	out, err := environmentgroups.RollbackEnvironmentGroup(ctx, agent, environmentgroups.RollbackEnvironmentGroupInput{
+		Name:      envGroupName,
+		Version:   request.Version,
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		if errors.Is(err, environmentgroups.ErrEnvironmentGroupVersionNotFound) {
+			err = telemetry.Error(ctx, span, err, "env group version does not exist")
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, err, "unable to roll back env group")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := &RollbackEnvGroupResponse{
+		Version:            out.Version,
+		RolledBackTo:       request.Version,
+		Changed:            out.Changed,
+		EnvGroupChanges:    toEnvGroupChanges(out.Diff),
+		LinkedApplications: []string{},
+	}
+
+	applications, err := environmentgroups.LinkedApplications(ctx, agent, envGroupName, false)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to get linked applications")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	for _, app := range applications {
+		res.LinkedApplications = append(res.LinkedApplications, app.Name)
+	}
+	sort.Strings(res.LinkedApplications)
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "changed", Value: out.Changed},
+		telemetry.AttributeKV{Key: "linked-applications", Value: len(res.LinkedApplications)},
+	)
+
+	// only apps deployed with validate apply v2 can be re-deployed with the latest version of their env groups
+	if !out.Changed || request.SkipRedeploys || len(res.LinkedApplications) == 0 || !project.GetFeatureFlag(models.ValidateApplyV2, c.Config().LaunchDarklyClient) {
+		c.WriteResult(w, r, res)
+		return
+	}
+
+	_, err = c.Config().ClusterControlPlaneClient.UpdateAppsLinkedToEnvGroup(ctx, connect.NewRequest(&porterv1.UpdateAppsLinkedToEnvGroupRequest{
+		ProjectId:    int64(project.ID),
+		ClusterId:    int64(cluster.ID),
+		EnvGroupName: envGroupName,
+	}))
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error calling ccp update apps linked to env group")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res.Redeployed = true
+
+	c.WriteResult(w, r, res)
+}
+
+// toEnvGroupChanges converts a redacted diff of environment group variables to the changes returned to the user
+func toEnvGroupChanges(d diff.Diff) EnvGroupChanges {
+	convert := func(changes []diff.Change) []EnvGroupVariableChange {
+		res := make([]EnvGroupVariableChange, 0, len(changes))
+		for _, c := range changes {
+			res = append(res, EnvGroupVariableChange{
+				Key:       c.Key,
+				Secret:    c.Secret,
+				WasSecret: c.WasSecret,
+				Old:       c.Old,
+				New:       c.New,
+			})
+		}
+		return res
+	}
+
+	return EnvGroupChanges{
+		Added:   convert(d.Added),
+		Changed: convert(d.Changed),
+		Removed: convert(d.Removed),
+	}
+}
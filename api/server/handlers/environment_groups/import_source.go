@@ -0,0 +1,321 @@
+package environment_groups
+
+import (
+	"errors"
+	"net/http"
+
+	"connectrpc.com/connect"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups/importer"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/netguard"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// GetImportSourceHandler is the handler for the GET /environment-groups/{env_group_name}/import-source endpoint
+type GetImportSourceHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewGetImportSourceHandler creates an instance of GetImportSourceHandler
+func NewGetImportSourceHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetImportSourceHandler {
+	return &GetImportSourceHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP returns the import source of an environment group
+func (c *GetImportSourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-env-group-import-source")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	envGroupName, reqErr := requestutils.GetURLParamString(r, types.URLParamEnvGroupName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing env group name from url")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "env-group-name", Value: envGroupName})
+
+	source, err := c.Repo().EnvGroupImportSource().ReadEnvGroupImportSource(ctx, cluster.ProjectID, cluster.ID, envGroupName)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = telemetry.Error(ctx, span, err, "env group has no import source")
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, err, "error reading import source")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, source.ToEnvGroupImportSourceType())
+}
+
+// UpdateImportSourceHandler is the handler for the POST /environment-groups/{env_group_name}/import-source endpoint
+type UpdateImportSourceHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewUpdateImportSourceHandler creates an instance of UpdateImportSourceHandler
+func NewUpdateImportSourceHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateImportSourceHandler {
+	return &UpdateImportSourceHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP creates or updates the import source of an environment group. Import sources can only be set once external
+// env group providers are enabled on the cluster, see EnableExternalProvidersHandler.
+func (c *UpdateImportSourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-update-env-group-import-source")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	envGroupName, reqErr := requestutils.GetURLParamString(r, types.URLParamEnvGroupName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing env group name from url")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &types.UpdateEnvGroupImportSourceRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "env-group-name", Value: envGroupName},
+		telemetry.AttributeKV{Key: "provider", Value: string(request.Provider)},
+		telemetry.AttributeKV{Key: "as-secrets", Value: request.AsSecrets},
+		telemetry.AttributeKV{Key: "prune", Value: request.Prune},
+		telemetry.AttributeKV{Key: "sync-interval-minutes", Value: request.SyncIntervalMinutes},
+	)
+
+	enabledResp, err := c.Config().ClusterControlPlaneClient.AreExternalEnvGroupProvidersEnabled(ctx, connect.NewRequest(&porterv1.AreExternalEnvGroupProvidersEnabledRequest{
+		ProjectId: int64(project.ID),
+		ClusterId: int64(cluster.ID),
+	}))
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "unable to check if external providers are enabled")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	if enabledResp.Msg == nil || !enabledResp.Msg.Enabled {
+		err := telemetry.Error(ctx, span, nil, "external env group providers must be enabled on the cluster before adding an import source")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	existing, err := c.Repo().EnvGroupImportSource().ReadEnvGroupImportSource(ctx, project.ID, cluster.ID, envGroupName)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		err = telemetry.Error(ctx, span, err, "error reading import source")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	source := &models.EnvGroupImportSource{
+		ProjectID:           project.ID,
+		ClusterID:           cluster.ID,
+		EnvGroupName:        envGroupName,
+		Provider:            request.Provider,
+		Path:                request.Path,
+		AsSecrets:           request.AsSecrets,
+		Prune:               request.Prune,
+		SkipRedeploys:       request.SkipRedeploys,
+		SyncIntervalMinutes: request.SyncIntervalMinutes,
+	}
+
+	switch request.Provider {
+	case types.EnvGroupImportProvider_AWSSecretsManager:
+		if _, err := c.Repo().AWSIntegration().ReadAWSIntegration(project.ID, request.AWSIntegrationID); err != nil {
+			err = telemetry.Error(ctx, span, err, "aws_integration_id must be an aws integration of the project")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		source.AWSIntegrationID = request.AWSIntegrationID
+	case types.EnvGroupImportProvider_GCPSecretManager:
+		if _, err := c.Repo().GCPIntegration().ReadGCPIntegration(project.ID, request.GCPIntegrationID); err != nil {
+			err = telemetry.Error(ctx, span, err, "gcp_integration_id must be a gcp integration of the project")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		source.GCPIntegrationID = request.GCPIntegrationID
+	case types.EnvGroupImportProvider_Vault:
+		if request.VaultAddress == "" {
+			err := telemetry.Error(ctx, span, nil, "vault_address is required for the vault provider")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		if err := netguard.ValidateURL(ctx, request.VaultAddress); err != nil {
+			err = telemetry.Error(ctx, span, err, "vault_address must be a public http or https address")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		// the existing token is only kept by the repository when it is sent to the same Vault server, so that it
+		// cannot be sent to another host by changing the address
+		hasToken := existing != nil &&
+			existing.Provider == types.EnvGroupImportProvider_Vault &&
+			existing.VaultAddress == request.VaultAddress &&
+			len(existing.VaultToken) > 0
+		if request.VaultToken == "" && !hasToken {
+			err := telemetry.Error(ctx, span, nil, "vault_token is required for the vault provider")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		source.VaultAddress = request.VaultAddress
+		source.VaultToken = []byte(request.VaultToken)
+	}
+
+	source, err = c.Repo().EnvGroupImportSource().UpdateEnvGroupImportSource(ctx, source)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error updating import source")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, source.ToEnvGroupImportSourceType())
+}
+
+// DeleteImportSourceHandler is the handler for the DELETE /environment-groups/{env_group_name}/import-source endpoint
+type DeleteImportSourceHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewDeleteImportSourceHandler creates an instance of DeleteImportSourceHandler
+func NewDeleteImportSourceHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *DeleteImportSourceHandler {
+	return &DeleteImportSourceHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP deletes the import source of an environment group. The variables which were imported are kept.
+func (c *DeleteImportSourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-delete-env-group-import-source")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	envGroupName, reqErr := requestutils.GetURLParamString(r, types.URLParamEnvGroupName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing env group name from url")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "env-group-name", Value: envGroupName})
+
+	source, err := c.Repo().EnvGroupImportSource().ReadEnvGroupImportSource(ctx, cluster.ProjectID, cluster.ID, envGroupName)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = telemetry.Error(ctx, span, err, "env group has no import source")
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, err, "error reading import source")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := c.Repo().EnvGroupImportSource().DeleteEnvGroupImportSource(ctx, source); err != nil {
+		err = telemetry.Error(ctx, span, err, "error deleting import source")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// SyncImportSourceHandler is the handler for the POST /environment-groups/{env_group_name}/import-source/sync endpoint
+type SyncImportSourceHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewSyncImportSourceHandler creates an instance of SyncImportSourceHandler
+func NewSyncImportSourceHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *SyncImportSourceHandler {
+	return &SyncImportSourceHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP syncs an environment group with its import source on demand
+func (c *SyncImportSourceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-sync-env-group-import-source")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	envGroupName, reqErr := requestutils.GetURLParamString(r, types.URLParamEnvGroupName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing env group name from url")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "env-group-name", Value: envGroupName})
+
+	source, err := c.Repo().EnvGroupImportSource().ReadEnvGroupImportSource(ctx, cluster.ProjectID, cluster.ID, envGroupName)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = telemetry.Error(ctx, span, err, "env group has no import source")
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, err, "error reading import source")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to connect to cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	result, err := importer.Sync(ctx, c.Config(), agent, source)
+	if err != nil {
+		// the error may contain the response of the secret manager, which is not passed to the client
+		err = telemetry.Error(ctx, span, err, "error syncing import source")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, result)
+}
@@ -1,11 +1,13 @@
 package helmrepo
 
 import (
+	"context"
 	"net/http"
 
 	"k8s.io/helm/pkg/repo"
 
 	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
@@ -32,6 +34,18 @@ func (t *ChartListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 	helmRepo, _ := r.Context().Value(types.HelmRepoScope).(*models.HelmRepo)
 
+	// OCI repos do not have an index file, so their charts are listed from the registry of the repo
+	if loader.IsOCIRepoURL(helmRepo.RepoURL) {
+		charts, err := listOCICharts(r.Context(), t.Config(), helmRepo)
+		if err != nil {
+			t.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		t.WriteResult(w, r, charts)
+		return
+	}
+
 	var repoIndex *repo.IndexFile
 	var err error
 
@@ -60,3 +74,17 @@ func (t *ChartListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	t.WriteResult(w, r, charts)
 }
+
+func listOCICharts(ctx context.Context, config *config.Config, helmRepo *models.HelmRepo) (types.ListTemplatesResponse, error) {
+	chartNames, err := release.ListOCIChartNames(ctx, config, helmRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	ociClient, err := release.GetOCIClient(ctx, config, helmRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	return loader.LoadOCIRepoCharts(ociClient, helmRepo.RepoURL, chartNames)
+}
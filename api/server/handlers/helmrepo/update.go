@@ -11,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/loader"
 	"github.com/porter-dev/porter/internal/models"
 	"gorm.io/gorm"
 )
@@ -58,6 +59,15 @@ func (p *HelmRepoUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if loader.IsOCIRepoURL(request.URL) && loader.OCIRepoHost(request.URL) == "" {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("invalid oci repo url %s: the url must include the host of the registry", request.URL),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
 	if request.BasicIntegrationID != 0 &&
 		helmRepo.BasicAuthIntegrationID != 0 &&
 		request.BasicIntegrationID != helmRepo.BasicAuthIntegrationID {
@@ -0,0 +1,202 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// GetResourceGraphHandler exports the graph of the objects of the releases in a namespace
+type GetResourceGraphHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewGetResourceGraphHandler returns a new GetResourceGraphHandler
+func NewGetResourceGraphHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GetResourceGraphHandler {
+	return &GetResourceGraphHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *GetResourceGraphHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-resource-graph")
+	defer span.End()
+
+	namespace := ctx.Value(types.NamespaceScope).(string)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.GetResourceGraphRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.Format == "" {
+		request.Format = types.ResourceGraphFormatJSON
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "namespace", Value: namespace},
+		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
+		telemetry.AttributeKV{Key: "format", Value: string(request.Format)},
+		telemetry.AttributeKV{Key: "release", Value: request.Release},
+	)
+
+	if request.Format != types.ResourceGraphFormatJSON && request.Format != types.ResourceGraphFormatDOT {
+		err := telemetry.Error(ctx, span, nil, fmt.Sprintf("invalid format %q: must be one of json, dot", request.Format))
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(ctx, r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "failed to get helm agent")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	graph, releaseFound, err := getNamespaceResourceGraph(ctx, helmAgent, namespace, request.Release)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "failed to get resource graph")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if request.Release != "" {
+		if !releaseFound {
+			err := telemetry.Error(ctx, span, nil, fmt.Sprintf("release %s not found in namespace %s", request.Release, namespace))
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
+
+		graph = graph.FilterRelease(request.Release)
+	}
+
+	res := &types.GetResourceGraphResponse{}
+
+	if request.Format == types.ResourceGraphFormatDOT {
+		res.DOT = graph.DOT()
+	} else {
+		res.Graph = graph
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// GetResourceImpactHandler finds the objects in a namespace affected by a change to an object, across releases
+type GetResourceImpactHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewGetResourceImpactHandler returns a new GetResourceImpactHandler
+func NewGetResourceImpactHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GetResourceImpactHandler {
+	return &GetResourceImpactHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *GetResourceImpactHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-resource-impact")
+	defer span.End()
+
+	namespace := ctx.Value(types.NamespaceScope).(string)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.GetResourceImpactRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "namespace", Value: namespace},
+		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
+		telemetry.AttributeKV{Key: "kind", Value: request.Kind},
+		telemetry.AttributeKV{Key: "name", Value: request.Name},
+	)
+
+	helmAgent, err := c.GetHelmAgent(ctx, r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "failed to get helm agent")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	graph, _, err := getNamespaceResourceGraph(ctx, helmAgent, namespace, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "failed to get resource graph")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	impacted, err := graph.Impact(request.Kind, request.Name)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "object is neither part of a release in the namespace nor referenced by one")
+		c.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+		return
+	}
+
+	c.WriteResult(w, r, &types.GetResourceImpactResponse{
+		Impacted: impacted,
+	})
+}
+
+// getNamespaceResourceGraph parses the manifests of the deployed releases in a namespace into a single graph, and
+// returns whether the given release is one of them
+func getNamespaceResourceGraph(
+	ctx context.Context,
+	helmAgent *helm.Agent,
+	namespace, release string,
+) (*grapher.Graph, bool, error) {
+	releases, err := helmAgent.ListReleases(ctx, namespace, &types.ReleaseListFilter{
+		StatusFilter: []string{"deployed", "failed", "pending-install", "pending-upgrade", "pending-rollback"},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// releases are sorted so that the IDs of the objects of the graph are stable
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Name < releases[j].Name
+	})
+
+	manifests := make([]grapher.ReleaseManifest, 0, len(releases))
+	releaseFound := false
+
+	for _, rel := range releases {
+		manifests = append(manifests, grapher.ReleaseManifest{
+			Name:     rel.Name,
+			Manifest: rel.Manifest,
+		})
+
+		if rel.Name == release {
+			releaseFound = true
+		}
+	}
+
+	return grapher.ParseReleases(manifests, namespace), releaseFound, nil
+}
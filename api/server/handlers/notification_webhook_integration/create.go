@@ -0,0 +1,70 @@
+package notification_webhook_integration
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+type NotificationWebhookIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewNotificationWebhookIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *NotificationWebhookIntegrationCreateHandler {
+	return &NotificationWebhookIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *NotificationWebhookIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateNotificationWebhookIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// webhooks are sent by the server, so they must not target its internal network
+	if err := netguard.ValidateURL(r.Context(), request.URL); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("url must be a public http or https address: %w", err),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	webhookInt := &ints.NotificationWebhookIntegration{
+		UserID:    user.ID,
+		ProjectID: project.ID,
+		Name:      request.Name,
+		Kind:      request.Kind,
+		Webhook:   []byte(request.URL),
+	}
+
+	// Teams incoming webhooks do not verify signatures
+	if request.Kind == types.NotificationWebhookKind_Generic && request.SigningSecret != "" {
+		webhookInt.SigningSecret = []byte(request.SigningSecret)
+	}
+
+	webhookInt, err := p.Repo().NotificationWebhookIntegration().CreateNotificationWebhookIntegration(webhookInt)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, webhookInt.ToNotificationWebhookIntegrationType())
+}
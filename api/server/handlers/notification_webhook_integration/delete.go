@@ -0,0 +1,49 @@
+package notification_webhook_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type NotificationWebhookIntegrationDelete struct {
+	handlers.PorterHandler
+}
+
+func NewNotificationWebhookIntegrationDelete(
+	config *config.Config,
+) *NotificationWebhookIntegrationDelete {
+	return &NotificationWebhookIntegrationDelete{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (p *NotificationWebhookIntegrationDelete) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	integrationID, _ := requestutils.GetURLParamUint(r, types.URLParamNotificationWebhookIntegrationID)
+
+	webhookInts, err := p.Repo().NotificationWebhookIntegration().ListNotificationWebhookIntegrationsByProjectID(project.ID)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, webhookInt := range webhookInts {
+		if webhookInt.ID == integrationID {
+			err = p.Repo().NotificationWebhookIntegration().DeleteNotificationWebhookIntegration(webhookInt.ID)
+			if err != nil {
+				p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+}
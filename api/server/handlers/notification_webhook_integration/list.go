@@ -0,0 +1,43 @@
+package notification_webhook_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type NotificationWebhookIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewNotificationWebhookIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *NotificationWebhookIntegrationListHandler {
+	return &NotificationWebhookIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *NotificationWebhookIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	webhookInts, err := p.Repo().NotificationWebhookIntegration().ListNotificationWebhookIntegrationsByProjectID(project.ID)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListNotificationWebhookIntegrationsResponse, 0)
+
+	for _, webhookInt := range webhookInts {
+		res = append(res, webhookInt.ToNotificationWebhookIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
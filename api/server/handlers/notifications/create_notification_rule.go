@@ -0,0 +1,80 @@
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier/routing"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// CreateNotificationRuleHandler is the handler for the POST /notifications/rules endpoint
+type CreateNotificationRuleHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewCreateNotificationRuleHandler returns a new CreateNotificationRuleHandler
+func NewCreateNotificationRuleHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateNotificationRuleHandler {
+	return &CreateNotificationRuleHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP creates a notification rule
+func (n *CreateNotificationRuleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-create-notification-rule")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+
+	request := &types.CreateNotificationRuleRequest{}
+	if ok := n.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	rule := &models.NotificationRule{
+		ProjectID: project.ID,
+	}
+
+	slackInts, err := n.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(project.ID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing slack integrations")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	webhookInts, err := n.Repo().NotificationWebhookIntegration().ListNotificationWebhookIntegrationsByProjectID(project.ID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing notification webhook integrations")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	if err := routing.ApplyRequest(rule, request, slackInts, webhookInts); err != nil {
+		err := telemetry.Error(ctx, span, err, "invalid notification rule")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	rule, err = n.Repo().NotificationRule().CreateNotificationRule(ctx, rule)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error creating notification rule")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	n.WriteResult(w, r, rule.ToNotificationRuleType())
+}
@@ -0,0 +1,70 @@
+package notifications
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// DeleteNotificationRuleHandler is the handler for the DELETE /notifications/rules/{notification_rule_id} endpoint
+type DeleteNotificationRuleHandler struct {
+	handlers.PorterHandler
+}
+
+// NewDeleteNotificationRuleHandler returns a new DeleteNotificationRuleHandler
+func NewDeleteNotificationRuleHandler(
+	config *config.Config,
+) *DeleteNotificationRuleHandler {
+	return &DeleteNotificationRuleHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+// ServeHTTP deletes a notification rule
+func (n *DeleteNotificationRuleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-delete-notification-rule")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	ruleID, reqErr := requestutils.GetURLParamUint(r, types.URLParamNotificationRuleID)
+	if reqErr != nil {
+		e := telemetry.Error(ctx, span, nil, "error parsing notification rule id from url")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(e, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "notification-rule-id", Value: ruleID},
+	)
+
+	rule, err := n.Repo().NotificationRule().ReadNotificationRule(ctx, project.ID, ruleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err := telemetry.Error(ctx, span, err, "notification rule not found")
+			n.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
+
+		err := telemetry.Error(ctx, span, err, "error reading notification rule")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	if err := n.Repo().NotificationRule().DeleteNotificationRule(ctx, rule); err != nil {
+		err := telemetry.Error(ctx, span, err, "error deleting notification rule")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,53 @@
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ListNotificationRulesHandler is the handler for the GET /notifications/rules endpoint
+type ListNotificationRulesHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewListNotificationRulesHandler returns a new ListNotificationRulesHandler
+func NewListNotificationRulesHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListNotificationRulesHandler {
+	return &ListNotificationRulesHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP returns the notification rules of a project in the order in which they are evaluated
+func (n *ListNotificationRulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-notification-rules")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+
+	rules, err := n.Repo().NotificationRule().ListNotificationRulesByProjectID(ctx, project.ID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing notification rules")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := make(types.ListNotificationRulesResponse, 0, len(rules))
+
+	for _, rule := range rules {
+		res = append(res, rule.ToNotificationRuleType())
+	}
+
+	n.WriteResult(w, r, res)
+}
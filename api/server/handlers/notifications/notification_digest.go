@@ -0,0 +1,99 @@
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// GetNotificationDigestHandler is the handler for the GET /notifications/digest endpoint
+type GetNotificationDigestHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewGetNotificationDigestHandler returns a new GetNotificationDigestHandler
+func NewGetNotificationDigestHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetNotificationDigestHandler {
+	return &GetNotificationDigestHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP returns whether a project receives the daily notification digest
+func (n *GetNotificationDigestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-notification-digest")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+
+	setting, err := n.Repo().NotificationAggregate().ReadNotificationDigestSetting(ctx, project.ID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading notification digest setting")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	n.WriteResult(w, r, &types.NotificationDigestSetting{
+		Enabled: setting.Enabled,
+	})
+}
+
+// UpdateNotificationDigestHandler is the handler for the POST /notifications/digest endpoint
+type UpdateNotificationDigestHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewUpdateNotificationDigestHandler returns a new UpdateNotificationDigestHandler
+func NewUpdateNotificationDigestHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateNotificationDigestHandler {
+	return &UpdateNotificationDigestHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP opts a project in to or out of the daily notification digest
+func (n *UpdateNotificationDigestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-update-notification-digest")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	request := &types.NotificationDigestSetting{}
+	if ok := n.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "enabled", Value: request.Enabled},
+	)
+
+	setting, err := n.Repo().NotificationAggregate().UpdateNotificationDigestSetting(ctx, &models.NotificationDigestSetting{
+		ProjectID: project.ID,
+		Enabled:   request.Enabled,
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error updating notification digest setting")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	n.WriteResult(w, r, &types.NotificationDigestSetting{
+		Enabled: setting.Enabled,
+	})
+}
@@ -0,0 +1,134 @@
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier/dedup"
+	"github.com/porter-dev/porter/internal/notifier/routing"
+	"github.com/porter-dev/porter/internal/porter_app/notifications"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// RouteNotificationHandler is the handler for the POST /notifications/rules/route endpoint
+type RouteNotificationHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewRouteNotificationHandler returns a new RouteNotificationHandler
+func NewRouteNotificationHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RouteNotificationHandler {
+	return &RouteNotificationHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// RouteNotificationRequest is the request object for the /notifications/rules/route endpoint
+type RouteNotificationRequest struct {
+	// AppName is the name of the app the notification belongs to
+	AppName string `json:"app_name" form:"required"`
+	// DeploymentTargetID is the ID of the deployment target the app is deployed to
+	DeploymentTargetID string `json:"deployment_target_id"`
+	// Notification is the app notification to route
+	Notification notifications.Notification `json:"notification"`
+}
+
+// RouteNotificationResponse is the response object for the /notifications/rules/route endpoint
+type RouteNotificationResponse struct {
+	// Deliver is false if the notification should be dropped
+	Deliver bool `json:"deliver"`
+	// Reason explains why the notification should be dropped
+	Reason string `json:"reason,omitempty"`
+	// Rule is the rule which matched the notification. If no rule matched, the notification should be
+	// delivered to every channel of the project
+	Rule *types.NotificationRule `json:"rule,omitempty"`
+	// Occurrences is the number of times the notification occurred since it was first delivered, if it
+	// repeats within the dedup window
+	Occurrences uint `json:"occurrences,omitempty"`
+}
+
+// ServeHTTP evaluates the notification rules of a project for an app notification. It is called for each
+// notification of the app notification stream before it is delivered, and counts delivered notifications
+// towards the throttle window of the matched rule. Repeats of a notification within the dedup window are
+// dropped here and reported in a single message by the notification-aggregates-flusher job.
+func (n *RouteNotificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-route-notification")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	request := &RouteNotificationRequest{}
+	if ok := n.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "app-name", Value: request.AppName},
+		telemetry.AttributeKV{Key: "notification-id", Value: request.Notification.ID.String()},
+	)
+
+	aggregate, first, err := dedup.NewAggregator(n.Repo().NotificationAggregate(), n.Config().ServerConf.NotificationDedupWindow).Record(ctx, dedup.Occurrence{
+		ProjectID:          project.ID,
+		AppName:            request.AppName,
+		DeploymentTargetID: request.DeploymentTargetID,
+		AppRevisionID:      request.Notification.AppRevisionID,
+		ServiceName:        request.Notification.Metadata.ServiceName,
+		ErrorCode:          int(request.Notification.Error.Code),
+		Scope:              string(request.Notification.Scope),
+		Summary:            request.Notification.Error.Summary,
+		Timestamp:          request.Notification.Timestamp,
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error recording notification occurrence")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	if !first {
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "occurrences", Value: aggregate.Occurrences})
+
+		n.WriteResult(w, r, &RouteNotificationResponse{
+			Reason:      dedup.ReasonCollapsed,
+			Occurrences: aggregate.Occurrences,
+		})
+		return
+	}
+
+	event := routing.Event{
+		ProjectID:          project.ID,
+		AppName:            request.AppName,
+		DeploymentTargetID: request.DeploymentTargetID,
+		ServiceName:        request.Notification.Metadata.ServiceName,
+		ErrorCode:          int(request.Notification.Error.Code),
+		Scope:              types.NotificationRuleScope(request.Notification.Scope),
+	}
+
+	decision, err := routing.NewRouter(n.Repo().NotificationRule()).Route(ctx, event)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error routing notification")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := &RouteNotificationResponse{
+		Deliver: decision.Deliver,
+		Reason:  decision.Reason,
+	}
+
+	if decision.Rule != nil {
+		res.Rule = decision.Rule.ToNotificationRuleType()
+	}
+
+	n.WriteResult(w, r, res)
+}
@@ -0,0 +1,102 @@
+package notifications
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier/routing"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// UpdateNotificationRuleHandler is the handler for the POST /notifications/rules/{notification_rule_id} endpoint
+type UpdateNotificationRuleHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewUpdateNotificationRuleHandler returns a new UpdateNotificationRuleHandler
+func NewUpdateNotificationRuleHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateNotificationRuleHandler {
+	return &UpdateNotificationRuleHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP replaces all fields of a notification rule
+func (n *UpdateNotificationRuleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-update-notification-rule")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	ruleID, reqErr := requestutils.GetURLParamUint(r, types.URLParamNotificationRuleID)
+	if reqErr != nil {
+		e := telemetry.Error(ctx, span, nil, "error parsing notification rule id from url")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(e, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "notification-rule-id", Value: ruleID},
+	)
+
+	request := &types.UpdateNotificationRuleRequest{}
+	if ok := n.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	rule, err := n.Repo().NotificationRule().ReadNotificationRule(ctx, project.ID, ruleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err := telemetry.Error(ctx, span, err, "notification rule not found")
+			n.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
+
+		err := telemetry.Error(ctx, span, err, "error reading notification rule")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	slackInts, err := n.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(project.ID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing slack integrations")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	webhookInts, err := n.Repo().NotificationWebhookIntegration().ListNotificationWebhookIntegrationsByProjectID(project.ID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing notification webhook integrations")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	if err := routing.ApplyRequest(rule, (*types.CreateNotificationRuleRequest)(request), slackInts, webhookInts); err != nil {
+		err := telemetry.Error(ctx, span, err, "invalid notification rule")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	rule, err = n.Repo().NotificationRule().UpdateNotificationRule(ctx, rule)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error updating notification rule")
+		n.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	n.WriteResult(w, r, rule.ToNotificationRuleType())
+}
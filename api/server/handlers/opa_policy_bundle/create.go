@@ -0,0 +1,90 @@
+package opa_policy_bundle
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/opa"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// CreateOPAPolicyBundleHandler is the handler for the POST /opa-policy-bundles endpoint
+type CreateOPAPolicyBundleHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewCreateOPAPolicyBundleHandler returns a new CreateOPAPolicyBundleHandler
+func NewCreateOPAPolicyBundleHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateOPAPolicyBundleHandler {
+	return &CreateOPAPolicyBundleHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP compiles a policy bundle and stores it as the next version of the bundle with its name
+func (c *CreateOPAPolicyBundleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-create-opa-policy-bundle")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+
+	request := &types.CreateOPAPolicyBundleRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "bundle-name", Value: request.Name})
+
+	// bundles are compiled on upload so that invalid policies are never evaluated by the recommender
+	policies, err := opa.LoadPolicyBundle(request.Name, []byte(request.Config), request.Policies)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "invalid policy bundle")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	collections := make([]string, 0, len(policies.Policies))
+	for name := range policies.Policies {
+		collections = append(collections, name)
+	}
+	sort.Strings(collections)
+
+	policiesBytes, err := json.Marshal(request.Policies)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error marshaling policies")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	bundle, err := c.Repo().OPAPolicyBundle().CreateOPAPolicyBundle(ctx, &models.OPAPolicyBundle{
+		ProjectID:   project.ID,
+		Name:        request.Name,
+		Config:      []byte(request.Config),
+		Policies:    policiesBytes,
+		Collections: strings.Join(collections, ","),
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error creating policy bundle")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "bundle-version", Value: bundle.Version})
+
+	c.WriteResult(w, r, bundle.ToOPAPolicyBundleType(false))
+}
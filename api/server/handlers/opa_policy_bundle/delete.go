@@ -0,0 +1,58 @@
+package opa_policy_bundle
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// DeleteOPAPolicyBundleHandler is the handler for the DELETE /opa-policy-bundles/{opa_policy_bundle_name} endpoint
+type DeleteOPAPolicyBundleHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewDeleteOPAPolicyBundleHandler returns a new DeleteOPAPolicyBundleHandler
+func NewDeleteOPAPolicyBundleHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *DeleteOPAPolicyBundleHandler {
+	return &DeleteOPAPolicyBundleHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP deletes all versions of a policy bundle. Results of the bundle's policies are archived by the next run of
+// the recommender.
+func (c *DeleteOPAPolicyBundleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-delete-opa-policy-bundle")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	name, reqErr := requestutils.GetURLParamString(r, types.URLParamOPAPolicyBundleName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, nil, "error parsing policy bundle name from url")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "bundle-name", Value: name},
+	)
+
+	if err := c.Repo().OPAPolicyBundle().DeleteOPAPolicyBundle(ctx, project.ID, name); err != nil {
+		err := telemetry.Error(ctx, span, err, "error deleting policy bundle")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
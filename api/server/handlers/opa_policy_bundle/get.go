@@ -0,0 +1,75 @@
+package opa_policy_bundle
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// GetOPAPolicyBundleHandler is the handler for the GET /opa-policy-bundles/{opa_policy_bundle_name} endpoint
+type GetOPAPolicyBundleHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewGetOPAPolicyBundleHandler returns a new GetOPAPolicyBundleHandler
+func NewGetOPAPolicyBundleHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GetOPAPolicyBundleHandler {
+	return &GetOPAPolicyBundleHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP returns a version of a policy bundle along with its config and policies
+func (c *GetOPAPolicyBundleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-opa-policy-bundle")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	name, reqErr := requestutils.GetURLParamString(r, types.URLParamOPAPolicyBundleName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, nil, "error parsing policy bundle name from url")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &types.GetOPAPolicyBundleRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "bundle-name", Value: name},
+		telemetry.AttributeKV{Key: "bundle-version", Value: request.Version},
+	)
+
+	bundle, err := c.Repo().OPAPolicyBundle().ReadOPAPolicyBundle(ctx, project.ID, name, request.Version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err := telemetry.Error(ctx, span, err, "policy bundle not found")
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
+
+		err := telemetry.Error(ctx, span, err, "error reading policy bundle")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, bundle.ToOPAPolicyBundleType(true))
+}
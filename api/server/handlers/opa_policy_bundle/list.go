@@ -0,0 +1,53 @@
+package opa_policy_bundle
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ListOPAPolicyBundlesHandler is the handler for the GET /opa-policy-bundles endpoint
+type ListOPAPolicyBundlesHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewListOPAPolicyBundlesHandler returns a new ListOPAPolicyBundlesHandler
+func NewListOPAPolicyBundlesHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListOPAPolicyBundlesHandler {
+	return &ListOPAPolicyBundlesHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP returns the latest version of each policy bundle of a project
+func (c *ListOPAPolicyBundlesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-opa-policy-bundles")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+
+	bundles, err := c.Repo().OPAPolicyBundle().ListLatestOPAPolicyBundlesByProjectID(ctx, project.ID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing policy bundles")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := make(types.ListOPAPolicyBundlesResponse, 0, len(bundles))
+
+	for _, bundle := range bundles {
+		res = append(res, bundle.ToOPAPolicyBundleType(false))
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,64 @@
+package opa_policy_bundle
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ListOPAPolicyBundleVersionsHandler is the handler for the GET /opa-policy-bundles/{opa_policy_bundle_name}/versions endpoint
+type ListOPAPolicyBundleVersionsHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewListOPAPolicyBundleVersionsHandler returns a new ListOPAPolicyBundleVersionsHandler
+func NewListOPAPolicyBundleVersionsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListOPAPolicyBundleVersionsHandler {
+	return &ListOPAPolicyBundleVersionsHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP returns the versions of a policy bundle, latest first
+func (c *ListOPAPolicyBundleVersionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-opa-policy-bundle-versions")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	name, reqErr := requestutils.GetURLParamString(r, types.URLParamOPAPolicyBundleName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, nil, "error parsing policy bundle name from url")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "bundle-name", Value: name},
+	)
+
+	bundles, err := c.Repo().OPAPolicyBundle().ListOPAPolicyBundleVersions(ctx, project.ID, name)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing policy bundle versions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := make(types.ListOPAPolicyBundleVersionsResponse, 0, len(bundles))
+
+	for _, bundle := range bundles {
+		res = append(res, bundle.ToOPAPolicyBundleType(false))
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,122 @@
+package opa_policy_bundle
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ListOPAPolicyOverridesHandler is the handler for the GET /opa-policy-overrides endpoint
+type ListOPAPolicyOverridesHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewListOPAPolicyOverridesHandler returns a new ListOPAPolicyOverridesHandler
+func NewListOPAPolicyOverridesHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListOPAPolicyOverridesHandler {
+	return &ListOPAPolicyOverridesHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP returns the policy collection overrides of a project
+func (c *ListOPAPolicyOverridesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-opa-policy-overrides")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+
+	overrides, err := c.Repo().OPAPolicyBundle().ListOPAPolicyOverridesByProjectID(ctx, project.ID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing policy overrides")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, toOPAPolicyOverridesResponse(overrides))
+}
+
+// UpdateOPAPolicyOverridesHandler is the handler for the PUT /opa-policy-overrides endpoint
+type UpdateOPAPolicyOverridesHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewUpdateOPAPolicyOverridesHandler returns a new UpdateOPAPolicyOverridesHandler
+func NewUpdateOPAPolicyOverridesHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateOPAPolicyOverridesHandler {
+	return &UpdateOPAPolicyOverridesHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP replaces the policy collection overrides of a project
+func (c *UpdateOPAPolicyOverridesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-update-opa-policy-overrides")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+
+	request := &types.UpdateOPAPolicyOverridesRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	overrides := make([]*models.OPAPolicyOverride, 0, len(request.Overrides))
+	seen := make(map[string]bool)
+
+	for _, override := range request.Overrides {
+		if seen[override.Collection] {
+			err := telemetry.Error(ctx, span, nil, "policy collection is overridden more than once")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+		seen[override.Collection] = true
+
+		overrides = append(overrides, &models.OPAPolicyOverride{
+			ProjectID:   project.ID,
+			Collection:  override.Collection,
+			Enforcement: override.Enforcement,
+		})
+	}
+
+	overrides, err := c.Repo().OPAPolicyBundle().ReplaceOPAPolicyOverrides(ctx, project.ID, overrides)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error updating policy overrides")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, toOPAPolicyOverridesResponse(overrides))
+}
+
+func toOPAPolicyOverridesResponse(overrides []*models.OPAPolicyOverride) types.OPAPolicyOverridesResponse {
+	res := types.OPAPolicyOverridesResponse{
+		Overrides: make([]types.OPAPolicyOverride, 0, len(overrides)),
+	}
+
+	for _, override := range overrides {
+		res.Overrides = append(res.Overrides, types.OPAPolicyOverride{
+			Collection:  override.Collection,
+			Enforcement: override.Enforcement,
+		})
+	}
+
+	return res
+}
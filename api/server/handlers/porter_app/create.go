@@ -96,15 +96,39 @@ func (c *CreatePorterAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 			return
 		}
 
-		updateAppImageReq := connect.NewRequest(&porterv1.UpdateAppImageRequest{
-			ProjectId:     int64(project.ID),
-			AppName:       appName,
-			RepositoryUrl: request.ImageInfo.Repository,
-			Tag:           request.ImageInfo.Tag,
-			DeploymentTargetIdentifier: &porterv1.DeploymentTargetIdentifier{
-				Id: appInstance.DeploymentTargetId,
+		deploymentTargetIdentifier := &porterv1.DeploymentTargetIdentifier{
+			Id: appInstance.DeploymentTargetId,
+		}
+
+		blocked, err := checkImageScanGate(ctx, imageScanGateInput{
+			ProjectID:       project.ID,
+			Repo:            c.Repo(),
+			Config:          c.Config(),
+			ImageRepository: request.ImageInfo.Repository,
+			Tag:             request.ImageInfo.Tag,
+			CurrentImageRepository: func(ctx context.Context) (string, error) {
+				return currentAppImageRepository(ctx, c.Config().ClusterControlPlaneClient, project.ID, appName, deploymentTargetIdentifier)
 			},
 		})
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error checking image scan gate")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		if blocked != "" {
+			err := telemetry.Error(ctx, span, nil, blocked)
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusForbidden))
+			return
+		}
+
+		updateAppImageReq := connect.NewRequest(&porterv1.UpdateAppImageRequest{
+			ProjectId:                  int64(project.ID),
+			AppName:                    appName,
+			RepositoryUrl:              request.ImageInfo.Repository,
+			Tag:                        request.ImageInfo.Tag,
+			DeploymentTargetIdentifier: deploymentTargetIdentifier,
+		})
 
 		appImageResp, err := c.Config().ClusterControlPlaneClient.UpdateAppImage(ctx, updateAppImageReq)
 		if err != nil {
@@ -234,6 +258,28 @@ func (c *CreatePorterAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// only updates which set the image are gated, so that other updates of an app are not blocked by its current image
+	if request.ImageInfo.Tag != "" {
+		blocked, err := checkImageScanGate(ctx, imageScanGateInput{
+			ProjectID:       project.ID,
+			Repo:            c.Repo(),
+			Config:          c.Config(),
+			ImageRepository: imageInfo.Repository,
+			Tag:             imageInfo.Tag,
+		})
+		if err != nil {
+			err = telemetry.Error(ctx, span, err, "error checking image scan gate")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		if blocked != "" {
+			err = telemetry.Error(ctx, span, nil, blocked)
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusForbidden))
+			return
+		}
+	}
+
 	var addCustomNodeSelector bool
 	if (cluster.ProvisionedBy == "CAPI" && cluster.CloudProvider == "GCP") || cluster.GCPIntegrationID != 0 {
 		addCustomNodeSelector = true
@@ -259,6 +305,7 @@ func (c *CreatePorterAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 				dnsClient:     c.Config().DNSClient,
 				appRootDomain: c.Config().ServerConf.AppRootDomain,
 				stackName:     appName,
+				clusterID:     cluster.ID,
 			},
 			InjectLauncherToStartCommand: injectLauncher,
 			ShouldValidateHelmValues:     shouldCreate,
@@ -3,6 +3,7 @@ package porter_app
 import (
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/porter-dev/porter/internal/telemetry"
 
 	"github.com/porter-dev/porter/api/server/authz"
@@ -37,6 +38,9 @@ func NewCreateSubdomainHandler(
 // CreateSubdomainRequest is the request object for the /apps/{porter_app_name}/subdomain endpoint
 type CreateSubdomainRequest struct {
 	ServiceName string `schema:"service_name"`
+
+	// DeploymentTargetID is the deployment target of the app, so that the subdomain is deleted along with the deployment target
+	DeploymentTargetID string `schema:"deployment_target_id" json:"deployment_target_id"`
 }
 
 // CreateSubdomainResponse is the response object for the /apps/{porter_app_name}/subdomain endpoint
@@ -74,6 +78,37 @@ func (c *CreateSubdomainHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	}
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "service-name", Value: request.ServiceName})
 
+	// the record is always created with a deployment target, so that it is deleted along with the deployment target
+	var deploymentTargetID uuid.UUID
+	if request.DeploymentTargetID != "" {
+		id, err := uuid.Parse(request.DeploymentTargetID)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+		deploymentTargetID = id
+	} else {
+		defaultDeploymentTarget, err := defaultDeploymentTarget(ctx, defaultDeploymentTargetInput{
+			ProjectID:                 project.ID,
+			ClusterID:                 cluster.ID,
+			ClusterControlPlaneClient: c.Config().ClusterControlPlaneClient,
+		})
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error getting default deployment target")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+		deploymentTargetID = defaultDeploymentTarget.ID
+	}
+
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id cannot be nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()})
+
 	k8sAgent, err := c.GetAgent(r, cluster, "")
 	if err != nil {
 		err := telemetry.Error(ctx, span, nil, "error getting agent")
@@ -105,9 +140,12 @@ func (c *CreateSubdomainHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "nginx-ingress-ip", Value: endpoint})
 
 	createDomain := domain.CreateDNSRecordConfig{
-		ReleaseName: request.ServiceName,
-		RootDomain:  c.Config().ServerConf.AppRootDomain,
-		Endpoint:    endpoint,
+		ReleaseName:        request.ServiceName,
+		RootDomain:         c.Config().ServerConf.AppRootDomain,
+		Endpoint:           endpoint,
+		ClusterID:          cluster.ID,
+		AppName:            name,
+		DeploymentTargetID: deploymentTargetID,
 	}
 
 	record := createDomain.NewDNSRecordForEndpoint()
@@ -1,9 +1,12 @@
 package porter_app
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 
 	"connectrpc.com/connect"
+	"github.com/google/uuid"
 	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -13,6 +16,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
 	"github.com/porter-dev/porter/internal/telemetry"
 )
 
@@ -54,6 +58,12 @@ func (c *DeletePorterAppByNameHandler) ServeHTTP(w http.ResponseWriter, r *http.
 
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-name", Value: appName})
 
+	// subdomains created before records were assigned to apps are found by the domains of the app, which are read before the app is deleted
+	hostnames, err := c.appHostnames(ctx, project.ID, cluster.ID, appName)
+	if err != nil {
+		_ = telemetry.Error(ctx, span, err, "error getting porter app hostnames")
+	}
+
 	deleteReq := connect.NewRequest[porterv1.DeletePorterAppRequest](&porterv1.DeletePorterAppRequest{
 		ProjectId: int64(project.ID),
 		ClusterId: int64(cluster.ID),
@@ -77,5 +87,74 @@ func (c *DeletePorterAppByNameHandler) ServeHTTP(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// subdomains of the app in preview environments are deleted along with their deployment target
+	err = c.deleteSubdomains(ctx, project.ID, cluster.ID, appName, hostnames)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error deleting porter app subdomains")
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+
 	c.WriteResult(w, r, ccpResp.Msg)
 }
+
+func (c *DeletePorterAppByNameHandler) deleteSubdomains(ctx context.Context, projectID, clusterID uint, appName string, hostnames []string) error {
+	records, err := c.Repo().DNSRecord().ListDNSRecordsByPorterAppName(clusterID, appName)
+	if err != nil {
+		return fmt.Errorf("error listing dns records: %w", err)
+	}
+
+	var appRecords []*models.DNSRecord
+
+	for _, record := range records {
+		if record.DeploymentTargetID != uuid.Nil {
+			deploymentTarget, err := c.Repo().DeploymentTarget().DeploymentTarget(projectID, record.DeploymentTargetID.String())
+			if err == nil && deploymentTarget.Preview {
+				continue
+			}
+		}
+
+		appRecords = append(appRecords, record)
+	}
+
+	legacyRecords, err := c.Repo().DNSRecord().ListDNSRecordsWithoutAppByHostnames(hostnames)
+	if err != nil {
+		return fmt.Errorf("error listing dns records by hostname: %w", err)
+	}
+
+	appRecords = append(appRecords, legacyRecords...)
+
+	return porter_app.DeletePorterSubdomains(ctx, porter_app.DeletePorterSubdomainsInput{
+		Records:             appRecords,
+		DNSClient:           c.Config().DNSClient,
+		DNSRecordRepository: c.Repo().DNSRecord(),
+	})
+}
+
+// appHostnames returns the domains of the app in the default deployment target of the cluster
+func (c *DeletePorterAppByNameHandler) appHostnames(ctx context.Context, projectID, clusterID uint, appName string) ([]string, error) {
+	deploymentTarget, err := defaultDeploymentTarget(ctx, defaultDeploymentTargetInput{
+		ProjectID:                 projectID,
+		ClusterID:                 clusterID,
+		ClusterControlPlaneClient: c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting default deployment target: %w", err)
+	}
+
+	currentAppRevisionResp, err := c.Config().ClusterControlPlaneClient.CurrentAppRevision(ctx, connect.NewRequest(&porterv1.CurrentAppRevisionRequest{
+		ProjectId: int64(projectID),
+		DeploymentTargetIdentifier: &porterv1.DeploymentTargetIdentifier{
+			Id: deploymentTarget.ID.String(),
+		},
+		AppName: appName,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("error getting current app revision: %w", err)
+	}
+
+	if currentAppRevisionResp == nil || currentAppRevisionResp.Msg == nil || currentAppRevisionResp.Msg.AppRevision == nil {
+		return nil, nil
+	}
+
+	return porter_app.AppHostnames(currentAppRevisionResp.Msg.AppRevision.App), nil
+}
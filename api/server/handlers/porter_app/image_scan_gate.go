@@ -0,0 +1,137 @@
+package porter_app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/registry"
+	"github.com/porter-dev/porter/internal/registry/scanner"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// imageScanGateInput is the input to checkImageScanGate
+type imageScanGateInput struct {
+	ProjectID uint
+	Repo      repository.Repository
+	Config    *config.Config
+
+	// ImageRepository is the URI of the image repository, like 123456789.dkr.ecr.us-west-2.amazonaws.com/web
+	ImageRepository string
+	Tag             string
+
+	// CurrentImageRepository returns the image repository of the app if ImageRepository is empty. It is only called
+	// if the project has a severity gate.
+	CurrentImageRepository func(ctx context.Context) (string, error)
+}
+
+// checkImageScanGate returns a reason to block deploying an image if the stored scans of the digest its tag currently
+// points to found vulnerabilities at or above the severity gate of the project. When the project has a severity gate,
+// images whose digest cannot be resolved through the registries of the project or which have not been scanned are
+// blocked as well, since they cannot be checked. An empty reason is returned if the image can be deployed.
+func checkImageScanGate(ctx context.Context, input imageScanGateInput) (string, error) {
+	ctx, span := telemetry.NewSpan(ctx, "check-image-scan-gate")
+	defer span.End()
+
+	tag := input.Tag
+	if tag == "" {
+		tag = "latest"
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: input.ProjectID},
+		telemetry.AttributeKV{Key: "tag", Value: tag},
+	)
+
+	policy, err := input.Repo.ImageScan().ReadImageScanPolicy(ctx, input.ProjectID)
+	if err != nil {
+		return "", telemetry.Error(ctx, span, err, "error reading image scan policy")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "severity-threshold", Value: policy.SeverityThreshold})
+
+	if policy.SeverityThreshold == "" {
+		return "", nil
+	}
+
+	imageRepository := input.ImageRepository
+	if imageRepository == "" && input.CurrentImageRepository != nil {
+		imageRepository, err = input.CurrentImageRepository(ctx)
+		if err != nil {
+			return "", telemetry.Error(ctx, span, err, "error getting current image repository of app")
+		}
+	}
+
+	imageRepository = strings.TrimPrefix(strings.TrimPrefix(imageRepository, "https://"), "http://")
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "image-repository", Value: imageRepository})
+
+	if imageRepository == "" {
+		return "", nil
+	}
+
+	digest, err := registry.ResolveImageDigest(ctx, input.ProjectID, imageRepository, tag, input.Repo, input.Config)
+	if err != nil {
+		if errors.Is(err, registry.ErrImageNotFound) {
+			return fmt.Sprintf("image %s:%s is blocked: the image cannot be found in the registries of the project, so its vulnerabilities cannot be checked", imageRepository, tag), nil
+		}
+
+		return "", telemetry.Error(ctx, span, err, "error resolving digest of image")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "digest", Value: digest})
+
+	scans, err := input.Repo.ImageScan().ListImageScansByDigest(ctx, input.ProjectID, imageRepository, digest)
+	if err != nil {
+		return "", telemetry.Error(ctx, span, err, "error listing image scans")
+	}
+
+	if len(scans) == 0 {
+		return fmt.Sprintf("image %s:%s (%s) is blocked: the image has not been scanned", imageRepository, tag, digest), nil
+	}
+
+	// the latest scan of each scanner must pass the gate
+	for _, scan := range scans {
+		err = scanner.CheckSeverityGate(*scan.ToVulnerabilitySummaryType(), types.VulnerabilitySeverity(policy.SeverityThreshold))
+		if err != nil {
+			return fmt.Sprintf("image %s:%s (%s) is blocked: %s", imageRepository, tag, digest, err.Error()), nil
+		}
+	}
+
+	return "", nil
+}
+
+// currentAppImageRepository returns the image repository of the current revision of an app in a deployment target
+func currentAppImageRepository(
+	ctx context.Context,
+	ccpClient porterv1connect.ClusterControlPlaneServiceClient,
+	projectID uint,
+	appName string,
+	deploymentTargetIdentifier *porterv1.DeploymentTargetIdentifier,
+) (string, error) {
+	resp, err := ccpClient.CurrentAppRevision(ctx, connect.NewRequest(&porterv1.CurrentAppRevisionRequest{
+		ProjectId:                  int64(projectID),
+		AppName:                    appName,
+		DeploymentTargetIdentifier: deploymentTargetIdentifier,
+	}))
+	if err != nil {
+		return "", err
+	}
+
+	if resp == nil || resp.Msg == nil || resp.Msg.AppRevision == nil || resp.Msg.AppRevision.App == nil {
+		return "", errors.New("current app revision is nil")
+	}
+
+	if resp.Msg.AppRevision.App.Image == nil {
+		return "", nil
+	}
+
+	return resp.Msg.AppRevision.App.Image.Repository, nil
+}
@@ -42,6 +42,8 @@ type ParsePorterYAMLToProtoRequest struct {
 	B64Yaml         string              `json:"b64_yaml"`
 	AppName         string              `json:"app_name"`
 	PatchOperations []v2.PatchOperation `json:"patch_operations"`
+	// Strict rejects porter.yaml files with unknown fields, type mismatches or invalid values
+	Strict bool `json:"strict"`
 }
 
 // EncodedAppWithEnv is a struct that contains a base64-encoded app proto object and a map of env variables
@@ -97,6 +99,16 @@ func (c *ParsePorterYAMLToProtoHandler) ServeHTTP(w http.ResponseWriter, r *http
 		return
 	}
 
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "strict", Value: request.Strict})
+
+	if request.Strict {
+		if err := porter_app.ValidateYAML(ctx, yaml); err != nil {
+			err := telemetry.Error(ctx, span, err, "porter yaml is invalid")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+	}
+
 	appDefinition, err := porter_app.ParseYAML(ctx, yaml, request.AppName)
 	if err != nil {
 		err := telemetry.Error(ctx, span, err, "error parsing yaml")
@@ -0,0 +1,130 @@
+package porter_app
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// AppPolicyCheckHandler handles requests to the /apps/{porter_app_name}/policy-check endpoint
+type AppPolicyCheckHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewAppPolicyCheckHandler returns a new AppPolicyCheckHandler
+func NewAppPolicyCheckHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *AppPolicyCheckHandler {
+	return &AppPolicyCheckHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// AppPolicyCheckRequest is the request object for the /apps/{porter_app_name}/policy-check endpoint
+type AppPolicyCheckRequest struct {
+	DeploymentTargetID string `json:"deployment_target_id"`
+
+	// AppRevisionID is the revision whose manifests are checked, such as a revision which has been created but not deployed yet.
+	// If empty, the manifests of the current revision of the app are checked.
+	AppRevisionID string `json:"app_revision_id"`
+
+	// Base64Manifests are the manifests to check. If empty, the manifests of the app are templated by the cluster control plane.
+	Base64Manifests string `json:"base64_manifests"`
+}
+
+// AppPolicyViolation is a policy violated by an object of the manifests of an app
+type AppPolicyViolation struct {
+	// Enforcement is either "warn" or "deny"
+	Enforcement string `json:"enforcement"`
+	Collection  string `json:"collection"`
+	ObjectID    string `json:"object_id"`
+	PolicyID    string `json:"policy_id"`
+	Severity    string `json:"severity"`
+	Title       string `json:"title"`
+	Message     string `json:"message"`
+}
+
+// AppPolicySkippedBundle is a policy bundle of the project which could not be compiled, and whose policies were not evaluated
+type AppPolicySkippedBundle struct {
+	Name    string `json:"name"`
+	Version uint   `json:"version"`
+	Error   string `json:"error"`
+}
+
+// AppPolicyCheckResponse is the response object for the /apps/{porter_app_name}/policy-check endpoint
+type AppPolicyCheckResponse struct {
+	Violations []AppPolicyViolation `json:"violations"`
+
+	// SkippedBundles are the policy bundles which could not be compiled. The policies of the other bundles are still evaluated.
+	SkippedBundles []AppPolicySkippedBundle `json:"skipped_bundles"`
+
+	// Denied is true if any violated policy is enforced with "deny", in which case the app should not be deployed
+	Denied bool `json:"denied"`
+}
+
+// ServeHTTP evaluates the enforced policy collections of the project's OPA policy bundles against the manifests of an app
+func (c *AppPolicyCheckHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-app-policy-check")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
+	)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		e := telemetry.Error(ctx, span, reqErr, "error parsing app name from url")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(e, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-name", Value: appName})
+
+	request := &AppPolicyCheckRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: request.DeploymentTargetID},
+		telemetry.AttributeKV{Key: "app-revision-id", Value: request.AppRevisionID},
+	)
+
+	response, err := checkAppPolicies(ctx, appPolicyCheckInput{
+		ProjectID:          project.ID,
+		ClusterID:          cluster.ID,
+		AppName:            appName,
+		Repo:               c.Repo(),
+		Config:             c.Config(),
+		DeploymentTargetID: request.DeploymentTargetID,
+		AppRevisionID:      request.AppRevisionID,
+		Base64Manifests:    request.Base64Manifests,
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error checking app policies")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "violation-count", Value: len(response.Violations)},
+		telemetry.AttributeKV{Key: "denied", Value: response.Denied},
+	)
+
+	c.WriteResult(w, r, response)
+}
@@ -0,0 +1,189 @@
+package porter_app
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/opa"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// appPolicyCheckInput is the input to checkAppPolicies
+type appPolicyCheckInput struct {
+	ProjectID uint
+	ClusterID uint
+	AppName   string
+	Repo      repository.Repository
+	Config    *config.Config
+
+	DeploymentTargetID   string
+	DeploymentTargetName string
+
+	// AppRevisionID is the revision whose manifests are checked. If empty, the manifests of the current revision of the app are checked.
+	AppRevisionID string
+
+	// Base64Manifests are the manifests to check. If empty, the manifests of the app are templated by the cluster control plane.
+	Base64Manifests string
+}
+
+// checkAppPolicies evaluates the enforced policy collections of the project's OPA policy bundles against the manifests of a revision
+// of an app. Bundles which cannot be compiled are skipped and reported in the response, so that a single broken bundle does not
+// block every deploy of the project.
+func checkAppPolicies(ctx context.Context, input appPolicyCheckInput) (AppPolicyCheckResponse, error) {
+	ctx, span := telemetry.NewSpan(ctx, "check-app-policies")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: input.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: input.ClusterID},
+		telemetry.AttributeKV{Key: "app-name", Value: input.AppName},
+		telemetry.AttributeKV{Key: "app-revision-id", Value: input.AppRevisionID},
+	)
+
+	response := AppPolicyCheckResponse{
+		Violations:     []AppPolicyViolation{},
+		SkippedBundles: []AppPolicySkippedBundle{},
+	}
+
+	bundles, err := input.Repo.OPAPolicyBundle().ListLatestOPAPolicyBundlesByProjectID(ctx, input.ProjectID)
+	if err != nil {
+		return response, telemetry.Error(ctx, span, err, "error listing policy bundles")
+	}
+
+	policies, skipped := opa.LoadOPAPolicyBundles(bundles)
+
+	for _, bundle := range skipped {
+		response.SkippedBundles = append(response.SkippedBundles, AppPolicySkippedBundle{
+			Name:    bundle.Name,
+			Version: bundle.Version,
+			Error:   bundle.Err.Error(),
+		})
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "skipped-bundle-count", Value: len(response.SkippedBundles)})
+
+	overrideModels, err := input.Repo.OPAPolicyBundle().ListOPAPolicyOverridesByProjectID(ctx, input.ProjectID)
+	if err != nil {
+		return response, telemetry.Error(ctx, span, err, "error listing policy overrides")
+	}
+
+	overrides := make(map[string]opa.Enforcement)
+	for _, override := range overrideModels {
+		overrides[override.Collection] = opa.Enforcement(override.Enforcement)
+	}
+
+	// the manifests are only templated when a policy is enforced, so that projects without enforced policies do not depend on the cluster control plane to deploy
+	enforcement := opa.StrictestEnforcement(policies, overrides)
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "enforcement", Value: string(enforcement)})
+
+	if enforcement == opa.EnforcementNone {
+		return response, nil
+	}
+
+	b64Manifests := input.Base64Manifests
+	if b64Manifests == "" {
+		var deploymentTargetIdentifer *porterv1.DeploymentTargetIdentifier
+		if input.DeploymentTargetID != "" || input.DeploymentTargetName != "" {
+			deploymentTargetIdentifer = &porterv1.DeploymentTargetIdentifier{
+				Id:   input.DeploymentTargetID,
+				Name: input.DeploymentTargetName,
+			}
+		}
+
+		appManifestsReq := connect.NewRequest(&porterv1.TemplateAppManifestsRequest{
+			ProjectId:                  int64(input.ProjectID),
+			ClusterId:                  int64(input.ClusterID),
+			AppName:                    input.AppName,
+			AppRevisionId:              input.AppRevisionID,
+			DeploymentTargetIdentifier: deploymentTargetIdentifer,
+		})
+
+		appManifestsRes, err := input.Config.ClusterControlPlaneClient.TemplateAppManifests(ctx, appManifestsReq)
+		if err != nil {
+			return response, telemetry.Error(ctx, span, err, "error getting app manifests from cluster control plane client")
+		}
+
+		if appManifestsRes == nil || appManifestsRes.Msg == nil {
+			return response, telemetry.Error(ctx, span, nil, "app manifests resp is nil")
+		}
+
+		b64Manifests = appManifestsRes.Msg.Base64Manifests
+	}
+
+	manifests, err := base64.StdEncoding.DecodeString(b64Manifests)
+	if err != nil {
+		return response, telemetry.Error(ctx, span, err, "error decoding manifests")
+	}
+
+	violations, err := opa.EvaluateManifests(ctx, policies, overrides, manifests)
+	if err != nil {
+		return response, telemetry.Error(ctx, span, err, "error evaluating policies")
+	}
+
+	for _, violation := range violations {
+		if violation.Enforcement == opa.EnforcementDeny {
+			response.Denied = true
+		}
+
+		response.Violations = append(response.Violations, AppPolicyViolation{
+			Enforcement: string(violation.Enforcement),
+			Collection:  violation.CategoryName,
+			ObjectID:    violation.ObjectID,
+			PolicyID:    violation.PolicyID,
+			Severity:    violation.PolicySeverity,
+			Title:       violation.PolicyTitle,
+			Message:     violation.PolicyMessage,
+		})
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "violation-count", Value: len(response.Violations)},
+		telemetry.AttributeKV{Key: "denied", Value: response.Denied},
+	)
+
+	return response, nil
+}
+
+// deniedPolicyViolationsMessage returns the message returned to clients when a revision is rejected for violating denied policies
+func deniedPolicyViolationsMessage(response AppPolicyCheckResponse) string {
+	var denied []string
+	for _, violation := range response.Violations {
+		if violation.Enforcement != string(opa.EnforcementDeny) {
+			continue
+		}
+
+		denied = append(denied, fmt.Sprintf("%s: %s (%s)", violation.Collection, violation.Title, violation.ObjectID))
+	}
+
+	return fmt.Sprintf("app violates policies enforced by the project: %s", strings.Join(denied, "; "))
+}
+
+// failAppRevision marks a revision as failed to deploy, so that a revision which is rejected by the policies of the project is never deployed
+func failAppRevision(ctx context.Context, conf *config.Config, projectID uint, appRevisionID string) error {
+	ctx, span := telemetry.NewSpan(ctx, "fail-app-revision")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "app-revision-id", Value: appRevisionID},
+	)
+
+	updateStatusReq := connect.NewRequest(&porterv1.UpdateRevisionStatusRequest{
+		ProjectId:      int64(projectID),
+		AppRevisionId:  appRevisionID,
+		RevisionStatus: porterv1.EnumRevisionStatus_ENUM_REVISION_STATUS_DEPLOY_FAILED,
+	})
+
+	_, err := conf.ClusterControlPlaneClient.UpdateRevisionStatus(ctx, updateStatusReq)
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "error updating revision status")
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package porter_app
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	v2 "github.com/porter-dev/porter/internal/porter_app/v2"
+)
+
+// PorterYAMLSchemaHandler is the handler for the /porter-yaml/schema endpoint
+type PorterYAMLSchemaHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewPorterYAMLSchemaHandler returns a new PorterYAMLSchemaHandler
+func NewPorterYAMLSchemaHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *PorterYAMLSchemaHandler {
+	return &PorterYAMLSchemaHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP returns the JSON Schema of v2 porter.yaml files, for use in editors
+func (c *PorterYAMLSchemaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.WriteResult(w, r, v2.JSONSchema())
+}
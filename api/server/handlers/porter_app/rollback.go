@@ -126,6 +126,7 @@ func (c *RollbackPorterAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 				dnsClient:     c.Config().DNSClient,
 				appRootDomain: c.Config().ServerConf.AppRootDomain,
 				stackName:     appName,
+				clusterID:     cluster.ID,
 			},
 			InjectLauncherToStartCommand: injectLauncher,
 			FullHelmValues:               string(valuesYaml),
@@ -107,6 +107,10 @@ type UpdateAppRequest struct {
 type UpdateAppResponse struct {
 	AppName       string `json:"app_name"`
 	AppRevisionId string `json:"app_revision_id"`
+	// PolicyViolations are the policies enforced with "warn" which are violated by the revision. Revisions violating policies enforced with "deny" are rejected.
+	PolicyViolations []AppPolicyViolation `json:"policy_violations"`
+	// SkippedPolicyBundles are the policy bundles of the project which could not be compiled, and whose policies were not evaluated
+	SkippedPolicyBundles []AppPolicySkippedBundle `json:"skipped_policy_bundles"`
 }
 
 // ServeHTTP translates the request into an UpdateApp request, forwards to the cluster control plane, and returns the response
@@ -348,9 +352,49 @@ func (c *UpdateAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "resp-app-revision-id", Value: ccpResp.Msg.AppRevisionId})
 
+	// the policies are evaluated against the manifests of the revision created by the cluster control plane, which is marked as failed
+	// if it cannot be checked or is denied, so that it is never deployed
+	policyCheck, err := checkAppPolicies(ctx, appPolicyCheckInput{
+		ProjectID:            project.ID,
+		ClusterID:            cluster.ID,
+		AppName:              appProto.Name,
+		Repo:                 c.Repo(),
+		Config:               c.Config(),
+		DeploymentTargetID:   deploymentTargetID,
+		DeploymentTargetName: deploymentTargetName,
+		AppRevisionID:        ccpResp.Msg.AppRevisionId,
+	})
+	if err != nil {
+		_ = failAppRevision(ctx, c.Config(), project.ID, ccpResp.Msg.AppRevisionId)
+
+		err := telemetry.Error(ctx, span, err, "error checking app policies")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "policy-violation-count", Value: len(policyCheck.Violations)},
+		telemetry.AttributeKV{Key: "policy-denied", Value: policyCheck.Denied},
+	)
+
+	if policyCheck.Denied {
+		err := failAppRevision(ctx, c.Config(), project.ID, ccpResp.Msg.AppRevisionId)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error failing denied app revision")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, nil, deniedPolicyViolationsMessage(policyCheck))
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusForbidden))
+		return
+	}
+
 	response := &UpdateAppResponse{
-		AppRevisionId: ccpResp.Msg.AppRevisionId,
-		AppName:       appProto.Name,
+		AppRevisionId:        ccpResp.Msg.AppRevisionId,
+		AppName:              appProto.Name,
+		PolicyViolations:     policyCheck.Violations,
+		SkippedPolicyBundles: policyCheck.SkippedBundles,
 	}
 
 	c.WriteResult(w, r, response)
@@ -46,6 +46,7 @@ func (c *UpdateAppRevisionStatusHandler) ServeHTTP(w http.ResponseWriter, r *htt
 	defer span.End()
 
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 
 	// read the request object from the decoder
 	request := &UpdateAppRevisionStatusRequest{}
@@ -90,6 +91,40 @@ func (c *UpdateAppRevisionStatusHandler) ServeHTTP(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// a revision awaiting its build is deployed once its build succeeds, so its policies are checked again against the manifests with the built image
+	if request.Status == models.AppRevisionStatus_BuildSuccessful {
+		appName, _ := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+
+		policyCheck, err := checkAppPolicies(ctx, appPolicyCheckInput{
+			ProjectID:     project.ID,
+			ClusterID:     cluster.ID,
+			AppName:       appName,
+			Repo:          c.Repo(),
+			Config:        c.Config(),
+			AppRevisionID: appRevisionId,
+		})
+		if err != nil {
+			_ = failAppRevision(ctx, c.Config(), project.ID, appRevisionId)
+
+			err := telemetry.Error(ctx, span, err, "error checking app policies")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		if policyCheck.Denied {
+			err := failAppRevision(ctx, c.Config(), project.ID, appRevisionId)
+			if err != nil {
+				err := telemetry.Error(ctx, span, err, "error failing denied app revision")
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+				return
+			}
+
+			err = telemetry.Error(ctx, span, nil, deniedPolicyViolationsMessage(policyCheck))
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusForbidden))
+			return
+		}
+	}
+
 	updateStatusReq := connect.NewRequest(&porterv1.UpdateRevisionStatusRequest{
 		ProjectId:      int64(project.ID),
 		AppRevisionId:  appRevisionId,
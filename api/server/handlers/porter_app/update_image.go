@@ -1,6 +1,7 @@
 package porter_app
 
 import (
+	"context"
 	"net/http"
 
 	"connectrpc.com/connect"
@@ -102,16 +103,41 @@ func (c *UpdateImageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		telemetry.AttributeKV{Key: "deployment-target-name", Value: request.DeploymentTargetName},
 	)
 
-	updateImageReq := connect.NewRequest(&porterv1.UpdateAppImageRequest{
-		ProjectId:     int64(project.ID),
-		RepositoryUrl: request.Repository,
-		Tag:           request.Tag,
-		AppName:       appName,
-		DeploymentTargetIdentifier: &porterv1.DeploymentTargetIdentifier{
-			Id:   request.DeploymentTargetID,
-			Name: deploymentTargetName,
+	deploymentTargetIdentifier := &porterv1.DeploymentTargetIdentifier{
+		Id:   request.DeploymentTargetID,
+		Name: deploymentTargetName,
+	}
+
+	blocked, err := checkImageScanGate(ctx, imageScanGateInput{
+		ProjectID:       project.ID,
+		Repo:            c.Repo(),
+		Config:          c.Config(),
+		ImageRepository: request.Repository,
+		Tag:             request.Tag,
+		// the repository of the current revision is kept if the request only updates the tag
+		CurrentImageRepository: func(ctx context.Context) (string, error) {
+			return currentAppImageRepository(ctx, c.Config().ClusterControlPlaneClient, project.ID, appName, deploymentTargetIdentifier)
 		},
 	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error checking image scan gate")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	if blocked != "" {
+		err := telemetry.Error(ctx, span, nil, blocked)
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusForbidden))
+		return
+	}
+
+	updateImageReq := connect.NewRequest(&porterv1.UpdateAppImageRequest{
+		ProjectId:                  int64(project.ID),
+		RepositoryUrl:              request.Repository,
+		Tag:                        request.Tag,
+		AppName:                    appName,
+		DeploymentTargetIdentifier: deploymentTargetIdentifier,
+	})
 	ccpResp, err := c.Config().ClusterControlPlaneClient.UpdateAppImage(ctx, updateImageReq)
 	if err != nil {
 		err := telemetry.Error(ctx, span, err, "error calling ccp update porter app image")
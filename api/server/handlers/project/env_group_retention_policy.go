@@ -0,0 +1,108 @@
+package project
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// GetEnvGroupRetentionPolicyHandler is the handler for the GET /env-group-retention-policy endpoint
+type GetEnvGroupRetentionPolicyHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewGetEnvGroupRetentionPolicyHandler returns a new GetEnvGroupRetentionPolicyHandler
+func NewGetEnvGroupRetentionPolicyHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetEnvGroupRetentionPolicyHandler {
+	return &GetEnvGroupRetentionPolicyHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP returns the environment group retention policy of a project. Projects without a policy get a disabled
+// policy which keeps the default number of app revisions.
+func (c *GetEnvGroupRetentionPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-env-group-retention-policy")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+
+	policy, err := c.Repo().EnvGroupRetention().ReadEnvGroupRetentionPolicy(ctx, project.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.WriteResult(w, r, &types.EnvGroupRetentionPolicy{
+				KeepLastRevisions: types.DefaultEnvGroupRetentionKeepLastRevisions,
+			})
+			return
+		}
+
+		err := telemetry.Error(ctx, span, err, "error reading env group retention policy")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, policy.ToEnvGroupRetentionPolicyType())
+}
+
+// UpdateEnvGroupRetentionPolicyHandler is the handler for the POST /env-group-retention-policy endpoint
+type UpdateEnvGroupRetentionPolicyHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewUpdateEnvGroupRetentionPolicyHandler returns a new UpdateEnvGroupRetentionPolicyHandler
+func NewUpdateEnvGroupRetentionPolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateEnvGroupRetentionPolicyHandler {
+	return &UpdateEnvGroupRetentionPolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP sets the number of app revisions whose environment group versions are kept by the garbage collector, and
+// whether the garbage collector deletes the copies of the project which are not kept
+func (c *UpdateEnvGroupRetentionPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-update-env-group-retention-policy")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	request := &types.UpdateEnvGroupRetentionPolicyRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "keep-last-revisions", Value: request.KeepLastRevisions},
+		telemetry.AttributeKV{Key: "enabled", Value: request.Enabled},
+	)
+
+	policy, err := c.Repo().EnvGroupRetention().UpdateEnvGroupRetentionPolicy(ctx, &models.EnvGroupRetentionPolicy{
+		ProjectID:         project.ID,
+		KeepLastRevisions: request.KeepLastRevisions,
+		Enabled:           request.Enabled,
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error updating env group retention policy")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, policy.ToEnvGroupRetentionPolicyType())
+}
@@ -0,0 +1,99 @@
+package project
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// GetImageScanPolicyHandler is the handler for the GET /image-scan-policy endpoint
+type GetImageScanPolicyHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewGetImageScanPolicyHandler returns a new GetImageScanPolicyHandler
+func NewGetImageScanPolicyHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetImageScanPolicyHandler {
+	return &GetImageScanPolicyHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP returns the severity gate of a project
+func (c *GetImageScanPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-image-scan-policy")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+
+	policy, err := c.Repo().ImageScan().ReadImageScanPolicy(ctx, project.ID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading image scan policy")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, &types.ImageScanPolicy{
+		SeverityThreshold: types.VulnerabilitySeverity(policy.SeverityThreshold),
+	})
+}
+
+// UpdateImageScanPolicyHandler is the handler for the POST /image-scan-policy endpoint
+type UpdateImageScanPolicyHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewUpdateImageScanPolicyHandler returns a new UpdateImageScanPolicyHandler
+func NewUpdateImageScanPolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateImageScanPolicyHandler {
+	return &UpdateImageScanPolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP sets the severity gate of a project. An empty severity threshold removes the gate.
+func (c *UpdateImageScanPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-update-image-scan-policy")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ImageScanPolicy{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "severity-threshold", Value: string(request.SeverityThreshold)},
+	)
+
+	policy, err := c.Repo().ImageScan().UpdateImageScanPolicy(ctx, &models.ImageScanPolicy{
+		ProjectID:         project.ID,
+		SeverityThreshold: string(request.SeverityThreshold),
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error updating image scan policy")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, &types.ImageScanPolicy{
+		SeverityThreshold: types.VulnerabilitySeverity(policy.SeverityThreshold),
+	})
+}
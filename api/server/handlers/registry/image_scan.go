@@ -0,0 +1,214 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"github.com/porter-dev/porter/internal/registry/scanner"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// RegistryCreateImageScanHandler is the handler for the POST /registries/{registry_id}/scans endpoint
+type RegistryCreateImageScanHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewRegistryCreateImageScanHandler returns a new RegistryCreateImageScanHandler
+func NewRegistryCreateImageScanHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryCreateImageScanHandler {
+	return &RegistryCreateImageScanHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP scans an image of a repository of the registry, by ingesting an uploaded report or importing the
+// findings of the registry, and stores the result for the digest of the image
+func (p *RegistryCreateImageScanHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-create-image-scan")
+	defer span.End()
+
+	reg, _ := ctx.Value(types.RegistryScope).(*models.Registry)
+
+	request := &types.CreateImageScanRequest{}
+
+	ok := p.DecodeAndValidate(w, r, request)
+	if !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	_reg := registry.Registry(*reg)
+	regAPI := &_reg
+
+	repository := regAPI.RepositoryURI(request.RepoName)
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "registry-id", Value: reg.ID},
+		telemetry.AttributeKV{Key: "repository", Value: repository},
+		telemetry.AttributeKV{Key: "tag", Value: request.Tag},
+		telemetry.AttributeKV{Key: "digest", Value: request.Digest},
+		telemetry.AttributeKV{Key: "format", Value: string(request.Format)},
+	)
+
+	if request.Format == types.ImageScanFormatTrivy && len(request.Report) == 0 {
+		err := telemetry.Error(ctx, span, nil, "a report is required to ingest a trivy scan")
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	imageScanner, err := regAPI.GetScanner(ctx, request.Format, request.Report, p.Config())
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error getting image scanner")
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	report, err := imageScanner.Scan(ctx, scanner.Image{
+		RepoName: request.RepoName,
+		Tag:      request.Tag,
+		Digest:   request.Digest,
+	})
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error scanning image")
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	if report.Digest == "" {
+		err := telemetry.Error(ctx, span, nil, "the digest of the scanned image is unknown, so a digest must be set")
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	vulns, err := json.Marshal(report.Vulnerabilities)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error marshalling vulnerabilities")
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	summary := report.Summary()
+
+	scannedAt := time.Now().UTC()
+	if report.ScannedAt != nil {
+		scannedAt = report.ScannedAt.UTC()
+	}
+
+	scan, err := p.Repo().ImageScan().UpsertImageScan(ctx, &models.ImageScan{
+		ProjectID:       reg.ProjectID,
+		Repository:      repository,
+		Digest:          report.Digest,
+		Scanner:         report.Scanner,
+		Tag:             request.Tag,
+		Critical:        summary.Critical,
+		High:            summary.High,
+		Medium:          summary.Medium,
+		Low:             summary.Low,
+		Unknown:         summary.Unknown,
+		ScannedAt:       scannedAt,
+		Vulnerabilities: vulns,
+	})
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error storing image scan")
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	p.WriteResult(w, r, scan.ToImageScanType())
+}
+
+// RegistryGetImageScansHandler is the handler for the GET /registries/{registry_id}/scans endpoint
+type RegistryGetImageScansHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewRegistryGetImageScansHandler returns a new RegistryGetImageScansHandler
+func NewRegistryGetImageScansHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryGetImageScansHandler {
+	return &RegistryGetImageScansHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP returns the stored scans of an image of a repository of the registry, selected by digest or by the tag
+// of the image when it was last scanned
+func (p *RegistryGetImageScansHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-image-scans")
+	defer span.End()
+
+	reg, _ := ctx.Value(types.RegistryScope).(*models.Registry)
+
+	request := &types.GetImageScansRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	_reg := registry.Registry(*reg)
+	repository := _reg.RepositoryURI(request.RepoName)
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "registry-id", Value: reg.ID},
+		telemetry.AttributeKV{Key: "repository", Value: repository},
+		telemetry.AttributeKV{Key: "tag", Value: request.Tag},
+		telemetry.AttributeKV{Key: "digest", Value: request.Digest},
+	)
+
+	digest := request.Digest
+
+	if digest == "" {
+		if request.Tag == "" {
+			err := telemetry.Error(ctx, span, nil, "one of the digest or tag is required")
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		scan, err := p.Repo().ImageScan().ReadLatestImageScanByTag(ctx, reg.ProjectID, repository, request.Tag)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				err = telemetry.Error(ctx, span, err, "image has not been scanned")
+				p.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+				return
+			}
+
+			err = telemetry.Error(ctx, span, err, "error reading image scan")
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		digest = scan.Digest
+	}
+
+	scans, err := p.Repo().ImageScan().ListImageScansByDigest(ctx, reg.ProjectID, repository, digest)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing image scans")
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.GetImageScansResponse, 0, len(scans))
+
+	for _, scan := range scans {
+		res = append(res, scan.ToImageScanType())
+	}
+
+	p.WriteResult(w, r, res)
+}
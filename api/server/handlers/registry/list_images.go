@@ -48,5 +48,10 @@ func (c *RegistryListImagesHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if err := regAPI.AttachVulnerabilities(ctx, repoName, imgs, c.Repo()); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
 	c.WriteResult(w, r, imgs)
 }
@@ -0,0 +1,251 @@
+package registry
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"github.com/porter-dev/porter/internal/registry/gc"
+	"github.com/porter-dev/porter/internal/registry/retention"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// RegistryListRetentionPoliciesHandler is the handler for the GET /registries/{registry_id}/retention-policies endpoint
+type RegistryListRetentionPoliciesHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewRegistryListRetentionPoliciesHandler returns a new RegistryListRetentionPoliciesHandler
+func NewRegistryListRetentionPoliciesHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *RegistryListRetentionPoliciesHandler {
+	return &RegistryListRetentionPoliciesHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP returns the retention policies of the image repositories of the registry
+func (p *RegistryListRetentionPoliciesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-retention-policies")
+	defer span.End()
+
+	reg, _ := ctx.Value(types.RegistryScope).(*models.Registry)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "registry-id", Value: reg.ID})
+
+	policies, err := p.Repo().RegistryRetention().ListRetentionPoliciesByRegistryID(ctx, reg.ProjectID, reg.ID)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing retention policies")
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListRegistryRetentionPoliciesResponse, 0, len(policies))
+
+	for _, policy := range policies {
+		res = append(res, policy.ToRegistryRetentionPolicyType())
+	}
+
+	p.WriteResult(w, r, res)
+}
+
+// RegistryUpdateRetentionPolicyHandler is the handler for the POST /registries/{registry_id}/retention-policies endpoint
+type RegistryUpdateRetentionPolicyHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewRegistryUpdateRetentionPolicyHandler returns a new RegistryUpdateRetentionPolicyHandler
+func NewRegistryUpdateRetentionPolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryUpdateRetentionPolicyHandler {
+	return &RegistryUpdateRetentionPolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP creates or updates the retention policy of an image repository of the registry
+func (p *RegistryUpdateRetentionPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-update-retention-policy")
+	defer span.End()
+
+	reg, _ := ctx.Value(types.RegistryScope).(*models.Registry)
+
+	request := &types.UpdateRegistryRetentionPolicyRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "registry-id", Value: reg.ID},
+		telemetry.AttributeKV{Key: "repo-name", Value: request.RepoName},
+		telemetry.AttributeKV{Key: "keep-last", Value: request.KeepLast},
+		telemetry.AttributeKV{Key: "keep-tag-pattern", Value: request.KeepTagPattern},
+		telemetry.AttributeKV{Key: "enabled", Value: request.Enabled},
+	)
+
+	_reg := registry.Registry(*reg)
+
+	if !_reg.CanDeleteImages() {
+		err := telemetry.Error(ctx, span, nil, "retention policies are only supported for ecr and gar registries")
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	if _, err := retention.NewPolicy(request.KeepLast, request.KeepTagPattern); err != nil {
+		err = telemetry.Error(ctx, span, err, "invalid retention policy")
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	policy, err := p.Repo().RegistryRetention().UpdateRetentionPolicy(ctx, &models.RegistryRetentionPolicy{
+		ProjectID:      reg.ProjectID,
+		RegistryID:     reg.ID,
+		RepoName:       request.RepoName,
+		KeepLast:       request.KeepLast,
+		KeepTagPattern: request.KeepTagPattern,
+		Enabled:        request.Enabled,
+	})
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error updating retention policy")
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, policy.ToRegistryRetentionPolicyType())
+}
+
+// RegistryDeleteRetentionPolicyHandler is the handler for the DELETE /registries/{registry_id}/retention-policies endpoint
+type RegistryDeleteRetentionPolicyHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewRegistryDeleteRetentionPolicyHandler returns a new RegistryDeleteRetentionPolicyHandler
+func NewRegistryDeleteRetentionPolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryDeleteRetentionPolicyHandler {
+	return &RegistryDeleteRetentionPolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP deletes the retention policy of an image repository of the registry
+func (p *RegistryDeleteRetentionPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-delete-retention-policy")
+	defer span.End()
+
+	reg, _ := ctx.Value(types.RegistryScope).(*models.Registry)
+
+	request := &types.DeleteRegistryRetentionPolicyRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "registry-id", Value: reg.ID},
+		telemetry.AttributeKV{Key: "repo-name", Value: request.RepoName},
+	)
+
+	policy, err := p.Repo().RegistryRetention().ReadRetentionPolicy(ctx, reg.ProjectID, reg.ID, request.RepoName)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = telemetry.Error(ctx, span, err, "repository has no retention policy")
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, err, "error reading retention policy")
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := p.Repo().RegistryRetention().DeleteRetentionPolicy(ctx, policy); err != nil {
+		err = telemetry.Error(ctx, span, err, "error deleting retention policy")
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// RegistryGetRetentionReportHandler is the handler for the GET /registries/{registry_id}/retention-policies/report endpoint
+type RegistryGetRetentionReportHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewRegistryGetRetentionReportHandler returns a new RegistryGetRetentionReportHandler
+func NewRegistryGetRetentionReportHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryGetRetentionReportHandler {
+	return &RegistryGetRetentionReportHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP returns which images of an image repository of the registry would be kept and deleted by its retention
+// policy, without deleting any image. Reports can be generated for disabled policies.
+func (p *RegistryGetRetentionReportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-retention-report")
+	defer span.End()
+
+	reg, _ := ctx.Value(types.RegistryScope).(*models.Registry)
+
+	request := &types.GetRegistryRetentionReportRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "registry-id", Value: reg.ID},
+		telemetry.AttributeKV{Key: "repo-name", Value: request.RepoName},
+	)
+
+	policy, err := p.Repo().RegistryRetention().ReadRetentionPolicy(ctx, reg.ProjectID, reg.ID, request.RepoName)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = telemetry.Error(ctx, span, err, "repository has no retention policy")
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, err, "error reading retention policy")
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	refs, err := gc.CollectReferences(ctx, p.Config(), reg.ProjectID)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error collecting image references")
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	report, err := gc.Evaluate(ctx, p.Config(), reg, policy, refs)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error evaluating retention policy")
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, report)
+}
@@ -0,0 +1,187 @@
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"github.com/porter-dev/porter/provisioner/integrations/storage"
+	"github.com/stefanmcshane/helm/pkg/release"
+	"github.com/stefanmcshane/helm/pkg/storage/driver"
+)
+
+// errRevisionsStorageDisabled is returned when the instance has no storage backend for archived revisions
+var errRevisionsStorageDisabled = errors.New("archived release revisions are not available on this Porter instance")
+
+// ListArchivedReleaseRevisionsHandler lists the revisions of a release which were archived by the helm revisions tracker
+type ListArchivedReleaseRevisionsHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewListArchivedReleaseRevisionsHandler returns a new ListArchivedReleaseRevisionsHandler
+func NewListArchivedReleaseRevisionsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListArchivedReleaseRevisionsHandler {
+	return &ListArchivedReleaseRevisionsHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ListArchivedReleaseRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-archived-release-revisions")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+	namespace, _ := ctx.Value(types.NamespaceScope).(string)
+
+	if c.Config().RevisionsStorage == nil {
+		err := telemetry.Error(ctx, span, errRevisionsStorageDisabled, "revisions storage is not configured")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	name, reqErr := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing release name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "release-name", Value: name},
+		telemetry.AttributeKV{Key: "namespace", Value: namespace},
+	)
+
+	keys, err := c.Config().RevisionsStorage.ListFilesWithPrefix(
+		helm.ArchivedRevisionsPrefix(cluster.ProjectID, cluster.ID, namespace, name),
+	)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing archived revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, &types.ListArchivedReleaseRevisionsResponse{
+		Revisions: helm.ArchivedRevisionVersions(cluster.ProjectID, cluster.ID, namespace, name, keys),
+	})
+}
+
+// RestoreArchivedReleaseRevisionHandler restores an archived revision of a release into the helm storage of the cluster
+type RestoreArchivedReleaseRevisionHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewRestoreArchivedReleaseRevisionHandler returns a new RestoreArchivedReleaseRevisionHandler
+func NewRestoreArchivedReleaseRevisionHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RestoreArchivedReleaseRevisionHandler {
+	return &RestoreArchivedReleaseRevisionHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *RestoreArchivedReleaseRevisionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-restore-archived-release-revision")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+	namespace, _ := ctx.Value(types.NamespaceScope).(string)
+
+	if c.Config().RevisionsStorage == nil {
+		err := telemetry.Error(ctx, span, errRevisionsStorageDisabled, "revisions storage is not configured")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	name, reqErr := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing release name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &types.RestoreArchivedReleaseRevisionRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "release-name", Value: name},
+		telemetry.AttributeKV{Key: "namespace", Value: namespace},
+		telemetry.AttributeKV{Key: "revision", Value: request.Revision},
+	)
+
+	data, err := c.Config().RevisionsStorage.ReadFileWithKey(
+		helm.ArchivedRevisionKey(cluster.ProjectID, cluster.ID, namespace, name, request.Revision),
+		true,
+	)
+	if err != nil {
+		if errors.Is(err, storage.FileDoesNotExist) {
+			err = telemetry.Error(ctx, span, err, "archived revision not found")
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("revision %d of release %s is not archived", request.Revision, name)))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, err, "error reading archived revision")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	rel := &release.Release{}
+
+	err = json.Unmarshal(data, rel)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error unmarshalling archived revision")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if rel.Name != name || rel.Namespace != namespace || rel.Version != request.Revision {
+		err = telemetry.Error(ctx, span, nil, "archived revision does not match its key")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(ctx, r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error getting helm agent")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	err = helmAgent.RestoreReleaseRevision(ctx, rel)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseExists) {
+			err = telemetry.Error(ctx, span, err, "revision already exists in cluster")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("revision %d of release %s already exists in the cluster", request.Revision, name),
+				http.StatusConflict,
+			))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, err, "error restoring archived revision")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, nil)
+}
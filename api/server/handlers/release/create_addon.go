@@ -156,6 +156,15 @@ func LoadChart(ctx context.Context, config *config.Config, opts *LoadAddonChartO
 
 		for _, hr := range hrs {
 			if hr.RepoURL == opts.RepoURL {
+				if loader.IsOCIRepoURL(hr.RepoURL) {
+					ociClient, err := GetOCIClient(ctx, config, hr)
+					if err != nil {
+						return nil, err
+					}
+
+					return loader.LoadOCIChart(ctx, ociClient, hr.RepoURL, opts.TemplateName, opts.TemplateVersion)
+				}
+
 				if hr.BasicAuthIntegrationID != 0 {
 					// read the basic integration id
 					basic, err := config.Repo.BasicIntegration().ReadBasicIntegration(opts.ProjectID, hr.BasicAuthIntegrationID)
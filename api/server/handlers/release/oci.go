@@ -0,0 +1,131 @@
+package release
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// GetOCIClient returns a client for an OCI helm repo of a project. Repos with a basic auth integration use its
+// credentials, other repos use the credentials of the project's registry with the same host as the repo. Repos
+// without a matching registry are accessed anonymously.
+func GetOCIClient(ctx context.Context, config *config.Config, hr *models.HelmRepo) (*loader.OCIClient, error) {
+	ctx, span := telemetry.NewSpan(ctx, "get-oci-client")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: hr.ProjectID},
+		telemetry.AttributeKV{Key: "helm-repo-url", Value: hr.RepoURL},
+	)
+
+	if hr.BasicAuthIntegrationID != 0 {
+		basic, err := config.Repo.BasicIntegration().ReadBasicIntegration(hr.ProjectID, hr.BasicAuthIntegrationID)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error reading basic integration")
+		}
+
+		return loader.NewOCIClientFromBasicAuth(&loader.BasicAuthClient{
+			Username: string(basic.Username),
+			Password: string(basic.Password),
+		}, hr.RepoURL)
+	}
+
+	reg, err := getOCIRepoRegistry(config, hr)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error finding registry of oci repo")
+	}
+
+	if reg == nil {
+		return &loader.OCIClient{}, nil
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "registry-id", Value: reg.ID})
+
+	dockerConfigJSON, err := reg.GetDockerConfigJSON(config.Repo, config.DOConf)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error getting registry credentials")
+	}
+
+	return &loader.OCIClient{DockerConfigJSON: dockerConfigJSON}, nil
+}
+
+// ListOCIChartNames lists the names of the charts in an OCI helm repo of a project. OCI registries cannot list
+// their contents, so the charts are the repositories under the path of the helm repo in the project's registry with
+// the same host as the helm repo.
+func ListOCIChartNames(ctx context.Context, config *config.Config, hr *models.HelmRepo) ([]string, error) {
+	ctx, span := telemetry.NewSpan(ctx, "list-oci-chart-names")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: hr.ProjectID},
+		telemetry.AttributeKV{Key: "helm-repo-url", Value: hr.RepoURL},
+	)
+
+	reg, err := getOCIRepoRegistry(config, hr)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error finding registry of oci repo")
+	}
+
+	if reg == nil {
+		return nil, telemetry.Error(ctx, span, nil, fmt.Sprintf("no registry in the project has the host %s, so the charts of the repo cannot be listed", loader.OCIRepoHost(hr.RepoURL)))
+	}
+
+	repos, err := reg.ListRepositories(ctx, config.Repo, config)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing registry repositories")
+	}
+
+	prefix := loader.OCIRepoPath(hr.RepoURL) + "/"
+	names := make([]string, 0)
+
+	for _, repo := range repos {
+		uri := strings.TrimPrefix(strings.TrimPrefix(repo.URI, "https://"), "http://")
+
+		if strings.HasPrefix(uri, prefix) {
+			names = append(names, strings.TrimPrefix(uri, prefix))
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// getOCIRepoRegistry returns the registry of the project with the same host as an OCI helm repo, or nil if there is
+// no such registry
+func getOCIRepoRegistry(config *config.Config, hr *models.HelmRepo) (*registry.Registry, error) {
+	regs, err := config.Repo.Registry().ListRegistriesByProjectID(hr.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	host := loader.OCIRepoHost(hr.RepoURL)
+
+	for _, reg := range regs {
+		regURL := reg.URL
+
+		if !strings.Contains(regURL, "://") {
+			regURL = "https://" + regURL
+		}
+
+		parsedURL, err := url.Parse(regURL)
+		if err != nil {
+			continue
+		}
+
+		if strings.EqualFold(parsedURL.Host, host) {
+			_reg := registry.Registry(*reg)
+			return &_reg, nil
+		}
+	}
+
+	return nil, nil
+}
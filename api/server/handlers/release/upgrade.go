@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	semver "github.com/Masterminds/semver/v3"
 
@@ -15,9 +16,10 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/helm/upgrade"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/notifier"
-	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/routing"
 	"github.com/porter-dev/porter/internal/stacks"
 	"github.com/stefanmcshane/helm/pkg/release"
 )
@@ -113,6 +115,44 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		}
 
 		conf.Chart = chart
+
+		// the value migrations of the upgrade notes between the current and the new chart version are applied by
+		// the upgrade, and notes which require acknowledgement block the upgrade until they are acknowledged
+		upgradeFile, err := upgrade.GetUpgradeFileFromChart(chart)
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("error parsing upgrade notes of chart version %s: %w", request.ChartVersion, err),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		conf.UpgradeNotes, err = upgradeFile.GetUpgradeFileBetweenVersions(helmRelease.Chart.Metadata.Version, request.ChartVersion)
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("error getting upgrade notes: %w", err),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		if notes := conf.UpgradeNotes.GetNotesRequiringAcknowledgement(); len(notes) != 0 && !request.DryRun && !request.AcknowledgeUpgradeNotes {
+			noteTexts := make([]string, 0, len(notes))
+
+			for _, note := range notes {
+				noteTexts = append(noteTexts, fmt.Sprintf("%s -> %s: %s", note.PreviousVersion, note.TargetVersion, note.Note))
+			}
+
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("upgrading to chart version %s requires acknowledging the following upgrade notes:\n%s",
+					request.ChartVersion, strings.Join(noteTexts, "\n")),
+				http.StatusPreconditionFailed,
+			))
+
+			return
+		}
 	}
 
 	// if LatestRevision is set, check that the revision matches the latest revision in the database
@@ -154,6 +194,27 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	// a dry run only renders the upgrade, so there is nothing to notify about or sync afterwards
+	if request.DryRun {
+		diff, err := helmAgent.DiffUpgradeRelease(r.Context(), conf, request.Values, c.Config().DOConf,
+			c.Config().ServerConf.DisablePullSecretsInjection, request.IgnoreDependencies)
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("error running upgrade dry run: %w", err),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		if conf.UpgradeNotes != nil {
+			diff.UpgradeNotes = conf.UpgradeNotes.UpgradeNotes
+		}
+
+		c.WriteResult(w, r, diff)
+		return
+	}
+
 	newHelmRelease, upgradeErr := helmAgent.UpgradeRelease(context.Background(), conf, request.Values, c.Config().DOConf,
 		c.Config().ServerConf.DisablePullSecretsInjection, request.IgnoreDependencies)
 
@@ -161,8 +222,6 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		helmRelease = newHelmRelease
 	}
 
-	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
-
 	rel, releaseErr := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
 
 	var notifConf *types.NotificationConfig
@@ -177,7 +236,7 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		notifConf = conf.ToNotificationConfigType()
 	}
 
-	deplNotifier := slack.NewDeploymentNotifier(notifConf, slackInts...)
+	deplNotifier := routing.NewDeploymentNotifier(r.Context(), c.Repo(), notifConf)
 
 	notifyOpts := &notifier.NotifyOpts{
 		ProjectID:   cluster.ProjectID,
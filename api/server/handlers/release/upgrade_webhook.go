@@ -15,7 +15,7 @@ import (
 	"github.com/porter-dev/porter/internal/analytics"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/notifier"
-	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/routing"
 	"github.com/porter-dev/porter/internal/telemetry"
 	"gorm.io/gorm"
 )
@@ -151,8 +151,6 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Values:     rel.Config,
 	}
 
-	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(release.ProjectID)
-
 	var notifConf *types.NotificationConfig
 	notifConf = nil
 	if release.NotificationConfig != 0 {
@@ -166,7 +164,7 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		notifConf = conf.ToNotificationConfigType()
 	}
 
-	deplNotifier := slack.NewDeploymentNotifier(notifConf, slackInts...)
+	deplNotifier := routing.NewDeploymentNotifier(ctx, c.Repo(), notifConf)
 
 	notifyOpts := &notifier.NotifyOpts{
 		ProjectID:   release.ProjectID,
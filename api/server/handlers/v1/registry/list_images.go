@@ -144,5 +144,10 @@ func (c *RegistryListImagesHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		res.Images = append(res.Images, imgs...)
 	}
 
+	if err := regAPI.AttachVulnerabilities(ctx, repoName, res.Images, c.Repo()); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
 	c.WriteResult(w, r, res)
 }
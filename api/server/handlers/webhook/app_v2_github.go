@@ -178,6 +178,19 @@ func (c *GithubWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 			return
 		}
 
+		records, err := c.Repo().DNSRecord().ListDNSRecordsByDeploymentTargetID(deploymentTarget.ID)
+		if err == nil {
+			err = porter_app.DeletePorterSubdomains(ctx, porter_app.DeletePorterSubdomainsInput{
+				Records:             records,
+				DNSClient:           c.Config().DNSClient,
+				DNSRecordRepository: c.Repo().DNSRecord(),
+			})
+		}
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error deleting preview environment subdomains")
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		}
+
 		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "event-processed", Value: true})
 		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "pr-id", Value: event.GetPullRequest().GetID()})
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers/gitinstallation"
 	"github.com/porter-dev/porter/api/server/handlers/healthcheck"
 	"github.com/porter-dev/porter/api/server/handlers/metadata"
+	"github.com/porter-dev/porter/api/server/handlers/porter_app"
 	"github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/handlers/user"
 	"github.com/porter-dev/porter/api/server/handlers/webhook"
@@ -104,6 +105,29 @@ func GetBaseRoutes(
 		Router:   r,
 	})
 
+	// GET /api/porter-yaml/schema -> porter_app.NewPorterYAMLSchemaHandler
+	getPorterYAMLSchemaEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/porter-yaml/schema",
+			},
+		},
+	)
+
+	getPorterYAMLSchemaHandler := porter_app.NewPorterYAMLSchemaHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getPorterYAMLSchemaEndpoint,
+		Handler:  getPorterYAMLSchemaHandler,
+		Router:   r,
+	})
+
 	// GET /api/integrations/cluster -> metadata.NewListClusterIntegrationsHandler
 	listClusterIntsEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -114,6 +114,198 @@ func getNotificationRoutes(
 		Router:   r,
 	})
 
+	// GET /api/projects/{project_id}/notifications/rules -> notifications.NewListNotificationRulesHandler
+	listNotificationRulesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/rules",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listNotificationRulesHandler := notifications.NewListNotificationRulesHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listNotificationRulesEndpoint,
+		Handler:  listNotificationRulesHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/notifications/rules -> notifications.NewCreateNotificationRuleHandler
+	createNotificationRuleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/rules",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	createNotificationRuleHandler := notifications.NewCreateNotificationRuleHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: createNotificationRuleEndpoint,
+		Handler:  createNotificationRuleHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/notifications/digest -> notifications.NewGetNotificationDigestHandler
+	getNotificationDigestEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/digest",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	getNotificationDigestHandler := notifications.NewGetNotificationDigestHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getNotificationDigestEndpoint,
+		Handler:  getNotificationDigestHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/notifications/digest -> notifications.NewUpdateNotificationDigestHandler
+	updateNotificationDigestEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/digest",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	updateNotificationDigestHandler := notifications.NewUpdateNotificationDigestHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateNotificationDigestEndpoint,
+		Handler:  updateNotificationDigestHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/notifications/rules/route -> notifications.NewRouteNotificationHandler
+	routeNotificationEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/rules/route",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	routeNotificationHandler := notifications.NewRouteNotificationHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: routeNotificationEndpoint,
+		Handler:  routeNotificationHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/notifications/rules/{notification_rule_id} -> notifications.NewUpdateNotificationRuleHandler
+	updateNotificationRuleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/rules/{%s}", relPath, types.URLParamNotificationRuleID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	updateNotificationRuleHandler := notifications.NewUpdateNotificationRuleHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateNotificationRuleEndpoint,
+		Handler:  updateNotificationRuleHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/notifications/rules/{notification_rule_id} -> notifications.NewDeleteNotificationRuleHandler
+	deleteNotificationRuleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/rules/{%s}", relPath, types.URLParamNotificationRuleID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	deleteNotificationRuleHandler := notifications.NewDeleteNotificationRuleHandler(
+		config,
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: deleteNotificationRuleEndpoint,
+		Handler:  deleteNotificationRuleHandler,
+		Router:   r,
+	})
+
 	// GET /api/projects/{project_id}/notifications/{notification_id} -> notifications.NewNotificationConfigHandler
 	notificationEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
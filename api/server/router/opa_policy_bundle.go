@@ -0,0 +1,253 @@
+package router
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/porter-dev/porter/api/server/handlers/opa_policy_bundle"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/router"
+	"github.com/porter-dev/porter/api/types"
+)
+
+// NewOPAPolicyBundleScopedRegisterer is a registerer for all /opa-policy-bundles routes
+func NewOPAPolicyBundleScopedRegisterer(children ...*router.Registerer) *router.Registerer {
+	return &router.Registerer{
+		GetRoutes: GetOPAPolicyBundleScopedRoutes,
+		Children:  children,
+	}
+}
+
+// GetOPAPolicyBundleScopedRoutes returns all /opa-policy-bundles routes
+func GetOPAPolicyBundleScopedRoutes(
+	r chi.Router,
+	config *config.Config,
+	basePath *types.Path,
+	factory shared.APIEndpointFactory,
+	children ...*router.Registerer,
+) []*router.Route {
+	routes, projPath := getOPAPolicyBundleRoutes(r, config, basePath, factory)
+
+	if len(children) > 0 {
+		r.Route(projPath.RelativePath, func(r chi.Router) {
+			for _, child := range children {
+				childRoutes := child.GetRoutes(r, config, basePath, factory, child.Children...)
+
+				routes = append(routes, childRoutes...)
+			}
+		})
+	}
+
+	return routes
+}
+
+func getOPAPolicyBundleRoutes(
+	r chi.Router,
+	config *config.Config,
+	basePath *types.Path,
+	factory shared.APIEndpointFactory,
+) ([]*router.Route, *types.Path) {
+	relPath := "/opa-policy-bundles"
+
+	newPath := &types.Path{
+		Parent:       basePath,
+		RelativePath: relPath,
+	}
+
+	routes := make([]*router.Route, 0)
+
+	// POST /api/projects/{project_id}/opa-policy-bundles -> opa_policy_bundle.NewCreateOPAPolicyBundleHandler
+	createOPAPolicyBundleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath,
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	createOPAPolicyBundleHandler := opa_policy_bundle.NewCreateOPAPolicyBundleHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: createOPAPolicyBundleEndpoint,
+		Handler:  createOPAPolicyBundleHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/opa-policy-bundles -> opa_policy_bundle.NewListOPAPolicyBundlesHandler
+	listOPAPolicyBundlesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath,
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listOPAPolicyBundlesHandler := opa_policy_bundle.NewListOPAPolicyBundlesHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listOPAPolicyBundlesEndpoint,
+		Handler:  listOPAPolicyBundlesHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/opa-policy-bundles/{opa_policy_bundle_name} -> opa_policy_bundle.NewGetOPAPolicyBundleHandler
+	getOPAPolicyBundleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/{%s}", relPath, types.URLParamOPAPolicyBundleName),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	getOPAPolicyBundleHandler := opa_policy_bundle.NewGetOPAPolicyBundleHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getOPAPolicyBundleEndpoint,
+		Handler:  getOPAPolicyBundleHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/opa-policy-bundles/{opa_policy_bundle_name}/versions -> opa_policy_bundle.NewListOPAPolicyBundleVersionsHandler
+	listOPAPolicyBundleVersionsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/{%s}/versions", relPath, types.URLParamOPAPolicyBundleName),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listOPAPolicyBundleVersionsHandler := opa_policy_bundle.NewListOPAPolicyBundleVersionsHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listOPAPolicyBundleVersionsEndpoint,
+		Handler:  listOPAPolicyBundleVersionsHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/opa-policy-bundles/{opa_policy_bundle_name} -> opa_policy_bundle.NewDeleteOPAPolicyBundleHandler
+	deleteOPAPolicyBundleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/{%s}", relPath, types.URLParamOPAPolicyBundleName),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	deleteOPAPolicyBundleHandler := opa_policy_bundle.NewDeleteOPAPolicyBundleHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: deleteOPAPolicyBundleEndpoint,
+		Handler:  deleteOPAPolicyBundleHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/opa-policy-overrides -> opa_policy_bundle.NewListOPAPolicyOverridesHandler
+	listOPAPolicyOverridesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/opa-policy-overrides",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	listOPAPolicyOverridesHandler := opa_policy_bundle.NewListOPAPolicyOverridesHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listOPAPolicyOverridesEndpoint,
+		Handler:  listOPAPolicyOverridesHandler,
+		Router:   r,
+	})
+
+	// PUT /api/projects/{project_id}/opa-policy-overrides -> opa_policy_bundle.NewUpdateOPAPolicyOverridesHandler
+	updateOPAPolicyOverridesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPut,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/opa-policy-overrides",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	updateOPAPolicyOverridesHandler := opa_policy_bundle.NewUpdateOPAPolicyOverridesHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateOPAPolicyOverridesEndpoint,
+		Handler:  updateOPAPolicyOverridesHandler,
+		Router:   r,
+	})
+
+	return routes, newPath
+}
@@ -1740,5 +1740,115 @@ func getProjectRoutes(
 		Router:   r,
 	})
 
+	// GET /api/projects/{project_id}/image-scan-policy -> project.NewGetImageScanPolicyHandler
+	getImageScanPolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/image-scan-policy",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	getImageScanPolicyHandler := project.NewGetImageScanPolicyHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getImageScanPolicyEndpoint,
+		Handler:  getImageScanPolicyHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/image-scan-policy -> project.NewUpdateImageScanPolicyHandler
+	updateImageScanPolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/image-scan-policy",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	updateImageScanPolicyHandler := project.NewUpdateImageScanPolicyHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateImageScanPolicyEndpoint,
+		Handler:  updateImageScanPolicyHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/env-group-retention-policy -> project.NewGetEnvGroupRetentionPolicyHandler
+	getEnvGroupRetentionPolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/env-group-retention-policy",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	getEnvGroupRetentionPolicyHandler := project.NewGetEnvGroupRetentionPolicyHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getEnvGroupRetentionPolicyEndpoint,
+		Handler:  getEnvGroupRetentionPolicyHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/env-group-retention-policy -> project.NewUpdateEnvGroupRetentionPolicyHandler
+	updateEnvGroupRetentionPolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/env-group-retention-policy",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+		},
+	)
+
+	updateEnvGroupRetentionPolicyHandler := project.NewUpdateEnvGroupRetentionPolicyHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateEnvGroupRetentionPolicyEndpoint,
+		Handler:  updateEnvGroupRetentionPolicyHandler,
+		Router:   r,
+	})
+
 	return routes, newPath
 }
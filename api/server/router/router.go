@@ -46,7 +46,9 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 	projectIntegrationRegisterer := NewProjectIntegrationScopedRegisterer()
 	projectOAuthRegisterer := NewProjectOAuthScopedRegisterer()
 	notificationRegisterer := NewNotificationScopedRegisterer()
+	opaPolicyBundleRegisterer := NewOPAPolicyBundleScopedRegisterer()
 	slackIntegrationRegisterer := NewSlackIntegrationScopedRegisterer()
+	notificationWebhookIntegrationRegisterer := NewNotificationWebhookIntegrationScopedRegisterer()
 	projRegisterer := NewProjectScopedRegisterer(
 		cloudProviderRegisterer,
 		clusterRegisterer,
@@ -58,8 +60,10 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 		projectIntegrationRegisterer,
 		projectOAuthRegisterer,
 		slackIntegrationRegisterer,
+		notificationWebhookIntegrationRegisterer,
 		deploymentTargetRegisterer,
 		notificationRegisterer,
+		opaPolicyBundleRegisterer,
 	)
 	statusRegisterer := NewStatusScopedRegisterer()
 
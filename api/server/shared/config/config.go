@@ -20,6 +20,7 @@ import (
 	"github.com/porter-dev/porter/internal/telemetry"
 	"github.com/porter-dev/porter/pkg/logger"
 	"github.com/porter-dev/porter/provisioner/client"
+	"github.com/porter-dev/porter/provisioner/integrations/storage"
 	"golang.org/x/oauth2"
 	"gorm.io/gorm"
 )
@@ -100,6 +101,10 @@ type Config struct {
 	// DNSClient is a client for DNS, if the Porter instance supports vanity URLs
 	DNSClient *dns.Client
 
+	// RevisionsStorage is the storage backend of archived helm release revisions, if the Porter instance
+	// supports restoring them
+	RevisionsStorage storage.KeyStorageManager
+
 	// ClusterControlPlaneClient is a client for ClusterControlPlane
 	ClusterControlPlaneClient porterv1connect.ClusterControlPlaneServiceClient
 
@@ -69,6 +69,10 @@ type ServerConf struct {
 	SendgridDeleteProjectTemplateID    string `env:"SENDGRID_DELETE_PROJECT_TEMPLATE_ID"`
 	SendgridSenderEmail                string `env:"SENDGRID_SENDER_EMAIL"`
 
+	// NotificationDedupWindow is the window in which repeats of an app notification are collapsed into a
+	// single message. Setting it to zero disables collapsing.
+	NotificationDedupWindow time.Duration `env:"NOTIFICATION_DEDUP_WINDOW,default=10m"`
+
 	StripeSecretKey      string `env:"STRIPE_SECRET_KEY"`
 	StripePublishableKey string `env:"STRIPE_PUBLISHABLE_KEY"`
 	SlackClientID        string `env:"SLACK_CLIENT_ID"`
@@ -91,7 +95,7 @@ type ServerConf struct {
 
 	SegmentClientKey string `env:"SEGMENT_CLIENT_KEY"`
 
-	// DnsProvider controls which provider to use for dns (powerdns or cloudflare)
+	// DnsProvider controls which provider to use for dns (powerdns, cloudflare, route53 or clouddns)
 	// Setting this to empty string will disable external dns
 	DnsProvider string `env:"DNS_PROVIDER,default=powerdns"`
 
@@ -102,6 +106,15 @@ type ServerConf struct {
 	PowerDNSAPIServerURL string `env:"POWER_DNS_API_SERVER_URL"`
 	PowerDNSAPIKey       string `env:"POWER_DNS_API_KEY"`
 
+	// Route53 credentials for the hosted zone of the app root domain. If empty, credentials are read from the environment
+	Route53AWSAccessKeyID     string `env:"ROUTE53_AWS_ACCESS_KEY_ID"`
+	Route53AWSSecretAccessKey string `env:"ROUTE53_AWS_SECRET_ACCESS_KEY"`
+
+	// Google Cloud DNS project and service account credentials for the managed zone of the app root domain. If the
+	// credentials are empty, application default credentials are used
+	CloudDNSProjectID       string `env:"CLOUD_DNS_PROJECT_ID"`
+	CloudDNSCredentialsJSON string `env:"CLOUD_DNS_CREDENTIALS_JSON"`
+
 	// Email for an admin user. On a self-hosted instance of Porter, the
 	// admin user is the only user that can log in and register. After the admin
 	// user has logged in, registration is turned off.
@@ -154,6 +167,39 @@ type ServerConf struct {
 	TelemetryName string `env:"TELEMETRY_NAME"`
 	// TelemetryCollectorURL is the URL (host:port) for collecting spans
 	TelemetryCollectorURL string `env:"TELEMETRY_COLLECTOR_URL,default=localhost:4317"`
+
+	// RevisionsStorage is the storage backend of the helm release revisions archived by the workers, from which
+	// archived revisions are restored
+	RevisionsStorage RevisionsStorageConf
+}
+
+// RevisionsStorageConf configures the storage backend that pruned helm release revisions are archived to
+type RevisionsStorageConf struct {
+	// Backend is the storage backend for archived revisions (s3, gcs, azblob or local)
+	Backend string `env:"REVISIONS_STORAGE_BACKEND,default=s3"`
+
+	// EncryptionKey is the key archived revisions are encrypted with, for every backend
+	EncryptionKey string `env:"S3_ENCRYPTION_KEY"`
+
+	// S3 bucket and credentials
+	AWSAccessKeyID     string `env:"AWS_ACCESS_KEY_ID"`
+	AWSSecretAccessKey string `env:"AWS_SECRET_ACCESS_KEY"`
+	AWSRegion          string `env:"AWS_REGION"`
+	S3BucketName       string `env:"S3_BUCKET_NAME"`
+
+	// GCS bucket and service account credentials. If the credentials are empty, application default credentials are used
+	GCSBucketName      string `env:"GCS_BUCKET_NAME"`
+	GCSCredentialsJSON string `env:"GCS_CREDENTIALS_JSON"`
+
+	// Azure storage container and the credentials of a service principal with access to it
+	AzureStorageAccountName   string `env:"AZURE_STORAGE_ACCOUNT_NAME"`
+	AzureStorageContainerName string `env:"AZURE_STORAGE_CONTAINER_NAME"`
+	AzureTenantID             string `env:"AZURE_TENANT_ID"`
+	AzureClientID             string `env:"AZURE_CLIENT_ID"`
+	AzureClientSecret         string `env:"AZURE_CLIENT_SECRET"`
+
+	// LocalDir is the directory archived revisions are written to by the local backend
+	LocalDir string `env:"REVISIONS_STORAGE_DIR"`
 }
 
 // DBConf is the database configuration: if generated from environment variables,
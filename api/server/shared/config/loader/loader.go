@@ -25,9 +25,11 @@ import (
 	"github.com/porter-dev/porter/internal/billing"
 	"github.com/porter-dev/porter/internal/features"
 	"github.com/porter-dev/porter/internal/helm/urlcache"
+	"github.com/porter-dev/porter/internal/integrations/clouddns"
 	"github.com/porter-dev/porter/internal/integrations/cloudflare"
 	"github.com/porter-dev/porter/internal/integrations/dns"
 	"github.com/porter-dev/porter/internal/integrations/powerdns"
+	"github.com/porter-dev/porter/internal/integrations/route53"
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/notifier/sendgrid"
 	"github.com/porter-dev/porter/internal/oauth"
@@ -36,6 +38,7 @@ import (
 	"github.com/porter-dev/porter/internal/telemetry"
 	lr "github.com/porter-dev/porter/pkg/logger"
 	"github.com/porter-dev/porter/provisioner/client"
+	"github.com/porter-dev/porter/provisioner/integrations/storage/backend"
 	pgorm "gorm.io/gorm"
 )
 
@@ -324,6 +327,32 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 
 			res.DNSClient = &dns.Client{Client: cloudflareClient}
 		}
+	case "route53":
+		route53Client, err := route53.NewClient(sc.Route53AWSAccessKeyID, sc.Route53AWSSecretAccessKey, sc.AppRootDomain)
+		if err != nil {
+			return res, fmt.Errorf("unable to create route53 client: %w", err)
+		}
+
+		res.DNSClient = &dns.Client{Client: route53Client}
+	case "clouddns":
+		if sc.CloudDNSProjectID != "" {
+			cloudDNSClient, err := clouddns.NewClient(sc.CloudDNSProjectID, []byte(sc.CloudDNSCredentialsJSON), sc.AppRootDomain)
+			if err != nil {
+				return res, fmt.Errorf("unable to create cloud dns client: %w", err)
+			}
+
+			res.DNSClient = &dns.Client{Client: cloudDNSClient}
+		}
+	}
+
+	// archived revisions can only be read with the key they were encrypted with by the workers
+	if sc.RevisionsStorage.EncryptionKey != "" {
+		revisionsStorage, err := backend.NewRevisionsStorageManager(&sc.RevisionsStorage)
+		if err != nil {
+			return res, fmt.Errorf("unable to create %s revisions storage client: %w", sc.RevisionsStorage.Backend, err)
+		}
+
+		res.RevisionsStorage = revisionsStorage
 	}
 
 	res.EnableCAPIProvisioner = sc.EnableCAPIProvisioner
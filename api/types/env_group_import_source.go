@@ -0,0 +1,82 @@
+package types
+
+import "time"
+
+// EnvGroupImportProvider is the external secret manager an environment group imports its variables from
+type EnvGroupImportProvider string
+
+const (
+	// EnvGroupImportProvider_AWSSecretsManager imports the key/value pairs of an AWS Secrets Manager secret
+	EnvGroupImportProvider_AWSSecretsManager EnvGroupImportProvider = "aws_secrets_manager"
+	// EnvGroupImportProvider_GCPSecretManager imports the key/value pairs of the latest version of a GCP Secret Manager secret
+	EnvGroupImportProvider_GCPSecretManager EnvGroupImportProvider = "gcp_secret_manager"
+	// EnvGroupImportProvider_Vault imports the key/value pairs of a HashiCorp Vault KV secret
+	EnvGroupImportProvider_Vault EnvGroupImportProvider = "vault"
+)
+
+// EnvGroupImportSource is an external secret whose key/value pairs are synced into an environment group, either on
+// demand or on a schedule
+type EnvGroupImportSource struct {
+	ID           uint                   `json:"id"`
+	EnvGroupName string                 `json:"env_group_name"`
+	Provider     EnvGroupImportProvider `json:"provider"`
+
+	// Path identifies the secret: the name or ARN of an AWS secret, the name or resource name of a GCP secret, or the
+	// API path of a Vault secret such as secret/data/my-app
+	Path string `json:"path"`
+
+	AWSIntegrationID uint   `json:"aws_integration_id,omitempty"`
+	GCPIntegrationID uint   `json:"gcp_integration_id,omitempty"`
+	VaultAddress     string `json:"vault_address,omitempty"`
+
+	// AsSecrets imports the values as secret variables of the environment group, instead of variables
+	AsSecrets bool `json:"as_secrets"`
+	// Prune removes the variables of the environment group which are not part of the secret
+	Prune bool `json:"prune"`
+	// SkipRedeploys skips re-deploying the apps linked to the environment group when a sync changes it
+	SkipRedeploys bool `json:"skip_redeploys"`
+	// SyncIntervalMinutes is the interval between scheduled syncs. Sources with an interval of 0 are only synced on demand.
+	SyncIntervalMinutes uint `json:"sync_interval_minutes"`
+
+	LastSyncedAt      *time.Time `json:"last_synced_at,omitempty"`
+	LastSyncError     string     `json:"last_sync_error,omitempty"`
+	LastSyncedVersion int        `json:"last_synced_version,omitempty"`
+}
+
+// UpdateEnvGroupImportSourceRequest creates or updates the import source of an environment group
+type UpdateEnvGroupImportSourceRequest struct {
+	Provider EnvGroupImportProvider `json:"provider" form:"required,oneof=aws_secrets_manager gcp_secret_manager vault"`
+	Path     string                 `json:"path" form:"required"`
+
+	// AWSIntegrationID is required for the aws_secrets_manager provider
+	AWSIntegrationID uint `json:"aws_integration_id"`
+	// GCPIntegrationID is required for the gcp_secret_manager provider
+	GCPIntegrationID uint `json:"gcp_integration_id"`
+	// VaultAddress is required for the vault provider
+	VaultAddress string `json:"vault_address" form:"omitempty,url"`
+	// VaultToken is required when creating a source with the vault provider. The existing token is kept if it is empty
+	// when updating a source.
+	VaultToken string `json:"vault_token"`
+
+	AsSecrets           bool `json:"as_secrets"`
+	Prune               bool `json:"prune"`
+	SkipRedeploys       bool `json:"skip_redeploys"`
+	SyncIntervalMinutes uint `json:"sync_interval_minutes" form:"omitempty,min=5"`
+}
+
+// EnvGroupImportSyncResult is the result of syncing the import source of an environment group. Only the keys of
+// the variables are returned, since imported values are usually secret.
+type EnvGroupImportSyncResult struct {
+	EnvGroupName string `json:"env_group_name"`
+
+	// Changed is whether the sync created a new version of the environment group
+	Changed bool `json:"changed"`
+	// Version is the latest version of the environment group after the sync
+	Version int `json:"version"`
+
+	Added   []string `json:"added"`
+	Updated []string `json:"updated"`
+	Removed []string `json:"removed"`
+
+	SyncedAt time.Time `json:"synced_at"`
+}
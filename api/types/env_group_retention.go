@@ -0,0 +1,27 @@
+package types
+
+import "time"
+
+// DefaultEnvGroupRetentionKeepLastRevisions is the number of app revisions whose environment group versions are kept
+// by projects which have not configured a retention policy
+const DefaultEnvGroupRetentionKeepLastRevisions = 10
+
+// EnvGroupRetentionPolicy is the retention policy of the versioned copies of environment groups in the namespaces of
+// the apps of a project. Copies which are not referenced by a running Deployment, CronJob or Job, or by one of the
+// last KeepLastRevisions revisions of an app, are deleted by the environment group garbage collector.
+type EnvGroupRetentionPolicy struct {
+	// KeepLastRevisions is the number of most recent revisions of every app whose environment group versions are kept
+	KeepLastRevisions uint `json:"keep_last_revisions"`
+
+	// Enabled is whether the garbage collector deletes the copies which are not kept by the policy
+	Enabled bool `json:"enabled"`
+
+	LastRunAt        *time.Time `json:"last_run_at,omitempty"`
+	LastDeletedCount uint       `json:"last_deleted_count"`
+}
+
+// UpdateEnvGroupRetentionPolicyRequest creates or updates the environment group retention policy of a project
+type UpdateEnvGroupRetentionPolicyRequest struct {
+	KeepLastRevisions uint `json:"keep_last_revisions" form:"required,min=1"`
+	Enabled           bool `json:"enabled"`
+}
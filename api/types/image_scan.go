@@ -0,0 +1,111 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// VulnerabilitySeverity is the severity of a vulnerability found in an image
+type VulnerabilitySeverity string
+
+const (
+	// VulnerabilitySeverityCritical is the severity of critical vulnerabilities
+	VulnerabilitySeverityCritical VulnerabilitySeverity = "CRITICAL"
+	// VulnerabilitySeverityHigh is the severity of high vulnerabilities
+	VulnerabilitySeverityHigh VulnerabilitySeverity = "HIGH"
+	// VulnerabilitySeverityMedium is the severity of medium vulnerabilities
+	VulnerabilitySeverityMedium VulnerabilitySeverity = "MEDIUM"
+	// VulnerabilitySeverityLow is the severity of low vulnerabilities
+	VulnerabilitySeverityLow VulnerabilitySeverity = "LOW"
+	// VulnerabilitySeverityUnknown is the severity of vulnerabilities which have not been rated
+	VulnerabilitySeverityUnknown VulnerabilitySeverity = "UNKNOWN"
+)
+
+// ImageScanFormat is the source of the findings of an image scan
+type ImageScanFormat string
+
+const (
+	// ImageScanFormatTrivy is an uploaded report in the JSON format of Trivy
+	ImageScanFormatTrivy ImageScanFormat = "trivy"
+	// ImageScanFormatECR imports the findings of the native image scanning of ECR
+	ImageScanFormatECR ImageScanFormat = "ecr"
+)
+
+// VulnerabilitySummary is the number of vulnerabilities found in an image by severity
+type VulnerabilitySummary struct {
+	// Scanner is the name of the scanner which found the vulnerabilities, like "trivy" or "ecr"
+	Scanner string `json:"scanner"`
+
+	Critical uint `json:"critical"`
+	High     uint `json:"high"`
+	Medium   uint `json:"medium"`
+	Low      uint `json:"low"`
+	Unknown  uint `json:"unknown"`
+
+	// ScannedAt is when the image was scanned
+	ScannedAt *time.Time `json:"scanned_at,omitempty"`
+}
+
+// Vulnerability is a vulnerability found in a package of an image
+type Vulnerability struct {
+	// ID is the identifier of the vulnerability, like CVE-2023-1234
+	ID               string                `json:"id"`
+	Severity         VulnerabilitySeverity `json:"severity"`
+	PackageName      string                `json:"package_name,omitempty"`
+	InstalledVersion string                `json:"installed_version,omitempty"`
+
+	// FixedVersion is the version of the package which fixes the vulnerability, if there is one
+	FixedVersion string `json:"fixed_version,omitempty"`
+	Title        string `json:"title,omitempty"`
+	URL          string `json:"url,omitempty"`
+}
+
+// ImageScan is the result of a scan of an image
+type ImageScan struct {
+	// Repository is the URI of the image repository, like 123456789.dkr.ecr.us-west-2.amazonaws.com/web
+	Repository string `json:"repository"`
+
+	// Digest is the sha256 digest of the scanned image manifest
+	Digest string `json:"digest"`
+
+	// Tag is the tag of the image when it was scanned
+	Tag string `json:"tag,omitempty"`
+
+	Summary         VulnerabilitySummary `json:"summary"`
+	Vulnerabilities []Vulnerability      `json:"vulnerabilities"`
+}
+
+// CreateImageScanRequest scans an image of a repository of a registry and stores the results for its digest
+type CreateImageScanRequest struct {
+	// RepoName is the name of the repository in the registry
+	RepoName string `json:"repo_name" form:"required"`
+
+	// Tag is the tag of the image. One of the tag or the digest is required, unless the report identifies the image.
+	Tag string `json:"tag"`
+
+	// Digest is the sha256 digest of the image
+	Digest string `json:"digest"`
+
+	// Format is "trivy" to ingest an uploaded report, or "ecr" to import the findings of ECR image scanning
+	Format ImageScanFormat `json:"format" form:"required,oneof=trivy ecr"`
+
+	// Report is the report to ingest, if the format is "trivy"
+	Report json.RawMessage `json:"report,omitempty"`
+}
+
+// GetImageScansRequest selects the scans of an image of a repository of a registry
+type GetImageScansRequest struct {
+	RepoName string `schema:"repo_name" form:"required"`
+	Digest   string `schema:"digest"`
+	Tag      string `schema:"tag"`
+}
+
+// GetImageScansResponse is the latest scan of an image by each scanner
+type GetImageScansResponse []*ImageScan
+
+// ImageScanPolicy is the severity gate of a project: images with vulnerabilities at or above the severity threshold
+// cannot be deployed to the apps of the project
+type ImageScanPolicy struct {
+	// SeverityThreshold is the lowest severity which blocks a deploy. If empty, deploys are not gated.
+	SeverityThreshold VulnerabilitySeverity `json:"severity_threshold" form:"omitempty,oneof=CRITICAL HIGH MEDIUM LOW"`
+}
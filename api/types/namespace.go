@@ -3,6 +3,7 @@ package types
 import (
 	"time"
 
+	"github.com/porter-dev/porter/internal/helm/grapher"
 	"github.com/stefanmcshane/helm/pkg/action"
 	v1 "k8s.io/api/core/v1"
 )
@@ -294,3 +295,56 @@ type V1EnvGroupMeta struct {
 //
 // swagger:model
 type V1ListAllEnvGroupsResponse []*V1EnvGroupMeta
+
+// ResourceGraphFormat is the format a resource graph is exported in
+type ResourceGraphFormat string
+
+const (
+	// ResourceGraphFormatJSON exports the nodes and edges of a resource graph as JSON
+	ResourceGraphFormatJSON ResourceGraphFormat = "json"
+
+	// ResourceGraphFormatDOT exports a resource graph in the DOT language of Graphviz
+	ResourceGraphFormatDOT ResourceGraphFormat = "dot"
+)
+
+// GetResourceGraphRequest is the request to export the graph of the objects of the releases in a namespace
+type GetResourceGraphRequest struct {
+	// the format of the graph, json by default
+	//
+	// in: query
+	// example: dot
+	Format ResourceGraphFormat `schema:"format"`
+
+	// if set, only the objects of this release and the objects of other releases they are related to are included
+	//
+	// in: query
+	Release string `schema:"release"`
+}
+
+// GetResourceGraphResponse contains the resource graph in the requested format
+type GetResourceGraphResponse struct {
+	// the nodes and edges of the graph, for the json format
+	Graph *grapher.Graph `json:"graph,omitempty"`
+
+	// the graph in the DOT language, for the dot format
+	DOT string `json:"dot,omitempty"`
+}
+
+// GetResourceImpactRequest is the request to find the objects in a namespace affected by a change to an object
+type GetResourceImpactRequest struct {
+	// the kind of the changed object
+	//
+	// in: query
+	// example: ConfigMap
+	Kind string `schema:"kind" form:"required"`
+
+	// the name of the changed object
+	//
+	// in: query
+	Name string `schema:"name" form:"required"`
+}
+
+// GetResourceImpactResponse lists the objects affected by a change to an object, ordered by their distance from it
+type GetResourceImpactResponse struct {
+	Impacted []grapher.ImpactedObject `json:"impacted"`
+}
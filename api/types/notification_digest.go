@@ -0,0 +1,7 @@
+package types
+
+// NotificationDigestSetting is the opt-in of a project to the daily digest email of its app notifications,
+// which is sent to every member of the project
+type NotificationDigestSetting struct {
+	Enabled bool `json:"enabled"`
+}
@@ -0,0 +1,97 @@
+package types
+
+import "time"
+
+// NotificationRuleScope is the scope of the notifications matched by a rule. The values match the
+// scopes of app notifications.
+type NotificationRuleScope string
+
+const (
+	// NotificationRuleScope_Application matches notifications scoped to an application
+	NotificationRuleScope_Application NotificationRuleScope = "APPLICATION"
+	// NotificationRuleScope_Revision matches notifications scoped to an app revision
+	NotificationRuleScope_Revision NotificationRuleScope = "REVISION"
+	// NotificationRuleScope_Service matches notifications scoped to a single service
+	NotificationRuleScope_Service NotificationRuleScope = "SERVICE"
+)
+
+// NotificationRuleDestinationKind is the kind of channel notifications matched by a rule are sent to
+type NotificationRuleDestinationKind string
+
+const (
+	// NotificationRuleDestinationKind_Slack sends matched notifications to a Slack integration
+	NotificationRuleDestinationKind_Slack NotificationRuleDestinationKind = "slack"
+	// NotificationRuleDestinationKind_Webhook sends matched notifications to a notification webhook integration
+	NotificationRuleDestinationKind_Webhook NotificationRuleDestinationKind = "webhook"
+	// NotificationRuleDestinationKind_None drops matched notifications
+	NotificationRuleDestinationKind_None NotificationRuleDestinationKind = "none"
+)
+
+// NotificationRuleMatch selects the notifications a rule applies to. Empty fields match any notification.
+type NotificationRuleMatch struct {
+	AppName            string                  `json:"app_name,omitempty"`
+	DeploymentTargetID string                  `json:"deployment_target_id,omitempty"`
+	ServiceName        string                  `json:"service_name,omitempty"`
+	ErrorCodes         []int                   `json:"error_codes,omitempty"`
+	Scopes             []NotificationRuleScope `json:"scopes,omitempty" form:"dive,oneof=APPLICATION REVISION SERVICE"`
+}
+
+// NotificationRuleDestination is the channel notifications matched by a rule are sent to
+type NotificationRuleDestination struct {
+	Kind NotificationRuleDestinationKind `json:"kind" form:"required,oneof=slack webhook none"`
+
+	// IntegrationID is the ID of the Slack or notification webhook integration. It is ignored for the "none" kind.
+	IntegrationID uint `json:"integration_id,omitempty"`
+}
+
+// NotificationRuleQuietHours is a daily window in which matched notifications are dropped
+type NotificationRuleQuietHours struct {
+	// Start and End are formatted as "HH:MM". A window whose end is before its start spans midnight.
+	Start string `json:"start" form:"required"`
+	End   string `json:"end" form:"required"`
+
+	// Timezone is an IANA time zone name, such as "America/New_York". Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// NotificationRule routes the notifications of a project which match it to a destination. Rules are
+// evaluated in ascending order of priority, and the first enabled rule which matches a notification
+// applies to it.
+type NotificationRule struct {
+	ID        uint `json:"id"`
+	ProjectID uint `json:"project_id"`
+
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+	Disabled bool   `json:"disabled"`
+
+	Match       NotificationRuleMatch       `json:"match"`
+	Destination NotificationRuleDestination `json:"destination"`
+
+	// ThrottleWindowSeconds is the minimum number of seconds between two notifications for the same
+	// app, service, error code and scope matched by the rule. Zero disables throttling.
+	ThrottleWindowSeconds uint `json:"throttle_window_seconds,omitempty"`
+
+	QuietHours *NotificationRuleQuietHours `json:"quiet_hours,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateNotificationRuleRequest is the request object for creating or updating a notification rule
+type CreateNotificationRuleRequest struct {
+	Name     string `json:"name" form:"required"`
+	Priority int    `json:"priority"`
+	Disabled bool   `json:"disabled"`
+
+	Match       NotificationRuleMatch       `json:"match"`
+	Destination NotificationRuleDestination `json:"destination" form:"required"`
+
+	ThrottleWindowSeconds uint                        `json:"throttle_window_seconds"`
+	QuietHours            *NotificationRuleQuietHours `json:"quiet_hours"`
+}
+
+// UpdateNotificationRuleRequest replaces all fields of a notification rule
+type UpdateNotificationRuleRequest CreateNotificationRuleRequest
+
+type ListNotificationRulesResponse []*NotificationRule
@@ -0,0 +1,47 @@
+package types
+
+const (
+	URLParamNotificationWebhookIntegrationID = "notification_webhook_integration_id"
+)
+
+// NotificationWebhookKind is the format in which notifications are sent to a webhook
+type NotificationWebhookKind string
+
+const (
+	// NotificationWebhookKind_Generic sends signed JSON payloads to any HTTP receiver
+	NotificationWebhookKind_Generic NotificationWebhookKind = "webhook"
+
+	// NotificationWebhookKind_Teams sends Adaptive Cards to a Microsoft Teams incoming webhook
+	NotificationWebhookKind_Teams NotificationWebhookKind = "teams"
+)
+
+// NotificationWebhookIntegration is a webhook which receives deployment and incident
+// notifications for a project. The webhook URL and signing secret are never returned.
+type NotificationWebhookIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	// The display name of the webhook
+	Name string `json:"name"`
+
+	// The format in which notifications are sent to the webhook
+	Kind NotificationWebhookKind `json:"kind"`
+
+	// Whether payloads sent to the webhook are signed
+	Signed bool `json:"signed"`
+
+	NotificationConfigID uint `json:"notification_config_id"`
+}
+
+type CreateNotificationWebhookIntegrationRequest struct {
+	Name string                  `json:"name" form:"required"`
+	Kind NotificationWebhookKind `json:"kind" form:"required,oneof=webhook teams"`
+	URL  string                  `json:"url" form:"required,url"`
+
+	// SigningSecret is used to compute the HMAC-SHA256 signature of generic webhook payloads. It is
+	// ignored for Teams webhooks.
+	SigningSecret string `json:"signing_secret"`
+}
+
+type ListNotificationWebhookIntegrationsResponse []*NotificationWebhookIntegration
@@ -0,0 +1,60 @@
+package types
+
+import "time"
+
+// CreateOPAPolicyBundleRequest uploads a new version of a project policy bundle for the recommender
+type CreateOPAPolicyBundleRequest struct {
+	// Name identifies the bundle within the project. Uploading a bundle with an existing name creates a new version.
+	Name string `json:"name" form:"required,max=63"`
+
+	// Config lists the policy collections of the bundle, in the format of the built-in recommender config file
+	Config string `json:"config" form:"required"`
+
+	// Policies maps the policy paths referenced by the config to rego modules
+	Policies map[string]string `json:"policies" form:"required"`
+}
+
+// OPAPolicyBundle is a version of a project policy bundle
+type OPAPolicyBundle struct {
+	ID        uint      `json:"id"`
+	ProjectID uint      `json:"project_id"`
+	Name      string    `json:"name"`
+	Version   uint      `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Collections are the names of the policy collections of the bundle, as they appear in monitor test results
+	Collections []string `json:"collections"`
+
+	Config   string            `json:"config,omitempty"`
+	Policies map[string]string `json:"policies,omitempty"`
+}
+
+// ListOPAPolicyBundlesResponse is the latest version of each policy bundle of a project
+type ListOPAPolicyBundlesResponse []*OPAPolicyBundle
+
+// ListOPAPolicyBundleVersionsResponse is the versions of a policy bundle, latest first
+type ListOPAPolicyBundleVersionsResponse []*OPAPolicyBundle
+
+// GetOPAPolicyBundleRequest selects a version of a policy bundle. If version is 0, the latest version is returned.
+type GetOPAPolicyBundleRequest struct {
+	Version uint `schema:"version"`
+}
+
+// OPAPolicyOverride overrides the enforcement of a policy collection when the apps of a project are deployed
+type OPAPolicyOverride struct {
+	// Collection is the name of the policy collection, like "<bundle name>/<collection name>"
+	Collection string `json:"collection" form:"required"`
+
+	// Enforcement is used in place of the enforcement of the collection. "off" disables the enforcement.
+	Enforcement string `json:"enforcement" form:"required,oneof=warn deny off"`
+}
+
+// UpdateOPAPolicyOverridesRequest replaces the policy overrides of a project
+type UpdateOPAPolicyOverridesRequest struct {
+	Overrides []OPAPolicyOverride `json:"overrides" form:"dive"`
+}
+
+// OPAPolicyOverridesResponse is the policy overrides of a project
+type OPAPolicyOverridesResponse struct {
+	Overrides []OPAPolicyOverride `json:"overrides"`
+}
@@ -90,6 +90,9 @@ type Image struct {
 
 	// When the image was pushed
 	PushedAt *time.Time `json:"pushed_at"`
+
+	// The vulnerabilities found in the image, if it has been scanned
+	Vulnerabilities *VulnerabilitySummary `json:"vulnerabilities,omitempty"`
 }
 
 // Type of registry service
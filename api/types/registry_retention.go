@@ -0,0 +1,71 @@
+package types
+
+import "time"
+
+// RegistryRetentionPolicy is the retention policy of an image repository of a registry. Images of the repository
+// which are not kept by the policy are deleted by the registry garbage collector, but images referenced by a live
+// app revision or Helm release are always kept.
+type RegistryRetentionPolicy struct {
+	ID         uint   `json:"id"`
+	RegistryID uint   `json:"registry_id"`
+	RepoName   string `json:"repo_name"`
+
+	// KeepLast is the number of most recently pushed images to keep
+	KeepLast uint `json:"keep_last"`
+
+	// KeepTagPattern is a regular expression for the tags of images to keep, like ^v[0-9]+\.[0-9]+\.[0-9]+$
+	KeepTagPattern string `json:"keep_tag_pattern,omitempty"`
+
+	// Enabled is whether the garbage collector deletes the images which are not kept by the policy. Reports can be
+	// generated for disabled policies to preview their effect.
+	Enabled bool `json:"enabled"`
+
+	LastRunAt        *time.Time `json:"last_run_at,omitempty"`
+	LastDeletedCount uint       `json:"last_deleted_count"`
+}
+
+// ListRegistryRetentionPoliciesResponse is the response for listing the retention policies of a registry
+type ListRegistryRetentionPoliciesResponse []*RegistryRetentionPolicy
+
+// UpdateRegistryRetentionPolicyRequest creates or updates the retention policy of an image repository
+type UpdateRegistryRetentionPolicyRequest struct {
+	RepoName       string `json:"repo_name" form:"required"`
+	KeepLast       uint   `json:"keep_last" form:"required,min=1"`
+	KeepTagPattern string `json:"keep_tag_pattern"`
+	Enabled        bool   `json:"enabled"`
+}
+
+// DeleteRegistryRetentionPolicyRequest deletes the retention policy of an image repository
+type DeleteRegistryRetentionPolicyRequest struct {
+	RepoName string `json:"repo_name" form:"required"`
+}
+
+// GetRegistryRetentionReportRequest is the request for a dry-run of the retention policy of an image repository
+type GetRegistryRetentionReportRequest struct {
+	RepoName string `schema:"repo_name" form:"required"`
+}
+
+// RetentionDecision is whether an image is kept or deleted by a retention policy. Images are deleted by digest, so
+// all the tags of an image share the decision.
+type RetentionDecision struct {
+	Digest   string     `json:"digest"`
+	Tags     []string   `json:"tags"`
+	PushedAt *time.Time `json:"pushed_at,omitempty"`
+
+	// Reason is why the image is kept, like "referenced by helm release default/web in cluster 3"
+	Reason string `json:"reason,omitempty"`
+}
+
+// RegistryRetentionReport is the result of evaluating the retention policy of an image repository
+type RegistryRetentionReport struct {
+	RepoName string `json:"repo_name"`
+
+	// Repository is the URI of the image repository, like 123456789.dkr.ecr.us-west-2.amazonaws.com/web
+	Repository string `json:"repository"`
+
+	Policy *RegistryRetentionPolicy `json:"policy"`
+	Keep   []RetentionDecision      `json:"keep"`
+	Delete []RetentionDecision      `json:"delete"`
+
+	EvaluatedAt time.Time `json:"evaluated_at"`
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"github.com/porter-dev/porter/internal/helm/upgrade"
 	"github.com/stefanmcshane/helm/pkg/release"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -105,6 +106,18 @@ type RollbackReleaseRequest struct {
 	Revision int `json:"revision" form:"required"`
 }
 
+// ListArchivedReleaseRevisionsResponse lists the revisions of a release which were pruned from the cluster and
+// archived to the revisions storage backend, from newest to oldest
+type ListArchivedReleaseRevisionsResponse struct {
+	Revisions []int `json:"revisions"`
+}
+
+// RestoreArchivedReleaseRevisionRequest restores an archived revision of a release into the cluster, after which
+// the release can be rolled back to the revision
+type RestoreArchivedReleaseRevisionRequest struct {
+	Revision int `json:"revision" form:"required,min=1"`
+}
+
 // swagger:model UpdateReleaseRequest
 type V1UpgradeReleaseRequest struct {
 	// The Helm values to upgrade the release with
@@ -129,6 +142,46 @@ type UpgradeReleaseRequest struct {
 
 	// Required to ignore dependecies when we read releases for umbrella charts, because their subcharts aren't Porter charts https://github.com/helm/helm/issues/9214
 	IgnoreDependencies bool
+
+	// (optional) if set, the upgrade is rendered but not applied, and the changes it would make to the resources of
+	// the release are returned as a ReleaseDiff
+	DryRun bool `json:"dry_run"`
+
+	// (optional) must be set to upgrade to a chart version across upgrade notes which require acknowledgement
+	AcknowledgeUpgradeNotes bool `json:"acknowledge_upgrade_notes"`
+}
+
+// ResourceDiffAction is the change an upgrade makes to a resource of a release
+type ResourceDiffAction string
+
+const (
+	ResourceDiffActionCreate ResourceDiffAction = "create"
+	ResourceDiffActionUpdate ResourceDiffAction = "update"
+	ResourceDiffActionDelete ResourceDiffAction = "delete"
+)
+
+// ResourceDiff is the change an upgrade makes to a single resource of a release
+type ResourceDiff struct {
+	Kind      string             `json:"kind"`
+	Namespace string             `json:"namespace,omitempty"`
+	Name      string             `json:"name"`
+	Action    ResourceDiffAction `json:"action"`
+
+	// Diff is a line diff of the resource manifests, in which removed lines are prefixed with "-", added lines with
+	// "+" and unchanged context lines with a space
+	Diff string `json:"diff"`
+}
+
+// ReleaseDiff is the result of a dry-run upgrade of a release: the changes the upgrade would make to the resources
+// in the manifest of the release. Unchanged resources are omitted.
+type ReleaseDiff struct {
+	// CurrentRevision is the revision of the release the diff is computed against, or 0 if the release does not exist
+	CurrentRevision int `json:"current_revision"`
+
+	Resources []ResourceDiff `json:"resources"`
+
+	// UpgradeNotes are the upgrade notes between the current and the new chart version, if the chart version changes
+	UpgradeNotes []*upgrade.UpgradeNote `json:"upgrade_notes,omitempty"`
 }
 
 type UpdateImageBatchRequest struct {
@@ -58,6 +58,8 @@ const (
 	URLParamPaymentMethodID       URLParam = "payment_method_id"
 	URLParamNotificationConfigID  URLParam = "notification_config_id"
 	URLParamNotificationID        URLParam = "notification_id"
+	URLParamNotificationRuleID    URLParam = "notification_rule_id"
+	URLParamOPAPolicyBundleName   URLParam = "opa_policy_bundle_name"
 	URLParamCloudProviderType     URLParam = "cloud_provider_type"
 	URLParamCloudProviderID       URLParam = "cloud_provider_id"
 	URLParamDeploymentTargetID    URLParam = "deployment_target_id"
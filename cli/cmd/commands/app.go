@@ -3,10 +3,12 @@ package commands
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -43,6 +45,8 @@ var (
 	appInteractive       bool
 	appMemoryMi          int
 	appNamespace         string
+	appRenderEnvironment string
+	appRenderFile        string
 	appTag               string
 	appVerbose           bool
 	appWait              bool
@@ -250,6 +254,28 @@ in that it only updates the app, but does not attempt to build a new image.`,
 	}
 	appCmd.AddCommand(appManifestsCmd)
 
+	// appRenderCmd represents the "porter app render" subcommand
+	appRenderCmd := &cobra.Command{
+		Use:   "render",
+		Short: "Prints a porter.yaml with an environment merged over the rest of the file.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return appRender()
+		},
+	}
+	appRenderCmd.PersistentFlags().StringVarP(&appRenderFile, "file", "f", "porter.yaml", "path to porter.yaml")
+	appRenderCmd.PersistentFlags().StringVar(&appRenderEnvironment, "env", "", "name of the environment to merge")
+	appCmd.AddCommand(appRenderCmd)
+
+	// appYAMLSchemaCmd represents the "porter app yaml-schema" subcommand
+	appYAMLSchemaCmd := &cobra.Command{
+		Use:   "yaml-schema",
+		Short: "Prints the JSON Schema of porter.yaml, for use with editors that support YAML schemas.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return appYAMLSchema()
+		},
+	}
+	appCmd.AddCommand(appYAMLSchemaCmd)
+
 	return appCmd
 }
 
@@ -452,6 +478,39 @@ func appManifests(ctx context.Context, _ *types.GetAuthenticatedUserResponse, cl
 	return nil
 }
 
+func appRender() error {
+	porterYaml, err := os.ReadFile(filepath.Clean(appRenderFile))
+	if err != nil {
+		return fmt.Errorf("could not read porter yaml file: %w", err)
+	}
+
+	rendered, err := appV2.RenderEnvironment(porterYaml, appRenderEnvironment)
+	if err != nil {
+		return fmt.Errorf("failed to render porter yaml: %w", err)
+	}
+
+	_, err = os.Stdout.Write(rendered)
+	if err != nil {
+		return fmt.Errorf("failed to write porter yaml: %w", err)
+	}
+
+	return nil
+}
+
+func appYAMLSchema() error {
+	jsonSchema, err := json.MarshalIndent(appV2.JSONSchema(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal porter.yaml schema: %w", err)
+	}
+
+	_, err = fmt.Fprintln(os.Stdout, string(jsonSchema))
+	if err != nil {
+		return fmt.Errorf("failed to write porter.yaml schema: %w", err)
+	}
+
+	return nil
+}
+
 func appRollback(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConfig config.CLIConfig, _ config.FeatureFlags, _ *cobra.Command, args []string) error {
 	project, err := client.GetProject(ctx, cliConfig.Project)
 	if err != nil {
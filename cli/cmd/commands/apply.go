@@ -30,6 +30,7 @@ import (
 	previewV2Beta1 "github.com/porter-dev/porter/cli/cmd/preview/v2beta1"
 	cliUtils "github.com/porter-dev/porter/cli/cmd/utils"
 	previewInt "github.com/porter-dev/porter/internal/integrations/preview"
+	porterAppInt "github.com/porter-dev/porter/internal/porter_app"
 	"github.com/porter-dev/porter/internal/templater/utils"
 	"github.com/porter-dev/switchboard/pkg/drivers"
 	switchboardModels "github.com/porter-dev/switchboard/pkg/models"
@@ -48,6 +49,10 @@ var (
 	pullImageBeforeBuild bool
 	predeploy            bool
 	exact                bool
+	// strict is a flag that determines whether a v2 porter.yaml is rejected for unknown fields, type mismatches or invalid values
+	strict bool
+	// porterYAMLEnvironment is the name of the porter.yaml environment overlay to apply
+	porterYAMLEnvironment string
 )
 
 func registerCommand_Apply(cliConf config.CLIConfig) *cobra.Command {
@@ -115,6 +120,8 @@ applying a configuration:
 	applyCmd.PersistentFlags().BoolVarP(&previewApply, "preview", "p", false, "apply as preview environment based on current git branch")
 	applyCmd.PersistentFlags().BoolVar(&pullImageBeforeBuild, "pull-before-build", false, "attempt to pull image from registry before building")
 	applyCmd.PersistentFlags().BoolVar(&predeploy, "predeploy", false, "run predeploy job before deploying the application")
+	applyCmd.PersistentFlags().BoolVar(&strict, "strict", false, "reject a porter.yaml with unknown fields, type mismatches or invalid values, reporting their line and column")
+	applyCmd.PersistentFlags().StringVar(&porterYAMLEnvironment, "env", "", "name of the porter.yaml environment to apply (default is the environment named after the deployment target, if it exists)")
 	applyCmd.PersistentFlags().BoolVar(&exact, "exact", false, "apply the exact configuration as specified in the porter.yaml file (default is to merge with existing configuration)")
 	applyCmd.PersistentFlags().BoolVarP(
 		&appWait,
@@ -199,6 +206,8 @@ func apply(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client ap
 			PullImageBeforeBuild:        pullImageBeforeBuild,
 			WithPredeploy:               predeploy,
 			Exact:                       exact,
+			Strict:                      strict,
+			Environment:                 porterYAMLEnvironment,
 			PatchOperations:             patchOperations,
 			SkipBuild:                   noBuild,
 		}
@@ -389,6 +398,13 @@ func applyValidate() error {
 		return fmt.Errorf("error reading porter.yaml: %w", err)
 	}
 
+	if strict {
+		err = porterAppInt.ValidateYAML(context.Background(), fileBytes)
+		if err != nil {
+			return fmt.Errorf("error validating porter.yaml: %w", err)
+		}
+	}
+
 	validationErrors := previewInt.Validate(string(fileBytes))
 
 	if len(validationErrors) > 0 {
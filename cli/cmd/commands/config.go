@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -166,12 +167,79 @@ func registerCommand_Config(cliConf config.CLIConfig) *cobra.Command {
 		},
 	}
 
+	configUseContextCmd := &cobra.Command{
+		Use:   "use-context [name]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Sets the current context, and creates the context if it does not exist",
+		Long: fmt.Sprintf(`
+%s
+
+Sets the current context in the configuration. Each context holds its own host, project,
+cluster and token, which makes it possible to switch between Porter instances without
+logging in again. If the context does not exist, it is created, and you can then configure
+it with "porter config set-host" and "porter auth login".
+
+The current context can be overridden for a single command by setting the PORTER_CONTEXT
+environment variable.
+
+  %s
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter config use-context\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter config use-context staging"),
+			color.New(color.FgGreen, color.Bold).Sprintf("PORTER_CONTEXT=production porter app list"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := useContext(&cliConf, args[0])
+			if err != nil {
+				_, _ = color.New(color.FgRed).Fprintf(os.Stderr, "An error occurred: %s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	configGetContextsCmd := &cobra.Command{
+		Use:   "get-contexts",
+		Args:  cobra.NoArgs,
+		Short: "Lists the contexts in the configuration",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := getContexts(cliConf)
+			if err != nil {
+				_, _ = color.New(color.FgRed).Fprintf(os.Stderr, "An error occurred: %s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	configRenameContextCmd := &cobra.Command{
+		Use:   "rename-context [old-name] [new-name]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Renames a context in the configuration",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cliConf.RenameContext(args[0], args[1])
+			if err != nil {
+				_, _ = color.New(color.FgRed).Fprintf(os.Stderr, "An error occurred: %s\n", err.Error())
+				os.Exit(1)
+			}
+
+			color.New(color.FgGreen).Printf("Renamed context %s to %s\n", args[0], args[1]) // nolint:errcheck,gosec
+
+			if os.Getenv(config.ContextEnvVar) == args[0] {
+				color.New(color.FgYellow).Printf("%s is still set to %s, please update it to %s\n", config.ContextEnvVar, args[0], args[1]) // nolint:errcheck,gosec
+			}
+		},
+	}
+
 	configCmd.AddCommand(configSetProjectCmd)
 	configCmd.AddCommand(configSetClusterCmd)
 	configCmd.AddCommand(configSetHostCmd)
 	configCmd.AddCommand(configSetRegistryCmd)
 	configCmd.AddCommand(configSetHelmRepoCmd)
 	configCmd.AddCommand(configSetKubeconfigCmd)
+	configCmd.AddCommand(configUseContextCmd)
+	configCmd.AddCommand(configGetContextsCmd)
+	configCmd.AddCommand(configRenameContextCmd)
 	return configCmd
 }
 
@@ -186,6 +254,66 @@ func printConfig() error {
 	return nil
 }
 
+func useContext(cliConf *config.CLIConfig, name string) error {
+	created, err := cliConf.UseContext(name)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		color.New(color.FgGreen).Printf("Created context %s\n", name) // nolint:errcheck,gosec
+	}
+
+	color.New(color.FgGreen).Printf("Switched to context %s\n", name) // nolint:errcheck,gosec
+
+	if envContext := os.Getenv(config.ContextEnvVar); envContext != "" && envContext != name {
+		color.New(color.FgYellow).Printf("%s is set, so context %s is used until it is unset\n", config.ContextEnvVar, envContext) // nolint:errcheck,gosec
+	}
+
+	if created {
+		fmt.Println("To configure the context, run \"porter config set-host [HOST]\" and \"porter auth login\"")
+	}
+
+	return nil
+}
+
+func getContexts(cliConf config.CLIConfig) error {
+	contexts, current, err := cliConf.GetContexts()
+	if err != nil {
+		return err
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 2, '\t', 0)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "CURRENT", "NAME", "HOST", "PROJECT", "CLUSTER", "LOGGED IN")
+
+	found := false
+
+	for _, ctx := range contexts {
+		marker := ""
+		if ctx.Name == current {
+			marker = "*"
+			found = true
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%t\n", marker, ctx.Name, ctx.Host, ctx.Project, ctx.Cluster, ctx.Token != "")
+	}
+
+	if !found {
+		// the context set by PORTER_CONTEXT is only saved once it is configured
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%t\n", "*", current, "", 0, 0, false)
+	}
+
+	w.Flush()
+
+	if os.Getenv(config.ContextEnvVar) != "" {
+		color.New(color.FgYellow).Printf("The current context is set by %s\n", config.ContextEnvVar) // nolint:errcheck,gosec
+	}
+
+	return nil
+}
+
 func listAndSetProject(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
 	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
 	_ = s.Color("cyan")
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -13,6 +15,8 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers/porter_app"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups/diff"
 	"github.com/spf13/cobra"
 )
 
@@ -105,48 +109,141 @@ When updating an environment group, all apps linked to the environment group wil
 	unsetCommand.Flags().StringSliceP("secrets", "s", nil, "secrets to unset")
 	unsetCommand.Flags().Bool("skip-redeploys", false, "skip re-deploying apps linked to the environment group")
 
+	pushCommand := &cobra.Command{
+		Use:   "push",
+		Short: "Push environment variables from .env files to an app or environment group",
+		Long: fmt.Sprintf(`%s
+
+Push environment variables from .env files to an app or environment group.
+
+The variables of the file set with --file and the secrets of the file set with --secrets-file are compared with the
+current environment variables, and the keys which would be added, changed and removed are printed before any change
+is applied. Secret values are always masked. Keys which are currently secrets remain secrets, even if they are part
+of the --file file.
+
+Keys which are not part of the files are kept, unless the --prune flag is used. Changes are applied after
+confirmation, unless the --yes flag is used. Use --dry-run to only print the changes.
+
+When updating an environment group, all apps linked to the environment group will be re-deployed, unless the
+--skip-redeploys flag is used.
+
+  %s
+
+  %s
+
+  %s`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env push\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter env push --app my-app --file .env"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter env push --group shared --file .env --secrets-file .env.secrets --prune --dry-run"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter env push --group shared --file .env --yes --skip-redeploys"),
+		),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkLoginAndRunWithConfig(cmd, cliConf, args, pushEnv)
+		},
+	}
+	pushCommand.Flags().StringVarP(&envFilePath, "file", "f", "", ".env file of variables to push")
+	pushCommand.Flags().String("secrets-file", "", ".env file of secrets to push")
+	pushCommand.Flags().Bool("prune", false, "remove variables and secrets which are not part of the files")
+	pushCommand.Flags().Bool("dry-run", false, "print the changes without applying them")
+	pushCommand.Flags().BoolP("yes", "y", false, "apply the changes without confirmation")
+	pushCommand.Flags().Bool("skip-redeploys", false, "skip re-deploying apps linked to the environment group")
+
+	historyCommand := &cobra.Command{
+		Use:   "history",
+		Short: "List the versions of an environment group",
+		Long: fmt.Sprintf(`%s
+
+List the versions of an environment group, from the most recent to the oldest, with the time each version was
+created and its author.
+
+  %s`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env history\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter env history --group shared"),
+		),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkLoginAndRunWithConfig(cmd, cliConf, args, envHistory)
+		},
+	}
+
+	diffCommand := &cobra.Command{
+		Use:   "diff",
+		Short: "Show the changes between two versions of an environment group",
+		Long: fmt.Sprintf(`%s
+
+Show the keys added, changed and removed between two versions of an environment group. Secret values are always
+masked.
+
+By default, the latest version is compared with the version before it. Use --to to set the version the changes are
+computed to, and --from to set the version the changes are computed from.
+
+  %s
+
+  %s`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env diff\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter env diff --group shared"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter env diff --group shared --from 3 --to 5"),
+		),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkLoginAndRunWithConfig(cmd, cliConf, args, envDiff)
+		},
+	}
+	diffCommand.Flags().Int("from", 0, "version the changes are computed from (defaults to the version before --to)")
+	diffCommand.Flags().Int("to", 0, "version the changes are computed to (defaults to the latest version)")
+
+	rollbackCommand := &cobra.Command{
+		Use:   "rollback",
+		Short: "Roll an environment group back to a previous version",
+		Long: fmt.Sprintf(`%s
+
+Roll an environment group back to a previous version. A new version of the environment group is created with the
+variables and secrets of the version set with --version, so a rollback can itself be rolled back.
+
+The changes to the latest version are printed and applied after confirmation, unless the --yes flag is used. All apps
+linked to the environment group will be re-deployed, unless the --skip-redeploys flag is used.
+
+  %s
+
+  %s`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env rollback\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter env rollback --group shared --version 3"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter env rollback --group shared --version 3 --yes --skip-redeploys"),
+		),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkLoginAndRunWithConfig(cmd, cliConf, args, rollbackEnv)
+		},
+	}
+	rollbackCommand.Flags().Int("version", 0, "version of the environment group to roll back to")
+	rollbackCommand.Flags().BoolP("yes", "y", false, "roll back without confirmation")
+	rollbackCommand.Flags().Bool("skip-redeploys", false, "skip re-deploying apps linked to the environment group")
+	_ = rollbackCommand.MarkFlagRequired("version")
+
 	envCmd.AddCommand(pullCommand)
 	envCmd.AddCommand(setCommand)
 	envCmd.AddCommand(unsetCommand)
+	envCmd.AddCommand(pushCommand)
+	envCmd.AddCommand(historyCommand)
+	envCmd.AddCommand(diffCommand)
+	envCmd.AddCommand(rollbackCommand)
 
 	return envCmd
 }
 
 func pullEnv(ctx context.Context, user *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
-	var envVars envVariables
-
 	if appName != "" {
 		color.New(color.FgGreen).Printf("Pulling environment variables for app %s...\n", appName) // nolint:errcheck,gosec
-
-		envVarsResp, err := client.GetAppEnvVariables(ctx, cliConf.Project, cliConf.Cluster, appName, deploymentTargetName)
-		if err != nil {
-			return fmt.Errorf("could not get app env variables: %w", err)
-		}
-		if envVarsResp == nil {
-			return fmt.Errorf("could not get app env variables: response was nil")
-		}
-
-		envVars = envVariables{
-			Variables: envVarsResp.EnvVariables.Variables,
-			Secrets:   envVarsResp.EnvVariables.Secrets,
-		}
 	}
 
 	if envGroupName != "" {
 		color.New(color.FgGreen).Printf("Pulling environment variables for environment group %s...\n", envGroupName) // nolint:errcheck,gosec
+	}
 
-		envVarsResp, err := client.GetLatestEnvGroupVariables(ctx, cliConf.Project, cliConf.Cluster, envGroupName)
-		if err != nil {
-			return fmt.Errorf("could not get env group env variables: %w", err)
-		}
-		if envVarsResp == nil {
-			return fmt.Errorf("could not get env group variables: response was nil")
-		}
-
-		envVars = envVariables{
-			Variables: envVarsResp.Variables,
-			Secrets:   envVarsResp.Secrets,
-		}
+	envVars, err := getEnvVariables(ctx, client, cliConf)
+	if err != nil {
+		return err
 	}
 
 	if envFilePath != "" {
@@ -323,6 +420,444 @@ func unsetEnv(ctx context.Context, user *types.GetAuthenticatedUserResponse, cli
 	return nil
 }
 
+func pushEnv(ctx context.Context, user *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	secretsFilePath, err := cmd.Flags().GetString("secrets-file")
+	if err != nil {
+		return fmt.Errorf("could not get secrets-file: %w", err)
+	}
+
+	prune, err := cmd.Flags().GetBool("prune")
+	if err != nil {
+		return fmt.Errorf("could not get prune: %w", err)
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("could not get dry-run: %w", err)
+	}
+
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return fmt.Errorf("could not get yes: %w", err)
+	}
+
+	skipRedeploys, err := cmd.Flags().GetBool("skip-redeploys")
+	if err != nil {
+		return fmt.Errorf("could not get skip-redeploys: %w", err)
+	}
+
+	if envFilePath == "" && secretsFilePath == "" {
+		return fmt.Errorf("must specify at least one of --file or --secrets-file")
+	}
+
+	variables, err := readEnvFile(envFilePath)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := readEnvFile(secretsFilePath)
+	if err != nil {
+		return err
+	}
+
+	for k := range variables {
+		if _, ok := secrets[k]; ok {
+			return fmt.Errorf("%s is set in both %s and %s", k, envFilePath, secretsFilePath)
+		}
+	}
+
+	current, err := getEnvVariables(ctx, client, cliConf)
+	if err != nil {
+		return err
+	}
+
+	currentVars := diff.Variables{
+		Normal: current.Variables,
+		Secret: current.Secrets,
+	}
+
+	desired := diff.KeepSecrets(currentVars, diff.Variables{
+		Normal: variables,
+		Secret: secrets,
+	})
+
+	changes := diff.Compute(currentVars, desired, prune)
+
+	target := fmt.Sprintf("app %s", appName)
+	if envGroupName != "" {
+		target = fmt.Sprintf("environment group %s", envGroupName)
+	}
+
+	if changes.Empty() {
+		color.New(color.FgGreen).Printf("Environment variables of %s are up to date\n", target) // nolint:errcheck,gosec
+		return nil
+	}
+
+	printEnvDiff(target, changes)
+
+	if dryRun {
+		return nil
+	}
+
+	if !yes {
+		userResp, err := utils.PromptPlaintext(
+			fmt.Sprintf(
+				`Apply these changes to %s? %s `,
+				target,
+				color.New(color.FgCyan).Sprintf("[y/n]"),
+			),
+		)
+		if err != nil {
+			return err
+		}
+
+		if userResp := strings.ToLower(userResp); userResp != "y" && userResp != "yes" {
+			color.New(color.FgYellow).Println("No changes were applied") // nolint:errcheck,gosec
+			return nil
+		}
+	}
+
+	updates := changes.Updates()
+	deletedVariables, deletedSecrets := changes.Deletions()
+
+	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+	s.Color("cyan") // nolint:errcheck,gosec
+	s.Suffix = fmt.Sprintf(" Pushing environment variables to %s...", target)
+
+	s.Start()
+
+	if appName != "" {
+		_, err = client.UpdateApp(ctx, api.UpdateAppInput{
+			ProjectID:            cliConf.Project,
+			ClusterID:            cliConf.Cluster,
+			Name:                 appName,
+			DeploymentTargetName: deploymentTargetName,
+			Variables:            updates.Normal,
+			Secrets:              updates.Secret,
+			Deletions: porter_app.Deletions{
+				EnvVariableDeletions: porter_app.EnvVariableDeletions{
+					Variables: deletedVariables,
+					Secrets:   deletedSecrets,
+				},
+			},
+		})
+	}
+
+	if envGroupName != "" {
+		err = client.UpdateEnvGroup(ctx, api.UpdateEnvGroupInput{
+			ProjectID:    cliConf.Project,
+			ClusterID:    cliConf.Cluster,
+			EnvGroupName: envGroupName,
+			Variables:    updates.Normal,
+			Secrets:      updates.Secret,
+			Deletions: environment_groups.EnvVariableDeletions{
+				Variables: deletedVariables,
+				Secrets:   deletedSecrets,
+			},
+			SkipRedeploys: skipRedeploys,
+		})
+	}
+
+	s.Stop()
+
+	if err != nil {
+		return fmt.Errorf("could not push environment variables to %s: %w", target, err)
+	}
+
+	color.New(color.FgGreen).Printf("Pushed %d added, %d changed and %d removed keys to %s\n", len(changes.Added), len(changes.Changed), len(changes.Removed), target) // nolint:errcheck,gosec
+
+	return nil
+}
+
+func envHistory(ctx context.Context, user *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	if err := validateEnvGroupOnly("history"); err != nil {
+		return err
+	}
+
+	resp, err := client.ListEnvGroupVersions(ctx, cliConf.Project, cliConf.Cluster, envGroupName)
+	if err != nil {
+		return fmt.Errorf("could not list versions of environment group %s: %w", envGroupName, err)
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "VERSION", "CREATED AT", "CREATED BY", "VARIABLES", "SECRETS")
+
+	for _, v := range resp.Versions {
+		createdBy := v.CreatedBy
+		if createdBy == "" {
+			createdBy = "-"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\n", v.Version, v.CreatedAtUTC.Local().Format(time.RFC822), createdBy, len(v.Variables), len(v.SecretVariables))
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func envDiff(ctx context.Context, user *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	fromVersion, err := cmd.Flags().GetInt("from")
+	if err != nil {
+		return fmt.Errorf("could not get from: %w", err)
+	}
+
+	toVersion, err := cmd.Flags().GetInt("to")
+	if err != nil {
+		return fmt.Errorf("could not get to: %w", err)
+	}
+
+	if err := validateEnvGroupOnly("diff"); err != nil {
+		return err
+	}
+
+	resp, err := client.DiffEnvGroupVersions(ctx, cliConf.Project, cliConf.Cluster, envGroupName, &environment_groups.DiffEnvGroupVersionsRequest{
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("could not diff versions of environment group %s: %w", envGroupName, err)
+	}
+
+	changes := fromEnvGroupChanges(resp.EnvGroupChanges)
+	target := fmt.Sprintf("environment group %s (version %d -> %d)", envGroupName, resp.FromVersion, resp.ToVersion)
+
+	if changes.Empty() {
+		color.New(color.FgGreen).Printf("No changes to the environment variables of %s\n", target) // nolint:errcheck,gosec
+		return nil
+	}
+
+	printEnvDiff(target, changes)
+
+	return nil
+}
+
+func rollbackEnv(ctx context.Context, user *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	version, err := cmd.Flags().GetInt("version")
+	if err != nil {
+		return fmt.Errorf("could not get version: %w", err)
+	}
+
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return fmt.Errorf("could not get yes: %w", err)
+	}
+
+	skipRedeploys, err := cmd.Flags().GetBool("skip-redeploys")
+	if err != nil {
+		return fmt.Errorf("could not get skip-redeploys: %w", err)
+	}
+
+	if err := validateEnvGroupOnly("rollback"); err != nil {
+		return err
+	}
+
+	if version <= 0 {
+		return fmt.Errorf("--version must be a positive version of the environment group")
+	}
+
+	target := fmt.Sprintf("environment group %s", envGroupName)
+
+	versions, err := client.ListEnvGroupVersions(ctx, cliConf.Project, cliConf.Cluster, envGroupName)
+	if err != nil {
+		return fmt.Errorf("could not list versions of environment group %s: %w", envGroupName, err)
+	}
+
+	if len(versions.Versions) == 0 {
+		return fmt.Errorf("environment group %s has no versions", envGroupName)
+	}
+
+	// the changes of the rollback are the changes from the latest version to the target version
+	preview, err := client.DiffEnvGroupVersions(ctx, cliConf.Project, cliConf.Cluster, envGroupName, &environment_groups.DiffEnvGroupVersionsRequest{
+		FromVersion: versions.Versions[0].Version,
+		ToVersion:   version,
+	})
+	if err != nil {
+		return fmt.Errorf("could not diff version %d of environment group %s with the latest version: %w", version, envGroupName, err)
+	}
+
+	changes := fromEnvGroupChanges(preview.EnvGroupChanges)
+	if changes.Empty() {
+		color.New(color.FgGreen).Printf("The latest version of %s already matches version %d\n", target, version) // nolint:errcheck,gosec
+		return nil
+	}
+
+	printEnvDiff(target, changes)
+
+	if !yes {
+		userResp, err := utils.PromptPlaintext(
+			fmt.Sprintf(
+				`Roll %s back to version %d? %s `,
+				target,
+				version,
+				color.New(color.FgCyan).Sprintf("[y/n]"),
+			),
+		)
+		if err != nil {
+			return err
+		}
+
+		if userResp := strings.ToLower(userResp); userResp != "y" && userResp != "yes" {
+			color.New(color.FgYellow).Println("No changes were applied") // nolint:errcheck,gosec
+			return nil
+		}
+	}
+
+	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+	s.Color("cyan") // nolint:errcheck,gosec
+	s.Suffix = fmt.Sprintf(" Rolling %s back to version %d...", target, version)
+
+	s.Start()
+
+	resp, err := client.RollbackEnvGroup(ctx, cliConf.Project, cliConf.Cluster, envGroupName, &environment_groups.RollbackEnvGroupRequest{
+		Version:       version,
+		SkipRedeploys: skipRedeploys,
+	})
+
+	s.Stop()
+
+	if err != nil {
+		return fmt.Errorf("could not roll back %s: %w", target, err)
+	}
+
+	if !resp.Changed {
+		color.New(color.FgGreen).Printf("The latest version of %s already matches version %d\n", target, version) // nolint:errcheck,gosec
+		return nil
+	}
+
+	color.New(color.FgGreen).Printf("Rolled %s back to version %d as version %d\n", target, resp.RolledBackTo, resp.Version) // nolint:errcheck,gosec
+
+	if resp.Redeployed {
+		color.New(color.FgGreen).Printf("Re-deploying linked apps: %s\n", strings.Join(resp.LinkedApplications, ", ")) // nolint:errcheck,gosec
+	}
+
+	return nil
+}
+
+// validateEnvGroupOnly returns an error if the --group flag is not set, or if the --app flag is set, for commands which only apply to environment groups
+func validateEnvGroupOnly(command string) error {
+	if appName != "" {
+		return fmt.Errorf("porter env %s only supports environment groups, use --group instead of --app", command)
+	}
+
+	if envGroupName == "" {
+		return fmt.Errorf("must specify an environment group with --group")
+	}
+
+	return nil
+}
+
+// fromEnvGroupChanges converts the changes between two versions of an environment group to a diff which can be printed
+func fromEnvGroupChanges(changes environment_groups.EnvGroupChanges) diff.Diff {
+	convert := func(changes []environment_groups.EnvGroupVariableChange) []diff.Change {
+		res := make([]diff.Change, 0, len(changes))
+		for _, c := range changes {
+			res = append(res, diff.Change{
+				Key:       c.Key,
+				Secret:    c.Secret,
+				WasSecret: c.WasSecret,
+				Old:       c.Old,
+				New:       c.New,
+			})
+		}
+		return res
+	}
+
+	return diff.Diff{
+		Added:   convert(changes.Added),
+		Changed: convert(changes.Changed),
+		Removed: convert(changes.Removed),
+	}
+}
+
+// getEnvVariables returns the current variables and secrets of the app or environment group set by the --app and --group flags
+func getEnvVariables(ctx context.Context, client api.Client, cliConf config.CLIConfig) (envVariables, error) {
+	var envVars envVariables
+
+	if appName != "" {
+		envVarsResp, err := client.GetAppEnvVariables(ctx, cliConf.Project, cliConf.Cluster, appName, deploymentTargetName)
+		if err != nil {
+			return envVars, fmt.Errorf("could not get app env variables: %w", err)
+		}
+		if envVarsResp == nil {
+			return envVars, fmt.Errorf("could not get app env variables: response was nil")
+		}
+
+		envVars = envVariables{
+			Variables: envVarsResp.EnvVariables.Variables,
+			Secrets:   envVarsResp.EnvVariables.Secrets,
+		}
+	}
+
+	if envGroupName != "" {
+		envVarsResp, err := client.GetLatestEnvGroupVariables(ctx, cliConf.Project, cliConf.Cluster, envGroupName)
+		if err != nil {
+			return envVars, fmt.Errorf("could not get env group env variables: %w", err)
+		}
+		if envVarsResp == nil {
+			return envVars, fmt.Errorf("could not get env group variables: response was nil")
+		}
+
+		envVars = envVariables{
+			Variables: envVarsResp.Variables,
+			Secrets:   envVarsResp.Secrets,
+		}
+	}
+
+	return envVars, nil
+}
+
+// readEnvFile parses the .env file at path. An empty path returns no variables.
+func readEnvFile(path string) (map[string]string, error) {
+	if path == "" {
+		return map[string]string{}, nil
+	}
+
+	file, err := os.Open(path) // nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("could not open env file: %w", err)
+	}
+	defer file.Close() // nolint:errcheck
+
+	vars, err := diff.ParseDotEnv(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse env file %s: %w", path, err)
+	}
+
+	return vars, nil
+}
+
+// printEnvDiff prints the keys which are added, changed and removed by a push. Secret values are masked.
+func printEnvDiff(target string, changes diff.Diff) {
+	color.New(color.FgBlue, color.Bold).Printf("Changes to the environment variables of %s:\n", target) // nolint:errcheck,gosec
+
+	kind := func(secret bool) string {
+		if secret {
+			return "secret"
+		}
+		return "variable"
+	}
+
+	for _, c := range changes.Added {
+		color.New(color.FgGreen).Printf("  + %s=%s (%s)\n", c.Key, c.DisplayNew(), kind(c.Secret)) // nolint:errcheck,gosec
+	}
+
+	for _, c := range changes.Changed {
+		if c.Secret != c.WasSecret {
+			color.New(color.FgYellow).Printf("  ~ %s: %s -> %s (%s -> %s)\n", c.Key, c.DisplayOld(), c.DisplayNew(), kind(c.WasSecret), kind(c.Secret)) // nolint:errcheck,gosec
+			continue
+		}
+
+		color.New(color.FgYellow).Printf("  ~ %s: %s -> %s (%s)\n", c.Key, c.DisplayOld(), c.DisplayNew(), kind(c.Secret)) // nolint:errcheck,gosec
+	}
+
+	for _, c := range changes.Removed {
+		color.New(color.FgRed).Printf("  - %s=%s (%s)\n", c.Key, c.DisplayOld(), kind(c.WasSecret)) // nolint:errcheck,gosec
+	}
+}
+
 func writeEnvFile(envFilePath string, envVars envVariables) error {
 	// open existing file or create new file: https://pkg.go.dev/os#example-OpenFile-Append
 	envFile, err := os.OpenFile(envFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644) // nolint:gosec
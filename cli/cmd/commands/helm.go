@@ -2,16 +2,25 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
+	"text/tabwriter"
 
+	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/config"
 	"github.com/spf13/cobra"
 )
 
+var (
+	graphFormat  string
+	graphRelease string
+)
+
 func registerCommand_Helm(cliConf config.CLIConfig) *cobra.Command {
 	helmCmd := &cobra.Command{
 		Use:   "helm",
@@ -24,6 +33,107 @@ func registerCommand_Helm(cliConf config.CLIConfig) *cobra.Command {
 		},
 	}
 
+	revisionsCmd := &cobra.Command{
+		Use:   "revisions",
+		Short: "Manages the archived revisions of Helm releases",
+		Long: `Manages the revisions of Helm releases which were pruned from the cluster and archived by Porter.
+
+A release can only be rolled back to a revision which exists in the cluster. Restoring an archived revision
+writes it back to the Helm storage of the cluster, after which the release can be rolled back to it.`,
+	}
+
+	revisionsListCmd := &cobra.Command{
+		Use:   "list [release]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Lists the archived revisions of a release.",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, listArchivedRevisions)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	revisionsRestoreCmd := &cobra.Command{
+		Use:   "restore [release] [revision]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Restores an archived revision of a release into the cluster.",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, restoreArchivedRevision)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	revisionsCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"the namespace of the release",
+	)
+
+	revisionsCmd.AddCommand(revisionsListCmd)
+	revisionsCmd.AddCommand(revisionsRestoreCmd)
+	helmCmd.AddCommand(revisionsCmd)
+
+	graphCmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Exports the graph of the resources of the Helm releases in a namespace.",
+		Long: `Exports the graph of the resources of the Helm releases in a namespace, and the control, label and spec
+relations between them, including relations between resources of different releases. The graph is printed
+in the DOT language of Graphviz by default, and can be rendered with:
+
+  porter helm graph --namespace default | dot -Tsvg > graph.svg`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, exportResourceGraph)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	graphCmd.Flags().StringVar(
+		&graphFormat,
+		"format",
+		string(types.ResourceGraphFormatDOT),
+		"the format of the graph, one of dot, json",
+	)
+
+	graphCmd.Flags().StringVar(
+		&graphRelease,
+		"release",
+		"",
+		"only include the resources of this release and the resources of other releases they are related to",
+	)
+
+	impactCmd := &cobra.Command{
+		Use:   "impact [kind] [name]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Lists the resources affected by a change to a resource.",
+		Long: `Lists the resources of the Helm releases in a namespace which are affected by a change to a resource,
+such as the pods which mount a ConfigMap, or the ingresses which route to a Service:
+
+  porter helm impact ConfigMap web-config --namespace default`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, listResourceImpact)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	for _, c := range []*cobra.Command{graphCmd, impactCmd} {
+		c.Flags().StringVar(
+			&namespace,
+			"namespace",
+			"default",
+			"the namespace of the releases",
+		)
+
+		helmCmd.AddCommand(c)
+	}
+
 	return helmCmd
 }
 
@@ -58,3 +168,96 @@ func runHelm(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client
 
 	return nil
 }
+
+func listArchivedRevisions(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	resp, err := client.ListArchivedReleaseRevisions(ctx, cliConf.Project, cliConf.Cluster, namespace, args[0])
+	if err != nil {
+		return fmt.Errorf("error listing archived revisions: %w", err)
+	}
+
+	if len(resp.Revisions) == 0 {
+		fmt.Printf("Release %s in namespace %s has no archived revisions\n", args[0], namespace)
+		return nil
+	}
+
+	fmt.Printf("Archived revisions of release %s in namespace %s:\n", args[0], namespace)
+
+	for _, revision := range resp.Revisions {
+		fmt.Printf("  %d\n", revision)
+	}
+
+	return nil
+}
+
+func restoreArchivedRevision(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	revision, err := strconv.Atoi(args[1])
+	if err != nil || revision <= 0 {
+		return fmt.Errorf("invalid revision %q: must be a positive integer", args[1])
+	}
+
+	err = client.RestoreArchivedReleaseRevision(ctx, cliConf.Project, cliConf.Cluster, namespace, args[0], &types.RestoreArchivedReleaseRevisionRequest{
+		Revision: revision,
+	})
+	if err != nil {
+		return fmt.Errorf("error restoring revision %d of release %s: %w", revision, args[0], err)
+	}
+
+	color.New(color.FgGreen).Printf("Restored revision %d of release %s in namespace %s\n", revision, args[0], namespace) // nolint:errcheck,gosec
+	fmt.Printf("Run \"porter helm -- rollback %s %d --namespace %s\" to roll the release back to it\n", args[0], revision, namespace)
+
+	return nil
+}
+
+func exportResourceGraph(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	resp, err := client.GetResourceGraph(ctx, cliConf.Project, cliConf.Cluster, namespace, &types.GetResourceGraphRequest{
+		Format:  types.ResourceGraphFormat(graphFormat),
+		Release: graphRelease,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting resource graph: %w", err)
+	}
+
+	if resp.DOT != "" {
+		fmt.Print(resp.DOT)
+		return nil
+	}
+
+	graphBytes, err := json.MarshalIndent(resp.Graph, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding resource graph: %w", err)
+	}
+
+	fmt.Println(string(graphBytes))
+
+	return nil
+}
+
+func listResourceImpact(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	resp, err := client.GetResourceImpact(ctx, cliConf.Project, cliConf.Cluster, namespace, &types.GetResourceImpactRequest{
+		Kind: args[0],
+		Name: args[1],
+	})
+	if err != nil {
+		return fmt.Errorf("error getting impact of a change to %s/%s: %w", args[0], args[1], err)
+	}
+
+	if len(resp.Impacted) == 0 {
+		fmt.Printf("No resources in namespace %s are affected by a change to %s/%s\n", namespace, args[0], args[1])
+		return nil
+	}
+
+	fmt.Printf("Resources in namespace %s affected by a change to %s/%s:\n", namespace, args[0], args[1])
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "KIND", "NAME", "RELEASE", "REASON")
+
+	for _, obj := range resp.Impacted {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", obj.Kind, obj.Name, obj.Release, obj.Reason)
+	}
+
+	w.Flush() //nolint:errcheck,gosec
+
+	return nil
+}
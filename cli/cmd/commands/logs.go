@@ -4,21 +4,58 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"time"
 
+	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/config"
 	"github.com/porter-dev/porter/cli/cmd/utils"
+	v2 "github.com/porter-dev/porter/cli/cmd/v2"
 	"github.com/spf13/cobra"
 )
 
-var follow bool
+var (
+	follow       bool
+	logsService  string
+	logsRevision string
+	logsSince    string
+	logsUntil    string
+	logsGrep     string
+	logsOutput   string
+	logsLimit    uint
+)
+
+// logsV2Flags are the flags of porter logs which are only supported for apps deployed with porter.yaml v2
+var logsV2Flags = []string{"target", "service", "revision", "since", "until", "grep", "output", "limit"}
 
 func registerCommand_Logs(cliConf config.CLIConfig) *cobra.Command {
 	logsCmd := &cobra.Command{
-		Use:   "logs [release]",
+		Use:   "logs [application]",
 		Args:  cobra.ExactArgs(1),
 		Short: "Logs the output from a given application.",
+		Long: fmt.Sprintf(`
+%s
+
+Prints the logs of all services of an application, interleaved by time and prefixed with the
+name of their service. The logs can be restricted to a single service or revision of the
+application, and to a time range set with --since and --until. Both accept either an RFC 3339
+timestamp or a duration before now:
+
+  %s
+
+Log lines can be filtered with a regular expression, and printed as JSON objects, one per line:
+
+  %s
+
+For applications which are not deployed with porter.yaml v2, the logs of a single pod of the
+release are streamed.
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter logs\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter logs example-app --service web --since 2h --until 1h"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter logs example-app --grep \"error|panic\" --output json -f"),
+		),
 		Run: func(cmd *cobra.Command, args []string) {
 			err := checkLoginAndRunWithConfig(cmd, cliConf, args, logs)
 			if err != nil {
@@ -41,10 +78,79 @@ func registerCommand_Logs(cliConf config.CLIConfig) *cobra.Command {
 		false,
 		"specify if the logs should be streamed",
 	)
+
+	logsCmd.PersistentFlags().StringVarP(
+		&deploymentTargetName,
+		"target",
+		"x",
+		"",
+		"the name of the deployment target for the app",
+	)
+
+	logsCmd.PersistentFlags().StringVar(
+		&logsService,
+		"service",
+		"",
+		"only print the logs of this service of the app",
+	)
+
+	logsCmd.PersistentFlags().StringVar(
+		&logsRevision,
+		"revision",
+		"",
+		"only print the logs of this revision of the app, by revision number or id",
+	)
+
+	logsCmd.PersistentFlags().StringVar(
+		&logsSince,
+		"since",
+		"",
+		"only print logs after this time, as an RFC 3339 timestamp or a duration before now such as 2h",
+	)
+
+	logsCmd.PersistentFlags().StringVar(
+		&logsUntil,
+		"until",
+		"",
+		"only print logs before this time, as an RFC 3339 timestamp or a duration before now such as 1h",
+	)
+
+	logsCmd.PersistentFlags().StringVar(
+		&logsGrep,
+		"grep",
+		"",
+		"only print log lines matching this regular expression",
+	)
+
+	logsCmd.PersistentFlags().StringVarP(
+		&logsOutput,
+		"output",
+		"o",
+		v2.LogsOutputText,
+		"output format, either text or json",
+	)
+
+	logsCmd.PersistentFlags().UintVar(
+		&logsLimit,
+		"limit",
+		1000,
+		"maximum number of log lines to print before following logs",
+	)
+
 	return logsCmd
 }
 
-func logs(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConfig config.CLIConfig, _ config.FeatureFlags, _ *cobra.Command, args []string) error {
+func logs(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConfig config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	if featureFlags.ValidateApplyV2Enabled {
+		return appLogs(ctx, client, cliConfig, args[0])
+	}
+
+	for _, flag := range logsV2Flags {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("the --%s flag is only supported for apps deployed with porter.yaml v2", flag)
+		}
+	}
+
 	podsSimple, err := getPods(ctx, client, cliConfig, namespace, args[0])
 	if err != nil {
 		return fmt.Errorf("Could not retrieve list of pods: %s", err.Error())
@@ -108,3 +214,45 @@ func logs(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api
 
 	return err
 }
+
+func appLogs(ctx context.Context, client api.Client, cliConfig config.CLIConfig, appName string) error {
+	if logsOutput != v2.LogsOutputText && logsOutput != v2.LogsOutputJSON {
+		return fmt.Errorf("invalid output format %s: must be %s or %s", logsOutput, v2.LogsOutputText, v2.LogsOutputJSON)
+	}
+
+	now := time.Now()
+
+	since, err := v2.ParseLogTime(logsSince, now)
+	if err != nil {
+		return fmt.Errorf("invalid --since flag: %w", err)
+	}
+
+	until, err := v2.ParseLogTime(logsUntil, now)
+	if err != nil {
+		return fmt.Errorf("invalid --until flag: %w", err)
+	}
+
+	var grep *regexp.Regexp
+
+	if logsGrep != "" {
+		grep, err = regexp.Compile(logsGrep)
+		if err != nil {
+			return fmt.Errorf("invalid --grep flag: %w", err)
+		}
+	}
+
+	return v2.AppLogs(ctx, v2.AppLogsInput{
+		CLIConfig:            cliConfig,
+		Client:               client,
+		AppName:              appName,
+		DeploymentTargetName: deploymentTargetName,
+		ServiceName:          logsService,
+		Revision:             logsRevision,
+		Since:                since,
+		Until:                until,
+		Limit:                logsLimit,
+		Follow:               follow,
+		Grep:                 grep,
+		Output:               logsOutput,
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -16,6 +17,17 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	imageScanTag    string
+	imageScanDigest string
+	imageScanReport string
+	imageScanECR    bool
+
+	retentionKeepLast uint
+	retentionKeepTags string
+	retentionEnabled  bool
+)
+
 func registerCommand_Registry(cliConf config.CLIConfig) *cobra.Command {
 	registryCmd := &cobra.Command{
 		Use:     "registry",
@@ -81,6 +93,145 @@ func registerCommand_Registry(cliConf config.CLIConfig) *cobra.Command {
 		},
 	}
 
+	registryImageScanCmd := &cobra.Command{
+		Use:   "scan [repo_name]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Stores the vulnerabilities of an image, from a Trivy report or the findings of ECR image scanning",
+		Long: fmt.Sprintf(`
+%s
+
+Stores the vulnerabilities of an image of the specified image repository. Vulnerabilities are read from a
+JSON report created by Trivy, or imported from the findings of ECR image scanning for ECR registries. The
+results are stored for the digest of the image, and are checked against the severity gate of the project
+when the image of an app is updated.
+
+  %s
+
+To import the findings of ECR image scanning:
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter registry image scan\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("trivy image --format json -o report.json my-image.registry.io/web:v1 && porter registry image scan web --tag v1 --report report.json"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter registry image scan web --tag v1 --ecr"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, scanImage)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	registryImageScanCmd.Flags().StringVar(&imageScanTag, "tag", "", "the tag of the image")
+	registryImageScanCmd.Flags().StringVar(&imageScanDigest, "digest", "", "the digest of the image, if the report does not contain it")
+	registryImageScanCmd.Flags().StringVar(&imageScanReport, "report", "", "path to a JSON report created by Trivy")
+	registryImageScanCmd.Flags().BoolVar(&imageScanECR, "ecr", false, "import the findings of ECR image scanning")
+
+	registryScanGateCmd := &cobra.Command{
+		Use:   "scan-gate [severity]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Gets or sets the severity gate of the project",
+		Long: fmt.Sprintf(`
+%s
+
+Gets or sets the severity gate of the project. The image of an app cannot be updated to an image with
+vulnerabilities at or above the severity of the gate. The severity is one of critical, high, medium or
+low, and "off" removes the gate. Images which have not been scanned are not blocked.
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter registry scan-gate\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter registry scan-gate critical"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, imageScanGate)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	registryRetentionCmd := &cobra.Command{
+		Use:   "retention",
+		Short: "Commands to manage the retention policies of image repositories",
+	}
+
+	registryRetentionListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the retention policies of the image repositories of a registry",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, listRetentionPolicies)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	registryRetentionSetCmd := &cobra.Command{
+		Use:   "set [repo_name]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Creates or updates the retention policy of an image repository",
+		Long: fmt.Sprintf(`
+%s
+
+Creates or updates the retention policy of an image repository. Images which are among the last pushed images
+or have a tag matching --keep-tags are kept, and images referenced by a live app revision or Helm release are
+always kept. All other images are deleted by the registry garbage collector once the policy is enabled. Use
+"porter registry retention report" to preview which images a policy deletes before enabling it.
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter registry retention set\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter registry retention set web --keep-last 50 --keep-tags '^v[0-9]+\\.[0-9]+\\.[0-9]+$' --enable"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, setRetentionPolicy)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	registryRetentionSetCmd.Flags().UintVar(&retentionKeepLast, "keep-last", 20, "the number of most recently pushed images to keep")
+	registryRetentionSetCmd.Flags().StringVar(&retentionKeepTags, "keep-tags", "", "a regular expression for the tags of images to keep")
+	registryRetentionSetCmd.Flags().BoolVar(&retentionEnabled, "enable", false, "delete the images which are not kept by the policy")
+
+	registryRetentionDeleteCmd := &cobra.Command{
+		Use:   "delete [repo_name]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Deletes the retention policy of an image repository",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, deleteRetentionPolicy)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	registryRetentionReportCmd := &cobra.Command{
+		Use:   "report [repo_name]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Shows which images of an image repository are kept and deleted by its retention policy",
+		Long: fmt.Sprintf(`
+%s
+
+Shows which images of an image repository are kept and deleted by its retention policy, and why images are
+kept, without deleting any image. Reports can be generated for disabled policies.
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter registry retention report\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter registry retention report web"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, getRetentionReport)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
 	registryCmd.PersistentFlags().AddFlagSet(utils.RegistryFlagSet)
 
 	registryCmd.AddCommand(registryReposCmd)
@@ -91,6 +242,15 @@ func registerCommand_Registry(cliConf config.CLIConfig) *cobra.Command {
 
 	registryCmd.AddCommand(registryImageCmd)
 	registryImageCmd.AddCommand(registryImageListCmd)
+	registryImageCmd.AddCommand(registryImageScanCmd)
+
+	registryCmd.AddCommand(registryScanGateCmd)
+
+	registryCmd.AddCommand(registryRetentionCmd)
+	registryRetentionCmd.AddCommand(registryRetentionListCmd)
+	registryRetentionCmd.AddCommand(registryRetentionSetCmd)
+	registryRetentionCmd.AddCommand(registryRetentionDeleteCmd)
+	registryRetentionCmd.AddCommand(registryRetentionReportCmd)
 
 	return registryCmd
 }
@@ -210,13 +370,199 @@ func listImages(ctx context.Context, user *types.GetAuthenticatedUserResponse, c
 	w := new(tabwriter.Writer)
 	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
 
-	fmt.Fprintf(w, "%s\t%s\n", "IMAGE", "DIGEST")
+	fmt.Fprintf(w, "%s\t%s\t%s\n", "IMAGE", "DIGEST", "VULNERABILITIES")
 
 	for _, img := range imgs {
-		fmt.Fprintf(w, "%s\t%s\n", repoName+":"+img.Tag, img.Digest)
+		fmt.Fprintf(w, "%s\t%s\t%s\n", repoName+":"+img.Tag, img.Digest, formatVulnerabilitySummary(img.Vulnerabilities))
 	}
 
 	w.Flush()
 
 	return nil
 }
+
+func scanImage(ctx context.Context, user *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	req := &types.CreateImageScanRequest{
+		RepoName: args[0],
+		Tag:      imageScanTag,
+		Digest:   imageScanDigest,
+	}
+
+	switch {
+	case imageScanECR && imageScanReport != "":
+		return fmt.Errorf("only one of --ecr or --report can be set")
+	case imageScanECR:
+		req.Format = types.ImageScanFormatECR
+	case imageScanReport != "":
+		report, err := os.ReadFile(filepath.Clean(imageScanReport))
+		if err != nil {
+			return fmt.Errorf("error reading report: %w", err)
+		}
+
+		req.Format = types.ImageScanFormatTrivy
+		req.Report = report
+	default:
+		return fmt.Errorf("one of --ecr or --report must be set")
+	}
+
+	scan, err := client.CreateImageScan(ctx, cliConf.Project, cliConf.Registry, req)
+	if err != nil {
+		return err
+	}
+
+	_, _ = color.New(color.FgGreen).Printf("Stored %s scan of %s@%s: %s\n", scan.Summary.Scanner, scan.Repository, scan.Digest, formatVulnerabilitySummary(&scan.Summary))
+
+	if len(scan.Vulnerabilities) == 0 {
+		return nil
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "SEVERITY", "ID", "PACKAGE", "INSTALLED", "FIXED")
+
+	for _, vuln := range scan.Vulnerabilities {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", vuln.Severity, vuln.ID, vuln.PackageName, vuln.InstalledVersion, vuln.FixedVersion)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func imageScanGate(ctx context.Context, user *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	var policy *types.ImageScanPolicy
+	var err error
+
+	if len(args) == 0 {
+		policy, err = client.GetImageScanPolicy(ctx, cliConf.Project)
+	} else {
+		threshold := types.VulnerabilitySeverity(strings.ToUpper(args[0]))
+		if strings.EqualFold(args[0], "off") {
+			threshold = ""
+		}
+
+		policy, err = client.UpdateImageScanPolicy(ctx, cliConf.Project, &types.ImageScanPolicy{
+			SeverityThreshold: threshold,
+		})
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if policy.SeverityThreshold == "" {
+		fmt.Println("The project does not have a severity gate")
+		return nil
+	}
+
+	fmt.Printf("Images with vulnerabilities at or above %s severity are blocked\n", strings.ToLower(string(policy.SeverityThreshold)))
+
+	return nil
+}
+
+func listRetentionPolicies(ctx context.Context, user *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	resp, err := client.ListRegistryRetentionPolicies(ctx, cliConf.Project, cliConf.Registry)
+	if err != nil {
+		return err
+	}
+
+	policies := *resp
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "REPOSITORY", "KEEP LAST", "KEEP TAGS", "ENABLED", "LAST RUN")
+
+	for _, policy := range policies {
+		lastRun := "never"
+		if policy.LastRunAt != nil {
+			lastRun = fmt.Sprintf("%s (%d deleted)", policy.LastRunAt.Format("2006-01-02 15:04:05"), policy.LastDeletedCount)
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%s\t%t\t%s\n", policy.RepoName, policy.KeepLast, policy.KeepTagPattern, policy.Enabled, lastRun)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func setRetentionPolicy(ctx context.Context, user *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	policy, err := client.UpdateRegistryRetentionPolicy(ctx, cliConf.Project, cliConf.Registry, &types.UpdateRegistryRetentionPolicyRequest{
+		RepoName:       args[0],
+		KeepLast:       retentionKeepLast,
+		KeepTagPattern: retentionKeepTags,
+		Enabled:        retentionEnabled,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !policy.Enabled {
+		_, _ = color.New(color.FgGreen).Printf("Saved the retention policy of %s. The policy is disabled, so no images are deleted\n", policy.RepoName)
+		return nil
+	}
+
+	_, _ = color.New(color.FgGreen).Printf("Saved the retention policy of %s. Images which are not kept are deleted by the next garbage collection\n", policy.RepoName)
+
+	return nil
+}
+
+func deleteRetentionPolicy(ctx context.Context, user *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	err := client.DeleteRegistryRetentionPolicy(ctx, cliConf.Project, cliConf.Registry, &types.DeleteRegistryRetentionPolicyRequest{
+		RepoName: args[0],
+	})
+	if err != nil {
+		return err
+	}
+
+	_, _ = color.New(color.FgGreen).Printf("Deleted the retention policy of %s\n", args[0])
+
+	return nil
+}
+
+func getRetentionReport(ctx context.Context, user *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, cmd *cobra.Command, args []string) error {
+	report, err := client.GetRegistryRetentionReport(ctx, cliConf.Project, cliConf.Registry, &types.GetRegistryRetentionReportRequest{
+		RepoName: args[0],
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("The retention policy of %s keeps %d images and deletes %d images\n", report.Repository, len(report.Keep), len(report.Delete))
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "ACTION", "TAGS", "DIGEST", "PUSHED", "REASON")
+
+	for _, decisions := range []struct {
+		action    string
+		decisions []types.RetentionDecision
+	}{
+		{action: "keep", decisions: report.Keep},
+		{action: "delete", decisions: report.Delete},
+	} {
+		for _, decision := range decisions.decisions {
+			pushed := "unknown"
+			if decision.PushedAt != nil {
+				pushed = decision.PushedAt.Format("2006-01-02 15:04:05")
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", decisions.action, strings.Join(decision.Tags, ","), decision.Digest, pushed, decision.Reason)
+		}
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func formatVulnerabilitySummary(summary *types.VulnerabilitySummary) string {
+	if summary == nil {
+		return "not scanned"
+	}
+
+	return fmt.Sprintf("critical: %d, high: %d, medium: %d, low: %d", summary.Critical, summary.High, summary.Medium, summary.Low)
+}
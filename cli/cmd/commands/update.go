@@ -19,6 +19,7 @@ import (
 	"github.com/porter-dev/porter/cli/cmd/deploy"
 	"github.com/porter-dev/porter/cli/cmd/docker"
 	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/porter-dev/porter/internal/helm/upgrade"
 	templaterUtils "github.com/porter-dev/porter/internal/templater/utils"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,6 +42,9 @@ var (
 	normalEnvGroupVars      []string
 	secretEnvGroupVars      []string
 	waitForSuccessfulDeploy bool
+	dryRun                  bool
+	chartVersion            string
+	acknowledgeUpgradeNotes bool
 )
 
 func registerCommand_Update(cliConf config.CLIConfig) *cobra.Command {
@@ -226,10 +230,23 @@ You can update the configuration with only a new tag with the --tag flag, which
 the image that the application uses if no --values file is specified:
 
   %s
+
+To preview the changes an update would make to the resources of the application without deploying
+it, pass the --dry-run flag:
+
+  %s
+
+To upgrade the chart of the application to a new version, pass the --chart-version flag. The upgrade
+notes of the new version are shown, and you are asked to confirm the notes which require
+acknowledgement before the application is deployed:
+
+  %s
 `,
 			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter update config\":"),
 			color.New(color.FgGreen, color.Bold).Sprintf("porter update config --app example-app --values my-values.yaml"),
 			color.New(color.FgGreen, color.Bold).Sprintf("porter update config --app example-app --tag custom-tag"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter update config --app example-app --values my-values.yaml --dry-run"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter update config --app example-app --chart-version 0.50.0"),
 		),
 		Run: func(cmd *cobra.Command, args []string) {
 			err := checkLoginAndRunWithConfig(cmd, cliConf, args, updateUpgrade)
@@ -393,6 +410,27 @@ the image that the application uses if no --values file is specified:
 
 	updateConfigCmd.MarkPersistentFlagRequired("app")
 
+	updateConfigCmd.Flags().BoolVar(
+		&dryRun,
+		"dry-run",
+		false,
+		"show the changes the update would make to the resources of the application, without deploying it",
+	)
+
+	updateConfigCmd.Flags().StringVar(
+		&chartVersion,
+		"chart-version",
+		"",
+		"the chart version to upgrade the application to",
+	)
+
+	updateConfigCmd.Flags().BoolVar(
+		&acknowledgeUpgradeNotes,
+		"acknowledge-upgrade-notes",
+		false,
+		"acknowledge the upgrade notes of the new chart version without being prompted",
+	)
+
 	updateEnvGroupCmd.PersistentFlags().StringVar(
 		&name,
 		"name",
@@ -616,6 +654,17 @@ func updateUpgrade(ctx context.Context, _ *types.GetAuthenticatedUserResponse, c
 		return err
 	}
 
+	if dryRun {
+		return updateDiffWithAgent(ctx, updateAgent)
+	}
+
+	if chartVersion != "" && !acknowledgeUpgradeNotes {
+		err = updateAcknowledgeUpgradeNotes(ctx, client, updateAgent)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = updateUpgradeWithAgent(ctx, updateAgent)
 
 	if err != nil {
@@ -824,7 +873,9 @@ func updateGetAgent(ctx context.Context, client api.Client, cliConf config.CLICo
 			AdditionalEnv:   additionalEnv,
 			UseCache:        useCache,
 		},
-		Local: source != "github",
+		Local:                   source != "github",
+		ChartVersion:            chartVersion,
+		AcknowledgeUpgradeNotes: acknowledgeUpgradeNotes,
 	})
 }
 
@@ -997,6 +1048,44 @@ func updateUpgradeWithAgent(ctx context.Context, updateAgent *deploy.DeployAgent
 		return err
 	}
 
+	valuesObj, err = updateMergeAdditionalEnv(ctx, updateAgent, valuesObj)
+	if err != nil {
+		return err
+	}
+
+	err = updateAgent.UpdateImageAndValues(ctx, valuesObj)
+
+	if err != nil {
+		if stream {
+			updateAgent.StreamEvent(ctx, types.SubEvent{ //nolint:errcheck,gosec // do not want to change logic of CLI. New linter error
+				EventID: "upgrade",
+				Name:    "Upgrade",
+				Index:   320,
+				Status:  types.EventStatusFailed,
+				Info:    err.Error(),
+			})
+		}
+		return err
+	}
+
+	if stream {
+		updateAgent.StreamEvent(ctx, types.SubEvent{ //nolint:errcheck,gosec // do not want to change logic of CLI. New linter error
+			EventID: "upgrade",
+			Name:    "Upgrade",
+			Index:   330,
+			Status:  types.EventStatusSuccess,
+			Info:    "",
+		})
+	}
+
+	color.New(color.FgGreen).Println("Successfully updated", app)
+
+	return nil
+}
+
+// updateMergeAdditionalEnv adds the environment variables passed with the --env flag to the normal environment
+// variables in the values
+func updateMergeAdditionalEnv(ctx context.Context, updateAgent *deploy.DeployAgent, valuesObj map[string]interface{}) (map[string]interface{}, error) {
 	if len(updateAgent.Opts.AdditionalEnv) > 0 {
 		syncedEnv, err := deploy.GetSyncedEnv(
 			ctx,
@@ -1008,12 +1097,12 @@ func updateUpgradeWithAgent(ctx context.Context, updateAgent *deploy.DeployAgent
 			false,
 		)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		for k := range updateAgent.Opts.AdditionalEnv {
 			if _, ok := syncedEnv[k]; ok {
-				return fmt.Errorf("environment variable %s already exists as part of a synced environment group", k)
+				return nil, fmt.Errorf("environment variable %s already exists as part of a synced environment group", k)
 			}
 		}
 
@@ -1026,7 +1115,7 @@ func updateUpgradeWithAgent(ctx context.Context, updateAgent *deploy.DeployAgent
 			false,
 		)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		// add the additional environment variables to container.env.normal
@@ -1043,36 +1132,197 @@ func updateUpgradeWithAgent(ctx context.Context, updateAgent *deploy.DeployAgent
 		})
 	}
 
-	err = updateAgent.UpdateImageAndValues(ctx, valuesObj)
+	return valuesObj, nil
+}
 
+// updateDiffWithAgent prints the changes an upgrade with the values passed to "porter update config" would make to
+// the resources of the application, without upgrading it
+func updateDiffWithAgent(ctx context.Context, updateAgent *deploy.DeployAgent) error {
+	color.New(color.FgGreen).Println("Computing configuration changes for", app) //nolint:errcheck,gosec
+
+	diff, err := updateGetDiff(ctx, updateAgent)
 	if err != nil {
-		if stream {
-			updateAgent.StreamEvent(ctx, types.SubEvent{ //nolint:errcheck,gosec // do not want to change logic of CLI. New linter error
-				EventID: "upgrade",
-				Name:    "Upgrade",
-				Index:   320,
-				Status:  types.EventStatusFailed,
-				Info:    err.Error(),
-			})
+		return err
+	}
+
+	if len(diff.UpgradeNotes) != 0 {
+		updatePrintUpgradeNotes(diff.UpgradeNotes)
+	}
+
+	if len(diff.Resources) == 0 {
+		fmt.Printf("No changes to the resources of %s\n", app)
+		return nil
+	}
+
+	for _, res := range diff.Resources {
+		resColor := color.FgYellow
+
+		switch res.Action {
+		case types.ResourceDiffActionCreate:
+			resColor = color.FgGreen
+		case types.ResourceDiffActionDelete:
+			resColor = color.FgRed
+		}
+
+		color.New(resColor, color.Bold).Printf("%s %s %s/%s\n", res.Action, res.Kind, res.Namespace, res.Name) //nolint:errcheck,gosec
+
+		for _, line := range strings.Split(strings.TrimSuffix(res.Diff, "\n"), "\n") {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				color.New(color.FgGreen).Println(line) //nolint:errcheck,gosec
+			case strings.HasPrefix(line, "-"):
+				color.New(color.FgRed).Println(line) //nolint:errcheck,gosec
+			default:
+				fmt.Println(line)
+			}
 		}
+
+		fmt.Println()
+	}
+
+	fmt.Printf("%d resource(s) of %s would change. Run the command without --dry-run to apply the changes.\n", len(diff.Resources), app)
+
+	return nil
+}
+
+// updateAcknowledgeUpgradeNotes shows the upgrade notes between the current and the new chart version of the
+// application, and asks the user to confirm them if any of them require acknowledgement
+func updateAcknowledgeUpgradeNotes(ctx context.Context, client api.Client, updateAgent *deploy.DeployAgent) error {
+	notes, err := updateGetUpgradeNotes(ctx, client, updateAgent)
+	if err != nil {
 		return err
 	}
 
-	if stream {
-		updateAgent.StreamEvent(ctx, types.SubEvent{ //nolint:errcheck,gosec // do not want to change logic of CLI. New linter error
-			EventID: "upgrade",
-			Name:    "Upgrade",
-			Index:   330,
-			Status:  types.EventStatusSuccess,
-			Info:    "",
-		})
+	if len(notes) == 0 {
+		return nil
 	}
 
-	color.New(color.FgGreen).Println("Successfully updated", app)
+	updatePrintUpgradeNotes(notes)
+
+	requiresAcknowledgement := false
+
+	for _, note := range notes {
+		if note.RequiresAcknowledgement {
+			requiresAcknowledgement = true
+			break
+		}
+	}
+
+	if !requiresAcknowledgement {
+		return nil
+	}
+
+	userResp, err := utils.PromptPlaintext(
+		fmt.Sprintf(
+			`Have you read the upgrade notes above and would you like to upgrade %s to chart version %s? %s `,
+			app,
+			chartVersion,
+			color.New(color.FgCyan).Sprintf("[y/n]"),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	if userResp := strings.ToLower(userResp); userResp != "y" && userResp != "yes" {
+		return fmt.Errorf("upgrade notes were not acknowledged, aborting upgrade of %s", app)
+	}
+
+	updateAgent.Opts.AcknowledgeUpgradeNotes = true
 
 	return nil
 }
 
+// updateGetUpgradeNotes gets the upgrade notes between the current and the new chart version of the application
+// from the default Helm repositories of the Porter instance. Charts which are not found in these repositories
+// have no notes here: the upgrade is still rejected by the server if it has notes which require acknowledgement.
+func updateGetUpgradeNotes(ctx context.Context, client api.Client, updateAgent *deploy.DeployAgent) ([]*upgrade.UpgradeNote, error) {
+	if updateAgent.Release.Chart == nil || updateAgent.Release.Chart.Metadata == nil {
+		return nil, nil
+	}
+
+	serverMetadata, err := client.GetPorterInstanceMetadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching Porter instance metadata: %w", err)
+	}
+
+	appRepoURL := serverMetadata.DefaultAppHelmRepoURL
+	if appRepoURL == "" {
+		appRepoURL = "https://charts.getporter.dev"
+	}
+
+	addonRepoURL := serverMetadata.DefaultAddonHelmRepoURL
+	if addonRepoURL == "" {
+		addonRepoURL = "https://chart-addons.getporter.dev"
+	}
+
+	for _, repoURL := range []string{appRepoURL, addonRepoURL} {
+		resp, err := client.GetTemplateUpgradeNotes(
+			ctx,
+			updateAgent.Opts.ProjectID,
+			updateAgent.Release.Chart.Metadata.Name,
+			chartVersion,
+			&types.GetTemplateUpgradeNotesRequest{
+				TemplateGetBaseRequest: types.TemplateGetBaseRequest{
+					RepoURL: repoURL,
+				},
+				PrevVersion: updateAgent.Release.Chart.Metadata.Version,
+			},
+		)
+		if err == nil {
+			return resp.UpgradeNotes, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// updateGetDiff runs a dry run of an upgrade with the values passed to "porter update config"
+func updateGetDiff(ctx context.Context, updateAgent *deploy.DeployAgent) (*types.ReleaseDiff, error) {
+	valuesObj, err := readValuesFile()
+	if err != nil {
+		return nil, err
+	}
+
+	valuesObj, err = updateMergeAdditionalEnv(ctx, updateAgent, valuesObj)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := updateAgent.DiffImageAndValues(ctx, valuesObj)
+	if err != nil {
+		return nil, fmt.Errorf("error running upgrade dry run: %w", err)
+	}
+
+	return diff, nil
+}
+
+func updatePrintUpgradeNotes(notes []*upgrade.UpgradeNote) {
+	color.New(color.FgBlue, color.Bold).Printf("Upgrade notes for chart version %s:\n", chartVersion) //nolint:errcheck,gosec
+
+	for _, note := range notes {
+		noteColor := color.FgWhite
+		if note.RequiresAcknowledgement {
+			noteColor = color.FgYellow
+		}
+
+		color.New(noteColor).Printf("%s -> %s: %s\n", note.PreviousVersion, note.TargetVersion, note.Note) //nolint:errcheck,gosec
+
+		for _, migration := range note.Migrations {
+			switch migration.Type {
+			case upgrade.MigrationRenameKey:
+				fmt.Printf("  renames %s to %s\n", migration.Key, migration.NewKey)
+			case upgrade.MigrationSetDefault:
+				fmt.Printf("  sets %s to %v if it is not set\n", migration.Key, migration.Value)
+			case upgrade.MigrationRemoveKey:
+				fmt.Printf("  removes %s\n", migration.Key)
+			}
+		}
+	}
+
+	fmt.Println()
+}
+
 func checkDeploymentStatus(ctx context.Context, client api.Client, cliConfig config.CLIConfig) error {
 	color.New(color.FgBlue).Println("waiting for deployment to be ready, this may take a few minutes and will time out if it takes longer than 30 minutes")
 
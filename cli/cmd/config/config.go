@@ -17,14 +17,23 @@ import (
 
 var home = homedir.HomeDir()
 
+// loadedConfigFile is the content of ~/.porter/porter.yaml, which is loaded by InitAndLoadConfig
+var loadedConfigFile *configFile
+
 // CLIConfig is the set of shared configuration options for the CLI commands.
-// This config is used by viper: calling Set() function for any parameter will
-// update the corresponding field in the viper config file.
+// The options other than the driver are read from the context used by the CLI:
+// calling Set() function for any of these options will update the corresponding
+// field of that context in the config file.
 type CLIConfig struct {
 	// Driver can be either "docker" or "local", and represents which driver is
 	// used to run an instance of the server.
 	Driver string `yaml:"driver"`
 
+	// Context is the name of the context used by the CLI, which is either set
+	// by the PORTER_CONTEXT environment variable or the current context of the
+	// config file
+	Context string `yaml:"-"`
+
 	Host    string `yaml:"host"`
 	Project uint   `yaml:"project"`
 	Cluster uint   `yaml:"cluster"`
@@ -55,15 +64,20 @@ func InitAndLoadConfig() (CLIConfig, error) {
 	if err != nil {
 		return config, fmt.Errorf("unable to get or create porter directory: %w", err)
 	}
-	viper.SetConfigName("porter")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(porterDir)
 
 	err = createAndLoadPorterYaml(porterDir)
 	if err != nil {
 		return config, fmt.Errorf("unable to load porter config: %w", err)
 	}
 
+	contextName := loadedConfigFile.activeContextName()
+	if err := ValidateContextName(contextName); err != nil {
+		return config, err
+	}
+
+	// the settings of the config file have the lowest precedence, so they are set as defaults in viper
+	setConfigDefaults(loadedConfigFile, contextName)
+
 	utils.DriverFlagSet.StringVar(
 		&config.Driver,
 		"driver",
@@ -154,9 +168,37 @@ func InitAndLoadConfig() (CLIConfig, error) {
 		return config, fmt.Errorf("unable to unmarshal porter config: %w", err)
 	}
 
+	config.Context = contextName
+
 	return config, nil
 }
 
+// setConfigDefaults sets the driver and the settings of a context of the config file as defaults in viper. Unset
+// settings are skipped so that the defaults of the flags are used instead.
+func setConfigDefaults(file *configFile, contextName string) {
+	if file.Driver != "" {
+		viper.SetDefault("driver", file.Driver)
+	}
+
+	ctx := file.context(contextName)
+
+	defaults := map[string]interface{}{
+		"host":       ctx.Host,
+		"project":    ctx.Project,
+		"cluster":    ctx.Cluster,
+		"token":      ctx.Token,
+		"registry":   ctx.Registry,
+		"helmrepo":   ctx.HelmRepo,
+		"kubeconfig": ctx.Kubeconfig,
+	}
+
+	for key, value := range defaults {
+		if value != "" && value != uint(0) {
+			viper.SetDefault(key, value)
+		}
+	}
+}
+
 // getOrCreatePorterDirectoryAndConfig checks that the .porter folder exists; create if not
 func getOrCreatePorterDirectoryAndConfig() (string, error) {
 	porterDir := filepath.Join(home, ".porter")
@@ -174,27 +216,55 @@ func getOrCreatePorterDirectoryAndConfig() (string, error) {
 	return porterDir, nil
 }
 
-// createAndLoadPorterYaml loads a porter.yaml config into Viper if it exists, or creates the file if it does not
+// createAndLoadPorterYaml loads a porter.yaml config if it exists, or creates the file if it does not.
+// Configs created before contexts were introduced are migrated to a default context.
 func createAndLoadPorterYaml(porterDir string) error {
-	err := viper.ReadInConfig()
+	path := filepath.Join(porterDir, "porter.yaml")
+
+	_, err := os.Stat(path)
 	if err != nil {
-		_, ok := err.(viper.ConfigFileNotFoundError)
-		if !ok {
+		if !os.IsNotExist(err) {
 			return fmt.Errorf("unknown error reading ~/.porter/porter.yaml config: %w", err)
 		}
 
-		err := os.WriteFile(filepath.Join(porterDir, "porter.yaml"), []byte{}, 0o644) //nolint:gosec // do not want to change program logic. Should be addressed later
+		err := os.WriteFile(path, []byte{}, 0o600)
 		if err != nil {
 			return fmt.Errorf("unable to create ~/.porter/porter.yaml config: %w", err)
 		}
 	}
+
+	file, err := loadConfigFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read ~/.porter/porter.yaml config: %w", err)
+	}
+
+	loadedConfigFile = file
+
 	return nil
 }
 
+// updateContext applies update to the context used by the CLI and writes the config file
+func (c *CLIConfig) updateContext(update func(ctx *ContextConfig)) error {
+	if loadedConfigFile == nil {
+		return errConfigNotLoaded
+	}
+
+	contextName := c.Context
+	if contextName == "" {
+		contextName = loadedConfigFile.activeContextName()
+	}
+
+	return loadedConfigFile.updateContext(contextName, update)
+}
+
 func (c *CLIConfig) SetDriver(driver string) error {
-	viper.Set("driver", driver)
+	if loadedConfigFile == nil {
+		return errConfigNotLoaded
+	}
+
+	loadedConfigFile.Driver = driver
 	color.New(color.FgGreen).Printf("Set the current driver as %s\n", driver)
-	err := viper.WriteConfig()
+	err := loadedConfigFile.save()
 	if err != nil {
 		return err
 	}
@@ -208,14 +278,14 @@ func (c *CLIConfig) SetHost(host string) error {
 	// a trailing / can lead to errors with the api server
 	host = strings.TrimRight(host, "/")
 
-	viper.Set("host", host)
-
-	// let us clear the project ID, cluster ID, and token when we reset a host
-	viper.Set("project", 0)
-	viper.Set("cluster", 0)
-	viper.Set("token", "")
+	err := c.updateContext(func(ctx *ContextConfig) {
+		ctx.Host = host
 
-	err := viper.WriteConfig()
+		// let us clear the project ID, cluster ID, and token when we reset a host
+		ctx.Project = 0
+		ctx.Cluster = 0
+		ctx.Token = ""
+	})
 	if err != nil {
 		return err
 	}
@@ -232,19 +302,19 @@ func (c *CLIConfig) SetHost(host string) error {
 
 // SetProject sets a project for all API commands
 func (c *CLIConfig) SetProject(ctx context.Context, apiClient api.Client, projectID uint) error {
-	viper.Set("project", projectID)
+	err := c.updateContext(func(ctx *ContextConfig) {
+		ctx.Project = projectID
+	})
+	if err != nil {
+		return err
+	}
 
 	color.New(color.FgGreen).Printf("Set the current project as %d\n", projectID)
 
-	if c.Kubeconfig != "" || viper.IsSet("kubeconfig") {
+	if c.Kubeconfig != "" {
 		color.New(color.FgYellow).Println("Please change local kubeconfig if needed")
 	}
 
-	err := viper.WriteConfig()
-	if err != nil {
-		return err
-	}
-
 	c.Project = projectID
 
 	resp, err := apiClient.ListProjectClusters(ctx, projectID)
@@ -259,27 +329,28 @@ func (c *CLIConfig) SetProject(ctx context.Context, apiClient api.Client, projec
 }
 
 func (c *CLIConfig) SetCluster(clusterID uint) error {
-	viper.Set("cluster", clusterID)
+	err := c.updateContext(func(ctx *ContextConfig) {
+		ctx.Cluster = clusterID
+	})
+	if err != nil {
+		return err
+	}
 
 	color.New(color.FgGreen).Printf("Set the current cluster as %d\n", clusterID)
 
-	if c.Kubeconfig != "" || viper.IsSet("kubeconfig") {
+	if c.Kubeconfig != "" {
 		color.New(color.FgYellow).Println("Please change local kubeconfig if needed")
 	}
 
-	err := viper.WriteConfig()
-	if err != nil {
-		return err
-	}
-
 	c.Cluster = clusterID
 
 	return nil
 }
 
 func (c *CLIConfig) SetToken(token string) error {
-	viper.Set("token", token)
-	err := viper.WriteConfig()
+	err := c.updateContext(func(ctx *ContextConfig) {
+		ctx.Token = token
+	})
 	if err != nil {
 		return err
 	}
@@ -290,26 +361,30 @@ func (c *CLIConfig) SetToken(token string) error {
 }
 
 func (c *CLIConfig) SetRegistry(registryID uint) error {
-	viper.Set("registry", registryID)
-	color.New(color.FgGreen).Printf("Set the current registry as %d\n", registryID)
-	err := viper.WriteConfig()
+	err := c.updateContext(func(ctx *ContextConfig) {
+		ctx.Registry = registryID
+	})
 	if err != nil {
 		return err
 	}
 
+	color.New(color.FgGreen).Printf("Set the current registry as %d\n", registryID)
+
 	c.Registry = registryID
 
 	return nil
 }
 
 func (c *CLIConfig) SetHelmRepo(helmRepoID uint) error {
-	viper.Set("helm_repo", helmRepoID)
-	color.New(color.FgGreen).Printf("Set the current Helm repo as %d\n", helmRepoID)
-	err := viper.WriteConfig()
+	err := c.updateContext(func(ctx *ContextConfig) {
+		ctx.HelmRepo = helmRepoID
+	})
 	if err != nil {
 		return err
 	}
 
+	color.New(color.FgGreen).Printf("Set the current Helm repo as %d\n", helmRepoID)
+
 	c.HelmRepo = helmRepoID
 
 	return nil
@@ -325,14 +400,15 @@ func (c *CLIConfig) SetKubeconfig(kubeconfig string) error {
 		return fmt.Errorf("%s does not exist", path)
 	}
 
-	viper.Set("kubeconfig", path)
-	color.New(color.FgGreen).Printf("Set the path to kubeconfig as %s\n", path)
-	err = viper.WriteConfig()
-
+	err = c.updateContext(func(ctx *ContextConfig) {
+		ctx.Kubeconfig = path
+	})
 	if err != nil {
 		return err
 	}
 
+	color.New(color.FgGreen).Printf("Set the path to kubeconfig as %s\n", path)
+
 	c.Kubeconfig = kubeconfig
 
 	return nil
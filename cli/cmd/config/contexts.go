@@ -0,0 +1,287 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultContextName is the name of the context which holds the settings of configs created before contexts
+// were introduced, and of new configs
+const DefaultContextName = "default"
+
+// ContextEnvVar is the environment variable which overrides the current context of the config file
+const ContextEnvVar = "PORTER_CONTEXT"
+
+var contextNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// errConfigNotLoaded is returned when contexts are managed before the config file was loaded
+var errConfigNotLoaded = errors.New("porter config has not been loaded")
+
+// ContextConfig is the set of settings of a named context. Every context targets its own Porter instance, project
+// and cluster, and holds its own token.
+type ContextConfig struct {
+	Host    string `yaml:"host,omitempty"`
+	Project uint   `yaml:"project,omitempty"`
+	Cluster uint   `yaml:"cluster,omitempty"`
+
+	Token string `yaml:"token,omitempty"`
+
+	Registry   uint   `yaml:"registry,omitempty"`
+	HelmRepo   uint   `yaml:"helm_repo,omitempty"`
+	Kubeconfig string `yaml:"kubeconfig,omitempty"`
+}
+
+// NamedContext is a context of the config file along with its name
+type NamedContext struct {
+	Name string
+	ContextConfig
+}
+
+// configFile is the layout of ~/.porter/porter.yaml
+type configFile struct {
+	Driver         string                    `yaml:"driver,omitempty"`
+	CurrentContext string                    `yaml:"current_context,omitempty"`
+	Contexts       map[string]*ContextConfig `yaml:"contexts,omitempty"`
+
+	path string
+}
+
+// legacyConfigFile is the layout of ~/.porter/porter.yaml before contexts were introduced, where all settings were
+// stored at the top level of the file
+type legacyConfigFile struct {
+	Host       string `yaml:"host"`
+	Project    uint   `yaml:"project"`
+	Cluster    uint   `yaml:"cluster"`
+	Token      string `yaml:"token"`
+	Registry   uint   `yaml:"registry"`
+	HelmRepo   uint   `yaml:"helm_repo"`
+	Kubeconfig string `yaml:"kubeconfig"`
+
+	// HelmRepoFlag is the key under which the helm repo was written when it was set with the --helmrepo flag
+	HelmRepoFlag uint `yaml:"helmrepo"`
+}
+
+// loadConfigFile reads the config file at path. Configs without contexts are migrated to a config with a single
+// default context holding their settings, and the migrated config is written back to path.
+func loadConfigFile(path string) (*configFile, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is always within the porter directory
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	file := &configFile{}
+
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("error parsing config file: %w", err)
+	}
+
+	file.path = path
+
+	if len(file.Contexts) > 0 {
+		if file.CurrentContext == "" {
+			file.CurrentContext = DefaultContextName
+		}
+
+		return file, nil
+	}
+
+	legacy := &legacyConfigFile{}
+
+	if err := yaml.Unmarshal(data, legacy); err != nil {
+		return nil, fmt.Errorf("error parsing config file: %w", err)
+	}
+
+	helmRepo := legacy.HelmRepo
+	if helmRepo == 0 {
+		helmRepo = legacy.HelmRepoFlag
+	}
+
+	file.CurrentContext = DefaultContextName
+	file.Contexts = map[string]*ContextConfig{
+		DefaultContextName: {
+			Host:       legacy.Host,
+			Project:    legacy.Project,
+			Cluster:    legacy.Cluster,
+			Token:      legacy.Token,
+			Registry:   legacy.Registry,
+			HelmRepo:   helmRepo,
+			Kubeconfig: legacy.Kubeconfig,
+		},
+	}
+
+	if err := file.save(); err != nil {
+		return nil, fmt.Errorf("error migrating config file to contexts: %w", err)
+	}
+
+	return file, nil
+}
+
+// save writes the config file to its path. Since the file holds the tokens of all contexts, it is only readable by
+// its owner, including when it was created with broader permissions by an older version of the CLI.
+func (f *configFile) save() error {
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("error marshalling config file: %w", err)
+	}
+
+	if err := os.WriteFile(f.path, data, 0o600); err != nil {
+		return fmt.Errorf("error writing config file: %w", err)
+	}
+
+	if err := os.Chmod(f.path, 0o600); err != nil {
+		return fmt.Errorf("error setting permissions of config file: %w", err)
+	}
+
+	return nil
+}
+
+// activeContextName returns the name of the context used by the CLI, which is the context set by the PORTER_CONTEXT
+// environment variable if it is set, and the current context of the file otherwise
+func (f *configFile) activeContextName() string {
+	if name := os.Getenv(ContextEnvVar); name != "" {
+		return name
+	}
+
+	return f.CurrentContext
+}
+
+// context returns the context with the given name. Contexts which do not exist yet, for instance when PORTER_CONTEXT
+// is set to a new name, are returned empty and only added to the file once they are updated.
+func (f *configFile) context(name string) *ContextConfig {
+	if ctx, ok := f.Contexts[name]; ok && ctx != nil {
+		return ctx
+	}
+
+	return &ContextConfig{}
+}
+
+// updateContext applies update to the context with the given name, creating the context if it does not exist, and
+// writes the config file
+func (f *configFile) updateContext(name string, update func(ctx *ContextConfig)) error {
+	ctx := f.context(name)
+	update(ctx)
+
+	if f.Contexts == nil {
+		f.Contexts = make(map[string]*ContextConfig)
+	}
+
+	f.Contexts[name] = ctx
+
+	return f.save()
+}
+
+// ValidateContextName checks that a context name only contains alphanumeric characters, '.', '_' and '-'
+func ValidateContextName(name string) error {
+	if !contextNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid context name %q: context names must start with an alphanumeric character and only contain alphanumeric characters, '.', '_' and '-'", name)
+	}
+
+	return nil
+}
+
+// GetContexts returns all contexts of the config file sorted by name, along with the name of the context used by the
+// CLI. The context used by the CLI may not be part of the returned contexts if it was set by PORTER_CONTEXT and was
+// never updated.
+func (c *CLIConfig) GetContexts() ([]NamedContext, string, error) {
+	if loadedConfigFile == nil {
+		return nil, "", errConfigNotLoaded
+	}
+
+	contexts := make([]NamedContext, 0, len(loadedConfigFile.Contexts))
+
+	for name, ctx := range loadedConfigFile.Contexts {
+		if ctx == nil {
+			ctx = &ContextConfig{}
+		}
+
+		contexts = append(contexts, NamedContext{
+			Name:          name,
+			ContextConfig: *ctx,
+		})
+	}
+
+	sort.Slice(contexts, func(i, j int) bool {
+		return contexts[i].Name < contexts[j].Name
+	})
+
+	return contexts, c.Context, nil
+}
+
+// UseContext sets the current context of the config file, and creates the context if it does not exist. It returns
+// whether the context was created. The new current context is only used by the CLI if PORTER_CONTEXT is not set.
+func (c *CLIConfig) UseContext(name string) (bool, error) {
+	if loadedConfigFile == nil {
+		return false, errConfigNotLoaded
+	}
+
+	if err := ValidateContextName(name); err != nil {
+		return false, err
+	}
+
+	_, exists := loadedConfigFile.Contexts[name]
+
+	loadedConfigFile.CurrentContext = name
+
+	err := loadedConfigFile.updateContext(name, func(ctx *ContextConfig) {})
+	if err != nil {
+		return false, err
+	}
+
+	if os.Getenv(ContextEnvVar) == "" {
+		ctx := loadedConfigFile.context(name)
+
+		c.Context = name
+		c.Host = ctx.Host
+		c.Project = ctx.Project
+		c.Cluster = ctx.Cluster
+		c.Token = ctx.Token
+		c.Registry = ctx.Registry
+		c.HelmRepo = ctx.HelmRepo
+		c.Kubeconfig = ctx.Kubeconfig
+	}
+
+	return !exists, nil
+}
+
+// RenameContext renames a context of the config file. If the context is the current context of the file, the
+// current context is renamed as well.
+func (c *CLIConfig) RenameContext(oldName string, newName string) error {
+	if loadedConfigFile == nil {
+		return errConfigNotLoaded
+	}
+
+	ctx, ok := loadedConfigFile.Contexts[oldName]
+	if !ok {
+		return fmt.Errorf("context %s does not exist", oldName)
+	}
+
+	if err := ValidateContextName(newName); err != nil {
+		return err
+	}
+
+	if _, exists := loadedConfigFile.Contexts[newName]; exists {
+		return fmt.Errorf("context %s already exists", newName)
+	}
+
+	delete(loadedConfigFile.Contexts, oldName)
+	loadedConfigFile.Contexts[newName] = ctx
+
+	if loadedConfigFile.CurrentContext == oldName {
+		loadedConfigFile.CurrentContext = newName
+	}
+
+	if err := loadedConfigFile.save(); err != nil {
+		return err
+	}
+
+	if c.Context == oldName && os.Getenv(ContextEnvVar) == "" {
+		c.Context = newName
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadConfigFile(t *testing.T) {
+	tests := []struct {
+		name                   string
+		content                string
+		expectedCurrentContext string
+		expectedContexts       map[string]*ContextConfig
+		expectedDriver         string
+	}{
+		{
+			name:                   "empty file",
+			content:                "",
+			expectedCurrentContext: DefaultContextName,
+			expectedContexts: map[string]*ContextConfig{
+				DefaultContextName: {},
+			},
+		},
+		{
+			name: "legacy top level settings",
+			content: `driver: local
+host: https://dashboard.getporter.dev
+project: 1
+cluster: 2
+token: secret
+registry: 3
+helm_repo: 4
+kubeconfig: /home/user/.kube/config
+`,
+			expectedCurrentContext: DefaultContextName,
+			expectedDriver:         "local",
+			expectedContexts: map[string]*ContextConfig{
+				DefaultContextName: {
+					Host:       "https://dashboard.getporter.dev",
+					Project:    1,
+					Cluster:    2,
+					Token:      "secret",
+					Registry:   3,
+					HelmRepo:   4,
+					Kubeconfig: "/home/user/.kube/config",
+				},
+			},
+		},
+		{
+			name: "legacy helm repo set with the helmrepo flag",
+			content: `host: https://dashboard.getporter.dev
+helmrepo: 5
+`,
+			expectedCurrentContext: DefaultContextName,
+			expectedContexts: map[string]*ContextConfig{
+				DefaultContextName: {
+					Host:     "https://dashboard.getporter.dev",
+					HelmRepo: 5,
+				},
+			},
+		},
+		{
+			name: "legacy helm_repo takes precedence over helmrepo",
+			content: `helm_repo: 4
+helmrepo: 5
+`,
+			expectedCurrentContext: DefaultContextName,
+			expectedContexts: map[string]*ContextConfig{
+				DefaultContextName: {
+					HelmRepo: 4,
+				},
+			},
+		},
+		{
+			name: "existing contexts",
+			content: `driver: docker
+current_context: staging
+contexts:
+  default:
+    host: https://dashboard.getporter.dev
+    project: 1
+  staging:
+    host: https://staging.example.com
+    token: staging-token
+`,
+			expectedCurrentContext: "staging",
+			expectedDriver:         "docker",
+			expectedContexts: map[string]*ContextConfig{
+				DefaultContextName: {
+					Host:    "https://dashboard.getporter.dev",
+					Project: 1,
+				},
+				"staging": {
+					Host:  "https://staging.example.com",
+					Token: "staging-token",
+				},
+			},
+		},
+		{
+			name: "existing contexts without a current context",
+			content: `contexts:
+  default:
+    project: 1
+`,
+			expectedCurrentContext: DefaultContextName,
+			expectedContexts: map[string]*ContextConfig{
+				DefaultContextName: {
+					Project: 1,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "porter.yaml")
+
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("error writing config file: %v", err)
+			}
+
+			file, err := loadConfigFile(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if file.CurrentContext != tt.expectedCurrentContext {
+				t.Errorf("expected current context %q, got %q", tt.expectedCurrentContext, file.CurrentContext)
+			}
+
+			if file.Driver != tt.expectedDriver {
+				t.Errorf("expected driver %q, got %q", tt.expectedDriver, file.Driver)
+			}
+
+			if !reflect.DeepEqual(file.Contexts, tt.expectedContexts) {
+				t.Errorf("expected contexts %+v, got %+v", tt.expectedContexts, file.Contexts)
+			}
+
+			// loading the file again, which was migrated if it had no contexts, must give the same config
+			reloaded, err := loadConfigFile(path)
+			if err != nil {
+				t.Fatalf("unexpected error reloading config file: %v", err)
+			}
+
+			if reloaded.CurrentContext != file.CurrentContext || reloaded.Driver != file.Driver ||
+				!reflect.DeepEqual(reloaded.Contexts, file.Contexts) {
+				t.Errorf("expected reloaded config %+v to equal loaded config %+v", reloaded, file)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "porter.yaml")
+
+	if err := os.WriteFile(path, []byte("contexts: [\n"), 0o600); err != nil {
+		t.Fatalf("error writing config file: %v", err)
+	}
+
+	if _, err := loadConfigFile(path); err == nil {
+		t.Fatalf("expected an error for an invalid config file")
+	}
+}
+
+func TestConfigFileSaveRestrictsPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "porter.yaml")
+
+	if err := os.WriteFile(path, []byte("token: secret\n"), 0o644); err != nil { //nolint:gosec // config files of older versions were world readable
+		t.Fatalf("error writing config file: %v", err)
+	}
+
+	if _, err := loadConfigFile(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("error reading config file: %v", err)
+	}
+
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("expected config file permissions 0600, got %#o", perm)
+	}
+}
+
+func TestActiveContextUnsavedContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "porter.yaml")
+
+	content := `current_context: default
+contexts:
+  default:
+    project: 1
+`
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("error writing config file: %v", err)
+	}
+
+	file, err := loadConfigFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if name := file.activeContextName(); name != DefaultContextName {
+		t.Errorf("expected active context %q without %s, got %q", DefaultContextName, ContextEnvVar, name)
+	}
+
+	t.Setenv(ContextEnvVar, "unsaved")
+
+	if name := file.activeContextName(); name != "unsaved" {
+		t.Fatalf("expected active context %q, got %q", "unsaved", name)
+	}
+
+	if ctx := file.context("unsaved"); !reflect.DeepEqual(ctx, &ContextConfig{}) {
+		t.Errorf("expected unsaved context to be empty, got %+v", ctx)
+	}
+
+	if _, exists := file.Contexts["unsaved"]; exists {
+		t.Fatalf("expected unsaved context not to be added to the config file by reading it")
+	}
+
+	err = file.updateContext("unsaved", func(ctx *ContextConfig) {
+		ctx.Project = 2
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reloaded, err := loadConfigFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error reloading config file: %v", err)
+	}
+
+	if reloaded.CurrentContext != DefaultContextName {
+		t.Errorf("expected current context to remain %q, got %q", DefaultContextName, reloaded.CurrentContext)
+	}
+
+	if ctx := reloaded.Contexts["unsaved"]; ctx == nil || ctx.Project != 2 {
+		t.Errorf("expected updated context to be saved with project 2, got %+v", ctx)
+	}
+
+	if ctx := reloaded.Contexts[DefaultContextName]; ctx == nil || ctx.Project != 1 {
+		t.Errorf("expected default context to keep project 1, got %+v", ctx)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/porter-dev/porter/api/types"
 
 	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/porter-dev/porter/internal/helm/loader"
 )
 
 func HelmRepo(
@@ -28,7 +30,8 @@ Name: `))
 		return 0, err
 	}
 
-	repoURL, err := utils.PromptPlaintext(fmt.Sprintf(`Provide the Helm registry URL, make sure to include the protocol. For example, https://charts.bitnami.com/bitnami.
+	repoURL, err := utils.PromptPlaintext(fmt.Sprintf(`Provide the Helm registry URL, make sure to include the protocol. For example, https://charts.bitnami.com/bitnami,
+or oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts for charts stored in an OCI registry.
 Registry URL: `))
 	if err != nil {
 		return 0, err
@@ -38,15 +41,27 @@ Registry URL: `))
 		return 0, fmt.Errorf("not a valid url: %s", err)
 	}
 
-	username, err := utils.PromptPlaintext(fmt.Sprintf(`Helm repo username (press enter for a public registry):`))
+	var username, password string
+
+	// charts in an OCI registry which is connected to the project are pulled with the credentials of the registry
+	regName, err := ociRepoRegistryName(ctx, client, projectID, repoURL)
 	if err != nil {
 		return 0, err
 	}
 
-	password, err := utils.PromptPassword(`Helm registry password (press enter for a public registry).
+	if regName != "" {
+		color.New(color.FgGreen).Printf("charts will be pulled with the credentials of the registry %s\n", regName) //nolint:errcheck,gosec
+	} else {
+		username, err = utils.PromptPlaintext(fmt.Sprintf(`Helm repo username (press enter for a public registry):`))
+		if err != nil {
+			return 0, err
+		}
+
+		password, err = utils.PromptPassword(`Helm registry password (press enter for a public registry).
 Password:`)
-	if err != nil {
-		return 0, err
+		if err != nil {
+			return 0, err
+		}
 	}
 
 	var basicIntegrationID uint = 0
@@ -87,3 +102,36 @@ Password:`)
 
 	return reg.ID, nil
 }
+
+// ociRepoRegistryName returns the name of the registry of the project with the same host as an OCI repo URL, or
+// an empty string if the URL is not an OCI repo URL or no registry matches
+func ociRepoRegistryName(ctx context.Context, client api.Client, projectID uint, repoURL string) (string, error) {
+	if !loader.IsOCIRepoURL(repoURL) {
+		return "", nil
+	}
+
+	host := loader.OCIRepoHost(repoURL)
+
+	if host == "" {
+		return "", fmt.Errorf("not a valid oci url: %s", repoURL)
+	}
+
+	regs, err := client.ListRegistries(ctx, projectID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, reg := range *regs {
+		regURL := reg.URL
+
+		if !strings.Contains(regURL, "://") {
+			regURL = "https://" + regURL
+		}
+
+		if parsedURL, err := url.Parse(regURL); err == nil && strings.EqualFold(parsedURL.Host, host) {
+			return reg.Name, nil
+		}
+	}
+
+	return "", nil
+}
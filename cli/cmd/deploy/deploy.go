@@ -49,6 +49,12 @@ type DeployOpts struct {
 	*SharedOpts
 
 	Local bool
+
+	// ChartVersion is the chart version to upgrade the release to. If empty, the chart version is not changed.
+	ChartVersion string
+
+	// AcknowledgeUpgradeNotes acknowledges the upgrade notes of the new chart version which block the upgrade
+	AcknowledgeUpgradeNotes bool
 }
 
 // NewDeployAgent creates a new DeployAgent given a Porter API client, application
@@ -340,10 +346,53 @@ func (d *DeployAgent) Push(ctx context.Context) error {
 // reuses the configuration set for the application. If overrideValues is not nil,
 // it will merge the overriding values with the existing configuration.
 func (d *DeployAgent) UpdateImageAndValues(ctx context.Context, overrideValues map[string]interface{}) error {
+	values, err := d.getUpgradeValues(ctx, overrideValues)
+	if err != nil {
+		return err
+	}
+
+	return d.Client.UpgradeRelease(
+		ctx,
+		d.Opts.ProjectID,
+		d.Opts.ClusterID,
+		d.Release.Namespace,
+		d.Release.Name,
+		&types.UpgradeReleaseRequest{
+			Values:                  values,
+			ChartVersion:            d.Opts.ChartVersion,
+			AcknowledgeUpgradeNotes: d.Opts.AcknowledgeUpgradeNotes,
+		},
+	)
+}
+
+// DiffImageAndValues returns the changes UpdateImageAndValues would make to the resources of the release, without
+// upgrading it
+func (d *DeployAgent) DiffImageAndValues(ctx context.Context, overrideValues map[string]interface{}) (*types.ReleaseDiff, error) {
+	values, err := d.getUpgradeValues(ctx, overrideValues)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Client.DiffUpgradeRelease(
+		ctx,
+		d.Opts.ProjectID,
+		d.Opts.ClusterID,
+		d.Release.Namespace,
+		d.Release.Name,
+		&types.UpgradeReleaseRequest{
+			Values:       values,
+			ChartVersion: d.Opts.ChartVersion,
+		},
+	)
+}
+
+// getUpgradeValues merges the override values and the image tag of the agent into the values of the latest
+// release, and returns the result as JSON
+func (d *DeployAgent) getUpgradeValues(ctx context.Context, overrideValues map[string]interface{}) (string, error) {
 	// we should fetch the latest release and its config
 	release, err := d.Client.GetRelease(ctx, d.Opts.ProjectID, d.Opts.ClusterID, d.Opts.Namespace, d.App)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	d.Release = release
@@ -377,7 +426,7 @@ func (d *DeployAgent) UpdateImageAndValues(ctx context.Context, overrideValues m
 		currImageSection["repository"] == "public.ecr.aws/o1j4x7p4/hello-porter-job" {
 		newImage, err := d.getReleaseImage()
 		if err != nil {
-			return fmt.Errorf("could not overwrite hello-porter image: %s", err.Error())
+			return "", fmt.Errorf("could not overwrite hello-porter image: %s", err.Error())
 		}
 
 		currImageSection["repository"] = newImage
@@ -393,19 +442,10 @@ func (d *DeployAgent) UpdateImageAndValues(ctx context.Context, overrideValues m
 
 	bytes, err := json.Marshal(mergedValues)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return d.Client.UpgradeRelease(
-		ctx,
-		d.Opts.ProjectID,
-		d.Opts.ClusterID,
-		d.Release.Namespace,
-		d.Release.Name,
-		&types.UpgradeReleaseRequest{
-			Values: string(bytes),
-		},
-	)
+	return string(bytes), nil
 }
 
 type SyncedEnvSection struct {
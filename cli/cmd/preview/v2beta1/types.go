@@ -31,6 +31,8 @@ type Build struct {
 }
 
 type HelmChart struct {
+	// URL is the URL of the helm repo of the chart. Charts stored in an OCI registry are referenced with an oci:// URL
+	// of a helm repo connected to the project.
 	URL     *string `yaml:"url" validate:"url"`
 	Name    *string `yaml:"name" validate:"required"`
 	Version *string `yaml:"version"`
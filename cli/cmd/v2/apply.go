@@ -15,6 +15,7 @@ import (
 
 	"github.com/fatih/color"
 	app_api "github.com/porter-dev/porter/api/server/handlers/porter_app"
+	porter_app_internal "github.com/porter-dev/porter/internal/porter_app"
 	v2 "github.com/porter-dev/porter/internal/porter_app/v2"
 
 	"github.com/porter-dev/porter/api/types"
@@ -48,6 +49,10 @@ type ApplyInput struct {
 	WithPredeploy bool
 	// Exact is true when Apply should use the exact app config provided by the user
 	Exact bool
+	// Strict is true when Apply should reject a v2 porter.yaml with unknown fields, type mismatches or invalid values
+	Strict bool
+	// Environment is the name of the porter.yaml environment overlay to apply. If empty, the overlay named after the deployment target is applied, if it exists
+	Environment string
 	// PatchOperations is a list of patch operations to apply to the app
 	PatchOperations []v2.PatchOperation
 	// SkipBuild is true when Apply should skip the build step
@@ -117,6 +122,24 @@ func Apply(ctx context.Context, inp ApplyInput) error {
 			return fmt.Errorf("could not read porter yaml file: %w", err)
 		}
 
+		if inp.Strict {
+			err = porter_app_internal.ValidateYAML(ctx, porterYaml)
+			if err != nil {
+				return fmt.Errorf("porter yaml file is invalid: %w", err)
+			}
+		}
+
+		porterYaml, err = renderEnvironment(ctx, renderEnvironmentInput{
+			client:             client,
+			cliConf:            cliConf,
+			deploymentTargetID: deploymentTargetID,
+			porterYaml:         porterYaml,
+			environment:        inp.Environment,
+		})
+		if err != nil {
+			return fmt.Errorf("error rendering porter yaml environment: %w", err)
+		}
+
 		b64YAML = base64.StdEncoding.EncodeToString(porterYaml)
 		color.New(color.FgGreen).Printf("Using Porter YAML at path: %s\n", inp.PorterYamlPath) // nolint:errcheck,gosec
 	}
@@ -158,6 +181,9 @@ func Apply(ctx context.Context, inp ApplyInput) error {
 
 	appName := updateResp.AppName
 
+	// revisions violating policies enforced with "deny" are rejected by the update app endpoint, so only warnings are left to print
+	printAppPolicyViolations(updateResp.PolicyViolations, updateResp.SkippedPolicyBundles)
+
 	buildSettings, err := client.GetBuildFromRevision(ctx, api.GetBuildFromRevisionInput{
 		ProjectID:     cliConf.Project,
 		ClusterID:     cliConf.Cluster,
@@ -168,7 +194,9 @@ func Apply(ctx context.Context, inp ApplyInput) error {
 		return fmt.Errorf("error getting build from revision: %w", err)
 	}
 
-	if !inp.SkipBuild && buildSettings != nil && buildSettings.Build.Method != "" {
+	requiresBuild := !inp.SkipBuild && buildSettings != nil && buildSettings.Build.Method != ""
+
+	if requiresBuild {
 		eventID, _ := createBuildEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, deploymentTargetID, commitSHA)
 
 		var buildFinished bool
@@ -330,6 +358,22 @@ func Apply(ctx context.Context, inp ApplyInput) error {
 	return nil
 }
 
+// checkAppPolicies evaluates the enforced policies of the project against the manifests of a pending revision of the app, printing violations.
+// An error is returned if any violated policy is enforced with "deny", or if the policies cannot be evaluated, so that applies do not bypass enforced policies.
+func printAppPolicyViolations(violations []app_api.AppPolicyViolation, skippedBundles []app_api.AppPolicySkippedBundle) {
+	for _, bundle := range skippedBundles {
+		color.New(color.FgYellow).Printf("[warn] policy bundle %s v%d could not be compiled and was not evaluated: %s\n", bundle.Name, bundle.Version, bundle.Error) // nolint:errcheck,gosec
+	}
+
+	for _, violation := range violations {
+		c := color.New(color.FgYellow)
+		c.Printf("[%s] %s: %s\n", violation.Enforcement, violation.Collection, violation.Title) // nolint:errcheck,gosec
+		if violation.Message != "" {
+			c.Printf("  %s (%s)\n", violation.Message, violation.ObjectID) // nolint:errcheck,gosec
+		}
+	}
+}
+
 func commitSHAFromEnv() string {
 	var commitSHA string
 	if os.Getenv("PORTER_COMMIT_SHA") != "" {
@@ -389,6 +433,53 @@ func deploymentTargetFromConfig(ctx context.Context, client api.Client, projectI
 	return deploymentTargetID, nil
 }
 
+type renderEnvironmentInput struct {
+	client             api.Client
+	cliConf            config.CLIConfig
+	deploymentTargetID string
+	porterYaml         []byte
+	environment        string
+}
+
+// renderEnvironment merges an environment overlay over the porter yaml. If no environment is specified, the overlay named after the
+// deployment target is used, if it exists. Files without environments are returned as is.
+func renderEnvironment(ctx context.Context, inp renderEnvironmentInput) ([]byte, error) {
+	environments, err := v2.EnvironmentNames(inp.porterYaml)
+	if err != nil {
+		return nil, fmt.Errorf("error reading environments: %w", err)
+	}
+
+	if len(environments) == 0 && inp.environment == "" {
+		return inp.porterYaml, nil
+	}
+
+	environment := inp.environment
+	if environment == "" {
+		targetResp, err := inp.client.GetDeploymentTarget(ctx, inp.cliConf.Project, inp.cliConf.Cluster, inp.deploymentTargetID)
+		if err != nil {
+			return nil, fmt.Errorf("error calling get deployment target endpoint: %w", err)
+		}
+
+		for _, name := range environments {
+			if name == targetResp.DeploymentTarget.Name {
+				environment = name
+				break
+			}
+		}
+	}
+
+	rendered, err := v2.RenderEnvironment(inp.porterYaml, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	if environment != "" {
+		color.New(color.FgGreen).Printf("Using Porter YAML environment: %s\n", environment) // nolint:errcheck,gosec
+	}
+
+	return rendered, nil
+}
+
 type reportBuildFailureInput struct {
 	client             api.Client
 	appName            string
@@ -0,0 +1,532 @@
+package v2
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/porter-dev/api-contracts/generated/go/helpers"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/cli/cmd/config"
+	porter_app_internal "github.com/porter-dev/porter/internal/porter_app"
+)
+
+const (
+	// LogsOutputText prints each log line prefixed with the colorized name of its service
+	LogsOutputText = "text"
+	// LogsOutputJSON prints each log line as a JSON object on its own line
+	LogsOutputJSON = "json"
+)
+
+// allServices is the service name which matches the logs of all services of an app
+const allServices = "all"
+
+// logsPageLimit is the maximum number of log lines requested at once
+const logsPageLimit = 1000
+
+// logsFollowInterval is the interval between requests for new log lines when following logs
+const logsFollowInterval = 2 * time.Second
+
+// logsDefaultRange is the time range of the logs returned when no start of the range is set
+const logsDefaultRange = 24 * time.Hour
+
+// serviceColors are the colors used to prefix the log lines of each service
+var serviceColors = []color.Attribute{
+	color.FgCyan,
+	color.FgGreen,
+	color.FgYellow,
+	color.FgMagenta,
+	color.FgBlue,
+	color.FgHiCyan,
+	color.FgHiGreen,
+	color.FgHiYellow,
+	color.FgHiMagenta,
+	color.FgHiBlue,
+}
+
+// AppLogsInput is the input for the AppLogs function
+type AppLogsInput struct {
+	// CLIConfig is the CLI configuration
+	CLIConfig config.CLIConfig
+	// Client is the Porter API client
+	Client api.Client
+	// AppName is the name of the app to get logs for
+	AppName string
+	// DeploymentTargetName is the name of the deployment target of the app. The default deployment target is used if empty.
+	DeploymentTargetName string
+
+	// ServiceName restricts the logs to a single service of the app. The logs of all services are returned if empty.
+	ServiceName string
+	// Revision restricts the logs to a single revision of the app, either by revision number or by revision id
+	Revision string
+
+	// Since is the start of the time range of the logs. If zero, the most recent logs are returned.
+	Since time.Time
+	// Until is the end of the time range of the logs. The range ends now if zero.
+	Until time.Time
+	// Limit is the maximum number of log lines returned before following logs. Only log lines matching Grep are counted.
+	Limit uint
+	// Follow streams new log lines until the context is cancelled
+	Follow bool
+
+	// Grep only keeps the log lines matching the regular expression if set
+	Grep *regexp.Regexp
+	// Output is the output format, either LogsOutputText or LogsOutputJSON
+	Output string
+}
+
+// AppLogs prints the logs of all services of an app interleaved by time, optionally restricted to a service or a
+// revision of the app
+func AppLogs(ctx context.Context, inp AppLogsInput) error {
+	if inp.Follow && !inp.Until.IsZero() {
+		return errors.New("logs cannot be followed when the end of the time range is set")
+	}
+
+	if !inp.Since.IsZero() && !inp.Until.IsZero() && !inp.Since.Before(inp.Until) {
+		return errors.New("the start of the time range must be before its end")
+	}
+
+	if inp.Limit == 0 {
+		inp.Limit = logsPageLimit
+	}
+
+	currentAppRevisionResp, err := inp.Client.CurrentAppRevision(ctx, api.CurrentAppRevisionInput{
+		ProjectID:            inp.CLIConfig.Project,
+		ClusterID:            inp.CLIConfig.Cluster,
+		AppName:              inp.AppName,
+		DeploymentTargetName: inp.DeploymentTargetName,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting current app revision: %w", err)
+	}
+
+	deploymentTargetID := currentAppRevisionResp.AppRevision.DeploymentTarget.ID
+	if deploymentTargetID == "" {
+		return errors.New("current app revision has no deployment target")
+	}
+
+	serviceNames, err := appServiceNames(currentAppRevisionResp.AppRevision.B64AppProto)
+	if err != nil {
+		return err
+	}
+
+	serviceName := allServices
+	if inp.ServiceName != "" {
+		if !contains(serviceNames, inp.ServiceName) {
+			return fmt.Errorf("service %s not found in app %s. Available services: %s", inp.ServiceName, inp.AppName, strings.Join(serviceNames, ", "))
+		}
+
+		serviceName = inp.ServiceName
+	}
+
+	appRevisionID, err := resolveAppRevisionID(ctx, inp, deploymentTargetID)
+	if err != nil {
+		return err
+	}
+
+	query := api.AppLogsInput{
+		ProjectID:          inp.CLIConfig.Project,
+		ClusterID:          inp.CLIConfig.Cluster,
+		AppName:            inp.AppName,
+		DeploymentTargetID: deploymentTargetID,
+		ServiceName:        serviceName,
+		AppRevisionID:      appRevisionID,
+	}
+
+	// the server only returns the log lines containing a literal pattern, so that the log lines are not all sent
+	// back to be filtered here. Other patterns are matched while paging through the logs.
+	if inp.Grep != nil {
+		if literal, complete := inp.Grep.LiteralPrefix(); complete && literal != "" {
+			query.SearchParam = literal
+		}
+	}
+
+	printer := newLogPrinter(os.Stdout, inp.Output, serviceNames)
+
+	end := inp.Until
+	if end.IsZero() {
+		end = time.Now().UTC()
+	}
+
+	var logs []porter_app_internal.StructuredLog
+
+	if inp.Since.IsZero() {
+		logs, err = latestLogs(ctx, inp.Client, query, end.Add(-logsDefaultRange), end, inp.Limit, inp.Grep)
+	} else {
+		logs, err = logsInRange(ctx, inp.Client, query, inp.Since, end, inp.Limit, inp.Grep)
+	}
+	if err != nil {
+		return err
+	}
+
+	cursor := &logCursor{}
+
+	for _, log := range logs {
+		if cursor.advance(log) {
+			if err := printer.print(log); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !inp.Follow {
+		return nil
+	}
+
+	if cursor.last.IsZero() {
+		cursor.last = end
+	}
+
+	ticker := time.NewTicker(logsFollowInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		// the start of the range is inclusive, so the log lines of the last timestamp are requested again and skipped by the cursor
+		logs, err := logsInRange(ctx, inp.Client, query, cursor.last, time.Now().UTC(), 0, inp.Grep)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		}
+
+		for _, log := range logs {
+			if cursor.advance(log) {
+				if err := printer.print(log); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// latestLogs returns the most recent log lines of a time range which match grep, ordered from the oldest. Pages of
+// log lines are requested backward from the end of the range, following the continue time returned with each page,
+// until limit matching log lines are found or the start of the range is reached.
+func latestLogs(
+	ctx context.Context,
+	client api.Client,
+	query api.AppLogsInput,
+	start time.Time,
+	end time.Time,
+	limit uint,
+	grep *regexp.Regexp,
+) ([]porter_app_internal.StructuredLog, error) {
+	logs := make([]porter_app_internal.StructuredLog, 0)
+	cursor := &logCursor{backward: true}
+
+	query.StartRange = start
+	query.Direction = "backward"
+	query.Limit = pageLimit(limit, 0, 0, grep)
+
+	for {
+		query.EndRange = end
+
+		resp, err := client.AppLogs(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("error getting logs: %w", err)
+		}
+
+		// pages are ordered from the newest log line, so they are reversed before sorting to keep log lines which
+		// share a timestamp in the order they were written
+		page := resp.Logs
+		reverseLogs(page)
+		sortLogs(page)
+
+		for i := len(page) - 1; i >= 0 && uint(len(logs)) < limit; i-- {
+			if cursor.advance(page[i]) && matches(grep, page[i]) {
+				logs = append(logs, page[i])
+			}
+		}
+
+		// a page which is not full is the last page of the range. The end of the range is inclusive, so a page whose
+		// lines all share the timestamp it ends at cannot be moved past, and paging stops as well.
+		if uint(len(page)) < query.Limit || uint(len(logs)) >= limit ||
+			resp.BackwardContinueTime == nil || !resp.BackwardContinueTime.Before(end) {
+			break
+		}
+
+		end = *resp.BackwardContinueTime
+	}
+
+	// log lines were found from the newest
+	reverseLogs(logs)
+
+	return logs, nil
+}
+
+// logsInRange returns the log lines of a time range which match grep, ordered from the oldest. Pages of log lines are
+// requested forward from the start of the range, following the continue time returned with each page, until the end
+// of the range or limit matching log lines are reached. A limit of 0 returns all matching log lines of the range.
+func logsInRange(
+	ctx context.Context,
+	client api.Client,
+	query api.AppLogsInput,
+	start time.Time,
+	end time.Time,
+	limit uint,
+	grep *regexp.Regexp,
+) ([]porter_app_internal.StructuredLog, error) {
+	logs := make([]porter_app_internal.StructuredLog, 0)
+	cursor := &logCursor{}
+
+	query.EndRange = end
+	query.Direction = "forward"
+
+	for {
+		query.StartRange = start
+		// the log lines of the last timestamp are requested again, so they are added to the number of log lines requested
+		query.Limit = pageLimit(limit, uint(len(logs)), uint(len(cursor.seen)), grep)
+
+		resp, err := client.AppLogs(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("error getting logs: %w", err)
+		}
+
+		page := resp.Logs
+		sortLogs(page)
+
+		for _, log := range page {
+			if limit != 0 && uint(len(logs)) >= limit {
+				break
+			}
+
+			if cursor.advance(log) && matches(grep, log) {
+				logs = append(logs, log)
+			}
+		}
+
+		// a page which is not full is the last page of the range. The start of the range is inclusive, so a page
+		// whose lines all share the timestamp it starts at cannot be moved past, and paging stops as well.
+		if uint(len(page)) < query.Limit || (limit != 0 && uint(len(logs)) >= limit) ||
+			resp.ForwardContinueTime == nil || !resp.ForwardContinueTime.After(start) {
+			break
+		}
+
+		start = *resp.ForwardContinueTime
+	}
+
+	return logs, nil
+}
+
+// pageLimit returns the number of log lines to request in the next page, when found log lines out of limit matching
+// log lines were found and the page starts with seen log lines which were already returned. Only the remaining
+// number of log lines is requested if all log lines match, since the number of log lines of a page which match a
+// pattern is not known in advance.
+func pageLimit(limit uint, found uint, seen uint, grep *regexp.Regexp) uint {
+	if grep == nil && limit != 0 && limit-found+seen < logsPageLimit {
+		return limit - found + seen
+	}
+
+	return logsPageLimit
+}
+
+// matches returns whether a log line matches grep. All log lines match if grep is not set.
+func matches(grep *regexp.Regexp, log porter_app_internal.StructuredLog) bool {
+	return grep == nil || grep.MatchString(log.Line)
+}
+
+// logCursor tracks the timestamp of the last log line which was returned, and the log lines returned at that
+// timestamp. Requests for log lines start at the timestamp of the last log line since log lines can share
+// timestamps, so log lines which were already returned are skipped. Log lines are returned from the oldest, or from
+// the newest if backward is set.
+type logCursor struct {
+	backward bool
+	last     time.Time
+	seen     map[string]bool
+}
+
+// advance moves the cursor to a log line, and returns whether the log line was not returned yet
+func (c *logCursor) advance(log porter_app_internal.StructuredLog) bool {
+	key := strings.Join([]string{log.ServiceName, log.AppInstanceID, log.JobRunID, log.OutputStream, log.Line}, "\x00")
+
+	switch {
+	case c.passed(log.Timestamp):
+		return false
+	case log.Timestamp.Equal(c.last):
+		if c.seen[key] {
+			return false
+		}
+	default:
+		c.last = log.Timestamp
+		c.seen = make(map[string]bool)
+	}
+
+	c.seen[key] = true
+
+	return true
+}
+
+// passed returns whether the cursor already moved past a timestamp
+func (c *logCursor) passed(timestamp time.Time) bool {
+	if c.backward {
+		return !c.last.IsZero() && timestamp.After(c.last)
+	}
+
+	return timestamp.Before(c.last)
+}
+
+func sortLogs(logs []porter_app_internal.StructuredLog) {
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].Timestamp.Before(logs[j].Timestamp)
+	})
+}
+
+func reverseLogs(logs []porter_app_internal.StructuredLog) {
+	for i, j := 0, len(logs)-1; i < j; i, j = i+1, j-1 {
+		logs[i], logs[j] = logs[j], logs[i]
+	}
+}
+
+// resolveAppRevisionID returns the id of the revision set in the input, which is either a revision number or a
+// revision id
+func resolveAppRevisionID(ctx context.Context, inp AppLogsInput, deploymentTargetID string) (string, error) {
+	if inp.Revision == "" {
+		return "", nil
+	}
+
+	revisionNumber, err := strconv.ParseUint(inp.Revision, 10, 64)
+	if err != nil {
+		// the revision is not a number, so it is assumed to be a revision id
+		return inp.Revision, nil
+	}
+
+	revisionsResp, err := inp.Client.ListAppRevisions(ctx, inp.CLIConfig.Project, inp.CLIConfig.Cluster, inp.AppName, deploymentTargetID)
+	if err != nil {
+		return "", fmt.Errorf("error listing app revisions: %w", err)
+	}
+
+	for _, revision := range revisionsResp.AppRevisions {
+		if revision.RevisionNumber == revisionNumber {
+			return revision.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("revision %d not found in the recent revisions of app %s. Please use the id of the revision instead", revisionNumber, inp.AppName)
+}
+
+// appServiceNames returns the sorted names of the services of a base64 encoded app
+func appServiceNames(b64AppProto string) ([]string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(b64AppProto)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode base64 app for revision: %w", err)
+	}
+
+	app := &porterv1.PorterApp{}
+	err = helpers.UnmarshalContractObject(decoded, app)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal app for revision: %w", err)
+	}
+
+	names := make([]string, 0, len(app.ServiceList))
+	for _, service := range app.ServiceList {
+		names = append(names, service.Name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// logPrinter writes log lines in the text or json output format
+type logPrinter struct {
+	writer  io.Writer
+	output  string
+	width   int
+	colors  map[string]*color.Color
+	encoder *json.Encoder
+}
+
+func newLogPrinter(writer io.Writer, output string, serviceNames []string) *logPrinter {
+	p := &logPrinter{
+		writer:  writer,
+		output:  output,
+		colors:  make(map[string]*color.Color),
+		encoder: json.NewEncoder(writer),
+	}
+
+	// services are assigned colors in order of their names, so each service keeps its color across runs
+	for _, name := range serviceNames {
+		p.colorFor(name)
+	}
+
+	return p
+}
+
+func (p *logPrinter) colorFor(serviceName string) *color.Color {
+	if c, ok := p.colors[serviceName]; ok {
+		return c
+	}
+
+	c := color.New(serviceColors[len(p.colors)%len(serviceColors)])
+	p.colors[serviceName] = c
+
+	if len(serviceName) > p.width {
+		p.width = len(serviceName)
+	}
+
+	return c
+}
+
+func (p *logPrinter) print(log porter_app_internal.StructuredLog) error {
+	if p.output == LogsOutputJSON {
+		return p.encoder.Encode(log)
+	}
+
+	prefix := p.colorFor(log.ServiceName).Sprintf("%-*s |", p.width, log.ServiceName)
+
+	_, err := fmt.Fprintf(p.writer, "%s %s\n", prefix, strings.TrimRight(log.Line, "\n"))
+	return err
+}
+
+// ParseLogTime parses the start or end of a time range of logs, which is either an RFC 3339 timestamp or a duration
+// before now such as 30m or 2h
+func ParseLogTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("invalid time %s: durations must be positive", value)
+		}
+
+		return now.Add(-d).UTC(), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %s: must be an RFC 3339 timestamp such as 2006-01-02T15:04:05Z or a duration such as 30m", value)
+	}
+
+	return t.UTC(), nil
+}
@@ -0,0 +1,312 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/server/handlers/porter_app"
+	porter_app_internal "github.com/porter-dev/porter/internal/porter_app"
+)
+
+var logsStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// testLogs returns count log lines, three per second, so that pages of log lines start and end at timestamps shared
+// with the log lines of the previous page. The log lines at errorIndices contain "error".
+func testLogs(count int, errorIndices ...int) []porter_app_internal.StructuredLog {
+	errorLines := make(map[int]bool)
+	for _, i := range errorIndices {
+		errorLines[i] = true
+	}
+
+	logs := make([]porter_app_internal.StructuredLog, 0, count)
+	for i := 0; i < count; i++ {
+		line := fmt.Sprintf("line %d", i)
+		if errorLines[i] {
+			line = fmt.Sprintf("error %d", i)
+		}
+
+		logs = append(logs, porter_app_internal.StructuredLog{
+			Timestamp:     logsStart.Add(time.Duration(i/3) * time.Second),
+			Line:          line,
+			ServiceName:   "web",
+			AppInstanceID: "web-0",
+		})
+	}
+
+	return logs
+}
+
+// logIndex returns the index of a log line returned by testLogs
+func logIndex(t *testing.T, log porter_app_internal.StructuredLog) int {
+	fields := strings.Fields(log.Line)
+
+	i, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil {
+		t.Fatalf("unexpected log line %q", log.Line)
+	}
+
+	return i
+}
+
+// newFakeLogsClient returns a client of a fake Porter API which pages through logs like the logs endpoint. Both ends
+// of the time range are inclusive, and the continue time of a page is the timestamp of its last log line.
+func newFakeLogsClient(t *testing.T, logs []porter_app_internal.StructuredLog) api.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		start, err := time.Parse(time.RFC3339Nano, query.Get("start_range"))
+		if err != nil {
+			t.Errorf("invalid start_range: %v", err)
+		}
+
+		end, err := time.Parse(time.RFC3339Nano, query.Get("end_range"))
+		if err != nil {
+			t.Errorf("invalid end_range: %v", err)
+		}
+
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil || limit == 0 {
+			t.Errorf("invalid limit %q", query.Get("limit"))
+		}
+
+		inRange := make([]porter_app_internal.StructuredLog, 0)
+		for _, log := range logs {
+			if !log.Timestamp.Before(start) && !log.Timestamp.After(end) {
+				inRange = append(inRange, log)
+			}
+		}
+
+		resp := porter_app.AppLogsResponse{Logs: make([]porter_app_internal.StructuredLog, 0)}
+
+		switch query.Get("direction") {
+		case "backward":
+			for i := len(inRange) - 1; i >= 0 && len(resp.Logs) < limit; i-- {
+				resp.Logs = append(resp.Logs, inRange[i])
+			}
+
+			if len(resp.Logs) > 0 {
+				resp.BackwardContinueTime = &resp.Logs[len(resp.Logs)-1].Timestamp
+			}
+		case "forward":
+			for i := 0; i < len(inRange) && len(resp.Logs) < limit; i++ {
+				resp.Logs = append(resp.Logs, inRange[i])
+			}
+
+			if len(resp.Logs) > 0 {
+				resp.ForwardContinueTime = &resp.Logs[len(resp.Logs)-1].Timestamp
+			}
+		default:
+			t.Errorf("invalid direction %q", query.Get("direction"))
+		}
+
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("unexpected error writing response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return api.Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "token",
+	}
+}
+
+func indices(from int, to int) []int {
+	res := make([]int, 0, to-from)
+	for i := from; i < to; i++ {
+		res = append(res, i)
+	}
+
+	return res
+}
+
+func TestLatestLogs(t *testing.T) {
+	tests := []struct {
+		name            string
+		logs            []porter_app_internal.StructuredLog
+		limit           uint
+		grep            *regexp.Regexp
+		expectedIndices []int
+	}{
+		{
+			name:            "fewer log lines than the limit",
+			logs:            testLogs(10),
+			limit:           logsPageLimit,
+			expectedIndices: indices(0, 10),
+		},
+		{
+			name:            "skips log lines of a shared timestamp returned by the previous page",
+			logs:            testLogs(2500),
+			limit:           1500,
+			expectedIndices: indices(1000, 2500),
+		},
+		{
+			name:            "applies the limit to the log lines matching grep",
+			logs:            testLogs(2500, 10, 20, 30, 40, 50, 60, 1600),
+			limit:           5,
+			grep:            regexp.MustCompile("error"),
+			expectedIndices: []int{30, 40, 50, 60, 1600},
+		},
+		{
+			name:            "fewer log lines matching grep than the limit",
+			logs:            testLogs(2500, 999, 1000, 1001),
+			limit:           5,
+			grep:            regexp.MustCompile("error"),
+			expectedIndices: []int{999, 1000, 1001},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeLogsClient(t, tt.logs)
+			end := tt.logs[len(tt.logs)-1].Timestamp
+
+			logs, err := latestLogs(context.Background(), client, api.AppLogsInput{AppName: "app"}, logsStart, end, tt.limit, tt.grep)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := make([]int, 0, len(logs))
+			for _, log := range logs {
+				got = append(got, logIndex(t, log))
+			}
+
+			if !reflect.DeepEqual(got, tt.expectedIndices) {
+				t.Errorf("expected log lines %v, got %v", tt.expectedIndices, got)
+			}
+		})
+	}
+}
+
+func TestLogsInRange(t *testing.T) {
+	tests := []struct {
+		name            string
+		logs            []porter_app_internal.StructuredLog
+		limit           uint
+		grep            *regexp.Regexp
+		expectedIndices []int
+	}{
+		{
+			name:            "returns all log lines of the range without a limit",
+			logs:            testLogs(2500),
+			expectedIndices: indices(0, 2500),
+		},
+		{
+			name:            "requests the log lines of a shared timestamp again with the remaining log lines",
+			logs:            testLogs(2500),
+			limit:           1200,
+			expectedIndices: indices(0, 1200),
+		},
+		{
+			name:            "applies the limit to the log lines matching grep",
+			logs:            testLogs(2500, 10, 1500, 2000, 2400),
+			limit:           3,
+			grep:            regexp.MustCompile("error"),
+			expectedIndices: []int{10, 1500, 2000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeLogsClient(t, tt.logs)
+			end := tt.logs[len(tt.logs)-1].Timestamp
+
+			logs, err := logsInRange(context.Background(), client, api.AppLogsInput{AppName: "app"}, logsStart, end, tt.limit, tt.grep)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := make([]int, 0, len(logs))
+			for _, log := range logs {
+				got = append(got, logIndex(t, log))
+			}
+
+			if !reflect.DeepEqual(got, tt.expectedIndices) {
+				t.Errorf("expected log lines %v, got %v", tt.expectedIndices, got)
+			}
+		})
+	}
+}
+
+func TestLogPrinterJSON(t *testing.T) {
+	logs := []porter_app_internal.StructuredLog{
+		{
+			Timestamp:          logsStart,
+			Line:               "listening on :8080\n",
+			OutputStream:       "stdout",
+			ServiceName:        "web",
+			AppRevisionID:      "revision",
+			DeploymentTargetID: "target",
+			AppInstanceID:      "web-0",
+		},
+		{
+			Timestamp:     logsStart.Add(time.Second),
+			Line:          "job done",
+			OutputStream:  "stderr",
+			ServiceName:   "cron",
+			AppInstanceID: "cron-0",
+			JobName:       "cron",
+			JobRunID:      "cron-1",
+		},
+	}
+
+	var buf bytes.Buffer
+
+	printer := newLogPrinter(&buf, LogsOutputJSON, []string{"cron", "web"})
+	for _, log := range logs {
+		if err := printer.print(log); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(logs) {
+		t.Fatalf("expected one line per log line, got %q", buf.String())
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"timestamp":            "2024-01-01T00:00:00Z",
+			"line":                 "listening on :8080\n",
+			"output_stream":        "stdout",
+			"service_name":         "web",
+			"app_revision_id":      "revision",
+			"deployment_target_id": "target",
+			"app_instance_id":      "web-0",
+		},
+		{
+			"timestamp":            "2024-01-01T00:00:01Z",
+			"line":                 "job done",
+			"output_stream":        "stderr",
+			"service_name":         "cron",
+			"app_revision_id":      "",
+			"deployment_target_id": "",
+			"app_instance_id":      "cron-0",
+			"job_name":             "cron",
+			"job_run_id":           "cron-1",
+		},
+	}
+
+	for i, line := range lines {
+		var got map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("expected line %d to be a JSON object, got %q: %v", i, line, err)
+		}
+
+		if !reflect.DeepEqual(got, expected[i]) {
+			t.Errorf("expected line %d to be %v, got %v", i, expected[i], got)
+		}
+	}
+}
@@ -86,6 +86,7 @@ require (
 	github.com/open-policy-agent/opa v0.44.0
 	github.com/porter-dev/api-contracts v0.2.136
 	github.com/riandyrn/otelchi v0.5.1
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/santhosh-tekuri/jsonschema/v5 v5.0.1
 	github.com/stefanmcshane/helm v0.0.0-20221213002717-88a4a2c6e77d
 	github.com/stripe/stripe-go/v76 v76.21.0
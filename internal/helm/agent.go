@@ -13,6 +13,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/helm/upgrade"
 	"github.com/stefanmcshane/helm/pkg/action"
 	"github.com/stefanmcshane/helm/pkg/chart"
 	"github.com/stefanmcshane/helm/pkg/release"
@@ -261,6 +262,35 @@ func (a *Agent) DeleteReleaseRevision(
 	return err
 }
 
+// RestoreReleaseRevision writes an archived revision of a release back to the release storage of the cluster, so
+// that the release can be rolled back to it. A restored revision is never the deployed revision of the release.
+func (a *Agent) RestoreReleaseRevision(
+	ctx context.Context,
+	rel *release.Release,
+) error {
+	ctx, span := telemetry.NewSpan(ctx, "helm-restore-release-revision")
+	defer span.End()
+
+	if rel == nil || rel.Info == nil {
+		return fmt.Errorf("release revision is empty")
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "name", Value: rel.Name},
+		telemetry.AttributeKV{Key: "version", Value: rel.Version},
+	)
+
+	if rel.Namespace != a.namespace {
+		return fmt.Errorf("release %s belongs to namespace %s, not %s", rel.Name, rel.Namespace, a.namespace)
+	}
+
+	if rel.Info.Status == release.StatusDeployed {
+		rel.Info.Status = release.StatusSuperseded
+	}
+
+	return a.ActionConfig.Releases.Create(rel)
+}
+
 // GetReleaseHistory returns a list of charts for a specific release
 func (a *Agent) GetReleaseHistory(
 	ctx context.Context,
@@ -291,6 +321,13 @@ type UpgradeReleaseConfig struct {
 	// Optional, if chart is part of a Porter Stack
 	StackName     string
 	StackRevision uint
+
+	// Optional, if set the upgrade is rendered and validated but not applied
+	DryRun bool
+
+	// Optional, the upgrade notes between the current and the new chart version, whose value migrations are
+	// applied to the values before upgrading
+	UpgradeNotes *upgrade.UpgradeFile
 }
 
 // UpgradeRelease upgrades a specific release with new values.yaml
@@ -356,6 +393,7 @@ func (a *Agent) UpgradeReleaseByValues(
 
 	cmd := action.NewUpgrade(a.ActionConfig)
 	cmd.Namespace = rel.Namespace
+	cmd.DryRun = conf.DryRun
 
 	cmd.PostRenderer, err = NewPorterPostrenderer(
 		conf.Cluster,
@@ -365,11 +403,23 @@ func (a *Agent) UpgradeReleaseByValues(
 		conf.Registries,
 		doAuth,
 		disablePullSecretsInjection,
+		conf.DryRun,
 	)
 	if err != nil {
 		return nil, telemetry.Error(ctx, span, err, "error getting porter postrenderer")
 	}
 
+	if conf.UpgradeNotes != nil {
+		if conf.Values == nil {
+			conf.Values = make(map[string]interface{})
+		}
+
+		err = conf.UpgradeNotes.ApplyMigrations(conf.Values)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error applying upgrade note migrations")
+		}
+	}
+
 	if conf.StackName != "" && conf.StackRevision > 0 {
 		conf.Values["stack"] = map[string]interface{}{
 			"enabled":  true,
@@ -379,6 +429,35 @@ func (a *Agent) UpgradeReleaseByValues(
 	}
 
 	res, err := cmd.Run(conf.Name, ch, conf.Values)
+	if err != nil && conf.DryRun {
+		// the recoveries below modify the release history, which a dry run must not do. A release whose current manifest
+		// contains removed kubernetes apis is upgraded to the manifest the chart renders to once its history is updated, so
+		// that manifest is rendered without persisting anything instead.
+		if !isRemovedAPIError(err) {
+			return nil, telemetry.Error(ctx, span, err, "error running upgrade dry run")
+		}
+
+		installCmd := action.NewInstall(a.ActionConfig)
+
+		installCmd.ReleaseName = conf.Name
+		installCmd.Namespace = rel.Namespace
+		installCmd.DryRun = true
+		installCmd.Replace = true
+		installCmd.PostRenderer = cmd.PostRenderer
+
+		installCmd.ClientOnly = false
+		installCmd.IncludeCRDs = true
+
+		res, err = installCmd.Run(ch, conf.Values)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error running install dry run for release with removed kubernetes apis")
+		}
+
+		res.Version = rel.Version + 1
+
+		return res, nil
+	}
+
 	if err != nil {
 		// refer: https://github.com/helm/helm/blob/release-3.8/pkg/action/action.go#L62
 		// issue tracker: https://github.com/helm/helm/issues/4558
@@ -427,7 +506,7 @@ func (a *Agent) UpgradeReleaseByValues(
 					return nil, telemetry.Error(ctx, span, err, "another operation (install/upgrade/rollback) is in progress. If this error persists, please wait for 60 seconds to force an upgrade")
 				}
 			}
-		} else if strings.Contains(err.Error(), "current release manifest contains removed kubernetes api(s)") || strings.Contains(err.Error(), "resource mapping not found for name") {
+		} else if isRemovedAPIError(err) {
 			// ref: https://helm.sh/docs/topics/kubernetes_apis/#updating-api-versions-of-a-release-manifest
 			// in this case, we manually update the secret containing the new manifests
 			secretList, err := a.K8sAgent.Clientset.CoreV1().Secrets(rel.Namespace).List(
@@ -502,6 +581,12 @@ func (a *Agent) UpgradeReleaseByValues(
 	return res, nil
 }
 
+// isRemovedAPIError returns true if an upgrade failed because the current manifest of the release contains kubernetes
+// apis which were removed from the cluster
+func isRemovedAPIError(err error) bool {
+	return strings.Contains(err.Error(), "current release manifest contains removed kubernetes api(s)") || strings.Contains(err.Error(), "resource mapping not found for name")
+}
+
 // InstallChartConfig is the config required to install a chart
 type InstallChartConfig struct {
 	Chart      *chart.Chart
@@ -511,6 +596,9 @@ type InstallChartConfig struct {
 	Cluster    *models.Cluster
 	Repo       repository.Repository
 	Registries []*models.Registry
+
+	// Optional, if set the chart is rendered and validated but not installed
+	DryRun bool
 }
 
 // InstallChartFromValuesBytes reads the raw values and calls Agent.InstallChart
@@ -573,6 +661,7 @@ func (a *Agent) InstallChart(
 	cmd.ReleaseName = conf.Name
 	cmd.Namespace = conf.Namespace
 	cmd.Timeout = 300 * time.Second
+	cmd.DryRun = conf.DryRun
 
 	if err := checkIfInstallable(conf.Chart); err != nil {
 		return nil, telemetry.Error(ctx, span, err, "error checking if installable")
@@ -588,6 +677,7 @@ func (a *Agent) InstallChart(
 		conf.Registries,
 		doAuth,
 		disablePullSecretsInjection,
+		conf.DryRun,
 	)
 	if err != nil {
 		return nil, telemetry.Error(ctx, span, err, "error getting post renderer")
@@ -638,6 +728,7 @@ func (a *Agent) UpgradeInstallChart(
 
 	cmd.Namespace = conf.Namespace
 	cmd.Timeout = 300 * time.Second
+	cmd.DryRun = conf.DryRun
 
 	if err := checkIfInstallable(conf.Chart); err != nil {
 		return nil, telemetry.Error(ctx, span, err, "error checking if installable")
@@ -653,6 +744,7 @@ func (a *Agent) UpgradeInstallChart(
 		conf.Registries,
 		doAuth,
 		disablePullSecretsInjection,
+		conf.DryRun,
 	)
 	if err != nil {
 		return nil, telemetry.Error(ctx, span, err, "error getting post renderer")
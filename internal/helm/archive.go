@@ -0,0 +1,43 @@
+package helm
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ArchivedRevisionKey returns the storage key of an archived revision of a release, in the format
+// <project_id>/<cluster_id>/<namespace>/<release_name>/<revision_number>
+func ArchivedRevisionKey(projectID, clusterID uint, namespace, name string, version int) string {
+	return fmt.Sprintf("%s%d", ArchivedRevisionsPrefix(projectID, clusterID, namespace, name), version)
+}
+
+// ArchivedRevisionsPrefix returns the common prefix of the storage keys of the archived revisions of a release
+func ArchivedRevisionsPrefix(projectID, clusterID uint, namespace, name string) string {
+	return fmt.Sprintf("%d/%d/%s/%s/", projectID, clusterID, namespace, name)
+}
+
+// ArchivedRevisionVersions returns the revision numbers of the given storage keys of archived revisions of a release,
+// from newest to oldest. Keys which do not belong to a revision of the release are ignored.
+func ArchivedRevisionVersions(projectID, clusterID uint, namespace, name string, keys []string) []int {
+	prefix := ArchivedRevisionsPrefix(projectID, clusterID, namespace, name)
+	versions := make([]int, 0, len(keys))
+
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		version, err := strconv.Atoi(strings.TrimPrefix(key, prefix))
+		if err != nil || version <= 0 {
+			continue
+		}
+
+		versions = append(versions, version)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+	return versions
+}
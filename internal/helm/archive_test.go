@@ -0,0 +1,70 @@
+package helm_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm"
+)
+
+func TestArchivedRevisionKey(t *testing.T) {
+	if key := helm.ArchivedRevisionKey(1, 2, "default", "web", 3); key != "1/2/default/web/3" {
+		t.Fatalf("expected key %q, got %q", "1/2/default/web/3", key)
+	}
+
+	if prefix := helm.ArchivedRevisionsPrefix(1, 2, "default", "web"); prefix != "1/2/default/web/" {
+		t.Fatalf("expected prefix %q, got %q", "1/2/default/web/", prefix)
+	}
+}
+
+func TestArchivedRevisionVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []string
+		expected []int
+	}{
+		{
+			name:     "no keys",
+			keys:     nil,
+			expected: []int{},
+		},
+		{
+			name:     "sorted from newest to oldest",
+			keys:     []string{"1/2/default/web/2", "1/2/default/web/10", "1/2/default/web/1", "1/2/default/web/9"},
+			expected: []int{10, 9, 2, 1},
+		},
+		{
+			name: "keys of other releases are ignored",
+			keys: []string{
+				"1/2/default/web/3",
+				"1/2/default/web-worker/4",
+				"1/2/other/web/5",
+				"1/3/default/web/6",
+				"2/2/default/web/7",
+			},
+			expected: []int{3},
+		},
+		{
+			name: "keys which are not revisions are ignored",
+			keys: []string{
+				"1/2/default/web/",
+				"1/2/default/web/0",
+				"1/2/default/web/-1",
+				"1/2/default/web/latest",
+				"1/2/default/web/3/manifest",
+				"1/2/default/web/4",
+			},
+			expected: []int{4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := helm.ArchivedRevisionVersions(1, 2, "default", "web", tt.keys)
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("expected versions %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,435 @@
+package helm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"github.com/stefanmcshane/helm/pkg/release"
+	"github.com/stefanmcshane/helm/pkg/storage/driver"
+	"golang.org/x/oauth2"
+	"gopkg.in/yaml.v2"
+)
+
+// diffContextLines is the number of unchanged lines shown around each changed line of a resource diff
+const diffContextLines = 3
+
+// DiffUpgradeRelease renders an upgrade of a release through the same post-renderers as UpgradeRelease, without
+// applying it, and returns the changes the upgrade would make to the resources of the release
+func (a *Agent) DiffUpgradeRelease(
+	ctx context.Context,
+	conf *UpgradeReleaseConfig,
+	values string,
+	doAuth *oauth2.Config,
+	disablePullSecretsInjection bool,
+	ignoreDependencies bool,
+) (*types.ReleaseDiff, error) {
+	ctx, span := telemetry.NewSpan(ctx, "helm-diff-upgrade-release")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: conf.Cluster.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: conf.Cluster.ID},
+		telemetry.AttributeKV{Key: "name", Value: conf.Name},
+	)
+
+	current, err := a.GetRelease(ctx, conf.Name, 0, !ignoreDependencies)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "Could not get release to be upgraded")
+	}
+
+	conf.DryRun = true
+
+	upgraded, err := a.UpgradeRelease(ctx, conf, values, doAuth, disablePullSecretsInjection, ignoreDependencies)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error running upgrade dry run")
+	}
+
+	diff, err := DiffReleaseManifests(current, upgraded)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error diffing release manifests")
+	}
+
+	return diff, nil
+}
+
+// DiffUpgradeInstallChart renders an install or upgrade of a chart through the same post-renderers as
+// UpgradeInstallChart, without applying it, and returns the changes it would make to the resources of the release
+func (a *Agent) DiffUpgradeInstallChart(
+	ctx context.Context,
+	conf *InstallChartConfig,
+	doAuth *oauth2.Config,
+	disablePullSecretsInjection bool,
+) (*types.ReleaseDiff, error) {
+	ctx, span := telemetry.NewSpan(ctx, "helm-diff-upgrade-install-chart")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: conf.Cluster.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: conf.Cluster.ID},
+		telemetry.AttributeKV{Key: "chart-name", Value: conf.Name},
+		telemetry.AttributeKV{Key: "chart-namespace", Value: conf.Namespace},
+	)
+
+	conf.DryRun = true
+
+	var upgraded *release.Release
+
+	current, err := a.ActionConfig.Releases.Last(conf.Name)
+
+	switch {
+	case errors.Is(err, driver.ErrReleaseNotFound):
+		current = nil
+
+		upgraded, err = a.InstallChart(ctx, conf, doAuth, disablePullSecretsInjection)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error running install dry run")
+		}
+	case err != nil:
+		return nil, telemetry.Error(ctx, span, err, "error getting current release")
+	default:
+		upgraded, err = a.UpgradeInstallChart(ctx, conf, doAuth, disablePullSecretsInjection)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error running upgrade dry run")
+		}
+	}
+
+	diff, err := DiffReleaseManifests(current, upgraded)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error diffing release manifests")
+	}
+
+	return diff, nil
+}
+
+// DiffReleaseManifests returns the changes from the manifest of the current revision of a release, which may be nil,
+// to the manifest of an upgraded revision. Resources are matched by kind, namespace and name. The API versions of the
+// current manifest are first mapped to the API versions of the upgraded manifest by the DeprecatedAPIVersionMapper,
+// so that resources moved off a deprecated API version are only shown as changed if their contents changed.
+func DiffReleaseManifests(current, upgraded *release.Release) (*types.ReleaseDiff, error) {
+	if upgraded == nil {
+		return nil, fmt.Errorf("upgraded release is nil")
+	}
+
+	res := &types.ReleaseDiff{
+		Resources: make([]types.ResourceDiff, 0),
+	}
+
+	currentResources := make([]resource, 0)
+
+	if current != nil {
+		res.CurrentRevision = current.Version
+
+		mappedManifest, err := (&DeprecatedAPIVersionMapper{}).Run(
+			bytes.NewBufferString(current.Manifest),
+			bytes.NewBufferString(upgraded.Manifest),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error mapping api versions of current manifest: %w", err)
+		}
+
+		currentResources, err = decodeRenderedManifests(mappedManifest)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding current manifest: %w", err)
+		}
+	}
+
+	upgradedResources, err := decodeRenderedManifests(bytes.NewBufferString(upgraded.Manifest))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding upgraded manifest: %w", err)
+	}
+
+	currentByID, err := encodeResourcesByID(currentResources, upgraded.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	upgradedByID, err := encodeResourcesByID(upgradedResources, upgraded.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	for id, upgradedYAML := range upgradedByID {
+		currentYAML, exists := currentByID[id]
+
+		switch {
+		case !exists:
+			res.Resources = append(res.Resources, id.toResourceDiff(types.ResourceDiffActionCreate, "", upgradedYAML))
+		case currentYAML != upgradedYAML:
+			res.Resources = append(res.Resources, id.toResourceDiff(types.ResourceDiffActionUpdate, currentYAML, upgradedYAML))
+		}
+	}
+
+	for id, currentYAML := range currentByID {
+		if _, exists := upgradedByID[id]; !exists {
+			res.Resources = append(res.Resources, id.toResourceDiff(types.ResourceDiffActionDelete, currentYAML, ""))
+		}
+	}
+
+	sort.Slice(res.Resources, func(i, j int) bool {
+		a, b := res.Resources[i], res.Resources[j]
+
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+
+		return a.Name < b.Name
+	})
+
+	return res, nil
+}
+
+// resourceID identifies a resource within the manifest of a release
+type resourceID struct {
+	kind, namespace, name string
+}
+
+func (id resourceID) toResourceDiff(action types.ResourceDiffAction, from, to string) types.ResourceDiff {
+	return types.ResourceDiff{
+		Kind:      id.kind,
+		Namespace: id.namespace,
+		Name:      id.name,
+		Action:    action,
+		Diff:      lineDiff(from, to),
+	}
+}
+
+// encodeResourcesByID re-encodes each resource, so that the manifests of both revisions are formatted alike.
+// Resources without a namespace are placed in the namespace of the release.
+func encodeResourcesByID(resources []resource, releaseNamespace string) (map[resourceID]string, error) {
+	res := make(map[resourceID]string)
+
+	for _, r := range resources {
+		name, ok := getResourceName(r)
+		if !ok {
+			continue
+		}
+
+		kind, _, ok := getKindAndAPIVersion(r)
+		if !ok {
+			continue
+		}
+
+		namespace := releaseNamespace
+
+		if metadata, ok := r["metadata"].(resource); ok {
+			if ns, ok := metadata["namespace"].(string); ok && ns != "" {
+				namespace = ns
+			}
+		}
+
+		encoded, err := yaml.Marshal(r)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding %s %s: %w", kind, name, err)
+		}
+
+		res[resourceID{kind: kind, namespace: namespace, name: name}] = string(encoded)
+	}
+
+	return res, nil
+}
+
+// lineDiff returns the lines which differ between from and to, along with up to diffContextLines unchanged lines
+// around each change. Runs of omitted unchanged lines are shown as "...".
+func lineDiff(from, to string) string {
+	d := &differ{}
+	d.diff(splitLines(from), splitLines(to))
+
+	lines := d.lines
+
+	// list the removed lines of each changed block before its added lines
+	for i := 0; i < len(lines); {
+		if lines[i].prefix == " " {
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(lines) && lines[j].prefix != " " {
+			j++
+		}
+
+		block := lines[i:j]
+
+		sort.SliceStable(block, func(p, q int) bool {
+			return block[p].prefix == "-" && block[q].prefix == "+"
+		})
+
+		i = j
+	}
+
+	// show only the lines within diffContextLines of a change
+	shown := make([]bool, len(lines))
+
+	for k, line := range lines {
+		if line.prefix == " " {
+			continue
+		}
+
+		for c := k - diffContextLines; c <= k+diffContextLines; c++ {
+			if c >= 0 && c < len(lines) {
+				shown[c] = true
+			}
+		}
+	}
+
+	var out strings.Builder
+
+	skipped := false
+
+	for k, line := range lines {
+		if !shown[k] {
+			skipped = true
+			continue
+		}
+
+		if skipped {
+			out.WriteString("...\n")
+			skipped = false
+		}
+
+		out.WriteString(line.prefix + line.text + "\n")
+	}
+
+	if skipped && out.Len() > 0 {
+		out.WriteString("...\n")
+	}
+
+	return out.String()
+}
+
+type diffLine struct {
+	prefix string
+	text   string
+}
+
+// differ computes a shortest line diff with the linear space variant of Myers' algorithm, which recursively splits
+// both inputs at the middle snake of a shortest edit script, so that large manifests can be diffed without allocating
+// memory proportional to the product of their lengths
+type differ struct {
+	lines []diffLine
+}
+
+func (d *differ) emit(prefix string, lines []string) {
+	for _, line := range lines {
+		d.lines = append(d.lines, diffLine{prefix, line})
+	}
+}
+
+func (d *differ) diff(a, b []string) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	d.emit(" ", a[:prefix])
+	a, b = a[prefix:], b[prefix:]
+
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	common := a[len(a)-suffix:]
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+
+	switch {
+	case len(a) == 0:
+		d.emit("+", b)
+	case len(b) == 0:
+		d.emit("-", a)
+	default:
+		// both inputs differ at their first and last lines, so the edit script has at least two edits and both
+		// halves around the middle snake are smaller than the inputs
+		x, y, u, v := middleSnake(a, b)
+
+		d.diff(a[:x], b[:y])
+		d.emit(" ", a[x:u])
+		d.diff(a[u:], b[v:])
+	}
+
+	d.emit(" ", common)
+}
+
+// middleSnake returns the middle snake of a shortest edit script from a to b, which is the run of common lines from
+// a[x:u] and b[y:v] where the forward and backward searches for the script meet
+func middleSnake(a, b []string) (x, y, u, v int) {
+	n, m := len(a), len(b)
+	delta := n - m
+	odd := delta%2 != 0
+	maxD := (n + m + 1) / 2
+
+	// forward[k] is the furthest x reached on diagonal k = x - y from the start of the inputs, and backward[c] is
+	// the furthest number of lines consumed from the end of a on diagonal c of the reversed inputs. Diagonal k of the
+	// forward search is diagonal delta - k of the backward search.
+	forward := make([]int, 2*maxD+2)
+	backward := make([]int, 2*maxD+2)
+
+	for d := 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && forward[maxD+k-1] < forward[maxD+k+1]) {
+				x = forward[maxD+k+1]
+			} else {
+				x = forward[maxD+k-1] + 1
+			}
+
+			y := x - k
+			x0, y0 := x, y
+
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			forward[maxD+k] = x
+
+			if c := delta - k; odd && c >= -(d-1) && c <= d-1 && x+backward[maxD+c] >= n {
+				return x0, y0, x, y
+			}
+		}
+
+		for c := -d; c <= d; c += 2 {
+			var xr int
+			if c == -d || (c != d && backward[maxD+c-1] < backward[maxD+c+1]) {
+				xr = backward[maxD+c+1]
+			} else {
+				xr = backward[maxD+c-1] + 1
+			}
+
+			yr := xr - c
+			xr0, yr0 := xr, yr
+
+			for xr < n && yr < m && a[n-1-xr] == b[m-1-yr] {
+				xr++
+				yr++
+			}
+
+			backward[maxD+c] = xr
+
+			if k := delta - c; !odd && k >= -d && k <= d && forward[maxD+k]+xr >= n {
+				return n - xr, m - yr, n - xr0, m - yr0
+			}
+		}
+	}
+
+	// unreachable, since the searches meet once d reaches half the length of the edit script
+	return n, m, n, m
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
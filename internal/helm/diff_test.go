@@ -0,0 +1,221 @@
+package helm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/stefanmcshane/helm/pkg/release"
+)
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		expected string
+	}{
+		{
+			name:     "identical",
+			from:     "a\nb\nc\n",
+			to:       "a\nb\nc\n",
+			expected: "",
+		},
+		{
+			name:     "created",
+			from:     "",
+			to:       "a\nb\n",
+			expected: "+a\n+b\n",
+		},
+		{
+			name:     "deleted",
+			from:     "a\nb\n",
+			to:       "",
+			expected: "-a\n-b\n",
+		},
+		{
+			name:     "changed line with context",
+			from:     "a\nb\nc\n",
+			to:       "a\nx\nc\n",
+			expected: " a\n-b\n+x\n c\n",
+		},
+		{
+			name:     "inserted line",
+			from:     "a\nc\n",
+			to:       "a\nb\nc\n",
+			expected: " a\n+b\n c\n",
+		},
+		{
+			name:     "unchanged lines outside of the context are omitted",
+			from:     "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n",
+			to:       "1\n2\n3\n4\n5\nx\n7\n8\n9\n10\n11\n",
+			expected: "...\n 3\n 4\n 5\n-6\n+x\n 7\n 8\n 9\n...\n",
+		},
+		{
+			name:     "separate changes",
+			from:     "a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n",
+			to:       "x\n1\n2\n3\n4\n5\n6\n7\n8\ny\n",
+			expected: "-a\n+x\n 1\n 2\n 3\n...\n 6\n 7\n 8\n-b\n+y\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lineDiff(tt.from, tt.to); got != tt.expected {
+				t.Errorf("expected diff %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLineDiffLargeInput(t *testing.T) {
+	var from, to strings.Builder
+
+	for i := 0; i < 20000; i++ {
+		fmt.Fprintf(&from, "from-%d\n", i)
+		fmt.Fprintf(&to, "to-%d\n", i)
+	}
+
+	got := lineDiff(from.String(), to.String())
+
+	if lines := strings.Count(got, "\n"); lines != 40000 {
+		t.Fatalf("expected 40000 changed lines, got %d", lines)
+	}
+
+	if !strings.HasPrefix(got, "-from-0\n") || !strings.HasSuffix(got, "+to-19999\n") {
+		t.Fatalf("expected all removed lines before all added lines")
+	}
+}
+
+const diffTestCurrentManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 1
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: removed
+data:
+  key: value
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: other
+spec:
+  type: ClusterIP
+`
+
+const diffTestUpgradedManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: added
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: other
+spec:
+  type: ClusterIP
+`
+
+func TestDiffReleaseManifests(t *testing.T) {
+	current := &release.Release{
+		Namespace: "default",
+		Version:   3,
+		Manifest:  diffTestCurrentManifest,
+	}
+
+	upgraded := &release.Release{
+		Namespace: "default",
+		Version:   4,
+		Manifest:  diffTestUpgradedManifest,
+	}
+
+	diff, err := DiffReleaseManifests(current, upgraded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff.CurrentRevision != 3 {
+		t.Errorf("expected current revision 3, got %d", diff.CurrentRevision)
+	}
+
+	expected := []types.ResourceDiff{
+		{Kind: "ConfigMap", Namespace: "default", Name: "removed", Action: types.ResourceDiffActionDelete},
+		{Kind: "Deployment", Namespace: "default", Name: "web", Action: types.ResourceDiffActionUpdate},
+		{Kind: "Secret", Namespace: "default", Name: "added", Action: types.ResourceDiffActionCreate},
+	}
+
+	if len(diff.Resources) != len(expected) {
+		t.Fatalf("expected %d changed resources, got %d: %v", len(expected), len(diff.Resources), diff.Resources)
+	}
+
+	for i, exp := range expected {
+		got := diff.Resources[i]
+
+		if got.Kind != exp.Kind || got.Namespace != exp.Namespace || got.Name != exp.Name || got.Action != exp.Action {
+			t.Errorf("expected resource %d to be %s %s/%s %s, got %s %s/%s %s", i,
+				exp.Action, exp.Namespace, exp.Kind, exp.Name, got.Action, got.Namespace, got.Kind, got.Name)
+		}
+	}
+
+	if update := diff.Resources[1].Diff; !strings.Contains(update, "-  replicas: 1\n+  replicas: 2\n") {
+		t.Errorf("expected deployment diff to change the replicas, got %q", update)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(diff.Resources[0].Diff, "\n"), "\n") {
+		if !strings.HasPrefix(line, "-") {
+			t.Errorf("expected only removed lines for a deleted resource, got %q", line)
+		}
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(diff.Resources[2].Diff, "\n"), "\n") {
+		if !strings.HasPrefix(line, "+") {
+			t.Errorf("expected only added lines for a created resource, got %q", line)
+		}
+	}
+}
+
+func TestDiffReleaseManifestsInstall(t *testing.T) {
+	diff, err := DiffReleaseManifests(nil, &release.Release{
+		Namespace: "default",
+		Version:   1,
+		Manifest:  diffTestUpgradedManifest,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff.CurrentRevision != 0 {
+		t.Errorf("expected no current revision, got %d", diff.CurrentRevision)
+	}
+
+	if len(diff.Resources) != 3 {
+		t.Fatalf("expected 3 created resources, got %d", len(diff.Resources))
+	}
+
+	for _, res := range diff.Resources {
+		if res.Action != types.ResourceDiffActionCreate {
+			t.Errorf("expected %s %s to be created, got %s", res.Kind, res.Name, res.Action)
+		}
+	}
+}
+
+func TestDiffReleaseManifestsNilUpgrade(t *testing.T) {
+	if _, err := DiffReleaseManifests(&release.Release{}, nil); err == nil {
+		t.Fatalf("expected an error for a nil upgraded release")
+	}
+}
@@ -0,0 +1,453 @@
+package grapher
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RelationType is the type of relation of an edge of a graph
+type RelationType string
+
+const (
+	// RelationTypeControl is the relation between a controller and the pods it creates
+	RelationTypeControl RelationType = "control"
+
+	// RelationTypeLabel is the relation between an object with a label selector and the pods it selects
+	RelationTypeLabel RelationType = "label"
+
+	// RelationTypeSpec is the relation between an object and an object its spec refers to, such as a pod and
+	// the ConfigMaps it mounts
+	RelationTypeSpec RelationType = "spec"
+)
+
+// ReleaseManifest is the rendered manifest of a release
+type ReleaseManifest struct {
+	Name     string
+	Manifest string
+}
+
+// Graph is the graph of the objects of one or more releases and the relations between them, in a format
+// that can be exported
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Node is an object of a graph
+type Node struct {
+	ID        int    `json:"id"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Release is the name of the release the object belongs to. It is empty for the ConfigMaps and Secrets which
+	// are referenced by the objects of releases without being part of any release.
+	Release string `json:"release"`
+}
+
+// Edge is a relation between two objects of a graph. The source of a control relation is the controller,
+// the source of a label relation is the object with the selector, and the source of a spec relation is the
+// object whose spec refers to the target.
+type Edge struct {
+	Source int          `json:"source"`
+	Target int          `json:"target"`
+	Type   RelationType `json:"type"`
+}
+
+// ImpactedObject is an object affected by a change to another object
+type ImpactedObject struct {
+	Node
+
+	// Reason describes the relation through which the object is affected, such as "references ConfigMap/web-config"
+	Reason string `json:"reason"`
+}
+
+// ParseReleases parses the objects of the releases of a namespace into a single graph, so that the
+// relations between objects of different releases are included. ConfigMaps and Secrets which are referenced by
+// the pods of the releases but are not part of any release, such as the ones created by Porter for environment
+// groups, are added to the graph without a release.
+func ParseReleases(releases []ReleaseManifest, namespace string) *Graph {
+	parsed := &ParsedObjs{}
+	objReleases := make(map[int]string)
+
+	for _, rel := range releases {
+		offset := 0
+		for _, obj := range parsed.Objects {
+			if obj.ID >= offset {
+				offset = obj.ID + 1
+			}
+		}
+
+		for _, obj := range ParseObjs(ImportMultiDocYAML([]byte(rel.Manifest)), namespace) {
+			obj.ID += offset
+			objReleases[obj.ID] = rel.Name
+			parsed.Objects = append(parsed.Objects, obj)
+		}
+	}
+
+	parsed.GetControlRel()
+	addExternalConfigObjects(parsed)
+	parsed.GetLabelRel()
+	parsed.GetSpecRel()
+
+	// pods created by controllers belong to the release of their controller
+	for _, obj := range parsed.Objects {
+		if _, ok := objReleases[obj.ID]; !ok && len(obj.Relations.ControlRels) != 0 {
+			objReleases[obj.ID] = objReleases[obj.Relations.ControlRels[0].Source]
+		}
+	}
+
+	return NewGraph(parsed, objReleases)
+}
+
+// addExternalConfigObjects adds an object for each ConfigMap and Secret referenced by the pods of parsed objects
+// which is not one of the parsed objects, so that spec relations to them are found
+func addExternalConfigObjects(parsed *ParsedObjs) {
+	type objectKey struct {
+		kind, name string
+	}
+
+	exists := make(map[objectKey]bool)
+	nextID := 0
+
+	for _, obj := range parsed.Objects {
+		exists[objectKey{obj.Kind, obj.Name}] = true
+
+		if obj.ID >= nextID {
+			nextID = obj.ID + 1
+		}
+	}
+
+	external := []Object{}
+
+	for _, obj := range parsed.Objects {
+		if obj.Kind != "Pod" {
+			continue
+		}
+
+		for _, ref := range configReferences(obj.RawYAML) {
+			key := objectKey{ref.kind, ref.name}
+
+			if exists[key] {
+				continue
+			}
+
+			exists[key] = true
+
+			external = append(external, Object{
+				ID:        nextID,
+				Kind:      ref.kind,
+				Name:      ref.name,
+				Namespace: obj.Namespace,
+				RawYAML: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       ref.kind,
+					"metadata": map[string]interface{}{
+						"name":      ref.name,
+						"namespace": obj.Namespace,
+					},
+				},
+				Relations: Relations{
+					ControlRels: []ControlRel{},
+					LabelRels:   []LabelRel{},
+					SpecRels:    []SpecRel{},
+				},
+			})
+
+			nextID++
+		}
+	}
+
+	parsed.Objects = append(parsed.Objects, external...)
+}
+
+type configReference struct {
+	kind, name string
+}
+
+// configReferences returns the ConfigMaps and Secrets that the spec of a pod mounts as volumes or reads
+// environment variables from
+func configReferences(yaml map[string]interface{}) []configReference {
+	refs := []configReference{}
+
+	add := func(kind string, name interface{}) {
+		if n, ok := name.(string); ok && n != "" {
+			refs = append(refs, configReference{kind: kind, name: n})
+		}
+	}
+
+	volumes, _ := getField(yaml, "spec", "volumes").([]interface{})
+
+	for _, v := range volumes {
+		volume, _ := v.(map[string]interface{})
+		if volume == nil {
+			continue
+		}
+
+		add("ConfigMap", getField(volume, "configMap", "name"))
+		add("Secret", getField(volume, "secret", "secretName"))
+	}
+
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _ := getField(yaml, "spec", field).([]interface{})
+
+		for _, c := range containers {
+			container, _ := c.(map[string]interface{})
+			if container == nil {
+				continue
+			}
+
+			envFrom, _ := container["envFrom"].([]interface{})
+
+			for _, ef := range envFrom {
+				if source, _ := ef.(map[string]interface{}); source != nil {
+					add("ConfigMap", getField(source, "configMapRef", "name"))
+					add("Secret", getField(source, "secretRef", "name"))
+				}
+			}
+
+			env, _ := container["env"].([]interface{})
+
+			for _, e := range env {
+				if envVar, _ := e.(map[string]interface{}); envVar != nil && envVar["valueFrom"] != nil {
+					add("ConfigMap", getField(envVar, "valueFrom", "configMapKeyRef", "name"))
+					add("Secret", getField(envVar, "valueFrom", "secretKeyRef", "name"))
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+// NewGraph creates a graph from parsed objects whose relations have been computed. releases maps the ID of
+// each object to the name of its release, and may be nil.
+func NewGraph(parsed *ParsedObjs, releases map[int]string) *Graph {
+	graph := &Graph{
+		Nodes: []Node{},
+		Edges: []Edge{},
+	}
+
+	// relations are stored on both of the objects they connect, so edges are deduplicated
+	seen := make(map[Edge]bool)
+
+	addEdge := func(rel Relation, relType RelationType) {
+		edge := Edge{Source: rel.Source, Target: rel.Target, Type: relType}
+
+		if rel.Source == rel.Target || seen[edge] {
+			return
+		}
+
+		seen[edge] = true
+		graph.Edges = append(graph.Edges, edge)
+	}
+
+	for _, obj := range parsed.Objects {
+		graph.Nodes = append(graph.Nodes, Node{
+			ID:        obj.ID,
+			Kind:      obj.Kind,
+			Name:      obj.Name,
+			Namespace: obj.Namespace,
+			Release:   releases[obj.ID],
+		})
+
+		for _, rel := range obj.Relations.ControlRels {
+			addEdge(rel.Relation, RelationTypeControl)
+		}
+
+		for _, rel := range obj.Relations.LabelRels {
+			addEdge(rel.Relation, RelationTypeLabel)
+		}
+
+		for _, rel := range obj.Relations.SpecRels {
+			addEdge(rel.Relation, RelationTypeSpec)
+		}
+	}
+
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].Source != graph.Edges[j].Source {
+			return graph.Edges[i].Source < graph.Edges[j].Source
+		}
+
+		if graph.Edges[i].Target != graph.Edges[j].Target {
+			return graph.Edges[i].Target < graph.Edges[j].Target
+		}
+
+		return graph.Edges[i].Type < graph.Edges[j].Type
+	})
+
+	return graph
+}
+
+// FilterRelease returns the subgraph of the objects of a release, along with the objects of other releases
+// that they are directly related to
+func (g *Graph) FilterRelease(release string) *Graph {
+	include := make(map[int]bool)
+
+	for _, node := range g.Nodes {
+		if node.Release == release {
+			include[node.ID] = true
+		}
+	}
+
+	res := &Graph{
+		Nodes: []Node{},
+		Edges: []Edge{},
+	}
+
+	neighbours := make(map[int]bool)
+
+	for _, edge := range g.Edges {
+		if include[edge.Source] || include[edge.Target] {
+			res.Edges = append(res.Edges, edge)
+			neighbours[edge.Source] = true
+			neighbours[edge.Target] = true
+		}
+	}
+
+	for _, node := range g.Nodes {
+		if include[node.ID] || neighbours[node.ID] {
+			res.Nodes = append(res.Nodes, node)
+		}
+	}
+
+	return res
+}
+
+// Impact returns the objects affected by a change to the objects with the given kind and name, ordered by
+// their distance from the changed objects. A change propagates from a controller to its pods, from an
+// object to the objects whose specs refer to it, and from a pod to the objects that select it.
+func (g *Graph) Impact(kind, name string) ([]ImpactedObject, error) {
+	nodes := make(map[int]Node)
+	queue := []int{}
+	visited := make(map[int]bool)
+
+	for _, node := range g.Nodes {
+		nodes[node.ID] = node
+
+		if strings.EqualFold(node.Kind, kind) && node.Name == name {
+			queue = append(queue, node.ID)
+			visited[node.ID] = true
+		}
+	}
+
+	if len(queue) == 0 {
+		return nil, fmt.Errorf("%s/%s not found", kind, name)
+	}
+
+	// affected maps each object to the objects a change to it affects, with the reason they are affected
+	type affected struct {
+		id     int
+		reason string
+	}
+
+	affects := make(map[int][]affected)
+
+	for _, edge := range g.Edges {
+		source, target := nodes[edge.Source], nodes[edge.Target]
+
+		switch edge.Type {
+		case RelationTypeControl:
+			affects[edge.Source] = append(affects[edge.Source], affected{
+				id:     edge.Target,
+				reason: fmt.Sprintf("controlled by %s/%s", source.Kind, source.Name),
+			})
+		case RelationTypeLabel:
+			affects[edge.Target] = append(affects[edge.Target], affected{
+				id:     edge.Source,
+				reason: fmt.Sprintf("selects %s/%s", target.Kind, target.Name),
+			})
+		case RelationTypeSpec:
+			affects[edge.Target] = append(affects[edge.Target], affected{
+				id:     edge.Source,
+				reason: fmt.Sprintf("references %s/%s", target.Kind, target.Name),
+			})
+		}
+	}
+
+	res := []ImpactedObject{}
+
+	for len(queue) != 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		for _, a := range affects[id] {
+			if visited[a.id] {
+				continue
+			}
+
+			visited[a.id] = true
+			queue = append(queue, a.id)
+
+			res = append(res, ImpactedObject{
+				Node:   nodes[a.id],
+				Reason: a.reason,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// DOT returns the graph in the DOT language of Graphviz. The objects of each release are grouped in a
+// cluster.
+func (g *Graph) DOT() string {
+	var sb strings.Builder
+
+	sb.WriteString("digraph resources {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+
+	releaseNodes := make(map[string][]Node)
+	releaseNames := []string{}
+
+	for _, node := range g.Nodes {
+		if _, ok := releaseNodes[node.Release]; !ok {
+			releaseNames = append(releaseNames, node.Release)
+		}
+
+		releaseNodes[node.Release] = append(releaseNodes[node.Release], node)
+	}
+
+	sort.Strings(releaseNames)
+
+	for i, release := range releaseNames {
+		indent := "  "
+
+		if release != "" {
+			fmt.Fprintf(&sb, "  subgraph cluster_%d {\n", i)
+			fmt.Fprintf(&sb, "    label=%s;\n", dotQuote(release))
+			indent = "    "
+		}
+
+		for _, node := range releaseNodes[release] {
+			fmt.Fprintf(&sb, "%sn%d [label=%s];\n", indent, node.ID, dotQuote(node.Kind+"\n"+node.Name))
+		}
+
+		if release != "" {
+			sb.WriteString("  }\n")
+		}
+	}
+
+	for _, edge := range g.Edges {
+		style := "solid"
+
+		switch edge.Type {
+		case RelationTypeLabel:
+			style = "dashed"
+		case RelationTypeSpec:
+			style = "dotted"
+		}
+
+		fmt.Fprintf(&sb, "  n%d -> n%d [label=%s, style=%s];\n", edge.Source, edge.Target, dotQuote(string(edge.Type)), style)
+	}
+
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
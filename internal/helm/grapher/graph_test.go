@@ -0,0 +1,214 @@
+package grapher_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm/grapher"
+)
+
+const sharedConfigManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: shared-config
+data:
+  LOG_LEVEL: debug
+`
+
+const webManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx
+        envFrom:
+        - configMapRef:
+            name: shared-config
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  selector:
+    app: web
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+spec:
+  rules:
+  - host: web.example.com
+    http:
+      paths:
+      - path: /
+        backend:
+          service:
+            name: web
+`
+
+func TestImpactAcrossReleases(t *testing.T) {
+	graph := grapher.ParseReleases([]grapher.ReleaseManifest{
+		{Name: "shared", Manifest: sharedConfigManifest},
+		{Name: "web", Manifest: webManifest},
+	}, "default")
+
+	impacted, err := graph.Impact("ConfigMap", "shared-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]string)
+
+	for _, obj := range impacted {
+		got[obj.Kind+"/"+obj.Name] = obj.Release
+	}
+
+	expected := map[string]string{
+		"Pod/web-0":      "web",
+		"Deployment/web": "web",
+		"Service/web":    "web",
+		"Ingress/web":    "web",
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("expected impacted objects %v, got %v", expected, got)
+	}
+
+	for obj, release := range expected {
+		if got[obj] != release {
+			t.Errorf("expected %s of release %s to be impacted, got %v", obj, release, got)
+		}
+	}
+
+	if impacted[0].Kind != "Pod" || impacted[0].Reason != "references ConfigMap/shared-config" {
+		t.Errorf("expected the pod to be impacted first through the config map, got %s/%s: %s",
+			impacted[0].Kind, impacted[0].Name, impacted[0].Reason)
+	}
+
+	if _, err := graph.Impact("Secret", "missing"); err == nil {
+		t.Errorf("expected an error for an object which does not exist")
+	}
+}
+
+func TestFilterReleaseAndDOT(t *testing.T) {
+	graph := grapher.ParseReleases([]grapher.ReleaseManifest{
+		{Name: "shared", Manifest: sharedConfigManifest},
+		{Name: "web", Manifest: webManifest},
+	}, "default")
+
+	// the config map of the shared release is included as a neighbour of the pod of the web release
+	filtered := graph.FilterRelease("web")
+
+	if len(filtered.Nodes) != len(graph.Nodes) {
+		t.Errorf("expected %d nodes, got %d", len(graph.Nodes), len(filtered.Nodes))
+	}
+
+	filtered = graph.FilterRelease("shared")
+
+	if len(filtered.Nodes) != 2 || len(filtered.Edges) != 1 {
+		t.Errorf("expected the config map and the pod which references it, got %v", filtered.Nodes)
+	}
+
+	dot := graph.DOT()
+
+	for _, expected := range []string{
+		"digraph resources {",
+		`label="shared";`,
+		`[label="ConfigMap\nshared-config"];`,
+		`[label="spec", style=dotted];`,
+	} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("expected dot output to contain %s, got:\n%s", expected, dot)
+		}
+	}
+}
+
+const workerManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: worker
+  template:
+    metadata:
+      labels:
+        app: worker
+    spec:
+      containers:
+      - name: worker
+        image: worker
+        env:
+        - name: DATABASE_PASSWORD
+          valueFrom:
+            secretKeyRef:
+              name: db-credentials
+              key: password
+        envFrom:
+        - configMapRef:
+            name: shared-config
+      volumes:
+      - name: settings
+        configMap:
+          name: worker-settings
+`
+
+func TestImpactOfObjectsOutsideReleases(t *testing.T) {
+	graph := grapher.ParseReleases([]grapher.ReleaseManifest{
+		{Name: "shared", Manifest: sharedConfigManifest},
+		{Name: "worker", Manifest: workerManifest},
+	}, "default")
+
+	external := make(map[string]grapher.Node)
+	configMaps := 0
+
+	for _, node := range graph.Nodes {
+		if node.Release == "" {
+			external[node.Kind+"/"+node.Name] = node
+		}
+
+		if node.Kind == "ConfigMap" && node.Name == "shared-config" {
+			configMaps++
+		}
+	}
+
+	if len(external) != 2 || external["Secret/db-credentials"].Namespace != "default" || external["ConfigMap/worker-settings"].Namespace != "default" {
+		t.Fatalf("expected the secret and the config map referenced by the worker to be added without a release, got %v", external)
+	}
+
+	if configMaps != 1 {
+		t.Errorf("expected the config map of the shared release not to be added again, got %d", configMaps)
+	}
+
+	for _, ref := range []struct {
+		kind, name string
+	}{
+		{"Secret", "db-credentials"},
+		{"ConfigMap", "worker-settings"},
+	} {
+		impacted, err := graph.Impact(ref.kind, ref.name)
+		if err != nil {
+			t.Fatalf("unexpected error getting the impact of %s/%s: %v", ref.kind, ref.name, err)
+		}
+
+		if len(impacted) == 0 || impacted[0].Kind != "Pod" || impacted[0].Release != "worker" ||
+			impacted[0].Reason != "references "+ref.kind+"/"+ref.name {
+			t.Fatalf("expected the pod of the worker to be impacted through %s/%s, got %v", ref.kind, ref.name, impacted)
+		}
+	}
+}
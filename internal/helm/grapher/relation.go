@@ -64,6 +64,16 @@ func (parsed *ParsedObjs) GetControlRel() {
 	// First collect all children (Pods) that are not included in the yaml as top-level object.
 	children := []Object{}
 	selectors := []string{}
+
+	// children get IDs after the highest ID of the parsed objects, which is not the number of objects if
+	// documents were skipped while parsing
+	nextID := len(parsed.Objects)
+	for _, obj := range parsed.Objects {
+		if obj.ID >= nextID {
+			nextID = obj.ID + 1
+		}
+	}
+
 	for i, obj := range parsed.Objects {
 		yaml := obj.RawYAML
 		kind := getField(yaml, "kind")
@@ -89,7 +99,7 @@ func (parsed *ParsedObjs) GetControlRel() {
 			}
 
 			for j := 0; j < rs.(int); j++ {
-				cid := len(parsed.Objects) + len(children)
+				cid := nextID + len(children)
 				crel := ControlRel{
 					Relation: Relation{
 						Source: obj.ID,
@@ -190,7 +200,7 @@ func (parsed *ParsedObjs) GetSpecRel() {
 						name = getField(p.(map[string]interface{}), "backend", "resource", "name")
 						kind = getField(p.(map[string]interface{}), "backend", "resource", "kind").(string)
 					}
-					tid = parsed.findObjectByNameAndKind(o.ID, name, kind)
+					tid = append(tid, parsed.findObjectByNameAndKind(o.ID, name, kind)...)
 				}
 			}
 		case "StatefulSet":
@@ -224,6 +234,8 @@ func (parsed *ParsedObjs) GetSpecRel() {
 				tid = append(tid, parsed.findObjectByNameAndKind(o.ID, pvc, "PersistentVolumeClaim")...)
 				tid = append(tid, parsed.findObjectByNameAndKind(o.ID, secret, "Secret")...)
 			}
+
+			tid = append(tid, parsed.findEnvTargets(o.ID, o.RawYAML)...)
 		}
 
 		// Add edges to parent
@@ -316,6 +328,48 @@ func (parsed *ParsedObjs) findObjectByNameAndKind(parentID int, name interface{}
 	return targets
 }
 
+// findEnvTargets finds the ConfigMaps and Secrets that the containers of a pod read environment variables from
+func (parsed *ParsedObjs) findEnvTargets(parentID int, yaml map[string]interface{}) []int {
+	targets := []int{}
+
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _ := getField(yaml, "spec", field).([]interface{})
+
+		for _, c := range containers {
+			container, _ := c.(map[string]interface{})
+			if container == nil {
+				continue
+			}
+
+			envFrom, _ := container["envFrom"].([]interface{})
+
+			for _, ef := range envFrom {
+				source, _ := ef.(map[string]interface{})
+				if source == nil {
+					continue
+				}
+
+				targets = append(targets, parsed.findObjectByNameAndKind(parentID, getField(source, "configMapRef", "name"), "ConfigMap")...)
+				targets = append(targets, parsed.findObjectByNameAndKind(parentID, getField(source, "secretRef", "name"), "Secret")...)
+			}
+
+			env, _ := container["env"].([]interface{})
+
+			for _, e := range env {
+				envVar, _ := e.(map[string]interface{})
+				if envVar == nil || envVar["valueFrom"] == nil {
+					continue
+				}
+
+				targets = append(targets, parsed.findObjectByNameAndKind(parentID, getField(envVar, "valueFrom", "configMapKeyRef", "name"), "ConfigMap")...)
+				targets = append(targets, parsed.findObjectByNameAndKind(parentID, getField(envVar, "valueFrom", "secretKeyRef", "name"), "Secret")...)
+			}
+		}
+	}
+
+	return targets
+}
+
 func (parsed *ParsedObjs) findRBACTargets(parentID int, yaml map[string]interface{}) []int {
 	roleRef := getField(yaml, "roleRef")
 	subjects := getField(yaml, "subjects")
@@ -370,10 +424,10 @@ func (parsed *ParsedObjs) findLabelsBySelector(parentID int, ml []MatchLabel, me
 		}
 
 		// find Pods that match labels
-		labels := getField(o.RawYAML, "metadata", "labels")
+		labels, _ := getField(o.RawYAML, "metadata", "labels").(map[string]interface{})
 		match := 0
 		for _, l := range ml {
-			if labels.(map[string]interface{})[l.key] == l.value {
+			if labels[l.key] == l.value {
 				match++
 			}
 		}
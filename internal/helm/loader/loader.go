@@ -137,6 +137,16 @@ func LoadChart(ctx context.Context, client *BasicAuthClient, repoURL, chartName,
 		telemetry.AttributeKV{Key: "chart-version", Value: chartVersion},
 	)
 
+	// OCI repos do not have an index file, so the chart is pulled from the registry directly
+	if IsOCIRepoURL(repoURL) {
+		ociClient, err := NewOCIClientFromBasicAuth(client, repoURL)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error creating oci client")
+		}
+
+		return LoadOCIChart(ctx, ociClient, repoURL, chartName, chartVersion)
+	}
+
 	repoIndex, err := LoadRepoIndex(client, repoURL)
 	if err != nil {
 		return nil, telemetry.Error(ctx, span, err, "error loading repo index")
@@ -0,0 +1,176 @@
+package loader
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"github.com/stefanmcshane/helm/pkg/chart"
+	chartloader "github.com/stefanmcshane/helm/pkg/chart/loader"
+	"github.com/stefanmcshane/helm/pkg/registry"
+)
+
+// OCIClient holds the credentials used to access charts in an OCI registry
+type OCIClient struct {
+	// DockerConfigJSON is a docker config file with credentials for the registry. If it is empty, the
+	// registry is accessed anonymously.
+	DockerConfigJSON []byte
+}
+
+// NewOCIClientFromBasicAuth creates an OCI client which authenticates to the registry of an OCI repo
+// with a username and password
+func NewOCIClientFromBasicAuth(client *BasicAuthClient, repoURL string) (*OCIClient, error) {
+	if client == nil || client.Username == "" {
+		return &OCIClient{}, nil
+	}
+
+	dockerConfigJSON, err := json.Marshal(map[string]interface{}{
+		"auths": map[string]interface{}{
+			OCIRepoHost(repoURL): map[string]string{
+				"auth": base64.StdEncoding.EncodeToString([]byte(client.Username + ":" + client.Password)),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OCIClient{DockerConfigJSON: dockerConfigJSON}, nil
+}
+
+// IsOCIRepoURL returns true if the repo URL refers to charts stored in an OCI registry, such as
+// oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts
+func IsOCIRepoURL(repoURL string) bool {
+	return registry.IsOCI(strings.TrimSpace(repoURL))
+}
+
+// OCIRepoHost returns the host of the registry of an OCI repo URL
+func OCIRepoHost(repoURL string) string {
+	return strings.SplitN(OCIRepoPath(repoURL), "/", 2)[0]
+}
+
+// OCIRepoPath returns an OCI repo URL without the oci:// scheme, which is the prefix of the OCI
+// references of the charts in the repo
+func OCIRepoPath(repoURL string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(repoURL), registry.OCIScheme+"://"), "/")
+}
+
+// LoadOCIChartVersions lists the versions of a chart in an OCI repo, which are the semver tags of the
+// chart, sorted from newest to oldest
+func LoadOCIChartVersions(client *OCIClient, repoURL, chartName string) ([]string, error) {
+	registryClient, cleanup, err := client.newRegistryClient()
+	if err != nil {
+		return nil, err
+	}
+
+	defer cleanup()
+
+	return registryClient.Tags(OCIRepoPath(repoURL) + "/" + chartName)
+}
+
+// LoadOCIRepoCharts lists the given charts of an OCI repo as porter charts. Charts without any
+// versions are omitted.
+func LoadOCIRepoCharts(client *OCIClient, repoURL string, chartNames []string) (types.ListTemplatesResponse, error) {
+	porterCharts := make(types.ListTemplatesResponse, 0)
+
+	for _, chartName := range chartNames {
+		versions, err := LoadOCIChartVersions(client, repoURL, chartName)
+		if err != nil {
+			return nil, fmt.Errorf("error listing versions of chart %s: %w", chartName, err)
+		}
+
+		if len(versions) == 0 {
+			continue
+		}
+
+		porterCharts = append(porterCharts, types.PorterTemplateSimple{
+			Name:     chartName,
+			Versions: versions,
+			RepoURL:  repoURL,
+		})
+	}
+
+	return porterCharts, nil
+}
+
+// LoadOCIChart pulls a chart from an OCI repo. If chartVersion is an empty string, the latest
+// version is pulled.
+func LoadOCIChart(ctx context.Context, client *OCIClient, repoURL, chartName, chartVersion string) (*chart.Chart, error) {
+	ctx, span := telemetry.NewSpan(ctx, "load-oci-chart")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "repo-url", Value: repoURL},
+		telemetry.AttributeKV{Key: "chart-name", Value: chartName},
+		telemetry.AttributeKV{Key: "chart-version", Value: chartVersion},
+	)
+
+	registryClient, cleanup, err := client.newRegistryClient()
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error creating registry client")
+	}
+
+	defer cleanup()
+
+	ref := OCIRepoPath(repoURL) + "/" + chartName
+
+	tags, err := registryClient.Tags(ref)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing chart tags")
+	}
+
+	tag, err := registry.GetTagMatchingVersionOrConstraint(tags, chartVersion)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error finding chart version")
+	}
+
+	res, err := registryClient.Pull(fmt.Sprintf("%s:%s", ref, tag), registry.PullOptWithChart(true))
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error pulling chart")
+	}
+
+	return chartloader.LoadArchive(bytes.NewReader(res.Chart.Data))
+}
+
+// newRegistryClient creates a registry client which reads the credentials of the OCI client from a
+// temporary credentials file. The returned function removes the credentials file.
+func (c *OCIClient) newRegistryClient() (*registry.Client, func(), error) {
+	dir, err := ioutil.TempDir("", "porter-oci-")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanup := func() {
+		os.RemoveAll(dir) //nolint:errcheck,gosec
+	}
+
+	dockerConfigJSON := []byte("{}")
+
+	if c != nil && len(c.DockerConfigJSON) != 0 {
+		dockerConfigJSON = c.DockerConfigJSON
+	}
+
+	credentialsFile := filepath.Join(dir, "config.json")
+
+	err = ioutil.WriteFile(credentialsFile, dockerConfigJSON, 0o600)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	registryClient, err := registry.NewClient(registry.ClientOptCredentialsFile(credentialsFile))
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	return registryClient, cleanup, nil
+}
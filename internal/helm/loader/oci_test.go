@@ -0,0 +1,86 @@
+package loader_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm/loader"
+)
+
+func TestOCIRepoURL(t *testing.T) {
+	tests := []struct {
+		repoURL string
+		isOCI   bool
+		host    string
+		path    string
+	}{
+		{
+			repoURL: "oci://123456789012.dkr.ecr.us-east-1.amazonaws.com/charts/",
+			isOCI:   true,
+			host:    "123456789012.dkr.ecr.us-east-1.amazonaws.com",
+			path:    "123456789012.dkr.ecr.us-east-1.amazonaws.com/charts",
+		},
+		{
+			repoURL: " oci://us-central1-docker.pkg.dev/my-project/charts",
+			isOCI:   true,
+			host:    "us-central1-docker.pkg.dev",
+			path:    "us-central1-docker.pkg.dev/my-project/charts",
+		},
+		{
+			repoURL: "https://charts.getporter.dev",
+			isOCI:   false,
+		},
+	}
+
+	for _, test := range tests {
+		if isOCI := loader.IsOCIRepoURL(test.repoURL); isOCI != test.isOCI {
+			t.Errorf("%q: expected oci to be %t, got %t", test.repoURL, test.isOCI, isOCI)
+		}
+
+		if !test.isOCI {
+			continue
+		}
+
+		if host := loader.OCIRepoHost(test.repoURL); host != test.host {
+			t.Errorf("%q: expected host %q, got %q", test.repoURL, test.host, host)
+		}
+
+		if path := loader.OCIRepoPath(test.repoURL); path != test.path {
+			t.Errorf("%q: expected path %q, got %q", test.repoURL, test.path, path)
+		}
+	}
+}
+
+func TestNewOCIClientFromBasicAuth(t *testing.T) {
+	client, err := loader.NewOCIClientFromBasicAuth(&loader.BasicAuthClient{
+		Username: "user",
+		Password: "pass",
+	}, "oci://registry.example.com/charts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dockerConfig := struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}{}
+
+	err = json.Unmarshal(client.DockerConfigJSON, &dockerConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if auth := dockerConfig.Auths["registry.example.com"].Auth; auth != "dXNlcjpwYXNz" {
+		t.Errorf("expected auth for registry.example.com to be dXNlcjpwYXNz, got %q", auth)
+	}
+
+	client, err = loader.NewOCIClientFromBasicAuth(&loader.BasicAuthClient{}, "oci://registry.example.com/charts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(client.DockerConfigJSON) != 0 {
+		t.Errorf("expected anonymous client, got docker config %s", client.DockerConfigJSON)
+	}
+}
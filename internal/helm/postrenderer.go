@@ -33,6 +33,7 @@ func NewPorterPostrenderer(
 	regs []*models.Registry,
 	doAuth *oauth2.Config,
 	disablePullSecretsInjection bool,
+	dryRun bool,
 ) (postrender.PostRenderer, error) {
 	var dockerSecretsPostrenderer *DockerSecretsPostRenderer
 	var err error
@@ -43,6 +44,8 @@ func NewPorterPostrenderer(
 		if err != nil {
 			return nil, err
 		}
+
+		dockerSecretsPostrenderer.DryRun = dryRun
 	}
 
 	envVarPostrenderer, err := NewEnvironmentVariablePostrenderer()
@@ -86,6 +89,9 @@ type DockerSecretsPostRenderer struct {
 	Namespace string
 	DOAuth    *oauth2.Config
 
+	// DryRun only adds the names of the image pull secrets to the pod specs, without creating or updating the secrets
+	DryRun bool
+
 	registries map[string]*models.Registry
 
 	podSpecs  []resource
@@ -200,6 +206,7 @@ func (d *DockerSecretsPostRenderer) Run(
 					Agent:      d.Agent,
 					Namespace:  d.Namespace,
 					DOAuth:     d.DOAuth,
+					DryRun:     d.DryRun,
 					registries: d.registries,
 					podSpecs:   make([]resource, 0),
 					resources:  make([]resource, 0),
@@ -217,15 +224,23 @@ func (d *DockerSecretsPostRenderer) Run(
 		}
 	}
 
-	// create the necessary secrets
-	secrets, err := d.Agent.CreateImagePullSecrets(
-		d.Repo,
-		d.Namespace,
-		linkedRegs,
-		d.DOAuth,
-	)
-	if err != nil {
-		return renderedManifests, nil
+	secrets := make(map[string]string)
+
+	if d.DryRun {
+		for key, reg := range linkedRegs {
+			secrets[key] = kubernetes.ImagePullSecretName(reg)
+		}
+	} else {
+		// create the necessary secrets
+		secrets, err = d.Agent.CreateImagePullSecrets(
+			d.Repo,
+			d.Namespace,
+			linkedRegs,
+			d.DOAuth,
+		)
+		if err != nil {
+			return renderedManifests, nil
+		}
 	}
 
 	d.updatePodSpecs(secrets)
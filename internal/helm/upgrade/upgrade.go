@@ -1,7 +1,12 @@
 package upgrade
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	semver "github.com/Masterminds/semver/v3"
+	"github.com/stefanmcshane/helm/pkg/chart"
 	"sigs.k8s.io/yaml"
 )
 
@@ -16,6 +21,35 @@ type UpgradeNote struct {
 	PreviousVersion string `yaml:"previous" json:"previous"`
 	TargetVersion   string `yaml:"target" json:"target"`
 	Note            string `yaml:"note" json:"note"`
+
+	// RequiresAcknowledgement blocks upgrades across the note until the user confirms that they have read it
+	RequiresAcknowledgement bool `yaml:"requires_acknowledgement" json:"requires_acknowledgement,omitempty"`
+
+	// Migrations are applied in order to the values of a release when it is upgraded across the note
+	Migrations []*ValueMigration `yaml:"migrations" json:"migrations,omitempty"`
+}
+
+// MigrationType is the kind of change a value migration makes
+type MigrationType string
+
+const (
+	// MigrationRenameKey moves the value at Key to NewKey. If NewKey is already set, the value at Key is dropped.
+	MigrationRenameKey MigrationType = "rename_key"
+
+	// MigrationSetDefault sets the value at Key to Value, if Key is not set
+	MigrationSetDefault MigrationType = "set_default"
+
+	// MigrationRemoveKey removes the value at Key
+	MigrationRemoveKey MigrationType = "remove_key"
+)
+
+// ValueMigration is a machine-readable change to the values of a release. Keys are dot-separated paths into the
+// values, such as "container.env.normal".
+type ValueMigration struct {
+	Type   MigrationType `yaml:"type" json:"type"`
+	Key    string        `yaml:"key" json:"key"`
+	NewKey string        `yaml:"new_key" json:"new_key,omitempty"`
+	Value  interface{}   `yaml:"value" json:"value,omitempty"`
 }
 
 // ParseUpgradeFileFromBytes parses the raw bytes of an upgrade file and returns an
@@ -29,20 +63,53 @@ func ParseUpgradeFileFromBytes(upgradeNotes []byte) (*UpgradeFile, error) {
 		return nil, err
 	}
 
+	for _, note := range res.UpgradeNotes {
+		for _, migration := range note.Migrations {
+			if err := migration.validate(); err != nil {
+				return nil, fmt.Errorf("invalid migration in upgrade note for %s: %w", note.TargetVersion, err)
+			}
+		}
+	}
+
 	return res, err
 }
 
+// GetUpgradeFileFromChart returns the upgrade file of a chart, or an empty upgrade file if the chart
+// does not have one
+func GetUpgradeFileFromChart(ch *chart.Chart) (*UpgradeFile, error) {
+	if ch != nil {
+		for _, file := range ch.Files {
+			if strings.Contains(file.Name, "upgrade.yaml") {
+				return ParseUpgradeFileFromBytes(file.Data)
+			}
+		}
+	}
+
+	return &UpgradeFile{}, nil
+}
+
 // GetUpgradeFileBetweenVersions gets the set of upgrade notes that are applicable to an upgrade
-// between a previous and target version.
+// between a previous and target version. If the target version is empty, all notes since the previous
+// version are applicable. The notes are sorted by target version.
 func (u *UpgradeFile) GetUpgradeFileBetweenVersions(prev, target string) (*UpgradeFile, error) {
 	prevVersion, err := semver.NewVersion(prev)
 	if err != nil {
 		return nil, err
 	}
 
+	var targetVersion *semver.Version
+
+	if target != "" {
+		targetVersion, err = semver.NewVersion(target)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// for each upgrade note, determine if it's geq than the previous version, leq the target
 	// version
 	resNotes := make([]*UpgradeNote, 0)
+	noteTargetVersions := make(map[*UpgradeNote]*semver.Version)
 
 	for _, note := range u.UpgradeNotes {
 		notePrevVersion, err := semver.NewVersion(note.PreviousVersion)
@@ -55,15 +122,171 @@ func (u *UpgradeFile) GetUpgradeFileBetweenVersions(prev, target string) (*Upgra
 			return nil, err
 		}
 
+		// notes for upgrades past the target version do not apply yet
+		if targetVersion != nil && noteTargetVersion.GreaterThan(targetVersion) {
+			continue
+		}
+
 		// if note(prev) <= prev and note(next) >= prev, render the note
 		if comp := notePrevVersion.Compare(prevVersion); comp != -1 {
 			if comp := noteTargetVersion.Compare(prevVersion); comp != -1 {
 				resNotes = append(resNotes, note)
+				noteTargetVersions[note] = noteTargetVersion
 			}
 		}
 	}
 
+	sort.SliceStable(resNotes, func(i, j int) bool {
+		return noteTargetVersions[resNotes[i]].LessThan(noteTargetVersions[resNotes[j]])
+	})
+
 	return &UpgradeFile{
 		UpgradeNotes: resNotes,
 	}, nil
 }
+
+// GetNotesRequiringAcknowledgement returns the notes which block an upgrade until they are acknowledged
+func (u *UpgradeFile) GetNotesRequiringAcknowledgement() []*UpgradeNote {
+	res := make([]*UpgradeNote, 0)
+
+	for _, note := range u.UpgradeNotes {
+		if note.RequiresAcknowledgement {
+			res = append(res, note)
+		}
+	}
+
+	return res
+}
+
+// ApplyMigrations applies the migrations of each note, in order, to the values of a release. The values are
+// modified in place.
+func (u *UpgradeFile) ApplyMigrations(values map[string]interface{}) error {
+	for _, note := range u.UpgradeNotes {
+		for _, migration := range note.Migrations {
+			if err := migration.apply(values); err != nil {
+				return fmt.Errorf("error applying %s migration of %s for upgrade to %s: %w", migration.Type,
+					migration.Key, note.TargetVersion, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ValueMigration) validate() error {
+	if m.Key == "" {
+		return fmt.Errorf("key is required")
+	}
+
+	switch m.Type {
+	case MigrationRenameKey:
+		if m.NewKey == "" {
+			return fmt.Errorf("new_key is required for %s migrations", m.Type)
+		}
+	case MigrationSetDefault:
+		if m.Value == nil {
+			return fmt.Errorf("value is required for %s migrations", m.Type)
+		}
+	case MigrationRemoveKey:
+	default:
+		return fmt.Errorf("unsupported migration type %q", m.Type)
+	}
+
+	return nil
+}
+
+func (m *ValueMigration) apply(values map[string]interface{}) error {
+	if err := m.validate(); err != nil {
+		return err
+	}
+
+	switch m.Type {
+	case MigrationRenameKey:
+		value, exists := getValue(values, m.Key)
+		if !exists {
+			return nil
+		}
+
+		// a value already set at the new key was set explicitly, so it takes precedence
+		if _, newExists := getValue(values, m.NewKey); !newExists {
+			if err := setValue(values, m.NewKey, value); err != nil {
+				return err
+			}
+		}
+
+		deleteValue(values, m.Key)
+	case MigrationSetDefault:
+		if _, exists := getValue(values, m.Key); !exists {
+			return setValue(values, m.Key, m.Value)
+		}
+	case MigrationRemoveKey:
+		deleteValue(values, m.Key)
+	}
+
+	return nil
+}
+
+func getValue(values map[string]interface{}, key string) (interface{}, bool) {
+	path := strings.Split(key, ".")
+	curr := values
+
+	for i, segment := range path {
+		value, exists := curr[segment]
+		if !exists {
+			return nil, false
+		}
+
+		if i == len(path)-1 {
+			return value, true
+		}
+
+		next, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		curr = next
+	}
+
+	return nil, false
+}
+
+func setValue(values map[string]interface{}, key string, value interface{}) error {
+	path := strings.Split(key, ".")
+	curr := values
+
+	for _, segment := range path[:len(path)-1] {
+		next, exists := curr[segment]
+		if !exists || next == nil {
+			next = make(map[string]interface{})
+			curr[segment] = next
+		}
+
+		nextMap, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot set %s: %s is not a map", key, segment)
+		}
+
+		curr = nextMap
+	}
+
+	curr[path[len(path)-1]] = value
+
+	return nil
+}
+
+func deleteValue(values map[string]interface{}, key string) {
+	path := strings.Split(key, ".")
+	curr := values
+
+	for _, segment := range path[:len(path)-1] {
+		next, ok := curr[segment].(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		curr = next
+	}
+
+	delete(curr, path[len(path)-1])
+}
@@ -0,0 +1,120 @@
+package upgrade_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm/upgrade"
+)
+
+const testUpgradeFile = `upgrade_notes:
+- previous: v0.2.0
+  target: v0.3.0
+  note: The ingress annotations moved
+  migrations:
+  - type: rename_key
+    key: ingress.custom_annotations
+    new_key: ingress.annotations
+- previous: v0.1.0
+  target: v0.2.0
+  note: Resources are required
+  requires_acknowledgement: true
+  migrations:
+  - type: set_default
+    key: resources.requests.cpu
+    value: 100m
+  - type: remove_key
+    key: legacy
+- previous: v0.3.0
+  target: v0.4.0
+  note: Not yet applicable
+  requires_acknowledgement: true
+`
+
+func TestGetUpgradeFileBetweenVersions(t *testing.T) {
+	upgradeFile, err := upgrade.ParseUpgradeFileFromBytes([]byte(testUpgradeFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notes, err := upgradeFile.GetUpgradeFileBetweenVersions("v0.1.0", "v0.3.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targets := make([]string, 0)
+
+	for _, note := range notes.UpgradeNotes {
+		targets = append(targets, note.TargetVersion)
+	}
+
+	if expected := []string{"v0.2.0", "v0.3.0"}; !reflect.DeepEqual(targets, expected) {
+		t.Fatalf("expected notes for %v, got %v", expected, targets)
+	}
+
+	if acks := notes.GetNotesRequiringAcknowledgement(); len(acks) != 1 || acks[0].TargetVersion != "v0.2.0" {
+		t.Fatalf("expected only the note for v0.2.0 to require acknowledgement, got %v", acks)
+	}
+
+	all, err := upgradeFile.GetUpgradeFileBetweenVersions("v0.1.0", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(all.UpgradeNotes) != 3 {
+		t.Fatalf("expected 3 notes without a target version, got %d", len(all.UpgradeNotes))
+	}
+}
+
+func TestApplyMigrations(t *testing.T) {
+	upgradeFile, err := upgrade.ParseUpgradeFileFromBytes([]byte(testUpgradeFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notes, err := upgradeFile.GetUpgradeFileBetweenVersions("v0.1.0", "v0.3.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := map[string]interface{}{
+		"legacy": true,
+		"ingress": map[string]interface{}{
+			"custom_annotations": map[string]interface{}{"a": "b"},
+		},
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{"memory": "256Mi"},
+		},
+	}
+
+	err = notes.ApplyMigrations(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"ingress": map[string]interface{}{
+			"annotations": map[string]interface{}{"a": "b"},
+		},
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{"memory": "256Mi", "cpu": "100m"},
+		},
+	}
+
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected values %v, got %v", expected, values)
+	}
+}
+
+func TestParseUpgradeFileInvalidMigration(t *testing.T) {
+	_, err := upgrade.ParseUpgradeFileFromBytes([]byte(`upgrade_notes:
+- previous: v0.1.0
+  target: v0.2.0
+  migrations:
+  - type: rename_key
+    key: a
+`))
+	if err == nil {
+		t.Fatal("expected a rename_key migration without a new_key to be invalid")
+	}
+}
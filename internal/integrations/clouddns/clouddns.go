@@ -0,0 +1,180 @@
+package clouddns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/integrations/dns"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	clouddns "google.golang.org/api/dns/v1"
+)
+
+// Client is a wrapper around the Google Cloud DNS API for the public managed zone of a domain
+type Client struct {
+	projectID   string
+	managedZone string
+
+	service *clouddns.Service
+}
+
+// NewClient creates a new Cloud DNS client for the public managed zone of runDomain in the given GCP project. If
+// credentialsJSON is empty, application default credentials are used.
+func NewClient(projectID string, credentialsJSON []byte, runDomain string) (Client, error) {
+	ctx := context.Background()
+
+	var opts []option.ClientOption
+	if len(credentialsJSON) != 0 {
+		opts = append(opts, option.WithCredentialsJSON(credentialsJSON))
+	}
+
+	service, err := clouddns.NewService(ctx, opts...)
+	if err != nil {
+		return Client{}, fmt.Errorf("failed to create cloud dns service: %w", err)
+	}
+
+	zones, err := service.ManagedZones.List(projectID).DnsName(canonicalize(runDomain)).Context(ctx).Do()
+	if err != nil {
+		return Client{}, fmt.Errorf("failed to list managed zones: %w", err)
+	}
+
+	for _, zone := range zones.ManagedZones {
+		if zone.Visibility == "private" {
+			continue
+		}
+
+		return Client{projectID: projectID, managedZone: zone.Name, service: service}, nil
+	}
+
+	return Client{}, fmt.Errorf("no public managed zone found for %s", runDomain)
+}
+
+// CreateCNAMERecord creates a new CNAME record for the managed zone
+func (c Client) CreateCNAMERecord(record dns.Record) error {
+	record.Type = dns.RecordType_CNAME
+
+	return c.createRecord(record)
+}
+
+// CreateARecord creates a new A record for the managed zone
+func (c Client) CreateARecord(record dns.Record) error {
+	record.Type = dns.RecordType_A
+
+	return c.createRecord(record)
+}
+
+// CreateTXTRecord creates a new TXT record for the managed zone
+func (c Client) CreateTXTRecord(record dns.Record) error {
+	record.Type = dns.RecordType_TXT
+
+	return c.createRecord(record)
+}
+
+// UpdateRecord sets the value of the record with the name and type of the record, creating it if it does not exist
+func (c Client) UpdateRecord(record dns.Record) error {
+	recordSet := resourceRecordSet(record)
+
+	_, err := c.service.ResourceRecordSets.Patch(c.projectID, c.managedZone, recordSet.Name, recordSet.Type, recordSet).Do()
+	if isNotFound(err) {
+		return c.createRecord(record)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update %s dns record: %w", record.Type, err)
+	}
+
+	return nil
+}
+
+// DeleteRecord deletes the record with the name and type of the record
+func (c Client) DeleteRecord(record dns.Record) error {
+	_, err := c.service.ResourceRecordSets.Delete(c.projectID, c.managedZone, canonicalize(record.Hostname()), record.Type.String()).Do()
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete %s dns record: %w", record.Type, err)
+	}
+
+	return nil
+}
+
+// LookupRecords returns the A, CNAME and TXT records with the given name
+func (c Client) LookupRecords(name, rootDomain string) ([]dns.Record, error) {
+	var records []dns.Record
+
+	hostname := canonicalize(dns.Record{Name: name, RootDomain: rootDomain}.Hostname())
+
+	err := c.service.ResourceRecordSets.List(c.projectID, c.managedZone).Name(hostname).Pages(context.Background(), func(page *clouddns.ResourceRecordSetsListResponse) error {
+		for _, recordSet := range page.Rrsets {
+			recordType, ok := dns.ParseRecordType(recordSet.Type)
+			if !ok {
+				continue
+			}
+
+			for _, value := range recordSet.Rrdatas {
+				switch recordType {
+				case dns.RecordType_CNAME:
+					value = strings.TrimSuffix(value, ".")
+				case dns.RecordType_TXT:
+					value = dns.UnquoteTXT(value)
+				}
+
+				records = append(records, dns.Record{
+					Type:       recordType,
+					Name:       name,
+					RootDomain: rootDomain,
+					Value:      value,
+				})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dns records: %w", err)
+	}
+
+	return records, nil
+}
+
+func (c Client) createRecord(record dns.Record) error {
+	_, err := c.service.ResourceRecordSets.Create(c.projectID, c.managedZone, resourceRecordSet(record)).Do()
+	if err != nil {
+		return fmt.Errorf("failed to create %s dns record: %w", record.Type, err)
+	}
+
+	return nil
+}
+
+func resourceRecordSet(record dns.Record) *clouddns.ResourceRecordSet {
+	value := record.Value
+
+	switch record.Type {
+	case dns.RecordType_CNAME:
+		value = canonicalize(record.Value)
+	case dns.RecordType_TXT:
+		value = dns.QuoteTXT(record.Value)
+	}
+
+	return &clouddns.ResourceRecordSet{
+		Name:    canonicalize(record.Hostname()),
+		Type:    record.Type.String(),
+		Ttl:     dns.TTL,
+		Rrdatas: []string{value},
+	}
+}
+
+func canonicalize(value string) string {
+	if strings.HasSuffix(value, ".") {
+		return value
+	}
+
+	return value + "."
+}
+
+func isNotFound(err error) bool {
+	var apiErr *googleapi.Error
+
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
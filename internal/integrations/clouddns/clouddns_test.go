@@ -0,0 +1,276 @@
+package clouddns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/integrations/dns"
+	"google.golang.org/api/option"
+
+	clouddns "google.golang.org/api/dns/v1"
+)
+
+const rrsetsPath = "/dns/v1/projects/project/managedZones/zone/rrsets"
+
+// mockCloudDNS is a managed zone served like the Cloud DNS API, which lists its record sets in pages of pageSize
+type mockCloudDNS struct {
+	t *testing.T
+
+	recordSets map[string]*clouddns.ResourceRecordSet
+	pageSize   int
+}
+
+func (m *mockCloudDNS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, rrsetsPath)
+
+	switch {
+	case r.Method == http.MethodGet && path == "":
+		m.list(w, r)
+	case r.Method == http.MethodPost && path == "":
+		recordSet := m.decode(r)
+
+		if _, ok := m.recordSets[recordSet.Name+"/"+recordSet.Type]; ok {
+			writeError(w, http.StatusConflict, "record set already exists")
+			return
+		}
+
+		m.recordSets[recordSet.Name+"/"+recordSet.Type] = recordSet
+		m.write(w, recordSet)
+	case r.Method == http.MethodPatch:
+		if _, ok := m.recordSets[strings.TrimPrefix(path, "/")]; !ok {
+			writeError(w, http.StatusNotFound, "record set not found")
+			return
+		}
+
+		recordSet := m.decode(r)
+		m.recordSets[strings.TrimPrefix(path, "/")] = recordSet
+		m.write(w, recordSet)
+	case r.Method == http.MethodDelete:
+		if _, ok := m.recordSets[strings.TrimPrefix(path, "/")]; !ok {
+			writeError(w, http.StatusNotFound, "record set not found")
+			return
+		}
+
+		delete(m.recordSets, strings.TrimPrefix(path, "/"))
+		m.write(w, &clouddns.ResourceRecordSetsDeleteResponse{})
+	default:
+		m.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		writeError(w, http.StatusBadRequest, "unexpected request")
+	}
+}
+
+func (m *mockCloudDNS) list(w http.ResponseWriter, r *http.Request) {
+	var keys []string
+	for key, recordSet := range m.recordSets {
+		if name := r.URL.Query().Get("name"); name == "" || recordSet.Name == name {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	start := 0
+	if token := r.URL.Query().Get("pageToken"); token != "" {
+		start = sort.SearchStrings(keys, token)
+	}
+
+	res := &clouddns.ResourceRecordSetsListResponse{}
+	for i := start; i < len(keys); i++ {
+		if len(res.Rrsets) == m.pageSize {
+			res.NextPageToken = keys[i]
+			break
+		}
+
+		res.Rrsets = append(res.Rrsets, m.recordSets[keys[i]])
+	}
+
+	m.write(w, res)
+}
+
+func (m *mockCloudDNS) decode(r *http.Request) *clouddns.ResourceRecordSet {
+	recordSet := &clouddns.ResourceRecordSet{}
+	if err := json.NewDecoder(r.Body).Decode(recordSet); err != nil {
+		m.t.Errorf("invalid record set: %v", err)
+	}
+
+	return recordSet
+}
+
+func (m *mockCloudDNS) write(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		m.t.Errorf("unexpected error writing response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"code": code, "message": message},
+	})
+}
+
+func recordSet(name, recordType string, values ...string) *clouddns.ResourceRecordSet {
+	return &clouddns.ResourceRecordSet{
+		Name:    name,
+		Type:    recordType,
+		Ttl:     dns.TTL,
+		Rrdatas: values,
+	}
+}
+
+func newTestClient(t *testing.T, recordSets ...*clouddns.ResourceRecordSet) (Client, *mockCloudDNS) {
+	mock := &mockCloudDNS{
+		t:          t,
+		recordSets: make(map[string]*clouddns.ResourceRecordSet),
+		pageSize:   2,
+	}
+
+	for _, recordSet := range recordSets {
+		mock.recordSets[recordSet.Name+"/"+recordSet.Type] = recordSet
+	}
+
+	server := httptest.NewServer(mock)
+	t.Cleanup(server.Close)
+
+	service, err := clouddns.NewService(context.Background(),
+		option.WithEndpoint(server.URL+"/"),
+		option.WithHTTPClient(server.Client()),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating cloud dns service: %v", err)
+	}
+
+	return Client{projectID: "project", managedZone: "zone", service: service}, mock
+}
+
+func TestCreateRecords(t *testing.T) {
+	tests := []struct {
+		name     string
+		create   func(c Client, record dns.Record) error
+		record   dns.Record
+		expected *clouddns.ResourceRecordSet
+	}{
+		{
+			name:     "cname values are fully qualified",
+			create:   Client.CreateCNAMERecord,
+			record:   dns.Record{Name: "web-abc", RootDomain: "porter.run", Value: "lb.example.com"},
+			expected: recordSet("web-abc.porter.run.", "CNAME", "lb.example.com."),
+		},
+		{
+			name:     "a",
+			create:   Client.CreateARecord,
+			record:   dns.Record{Name: "web-abc", RootDomain: "porter.run", Value: "10.0.0.1"},
+			expected: recordSet("web-abc.porter.run.", "A", "10.0.0.1"),
+		},
+		{
+			name:     "txt values are quoted",
+			create:   Client.CreateTXTRecord,
+			record:   dns.Record{Name: "_verify", RootDomain: "porter.run", Value: `token "abc"`},
+			expected: recordSet("_verify.porter.run.", "TXT", `"token \"abc\""`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := newTestClient(t)
+
+			if err := tt.create(client, tt.record); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expected := map[string]*clouddns.ResourceRecordSet{tt.expected.Name + "/" + tt.expected.Type: tt.expected}
+
+			if !reflect.DeepEqual(mock.recordSets, expected) {
+				t.Errorf("expected record sets %v, got %v", expected, mock.recordSets)
+			}
+
+			if err := tt.create(client, tt.record); err == nil {
+				t.Errorf("expected creating an existing record to fail")
+			}
+		})
+	}
+}
+
+func TestUpdateRecord(t *testing.T) {
+	client, mock := newTestClient(t, recordSet("web-abc.porter.run.", "A", "10.0.0.1"))
+
+	records := []dns.Record{
+		{Type: dns.RecordType_A, Name: "web-abc", RootDomain: "porter.run", Value: "10.0.0.2"},
+		// records which do not exist are created
+		{Type: dns.RecordType_A, Name: "web-def", RootDomain: "porter.run", Value: "10.0.0.3"},
+	}
+
+	for _, record := range records {
+		if err := client.UpdateRecord(record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	expected := map[string]*clouddns.ResourceRecordSet{
+		"web-abc.porter.run./A": recordSet("web-abc.porter.run.", "A", "10.0.0.2"),
+		"web-def.porter.run./A": recordSet("web-def.porter.run.", "A", "10.0.0.3"),
+	}
+
+	if !reflect.DeepEqual(mock.recordSets, expected) {
+		t.Errorf("expected record sets %v, got %v", expected, mock.recordSets)
+	}
+}
+
+func TestDeleteRecord(t *testing.T) {
+	client, mock := newTestClient(t,
+		recordSet("web-abc.porter.run.", "A", "10.0.0.1"),
+		recordSet("web-abc.porter.run.", "TXT", `"verify"`),
+	)
+
+	if err := client.DeleteRecord(dns.Record{Type: dns.RecordType_A, Name: "web-abc", RootDomain: "porter.run"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// deleting a record which does not exist is not an error
+	if err := client.DeleteRecord(dns.Record{Type: dns.RecordType_CNAME, Name: "web-abc", RootDomain: "porter.run"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]*clouddns.ResourceRecordSet{
+		"web-abc.porter.run./TXT": recordSet("web-abc.porter.run.", "TXT", `"verify"`),
+	}
+
+	if !reflect.DeepEqual(mock.recordSets, expected) {
+		t.Errorf("expected record sets %v, got %v", expected, mock.recordSets)
+	}
+}
+
+func TestLookupRecords(t *testing.T) {
+	client, _ := newTestClient(t,
+		recordSet("web-abc.porter.run.", "A", "10.0.0.1", "10.0.0.2"),
+		recordSet("web-abc.porter.run.", "CNAME", "lb.example.com."),
+		recordSet("web-abc.porter.run.", "MX", "10 mail.example.com."),
+		recordSet("web-abc.porter.run.", "TXT", `"token \"abc\""`),
+		recordSet("web-def.porter.run.", "A", "10.0.0.3"),
+	)
+
+	records, err := client.LookupRecords("web-abc", "porter.run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []dns.Record{
+		{Type: dns.RecordType_A, Name: "web-abc", RootDomain: "porter.run", Value: "10.0.0.1"},
+		{Type: dns.RecordType_A, Name: "web-abc", RootDomain: "porter.run", Value: "10.0.0.2"},
+		{Type: dns.RecordType_CNAME, Name: "web-abc", RootDomain: "porter.run", Value: "lb.example.com"},
+		{Type: dns.RecordType_TXT, Name: "web-abc", RootDomain: "porter.run", Value: `token "abc"`},
+	}
+
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("expected records %v, got %v", expected, records)
+	}
+}
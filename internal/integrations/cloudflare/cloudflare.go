@@ -17,6 +17,9 @@ const (
 
 	// RecordType_CNAME declares an CNME record type for cloudflare
 	RecordType_CNAME = "CNAME"
+
+	// RecordType_TXT declares a TXT record type for cloudflare
+	RecordType_TXT RecordType = "TXT"
 )
 
 // TTL sets the TTL for Cloudflare DNS records
@@ -24,7 +27,8 @@ const TTL = 300
 
 // Client is a struct wrapper around the cloudflare client
 type Client struct {
-	zoneID string
+	zoneID   string
+	zoneName string
 
 	client *cloudflare.API
 }
@@ -41,7 +45,7 @@ func NewClient(apiToken string, runDomain string) (Client, error) {
 		return Client{}, err
 	}
 
-	return Client{client: client, zoneID: zoneID}, nil
+	return Client{client: client, zoneID: zoneID, zoneName: runDomain}, nil
 }
 
 // CreateCNAMERecord creates a new CNAME record for the nameserver
@@ -87,3 +91,115 @@ func (c Client) CreateARecord(record dns.Record) error {
 
 	return nil
 }
+
+// CreateTXTRecord creates a new TXT record for the nameserver
+//
+// The method ignores record.RootDomain in favor of the zoneID derived from c.runDomain
+func (c Client) CreateTXTRecord(record dns.Record) error {
+	cloudflareRecord := cloudflare.CreateDNSRecordParams{
+		Name:    record.Name,
+		Type:    string(RecordType_TXT),
+		Content: record.Value,
+		TTL:     TTL,
+	}
+
+	_, err := c.client.CreateDNSRecord(context.Background(), cloudflare.ZoneIdentifier(c.zoneID), cloudflareRecord)
+	if err != nil {
+		return fmt.Errorf("failed to create TXT dns record: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRecord sets the value of the record with the name and type of the record, creating it if it does not exist
+//
+// The method ignores record.RootDomain in favor of the zoneID derived from c.runDomain
+func (c Client) UpdateRecord(record dns.Record) error {
+	existing, err := c.listRecords(record.Name, record.Type.String())
+	if err != nil {
+		return err
+	}
+
+	if len(existing) == 0 {
+		return dns.Client{Client: c}.CreateRecord(record)
+	}
+
+	params := cloudflare.UpdateDNSRecordParams{
+		ID:      existing[0].ID,
+		Name:    record.Name,
+		Type:    record.Type.String(),
+		Content: record.Value,
+		TTL:     TTL,
+	}
+
+	if record.Type != dns.RecordType_TXT {
+		proxy := false
+		params.Proxied = &proxy
+	}
+
+	_, err = c.client.UpdateDNSRecord(context.Background(), cloudflare.ZoneIdentifier(c.zoneID), params)
+	if err != nil {
+		return fmt.Errorf("failed to update %s dns record: %w", record.Type, err)
+	}
+
+	return nil
+}
+
+// DeleteRecord deletes the records with the name and type of the record
+//
+// The method ignores record.RootDomain in favor of the zoneID derived from c.runDomain
+func (c Client) DeleteRecord(record dns.Record) error {
+	existing, err := c.listRecords(record.Name, record.Type.String())
+	if err != nil {
+		return err
+	}
+
+	for _, cloudflareRecord := range existing {
+		err := c.client.DeleteDNSRecord(context.Background(), cloudflare.ZoneIdentifier(c.zoneID), cloudflareRecord.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete %s dns record: %w", record.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// LookupRecords returns the A, CNAME and TXT records with the given name
+//
+// The method ignores rootDomain in favor of the zoneID derived from c.runDomain
+func (c Client) LookupRecords(name, rootDomain string) ([]dns.Record, error) {
+	existing, err := c.listRecords(name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var records []dns.Record
+
+	for _, cloudflareRecord := range existing {
+		recordType, ok := dns.ParseRecordType(cloudflareRecord.Type)
+		if !ok {
+			continue
+		}
+
+		records = append(records, dns.Record{
+			Type:       recordType,
+			Name:       name,
+			RootDomain: rootDomain,
+			Value:      cloudflareRecord.Content,
+		})
+	}
+
+	return records, nil
+}
+
+func (c Client) listRecords(name, recordType string) ([]cloudflare.DNSRecord, error) {
+	records, _, err := c.client.ListDNSRecords(context.Background(), cloudflare.ZoneIdentifier(c.zoneID), cloudflare.ListDNSRecordsParams{
+		Name: dns.Record{Name: name, RootDomain: c.zoneName}.Hostname(),
+		Type: recordType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dns records: %w", err)
+	}
+
+	return records, nil
+}
@@ -1,5 +1,10 @@
 package dns
 
+import (
+	"fmt"
+	"strings"
+)
+
 // RecordType strongly types dns record types
 type RecordType int
 
@@ -9,13 +14,57 @@ const (
 
 	// RecordType_CNAME represents a DNS RecordType_CNAME record
 	RecordType_CNAME
+
+	// RecordType_TXT represents a DNS RecordType_TXT record
+	RecordType_TXT
 )
 
+// String returns the name of the record type as it appears in zone files
+func (t RecordType) String() string {
+	switch t {
+	case RecordType_A:
+		return "A"
+	case RecordType_CNAME:
+		return "CNAME"
+	case RecordType_TXT:
+		return "TXT"
+	default:
+		return fmt.Sprintf("RecordType(%d)", int(t))
+	}
+}
+
+// ParseRecordType returns the record type with the given name, as it appears in zone files
+func ParseRecordType(name string) (RecordType, bool) {
+	switch name {
+	case "A":
+		return RecordType_A, true
+	case "CNAME":
+		return RecordType_CNAME, true
+	case "TXT":
+		return RecordType_TXT, true
+	default:
+		return 0, false
+	}
+}
+
+// TTL is the TTL of the records created by Porter, in seconds
+const TTL = 300
+
 // WrappedClient is an interface describing a wrapper
 // around a particular dns implementation
 type WrappedClient interface {
 	CreateARecord(record Record) error
 	CreateCNAMERecord(record Record) error
+	CreateTXTRecord(record Record) error
+
+	// UpdateRecord sets the value of the record with the name and type of record, creating it if it does not exist
+	UpdateRecord(record Record) error
+
+	// DeleteRecord deletes the record with the name and type of record. Deleting a record which does not exist is not an error.
+	DeleteRecord(record Record) error
+
+	// LookupRecords returns the A, CNAME and TXT records with the given name
+	LookupRecords(name, rootDomain string) ([]Record, error)
 }
 
 // Client wraps the underlying powerdns client
@@ -33,11 +82,70 @@ type Record struct {
 	Value      string
 }
 
+// Hostname returns the fully qualified name of the record, without the trailing period
+func (r Record) Hostname() string {
+	if r.Name == "" {
+		return r.RootDomain
+	}
+
+	return fmt.Sprintf("%s.%s", r.Name, r.RootDomain)
+}
+
 // CreateRecord creates a new dns record
 func (c Client) CreateRecord(record Record) error {
-	if record.Type == RecordType_A {
+	switch record.Type {
+	case RecordType_A:
 		return c.Client.CreateARecord(record)
+	case RecordType_TXT:
+		return c.Client.CreateTXTRecord(record)
+	default:
+		return c.Client.CreateCNAMERecord(record)
+	}
+}
+
+// UpdateRecord sets the value of a dns record, creating it if it does not exist
+func (c Client) UpdateRecord(record Record) error {
+	return c.Client.UpdateRecord(record)
+}
+
+// DeleteRecord deletes a dns record
+func (c Client) DeleteRecord(record Record) error {
+	return c.Client.DeleteRecord(record)
+}
+
+// LookupRecords returns the dns records with the given name
+func (c Client) LookupRecords(name, rootDomain string) ([]Record, error) {
+	return c.Client.LookupRecords(name, rootDomain)
+}
+
+// QuoteTXT formats the value of a TXT record as a quoted character string, as expected by zone files
+func QuoteTXT(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// UnquoteTXT returns the value of a TXT record formatted as one or more quoted character strings
+func UnquoteTXT(content string) string {
+	var value strings.Builder
+
+	inString, escaped := false, false
+
+	for _, c := range content {
+		switch {
+		case escaped:
+			value.WriteRune(c)
+			escaped = false
+		case c == '\\' && inString:
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+			value.WriteRune(c)
+		}
+	}
+
+	if value.Len() == 0 && !strings.Contains(content, `"`) {
+		return content
 	}
 
-	return c.Client.CreateCNAMERecord(record)
+	return value.String()
 }
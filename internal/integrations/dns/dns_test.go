@@ -0,0 +1,28 @@
+package dns
+
+import "testing"
+
+func TestTXTQuoting(t *testing.T) {
+	values := []string{"v=spf1 -all", `say "hi"`, `back\slash`, ""}
+
+	for _, value := range values {
+		if got := UnquoteTXT(QuoteTXT(value)); got != value {
+			t.Errorf("expected %q to round trip, got %q", value, got)
+		}
+	}
+
+	// long values are split into several character strings by nameservers
+	if got := UnquoteTXT(`"first part" "second part"`); got != "first partsecond part" {
+		t.Errorf("expected character strings to be joined, got %q", got)
+	}
+}
+
+func TestHostname(t *testing.T) {
+	if hostname := (Record{Name: "web-abc", RootDomain: "porter.run"}).Hostname(); hostname != "web-abc.porter.run" {
+		t.Errorf("unexpected hostname %s", hostname)
+	}
+
+	if hostname := (Record{RootDomain: "porter.run"}).Hostname(); hostname != "porter.run" {
+		t.Errorf("expected apex record to be named after the root domain, got %s", hostname)
+	}
+}
@@ -41,7 +41,7 @@ type RR struct {
 	Name       string   `json:"name"`
 	Type       string   `json:"type"`
 	ChangeType string   `json:"changetype"`
-	TTL        uint     `json:"ttl"`
+	TTL        uint     `json:"ttl,omitempty"`
 	Records    []Record `json:"records"`
 }
 
@@ -57,47 +57,105 @@ type Record struct {
 
 // CreateCNAMERecord creates a new CNAME record for the nameserver
 func (c Client) CreateCNAMERecord(record dns.Record) error {
-	valueC := canonicalize(record.Value)
-	hostnameC := canonicalize(fmt.Sprintf("%s.%s", record.Name, record.RootDomain))
+	record.Type = dns.RecordType_CNAME
+
+	return c.UpdateRecord(record)
+}
+
+// CreateARecord creates a new A record for the nameserver
+func (c Client) CreateARecord(record dns.Record) error {
+	record.Type = dns.RecordType_A
+
+	return c.UpdateRecord(record)
+}
+
+// CreateTXTRecord creates a new TXT record for the nameserver
+func (c Client) CreateTXTRecord(record dns.Record) error {
+	record.Type = dns.RecordType_TXT
+
+	return c.UpdateRecord(record)
+}
+
+// UpdateRecord replaces the record set with the name and type of the record
+func (c Client) UpdateRecord(record dns.Record) error {
+	hostnameC := canonicalize(record.Hostname())
+
+	content := record.Value
+	switch record.Type {
+	case dns.RecordType_CNAME:
+		content = canonicalize(record.Value)
+	case dns.RecordType_TXT:
+		content = dns.QuoteTXT(record.Value)
+	}
 
 	return c.sendRequest("PATCH", &RecordData{
 		RRSets: []RR{{
 			Name:       hostnameC,
-			Type:       "CNAME",
+			Type:       record.Type.String(),
 			ChangeType: "REPLACE",
-			TTL:        300,
+			TTL:        dns.TTL,
 			Records: []Record{{
-				Content:  valueC,
+				Content:  content,
 				Disabled: false,
 				Name:     hostnameC,
-				Type:     "CNAME",
+				Type:     record.Type.String(),
 				Priority: 0,
 			}},
 		}},
 	})
 }
 
-// CreateARecord creates a new A record for the nameserver
-func (c Client) CreateARecord(record dns.Record) error {
-	hostnameC := canonicalize(fmt.Sprintf("%s.%s", record.Name, record.RootDomain))
-
+// DeleteRecord deletes the record set with the name and type of the record
+func (c Client) DeleteRecord(record dns.Record) error {
 	return c.sendRequest("PATCH", &RecordData{
 		RRSets: []RR{{
-			Name:       hostnameC,
-			Type:       "A",
-			ChangeType: "REPLACE",
-			TTL:        300,
-			Records: []Record{{
-				Content:  record.Value,
-				Disabled: false,
-				Name:     hostnameC,
-				Type:     "A",
-				Priority: 0,
-			}},
+			Name:       canonicalize(record.Hostname()),
+			Type:       record.Type.String(),
+			ChangeType: "DELETE",
 		}},
 	})
 }
 
+// LookupRecords returns the A, CNAME and TXT records with the given name
+func (c Client) LookupRecords(name, rootDomain string) ([]dns.Record, error) {
+	hostnameC := canonicalize(dns.Record{Name: name, RootDomain: rootDomain}.Hostname())
+
+	zone := &RecordData{}
+
+	err := c.doRequest("GET", url.Values{"rrset_name": []string{hostnameC}}, nil, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []dns.Record
+
+	for _, rrset := range zone.RRSets {
+		recordType, ok := dns.ParseRecordType(rrset.Type)
+		if !ok || rrset.Name != hostnameC {
+			continue
+		}
+
+		for _, rr := range rrset.Records {
+			value := rr.Content
+			switch recordType {
+			case dns.RecordType_CNAME:
+				value = strings.TrimSuffix(value, ".")
+			case dns.RecordType_TXT:
+				value = dns.UnquoteTXT(value)
+			}
+
+			records = append(records, dns.Record{
+				Type:       recordType,
+				Name:       name,
+				RootDomain: rootDomain,
+				Value:      value,
+			})
+		}
+	}
+
+	return records, nil
+}
+
 func canonicalize(value string) string {
 	// if the string ends in a period, return
 	if value[len(value)-1:] == "." {
@@ -108,22 +166,32 @@ func canonicalize(value string) string {
 }
 
 func (c *Client) sendRequest(method string, data *RecordData) error {
+	return c.doRequest(method, nil, data, nil)
+}
+
+func (c *Client) doRequest(method string, query url.Values, data *RecordData, response interface{}) error {
 	reqURL, err := url.Parse(c.serverURL)
 	if err != nil {
 		return nil
 	}
 
 	reqURL.Path = fmt.Sprintf("/api/v1/servers/localhost/zones/%s", c.runDomain)
+	reqURL.RawQuery = query.Encode()
 
-	strData, err := json.Marshal(data)
-	if err != nil {
-		return err
+	var body io.Reader
+	if data != nil {
+		strData, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+
+		body = strings.NewReader(string(strData))
 	}
 
 	req, err := http.NewRequest(
 		method,
 		reqURL.String(),
-		strings.NewReader(string(strData)),
+		body,
 	)
 	if err != nil {
 		return err
@@ -149,5 +217,9 @@ func (c *Client) sendRequest(method string, data *RecordData) error {
 		return fmt.Errorf("request failed with status code %d: %s\n", res.StatusCode, string(resBytes))
 	}
 
+	if response != nil {
+		return json.NewDecoder(res.Body).Decode(response)
+	}
+
 	return nil
 }
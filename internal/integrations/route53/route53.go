@@ -0,0 +1,211 @@
+package route53
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/porter-dev/porter/internal/integrations/dns"
+)
+
+// Client is a wrapper around the Route53 API for the public hosted zone of a domain
+type Client struct {
+	hostedZoneID string
+
+	client route53iface.Route53API
+}
+
+// NewClient creates a new Route53 client for the public hosted zone of runDomain. If accessKeyID is empty, credentials
+// are read from the environment.
+func NewClient(accessKeyID, secretAccessKey, runDomain string) (Client, error) {
+	awsConf := &aws.Config{}
+
+	if accessKeyID != "" {
+		awsConf.Credentials = credentials.NewStaticCredentials(accessKeyID, secretAccessKey, "")
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config:            *awsConf,
+	})
+	if err != nil {
+		return Client{}, fmt.Errorf("failed to create aws session: %w", err)
+	}
+
+	client := route53.New(sess)
+
+	zones, err := client.ListHostedZonesByName(&route53.ListHostedZonesByNameInput{
+		DNSName: aws.String(runDomain),
+	})
+	if err != nil {
+		return Client{}, fmt.Errorf("failed to list hosted zones: %w", err)
+	}
+
+	for _, zone := range zones.HostedZones {
+		if zone.Config != nil && aws.BoolValue(zone.Config.PrivateZone) {
+			continue
+		}
+
+		if sameName(aws.StringValue(zone.Name), runDomain) {
+			return Client{hostedZoneID: aws.StringValue(zone.Id), client: client}, nil
+		}
+	}
+
+	return Client{}, fmt.Errorf("no public hosted zone found for %s", runDomain)
+}
+
+// CreateCNAMERecord creates a new CNAME record for the hosted zone
+func (c Client) CreateCNAMERecord(record dns.Record) error {
+	record.Type = dns.RecordType_CNAME
+
+	return c.changeRecord(route53.ChangeActionCreate, resourceRecordSet(record))
+}
+
+// CreateARecord creates a new A record for the hosted zone
+func (c Client) CreateARecord(record dns.Record) error {
+	record.Type = dns.RecordType_A
+
+	return c.changeRecord(route53.ChangeActionCreate, resourceRecordSet(record))
+}
+
+// CreateTXTRecord creates a new TXT record for the hosted zone
+func (c Client) CreateTXTRecord(record dns.Record) error {
+	record.Type = dns.RecordType_TXT
+
+	return c.changeRecord(route53.ChangeActionCreate, resourceRecordSet(record))
+}
+
+// UpdateRecord sets the value of the record with the name and type of the record, creating it if it does not exist
+func (c Client) UpdateRecord(record dns.Record) error {
+	return c.changeRecord(route53.ChangeActionUpsert, resourceRecordSet(record))
+}
+
+// DeleteRecord deletes the record with the name and type of the record
+func (c Client) DeleteRecord(record dns.Record) error {
+	// route53 only deletes record sets matching the existing values and TTL
+	var existing *route53.ResourceRecordSet
+
+	err := c.listRecordSets(record.Hostname(), record.Type.String(), func(recordSet *route53.ResourceRecordSet) {
+		if aws.StringValue(recordSet.Type) == record.Type.String() {
+			existing = recordSet
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		return nil
+	}
+
+	return c.changeRecord(route53.ChangeActionDelete, existing)
+}
+
+// LookupRecords returns the A, CNAME and TXT records with the given name
+func (c Client) LookupRecords(name, rootDomain string) ([]dns.Record, error) {
+	var records []dns.Record
+
+	err := c.listRecordSets(dns.Record{Name: name, RootDomain: rootDomain}.Hostname(), "", func(recordSet *route53.ResourceRecordSet) {
+		recordType, ok := dns.ParseRecordType(aws.StringValue(recordSet.Type))
+		if !ok {
+			return
+		}
+
+		for _, rr := range recordSet.ResourceRecords {
+			value := aws.StringValue(rr.Value)
+			switch recordType {
+			case dns.RecordType_CNAME:
+				value = strings.TrimSuffix(value, ".")
+			case dns.RecordType_TXT:
+				value = dns.UnquoteTXT(value)
+			}
+
+			records = append(records, dns.Record{
+				Type:       recordType,
+				Name:       name,
+				RootDomain: rootDomain,
+				Value:      value,
+			})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (c Client) changeRecord(action string, recordSet *route53.ResourceRecordSet) error {
+	_, err := c.client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(c.hostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{{
+				Action:            aws.String(action),
+				ResourceRecordSet: recordSet,
+			}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to %s %s dns record: %w", strings.ToLower(action), aws.StringValue(recordSet.Type), err)
+	}
+
+	return nil
+}
+
+// listRecordSets calls fn with the record sets named hostname, of the given type if it is not empty
+func (c Client) listRecordSets(hostname, recordType string, fn func(recordSet *route53.ResourceRecordSet)) error {
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(c.hostedZoneID),
+		StartRecordName: aws.String(hostname),
+	}
+
+	if recordType != "" {
+		input.StartRecordType = aws.String(recordType)
+	}
+
+	// record sets are sorted by name, so listing stops at the first record set with another name
+	err := c.client.ListResourceRecordSetsPages(input, func(page *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+		for _, recordSet := range page.ResourceRecordSets {
+			if !sameName(aws.StringValue(recordSet.Name), hostname) {
+				return false
+			}
+
+			if recordType != "" && aws.StringValue(recordSet.Type) != recordType {
+				return false
+			}
+
+			fn(recordSet)
+		}
+
+		return !lastPage
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list dns records: %w", err)
+	}
+
+	return nil
+}
+
+func resourceRecordSet(record dns.Record) *route53.ResourceRecordSet {
+	value := record.Value
+	if record.Type == dns.RecordType_TXT {
+		value = dns.QuoteTXT(record.Value)
+	}
+
+	return &route53.ResourceRecordSet{
+		Name: aws.String(record.Hostname()),
+		Type: aws.String(record.Type.String()),
+		TTL:  aws.Int64(dns.TTL),
+		ResourceRecords: []*route53.ResourceRecord{{
+			Value: aws.String(value),
+		}},
+	}
+}
+
+func sameName(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
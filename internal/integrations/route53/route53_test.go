@@ -0,0 +1,296 @@
+package route53
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/porter-dev/porter/internal/integrations/dns"
+)
+
+// mockRoute53 is a hosted zone which applies changes to its record sets like Route53, and lists them in pages of pageSize
+type mockRoute53 struct {
+	route53iface.Route53API
+
+	recordSets []*route53.ResourceRecordSet
+	changes    []*route53.Change
+	pageSize   int
+}
+
+func (m *mockRoute53) ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	for _, change := range input.ChangeBatch.Changes {
+		m.changes = append(m.changes, change)
+
+		recordSet := change.ResourceRecordSet
+		existing := m.find(recordSetKey(recordSet))
+
+		switch aws.StringValue(change.Action) {
+		case route53.ChangeActionCreate:
+			if existing >= 0 {
+				return nil, errors.New("record set already exists")
+			}
+
+			m.recordSets = append(m.recordSets, recordSet)
+		case route53.ChangeActionUpsert:
+			if existing >= 0 {
+				m.recordSets[existing] = recordSet
+			} else {
+				m.recordSets = append(m.recordSets, recordSet)
+			}
+		case route53.ChangeActionDelete:
+			// record sets are only deleted if the values and TTL match the existing record set
+			if existing < 0 || !reflect.DeepEqual(m.recordSets[existing], recordSet) {
+				return nil, errors.New("record set not found")
+			}
+
+			m.recordSets = append(m.recordSets[:existing], m.recordSets[existing+1:]...)
+		}
+	}
+
+	sort.Slice(m.recordSets, func(i, j int) bool {
+		return recordSetKey(m.recordSets[i]) < recordSetKey(m.recordSets[j])
+	})
+
+	return &route53.ChangeResourceRecordSetsOutput{}, nil
+}
+
+func (m *mockRoute53) ListResourceRecordSetsPages(input *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool) error {
+	start := fqdn(aws.StringValue(input.StartRecordName)) + " " + aws.StringValue(input.StartRecordType)
+
+	var recordSets []*route53.ResourceRecordSet
+	for _, recordSet := range m.recordSets {
+		if recordSetKey(recordSet) >= start {
+			recordSets = append(recordSets, recordSet)
+		}
+	}
+
+	for i := 0; i < len(recordSets); i += m.pageSize {
+		end := i + m.pageSize
+		if end > len(recordSets) {
+			end = len(recordSets)
+		}
+
+		if !fn(&route53.ListResourceRecordSetsOutput{ResourceRecordSets: recordSets[i:end]}, end == len(recordSets)) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (m *mockRoute53) find(key string) int {
+	for i, recordSet := range m.recordSets {
+		if recordSetKey(recordSet) == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// recordSetKey orders record sets by name and type. Route53 treats names with and without a trailing dot the same.
+func recordSetKey(recordSet *route53.ResourceRecordSet) string {
+	return fqdn(aws.StringValue(recordSet.Name)) + " " + aws.StringValue(recordSet.Type)
+}
+
+func fqdn(name string) string {
+	return strings.TrimSuffix(name, ".") + "."
+}
+
+func recordSet(name, recordType string, ttl int64, values ...string) *route53.ResourceRecordSet {
+	recordSet := &route53.ResourceRecordSet{
+		Name: aws.String(name),
+		Type: aws.String(recordType),
+		TTL:  aws.Int64(ttl),
+	}
+
+	for _, value := range values {
+		recordSet.ResourceRecords = append(recordSet.ResourceRecords, &route53.ResourceRecord{Value: aws.String(value)})
+	}
+
+	return recordSet
+}
+
+func newTestClient(recordSets ...*route53.ResourceRecordSet) (Client, *mockRoute53) {
+	mock := &mockRoute53{pageSize: 2}
+
+	if len(recordSets) > 0 {
+		_, _ = mock.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{ChangeBatch: &route53.ChangeBatch{Changes: createChanges(recordSets)}})
+		mock.changes = nil
+	}
+
+	return Client{hostedZoneID: "zone", client: mock}, mock
+}
+
+func createChanges(recordSets []*route53.ResourceRecordSet) []*route53.Change {
+	changes := make([]*route53.Change, 0, len(recordSets))
+	for _, recordSet := range recordSets {
+		changes = append(changes, &route53.Change{Action: aws.String(route53.ChangeActionCreate), ResourceRecordSet: recordSet})
+	}
+
+	return changes
+}
+
+func TestCreateRecords(t *testing.T) {
+	tests := []struct {
+		name     string
+		create   func(c Client, record dns.Record) error
+		record   dns.Record
+		expected *route53.ResourceRecordSet
+	}{
+		{
+			name:     "cname",
+			create:   Client.CreateCNAMERecord,
+			record:   dns.Record{Name: "web-abc", RootDomain: "porter.run", Value: "lb.example.com"},
+			expected: recordSet("web-abc.porter.run", "CNAME", dns.TTL, "lb.example.com"),
+		},
+		{
+			name:     "a",
+			create:   Client.CreateARecord,
+			record:   dns.Record{Name: "web-abc", RootDomain: "porter.run", Value: "10.0.0.1"},
+			expected: recordSet("web-abc.porter.run", "A", dns.TTL, "10.0.0.1"),
+		},
+		{
+			name:     "txt values are quoted",
+			create:   Client.CreateTXTRecord,
+			record:   dns.Record{Name: "_verify", RootDomain: "porter.run", Value: `token "abc"`},
+			expected: recordSet("_verify.porter.run", "TXT", dns.TTL, `"token \"abc\""`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := newTestClient()
+
+			if err := tt.create(client, tt.record); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(mock.changes) != 1 || aws.StringValue(mock.changes[0].Action) != route53.ChangeActionCreate {
+				t.Fatalf("expected a single create change, got %v", mock.changes)
+			}
+
+			if !reflect.DeepEqual(mock.changes[0].ResourceRecordSet, tt.expected) {
+				t.Errorf("expected record set %v, got %v", tt.expected, mock.changes[0].ResourceRecordSet)
+			}
+		})
+	}
+}
+
+func TestCreateExistingRecord(t *testing.T) {
+	client, _ := newTestClient(recordSet("web-abc.porter.run", "A", dns.TTL, "10.0.0.1"))
+
+	err := client.CreateARecord(dns.Record{Name: "web-abc", RootDomain: "porter.run", Value: "10.0.0.2"})
+	if err == nil {
+		t.Fatalf("expected creating an existing record to fail")
+	}
+}
+
+func TestUpdateRecord(t *testing.T) {
+	client, mock := newTestClient(recordSet("web-abc.porter.run", "A", dns.TTL, "10.0.0.1"))
+
+	records := []dns.Record{
+		{Type: dns.RecordType_A, Name: "web-abc", RootDomain: "porter.run", Value: "10.0.0.2"},
+		{Type: dns.RecordType_A, Name: "web-def", RootDomain: "porter.run", Value: "10.0.0.3"},
+	}
+
+	for _, record := range records {
+		if err := client.UpdateRecord(record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	expected := []*route53.ResourceRecordSet{
+		recordSet("web-abc.porter.run", "A", dns.TTL, "10.0.0.2"),
+		recordSet("web-def.porter.run", "A", dns.TTL, "10.0.0.3"),
+	}
+
+	if !reflect.DeepEqual(mock.recordSets, expected) {
+		t.Errorf("expected record sets %v, got %v", expected, mock.recordSets)
+	}
+}
+
+func TestDeleteRecord(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   dns.Record
+		expected []*route53.ResourceRecordSet
+	}{
+		{
+			name:   "deletes the record set with its existing values and ttl",
+			record: dns.Record{Type: dns.RecordType_A, Name: "web-abc", RootDomain: "porter.run"},
+			expected: []*route53.ResourceRecordSet{
+				recordSet("web-abc.porter.run.", "TXT", 60, `"verify"`),
+				recordSet("web-def.porter.run.", "A", 60, "10.0.0.2"),
+			},
+		},
+		{
+			name:   "deletes only the record set of the type of the record",
+			record: dns.Record{Type: dns.RecordType_TXT, Name: "web-abc", RootDomain: "porter.run"},
+			expected: []*route53.ResourceRecordSet{
+				recordSet("web-abc.porter.run.", "A", 60, "10.0.0.1", "10.0.0.3"),
+				recordSet("web-def.porter.run.", "A", 60, "10.0.0.2"),
+			},
+		},
+		{
+			name:   "deleting a record which does not exist is not an error",
+			record: dns.Record{Type: dns.RecordType_CNAME, Name: "web-abc", RootDomain: "porter.run"},
+			expected: []*route53.ResourceRecordSet{
+				recordSet("web-abc.porter.run.", "A", 60, "10.0.0.1", "10.0.0.3"),
+				recordSet("web-abc.porter.run.", "TXT", 60, `"verify"`),
+				recordSet("web-def.porter.run.", "A", 60, "10.0.0.2"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// route53 returns fully qualified names, and the record sets were not created with the default ttl
+			client, mock := newTestClient(
+				recordSet("web-abc.porter.run.", "A", 60, "10.0.0.1", "10.0.0.3"),
+				recordSet("web-abc.porter.run.", "TXT", 60, `"verify"`),
+				recordSet("web-def.porter.run.", "A", 60, "10.0.0.2"),
+			)
+
+			if err := client.DeleteRecord(tt.record); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(mock.recordSets, tt.expected) {
+				t.Errorf("expected record sets %v, got %v", tt.expected, mock.recordSets)
+			}
+		})
+	}
+}
+
+func TestLookupRecords(t *testing.T) {
+	client, _ := newTestClient(
+		recordSet("api.porter.run.", "A", 60, "10.0.0.9"),
+		recordSet("web-abc.porter.run.", "A", 60, "10.0.0.1", "10.0.0.2"),
+		recordSet("web-abc.porter.run.", "CNAME", 60, "lb.example.com."),
+		recordSet("web-abc.porter.run.", "MX", 60, "10 mail.example.com."),
+		recordSet("web-abc.porter.run.", "TXT", 60, `"token \"abc\""`),
+		recordSet("web-def.porter.run.", "A", 60, "10.0.0.3"),
+	)
+
+	records, err := client.LookupRecords("web-abc", "porter.run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []dns.Record{
+		{Type: dns.RecordType_A, Name: "web-abc", RootDomain: "porter.run", Value: "10.0.0.1"},
+		{Type: dns.RecordType_A, Name: "web-abc", RootDomain: "porter.run", Value: "10.0.0.2"},
+		{Type: dns.RecordType_CNAME, Name: "web-abc", RootDomain: "porter.run", Value: "lb.example.com"},
+		{Type: dns.RecordType_TXT, Name: "web-abc", RootDomain: "porter.run", Value: `token "abc"`},
+	}
+
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("expected records %v, got %v", expected, records)
+	}
+}
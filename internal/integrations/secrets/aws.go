@@ -0,0 +1,43 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// AWSSecretsManagerSource is a secret stored in AWS Secrets Manager
+type AWSSecretsManagerSource struct {
+	Integration *ints.AWSIntegration
+
+	// SecretID is the name or the ARN of the secret
+	SecretID string
+}
+
+// Fetch returns the key/value pairs of the current version of the secret
+func (s *AWSSecretsManagerSource) Fetch(ctx context.Context) (map[string]string, error) {
+	if s.Integration == nil {
+		return nil, fmt.Errorf("aws integration is nil")
+	}
+
+	sess, err := s.Integration.GetSession()
+	if err != nil {
+		return nil, fmt.Errorf("error getting aws session: %w", err)
+	}
+
+	out, err := secretsmanager.New(sess).GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(s.SecretID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting value of secret %s: %w", s.SecretID, err)
+	}
+
+	if out.SecretString != nil {
+		return ParseValues([]byte(*out.SecretString))
+	}
+
+	return ParseValues(out.SecretBinary)
+}
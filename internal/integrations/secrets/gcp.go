@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"google.golang.org/api/option"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+)
+
+// GCPSecretManagerSource is a secret stored in GCP Secret Manager
+type GCPSecretManagerSource struct {
+	Integration *ints.GCPIntegration
+
+	// SecretName is either the name of a secret of the project of the integration, or the resource name of a secret
+	// such as projects/my-project/secrets/my-secret, optionally followed by /versions/<version>
+	SecretName string
+}
+
+// Fetch returns the key/value pairs of the latest version of the secret, or of the version set in the secret name
+func (s *GCPSecretManagerSource) Fetch(ctx context.Context) (map[string]string, error) {
+	if s.Integration == nil {
+		return nil, fmt.Errorf("gcp integration is nil")
+	}
+
+	svc, err := secretmanager.NewService(ctx, option.WithCredentialsJSON(s.Integration.GCPKeyData))
+	if err != nil {
+		return nil, fmt.Errorf("error creating gcp secret manager client: %w", err)
+	}
+
+	name := s.versionName()
+
+	resp, err := svc.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error accessing secret version %s: %w", name, err)
+	}
+
+	if resp.Payload == nil {
+		return nil, fmt.Errorf("secret version %s has no payload", name)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding payload of secret version %s: %w", name, err)
+	}
+
+	return ParseValues(data)
+}
+
+// versionName returns the resource name of the secret version to access
+func (s *GCPSecretManagerSource) versionName() string {
+	name := s.SecretName
+
+	if !strings.HasPrefix(name, "projects/") {
+		name = fmt.Sprintf("projects/%s/secrets/%s", s.Integration.GCPProjectID, name)
+	}
+
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+
+	return name
+}
@@ -0,0 +1,113 @@
+// Package secrets reads the key/value pairs of secrets stored in external secret managers, so that they can be
+// imported into environment groups.
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups/diff"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// Source is an external secret holding a set of key/value pairs
+type Source interface {
+	// Fetch returns the key/value pairs of the latest version of the secret
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+// NewSource returns the Source of the secret of an environment group import source, using the integrations of the
+// project of the import source to authenticate with AWS and GCP
+func NewSource(ctx context.Context, repo repository.Repository, importSource *models.EnvGroupImportSource) (Source, error) {
+	if importSource == nil {
+		return nil, fmt.Errorf("import source is nil")
+	}
+
+	if importSource.Path == "" {
+		return nil, fmt.Errorf("import source path is empty")
+	}
+
+	switch importSource.Provider {
+	case types.EnvGroupImportProvider_AWSSecretsManager:
+		awsInt, err := repo.AWSIntegration().ReadAWSIntegration(importSource.ProjectID, importSource.AWSIntegrationID)
+		if err != nil {
+			return nil, fmt.Errorf("error reading aws integration %d: %w", importSource.AWSIntegrationID, err)
+		}
+
+		return &AWSSecretsManagerSource{
+			Integration: awsInt,
+			SecretID:    importSource.Path,
+		}, nil
+	case types.EnvGroupImportProvider_GCPSecretManager:
+		gcpInt, err := repo.GCPIntegration().ReadGCPIntegration(importSource.ProjectID, importSource.GCPIntegrationID)
+		if err != nil {
+			return nil, fmt.Errorf("error reading gcp integration %d: %w", importSource.GCPIntegrationID, err)
+		}
+
+		return &GCPSecretManagerSource{
+			Integration: gcpInt,
+			SecretName:  importSource.Path,
+		}, nil
+	case types.EnvGroupImportProvider_Vault:
+		return &VaultSource{
+			Address: importSource.VaultAddress,
+			Token:   string(importSource.VaultToken),
+			Path:    importSource.Path,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported import provider %q", importSource.Provider)
+	}
+}
+
+// ParseValues parses the payload of a secret into key/value pairs. The payload must either be a JSON object, whose
+// non-string values are stored as their JSON encoding, or the contents of a .env file.
+func ParseValues(data []byte) (map[string]string, error) {
+	trimmed := bytes.TrimSpace(data)
+
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		obj := make(map[string]json.RawMessage)
+
+		if err := json.Unmarshal(trimmed, &obj); err != nil {
+			return nil, fmt.Errorf("error parsing secret as a json object: %w", err)
+		}
+
+		return stringValues(obj)
+	}
+
+	values, err := diff.ParseDotEnv(bytes.NewReader(trimmed))
+	if err != nil {
+		return nil, fmt.Errorf("secret must be a json object or a .env file: %w", err)
+	}
+
+	return values, nil
+}
+
+// stringValues converts the values of a JSON object to strings. Strings are unquoted, and other values are kept as JSON.
+func stringValues(obj map[string]json.RawMessage) (map[string]string, error) {
+	values := make(map[string]string, len(obj))
+
+	for key, raw := range obj {
+		if bytes.HasPrefix(raw, []byte(`"`)) {
+			var value string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, fmt.Errorf("error parsing value of %s: %w", key, err)
+			}
+
+			values[key] = value
+			continue
+		}
+
+		if string(raw) == "null" {
+			values[key] = ""
+			continue
+		}
+
+		values[key] = string(raw)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,129 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]string
+		err      bool
+	}{
+		{
+			"json object",
+			`{"DB_PASSWORD": "hunter2", "PORT": 8080, "DEBUG": true, "EMPTY": null, "NESTED": {"a": 1}}`,
+			map[string]string{"DB_PASSWORD": "hunter2", "PORT": "8080", "DEBUG": "true", "EMPTY": "", "NESTED": `{"a": 1}`},
+			false,
+		},
+		{
+			".env file",
+			"DB_PASSWORD=hunter2\nexport PORT=8080\n",
+			map[string]string{"DB_PASSWORD": "hunter2", "PORT": "8080"},
+			false,
+		},
+		{
+			"plain value",
+			"hunter2",
+			nil,
+			true,
+		},
+		{
+			"invalid json",
+			`{"DB_PASSWORD": }`,
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := ParseValues([]byte(tt.input))
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, values)
+		})
+	}
+}
+
+func TestVaultSource_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors": ["permission denied"]}`)) // nolint:errcheck,gosec
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/my-app":
+			w.Write([]byte(`{"data": {"data": {"DB_PASSWORD": "hunter2", "PORT": 8080}, "metadata": {"version": 3}}}`)) // nolint:errcheck,gosec
+		case "/v1/kv/my-app":
+			w.Write([]byte(`{"data": {"DB_PASSWORD": "hunter2"}}`)) // nolint:errcheck,gosec
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": []}`)) // nolint:errcheck,gosec
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		source   VaultSource
+		expected map[string]string
+		err      string
+	}{
+		{
+			"kv v2 secret",
+			VaultSource{Address: server.URL + "/", Token: "s.token", Path: "/secret/data/my-app", HTTPClient: server.Client()},
+			map[string]string{"DB_PASSWORD": "hunter2", "PORT": "8080"},
+			"",
+		},
+		{
+			"kv v1 secret",
+			VaultSource{Address: server.URL, Token: "s.token", Path: "kv/my-app", HTTPClient: server.Client()},
+			map[string]string{"DB_PASSWORD": "hunter2"},
+			"",
+		},
+		{
+			"permission denied",
+			VaultSource{Address: server.URL, Token: "s.other", Path: "kv/my-app", HTTPClient: server.Client()},
+			nil,
+			"error reading vault secret kv/my-app: permission denied",
+		},
+		{
+			"missing secret",
+			VaultSource{Address: server.URL, Token: "s.token", Path: "kv/missing", HTTPClient: server.Client()},
+			nil,
+			"error reading vault secret kv/missing: secret not found",
+		},
+		{
+			"private address",
+			VaultSource{Address: server.URL, Token: "s.token", Path: "kv/my-app"},
+			nil,
+			"error reading vault secret kv/my-app: unable to reach the vault server",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := tt.source.Fetch(context.Background())
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, values)
+		})
+	}
+}
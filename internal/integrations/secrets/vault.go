@@ -0,0 +1,102 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+// VaultSource is a secret stored in a HashiCorp Vault KV secrets engine
+type VaultSource struct {
+	// Address is the address of the Vault server, such as https://vault.example.com:8200
+	Address string
+	// Token is the token used to read the secret
+	Token string
+	// Path is the API path of the secret, such as secret/data/my-app for the KV v2 engine or secret/my-app for the KV v1 engine
+	Path string
+
+	// HTTPClient is the client used to call Vault. A client which refuses to connect to private addresses is used if
+	// it is nil.
+	HTTPClient *http.Client
+}
+
+// vaultReadResponse is the response of Vault to a read of a KV secret. The data of KV v2 secrets is nested under
+// data.data, along with the metadata of the version in data.metadata.
+type vaultReadResponse struct {
+	Data map[string]json.RawMessage `json:"data"`
+}
+
+// Fetch returns the key/value pairs of the latest version of the secret
+func (s *VaultSource) Fetch(ctx context.Context) (map[string]string, error) {
+	if s.Address == "" {
+		return nil, fmt.Errorf("vault address is empty")
+	}
+
+	if s.Token == "" {
+		return nil, fmt.Errorf("vault token is empty")
+	}
+
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(s.Address, "/"), strings.TrimPrefix(s.Path, "/"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating vault request: %w", err)
+	}
+
+	req.Header.Set("X-Vault-Token", s.Token)
+
+	client := s.HTTPClient
+	if client == nil {
+		client = netguard.NewHTTPClient(30 * time.Second)
+	}
+
+	// the errors and responses of the server are not returned, since the address of the server is chosen by users
+	// and the errors are shown to them
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error reading vault secret %s: unable to reach the vault server", s.Path)
+	}
+	defer res.Body.Close() // nolint:errcheck
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading vault secret %s: unable to read the response of the vault server", s.Path)
+	}
+
+	resp := &vaultReadResponse{}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("error reading vault secret %s: permission denied", s.Path)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("error reading vault secret %s: secret not found", s.Path)
+	default:
+		return nil, fmt.Errorf("error reading vault secret %s: unexpected response from the vault server", s.Path)
+	}
+
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, fmt.Errorf("error reading vault secret %s: invalid response from the vault server", s.Path)
+	}
+
+	data := resp.Data
+
+	// KV v2 secrets hold their key/value pairs under data.data
+	if nested, ok := data["data"]; ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = make(map[string]json.RawMessage)
+
+			if err := json.Unmarshal(nested, &data); err != nil {
+				return nil, fmt.Errorf("error reading vault secret %s: invalid response from the vault server", s.Path)
+			}
+		}
+	}
+
+	return stringValues(data)
+}
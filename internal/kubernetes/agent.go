@@ -1943,6 +1943,11 @@ func (a *Agent) StreamPorterAgentLokiLog(
 	return a.RunWebsocketTask(run)
 }
 
+// ImagePullSecretName returns the name of the image pull secret created by CreateImagePullSecrets for a registry
+func ImagePullSecretName(reg *models.Registry) string {
+	return fmt.Sprintf("porter-%s-%d", reg.ToRegistryType().Service, reg.ID)
+}
+
 // CreateImagePullSecrets will create the required image pull secrets and
 // return a map from the registry name to the name of the secret.
 func (a *Agent) CreateImagePullSecrets(
@@ -1961,7 +1966,7 @@ func (a *Agent) CreateImagePullSecrets(
 			return nil, err
 		}
 
-		secretName := fmt.Sprintf("porter-%s-%d", val.ToRegistryType().Service, val.ID)
+		secretName := ImagePullSecretName(val)
 
 		secret, err := a.Clientset.CoreV1().Secrets(namespace).Get(
 			context.TODO(),
@@ -6,6 +6,7 @@ import (
 	"net"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/porter-dev/porter/internal/encryption"
 	"github.com/porter-dev/porter/internal/integrations/dns"
 	"github.com/porter-dev/porter/internal/models"
@@ -81,6 +82,12 @@ type CreateDNSRecordConfig struct {
 	ReleaseName string
 	RootDomain  string
 	Endpoint    string
+
+	// ClusterID, AppName and DeploymentTargetID identify the app the record is created for, so that
+	// the record can be deleted along with the app
+	ClusterID          uint
+	AppName            string
+	DeploymentTargetID uuid.UUID
 }
 
 // NewDNSRecordForEndpoint generates a random subdomain and returns a DNSRecord
@@ -91,15 +98,27 @@ func (c *CreateDNSRecordConfig) NewDNSRecordForEndpoint() *models.DNSRecord {
 	subdomain := fmt.Sprintf("%s-%s", c.ReleaseName, suffix)
 
 	return &models.DNSRecord{
-		SubdomainPrefix: subdomain,
-		RootDomain:      c.RootDomain,
-		Endpoint:        c.Endpoint,
-		Hostname:        fmt.Sprintf("%s.%s", subdomain, c.RootDomain),
+		SubdomainPrefix:    subdomain,
+		RootDomain:         c.RootDomain,
+		Endpoint:           c.Endpoint,
+		Hostname:           fmt.Sprintf("%s.%s", subdomain, c.RootDomain),
+		ClusterID:          c.ClusterID,
+		PorterAppName:      c.AppName,
+		DeploymentTargetID: c.DeploymentTargetID,
 	}
 }
 
 // CreateDomain creates a new record for the vanity domain
 func (e *DNSRecord) CreateDomain(dnsClient *dns.Client) error {
+	return dnsClient.CreateRecord(e.record())
+}
+
+// DeleteDomain deletes the record for the vanity domain
+func (e *DNSRecord) DeleteDomain(dnsClient *dns.Client) error {
+	return dnsClient.DeleteRecord(e.record())
+}
+
+func (e *DNSRecord) record() dns.Record {
 	isIPv4 := net.ParseIP(e.Endpoint) != nil
 
 	dnsType := dns.RecordType_CNAME
@@ -107,10 +126,10 @@ func (e *DNSRecord) CreateDomain(dnsClient *dns.Client) error {
 		dnsType = dns.RecordType_A
 	}
 
-	return dnsClient.CreateRecord(dns.Record{
+	return dns.Record{
 		Type:       dnsType,
 		Value:      e.Endpoint,
 		Name:       e.SubdomainPrefix,
 		RootDomain: e.RootDomain,
-	})
+	}
 }
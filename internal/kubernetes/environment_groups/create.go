@@ -64,6 +64,7 @@ func CreateOrUpdateBaseEnvironmentGroup(ctx context.Context, a *kubernetes.Agent
 		SecretVariables: environmentGroup.SecretVariables,
 		Version:         latestEnvironmentGroup.Version + 1,
 		CreatedAtUTC:    environmentGroup.CreatedAtUTC,
+		CreatedBy:       environmentGroup.CreatedBy,
 	}
 
 	err = createVersionedEnvironmentGroupInNamespace(ctx, a, newEnvironmentGroup, Namespace_EnvironmentGroups, additionalLabels)
@@ -118,6 +119,58 @@ func createEnvironmentGroupInTargetNamespace(ctx context.Context, a *kubernetes.
 	return configMapName, nil
 }
 
+// inheritedLabels returns the labels of a version of a base environment group which must be kept on its next versions, such as its type and whether it is
+// the default environment group of an app, merged with the given labels. The given labels take precedence over the inherited ones.
+func inheritedLabels(ctx context.Context, a *kubernetes.Agent, environmentGroupName string, version int, additionalLabels map[string]string) (map[string]string, error) {
+	ctx, span := telemetry.NewSpan(ctx, "inherited-environment-group-labels")
+	defer span.End()
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "environment-group-name", Value: environmentGroupName},
+		telemetry.AttributeKV{Key: "version", Value: version},
+	)
+
+	labels := make(map[string]string)
+
+	if version != 0 {
+		listOptions := metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s,%s=%d", LabelKey_EnvironmentGroupName, environmentGroupName, LabelKey_EnvironmentGroupVersion, version),
+		}
+
+		configMaps, err := a.Clientset.CoreV1().ConfigMaps(Namespace_EnvironmentGroups).List(ctx, listOptions)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "unable to list environment group configmaps")
+		}
+
+		secrets, err := a.Clientset.CoreV1().Secrets(Namespace_EnvironmentGroups).List(ctx, listOptions)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "unable to list environment group secrets")
+		}
+
+		for _, secret := range secrets.Items {
+			for k, v := range secret.Labels {
+				labels[k] = v
+			}
+		}
+
+		for _, cm := range configMaps.Items {
+			for k, v := range cm.Labels {
+				labels[k] = v
+			}
+		}
+
+		// these labels are set for each version by createVersionedEnvironmentGroupInNamespace
+		delete(labels, LabelKey_EnvironmentGroupName)
+		delete(labels, LabelKey_EnvironmentGroupVersion)
+		delete(labels, LabelKey_PorterManaged)
+	}
+
+	for k, v := range additionalLabels {
+		labels[k] = v
+	}
+
+	return labels, nil
+}
+
 // createVersionedEnvironmentGroupInNamespace creates a new environment group in the target namespace. This is used to keep the configmap and secret version for an environment variable in sync
 func createVersionedEnvironmentGroupInNamespace(ctx context.Context, a *kubernetes.Agent, environmentGroup EnvironmentGroup, targetNamespace string, additionalLabels map[string]string) error {
 	ctx, span := telemetry.NewSpan(ctx, "create-environment-group-on-cluster")
@@ -138,6 +191,11 @@ func createVersionedEnvironmentGroupInNamespace(ctx context.Context, a *kubernet
 	for k, v := range additionalLabels {
 		configMap.Labels[k] = v
 	}
+	if environmentGroup.CreatedBy != "" {
+		configMap.Annotations = map[string]string{
+			AnnotationKey_EnvironmentGroupCreatedBy: environmentGroup.CreatedBy,
+		}
+	}
 
 	err := createConfigMapWithVersion(ctx, a, configMap, environmentGroup.Version)
 	if err != nil {
@@ -164,6 +222,11 @@ func createVersionedEnvironmentGroupInNamespace(ctx context.Context, a *kubernet
 	for k, v := range additionalLabels {
 		secret.Labels[k] = v
 	}
+	if environmentGroup.CreatedBy != "" {
+		secret.Annotations = map[string]string{
+			AnnotationKey_EnvironmentGroupCreatedBy: environmentGroup.CreatedBy,
+		}
+	}
 
 	err = createSecretWithVersion(ctx, a, secret, environmentGroup.Version)
 	if err != nil {
@@ -0,0 +1,286 @@
+// Package diff computes the changes between the variables of an environment group or app and a desired set of
+// variables, such as the contents of a .env file or of a secret in an external secret manager.
+package diff
+
+import "sort"
+
+// MaskedValue is displayed in place of the values of secret variables
+const MaskedValue = "********"
+
+// Variables is a set of environment variables, split between normal and secret variables
+type Variables struct {
+	Normal map[string]string
+	Secret map[string]string
+}
+
+// Change is a change to a single variable
+type Change struct {
+	Key string
+	// Secret is whether the variable is a secret after the change. For removed variables, it is whether the removed variable was a secret.
+	Secret bool
+	// WasSecret is whether the variable was a secret before the change
+	WasSecret bool
+	// Old is the value before the change, empty for added variables
+	Old string
+	// New is the value after the change, empty for removed variables
+	New string
+}
+
+// DisplayOld returns the value before the change, masked if the variable was a secret
+func (c Change) DisplayOld() string {
+	if c.WasSecret {
+		return MaskedValue
+	}
+
+	return c.Old
+}
+
+// DisplayNew returns the value after the change, masked if the variable is a secret
+func (c Change) DisplayNew() string {
+	if c.Secret {
+		return MaskedValue
+	}
+
+	return c.New
+}
+
+// Diff is the set of changes required to go from the current variables to the desired variables. Each list is sorted by key.
+type Diff struct {
+	Added   []Change
+	Changed []Change
+	Removed []Change
+}
+
+// Empty returns whether the diff has no changes
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// Compute returns the changes required to go from the current variables to the desired variables. A variable which
+// moves between normal and secret is changed even if its value is unchanged. Variables which are not desired are only
+// removed if prune is set.
+func Compute(current Variables, desired Variables, prune bool) Diff {
+	var d Diff
+
+	desiredKeys := make(map[string]bool)
+
+	add := func(key, value string, secret bool) {
+		desiredKeys[key] = true
+
+		old, wasSecret, exists := lookup(current, key)
+		if !exists {
+			d.Added = append(d.Added, Change{Key: key, Secret: secret, New: value})
+			return
+		}
+
+		if old != value || wasSecret != secret {
+			d.Changed = append(d.Changed, Change{Key: key, Secret: secret, WasSecret: wasSecret, Old: old, New: value})
+		}
+	}
+
+	for key, value := range desired.Normal {
+		// a key which is both a normal and a secret variable is treated as a secret
+		if _, ok := desired.Secret[key]; ok {
+			continue
+		}
+
+		add(key, value, false)
+	}
+
+	for key, value := range desired.Secret {
+		add(key, value, true)
+	}
+
+	if prune {
+		for key, value := range current.Normal {
+			if !desiredKeys[key] {
+				if _, ok := current.Secret[key]; !ok {
+					d.Removed = append(d.Removed, Change{Key: key, Old: value})
+				}
+			}
+		}
+
+		for key, value := range current.Secret {
+			if !desiredKeys[key] {
+				d.Removed = append(d.Removed, Change{Key: key, Secret: true, WasSecret: true, Old: value})
+			}
+		}
+	}
+
+	sortChanges(d.Added)
+	sortChanges(d.Changed)
+	sortChanges(d.Removed)
+
+	return d
+}
+
+// KeepSecrets moves the desired normal variables which are currently secrets to the desired secrets, so that
+// re-applying a file of plain values does not expose existing secrets
+func KeepSecrets(current Variables, desired Variables) Variables {
+	kept := Variables{
+		Normal: make(map[string]string),
+		Secret: make(map[string]string),
+	}
+
+	for key, value := range desired.Secret {
+		kept.Secret[key] = value
+	}
+
+	for key, value := range desired.Normal {
+		if _, ok := current.Secret[key]; ok {
+			kept.Secret[key] = value
+			continue
+		}
+
+		kept.Normal[key] = value
+	}
+
+	return kept
+}
+
+// Updates returns the variables which must be set to apply the diff
+func (d Diff) Updates() Variables {
+	updates := Variables{
+		Normal: make(map[string]string),
+		Secret: make(map[string]string),
+	}
+
+	for _, changes := range [][]Change{d.Added, d.Changed} {
+		for _, c := range changes {
+			if c.Secret {
+				updates.Secret[c.Key] = c.New
+				continue
+			}
+
+			updates.Normal[c.Key] = c.New
+		}
+	}
+
+	return updates
+}
+
+// Deletions returns the keys of the normal and secret variables which must be deleted to apply the diff. Variables
+// which move between normal and secret are deleted from their previous kind.
+func (d Diff) Deletions() (variables []string, secrets []string) {
+	for _, c := range d.Changed {
+		if c.Secret == c.WasSecret {
+			continue
+		}
+
+		if c.WasSecret {
+			secrets = append(secrets, c.Key)
+			continue
+		}
+
+		variables = append(variables, c.Key)
+	}
+
+	for _, c := range d.Removed {
+		if c.WasSecret {
+			secrets = append(secrets, c.Key)
+			continue
+		}
+
+		variables = append(variables, c.Key)
+	}
+
+	sort.Strings(variables)
+	sort.Strings(secrets)
+
+	return variables, secrets
+}
+
+// Apply returns the variables resulting from applying the diff to the current variables. The current variables are not modified.
+func (d Diff) Apply(current Variables) Variables {
+	result := Variables{
+		Normal: make(map[string]string),
+		Secret: make(map[string]string),
+	}
+
+	for key, value := range current.Normal {
+		result.Normal[key] = value
+	}
+
+	for key, value := range current.Secret {
+		result.Secret[key] = value
+	}
+
+	for _, changes := range [][]Change{d.Added, d.Changed} {
+		for _, c := range changes {
+			if c.Secret {
+				delete(result.Normal, c.Key)
+				result.Secret[c.Key] = c.New
+				continue
+			}
+
+			delete(result.Secret, c.Key)
+			result.Normal[c.Key] = c.New
+		}
+	}
+
+	for _, c := range d.Removed {
+		delete(result.Normal, c.Key)
+		delete(result.Secret, c.Key)
+	}
+
+	return result
+}
+
+// Redacted returns a copy of the diff where the values of secret variables are replaced with MaskedValue, so that it can be returned to users
+func (d Diff) Redacted() Diff {
+	redact := func(changes []Change) []Change {
+		if changes == nil {
+			return nil
+		}
+
+		res := make([]Change, 0, len(changes))
+		for _, c := range changes {
+			if c.WasSecret && c.Old != "" {
+				c.Old = MaskedValue
+			}
+			if c.Secret && c.New != "" {
+				c.New = MaskedValue
+			}
+			res = append(res, c)
+		}
+		return res
+	}
+
+	return Diff{
+		Added:   redact(d.Added),
+		Changed: redact(d.Changed),
+		Removed: redact(d.Removed),
+	}
+}
+
+// Keys returns the keys of the added, changed and removed variables
+func (d Diff) Keys() (added []string, changed []string, removed []string) {
+	keys := func(changes []Change) []string {
+		res := make([]string, 0, len(changes))
+		for _, c := range changes {
+			res = append(res, c.Key)
+		}
+		return res
+	}
+
+	return keys(d.Added), keys(d.Changed), keys(d.Removed)
+}
+
+// lookup returns the current value of a key and whether it is a secret. Secrets take precedence over normal variables.
+func lookup(vars Variables, key string) (string, bool, bool) {
+	if value, ok := vars.Secret[key]; ok {
+		return value, true, true
+	}
+
+	if value, ok := vars.Normal[key]; ok {
+		return value, false, true
+	}
+
+	return "", false, false
+}
+
+func sortChanges(changes []Change) {
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+}
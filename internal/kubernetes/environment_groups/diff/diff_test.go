@@ -0,0 +1,148 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompute(t *testing.T) {
+	current := Variables{
+		Normal: map[string]string{"PORT": "80", "LOG_LEVEL": "info", "REGION": "us-east-1", "STALE": "1"},
+		Secret: map[string]string{"DB_PASSWORD": "hunter2", "API_KEY": "abc", "OLD_TOKEN": "xyz"},
+	}
+	desired := Variables{
+		Normal: map[string]string{"PORT": "8080", "LOG_LEVEL": "info", "API_KEY": "abc", "NEW_VAR": "yes"},
+		Secret: map[string]string{"DB_PASSWORD": "hunter2", "REGION": "us-east-1", "NEW_SECRET": "s3cr3t"},
+	}
+
+	tests := []struct {
+		name     string
+		prune    bool
+		expected Diff
+	}{
+		{
+			"without prune",
+			false,
+			Diff{
+				Added: []Change{
+					{Key: "NEW_SECRET", Secret: true, New: "s3cr3t"},
+					{Key: "NEW_VAR", New: "yes"},
+				},
+				Changed: []Change{
+					{Key: "API_KEY", WasSecret: true, Old: "abc", New: "abc"},
+					{Key: "PORT", Old: "80", New: "8080"},
+					{Key: "REGION", Secret: true, Old: "us-east-1", New: "us-east-1"},
+				},
+			},
+		},
+		{
+			"with prune",
+			true,
+			Diff{
+				Added: []Change{
+					{Key: "NEW_SECRET", Secret: true, New: "s3cr3t"},
+					{Key: "NEW_VAR", New: "yes"},
+				},
+				Changed: []Change{
+					{Key: "API_KEY", WasSecret: true, Old: "abc", New: "abc"},
+					{Key: "PORT", Old: "80", New: "8080"},
+					{Key: "REGION", Secret: true, Old: "us-east-1", New: "us-east-1"},
+				},
+				Removed: []Change{
+					{Key: "OLD_TOKEN", Secret: true, WasSecret: true, Old: "xyz"},
+					{Key: "STALE", Old: "1"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Compute(current, desired, tt.prune)
+			assert.Equal(t, tt.expected, d)
+
+			applied := d.Apply(current)
+			if tt.prune {
+				assert.Equal(t, desired.Normal, applied.Normal)
+				assert.Equal(t, desired.Secret, applied.Secret)
+			}
+
+			assert.Empty(t, Compute(applied, desired, tt.prune).Added)
+			assert.True(t, Compute(applied, desired, tt.prune).Empty())
+		})
+	}
+}
+
+func TestDiff_UpdatesAndDeletions(t *testing.T) {
+	current := Variables{
+		Normal: map[string]string{"PORT": "80", "REGION": "us-east-1", "STALE": "1"},
+		Secret: map[string]string{"API_KEY": "abc", "OLD_TOKEN": "xyz"},
+	}
+	desired := Variables{
+		Normal: map[string]string{"PORT": "8080", "API_KEY": "abc"},
+		Secret: map[string]string{"REGION": "us-east-1"},
+	}
+
+	d := Compute(current, desired, true)
+
+	updates := d.Updates()
+	assert.Equal(t, map[string]string{"PORT": "8080", "API_KEY": "abc"}, updates.Normal)
+	assert.Equal(t, map[string]string{"REGION": "us-east-1"}, updates.Secret)
+
+	variables, secrets := d.Deletions()
+	assert.Equal(t, []string{"REGION", "STALE"}, variables)
+	assert.Equal(t, []string{"API_KEY", "OLD_TOKEN"}, secrets)
+}
+
+func TestKeepSecrets(t *testing.T) {
+	current := Variables{
+		Normal: map[string]string{"PORT": "80"},
+		Secret: map[string]string{"DB_PASSWORD": "hunter2"},
+	}
+	desired := Variables{
+		Normal: map[string]string{"PORT": "8080", "DB_PASSWORD": "hunter3"},
+	}
+
+	kept := KeepSecrets(current, desired)
+	assert.Equal(t, map[string]string{"PORT": "8080"}, kept.Normal)
+	assert.Equal(t, map[string]string{"DB_PASSWORD": "hunter3"}, kept.Secret)
+
+	d := Compute(current, kept, false)
+	assert.Equal(t, []Change{
+		{Key: "DB_PASSWORD", Secret: true, WasSecret: true, Old: "hunter2", New: "hunter3"},
+		{Key: "PORT", Old: "80", New: "8080"},
+	}, d.Changed)
+	assert.Equal(t, MaskedValue, d.Changed[0].DisplayOld())
+	assert.Equal(t, MaskedValue, d.Changed[0].DisplayNew())
+	assert.Equal(t, "8080", d.Changed[1].DisplayNew())
+}
+
+func TestDiff_Redacted(t *testing.T) {
+	current := Variables{
+		Normal: map[string]string{"PORT": "80"},
+		Secret: map[string]string{"DB_PASSWORD": "hunter2", "OLD_TOKEN": "xyz"},
+	}
+	desired := Variables{
+		Normal: map[string]string{"PORT": "8080", "DB_PASSWORD": "hunter3"},
+		Secret: map[string]string{"API_KEY": "abc"},
+	}
+
+	d := Compute(current, desired, true)
+
+	assert.Equal(t, Diff{
+		Added: []Change{
+			{Key: "API_KEY", Secret: true, New: MaskedValue},
+		},
+		Changed: []Change{
+			{Key: "DB_PASSWORD", WasSecret: true, Old: MaskedValue, New: "hunter3"},
+			{Key: "PORT", Old: "80", New: "8080"},
+		},
+		Removed: []Change{
+			{Key: "OLD_TOKEN", Secret: true, WasSecret: true, Old: MaskedValue},
+		},
+	}, d.Redacted())
+
+	// the original diff keeps its values
+	assert.Equal(t, "hunter2", d.Changed[0].Old)
+}
@@ -0,0 +1,138 @@
+package diff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var dotEnvKeyRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.-]*$`)
+
+// ParseDotEnv parses the contents of a .env file into a map of variables. It supports blank lines, comments starting
+// with '#', an optional 'export ' prefix, unquoted values with trailing comments, single-quoted values which are
+// taken literally and double-quoted values which may span multiple lines and contain \n, \t, \" and \\ escapes.
+func ParseDotEnv(r io.Reader) (map[string]string, error) {
+	vars := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)
+		}
+
+		key = strings.TrimSpace(key)
+		if !dotEnvKeyRegex.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid key %q", lineNumber, key)
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(value, `'`):
+			end := strings.Index(value[1:], `'`)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated single-quoted value for %s", lineNumber, key)
+			}
+
+			value = value[1 : end+1]
+		case strings.HasPrefix(value, `"`):
+			raw := value[1:]
+			startLine := lineNumber
+
+			// double-quoted values continue until the closing quote, which may be on a later line
+			for !hasClosingQuote(raw) {
+				if !scanner.Scan() {
+					return nil, fmt.Errorf("line %d: unterminated double-quoted value for %s", startLine, key)
+				}
+
+				lineNumber++
+				raw += "\n" + scanner.Text()
+			}
+
+			value = unescapeDoubleQuoted(raw[:closingQuoteIndex(raw)])
+		default:
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+		}
+
+		vars[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading .env file: %w", err)
+	}
+
+	return vars, nil
+}
+
+func hasClosingQuote(s string) bool {
+	return closingQuoteIndex(s) >= 0
+}
+
+// closingQuoteIndex returns the index of the first unescaped double quote of s, or -1 if there is none
+func closingQuoteIndex(s string) int {
+	escaped := false
+
+	for i, c := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+func unescapeDoubleQuoted(s string) string {
+	var b strings.Builder
+
+	escaped := false
+
+	for _, c := range s {
+		if !escaped {
+			if c == '\\' {
+				escaped = true
+				continue
+			}
+
+			b.WriteRune(c)
+			continue
+		}
+
+		escaped = false
+
+		switch c {
+		case 'n':
+			b.WriteRune('\n')
+		case 't':
+			b.WriteRune('\t')
+		case 'r':
+			b.WriteRune('\r')
+		default:
+			b.WriteRune(c)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	input := `# Generated by Porter CLI
+PORT=8080
+export LOG_LEVEL = debug # trailing comment
+
+URL=https://example.com/#anchor
+SINGLE='literal $HOME \n'
+DOUBLE="quoted \"value\"\twith escapes"
+MULTILINE="first
+second"
+EMPTY=
+`
+
+	vars, err := ParseDotEnv(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"PORT":      "8080",
+		"LOG_LEVEL": "debug",
+		"URL":       "https://example.com/#anchor",
+		"SINGLE":    `literal $HOME \n`,
+		"DOUBLE":    "quoted \"value\"\twith escapes",
+		"MULTILINE": "first\nsecond",
+		"EMPTY":     "",
+	}, vars)
+}
+
+func TestParseDotEnv_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"missing equals sign", "PORT 8080", "line 1: expected KEY=VALUE"},
+		{"invalid key", "1PORT=8080", `line 1: invalid key "1PORT"`},
+		{"unterminated single quote", "A='value", "line 1: unterminated single-quoted value for A"},
+		{"unterminated double quote", "A=1\nB=\"value\nmore", "line 2: unterminated double-quoted value for B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDotEnv(strings.NewReader(tt.input))
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
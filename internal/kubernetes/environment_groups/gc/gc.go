@@ -0,0 +1,364 @@
+package gc
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/api-contracts/generated/go/helpers"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	environmentgroups "github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups/retention"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CopyMinAge is the minimum age of the copies which are deleted. Copies are synced to the namespace of an app before
+// its workloads are updated, so recent copies may be loaded by a deploy which is still in progress.
+const CopyMinAge = time.Hour
+
+// CollectReferences returns the copies referenced by the Deployments, StatefulSets, DaemonSets, CronJobs, ReplicaSets
+// with replicas, running Jobs and running Pods of a cluster, and by the last keepLastRevisions revisions of every app deployed to the cluster. An error is returned if
+// the references of any workload or app revision cannot be collected, since copies which are in use could otherwise
+// be deleted.
+func CollectReferences(ctx context.Context, conf *config.Config, agent *kubernetes.Agent, cluster *models.Cluster, keepLastRevisions uint) (*retention.References, error) {
+	ctx, span := telemetry.NewSpan(ctx, "collect-env-group-copy-references")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: cluster.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
+		telemetry.AttributeKV{Key: "keep-last-revisions", Value: keepLastRevisions},
+	)
+
+	refs := retention.NewReferences()
+
+	if err := addWorkloadReferences(ctx, agent, refs); err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error getting env group copies referenced by workloads")
+	}
+
+	// revisions are linked to the namespace of the cluster they are deployed to through their deployment target
+	namespaces := make(map[uuid.UUID]string)
+
+	for _, preview := range []bool{false, true} {
+		targets, err := conf.Repo.DeploymentTarget().List(cluster.ProjectID, cluster.ID, preview)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error listing deployment targets")
+		}
+
+		for _, target := range targets {
+			if target.SelectorType == models.DeploymentTargetSelectorType_Namespace {
+				namespaces[target.ID] = target.Selector
+			}
+		}
+	}
+
+	revisions, err := conf.Repo.AppRevision().LatestAppRevisions(ctx, cluster.ProjectID, keepLastRevisions)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing latest app revisions")
+	}
+
+	revisionCount := 0
+
+	for _, revision := range revisions {
+		namespace, ok := namespaces[revision.DeploymentTargetID]
+		if !ok {
+			continue
+		}
+
+		if err := addAppRevisionReferences(refs, namespace, revision); err != nil {
+			return nil, telemetry.Error(ctx, span, err, fmt.Sprintf("error getting env groups of app revision %s", revision.ID))
+		}
+
+		revisionCount++
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "app-revision-count", Value: revisionCount},
+		telemetry.AttributeKV{Key: "reference-count", Value: refs.Len()},
+	)
+
+	return refs, nil
+}
+
+func addAppRevisionReferences(refs *retention.References, namespace string, revision *models.AppRevision) error {
+	decoded, err := base64.StdEncoding.DecodeString(revision.Base64App)
+	if err != nil {
+		return fmt.Errorf("error decoding app: %w", err)
+	}
+
+	app := &porterv1.PorterApp{}
+
+	if err := helpers.UnmarshalContractObject(decoded, app); err != nil {
+		return fmt.Errorf("error unmarshalling app: %w", err)
+	}
+
+	for _, envGroup := range app.EnvGroups {
+		if envGroup == nil {
+			continue
+		}
+
+		refs.AddEnvGroup(namespace, envGroup.Name, int(envGroup.Version), fmt.Sprintf("app %s (revision %d)", app.Name, revision.RevisionNumber))
+	}
+
+	return nil
+}
+
+func addWorkloadReferences(ctx context.Context, agent *kubernetes.Agent, refs *retention.References) error {
+	// workloads of all namespaces are listed
+	deployments, err := agent.Clientset.AppsV1().Deployments("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing deployments: %w", err)
+	}
+
+	for _, d := range deployments.Items {
+		refs.AddPodSpec(d.Namespace, d.Spec.Template.Spec, fmt.Sprintf("deployment %s/%s", d.Namespace, d.Name))
+	}
+
+	statefulSets, err := agent.Clientset.AppsV1().StatefulSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing statefulsets: %w", err)
+	}
+
+	for _, s := range statefulSets.Items {
+		refs.AddPodSpec(s.Namespace, s.Spec.Template.Spec, fmt.Sprintf("statefulset %s/%s", s.Namespace, s.Name))
+	}
+
+	daemonSets, err := agent.Clientset.AppsV1().DaemonSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing daemonsets: %w", err)
+	}
+
+	for _, d := range daemonSets.Items {
+		refs.AddPodSpec(d.Namespace, d.Spec.Template.Spec, fmt.Sprintf("daemonset %s/%s", d.Namespace, d.Name))
+	}
+
+	// the replicasets of previous revisions of a deployment keep their pods until a rollout completes, so the copies
+	// loaded by those pods are in use until the replicaset is scaled down
+	replicaSets, err := agent.Clientset.AppsV1().ReplicaSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing replicasets: %w", err)
+	}
+
+	for _, r := range replicaSets.Items {
+		if r.Status.Replicas == 0 {
+			continue
+		}
+
+		refs.AddPodSpec(r.Namespace, r.Spec.Template.Spec, fmt.Sprintf("replicaset %s/%s", r.Namespace, r.Name))
+	}
+
+	cronJobs, err := agent.Clientset.BatchV1().CronJobs("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing cronjobs: %w", err)
+	}
+
+	for _, c := range cronJobs.Items {
+		refs.AddPodSpec(c.Namespace, c.Spec.JobTemplate.Spec.Template.Spec, fmt.Sprintf("cronjob %s/%s", c.Namespace, c.Name))
+	}
+
+	// jobs which have finished no longer load their environment, but running jobs, like the pre-deploy jobs of apps,
+	// may still be starting pods
+	jobs, err := agent.Clientset.BatchV1().Jobs("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing jobs: %w", err)
+	}
+
+	for _, j := range jobs.Items {
+		if j.Status.CompletionTime != nil || (j.Status.Active == 0 && (j.Status.Failed > 0 || j.Status.Succeeded > 0)) {
+			continue
+		}
+
+		refs.AddPodSpec(j.Namespace, j.Spec.Template.Spec, fmt.Sprintf("job %s/%s", j.Namespace, j.Name))
+	}
+
+	// pods which are not owned by any of the workloads above, or whose workload was already updated, may still
+	// restart their containers with the environment they were created with
+	pods, err := agent.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing pods: %w", err)
+	}
+
+	for _, p := range pods.Items {
+		if p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		refs.AddPodSpec(p.Namespace, p.Spec, fmt.Sprintf("pod %s/%s", p.Namespace, p.Name))
+	}
+
+	return nil
+}
+
+// ListCopies returns the versioned copies of environment groups in the namespaces of a cluster. The versions of the
+// base environment groups in the porter-env-group namespace are not copies, and are never returned.
+func ListCopies(ctx context.Context, agent *kubernetes.Agent) ([]retention.Copy, error) {
+	ctx, span := telemetry.NewSpan(ctx, "list-env-group-copies")
+	defer span.End()
+
+	selector := fmt.Sprintf("%s,%s=true", environmentgroups.LabelKey_EnvironmentGroupName, environmentgroups.LabelKey_PorterManaged)
+
+	configMaps, err := agent.Clientset.CoreV1().ConfigMaps("").List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing env group configmaps")
+	}
+
+	secrets, err := agent.Clientset.CoreV1().Secrets("").List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing env group secrets")
+	}
+
+	// the configmap and the secret of a copy are deleted together, so both are merged into a single copy created at
+	// the time the first of them was created
+	copies := make(map[string]retention.Copy)
+	order := make([]string, 0)
+
+	add := func(meta metav1.ObjectMeta) {
+		if meta.Namespace == environmentgroups.Namespace_EnvironmentGroups {
+			return
+		}
+
+		version, err := strconv.Atoi(meta.Labels[environmentgroups.LabelKey_EnvironmentGroupVersion])
+		if err != nil {
+			return
+		}
+
+		c := retention.Copy{
+			Namespace:    meta.Namespace,
+			EnvGroupName: meta.Labels[environmentgroups.LabelKey_EnvironmentGroupName],
+			Version:      version,
+			CreatedAt:    meta.CreationTimestamp.Time,
+		}
+
+		// resources which are not named after their labels were not created by syncing an environment group
+		if c.Name() != meta.Name {
+			return
+		}
+
+		k := c.Namespace + "/" + c.Name()
+
+		existing, ok := copies[k]
+		if !ok {
+			order = append(order, k)
+			copies[k] = c
+			return
+		}
+
+		if c.CreatedAt.Before(existing.CreatedAt) {
+			existing.CreatedAt = c.CreatedAt
+			copies[k] = existing
+		}
+	}
+
+	for _, cm := range configMaps.Items {
+		add(cm.ObjectMeta)
+	}
+
+	for _, secret := range secrets.Items {
+		add(secret.ObjectMeta)
+	}
+
+	res := make([]retention.Copy, 0, len(order))
+
+	for _, k := range order {
+		res = append(res, copies[k])
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "copy-count", Value: len(res)})
+
+	return res, nil
+}
+
+// Evaluate returns which copies of the environment groups of a cluster are kept and deleted, without deleting any copy
+func Evaluate(ctx context.Context, agent *kubernetes.Agent, refs *retention.References) (retention.Report, error) {
+	ctx, span := telemetry.NewSpan(ctx, "evaluate-env-group-copies")
+	defer span.End()
+
+	var report retention.Report
+
+	copies, err := ListCopies(ctx, agent)
+	if err != nil {
+		return report, telemetry.Error(ctx, span, err, "error listing env group copies")
+	}
+
+	baseVersions, err := environmentgroups.ListEnvironmentGroups(ctx, agent, environmentgroups.WithNamespace(environmentgroups.Namespace_EnvironmentGroups))
+	if err != nil {
+		return report, telemetry.Error(ctx, span, err, "error listing base env groups")
+	}
+
+	latestVersions := make(map[string]int)
+
+	for _, eg := range baseVersions {
+		if eg.Version > latestVersions[eg.Name] {
+			latestVersions[eg.Name] = eg.Version
+		}
+	}
+
+	report = retention.Evaluate(retention.EvaluateInput{
+		Copies:         copies,
+		References:     refs,
+		LatestVersions: latestVersions,
+		MinAge:         CopyMinAge,
+		Now:            time.Now(),
+	})
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "copy-count", Value: len(copies)},
+		telemetry.AttributeKV{Key: "keep-count", Value: len(report.Keep)},
+		telemetry.AttributeKV{Key: "delete-count", Value: len(report.Delete)},
+	)
+
+	return report, nil
+}
+
+// Apply deletes the configmaps and secrets of the copies of a report which are not kept, and returns the copies which
+// were deleted. All copies are attempted, and the deleted copies are returned even if some copies could not be deleted.
+func Apply(ctx context.Context, agent *kubernetes.Agent, report retention.Report) ([]retention.Copy, error) {
+	ctx, span := telemetry.NewSpan(ctx, "apply-env-group-copy-retention")
+	defer span.End()
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "delete-count", Value: len(report.Delete)})
+
+	deleted := make([]retention.Copy, 0, len(report.Delete))
+	var errs []error
+
+	for _, decision := range report.Delete {
+		if err := deleteCopy(ctx, agent, decision.Copy); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting %s/%s: %w", decision.Namespace, decision.Name(), err))
+			continue
+		}
+
+		deleted = append(deleted, decision.Copy)
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deleted-count", Value: len(deleted)})
+
+	if len(errs) > 0 {
+		return deleted, telemetry.Error(ctx, span, errors.Join(errs...), "error deleting env group copies")
+	}
+
+	return deleted, nil
+}
+
+func deleteCopy(ctx context.Context, agent *kubernetes.Agent, c retention.Copy) error {
+	err := agent.Clientset.CoreV1().ConfigMaps(c.Namespace).Delete(ctx, c.Name(), metav1.DeleteOptions{})
+	if err != nil && !k8serror.IsNotFound(err) {
+		return fmt.Errorf("error deleting configmap: %w", err)
+	}
+
+	err = agent.Clientset.CoreV1().Secrets(c.Namespace).Delete(ctx, c.Name(), metav1.DeleteOptions{})
+	if err != nil && !k8serror.IsNotFound(err) {
+		return fmt.Errorf("error deleting secret: %w", err)
+	}
+
+	return nil
+}
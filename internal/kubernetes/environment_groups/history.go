@@ -0,0 +1,294 @@
+package environment_groups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups/diff"
+	"github.com/porter-dev/porter/internal/telemetry"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrEnvironmentGroupVersionNotFound is returned when a version of an environment group does not exist in the porter-env-group namespace
+var ErrEnvironmentGroupVersionNotFound = errors.New("environment group version not found")
+
+// ListEnvironmentGroupVersions returns all versions of a base environment group, from the most recent to the oldest.
+// Secret values are replaced with a dummy value, so the versions can be returned to the user.
+func ListEnvironmentGroupVersions(ctx context.Context, a *kubernetes.Agent, environmentGroupName string) ([]EnvironmentGroup, error) {
+	ctx, span := telemetry.NewSpan(ctx, "list-environment-group-versions")
+	defer span.End()
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "environment-group-name", Value: environmentGroupName})
+
+	if environmentGroupName == "" {
+		return nil, telemetry.Error(ctx, span, nil, "environment group name cannot be empty")
+	}
+
+	versions, err := ListEnvironmentGroups(ctx, a, WithEnvironmentGroupName(environmentGroupName), WithNamespace(Namespace_EnvironmentGroups))
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "unable to list base environment group versions")
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version > versions[j].Version
+	})
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "version-count", Value: len(versions)})
+
+	return versions, nil
+}
+
+// baseEnvironmentGroupVersion returns a version of an environment group stored in the porter-env-group namespace.
+// This is a private function because it returns all secret values.
+func baseEnvironmentGroupVersion(ctx context.Context, a *kubernetes.Agent, environmentGroupName string, version int) (EnvironmentGroup, error) {
+	ctx, span := telemetry.NewSpan(ctx, "base-env-group-version-private")
+	defer span.End()
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "environment-group-name", Value: environmentGroupName},
+		telemetry.AttributeKV{Key: "environment-group-version", Value: version},
+	)
+
+	var eg EnvironmentGroup
+
+	if version <= 0 {
+		return eg, telemetry.Error(ctx, span, ErrEnvironmentGroupVersionNotFound, "environment group version must be positive")
+	}
+
+	versions, err := listEnvironmentGroups(ctx, a,
+		WithEnvironmentGroupName(environmentGroupName),
+		WithEnvironmentGroupVersion(version),
+		WithNamespace(Namespace_EnvironmentGroups),
+	)
+	if err != nil {
+		return eg, telemetry.Error(ctx, span, err, "unable to list base environment group versions")
+	}
+
+	for _, v := range versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+
+	return eg, telemetry.Error(ctx, span, ErrEnvironmentGroupVersionNotFound, "environment group version does not exist")
+}
+
+// DiffEnvironmentGroupVersionsInput contains all information required to compare two versions of an environment group
+type DiffEnvironmentGroupVersionsInput struct {
+	// Name is the name of the environment group
+	Name string
+	// FromVersion is the version the changes are computed from
+	FromVersion int
+	// ToVersion is the version the changes are computed to
+	ToVersion int
+}
+
+// DiffEnvironmentGroupVersions returns the changes between two versions of a base environment group. Secret values are
+// compared, but are replaced with a dummy value in the returned diff.
+func DiffEnvironmentGroupVersions(ctx context.Context, a *kubernetes.Agent, inp DiffEnvironmentGroupVersionsInput) (diff.Diff, error) {
+	ctx, span := telemetry.NewSpan(ctx, "diff-environment-group-versions")
+	defer span.End()
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "environment-group-name", Value: inp.Name},
+		telemetry.AttributeKV{Key: "from-version", Value: inp.FromVersion},
+		telemetry.AttributeKV{Key: "to-version", Value: inp.ToVersion},
+	)
+
+	var d diff.Diff
+
+	from, err := baseEnvironmentGroupVersion(ctx, a, inp.Name, inp.FromVersion)
+	if err != nil {
+		return d, telemetry.Error(ctx, span, err, "unable to get from version of environment group")
+	}
+
+	to, err := baseEnvironmentGroupVersion(ctx, a, inp.Name, inp.ToVersion)
+	if err != nil {
+		return d, telemetry.Error(ctx, span, err, "unable to get to version of environment group")
+	}
+
+	d = diff.Compute(
+		diff.Variables{Normal: from.Variables, Secret: from.SecretVariables},
+		diff.Variables{Normal: to.Variables, Secret: to.SecretVariables},
+		true,
+	)
+
+	return d.Redacted(), nil
+}
+
+// RollbackEnvironmentGroupInput contains all information required to roll an environment group back to a previous version
+type RollbackEnvironmentGroupInput struct {
+	// Name is the name of the environment group
+	Name string
+	// Version is the version whose variables are restored
+	Version int
+	// CreatedBy is the author of the rollback, which is recorded on the new version
+	CreatedBy string
+}
+
+// RollbackEnvironmentGroupOutput is the result of rolling an environment group back to a previous version
+type RollbackEnvironmentGroupOutput struct {
+	// Diff is the set of changes made to the latest version of the environment group, with secret values replaced with a dummy value
+	Diff diff.Diff
+	// Version is the latest version of the environment group after the rollback
+	Version int
+	// Changed is whether the rollback created a new version. Rolling back to a version whose variables are identical to the latest version does not create a new version.
+	Changed bool
+}
+
+// RollbackEnvironmentGroup restores the variables of a previous version of a base environment group by creating a new
+// version with the same variables. Versions are never deleted, so a rollback can itself be rolled back.
+func RollbackEnvironmentGroup(ctx context.Context, a *kubernetes.Agent, inp RollbackEnvironmentGroupInput) (RollbackEnvironmentGroupOutput, error) {
+	ctx, span := telemetry.NewSpan(ctx, "rollback-environment-group")
+	defer span.End()
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "environment-group-name", Value: inp.Name},
+		telemetry.AttributeKV{Key: "target-version", Value: inp.Version},
+	)
+
+	var out RollbackEnvironmentGroupOutput
+
+	if inp.Name == "" {
+		return out, telemetry.Error(ctx, span, nil, "environment group name cannot be empty")
+	}
+
+	target, err := baseEnvironmentGroupVersion(ctx, a, inp.Name, inp.Version)
+	if err != nil {
+		return out, telemetry.Error(ctx, span, err, "unable to get target version of environment group")
+	}
+
+	latest, err := latestBaseEnvironmentGroup(ctx, a, inp.Name)
+	if err != nil {
+		return out, telemetry.Error(ctx, span, err, "unable to get latest base environment group by name")
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "latest-version", Value: latest.Version})
+
+	current := diff.Variables{Normal: latest.Variables, Secret: latest.SecretVariables}
+	desired := diff.Variables{Normal: target.Variables, Secret: target.SecretVariables}
+
+	d := diff.Compute(current, desired, true)
+
+	out.Diff = d.Redacted()
+	out.Version = latest.Version
+
+	if d.Empty() {
+		return out, nil
+	}
+
+	result := d.Apply(current)
+
+	labels, err := inheritedLabels(ctx, a, inp.Name, latest.Version, nil)
+	if err != nil {
+		return out, telemetry.Error(ctx, span, err, "unable to get labels of latest base environment group")
+	}
+
+	err = CreateOrUpdateBaseEnvironmentGroup(ctx, a, EnvironmentGroup{
+		Name:            inp.Name,
+		Variables:       result.Normal,
+		SecretVariables: result.Secret,
+		CreatedAtUTC:    time.Now().UTC(),
+		CreatedBy:       inp.CreatedBy,
+	}, labels)
+	if err != nil {
+		return out, telemetry.Error(ctx, span, err, "unable to create new version of environment group")
+	}
+
+	out.Version = latest.Version + 1
+	out.Changed = true
+
+	return out, nil
+}
+
+// RecordEnvironmentGroupAuthorInput contains all information required to record the author of the version of a base environment group created by an update
+type RecordEnvironmentGroupAuthorInput struct {
+	// Name is the name of the environment group
+	Name string
+	// UpdatedAt is the time at which the update which created the version was started
+	UpdatedAt time.Time
+	// Variables are the non-secret values set by the update
+	Variables map[string]string
+	// SecretVariables are the secret values set by the update
+	SecretVariables map[string]string
+	// CreatedBy is the author of the version
+	CreatedBy string
+}
+
+// RecordEnvironmentGroupAuthor records the author of the version of a base environment group which was created by an update outside of this package, such as
+// an update through the cluster control plane, which does not record authors. The author is only recorded on the latest version if it has no author, was created
+// after the update was started and holds the values set by the update, so that a version created by a concurrent update or a version which predates an update
+// which changed nothing is never attributed to the author. The version and whether an author was recorded are returned.
+func RecordEnvironmentGroupAuthor(ctx context.Context, a *kubernetes.Agent, inp RecordEnvironmentGroupAuthorInput) (int, bool, error) {
+	ctx, span := telemetry.NewSpan(ctx, "record-environment-group-author")
+	defer span.End()
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "environment-group-name", Value: inp.Name})
+
+	if inp.Name == "" {
+		return 0, false, telemetry.Error(ctx, span, nil, "environment group name cannot be empty")
+	}
+
+	if inp.CreatedBy == "" {
+		return 0, false, nil
+	}
+
+	latest, err := latestBaseEnvironmentGroup(ctx, a, inp.Name)
+	if err != nil {
+		return 0, false, telemetry.Error(ctx, span, err, "unable to get latest base environment group")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "environment-group-version", Value: latest.Version})
+
+	if latest.Version == 0 || latest.CreatedBy != "" || latest.CreatedAtUTC.Before(inp.UpdatedAt.Truncate(time.Second)) {
+		return latest.Version, false, nil
+	}
+
+	for key, value := range inp.Variables {
+		if current, ok := latest.Variables[key]; !ok || current != value {
+			return latest.Version, false, nil
+		}
+	}
+
+	for key, value := range inp.SecretVariables {
+		if current, ok := latest.SecretVariables[key]; !ok || current != value {
+			return latest.Version, false, nil
+		}
+	}
+
+	listOptions := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s=%d", LabelKey_EnvironmentGroupName, inp.Name, LabelKey_EnvironmentGroupVersion, latest.Version),
+	}
+
+	configMaps, err := a.Clientset.CoreV1().ConfigMaps(Namespace_EnvironmentGroups).List(ctx, listOptions)
+	if err != nil {
+		return latest.Version, false, telemetry.Error(ctx, span, err, "unable to list environment group configmaps")
+	}
+
+	for _, cm := range configMaps.Items {
+		if cm.Annotations == nil {
+			cm.Annotations = make(map[string]string)
+		}
+		cm.Annotations[AnnotationKey_EnvironmentGroupCreatedBy] = inp.CreatedBy
+
+		if _, err := a.Clientset.CoreV1().ConfigMaps(Namespace_EnvironmentGroups).Update(ctx, &cm, metav1.UpdateOptions{}); err != nil { // nolint:gosec
+			return latest.Version, false, telemetry.Error(ctx, span, err, "unable to record author of environment group configmap")
+		}
+	}
+
+	secrets, err := a.Clientset.CoreV1().Secrets(Namespace_EnvironmentGroups).List(ctx, listOptions)
+	if err != nil {
+		return latest.Version, false, telemetry.Error(ctx, span, err, "unable to list environment group secrets")
+	}
+
+	for _, secret := range secrets.Items {
+		if secret.Annotations == nil {
+			secret.Annotations = make(map[string]string)
+		}
+		secret.Annotations[AnnotationKey_EnvironmentGroupCreatedBy] = inp.CreatedBy
+
+		if _, err := a.Clientset.CoreV1().Secrets(Namespace_EnvironmentGroups).Update(ctx, &secret, metav1.UpdateOptions{}); err != nil { // nolint:gosec
+			return latest.Version, false, telemetry.Error(ctx, span, err, "unable to record author of environment group secret")
+		}
+	}
+
+	return latest.Version, true, nil
+}
@@ -0,0 +1,129 @@
+package environment_groups_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// environmentGroupVersion returns the configmap and secret of a version of the "vars" environment group
+func environmentGroupVersion(version int, createdAt time.Time, createdBy string, variables map[string]string, secretVariables map[string]string) []runtime.Object {
+	meta := metav1.ObjectMeta{
+		Name:      "vars." + strconv.Itoa(version),
+		Namespace: environment_groups.Namespace_EnvironmentGroups,
+		Labels: map[string]string{
+			environment_groups.LabelKey_EnvironmentGroupName:    "vars",
+			environment_groups.LabelKey_EnvironmentGroupVersion: strconv.Itoa(version),
+		},
+		CreationTimestamp: metav1.NewTime(createdAt),
+	}
+	if createdBy != "" {
+		meta.Annotations = map[string]string{environment_groups.AnnotationKey_EnvironmentGroupCreatedBy: createdBy}
+	}
+
+	secretData := make(map[string][]byte)
+	for k, v := range secretVariables {
+		secretData[k] = []byte(v)
+	}
+
+	return []runtime.Object{
+		&v1.ConfigMap{ObjectMeta: meta, Data: variables},
+		&v1.Secret{ObjectMeta: *meta.DeepCopy(), Data: secretData},
+	}
+}
+
+func TestRecordEnvironmentGroupAuthor(t *testing.T) {
+	updatedAt := time.Now().UTC()
+	before := updatedAt.Add(-time.Hour)
+	after := updatedAt.Add(time.Second)
+
+	tests := []struct {
+		name     string
+		versions [][]runtime.Object
+		// expectedAuthors maps versions of the environment group to their expected author
+		expectedAuthors map[int]string
+	}{
+		{
+			name: "records the author of the version created by the update",
+			versions: [][]runtime.Object{
+				environmentGroupVersion(1, before, "", map[string]string{"KEY": "old"}, nil),
+				environmentGroupVersion(2, after, "", map[string]string{"KEY": "new"}, map[string]string{"SECRET": "secret"}),
+			},
+			expectedAuthors: map[int]string{1: "", 2: "user@porter.run"},
+		},
+		{
+			name: "does not record the author of a version which predates the update",
+			versions: [][]runtime.Object{
+				environmentGroupVersion(1, before, "", map[string]string{"KEY": "new"}, map[string]string{"SECRET": "secret"}),
+			},
+			expectedAuthors: map[int]string{1: ""},
+		},
+		{
+			name: "does not record the author of a version created by a concurrent update",
+			versions: [][]runtime.Object{
+				environmentGroupVersion(2, after, "", map[string]string{"KEY": "new"}, map[string]string{"SECRET": "secret"}),
+				environmentGroupVersion(3, after, "", map[string]string{"KEY": "other"}, map[string]string{"SECRET": "secret"}),
+			},
+			expectedAuthors: map[int]string{2: "", 3: ""},
+		},
+		{
+			name: "does not overwrite the author of a version",
+			versions: [][]runtime.Object{
+				environmentGroupVersion(2, after, "other@porter.run", map[string]string{"KEY": "new"}, map[string]string{"SECRET": "secret"}),
+			},
+			expectedAuthors: map[int]string{2: "other@porter.run"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var objects []runtime.Object
+			for _, version := range tt.versions {
+				objects = append(objects, version...)
+			}
+
+			agent := &kubernetes.Agent{Clientset: fake.NewSimpleClientset(objects...)}
+
+			_, _, err := environment_groups.RecordEnvironmentGroupAuthor(ctx, agent, environment_groups.RecordEnvironmentGroupAuthorInput{
+				Name:            "vars",
+				UpdatedAt:       updatedAt,
+				Variables:       map[string]string{"KEY": "new"},
+				SecretVariables: map[string]string{"SECRET": "secret"},
+				CreatedBy:       "user@porter.run",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for version, expected := range tt.expectedAuthors {
+				name := "vars." + strconv.Itoa(version)
+
+				cm, err := agent.Clientset.CoreV1().ConfigMaps(environment_groups.Namespace_EnvironmentGroups).Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("unexpected error getting configmap: %v", err)
+				}
+
+				secret, err := agent.Clientset.CoreV1().Secrets(environment_groups.Namespace_EnvironmentGroups).Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("unexpected error getting secret: %v", err)
+				}
+
+				for kind, annotations := range map[string]map[string]string{"configmap": cm.Annotations, "secret": secret.Annotations} {
+					if author := annotations[environment_groups.AnnotationKey_EnvironmentGroupCreatedBy]; author != expected {
+						t.Errorf("expected author %q on the %s of version %d, got %q", expected, kind, version, author)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package environment_groups
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups/diff"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ErrEnvironmentGroupNotFound is returned when variables are imported into an environment group which must already exist but does not
+var ErrEnvironmentGroupNotFound = errors.New("environment group not found")
+
+// ImportVariablesInput contains all information required to import a set of variables into a base environment group
+type ImportVariablesInput struct {
+	// Name is the name of the environment group, which is created if it does not exist unless MustExist is set
+	Name string
+	// MustExist returns ErrEnvironmentGroupNotFound instead of creating the environment group if it does not exist
+	MustExist bool
+	// Values are the imported key/value pairs
+	Values map[string]string
+	// AsSecrets imports the values as secret variables. Otherwise, values are imported as variables, except for keys which are already secret variables of the environment group.
+	AsSecrets bool
+	// Prune removes the variables of the environment group which are not part of the imported values
+	Prune bool
+	// CreatedBy is the author of the new version of the environment group
+	CreatedBy string
+	// AdditionalLabels are added to the configmap and secret of the new version of the environment group, along with the labels of the latest version
+	AdditionalLabels map[string]string
+}
+
+// ImportVariablesOutput is the result of importing a set of variables into a base environment group
+type ImportVariablesOutput struct {
+	// Diff is the set of changes made to the environment group
+	Diff diff.Diff
+	// Version is the latest version of the environment group after the import
+	Version int
+}
+
+// ImportVariables imports a set of key/value pairs into a base environment group. A new version of the environment group is only created if the import changes it.
+func ImportVariables(ctx context.Context, a *kubernetes.Agent, inp ImportVariablesInput) (ImportVariablesOutput, error) {
+	ctx, span := telemetry.NewSpan(ctx, "import-environment-group-variables")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "environment-group-name", Value: inp.Name},
+		telemetry.AttributeKV{Key: "as-secrets", Value: inp.AsSecrets},
+		telemetry.AttributeKV{Key: "prune", Value: inp.Prune},
+		telemetry.AttributeKV{Key: "value-count", Value: len(inp.Values)},
+	)
+
+	var out ImportVariablesOutput
+
+	if inp.Name == "" {
+		return out, telemetry.Error(ctx, span, nil, "environment group name cannot be empty")
+	}
+
+	latest, err := latestBaseEnvironmentGroup(ctx, a, inp.Name)
+	if err != nil {
+		return out, telemetry.Error(ctx, span, err, "unable to get latest base environment group by name")
+	}
+
+	if inp.MustExist && latest.Version == 0 {
+		return out, telemetry.Error(ctx, span, ErrEnvironmentGroupNotFound, "environment group does not exist")
+	}
+
+	current := diff.Variables{
+		Normal: latest.Variables,
+		Secret: latest.SecretVariables,
+	}
+
+	desired := diff.KeepSecrets(current, diff.Variables{Normal: inp.Values})
+	if inp.AsSecrets {
+		desired = diff.Variables{Secret: inp.Values}
+	}
+
+	out.Diff = diff.Compute(current, desired, inp.Prune)
+	out.Version = latest.Version
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "added-count", Value: len(out.Diff.Added)},
+		telemetry.AttributeKV{Key: "changed-count", Value: len(out.Diff.Changed)},
+		telemetry.AttributeKV{Key: "removed-count", Value: len(out.Diff.Removed)},
+	)
+
+	if out.Diff.Empty() {
+		return out, nil
+	}
+
+	result := out.Diff.Apply(current)
+
+	labels, err := inheritedLabels(ctx, a, inp.Name, latest.Version, inp.AdditionalLabels)
+	if err != nil {
+		return out, telemetry.Error(ctx, span, err, "unable to get labels of latest base environment group")
+	}
+
+	err = CreateOrUpdateBaseEnvironmentGroup(ctx, a, EnvironmentGroup{
+		Name:            inp.Name,
+		Variables:       result.Normal,
+		SecretVariables: result.Secret,
+		CreatedAtUTC:    time.Now().UTC(),
+		CreatedBy:       inp.CreatedBy,
+	}, labels)
+	if err != nil {
+		return out, telemetry.Error(ctx, span, err, "unable to create new version of environment group")
+	}
+
+	out.Version = latest.Version + 1
+
+	return out, nil
+}
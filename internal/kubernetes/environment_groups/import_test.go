@@ -0,0 +1,94 @@
+package environment_groups_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestImportVariables(t *testing.T) {
+	tests := []struct {
+		name            string
+		existing        map[string]string
+		mustExist       bool
+		expectedErr     error
+		expectedVersion int
+	}{
+		{
+			name:            "creates a missing environment group",
+			expectedVersion: 1,
+		},
+		{
+			name:        "does not create a missing environment group which must exist",
+			mustExist:   true,
+			expectedErr: environment_groups.ErrEnvironmentGroupNotFound,
+		},
+		{
+			name:            "imports into an existing environment group which must exist",
+			existing:        map[string]string{"KEY": "old"},
+			mustExist:       true,
+			expectedVersion: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			agent := &kubernetes.Agent{Clientset: fake.NewSimpleClientset()}
+
+			if tt.existing != nil {
+				err := environment_groups.CreateOrUpdateBaseEnvironmentGroup(ctx, agent, environment_groups.EnvironmentGroup{
+					Name:      "imported",
+					Variables: tt.existing,
+				}, nil)
+				if err != nil {
+					t.Fatalf("unexpected error creating environment group: %v", err)
+				}
+			}
+
+			out, err := environment_groups.ImportVariables(ctx, agent, environment_groups.ImportVariablesInput{
+				Name:      "imported",
+				MustExist: tt.mustExist,
+				Values:    map[string]string{"KEY": "new"},
+			})
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+				}
+
+				configMaps, err := agent.Clientset.CoreV1().ConfigMaps(environment_groups.Namespace_EnvironmentGroups).List(ctx, metav1.ListOptions{})
+				if err != nil {
+					t.Fatalf("unexpected error listing configmaps: %v", err)
+				}
+
+				if len(configMaps.Items) != 0 {
+					t.Errorf("expected the environment group not to be created, got %d configmaps", len(configMaps.Items))
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if out.Version != tt.expectedVersion {
+				t.Errorf("expected version %d, got %d", tt.expectedVersion, out.Version)
+			}
+
+			latest, err := environment_groups.LatestBaseEnvironmentGroup(ctx, agent, "imported")
+			if err != nil {
+				t.Fatalf("unexpected error getting environment group: %v", err)
+			}
+
+			if latest.Version != tt.expectedVersion || latest.Variables["KEY"] != "new" {
+				t.Errorf("expected version %d with the imported value, got version %d with %v", tt.expectedVersion, latest.Version, latest.Variables)
+			}
+		})
+	}
+}
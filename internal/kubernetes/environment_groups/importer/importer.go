@@ -0,0 +1,125 @@
+// Package importer syncs environment groups with the secrets of their import sources in external secret managers.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/secrets"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// Sync fetches the key/value pairs of the secret of an import source and imports them into the environment group of
+// the source, using an agent of the cluster of the source. The apps linked to the environment group are re-deployed if
+// the sync changed it, unless the source skips redeploys. The result of the sync is recorded on the source, including
+// when the sync fails.
+func Sync(ctx context.Context, conf *config.Config, agent *kubernetes.Agent, source *models.EnvGroupImportSource) (*types.EnvGroupImportSyncResult, error) {
+	ctx, span := telemetry.NewSpan(ctx, "sync-env-group-import-source")
+	defer span.End()
+
+	if source == nil {
+		return nil, telemetry.Error(ctx, span, nil, "import source is nil")
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: source.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: source.ClusterID},
+		telemetry.AttributeKV{Key: "env-group-name", Value: source.EnvGroupName},
+		telemetry.AttributeKV{Key: "provider", Value: string(source.Provider)},
+	)
+
+	syncedAt := time.Now().UTC()
+
+	result, err := sync(ctx, conf, agent, source)
+	if err != nil {
+		if recordErr := conf.Repo.EnvGroupImportSource().RecordEnvGroupImportSourceSync(ctx, source.ID, syncedAt, 0, err.Error()); recordErr != nil {
+			telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "record-error", Value: recordErr.Error()})
+		}
+
+		return nil, telemetry.Error(ctx, span, err, "error syncing import source")
+	}
+
+	result.SyncedAt = syncedAt
+
+	if err := conf.Repo.EnvGroupImportSource().RecordEnvGroupImportSourceSync(ctx, source.ID, syncedAt, result.Version, ""); err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error recording sync of import source")
+	}
+
+	return result, nil
+}
+
+func sync(ctx context.Context, conf *config.Config, agent *kubernetes.Agent, source *models.EnvGroupImportSource) (*types.EnvGroupImportSyncResult, error) {
+	ctx, span := telemetry.NewSpan(ctx, "sync-env-group-import-source-values")
+	defer span.End()
+
+	if agent == nil {
+		return nil, telemetry.Error(ctx, span, nil, "kubernetes agent is nil")
+	}
+
+	src, err := secrets.NewSource(ctx, conf.Repo, source)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error creating secret source")
+	}
+
+	values, err := src.Fetch(ctx)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error fetching secret values")
+	}
+
+	// the first sync of a source may create its environment group, but an environment group which was deleted after
+	// it was synced is not brought back
+	out, err := environment_groups.ImportVariables(ctx, agent, environment_groups.ImportVariablesInput{
+		Name:      source.EnvGroupName,
+		MustExist: source.LastSyncedVersion > 0,
+		Values:    values,
+		AsSecrets: source.AsSecrets,
+		Prune:     source.Prune,
+		CreatedBy: fmt.Sprintf("import-source:%s", source.Provider),
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error importing secret values")
+	}
+
+	added, updated, removed := out.Diff.Keys()
+
+	result := &types.EnvGroupImportSyncResult{
+		EnvGroupName: source.EnvGroupName,
+		Changed:      !out.Diff.Empty(),
+		Version:      out.Version,
+		Added:        added,
+		Updated:      updated,
+		Removed:      removed,
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "changed", Value: result.Changed},
+		telemetry.AttributeKV{Key: "version", Value: result.Version},
+	)
+
+	if !result.Changed || source.SkipRedeploys {
+		return result, nil
+	}
+
+	if conf.ClusterControlPlaneClient == nil {
+		return nil, telemetry.Error(ctx, span, nil, "cluster control plane client is nil, cannot update linked apps")
+	}
+
+	_, err = conf.ClusterControlPlaneClient.UpdateAppsLinkedToEnvGroup(ctx, connect.NewRequest(&porterv1.UpdateAppsLinkedToEnvGroupRequest{
+		ProjectId:    int64(source.ProjectID),
+		ClusterId:    int64(source.ClusterID),
+		EnvGroupName: source.EnvGroupName,
+	}))
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error updating apps linked to env group")
+	}
+
+	return result, nil
+}
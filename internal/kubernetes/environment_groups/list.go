@@ -32,6 +32,10 @@ const (
 
 	// LabelKey_AppName is the label key for the app name
 	LabelKey_AppName = "porter.run/app-name"
+
+	// AnnotationKey_EnvironmentGroupCreatedBy is the annotation key for the author of an environment group version, such as the email of a user.
+	// This is an annotation rather than a label since authors are not valid label values.
+	AnnotationKey_EnvironmentGroupCreatedBy = "porter.run/environment-group-created-by"
 )
 
 // EnvironmentGroup represents a ConfigMap in the porter-env-group namespace
@@ -48,6 +52,9 @@ type EnvironmentGroup struct {
 	SecretVariables map[string]string `json:"secret_variables,omitempty"`
 	// CreatedAt is only used for display purposes and is in UTC Unix time
 	CreatedAtUTC time.Time `json:"created_at,omitempty"`
+	// CreatedBy is the author of the environment group version, which can be found in the annotations (AnnotationKey_EnvironmentGroupCreatedBy) of the ConfigMap.
+	// This is empty for versions which were created without an author.
+	CreatedBy string `json:"created_by,omitempty"`
 	// DefaultAppEnvironment is a boolean value that determines whether or not this environment group is the default environment group for an app
 	DefaultAppEnvironment bool `json:"default_app_environment"`
 }
@@ -179,6 +186,7 @@ func listEnvironmentGroups(ctx context.Context, a *kubernetes.Agent, listOpts ..
 			Variables:             cm.Data,
 			SecretVariables:       envGroupSet[cm.Name].SecretVariables,
 			CreatedAtUTC:          cm.CreationTimestamp.Time.UTC(),
+			CreatedBy:             cm.Annotations[AnnotationKey_EnvironmentGroupCreatedBy],
 			DefaultAppEnvironment: cm.Labels[LabelKey_DefaultAppEnvironment] == "true",
 		}
 	}
@@ -226,6 +234,7 @@ func listEnvironmentGroups(ctx context.Context, a *kubernetes.Agent, listOpts ..
 			SecretVariables:       stringSecret,
 			Variables:             envGroupSet[secret.Name].Variables,
 			CreatedAtUTC:          secret.CreationTimestamp.Time.UTC(),
+			CreatedBy:             secret.Annotations[AnnotationKey_EnvironmentGroupCreatedBy],
 			DefaultAppEnvironment: secret.Labels[LabelKey_DefaultAppEnvironment] == "true",
 		}
 	}
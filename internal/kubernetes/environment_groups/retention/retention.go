@@ -0,0 +1,242 @@
+package retention
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Copy is a versioned copy of an environment group in the namespace of an app. Copies are made of a configmap and a
+// secret which are both named <environment-group-name>.<version>.
+type Copy struct {
+	Namespace    string
+	EnvGroupName string
+	Version      int
+	CreatedAt    time.Time
+}
+
+// Name returns the name of the configmap and the secret of the copy
+func (c Copy) Name() string {
+	return fmt.Sprintf("%s.%d", c.EnvGroupName, c.Version)
+}
+
+// References is the set of copies which are referenced by workloads and app revisions, along with what references
+// them. Referenced copies are never deleted.
+type References struct {
+	// sources maps namespace/name keys to what references them
+	sources map[string]map[string]bool
+}
+
+// NewReferences returns an empty set of references
+func NewReferences() *References {
+	return &References{
+		sources: make(map[string]map[string]bool),
+	}
+}
+
+// Add records that the configmap or secret with a name in a namespace is referenced by source, like
+// "deployment default/web"
+func (r *References) Add(namespace, name, source string) {
+	if name == "" {
+		return
+	}
+
+	k := key(namespace, name)
+
+	if _, ok := r.sources[k]; !ok {
+		r.sources[k] = make(map[string]bool)
+	}
+
+	r.sources[k][source] = true
+}
+
+// AddEnvGroup records that a version of an environment group is referenced in a namespace by source, like
+// "app web (revision 4)"
+func (r *References) AddEnvGroup(namespace, envGroupName string, version int, source string) {
+	if envGroupName == "" || version <= 0 {
+		return
+	}
+
+	r.Add(namespace, Copy{EnvGroupName: envGroupName, Version: version}.Name(), source)
+}
+
+// AddPodSpec records the configmaps and secrets which are loaded by the containers and mounted in the volumes of a pod
+// spec in a namespace as referenced by source
+func (r *References) AddPodSpec(namespace string, spec v1.PodSpec, source string) {
+	containers := make([]v1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	containers = append(containers, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				r.Add(namespace, envFrom.ConfigMapRef.Name, source)
+			}
+
+			if envFrom.SecretRef != nil {
+				r.Add(namespace, envFrom.SecretRef.Name, source)
+			}
+		}
+
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				r.Add(namespace, env.ValueFrom.ConfigMapKeyRef.Name, source)
+			}
+
+			if env.ValueFrom.SecretKeyRef != nil {
+				r.Add(namespace, env.ValueFrom.SecretKeyRef.Name, source)
+			}
+		}
+	}
+
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			r.Add(namespace, volume.ConfigMap.Name, source)
+		}
+
+		if volume.Secret != nil {
+			r.Add(namespace, volume.Secret.SecretName, source)
+		}
+
+		if volume.Projected == nil {
+			continue
+		}
+
+		for _, projection := range volume.Projected.Sources {
+			if projection.ConfigMap != nil {
+				r.Add(namespace, projection.ConfigMap.Name, source)
+			}
+
+			if projection.Secret != nil {
+				r.Add(namespace, projection.Secret.Name, source)
+			}
+		}
+	}
+}
+
+// Sources returns what references the configmap or secret with a name in a namespace, ordered alphabetically
+func (r *References) Sources(namespace, name string) []string {
+	sources := r.sources[key(namespace, name)]
+	res := make([]string, 0, len(sources))
+
+	for source := range sources {
+		res = append(res, source)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// Len returns the number of referenced configmaps and secrets
+func (r *References) Len() int {
+	return len(r.sources)
+}
+
+func key(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// Decision is whether a copy is kept or deleted
+type Decision struct {
+	Copy
+
+	// Reason is why the copy is kept, like "referenced by deployment default/web"
+	Reason string
+}
+
+// Report is the result of evaluating which copies of the environment groups of a cluster are kept and deleted
+type Report struct {
+	Keep   []Decision
+	Delete []Decision
+}
+
+// EvaluateInput contains all information required to evaluate which copies are kept and deleted
+type EvaluateInput struct {
+	// Copies are the versioned copies of environment groups in the namespaces of apps
+	Copies []Copy
+	// References are the copies referenced by workloads and app revisions
+	References *References
+	// LatestVersions maps the names of environment groups to their latest version. Copies of the latest version of an
+	// environment group are kept, since they are reused by the next deploy of every app linked to the environment group.
+	LatestVersions map[string]int
+	// MinAge is the minimum age of the copies which are deleted. Copies are synced before the workloads which load them
+	// are updated, so recent copies may be in use by a deploy which is in progress.
+	MinAge time.Duration
+	// Now is the time the age of copies is computed from
+	Now time.Time
+}
+
+// Evaluate returns which copies are kept and which are deleted. Copies are kept if they are referenced, if they are
+// copies of the latest version of their environment group or if they were created less than MinAge ago. All other
+// copies are deleted. Decisions are ordered by namespace, environment group and version.
+func Evaluate(inp EvaluateInput) Report {
+	report := Report{
+		Keep:   make([]Decision, 0),
+		Delete: make([]Decision, 0),
+	}
+
+	refs := inp.References
+	if refs == nil {
+		refs = NewReferences()
+	}
+
+	copies := make([]Copy, len(inp.Copies))
+	copy(copies, inp.Copies)
+
+	sort.Slice(copies, func(i, j int) bool {
+		if copies[i].Namespace != copies[j].Namespace {
+			return copies[i].Namespace < copies[j].Namespace
+		}
+
+		if copies[i].EnvGroupName != copies[j].EnvGroupName {
+			return copies[i].EnvGroupName < copies[j].EnvGroupName
+		}
+
+		return copies[i].Version < copies[j].Version
+	})
+
+	for _, c := range copies {
+		if sources := refs.Sources(c.Namespace, c.Name()); len(sources) > 0 {
+			reason := "referenced by " + sources[0]
+			if len(sources) > 1 {
+				reason = fmt.Sprintf("referenced by %s and %d more", sources[0], len(sources)-1)
+			}
+
+			report.Keep = append(report.Keep, Decision{
+				Copy:   c,
+				Reason: reason,
+			})
+
+			continue
+		}
+
+		if latest, ok := inp.LatestVersions[c.EnvGroupName]; ok && c.Version >= latest {
+			report.Keep = append(report.Keep, Decision{
+				Copy:   c,
+				Reason: "copy of the latest version of the environment group",
+			})
+
+			continue
+		}
+
+		if !c.CreatedAt.IsZero() && inp.Now.Sub(c.CreatedAt) < inp.MinAge {
+			report.Keep = append(report.Keep, Decision{
+				Copy:   c,
+				Reason: fmt.Sprintf("created less than %s ago", inp.MinAge),
+			})
+
+			continue
+		}
+
+		report.Delete = append(report.Delete, Decision{Copy: c})
+	}
+
+	return report
+}
@@ -0,0 +1,134 @@
+package retention_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups/retention"
+	v1 "k8s.io/api/core/v1"
+)
+
+var podSpec = v1.PodSpec{
+	InitContainers: []v1.Container{
+		{
+			Name: "migrate",
+			EnvFrom: []v1.EnvFromSource{
+				{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "shared.2"}}},
+			},
+		},
+	},
+	Containers: []v1.Container{
+		{
+			Name: "web",
+			EnvFrom: []v1.EnvFromSource{
+				{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "shared.3"}}},
+				{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "shared.3"}}},
+			},
+			Env: []v1.EnvVar{
+				{Name: "PLAIN", Value: "value"},
+				{Name: "DATABASE_URL", ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "database.1"}, Key: "url"},
+				}},
+			},
+		},
+	},
+	Volumes: []v1.Volume{
+		{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "files.4"}}}},
+		{Name: "projected", VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{Sources: []v1.VolumeProjection{
+			{Secret: &v1.SecretProjection{LocalObjectReference: v1.LocalObjectReference{Name: "files.5"}}},
+		}}}},
+	},
+}
+
+func TestAddPodSpec(t *testing.T) {
+	refs := retention.NewReferences()
+	refs.AddPodSpec("default", podSpec, "deployment default/web")
+
+	for _, name := range []string{"shared.2", "shared.3", "database.1", "files.4", "files.5"} {
+		if got := refs.Sources("default", name); !reflect.DeepEqual(got, []string{"deployment default/web"}) {
+			t.Errorf("expected %s to be referenced by deployment default/web, got %v", name, got)
+		}
+	}
+
+	if got := refs.Sources("staging", "shared.3"); len(got) != 0 {
+		t.Errorf("expected references to be scoped to their namespace, got %v", got)
+	}
+
+	if refs.Len() != 5 {
+		t.Errorf("expected 5 references, got %d", refs.Len())
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Now()
+	daysAgo := func(days int) time.Time {
+		return now.Add(-time.Duration(days) * 24 * time.Hour)
+	}
+
+	refs := retention.NewReferences()
+	refs.AddPodSpec("default", podSpec, "deployment default/web")
+	refs.AddEnvGroup("default", "shared", 3, "app web (revision 7)")
+	refs.AddEnvGroup("default", "shared", 1, "app web (revision 5)")
+	refs.AddEnvGroup("default", "shared", 0, "app worker (revision 1)")
+
+	copies := []retention.Copy{
+		{Namespace: "staging", EnvGroupName: "shared", Version: 1, CreatedAt: daysAgo(10)},
+		{Namespace: "default", EnvGroupName: "shared", Version: 4, CreatedAt: daysAgo(1)},
+		{Namespace: "default", EnvGroupName: "shared", Version: 3, CreatedAt: daysAgo(2)},
+		{Namespace: "default", EnvGroupName: "shared", Version: 2, CreatedAt: daysAgo(3)},
+		{Namespace: "default", EnvGroupName: "shared", Version: 1, CreatedAt: daysAgo(4)},
+		{Namespace: "default", EnvGroupName: "shared", Version: 0, CreatedAt: daysAgo(5)},
+		{Namespace: "default", EnvGroupName: "deleted", Version: 7, CreatedAt: daysAgo(5)},
+		{Namespace: "default", EnvGroupName: "deleted", Version: 8, CreatedAt: now.Add(-time.Minute)},
+	}
+
+	report := retention.Evaluate(retention.EvaluateInput{
+		Copies:         copies,
+		References:     refs,
+		LatestVersions: map[string]int{"shared": 4},
+		MinAge:         time.Hour,
+		Now:            now,
+	})
+
+	reasons := make(map[string]string)
+
+	for _, decision := range report.Keep {
+		reasons[decision.Namespace+"/"+decision.Name()] = decision.Reason
+	}
+
+	expected := map[string]string{
+		"default/shared.4":  "copy of the latest version of the environment group",
+		"default/shared.3":  "referenced by app web (revision 7) and 1 more",
+		"default/shared.2":  "referenced by deployment default/web",
+		"default/shared.1":  "referenced by app web (revision 5)",
+		"default/deleted.8": "created less than 1h0m0s ago",
+	}
+
+	if !reflect.DeepEqual(reasons, expected) {
+		t.Fatalf("expected kept copies %v, got %v", expected, reasons)
+	}
+
+	deleted := make([]string, 0)
+
+	for _, decision := range report.Delete {
+		deleted = append(deleted, decision.Namespace+"/"+decision.Name())
+	}
+
+	if !reflect.DeepEqual(deleted, []string{"default/deleted.7", "default/shared.0", "staging/shared.1"}) {
+		t.Fatalf("expected the unreferenced copies to be deleted in order, got %v", deleted)
+	}
+}
+
+func TestEvaluateWithoutReferences(t *testing.T) {
+	report := retention.Evaluate(retention.EvaluateInput{
+		Copies: []retention.Copy{
+			{Namespace: "default", EnvGroupName: "shared", Version: 1},
+		},
+		Now: time.Now(),
+	})
+
+	if len(report.Keep) != 0 || len(report.Delete) != 1 {
+		t.Fatalf("expected the copy to be deleted, got %+v", report)
+	}
+}
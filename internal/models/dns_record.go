@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/porter-dev/porter/api/types"
 	"gorm.io/gorm"
 )
@@ -18,6 +19,12 @@ type DNSRecord struct {
 	Hostname string `json:"hostname"`
 
 	ClusterID uint `json:"cluster_id"`
+
+	// PorterAppName is the name of the app the record was created for, if any
+	PorterAppName string `json:"porter_app_name" gorm:"index"`
+
+	// DeploymentTargetID is the ID of the deployment target of the app the record was created for, if any
+	DeploymentTargetID uuid.UUID `json:"deployment_target_id" gorm:"type:uuid;default:00000000-0000-0000-0000-000000000000;index"`
 }
 
 func (p *DNSRecord) ToDNSRecordType() *types.DNSRecord {
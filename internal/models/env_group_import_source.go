@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// EnvGroupImportSource is an external secret whose key/value pairs are synced into an environment group
+type EnvGroupImportSource struct {
+	gorm.Model
+
+	ProjectID    uint   `gorm:"uniqueIndex:idx_env_group_import_source"`
+	ClusterID    uint   `gorm:"uniqueIndex:idx_env_group_import_source"`
+	EnvGroupName string `gorm:"uniqueIndex:idx_env_group_import_source"`
+
+	Provider types.EnvGroupImportProvider
+	Path     string
+
+	AWSIntegrationID uint
+	GCPIntegrationID uint
+	VaultAddress     string
+
+	// VaultToken is the token used to read the secret from Vault, encrypted at rest
+	VaultToken []byte
+
+	AsSecrets           bool
+	Prune               bool
+	SkipRedeploys       bool
+	SyncIntervalMinutes uint
+
+	LastSyncedAt      *time.Time
+	LastSyncError     string
+	LastSyncedVersion int
+}
+
+// SyncDue returns whether a scheduled sync of the source is due at the given time
+func (s *EnvGroupImportSource) SyncDue(now time.Time) bool {
+	if s.SyncIntervalMinutes == 0 {
+		return false
+	}
+
+	if s.LastSyncedAt == nil {
+		return true
+	}
+
+	return !now.Before(s.LastSyncedAt.Add(time.Duration(s.SyncIntervalMinutes) * time.Minute))
+}
+
+// ToEnvGroupImportSourceType generates an external types.EnvGroupImportSource to be shared over REST. The Vault token
+// is never returned.
+func (s *EnvGroupImportSource) ToEnvGroupImportSourceType() *types.EnvGroupImportSource {
+	return &types.EnvGroupImportSource{
+		ID:                  s.ID,
+		EnvGroupName:        s.EnvGroupName,
+		Provider:            s.Provider,
+		Path:                s.Path,
+		AWSIntegrationID:    s.AWSIntegrationID,
+		GCPIntegrationID:    s.GCPIntegrationID,
+		VaultAddress:        s.VaultAddress,
+		AsSecrets:           s.AsSecrets,
+		Prune:               s.Prune,
+		SkipRedeploys:       s.SkipRedeploys,
+		SyncIntervalMinutes: s.SyncIntervalMinutes,
+		LastSyncedAt:        s.LastSyncedAt,
+		LastSyncError:       s.LastSyncError,
+		LastSyncedVersion:   s.LastSyncedVersion,
+	}
+}
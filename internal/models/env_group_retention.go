@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// EnvGroupRetentionPolicy is the retention policy of the versioned copies of environment groups which are synced to
+// the namespaces of the apps of a project
+type EnvGroupRetentionPolicy struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"uniqueIndex"`
+
+	// KeepLastRevisions is the number of most recent revisions of every app whose environment group versions are kept
+	KeepLastRevisions uint
+
+	// Enabled is whether the garbage collector deletes the copies which are not kept by the policy
+	Enabled bool
+
+	LastRunAt        *time.Time
+	LastDeletedCount uint
+}
+
+// ToEnvGroupRetentionPolicyType generates an external types.EnvGroupRetentionPolicy to be shared over REST
+func (p *EnvGroupRetentionPolicy) ToEnvGroupRetentionPolicyType() *types.EnvGroupRetentionPolicy {
+	return &types.EnvGroupRetentionPolicy{
+		KeepLastRevisions: p.KeepLastRevisions,
+		Enabled:           p.Enabled,
+		LastRunAt:         p.LastRunAt,
+		LastDeletedCount:  p.LastDeletedCount,
+	}
+}
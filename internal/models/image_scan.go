@@ -0,0 +1,93 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ImageScan is the latest result of a scan of an image by a scanner. Results are stored by the digest of the image,
+// since the tag of an image can be moved to another image.
+type ImageScan struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"uniqueIndex:idx_image_scan_digest"`
+
+	// Repository is the URI of the image repository, like 123456789.dkr.ecr.us-west-2.amazonaws.com/web
+	Repository string `gorm:"uniqueIndex:idx_image_scan_digest"`
+	Digest     string `gorm:"uniqueIndex:idx_image_scan_digest"`
+
+	// Scanner is the name of the scanner, like "trivy" or "ecr"
+	Scanner string `gorm:"uniqueIndex:idx_image_scan_digest"`
+
+	// Tag is the tag of the image when it was last scanned
+	Tag string
+
+	Critical uint
+	High     uint
+	Medium   uint
+	Low      uint
+	Unknown  uint
+
+	ScannedAt time.Time
+
+	// Vulnerabilities is a JSON array of the types.Vulnerability found by the scan
+	Vulnerabilities []byte
+}
+
+// GetVulnerabilities returns the vulnerabilities found by the scan
+func (s *ImageScan) GetVulnerabilities() ([]types.Vulnerability, error) {
+	vulns := make([]types.Vulnerability, 0)
+
+	if len(s.Vulnerabilities) == 0 {
+		return vulns, nil
+	}
+
+	err := json.Unmarshal(s.Vulnerabilities, &vulns)
+	if err != nil {
+		return nil, err
+	}
+
+	return vulns, nil
+}
+
+// ToVulnerabilitySummaryType generates an external types.VulnerabilitySummary to be shared over REST
+func (s *ImageScan) ToVulnerabilitySummaryType() *types.VulnerabilitySummary {
+	scannedAt := s.ScannedAt
+
+	return &types.VulnerabilitySummary{
+		Scanner:   s.Scanner,
+		Critical:  s.Critical,
+		High:      s.High,
+		Medium:    s.Medium,
+		Low:       s.Low,
+		Unknown:   s.Unknown,
+		ScannedAt: &scannedAt,
+	}
+}
+
+// ToImageScanType generates an external types.ImageScan to be shared over REST
+func (s *ImageScan) ToImageScanType() *types.ImageScan {
+	vulns, _ := s.GetVulnerabilities()
+
+	return &types.ImageScan{
+		Repository:      s.Repository,
+		Digest:          s.Digest,
+		Tag:             s.Tag,
+		Summary:         *s.ToVulnerabilitySummaryType(),
+		Vulnerabilities: vulns,
+	}
+}
+
+// ImageScanPolicy is the severity gate of a project, which blocks updating the image of an app to an image with
+// vulnerabilities at or above the severity threshold
+type ImageScanPolicy struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"uniqueIndex"`
+
+	// SeverityThreshold is one of CRITICAL, HIGH, MEDIUM or LOW, or empty if updates are not gated
+	SeverityThreshold string
+}
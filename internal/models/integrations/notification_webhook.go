@@ -0,0 +1,49 @@
+package integrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// NotificationWebhookIntegration is a notifier which posts deployment and incident notifications
+// to a generic HTTP receiver or a Microsoft Teams incoming webhook.
+type NotificationWebhookIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this webhook
+	UserID uint `json:"user_id"`
+
+	// The project that this integration belongs to
+	ProjectID uint `json:"project_id"`
+
+	// The display name of the webhook
+	Name string
+
+	// The format in which notifications are sent to the webhook
+	Kind types.NotificationWebhookKind
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The webhook to call
+	Webhook []byte
+
+	// The secret used to sign the payloads sent to a generic webhook
+	SigningSecret []byte
+
+	// NotificationConfigID is the ID of the notification config to use
+	NotificationConfigID uint `gorm:"default:0"`
+}
+
+func (n *NotificationWebhookIntegration) ToNotificationWebhookIntegrationType() *types.NotificationWebhookIntegration {
+	return &types.NotificationWebhookIntegration{
+		ID:                   n.ID,
+		ProjectID:            n.ProjectID,
+		Name:                 n.Name,
+		Kind:                 n.Kind,
+		Signed:               len(n.SigningSecret) > 0,
+		NotificationConfigID: n.NotificationConfigID,
+	}
+}
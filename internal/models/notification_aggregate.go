@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationAggregate collapses identical app notifications, which share the app revision, service
+// and error code, into a single record. At most one aggregate per key is unresolved at a time.
+type NotificationAggregate struct {
+	gorm.Model
+
+	ProjectID     uint   `gorm:"uniqueIndex:idx_notification_aggregate_key,where:resolved_at IS NULL"`
+	AppRevisionID string `gorm:"uniqueIndex:idx_notification_aggregate_key"`
+	ServiceName   string `gorm:"uniqueIndex:idx_notification_aggregate_key"`
+	ErrorCode     int    `gorm:"uniqueIndex:idx_notification_aggregate_key"`
+
+	AppName            string
+	DeploymentTargetID string
+
+	// Scope is the scope of the collapsed notifications
+	Scope string
+
+	// Summary is the summary of the latest occurrence
+	Summary string
+
+	// Occurrences is the number of occurrences since the aggregate was created
+	Occurrences uint
+
+	// PendingOccurrences is the number of occurrences which have not been reported yet
+	PendingOccurrences uint
+
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time `gorm:"index"`
+
+	// WindowSeconds is the length of the window in which repeats are collapsed
+	WindowSeconds uint
+
+	// WindowEndsAt is the time at which the pending occurrences are reported
+	WindowEndsAt time.Time
+
+	ResolvedAt *time.Time
+}
+
+// NotificationDigestSetting is the opt-in of a project to the daily digest email of its app notifications.
+// Projects without a setting do not receive the digest.
+type NotificationDigestSetting struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"uniqueIndex"`
+
+	Enabled bool
+}
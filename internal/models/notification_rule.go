@@ -0,0 +1,139 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// NotificationRule routes the notifications of a project which match it to a destination channel
+type NotificationRule struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+
+	Name string
+
+	// Priority orders the evaluation of the rules of a project, lowest first
+	Priority int
+
+	Disabled bool
+
+	// AppName, DeploymentTargetID and ServiceName match notifications exactly. Empty values match any notification.
+	AppName            string
+	DeploymentTargetID string
+	ServiceName        string
+
+	// ErrorCodes is a comma-separated list of Porter error codes. Empty matches any error code.
+	ErrorCodes string
+
+	// Scopes is a comma-separated list of notification scopes. Empty matches any scope.
+	Scopes string
+
+	DestinationKind          types.NotificationRuleDestinationKind
+	DestinationIntegrationID uint
+
+	ThrottleWindowSeconds uint
+
+	// QuietHoursStart and QuietHoursEnd are formatted as "HH:MM" in QuietHoursTimezone. Empty
+	// values disable quiet hours.
+	QuietHoursStart    string
+	QuietHoursEnd      string
+	QuietHoursTimezone string
+}
+
+// GetErrorCodes returns the error codes matched by the rule
+func (r *NotificationRule) GetErrorCodes() []int {
+	res := make([]int, 0)
+
+	for _, code := range strings.Split(r.ErrorCodes, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(code)); err == nil {
+			res = append(res, n)
+		}
+	}
+
+	return res
+}
+
+// SetErrorCodes sets the error codes matched by the rule
+func (r *NotificationRule) SetErrorCodes(codes []int) {
+	strs := make([]string, 0, len(codes))
+
+	for _, code := range codes {
+		strs = append(strs, strconv.Itoa(code))
+	}
+
+	r.ErrorCodes = strings.Join(strs, ",")
+}
+
+// GetScopes returns the notification scopes matched by the rule
+func (r *NotificationRule) GetScopes() []types.NotificationRuleScope {
+	res := make([]types.NotificationRuleScope, 0)
+
+	for _, scope := range strings.Split(r.Scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			res = append(res, types.NotificationRuleScope(scope))
+		}
+	}
+
+	return res
+}
+
+// SetScopes sets the notification scopes matched by the rule
+func (r *NotificationRule) SetScopes(scopes []types.NotificationRuleScope) {
+	strs := make([]string, 0, len(scopes))
+
+	for _, scope := range scopes {
+		strs = append(strs, string(scope))
+	}
+
+	r.Scopes = strings.Join(strs, ",")
+}
+
+// ToNotificationRuleType generates an external types.NotificationRule to be shared over REST
+func (r *NotificationRule) ToNotificationRuleType() *types.NotificationRule {
+	res := &types.NotificationRule{
+		ID:        r.ID,
+		ProjectID: r.ProjectID,
+		Name:      r.Name,
+		Priority:  r.Priority,
+		Disabled:  r.Disabled,
+		Match: types.NotificationRuleMatch{
+			AppName:            r.AppName,
+			DeploymentTargetID: r.DeploymentTargetID,
+			ServiceName:        r.ServiceName,
+			ErrorCodes:         r.GetErrorCodes(),
+			Scopes:             r.GetScopes(),
+		},
+		Destination: types.NotificationRuleDestination{
+			Kind:          r.DestinationKind,
+			IntegrationID: r.DestinationIntegrationID,
+		},
+		ThrottleWindowSeconds: r.ThrottleWindowSeconds,
+		CreatedAt:             r.CreatedAt,
+		UpdatedAt:             r.UpdatedAt,
+	}
+
+	if r.QuietHoursStart != "" && r.QuietHoursEnd != "" {
+		res.QuietHours = &types.NotificationRuleQuietHours{
+			Start:    r.QuietHoursStart,
+			End:      r.QuietHoursEnd,
+			Timezone: r.QuietHoursTimezone,
+		}
+	}
+
+	return res
+}
+
+// NotificationRuleThrottle records when a rule last let through a notification for a given key
+type NotificationRuleThrottle struct {
+	gorm.Model
+
+	NotificationRuleID uint   `gorm:"uniqueIndex:idx_notification_rule_throttle_key"`
+	Key                string `gorm:"uniqueIndex:idx_notification_rule_throttle_key"`
+
+	LastNotifiedAt time.Time
+}
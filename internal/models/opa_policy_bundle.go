@@ -0,0 +1,90 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// OPAPolicyBundle is a version of a collection of OPA policies uploaded by a project, which the recommender evaluates
+// alongside the built-in policies. The latest version of each bundle is evaluated.
+type OPAPolicyBundle struct {
+	gorm.Model
+
+	ProjectID uint   `gorm:"uniqueIndex:idx_opa_policy_bundle_version"`
+	Name      string `gorm:"uniqueIndex:idx_opa_policy_bundle_version"`
+	Version   uint   `gorm:"uniqueIndex:idx_opa_policy_bundle_version"`
+
+	// Config is the config file of the bundle, listing its policy collections
+	Config []byte
+
+	// Policies is a JSON object of the policy paths referenced by the config to rego modules
+	Policies []byte
+
+	// Collections is a comma-separated list of the names of the compiled policy collections
+	Collections string
+}
+
+// GetPolicies returns the rego modules of the bundle by path
+func (b *OPAPolicyBundle) GetPolicies() (map[string]string, error) {
+	policies := make(map[string]string)
+
+	if len(b.Policies) == 0 {
+		return policies, nil
+	}
+
+	err := json.Unmarshal(b.Policies, &policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// GetCollections returns the names of the compiled policy collections of the bundle
+func (b *OPAPolicyBundle) GetCollections() []string {
+	res := make([]string, 0)
+
+	for _, collection := range strings.Split(b.Collections, ",") {
+		if collection != "" {
+			res = append(res, collection)
+		}
+	}
+
+	return res
+}
+
+// ToOPAPolicyBundleType generates an external types.OPAPolicyBundle to be shared over REST. The config and policies
+// are only included if withSource is true.
+func (b *OPAPolicyBundle) ToOPAPolicyBundleType(withSource bool) *types.OPAPolicyBundle {
+	res := &types.OPAPolicyBundle{
+		ID:          b.ID,
+		ProjectID:   b.ProjectID,
+		Name:        b.Name,
+		Version:     b.Version,
+		CreatedAt:   b.CreatedAt,
+		Collections: b.GetCollections(),
+	}
+
+	if withSource {
+		res.Config = string(b.Config)
+		res.Policies, _ = b.GetPolicies()
+	}
+
+	return res
+}
+
+// OPAPolicyOverride overrides the enforcement of a policy collection for the apps of a project
+type OPAPolicyOverride struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+
+	// Collection is the name of the overridden policy collection, like "<bundle name>/<collection name>"
+	Collection string
+
+	// Enforcement is one of "warn", "deny" or "off"
+	Enforcement string
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// RegistryRetentionPolicy is the retention policy of an image repository of a registry
+type RegistryRetentionPolicy struct {
+	gorm.Model
+
+	ProjectID  uint   `gorm:"uniqueIndex:idx_registry_retention_policy"`
+	RegistryID uint   `gorm:"uniqueIndex:idx_registry_retention_policy"`
+	RepoName   string `gorm:"uniqueIndex:idx_registry_retention_policy"`
+
+	// KeepLast is the number of most recently pushed images to keep
+	KeepLast uint
+
+	// KeepTagPattern is a regular expression for the tags of images to keep
+	KeepTagPattern string
+
+	// Enabled is whether the garbage collector deletes the images which are not kept by the policy
+	Enabled bool
+
+	LastRunAt        *time.Time
+	LastDeletedCount uint
+}
+
+// ToRegistryRetentionPolicyType generates an external types.RegistryRetentionPolicy to be shared over REST
+func (p *RegistryRetentionPolicy) ToRegistryRetentionPolicyType() *types.RegistryRetentionPolicy {
+	return &types.RegistryRetentionPolicy{
+		ID:               p.ID,
+		RegistryID:       p.RegistryID,
+		RepoName:         p.RepoName,
+		KeepLast:         p.KeepLast,
+		KeepTagPattern:   p.KeepTagPattern,
+		Enabled:          p.Enabled,
+		LastRunAt:        p.LastRunAt,
+		LastDeletedCount: p.LastDeletedCount,
+	}
+}
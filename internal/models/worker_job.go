@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WorkerJobStatus is the status of a job persisted by the workers binary
+type WorkerJobStatus string
+
+const (
+	// WorkerJobStatus_Queued is the status for a job that is waiting to be leased by a worker
+	WorkerJobStatus_Queued WorkerJobStatus = "QUEUED"
+	// WorkerJobStatus_Running is the status for a job that has been leased by a worker
+	WorkerJobStatus_Running WorkerJobStatus = "RUNNING"
+	// WorkerJobStatus_Retrying is the status for a job that failed and is waiting for its next attempt
+	WorkerJobStatus_Retrying WorkerJobStatus = "RETRYING"
+	// WorkerJobStatus_Succeeded is the status for a job that completed without error
+	WorkerJobStatus_Succeeded WorkerJobStatus = "SUCCEEDED"
+	// WorkerJobStatus_Dead is the status for a job that exhausted all of its attempts. These jobs make up the dead-letter list
+	WorkerJobStatus_Dead WorkerJobStatus = "DEAD"
+	// WorkerJobStatus_Canceled is the status for a job that was canceled before it finished
+	WorkerJobStatus_Canceled WorkerJobStatus = "CANCELED"
+)
+
+// WorkerJob is a database model that represents a single run of a job in the workers binary
+type WorkerJob struct {
+	gorm.Model
+
+	// ID is a uuid that references the job run
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+
+	// JobID is the string identifier of the job to run, such as "recommender"
+	JobID string `json:"job_id" gorm:"index"`
+
+	// Input is the JSON payload that was sent when the job was enqueued
+	Input []byte `json:"input"`
+
+	// Status is the current state of the job run
+	Status WorkerJobStatus `json:"status" gorm:"index"`
+
+	// Attempts is the number of times this job has been leased by a worker
+	Attempts int `json:"attempts"`
+
+	// MaxAttempts is the number of attempts after which a failing job is moved to the dead-letter list
+	MaxAttempts int `json:"max_attempts"`
+
+	// RunAfter is the time (UTC) before which the job should not be leased
+	RunAfter time.Time `json:"run_after" gorm:"index"`
+
+	// LeasedBy is the UUID of the worker which currently holds the job
+	LeasedBy string `json:"leased_by"`
+
+	// LeasedAt is the time (UTC) that the job was last leased by a worker
+	LeasedAt *time.Time `json:"leased_at"`
+
+	// StartedAt is the time (UTC) that the most recent attempt of the job started
+	StartedAt *time.Time `json:"started_at"`
+
+	// FinishedAt is the time (UTC) that the most recent attempt of the job finished
+	FinishedAt *time.Time `json:"finished_at"`
+
+	// LastError is the error returned by the most recent failed attempt
+	LastError string `json:"last_error"`
+
+	// Logs is the log output captured during the most recent attempt
+	Logs string `json:"logs" gorm:"type:text"`
+
+	// Checkpoint is the progress saved by the job while running, which lets an attempt resume where
+	// a previous interrupted attempt left off. It is cleared once the job succeeds
+	Checkpoint []byte `json:"checkpoint"`
+}
+
+// IsFinished returns true if the job will not be run again
+func (j *WorkerJob) IsFinished() bool {
+	switch j.Status {
+	case WorkerJobStatus_Succeeded, WorkerJobStatus_Dead, WorkerJobStatus_Canceled:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WorkerJobSchedule is a database model that tracks the cron schedule of a job in the workers binary. It is
+// shared by all replicas of the workers binary so that each scheduled run is only enqueued once.
+type WorkerJobSchedule struct {
+	gorm.Model
+
+	// JobID is the string identifier of the scheduled job, such as "recommender"
+	JobID string `json:"job_id" gorm:"uniqueIndex"`
+
+	// LastScheduledAt is the most recent schedule time (UTC) that was claimed by a replica
+	LastScheduledAt *time.Time `json:"last_scheduled_at"`
+
+	// LastRunID is the ID of the most recent job run enqueued by the schedule. Ticks are skipped, and do not
+	// change this ID, while a previous run of the job is still in flight.
+	LastRunID uuid.UUID `json:"last_run_id" gorm:"type:uuid"`
+}
@@ -0,0 +1,107 @@
+package notifier
+
+import "time"
+
+// AggregateOpts describes a set of identical app notifications which were collapsed into a single
+// message. Notifications are identical if they share the app revision, service and error code.
+type AggregateOpts struct {
+	ProjectID uint
+
+	AppName            string
+	DeploymentTargetID string
+	AppRevisionID      string
+	ServiceName        string
+	ErrorCode          int
+
+	// Summary is the summary of the latest occurrence
+	Summary string
+
+	// Occurrences is the number of occurrences since the last message was sent
+	Occurrences uint
+
+	// TotalOccurrences is the number of occurrences since the condition was first seen
+	TotalOccurrences uint
+
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+
+	URL string
+}
+
+// AggregateNotifier sends the messages of collapsed app notifications
+type AggregateNotifier interface {
+	// NotifyRepeated is sent at the end of a window in which a notification repeated
+	NotifyRepeated(opts *AggregateOpts) error
+
+	// NotifyResolved is sent once a notification has not repeated for a while
+	NotifyResolved(opts *AggregateOpts) error
+}
+
+type MultiAggregateNotifier struct {
+	notifiers []AggregateNotifier
+}
+
+func NewMultiAggregateNotifier(notifiers ...AggregateNotifier) AggregateNotifier {
+	return &MultiAggregateNotifier{notifiers}
+}
+
+func (m *MultiAggregateNotifier) NotifyRepeated(opts *AggregateOpts) error {
+	var firstErr error
+
+	for _, n := range m.notifiers {
+		if err := n.NotifyRepeated(opts); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (m *MultiAggregateNotifier) NotifyResolved(opts *AggregateOpts) error {
+	var firstErr error
+
+	for _, n := range m.notifiers {
+		if err := n.NotifyResolved(opts); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// Digest summarizes the app notifications of a project over a period of time
+type Digest struct {
+	ProjectID   uint
+	ProjectName string
+
+	Since time.Time
+	Until time.Time
+
+	TotalOccurrences uint
+
+	// Entries are ordered by number of occurrences, highest first
+	Entries []*DigestEntry
+
+	URL string
+}
+
+// DigestEntry is a single collapsed notification of a digest
+type DigestEntry struct {
+	AppName       string
+	AppRevisionID string
+	ServiceName   string
+	ErrorCode     int
+	Summary       string
+
+	Occurrences uint
+
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+
+	Resolved bool
+}
+
+// DigestNotifier sends notification digests
+type DigestNotifier interface {
+	NotifyDigest(digest *Digest) error
+}
@@ -0,0 +1,187 @@
+package dedup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// ReasonCollapsed explains why a repeated notification is dropped
+const ReasonCollapsed = "collapsed into an earlier notification"
+
+// Occurrence is a single app notification. Occurrences with the same project, app revision,
+// service and error code are collapsed together.
+type Occurrence struct {
+	ProjectID uint
+
+	AppName            string
+	DeploymentTargetID string
+	AppRevisionID      string
+	ServiceName        string
+	ErrorCode          int
+	Scope              string
+
+	Summary string
+
+	Timestamp time.Time
+}
+
+// Aggregator collapses repeated app notifications. The first occurrence of a notification is
+// delivered immediately, while repeats within the window are counted and later reported in a
+// single message by a Flusher.
+type Aggregator struct {
+	repo   repository.NotificationAggregateRepository
+	window time.Duration
+	now    func() time.Time
+}
+
+func NewAggregator(repo repository.NotificationAggregateRepository, window time.Duration) *Aggregator {
+	return &Aggregator{
+		repo:   repo,
+		window: window,
+		now:    time.Now,
+	}
+}
+
+// Record counts the occurrence and returns true if it should be delivered. A zero window
+// delivers every occurrence.
+func (a *Aggregator) Record(ctx context.Context, occurrence Occurrence) (*models.NotificationAggregate, bool, error) {
+	if a.window <= 0 {
+		return nil, true, nil
+	}
+
+	at := occurrence.Timestamp
+
+	if at.IsZero() {
+		at = a.now()
+	}
+
+	aggregate, created, err := a.repo.RecordOccurrence(ctx, &models.NotificationAggregate{
+		ProjectID:          occurrence.ProjectID,
+		AppName:            occurrence.AppName,
+		DeploymentTargetID: occurrence.DeploymentTargetID,
+		AppRevisionID:      occurrence.AppRevisionID,
+		ServiceName:        occurrence.ServiceName,
+		ErrorCode:          occurrence.ErrorCode,
+		Scope:              occurrence.Scope,
+		Summary:            occurrence.Summary,
+	}, at, a.window)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return aggregate, created, nil
+}
+
+// NotifierFunc returns the notifier for the messages of an aggregate
+type NotifierFunc func(ctx context.Context, aggregate *models.NotificationAggregate) (notifier.AggregateNotifier, error)
+
+// Flusher reports the repeats collapsed by an Aggregator, and sends a resolved follow-up once a
+// notification has stopped repeating
+type Flusher struct {
+	repo         repository.NotificationAggregateRepository
+	notifierFor  NotifierFunc
+	resolveAfter time.Duration
+	serverURL    string
+	now          func() time.Time
+}
+
+func NewFlusher(
+	repo repository.NotificationAggregateRepository,
+	notifierFor NotifierFunc,
+	resolveAfter time.Duration,
+	serverURL string,
+) *Flusher {
+	return &Flusher{
+		repo:         repo,
+		notifierFor:  notifierFor,
+		resolveAfter: resolveAfter,
+		serverURL:    serverURL,
+		now:          time.Now,
+	}
+}
+
+// Flush sends the messages of every due aggregate. Aggregates are updated even if their messages
+// fail to send, so that a failing channel does not receive the same message twice. The first
+// error is returned.
+func (f *Flusher) Flush(ctx context.Context) error {
+	now := f.now().UTC()
+
+	aggregates, err := f.repo.ListDueNotificationAggregates(ctx, now, now.Add(-f.resolveAfter))
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+
+	for _, aggregate := range aggregates {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := f.flush(ctx, aggregate, now); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (f *Flusher) flush(ctx context.Context, aggregate *models.NotificationAggregate, now time.Time) error {
+	n, err := f.notifierFor(ctx, aggregate)
+	if err != nil {
+		return err
+	}
+
+	resolved := !aggregate.LastSeenAt.After(now.Add(-f.resolveAfter))
+
+	var sendErr error
+
+	// pending repeats are reported before the resolved follow-up, even if their window is still open
+	if aggregate.PendingOccurrences > 0 && (resolved || !aggregate.WindowEndsAt.After(now)) {
+		sendErr = n.NotifyRepeated(f.toAggregateOpts(aggregate))
+
+		aggregate.PendingOccurrences = 0
+		aggregate.WindowEndsAt = now.Add(time.Duration(aggregate.WindowSeconds) * time.Second)
+	}
+
+	if resolved {
+		if err := n.NotifyResolved(f.toAggregateOpts(aggregate)); err != nil && sendErr == nil {
+			sendErr = err
+		}
+
+		aggregate.ResolvedAt = &now
+	}
+
+	if _, err := f.repo.UpdateNotificationAggregate(ctx, aggregate); err != nil {
+		return err
+	}
+
+	return sendErr
+}
+
+func (f *Flusher) toAggregateOpts(aggregate *models.NotificationAggregate) *notifier.AggregateOpts {
+	return &notifier.AggregateOpts{
+		ProjectID:          aggregate.ProjectID,
+		AppName:            aggregate.AppName,
+		DeploymentTargetID: aggregate.DeploymentTargetID,
+		AppRevisionID:      aggregate.AppRevisionID,
+		ServiceName:        aggregate.ServiceName,
+		ErrorCode:          aggregate.ErrorCode,
+		Summary:            aggregate.Summary,
+		Occurrences:        aggregate.PendingOccurrences,
+		TotalOccurrences:   aggregate.Occurrences,
+		FirstSeenAt:        aggregate.FirstSeenAt,
+		LastSeenAt:         aggregate.LastSeenAt,
+		URL:                AppURL(f.serverURL, aggregate.ProjectID, aggregate.AppName),
+	}
+}
+
+// AppURL links to an app in the Porter dashboard
+func AppURL(serverURL string, projectID uint, appName string) string {
+	return fmt.Sprintf("%s/apps/%s?project_id=%d", serverURL, appName, projectID)
+}
@@ -0,0 +1,163 @@
+package dedup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type memoryAggregateRepository struct {
+	repository.NotificationAggregateRepository
+
+	aggregates []*models.NotificationAggregate
+}
+
+func (m *memoryAggregateRepository) RecordOccurrence(ctx context.Context, occurrence *models.NotificationAggregate, at time.Time, window time.Duration) (*models.NotificationAggregate, bool, error) {
+	for _, aggregate := range m.aggregates {
+		if aggregate.ResolvedAt == nil && aggregate.AppRevisionID == occurrence.AppRevisionID &&
+			aggregate.ServiceName == occurrence.ServiceName && aggregate.ErrorCode == occurrence.ErrorCode {
+			aggregate.Occurrences++
+			aggregate.PendingOccurrences++
+			aggregate.LastSeenAt = at
+
+			return aggregate, false, nil
+		}
+	}
+
+	aggregate := *occurrence
+	aggregate.ID = uint(len(m.aggregates) + 1)
+	aggregate.Occurrences = 1
+	aggregate.FirstSeenAt = at
+	aggregate.LastSeenAt = at
+	aggregate.WindowSeconds = uint(window.Seconds())
+	aggregate.WindowEndsAt = at.Add(window)
+
+	m.aggregates = append(m.aggregates, &aggregate)
+
+	return &aggregate, true, nil
+}
+
+func (m *memoryAggregateRepository) ListDueNotificationAggregates(ctx context.Context, now, resolveBefore time.Time) ([]*models.NotificationAggregate, error) {
+	res := make([]*models.NotificationAggregate, 0)
+
+	for _, aggregate := range m.aggregates {
+		if aggregate.ResolvedAt != nil {
+			continue
+		}
+
+		if (aggregate.PendingOccurrences > 0 && !aggregate.WindowEndsAt.After(now)) || !aggregate.LastSeenAt.After(resolveBefore) {
+			res = append(res, aggregate)
+		}
+	}
+
+	return res, nil
+}
+
+func (m *memoryAggregateRepository) UpdateNotificationAggregate(ctx context.Context, aggregate *models.NotificationAggregate) (*models.NotificationAggregate, error) {
+	return aggregate, nil
+}
+
+type recordingNotifier struct {
+	repeated []uint
+	resolved int
+}
+
+func (r *recordingNotifier) NotifyRepeated(opts *notifier.AggregateOpts) error {
+	r.repeated = append(r.repeated, opts.Occurrences)
+	return nil
+}
+
+func (r *recordingNotifier) NotifyResolved(opts *notifier.AggregateOpts) error {
+	r.resolved++
+	return nil
+}
+
+func TestCollapseRepeatsAndResolve(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	repo := &memoryAggregateRepository{}
+	aggregator := NewAggregator(repo, 10*time.Minute)
+
+	n := &recordingNotifier{}
+	flusher := NewFlusher(repo, func(ctx context.Context, aggregate *models.NotificationAggregate) (notifier.AggregateNotifier, error) {
+		return n, nil
+	}, 30*time.Minute, "https://dashboard.getporter.dev")
+
+	occurrence := Occurrence{ProjectID: 1, AppName: "api", AppRevisionID: "rev-1", ServiceName: "web", ErrorCode: 10}
+
+	// a crash loop produces an occurrence every minute for five minutes
+	for i := 0; i < 5; i++ {
+		occurrence.Timestamp = start.Add(time.Duration(i) * time.Minute)
+
+		_, deliver, err := aggregator.Record(ctx, occurrence)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		if deliver != (i == 0) {
+			t.Errorf("occurrence %d: expected deliver to be %t, got %t", i, i == 0, deliver)
+		}
+	}
+
+	flusher.now = func() time.Time { return start.Add(5 * time.Minute) }
+
+	if err := flusher.Flush(ctx); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(n.repeated) != 0 {
+		t.Fatalf("expected no message before the end of the window, got %v", n.repeated)
+	}
+
+	flusher.now = func() time.Time { return start.Add(10 * time.Minute) }
+
+	if err := flusher.Flush(ctx); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(n.repeated) != 1 || n.repeated[0] != 4 {
+		t.Fatalf("expected a single message for 4 repeats, got %v", n.repeated)
+	}
+
+	// reported repeats are not reported again
+	if err := flusher.Flush(ctx); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(n.repeated) != 1 || n.resolved != 0 {
+		t.Fatalf("expected no further messages, got %v repeated and %d resolved", n.repeated, n.resolved)
+	}
+
+	// the condition clears once no occurrence was seen for 30 minutes
+	flusher.now = func() time.Time { return start.Add(34 * time.Minute) }
+
+	if err := flusher.Flush(ctx); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if n.resolved != 1 || repo.aggregates[0].ResolvedAt == nil {
+		t.Fatalf("expected the aggregate to be resolved")
+	}
+
+	// a new occurrence after resolution is delivered again
+	occurrence.Timestamp = start.Add(time.Hour)
+
+	if _, deliver, err := aggregator.Record(ctx, occurrence); err != nil || !deliver {
+		t.Fatalf("expected occurrence after resolution to be delivered, got %t %v", deliver, err)
+	}
+}
+
+func TestZeroWindowDeliversEveryOccurrence(t *testing.T) {
+	aggregator := NewAggregator(&memoryAggregateRepository{}, 0)
+
+	for i := 0; i < 3; i++ {
+		if _, deliver, err := aggregator.Record(context.Background(), Occurrence{ProjectID: 1}); err != nil || !deliver {
+			t.Fatalf("expected every occurrence to be delivered, got %t %v", deliver, err)
+		}
+	}
+}
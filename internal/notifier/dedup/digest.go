@@ -0,0 +1,45 @@
+package dedup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+// BuildDigest summarizes the aggregates of a project which saw an occurrence between since and until.
+// Aggregates are expected in the order of the digest entries.
+func BuildDigest(
+	project *models.Project,
+	aggregates []*models.NotificationAggregate,
+	since, until time.Time,
+	serverURL string,
+) *notifier.Digest {
+	digest := &notifier.Digest{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		Since:       since,
+		Until:       until,
+		Entries:     make([]*notifier.DigestEntry, 0, len(aggregates)),
+		URL:         fmt.Sprintf("%s/apps?project_id=%d", serverURL, project.ID),
+	}
+
+	for _, aggregate := range aggregates {
+		digest.TotalOccurrences += aggregate.Occurrences
+
+		digest.Entries = append(digest.Entries, &notifier.DigestEntry{
+			AppName:       aggregate.AppName,
+			AppRevisionID: aggregate.AppRevisionID,
+			ServiceName:   aggregate.ServiceName,
+			ErrorCode:     aggregate.ErrorCode,
+			Summary:       aggregate.Summary,
+			Occurrences:   aggregate.Occurrences,
+			FirstSeenAt:   aggregate.FirstSeenAt,
+			LastSeenAt:    aggregate.LastSeenAt,
+			Resolved:      aggregate.ResolvedAt != nil,
+		})
+	}
+
+	return digest
+}
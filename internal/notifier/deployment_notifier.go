@@ -1,11 +1,59 @@
 package notifier
 
-import "time"
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+)
 
 type Notifier interface {
 	Notify(opts *NotifyOpts) error
 }
 
+// MultiDeploymentNotifier sends deployment notifications through all of its notifiers
+type MultiDeploymentNotifier struct {
+	notifiers []Notifier
+}
+
+func NewMultiDeploymentNotifier(notifiers ...Notifier) Notifier {
+	return &MultiDeploymentNotifier{notifiers}
+}
+
+// Notify sends the notification through every notifier, even if some of them fail. The first
+// error is returned.
+func (m *MultiDeploymentNotifier) Notify(opts *NotifyOpts) error {
+	var firstErr error
+
+	for _, n := range m.notifiers {
+		if err := n.Notify(opts); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// IsStatusEnabled returns false if the notification config disables notifications for
+// deployments with the given status. A nil config enables all notifications.
+func IsStatusEnabled(conf *types.NotificationConfig, status DeploymentStatus) bool {
+	if conf == nil {
+		return true
+	}
+
+	if !conf.Enabled {
+		return false
+	}
+
+	switch status {
+	case StatusHelmDeployed:
+		return conf.Success
+	case StatusPodCrashed, StatusHelmFailed:
+		return conf.Failure
+	}
+
+	return true
+}
+
 type DeploymentStatus string
 
 const (
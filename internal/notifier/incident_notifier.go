@@ -23,13 +23,16 @@ func (m *MultiIncidentNotifier) NotifyNew(incident *types.Incident, url string)
 		return nil
 	}
 
+	// a failing notifier should not keep the incident from reaching the others
+	var firstErr error
+
 	for _, n := range m.notifiers {
-		if err := n.NotifyNew(incident, url); err != nil {
-			return err
+		if err := n.NotifyNew(incident, url); err != nil && firstErr == nil {
+			firstErr = err
 		}
 	}
 
-	return nil
+	return firstErr
 }
 
 func (m *MultiIncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
@@ -39,11 +42,14 @@ func (m *MultiIncidentNotifier) NotifyResolved(incident *types.Incident, url str
 		return nil
 	}
 
+	// a failing notifier should not keep the incident from reaching the others
+	var firstErr error
+
 	for _, n := range m.notifiers {
-		if err := n.NotifyResolved(incident, url); err != nil {
-			return err
+		if err := n.NotifyResolved(incident, url); err != nil && firstErr == nil {
+			firstErr = err
 		}
 	}
 
-	return nil
+	return firstErr
 }
@@ -0,0 +1,47 @@
+package routing
+
+import (
+	"context"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/teams"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// AggregateNotifierFor returns a notifier which sends the messages of a collapsed notification to the
+// channels picked by the notification rules of its project. Messages which match no rule are sent to
+// all Slack and notification webhook integrations of the project.
+func AggregateNotifierFor(ctx context.Context, repo repository.Repository, aggregate *models.NotificationAggregate) (notifier.AggregateNotifier, error) {
+	decision, err := NewRouter(repo.NotificationRule()).RouteAggregate(ctx, Event{
+		ProjectID:          aggregate.ProjectID,
+		AppName:            aggregate.AppName,
+		DeploymentTargetID: aggregate.DeploymentTargetID,
+		ServiceName:        aggregate.ServiceName,
+		ErrorCode:          aggregate.ErrorCode,
+		Scope:              types.NotificationRuleScope(aggregate.Scope),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !decision.Deliver {
+		return notifier.NewMultiAggregateNotifier(), nil
+	}
+
+	slackInts, _ := repo.SlackIntegration().ListSlackIntegrationsByProjectID(aggregate.ProjectID)
+	webhookInts, _ := repo.NotificationWebhookIntegration().ListNotificationWebhookIntegrationsByProjectID(aggregate.ProjectID)
+
+	if decision.Rule != nil {
+		slackInts, webhookInts = filterDestination(decision.Rule.DestinationKind, decision.Rule.DestinationIntegrationID, slackInts, webhookInts)
+	}
+
+	return notifier.NewMultiAggregateNotifier(
+		slack.NewAggregateNotifier(slackInts...),
+		webhook.NewAggregateNotifier(webhookInts...),
+		teams.NewAggregateNotifier(webhookInts...),
+	), nil
+}
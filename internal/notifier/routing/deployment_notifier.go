@@ -0,0 +1,88 @@
+package routing
+
+import (
+	"context"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/teams"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// DeploymentNotifier sends legacy deployment notifications to the channels picked by the
+// notification rules of the project. Notifications which match no rule are sent to all Slack
+// and notification webhook integrations of the project.
+type DeploymentNotifier struct {
+	ctx    context.Context
+	repo   repository.Repository
+	router *Router
+	Config *types.NotificationConfig
+}
+
+func NewDeploymentNotifier(ctx context.Context, repo repository.Repository, conf *types.NotificationConfig) *DeploymentNotifier {
+	return &DeploymentNotifier{
+		ctx:    ctx,
+		repo:   repo,
+		router: NewRouter(repo.NotificationRule()),
+		Config: conf,
+	}
+}
+
+func (d *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if !notifier.IsStatusEnabled(d.Config, opts.Status) {
+		return nil
+	}
+
+	decision, err := d.router.Route(d.ctx, EventFromNotifyOpts(opts))
+	if err != nil {
+		return err
+	}
+
+	if !decision.Deliver {
+		return nil
+	}
+
+	slackInts, _ := d.repo.SlackIntegration().ListSlackIntegrationsByProjectID(opts.ProjectID)
+	webhookInts, _ := d.repo.NotificationWebhookIntegration().ListNotificationWebhookIntegrationsByProjectID(opts.ProjectID)
+
+	if decision.Rule != nil {
+		slackInts, webhookInts = filterDestination(decision.Rule.DestinationKind, decision.Rule.DestinationIntegrationID, slackInts, webhookInts)
+	}
+
+	return notifier.NewMultiDeploymentNotifier(
+		slack.NewDeploymentNotifier(d.Config, slackInts...),
+		webhook.NewDeploymentNotifier(d.Config, webhookInts...),
+		teams.NewDeploymentNotifier(d.Config, webhookInts...),
+	).Notify(opts)
+}
+
+// filterDestination keeps only the integration a rule routes notifications to
+func filterDestination(
+	kind types.NotificationRuleDestinationKind,
+	id uint,
+	slackInts []*integrations.SlackIntegration,
+	webhookInts []*integrations.NotificationWebhookIntegration,
+) ([]*integrations.SlackIntegration, []*integrations.NotificationWebhookIntegration) {
+	resSlack := make([]*integrations.SlackIntegration, 0)
+	resWebhook := make([]*integrations.NotificationWebhookIntegration, 0)
+
+	switch kind {
+	case types.NotificationRuleDestinationKind_Slack:
+		for _, slackInt := range slackInts {
+			if slackInt.ID == id {
+				resSlack = append(resSlack, slackInt)
+			}
+		}
+	case types.NotificationRuleDestinationKind_Webhook:
+		for _, webhookInt := range webhookInts {
+			if webhookInt.ID == id {
+				resWebhook = append(resWebhook, webhookInt)
+			}
+		}
+	}
+
+	return resSlack, resWebhook
+}
@@ -0,0 +1,243 @@
+package routing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// Event is a notification as seen by the routing rules. It is built from either a v2 app
+// notification or the NotifyOpts of a legacy deployment notification.
+type Event struct {
+	ProjectID uint
+
+	AppName            string
+	DeploymentTargetID string
+	ServiceName        string
+
+	// ErrorCode is the Porter error code of the notification, or zero if it has none
+	ErrorCode int
+
+	Scope types.NotificationRuleScope
+}
+
+// EventFromNotifyOpts builds the routing event of a legacy deployment notification. Legacy
+// notifications are scoped to the application and have no deployment target or error code.
+func EventFromNotifyOpts(opts *notifier.NotifyOpts) Event {
+	return Event{
+		ProjectID: opts.ProjectID,
+		AppName:   opts.Name,
+		Scope:     types.NotificationRuleScope_Application,
+	}
+}
+
+// throttleKey identifies the notifications a rule throttles together
+func (e Event) throttleKey() string {
+	return fmt.Sprintf("%s/%s/%s/%d/%s", e.AppName, e.DeploymentTargetID, e.ServiceName, e.ErrorCode, e.Scope)
+}
+
+// Decision is the outcome of routing an event
+type Decision struct {
+	// Rule is the rule which matched the event, or nil if no rule matched. Events which match no
+	// rule are delivered to every channel of the project.
+	Rule *models.NotificationRule
+
+	// Deliver is false if the event should be dropped
+	Deliver bool
+
+	// Reason explains why the event is dropped
+	Reason string
+}
+
+const (
+	ReasonMuted      = "muted by rule"
+	ReasonQuietHours = "within quiet hours"
+	ReasonThrottled  = "throttled"
+)
+
+// Router evaluates the notification rules of a project
+type Router struct {
+	repo repository.NotificationRuleRepository
+	now  func() time.Time
+}
+
+func NewRouter(repo repository.NotificationRuleRepository) *Router {
+	return &Router{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// Route finds the first enabled rule of the project which matches the event, and decides whether the
+// event should be delivered. A delivered event counts towards the throttle window of the rule.
+func (r *Router) Route(ctx context.Context, event Event) (*Decision, error) {
+	return r.route(ctx, event, true)
+}
+
+// RouteAggregate routes the messages of collapsed notifications. These are already limited to one
+// per window, so they are not throttled by the matched rule.
+func (r *Router) RouteAggregate(ctx context.Context, event Event) (*Decision, error) {
+	return r.route(ctx, event, false)
+}
+
+func (r *Router) route(ctx context.Context, event Event, throttle bool) (*Decision, error) {
+	rules, err := r.repo.ListNotificationRulesByProjectID(ctx, event.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := Match(rules, event)
+
+	if rule == nil {
+		return &Decision{Deliver: true}, nil
+	}
+
+	decision := &Decision{Rule: rule}
+
+	if rule.DestinationKind == types.NotificationRuleDestinationKind_None {
+		decision.Reason = ReasonMuted
+		return decision, nil
+	}
+
+	quiet, err := InQuietHours(rule, r.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if quiet {
+		decision.Reason = ReasonQuietHours
+		return decision, nil
+	}
+
+	if throttle && rule.ThrottleWindowSeconds > 0 {
+		window := time.Duration(rule.ThrottleWindowSeconds) * time.Second
+
+		acquired, err := r.repo.AcquireThrottle(ctx, rule.ID, event.throttleKey(), window)
+		if err != nil {
+			return nil, err
+		}
+
+		if !acquired {
+			decision.Reason = ReasonThrottled
+			return decision, nil
+		}
+	}
+
+	decision.Deliver = true
+
+	return decision, nil
+}
+
+// Match returns the first enabled rule which matches the event. Rules are expected in the order
+// in which they are evaluated.
+func Match(rules []*models.NotificationRule, event Event) *models.NotificationRule {
+	for _, rule := range rules {
+		if !rule.Disabled && matches(rule, event) {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+func matches(rule *models.NotificationRule, event Event) bool {
+	if rule.AppName != "" && rule.AppName != event.AppName {
+		return false
+	}
+
+	if rule.DeploymentTargetID != "" && rule.DeploymentTargetID != event.DeploymentTargetID {
+		return false
+	}
+
+	if rule.ServiceName != "" && rule.ServiceName != event.ServiceName {
+		return false
+	}
+
+	if codes := rule.GetErrorCodes(); len(codes) > 0 {
+		found := false
+
+		for _, code := range codes {
+			if code == event.ErrorCode {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if scopes := rule.GetScopes(); len(scopes) > 0 {
+		found := false
+
+		for _, scope := range scopes {
+			if strings.EqualFold(string(scope), string(event.Scope)) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// InQuietHours returns true if t falls within the quiet hours of the rule
+func InQuietHours(rule *models.NotificationRule, t time.Time) (bool, error) {
+	if rule.QuietHoursStart == "" || rule.QuietHoursEnd == "" {
+		return false, nil
+	}
+
+	loc := time.UTC
+
+	if rule.QuietHoursTimezone != "" {
+		var err error
+
+		loc, err = time.LoadLocation(rule.QuietHoursTimezone)
+		if err != nil {
+			return false, fmt.Errorf("invalid quiet hours timezone %q: %w", rule.QuietHoursTimezone, err)
+		}
+	}
+
+	start, err := minuteOfDay(rule.QuietHoursStart)
+	if err != nil {
+		return false, err
+	}
+
+	end, err := minuteOfDay(rule.QuietHoursEnd)
+	if err != nil {
+		return false, err
+	}
+
+	local := t.In(loc)
+	now := local.Hour()*60 + local.Minute()
+
+	switch {
+	case start == end:
+		return false, nil
+	case start < end:
+		return now >= start && now < end, nil
+	default:
+		// the window spans midnight
+		return now >= start || now < end, nil
+	}
+}
+
+func minuteOfDay(hhmm string) (int, error) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", hhmm)
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,261 @@
+package routing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type memoryRuleRepository struct {
+	repository.NotificationRuleRepository
+
+	rules     []*models.NotificationRule
+	throttles map[string]time.Time
+}
+
+func (m *memoryRuleRepository) ListNotificationRulesByProjectID(ctx context.Context, projectID uint) ([]*models.NotificationRule, error) {
+	return m.rules, nil
+}
+
+func (m *memoryRuleRepository) AcquireThrottle(ctx context.Context, ruleID uint, key string, window time.Duration) (bool, error) {
+	if last, ok := m.throttles[key]; ok && time.Now().Before(last.Add(window)) {
+		return false, nil
+	}
+
+	m.throttles[key] = time.Now()
+
+	return true, nil
+}
+
+func TestMatch(t *testing.T) {
+	crashes := &models.NotificationRule{
+		AppName:    "api",
+		ErrorCodes: "10,11",
+		Scopes:     "SERVICE",
+	}
+	crashes.ID = 1
+
+	disabled := &models.NotificationRule{
+		AppName:  "api",
+		Disabled: true,
+	}
+	disabled.ID = 2
+
+	catchAll := &models.NotificationRule{}
+	catchAll.ID = 3
+
+	rules := []*models.NotificationRule{disabled, crashes, catchAll}
+
+	tests := []struct {
+		name     string
+		event    Event
+		expected uint
+	}{
+		{
+			name:     "matches app, error code and scope",
+			event:    Event{AppName: "api", ErrorCode: 11, Scope: types.NotificationRuleScope_Service},
+			expected: 1,
+		},
+		{
+			name:     "falls through on a different error code",
+			event:    Event{AppName: "api", ErrorCode: 12, Scope: types.NotificationRuleScope_Service},
+			expected: 3,
+		},
+		{
+			name:     "falls through on a different scope",
+			event:    Event{AppName: "api", ErrorCode: 10, Scope: types.NotificationRuleScope_Revision},
+			expected: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := Match(rules, tt.event)
+			if rule == nil || rule.ID != tt.expected {
+				t.Errorf("expected rule %d to match, got %v", tt.expected, rule)
+			}
+		})
+	}
+
+	if rule := Match([]*models.NotificationRule{crashes}, Event{AppName: "web"}); rule != nil {
+		t.Errorf("expected no rule to match, got %d", rule.ID)
+	}
+}
+
+func TestInQuietHours(t *testing.T) {
+	overnight := &models.NotificationRule{
+		QuietHoursStart:    "22:00",
+		QuietHoursEnd:      "07:00",
+		QuietHoursTimezone: "America/New_York",
+	}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected bool
+	}{
+		// 23:30 in New York
+		{name: "before midnight", at: time.Date(2024, 1, 10, 4, 30, 0, 0, time.UTC), expected: true},
+		// 06:59 in New York
+		{name: "after midnight", at: time.Date(2024, 1, 10, 11, 59, 0, 0, time.UTC), expected: true},
+		// 07:00 in New York
+		{name: "end is exclusive", at: time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC), expected: false},
+		// 12:00 in New York
+		{name: "daytime", at: time.Date(2024, 1, 10, 17, 0, 0, 0, time.UTC), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiet, err := InQuietHours(overnight, tt.at)
+			if err != nil {
+				t.Fatalf("%v\n", err)
+			}
+
+			if quiet != tt.expected {
+				t.Errorf("expected quiet hours to be %t, got %t", tt.expected, quiet)
+			}
+		})
+	}
+}
+
+func TestRouteThrottlesAndMutes(t *testing.T) {
+	throttled := &models.NotificationRule{
+		AppName:               "api",
+		DestinationKind:       types.NotificationRuleDestinationKind_Slack,
+		ThrottleWindowSeconds: 600,
+	}
+	throttled.ID = 1
+
+	muted := &models.NotificationRule{
+		AppName:         "worker",
+		DestinationKind: types.NotificationRuleDestinationKind_None,
+	}
+	muted.ID = 2
+
+	router := NewRouter(&memoryRuleRepository{
+		rules:     []*models.NotificationRule{throttled, muted},
+		throttles: make(map[string]time.Time),
+	})
+
+	ctx := context.Background()
+	event := Event{AppName: "api", ServiceName: "web", ErrorCode: 10}
+
+	first, err := router.Route(ctx, event)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !first.Deliver || first.Rule != throttled {
+		t.Errorf("expected first notification to be delivered by the throttled rule")
+	}
+
+	second, err := router.Route(ctx, event)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if second.Deliver || second.Reason != ReasonThrottled {
+		t.Errorf("expected second notification to be throttled, got deliver %t reason %q", second.Deliver, second.Reason)
+	}
+
+	// a different service is throttled separately
+	other, err := router.Route(ctx, Event{AppName: "api", ServiceName: "cron", ErrorCode: 10})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !other.Deliver {
+		t.Errorf("expected notification for another service to be delivered")
+	}
+
+	mutedDecision, err := router.Route(ctx, Event{AppName: "worker"})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if mutedDecision.Deliver || mutedDecision.Reason != ReasonMuted {
+		t.Errorf("expected notification to be muted, got deliver %t reason %q", mutedDecision.Deliver, mutedDecision.Reason)
+	}
+
+	unmatched, err := router.Route(ctx, Event{AppName: "web"})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !unmatched.Deliver || unmatched.Rule != nil {
+		t.Errorf("expected unmatched notification to be delivered to all channels")
+	}
+}
+
+func TestApplyRequestDestination(t *testing.T) {
+	slackInt := &integrations.SlackIntegration{}
+	slackInt.ID = 1
+
+	webhookInt := &integrations.NotificationWebhookIntegration{}
+	webhookInt.ID = 2
+
+	slackInts := []*integrations.SlackIntegration{slackInt}
+	webhookInts := []*integrations.NotificationWebhookIntegration{webhookInt}
+
+	tests := []struct {
+		name        string
+		destination types.NotificationRuleDestination
+		expectErr   bool
+	}{
+		{
+			name:        "slack integration of the project",
+			destination: types.NotificationRuleDestination{Kind: types.NotificationRuleDestinationKind_Slack, IntegrationID: 1},
+		},
+		{
+			name:        "webhook integration of the project",
+			destination: types.NotificationRuleDestination{Kind: types.NotificationRuleDestinationKind_Webhook, IntegrationID: 2},
+		},
+		{
+			name:        "muted rule ignores the integration id",
+			destination: types.NotificationRuleDestination{Kind: types.NotificationRuleDestinationKind_None, IntegrationID: 3},
+		},
+		{
+			name:        "missing integration id",
+			destination: types.NotificationRuleDestination{Kind: types.NotificationRuleDestinationKind_Slack},
+			expectErr:   true,
+		},
+		{
+			name:        "integration which is not in the project",
+			destination: types.NotificationRuleDestination{Kind: types.NotificationRuleDestinationKind_Slack, IntegrationID: 3},
+			expectErr:   true,
+		},
+		{
+			name:        "integration of another kind",
+			destination: types.NotificationRuleDestination{Kind: types.NotificationRuleDestinationKind_Slack, IntegrationID: 2},
+			expectErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &models.NotificationRule{}
+
+			err := ApplyRequest(rule, &types.CreateNotificationRuleRequest{Destination: tt.destination}, slackInts, webhookInts)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if rule.DestinationKind != tt.destination.Kind {
+				t.Errorf("expected destination kind %s, got %s", tt.destination.Kind, rule.DestinationKind)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// ApplyRequest validates a create or update request and sets the fields of the rule from it. The destination
+// of the rule must be one of the given Slack or notification webhook integrations of the rule's project.
+func ApplyRequest(
+	rule *models.NotificationRule,
+	req *types.CreateNotificationRuleRequest,
+	slackInts []*integrations.SlackIntegration,
+	webhookInts []*integrations.NotificationWebhookIntegration,
+) error {
+	if req.Destination.Kind != types.NotificationRuleDestinationKind_None {
+		if req.Destination.IntegrationID == 0 {
+			return errors.New("destination integration id is required")
+		}
+
+		resSlack, resWebhook := filterDestination(req.Destination.Kind, req.Destination.IntegrationID, slackInts, webhookInts)
+		if len(resSlack) == 0 && len(resWebhook) == 0 {
+			return fmt.Errorf("%s integration %d not found in project", req.Destination.Kind, req.Destination.IntegrationID)
+		}
+	}
+
+	if q := req.QuietHours; q != nil {
+		if _, err := minuteOfDay(q.Start); err != nil {
+			return err
+		}
+
+		if _, err := minuteOfDay(q.End); err != nil {
+			return err
+		}
+
+		if q.Timezone != "" {
+			if _, err := time.LoadLocation(q.Timezone); err != nil {
+				return fmt.Errorf("invalid quiet hours timezone %q", q.Timezone)
+			}
+		}
+	}
+
+	rule.Name = req.Name
+	rule.Priority = req.Priority
+	rule.Disabled = req.Disabled
+
+	rule.AppName = req.Match.AppName
+	rule.DeploymentTargetID = req.Match.DeploymentTargetID
+	rule.ServiceName = req.Match.ServiceName
+	rule.SetErrorCodes(req.Match.ErrorCodes)
+	rule.SetScopes(req.Match.Scopes)
+
+	rule.DestinationKind = req.Destination.Kind
+	rule.DestinationIntegrationID = req.Destination.IntegrationID
+
+	if rule.DestinationKind == types.NotificationRuleDestinationKind_None {
+		rule.DestinationIntegrationID = 0
+	}
+
+	rule.ThrottleWindowSeconds = req.ThrottleWindowSeconds
+
+	rule.QuietHoursStart = ""
+	rule.QuietHoursEnd = ""
+	rule.QuietHoursTimezone = ""
+
+	if q := req.QuietHours; q != nil {
+		rule.QuietHoursStart = q.Start
+		rule.QuietHoursEnd = q.End
+		rule.QuietHoursTimezone = q.Timezone
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package sendgrid
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/sendgrid/sendgrid-go"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+type DigestNotifier struct {
+	opts *DigestNotifierOpts
+}
+
+type DigestNotifierOpts struct {
+	*SharedOpts
+	DigestTemplateID string
+	Users            []*models.User
+}
+
+func NewDigestNotifier(opts *DigestNotifierOpts) notifier.DigestNotifier {
+	return &DigestNotifier{opts}
+}
+
+func (s *DigestNotifier) NotifyDigest(digest *notifier.Digest) error {
+	if len(s.opts.Users) == 0 {
+		return nil
+	}
+
+	request := sendgrid.GetRequest(s.opts.APIKey, "/v3/mail/send", "https://api.sendgrid.com")
+	request.Method = "POST"
+
+	personalizations := make([]*mail.Personalization, 0)
+
+	entries := make([]map[string]interface{}, 0, len(digest.Entries))
+
+	for _, entry := range digest.Entries {
+		entries = append(entries, map[string]interface{}{
+			"app_name":        entry.AppName,
+			"app_revision_id": entry.AppRevisionID,
+			"service_name":    entry.ServiceName,
+			"error_code":      entry.ErrorCode,
+			"summary":         entry.Summary,
+			"occurrences":     entry.Occurrences,
+			"first_seen_at":   entry.FirstSeenAt.Format("Jan 2, 2006 at 3:04pm (MST)"),
+			"last_seen_at":    entry.LastSeenAt.Format("Jan 2, 2006 at 3:04pm (MST)"),
+			"resolved":        entry.Resolved,
+		})
+	}
+
+	templData := map[string]interface{}{
+		"project_name":      digest.ProjectName,
+		"project_url":       digest.URL,
+		"subject":           fmt.Sprintf("Your daily notification digest for project %s on Porter", digest.ProjectName),
+		"preheader":         fmt.Sprintf("%d notifications across %d issues", digest.TotalOccurrences, len(digest.Entries)),
+		"since":             digest.Since.Format("Jan 2, 2006 at 3:04pm (MST)"),
+		"until":             digest.Until.Format("Jan 2, 2006 at 3:04pm (MST)"),
+		"total_occurrences": digest.TotalOccurrences,
+		"entries":           entries,
+	}
+
+	for _, user := range s.opts.Users {
+		personalizations = append(personalizations, &mail.Personalization{
+			To: []*mail.Email{
+				{
+					Address: user.Email,
+				},
+			},
+			DynamicTemplateData: templData,
+		})
+	}
+
+	sgMail := &mail.SGMailV3{
+		Personalizations: personalizations,
+		From: &mail.Email{
+			Address: s.opts.SenderEmail,
+			Name:    "Porter Notifications",
+		},
+		TemplateID: s.opts.DigestTemplateID,
+	}
+
+	request.Body = mail.GetRequestBody(sgMail)
+
+	_, err := sendgrid.API(request)
+
+	return err
+}
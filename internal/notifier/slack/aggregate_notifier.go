@@ -0,0 +1,88 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+// AggregateNotifier posts collapsed app notifications to Slack
+type AggregateNotifier struct {
+	slackInts []*integrations.SlackIntegration
+}
+
+func NewAggregateNotifier(slackInts ...*integrations.SlackIntegration) *AggregateNotifier {
+	return &AggregateNotifier{
+		slackInts: slackInts,
+	}
+}
+
+func (s *AggregateNotifier) NotifyRepeated(opts *notifier.AggregateOpts) error {
+	topSectionMarkdwn := fmt.Sprintf(
+		":warning: The following error occurred %d more times in service %s of application %s. <%s|View the application.>",
+		opts.Occurrences,
+		"`"+opts.ServiceName+"`",
+		"`"+opts.AppName+"`",
+		opts.URL,
+	)
+
+	return s.post(getAggregateBlocks(topSectionMarkdwn, opts))
+}
+
+func (s *AggregateNotifier) NotifyResolved(opts *notifier.AggregateOpts) error {
+	topSectionMarkdwn := fmt.Sprintf(
+		":white_check_mark: The following error has stopped occurring in service %s of application %s. <%s|View the application.>",
+		"`"+opts.ServiceName+"`",
+		"`"+opts.AppName+"`",
+		opts.URL,
+	)
+
+	return s.post(getAggregateBlocks(topSectionMarkdwn, opts))
+}
+
+func (s *AggregateNotifier) post(blocks []*SlackBlock) error {
+	payload, err := json.Marshal(&SlackPayload{
+		Blocks: blocks,
+	})
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	for _, slackInt := range s.slackInts {
+		_, err := client.Post(string(slackInt.Webhook), "application/json", bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getAggregateBlocks(topSectionMarkdwn string, opts *notifier.AggregateOpts) []*SlackBlock {
+	return []*SlackBlock{
+		getMarkdownBlock(topSectionMarkdwn),
+		getDividerBlock(),
+		getMarkdownBlock(fmt.Sprintf("*Revision:* %s", "`"+opts.AppRevisionID+"`")),
+		getMarkdownBlock(fmt.Sprintf("*Occurrences:* %d", opts.TotalOccurrences)),
+		getMarkdownBlock(fmt.Sprintf(
+			"*First seen:* <!date^%d^ {date_num} {time_secs}| %s>",
+			opts.FirstSeenAt.Unix(),
+			opts.FirstSeenAt.Format("2006-01-02 15:04:05 UTC"),
+		)),
+		getMarkdownBlock(fmt.Sprintf(
+			"*Last seen:* <!date^%d^ {date_num} {time_secs}| %s>",
+			opts.LastSeenAt.Unix(),
+			opts.LastSeenAt.Format("2006-01-02 15:04:05 UTC"),
+		)),
+		getMarkdownBlock(fmt.Sprintf("```\n%s\n```", opts.Summary)),
+	}
+}
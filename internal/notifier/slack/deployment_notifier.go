@@ -38,19 +38,8 @@ type SlackText struct {
 }
 
 func (s *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
-	if s.Config != nil {
-		if !s.Config.Enabled {
-			return nil
-		}
-		if opts.Status == notifier.StatusHelmDeployed && !s.Config.Success {
-			return nil
-		}
-		if opts.Status == notifier.StatusPodCrashed && !s.Config.Failure {
-			return nil
-		}
-		if opts.Status == notifier.StatusHelmFailed && !s.Config.Failure {
-			return nil
-		}
+	if !notifier.IsStatusEnabled(s.Config, opts.Status) {
+		return nil
 	}
 
 	// we create a basic payload as a fallback if the detailed payload with "info" fails, due to
@@ -0,0 +1,95 @@
+package teams
+
+// TeamsMessage is the body accepted by Microsoft Teams incoming webhooks
+type TeamsMessage struct {
+	Type        string             `json:"type"`
+	Attachments []*TeamsAttachment `json:"attachments"`
+}
+
+type TeamsAttachment struct {
+	ContentType string        `json:"contentType"`
+	Content     *AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard is an Adaptive Card, see https://adaptivecards.io/explorer/AdaptiveCard.html
+type AdaptiveCard struct {
+	Schema  string                `json:"$schema"`
+	Type    string                `json:"type"`
+	Version string                `json:"version"`
+	Body    []*AdaptiveCardBlock  `json:"body"`
+	Actions []*AdaptiveCardAction `json:"actions,omitempty"`
+}
+
+// AdaptiveCardBlock is either a TextBlock or a FactSet
+type AdaptiveCardBlock struct {
+	Type  string              `json:"type"`
+	Text  string              `json:"text,omitempty"`
+	Size  string              `json:"size,omitempty"`
+	Color string              `json:"color,omitempty"`
+	Wrap  bool                `json:"wrap,omitempty"`
+	Font  string              `json:"fontType,omitempty"`
+	Facts []*AdaptiveCardFact `json:"facts,omitempty"`
+}
+
+type AdaptiveCardFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type AdaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func newMessage(body []*AdaptiveCardBlock, actions ...*AdaptiveCardAction) *TeamsMessage {
+	return &TeamsMessage{
+		Type: "message",
+		Attachments: []*TeamsAttachment{
+			{
+				ContentType: "application/vnd.microsoft.card.adaptive",
+				Content: &AdaptiveCard{
+					Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+					Type:    "AdaptiveCard",
+					Version: "1.4",
+					Body:    body,
+					Actions: actions,
+				},
+			},
+		},
+	}
+}
+
+func getTitleBlock(text string, color string) *AdaptiveCardBlock {
+	return &AdaptiveCardBlock{
+		Type:  "TextBlock",
+		Text:  text,
+		Size:  "Medium",
+		Color: color,
+		Wrap:  true,
+	}
+}
+
+func getFactSetBlock(facts ...*AdaptiveCardFact) *AdaptiveCardBlock {
+	return &AdaptiveCardBlock{
+		Type:  "FactSet",
+		Facts: facts,
+	}
+}
+
+func getCodeBlock(text string) *AdaptiveCardBlock {
+	return &AdaptiveCardBlock{
+		Type: "TextBlock",
+		Text: text,
+		Font: "Monospace",
+		Wrap: true,
+	}
+}
+
+func getOpenURLAction(title, url string) *AdaptiveCardAction {
+	return &AdaptiveCardAction{
+		Type:  "Action.OpenUrl",
+		Title: title,
+		URL:   url,
+	}
+}
@@ -0,0 +1,207 @@
+package teams
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+)
+
+// maxInfoLength is the number of characters of a deployment error shown in a card
+const maxInfoLength = 500
+
+// DeploymentNotifier posts deployment notifications as Adaptive Cards to Microsoft Teams
+// incoming webhooks. Webhook integrations of other kinds are ignored.
+type DeploymentNotifier struct {
+	webhookInts []*integrations.NotificationWebhookIntegration
+	sender      *webhook.Sender
+	Config      *types.NotificationConfig
+}
+
+func NewDeploymentNotifier(conf *types.NotificationConfig, webhookInts ...*integrations.NotificationWebhookIntegration) *DeploymentNotifier {
+	return &DeploymentNotifier{
+		webhookInts: filterTeams(webhookInts),
+		sender:      webhook.NewSender(),
+		Config:      conf,
+	}
+}
+
+func (t *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if !notifier.IsStatusEnabled(t.Config, opts.Status) {
+		return nil
+	}
+
+	var title, color, action string
+
+	switch opts.Status {
+	case notifier.StatusHelmDeployed:
+		title = fmt.Sprintf("Your application %s was successfully updated on Porter!", opts.Name)
+		color = "Good"
+		action = "View the new release"
+	case notifier.StatusHelmFailed:
+		title = fmt.Sprintf("Your application %s failed to deploy on Porter.", opts.Name)
+		color = "Attention"
+		action = "View the status"
+	case notifier.StatusPodCrashed:
+		title = fmt.Sprintf("Your application %s crashed on Porter.", opts.Name)
+		color = "Attention"
+		action = "View the application"
+	default:
+		return nil
+	}
+
+	facts := []*AdaptiveCardFact{
+		{Title: "Name", Value: opts.Name},
+		{Title: "Namespace", Value: opts.Namespace},
+		{Title: "Cluster", Value: opts.ClusterName},
+	}
+
+	if opts.Timestamp != nil {
+		facts = append(facts, &AdaptiveCardFact{Title: "Alerted at", Value: opts.Timestamp.Format("2006-01-02 15:04:05 UTC")})
+	}
+
+	if opts.Status == notifier.StatusHelmDeployed || opts.Status == notifier.StatusHelmFailed {
+		facts = append(facts, &AdaptiveCardFact{Title: "Version", Value: fmt.Sprintf("%d", opts.Version)})
+	}
+
+	body := []*AdaptiveCardBlock{
+		getTitleBlock(title, color),
+		getFactSetBlock(facts...),
+	}
+
+	if info := opts.Info; info != "" && opts.Status != notifier.StatusHelmDeployed {
+		if len(info) > maxInfoLength {
+			info = info[:maxInfoLength] + "..."
+		}
+
+		body = append(body, getCodeBlock(info))
+	}
+
+	return send(t.sender, t.webhookInts, newMessage(body, getOpenURLAction(action, opts.URL)))
+}
+
+// IncidentNotifier posts incident notifications as Adaptive Cards to Microsoft Teams
+// incoming webhooks. Webhook integrations of other kinds are ignored.
+type IncidentNotifier struct {
+	webhookInts []*integrations.NotificationWebhookIntegration
+	sender      *webhook.Sender
+}
+
+func NewIncidentNotifier(webhookInts ...*integrations.NotificationWebhookIntegration) *IncidentNotifier {
+	return &IncidentNotifier{
+		webhookInts: filterTeams(webhookInts),
+		sender:      webhook.NewSender(),
+	}
+}
+
+func (t *IncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	resourceKind := "application"
+
+	if strings.ToLower(string(incident.InvolvedObjectKind)) == "job" {
+		resourceKind = "job"
+	}
+
+	body := []*AdaptiveCardBlock{
+		getTitleBlock(fmt.Sprintf("Your %s %s crashed on Porter.", resourceKind, incident.ReleaseName), "Attention"),
+		getFactSetBlock(
+			&AdaptiveCardFact{Title: "Namespace", Value: incident.ReleaseNamespace},
+			&AdaptiveCardFact{Title: "Name", Value: incident.ReleaseName},
+			&AdaptiveCardFact{Title: "Created at", Value: incident.CreatedAt.Format("2006-01-02 15:04:05 UTC")},
+		),
+		getCodeBlock(incident.Summary),
+	}
+
+	return send(t.sender, t.webhookInts, newMessage(body, getOpenURLAction("View the incident", url)))
+}
+
+func (t *IncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	body := []*AdaptiveCardBlock{
+		getTitleBlock(fmt.Sprintf("The incident for application %s has been resolved.", incident.ReleaseName), "Good"),
+		getFactSetBlock(
+			&AdaptiveCardFact{Title: "Namespace", Value: incident.ReleaseNamespace},
+			&AdaptiveCardFact{Title: "Name", Value: incident.ReleaseName},
+			&AdaptiveCardFact{Title: "Created at", Value: incident.CreatedAt.Format("2006-01-02 15:04:05 UTC")},
+			&AdaptiveCardFact{Title: "Resolved at", Value: incident.UpdatedAt.Format("2006-01-02 15:04:05 UTC")},
+		),
+		getCodeBlock(incident.Summary),
+	}
+
+	return send(t.sender, t.webhookInts, newMessage(body, getOpenURLAction("View the incident", url)))
+}
+
+// AggregateNotifier posts collapsed app notifications as Adaptive Cards to Microsoft Teams
+// incoming webhooks. Webhook integrations of other kinds are ignored.
+type AggregateNotifier struct {
+	webhookInts []*integrations.NotificationWebhookIntegration
+	sender      *webhook.Sender
+}
+
+func NewAggregateNotifier(webhookInts ...*integrations.NotificationWebhookIntegration) *AggregateNotifier {
+	return &AggregateNotifier{
+		webhookInts: filterTeams(webhookInts),
+		sender:      webhook.NewSender(),
+	}
+}
+
+func (t *AggregateNotifier) NotifyRepeated(opts *notifier.AggregateOpts) error {
+	title := fmt.Sprintf("An error occurred %d more times in service %s of application %s.", opts.Occurrences, opts.ServiceName, opts.AppName)
+
+	return send(t.sender, t.webhookInts, getAggregateMessage(title, "Attention", opts))
+}
+
+func (t *AggregateNotifier) NotifyResolved(opts *notifier.AggregateOpts) error {
+	title := fmt.Sprintf("An error has stopped occurring in service %s of application %s.", opts.ServiceName, opts.AppName)
+
+	return send(t.sender, t.webhookInts, getAggregateMessage(title, "Good", opts))
+}
+
+func getAggregateMessage(title, color string, opts *notifier.AggregateOpts) *TeamsMessage {
+	body := []*AdaptiveCardBlock{
+		getTitleBlock(title, color),
+		getFactSetBlock(
+			&AdaptiveCardFact{Title: "Revision", Value: opts.AppRevisionID},
+			&AdaptiveCardFact{Title: "Occurrences", Value: fmt.Sprintf("%d", opts.TotalOccurrences)},
+			&AdaptiveCardFact{Title: "First seen", Value: opts.FirstSeenAt.Format("2006-01-02 15:04:05 UTC")},
+			&AdaptiveCardFact{Title: "Last seen", Value: opts.LastSeenAt.Format("2006-01-02 15:04:05 UTC")},
+		),
+		getCodeBlock(opts.Summary),
+	}
+
+	return newMessage(body, getOpenURLAction("View the application", opts.URL))
+}
+
+// send posts the message to every webhook, even if some of them fail. The first error is returned.
+func send(sender *webhook.Sender, webhookInts []*integrations.NotificationWebhookIntegration, msg *TeamsMessage) error {
+	if len(webhookInts) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	destinations := make([]webhook.Destination, 0, len(webhookInts))
+
+	for _, webhookInt := range webhookInts {
+		destinations = append(destinations, webhook.Destination{URL: string(webhookInt.Webhook)})
+	}
+
+	return sender.SendAll(destinations, "", body)
+}
+
+func filterTeams(webhookInts []*integrations.NotificationWebhookIntegration) []*integrations.NotificationWebhookIntegration {
+	res := make([]*integrations.NotificationWebhookIntegration, 0)
+
+	for _, webhookInt := range webhookInts {
+		if webhookInt.Kind == types.NotificationWebhookKind_Teams {
+			res = append(res, webhookInt)
+		}
+	}
+
+	return res
+}
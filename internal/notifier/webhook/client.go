@@ -0,0 +1,173 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/internal/netguard"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 signature of a payload, formatted as "sha256=<hex digest>"
+	SignatureHeader = "X-Porter-Signature-256"
+
+	// TimestampHeader carries the unix timestamp at which a payload was signed. The timestamp is part
+	// of the signed content, so that receivers can reject replayed payloads.
+	TimestampHeader = "X-Porter-Timestamp"
+
+	// EventHeader carries the kind of event a payload describes
+	EventHeader = "X-Porter-Event"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBaseDelay   = 500 * time.Millisecond
+	defaultTimeout     = 5 * time.Second
+
+	// defaultMaxDuration caps the total duration of a delivery, including retries, since deliveries are made while
+	// handling requests
+	defaultMaxDuration = 10 * time.Second
+)
+
+// Sign computes the signature of body sent at the given unix timestamp. The signed content is
+// "<timestamp>.<body>", and the signature is formatted as "sha256=<hex digest>".
+func Sign(secret []byte, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sender posts JSON payloads to webhooks, retrying with exponential backoff on network
+// errors, rate limiting and server errors. Webhooks are only delivered to public addresses, so that
+// they cannot be used to reach the internal network of Porter.
+type Sender struct {
+	client      *http.Client
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDuration time.Duration
+}
+
+func NewSender() *Sender {
+	return &Sender{
+		client:      netguard.NewHTTPClient(defaultTimeout),
+		maxAttempts: defaultMaxAttempts,
+		baseDelay:   defaultBaseDelay,
+		maxDuration: defaultMaxDuration,
+	}
+}
+
+// Send posts body to url. If secret is non-empty, the payload is signed with it. Retries are
+// abandoned once the total duration of the delivery exceeds the maximum duration of the sender.
+func (s *Sender) Send(url string, event string, body []byte, secret []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.maxDuration)
+	defer cancel()
+
+	var err error
+
+	for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+		var retry bool
+
+		retry, err = s.send(ctx, url, event, body, secret)
+		if err == nil || !retry {
+			return err
+		}
+
+		if attempt < s.maxAttempts {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("webhook delivery timed out after %d attempts: %w", attempt, err)
+			case <-time.After(s.baseDelay * time.Duration(1<<(attempt-1))):
+			}
+		}
+	}
+
+	return fmt.Errorf("webhook delivery failed after %d attempts: %w", s.maxAttempts, err)
+}
+
+// Destination is a webhook a payload is delivered to
+type Destination struct {
+	URL string
+
+	// Secret signs the payload if it is non-empty
+	Secret []byte
+}
+
+// SendAll posts body to every destination concurrently, even if some of them fail, so that
+// delivering to several webhooks takes no longer than a single delivery. The first error is returned.
+func (s *Sender) SendAll(destinations []Destination, event string, body []byte) error {
+	errs := make([]error, len(destinations))
+
+	var wg sync.WaitGroup
+
+	for i, dest := range destinations {
+		wg.Add(1)
+
+		go func(i int, dest Destination) {
+			defer wg.Done()
+
+			errs[i] = s.Send(dest.URL, event, body, dest.Secret)
+		}(i, dest)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// send makes a single delivery attempt, and returns whether a failed attempt should be retried
+func (s *Sender) send(ctx context.Context, url string, event string, body []byte, secret []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if event != "" {
+		req.Header.Set(EventHeader, event)
+	}
+
+	if len(secret) > 0 {
+		timestamp := time.Now().Unix()
+
+		req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(SignatureHeader, Sign(secret, timestamp, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+
+	defer resp.Body.Close()
+
+	// drain the body so that the connection can be reused
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+
+	return retry, fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+}
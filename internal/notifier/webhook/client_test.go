@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestSender returns a sender which can reach the test server, since the default sender refuses
+// to connect to loopback addresses
+func newTestSender(server *httptest.Server) *Sender {
+	sender := NewSender()
+	sender.client = server.Client()
+	sender.baseDelay = time.Millisecond
+
+	return sender
+}
+
+func TestSendSignsPayload(t *testing.T) {
+	secret := []byte("signing-secret")
+	body := []byte(`{"event":"deployment"}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ := io.ReadAll(r.Body)
+
+		timestamp, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		if err != nil {
+			t.Errorf("invalid timestamp header: %v", err)
+		}
+
+		if expected := Sign(secret, timestamp, received); r.Header.Get(SignatureHeader) != expected {
+			t.Errorf("expected signature %s, got %s", expected, r.Header.Get(SignatureHeader))
+		}
+
+		if r.Header.Get(EventHeader) != "deployment" {
+			t.Errorf("expected event header deployment, got %s", r.Header.Get(EventHeader))
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := newTestSender(server).Send(server.URL, "deployment", body, secret); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+}
+
+func TestSendRetriesServerErrors(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(server)
+
+	if err := sender.Send(server.URL, "", []byte("{}"), nil); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("expected 3 delivery attempts, got %d", calls)
+	}
+}
+
+func TestSendDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(server)
+
+	if err := sender.Send(server.URL, "", []byte("{}"), nil); err == nil {
+		t.Fatalf("expected delivery to fail")
+	}
+
+	if calls != 1 {
+		t.Errorf("expected 1 delivery attempt, got %d", calls)
+	}
+}
+
+func TestSendRefusesPrivateAddresses(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender := NewSender()
+	sender.baseDelay = time.Millisecond
+
+	if err := sender.Send(server.URL, "", []byte("{}"), nil); err == nil {
+		t.Fatalf("expected delivery to a loopback address to fail")
+	}
+
+	if calls != 0 {
+		t.Errorf("expected no delivery attempt to reach the server, got %d", calls)
+	}
+}
+
+func TestSendCapsTotalDuration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(server)
+	sender.baseDelay = time.Second
+	sender.maxDuration = 50 * time.Millisecond
+
+	start := time.Now()
+
+	if err := sender.Send(server.URL, "", []byte("{}"), nil); err == nil {
+		t.Fatalf("expected delivery to fail")
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected delivery to give up after its maximum duration, took %s", elapsed)
+	}
+}
+
+func TestSendAllDeliversConcurrently(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	destinations := []Destination{{URL: server.URL}, {URL: server.URL}, {URL: server.URL}}
+
+	start := time.Now()
+
+	if err := newTestSender(server).SendAll(destinations, "", []byte("{}")); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("expected 3 deliveries, got %d", calls)
+	}
+
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Errorf("expected deliveries to be concurrent, took %s", elapsed)
+	}
+}
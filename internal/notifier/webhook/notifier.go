@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+// DeploymentNotifier posts signed deployment notifications to generic webhooks. Webhook
+// integrations of other kinds are ignored.
+type DeploymentNotifier struct {
+	webhookInts []*integrations.NotificationWebhookIntegration
+	sender      *Sender
+	Config      *types.NotificationConfig
+}
+
+func NewDeploymentNotifier(conf *types.NotificationConfig, webhookInts ...*integrations.NotificationWebhookIntegration) *DeploymentNotifier {
+	return &DeploymentNotifier{
+		webhookInts: filterGeneric(webhookInts),
+		sender:      NewSender(),
+		Config:      conf,
+	}
+}
+
+func (w *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if !notifier.IsStatusEnabled(w.Config, opts.Status) {
+		return nil
+	}
+
+	return send(w.sender, w.webhookInts, &Payload{
+		Event:      EventDeployment,
+		URL:        opts.URL,
+		Deployment: toDeployment(opts),
+	})
+}
+
+// IncidentNotifier posts signed incident notifications to generic webhooks. Webhook
+// integrations of other kinds are ignored.
+type IncidentNotifier struct {
+	webhookInts []*integrations.NotificationWebhookIntegration
+	sender      *Sender
+}
+
+func NewIncidentNotifier(webhookInts ...*integrations.NotificationWebhookIntegration) *IncidentNotifier {
+	return &IncidentNotifier{
+		webhookInts: filterGeneric(webhookInts),
+		sender:      NewSender(),
+	}
+}
+
+func (w *IncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	return send(w.sender, w.webhookInts, &Payload{
+		Event:    EventIncidentNew,
+		URL:      url,
+		Incident: incident,
+	})
+}
+
+func (w *IncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	return send(w.sender, w.webhookInts, &Payload{
+		Event:    EventIncidentResolved,
+		URL:      url,
+		Incident: incident,
+	})
+}
+
+// AggregateNotifier posts signed collapsed app notifications to generic webhooks. Webhook
+// integrations of other kinds are ignored.
+type AggregateNotifier struct {
+	webhookInts []*integrations.NotificationWebhookIntegration
+	sender      *Sender
+}
+
+func NewAggregateNotifier(webhookInts ...*integrations.NotificationWebhookIntegration) *AggregateNotifier {
+	return &AggregateNotifier{
+		webhookInts: filterGeneric(webhookInts),
+		sender:      NewSender(),
+	}
+}
+
+func (w *AggregateNotifier) NotifyRepeated(opts *notifier.AggregateOpts) error {
+	return send(w.sender, w.webhookInts, &Payload{
+		Event:        EventNotificationRepeated,
+		URL:          opts.URL,
+		Notification: toNotification(opts),
+	})
+}
+
+func (w *AggregateNotifier) NotifyResolved(opts *notifier.AggregateOpts) error {
+	return send(w.sender, w.webhookInts, &Payload{
+		Event:        EventNotificationResolved,
+		URL:          opts.URL,
+		Notification: toNotification(opts),
+	})
+}
+
+// send delivers the payload to every webhook, even if some of them fail. The first error is returned.
+func send(sender *Sender, webhookInts []*integrations.NotificationWebhookIntegration, payload *Payload) error {
+	if len(webhookInts) == 0 {
+		return nil
+	}
+
+	payload.SentAt = time.Now().UTC()
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	destinations := make([]Destination, 0, len(webhookInts))
+
+	for _, webhookInt := range webhookInts {
+		destinations = append(destinations, Destination{
+			URL:    string(webhookInt.Webhook),
+			Secret: webhookInt.SigningSecret,
+		})
+	}
+
+	return sender.SendAll(destinations, string(payload.Event), body)
+}
+
+func filterGeneric(webhookInts []*integrations.NotificationWebhookIntegration) []*integrations.NotificationWebhookIntegration {
+	res := make([]*integrations.NotificationWebhookIntegration, 0)
+
+	for _, webhookInt := range webhookInts {
+		if webhookInt.Kind == types.NotificationWebhookKind_Generic {
+			res = append(res, webhookInt)
+		}
+	}
+
+	return res
+}
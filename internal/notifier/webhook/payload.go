@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+// Event is the kind of event described by a webhook payload
+type Event string
+
+const (
+	EventDeployment       Event = "deployment"
+	EventIncidentNew      Event = "incident.new"
+	EventIncidentResolved Event = "incident.resolved"
+
+	EventNotificationRepeated Event = "notification.repeated"
+	EventNotificationResolved Event = "notification.resolved"
+)
+
+// Payload is the JSON body posted to generic webhooks
+type Payload struct {
+	Event  Event     `json:"event"`
+	SentAt time.Time `json:"sent_at"`
+
+	// URL links to the deployment, incident or application in the Porter dashboard
+	URL string `json:"url,omitempty"`
+
+	Deployment *Deployment     `json:"deployment,omitempty"`
+	Incident   *types.Incident `json:"incident,omitempty"`
+
+	Notification *Notification `json:"notification,omitempty"`
+}
+
+// Deployment mirrors notifier.NotifyOpts
+type Deployment struct {
+	ProjectID   uint                      `json:"project_id"`
+	ClusterID   uint                      `json:"cluster_id"`
+	ClusterName string                    `json:"cluster_name"`
+	Status      notifier.DeploymentStatus `json:"status"`
+	Info        string                    `json:"info,omitempty"`
+	Name        string                    `json:"name"`
+	Namespace   string                    `json:"namespace"`
+	Timestamp   *time.Time                `json:"timestamp,omitempty"`
+	Version     int                       `json:"version,omitempty"`
+}
+
+func toDeployment(opts *notifier.NotifyOpts) *Deployment {
+	return &Deployment{
+		ProjectID:   opts.ProjectID,
+		ClusterID:   opts.ClusterID,
+		ClusterName: opts.ClusterName,
+		Status:      opts.Status,
+		Info:        opts.Info,
+		Name:        opts.Name,
+		Namespace:   opts.Namespace,
+		Timestamp:   opts.Timestamp,
+		Version:     opts.Version,
+	}
+}
+
+// Notification mirrors notifier.AggregateOpts
+type Notification struct {
+	ProjectID          uint      `json:"project_id"`
+	AppName            string    `json:"app_name"`
+	DeploymentTargetID string    `json:"deployment_target_id,omitempty"`
+	AppRevisionID      string    `json:"app_revision_id"`
+	ServiceName        string    `json:"service_name"`
+	ErrorCode          int       `json:"error_code"`
+	Summary            string    `json:"summary"`
+	Occurrences        uint      `json:"occurrences"`
+	TotalOccurrences   uint      `json:"total_occurrences"`
+	FirstSeenAt        time.Time `json:"first_seen_at"`
+	LastSeenAt         time.Time `json:"last_seen_at"`
+}
+
+func toNotification(opts *notifier.AggregateOpts) *Notification {
+	return &Notification{
+		ProjectID:          opts.ProjectID,
+		AppName:            opts.AppName,
+		DeploymentTargetID: opts.DeploymentTargetID,
+		AppRevisionID:      opts.AppRevisionID,
+		ServiceName:        opts.ServiceName,
+		ErrorCode:          opts.ErrorCode,
+		Summary:            opts.Summary,
+		Occurrences:        opts.Occurrences,
+		TotalOccurrences:   opts.TotalOccurrences,
+		FirstSeenAt:        opts.FirstSeenAt,
+		LastSeenAt:         opts.LastSeenAt,
+	}
+}
@@ -0,0 +1,239 @@
+package opa
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+
+	"github.com/mitchellh/mapstructure"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Enforcement is the action taken when a policy is violated by the manifests of an app before it is deployed
+type Enforcement string
+
+const (
+	// EnforcementNone only evaluates the policy in the recommender
+	EnforcementNone Enforcement = ""
+	// EnforcementWarn reports violations of the policy without blocking the deploy
+	EnforcementWarn Enforcement = "warn"
+	// EnforcementDeny blocks the deploy when the policy is violated
+	EnforcementDeny Enforcement = "deny"
+	// EnforcementOff disables the enforcement of a policy collection in a project override
+	EnforcementOff Enforcement = "off"
+)
+
+// admissionKinds maps the kinds which can be enforced to the kinds of the manifests they are evaluated against
+var admissionKinds = map[KubernetesBuiltInKind]string{
+	Pod:        "Pod",
+	Daemonset:  "DaemonSet",
+	Deployment: "Deployment",
+	Ingress:    "Ingress",
+	Service:    "Service",
+	HPA:        "HorizontalPodAutoscaler",
+	PVC:        "PersistentVolumeClaim",
+}
+
+// AdmissionViolation is a policy violated by an object of the manifests of an app
+type AdmissionViolation struct {
+	Enforcement Enforcement
+
+	CategoryName string
+	ObjectID     string
+
+	PolicyID       string
+	PolicySeverity string
+	PolicyTitle    string
+	PolicyMessage  string
+}
+
+// StrictestEnforcement returns the strictest enforcement of the policy collections after overrides: EnforcementDeny if
+// any collection is enforced with "deny", EnforcementWarn if any collection is enforced with "warn", and
+// EnforcementNone otherwise.
+func StrictestEnforcement(policies *KubernetesPolicies, overrides map[string]Enforcement) Enforcement {
+	res := EnforcementNone
+
+	if policies == nil {
+		return res
+	}
+
+	for name, collection := range policies.Policies {
+		enforcement := collection.Enforcement
+		if override, ok := overrides[name]; ok {
+			enforcement = override
+		}
+
+		if _, ok := admissionKinds[collection.Kind]; !ok {
+			continue
+		}
+
+		switch enforcement {
+		case EnforcementDeny:
+			return EnforcementDeny
+		case EnforcementWarn:
+			res = EnforcementWarn
+		}
+	}
+
+	return res
+}
+
+// EvaluateManifests evaluates the enforced policy collections against each object of a multi-document YAML or JSON
+// stream of manifests, and returns the violated policies. Overrides map collection names to the enforcement used in
+// place of the enforcement of the collection, so that a project can relax or disable an enforced collection.
+func EvaluateManifests(ctx context.Context, policies *KubernetesPolicies, overrides map[string]Enforcement, manifests []byte) ([]AdmissionViolation, error) {
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]AdmissionViolation, 0)
+
+	if policies == nil {
+		return res, nil
+	}
+
+	collectionNames := make([]string, 0, len(policies.Policies))
+	for name := range policies.Policies {
+		collectionNames = append(collectionNames, name)
+	}
+	sort.Strings(collectionNames)
+
+	for _, name := range collectionNames {
+		collection := policies.Policies[name]
+
+		enforcement := collection.Enforcement
+		if override, ok := overrides[name]; ok {
+			enforcement = override
+		}
+
+		if enforcement != EnforcementWarn && enforcement != EnforcementDeny {
+			continue
+		}
+
+		kind, ok := admissionKinds[collection.Kind]
+		if !ok {
+			continue
+		}
+
+		matches, err := objectMatcher(collection.Match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid match parameters for %s: %w", name, err)
+		}
+
+		for _, object := range objects {
+			if object.GetKind() != kind || !matches(object) {
+				continue
+			}
+
+			for _, query := range collection.Queries {
+				results, err := evalPolicy(ctx, query, object.Object)
+				if err != nil {
+					return nil, err
+				}
+
+				if len(results) != 1 {
+					continue
+				}
+
+				rawQueryRes := &rawQueryResult{}
+
+				err = mapstructure.Decode(results[0].Expressions[0].Value, rawQueryRes)
+				if err != nil {
+					return nil, err
+				}
+
+				if rawQueryRes.Allow {
+					continue
+				}
+
+				objectID := policyObjectID(collection.Kind)(kubernetesObject{namespace: object.GetNamespace(), name: object.GetName()}, rawQueryRes)
+
+				queryRes := rawQueryResToRecommenderQueryResult(rawQueryRes, objectID, name, collection)
+
+				res = append(res, AdmissionViolation{
+					Enforcement:    enforcement,
+					CategoryName:   queryRes.CategoryName,
+					ObjectID:       queryRes.ObjectID,
+					PolicyID:       rawQueryRes.PolicyID,
+					PolicySeverity: queryRes.PolicySeverity,
+					PolicyTitle:    queryRes.PolicyTitle,
+					PolicyMessage:  queryRes.PolicyMessage,
+				})
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// objectMatcher returns a function matching manifests against the namespace, labels and name regex of a collection.
+// Manifests without a namespace are deployed to the namespace of the app, so they match any namespace.
+func objectMatcher(match MatchParameters) (func(object *unstructured.Unstructured) bool, error) {
+	selector, err := labels.Parse(labelSelector(match))
+	if err != nil {
+		return nil, err
+	}
+
+	nameRegex, err := regexp.Compile(match.NameRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(object *unstructured.Unstructured) bool {
+		if match.Namespace != "" && object.GetNamespace() != "" && object.GetNamespace() != match.Namespace {
+			return false
+		}
+
+		return selector.Matches(labels.Set(object.GetLabels())) && nameRegex.MatchString(object.GetName())
+	}, nil
+}
+
+func decodeManifests(manifests []byte) ([]*unstructured.Unstructured, error) {
+	res := make([]*unstructured.Unstructured, 0)
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifests), 4096)
+
+	for {
+		object := make(map[string]interface{})
+
+		err := decoder.Decode(&object)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error decoding manifests: %w", err)
+		}
+
+		// empty documents decode to an empty object
+		if len(object) == 0 {
+			continue
+		}
+
+		u := &unstructured.Unstructured{Object: object}
+
+		// lists, like the output of kubectl, are flattened into their items
+		if u.IsList() {
+			list, err := u.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("error decoding manifests: %w", err)
+			}
+
+			for i := range list.Items {
+				res = append(res, &list.Items[i])
+			}
+
+			continue
+		}
+
+		res = append(res, u)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,372 @@
+package opa
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const replicasPolicy = `package replicas.minimum
+
+import future.keywords.contains
+import future.keywords.if
+
+POLICY_ID := "replicas_minimum"
+
+POLICY_VERSION := "v0.0.1"
+
+POLICY_SEVERITY := "high"
+
+POLICY_TITLE := sprintf("Deployment %s should have at least 2 replicas", [input.metadata.name])
+
+POLICY_SUCCESS_MESSAGE := "Success: deployment has at least 2 replicas"
+
+allow if {
+	input.spec.replicas >= 2
+}
+
+FAILURE_MESSAGE contains msg if {
+	not allow
+	msg := sprintf("Failed: deployment %s has %d replicas", [input.metadata.name, input.spec.replicas])
+}
+`
+
+const replicasBundleConfig = `replicas:
+  kind: deployment
+  enforcement: deny
+  match:
+    label_selector: "porter.run/app-name"
+  policies:
+  - path: replicas.rego
+    name: replicas.minimum
+`
+
+const admissionTestManifests = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    porter.run/app-name: api
+spec:
+  replicas: 1
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+  namespace: default
+  labels:
+    porter.run/app-name: api
+spec:
+  replicas: 3
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: unlabeled
+spec:
+  replicas: 1
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  labels:
+    porter.run/app-name: api
+`
+
+func loadTestBundle(t *testing.T, name string, config string, policies map[string]string) *KubernetesPolicies {
+	t.Helper()
+
+	res, err := LoadPolicyBundle(name, []byte(config), policies)
+	if err != nil {
+		t.Fatalf("unexpected error loading bundle: %v", err)
+	}
+
+	return res
+}
+
+func TestEvaluateManifests(t *testing.T) {
+	policies := loadTestBundle(t, "test", replicasBundleConfig, map[string]string{"replicas.rego": replicasPolicy})
+
+	tests := []struct {
+		name                string
+		overrides           map[string]Enforcement
+		expectedEnforcement Enforcement
+		expectedViolations  int
+	}{
+		{
+			name:                "enforcement of the collection",
+			expectedEnforcement: EnforcementDeny,
+			expectedViolations:  1,
+		},
+		{
+			name:                "relaxed by an override",
+			overrides:           map[string]Enforcement{"test/replicas": EnforcementWarn},
+			expectedEnforcement: EnforcementWarn,
+			expectedViolations:  1,
+		},
+		{
+			name:      "disabled by an override",
+			overrides: map[string]Enforcement{"test/replicas": EnforcementOff},
+		},
+		{
+			name:                "override of another collection",
+			overrides:           map[string]Enforcement{"test/other": EnforcementOff},
+			expectedEnforcement: EnforcementDeny,
+			expectedViolations:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := EvaluateManifests(context.Background(), policies, tt.overrides, []byte(admissionTestManifests))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(violations) != tt.expectedViolations {
+				t.Fatalf("expected %d violations, got %d: %+v", tt.expectedViolations, len(violations), violations)
+			}
+
+			if len(violations) == 0 {
+				return
+			}
+
+			expected := AdmissionViolation{
+				Enforcement:    tt.expectedEnforcement,
+				CategoryName:   "test/replicas",
+				ObjectID:       "deployment/web/replicas_minimum",
+				PolicyID:       "replicas_minimum",
+				PolicySeverity: "high",
+				PolicyTitle:    "Deployment web should have at least 2 replicas",
+				PolicyMessage:  "Failed: deployment web has 1 replicas",
+			}
+
+			if violations[0] != expected {
+				t.Errorf("expected violation %+v, got %+v", expected, violations[0])
+			}
+		})
+	}
+}
+
+func TestEvaluateManifestsList(t *testing.T) {
+	policies := loadTestBundle(t, "test", replicasBundleConfig, map[string]string{"replicas.rego": replicasPolicy})
+
+	list := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items": []interface{}{
+			map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":      "web",
+					"namespace": "default",
+					"labels":    map[string]interface{}{"porter.run/app-name": "api"},
+				},
+				"spec": map[string]interface{}{"replicas": 1},
+			},
+		},
+	}
+
+	manifests, err := json.Marshal(list)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	violations, err := EvaluateManifests(context.Background(), policies, nil, manifests)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(violations) != 1 || violations[0].ObjectID != "deployment/default/web/replicas_minimum" {
+		t.Fatalf("expected the items of a list to be evaluated, got %+v", violations)
+	}
+}
+
+func TestEvaluateManifestsInvalid(t *testing.T) {
+	policies := loadTestBundle(t, "test", replicasBundleConfig, map[string]string{"replicas.rego": replicasPolicy})
+
+	if _, err := EvaluateManifests(context.Background(), policies, nil, []byte("kind: [")); err == nil {
+		t.Fatalf("expected an error for invalid manifests")
+	}
+}
+
+func TestObjectMatcher(t *testing.T) {
+	object := func(namespace, name string, labels map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		u.SetLabels(labels)
+
+		return u
+	}
+
+	tests := []struct {
+		name     string
+		match    MatchParameters
+		object   *unstructured.Unstructured
+		expected bool
+	}{
+		{
+			name:     "empty match",
+			object:   object("default", "web", nil),
+			expected: true,
+		},
+		{
+			name:     "namespace",
+			match:    MatchParameters{Namespace: "default"},
+			object:   object("default", "web", nil),
+			expected: true,
+		},
+		{
+			name:     "other namespace",
+			match:    MatchParameters{Namespace: "default"},
+			object:   object("other", "web", nil),
+			expected: false,
+		},
+		{
+			name:     "object without a namespace matches any namespace",
+			match:    MatchParameters{Namespace: "default"},
+			object:   object("", "web", nil),
+			expected: true,
+		},
+		{
+			name:     "labels",
+			match:    MatchParameters{Labels: map[string]string{"tier": "web"}},
+			object:   object("default", "web", map[string]string{"tier": "web", "app": "api"}),
+			expected: true,
+		},
+		{
+			name:     "missing label",
+			match:    MatchParameters{Labels: map[string]string{"tier": "web"}},
+			object:   object("default", "web", map[string]string{"app": "api"}),
+			expected: false,
+		},
+		{
+			name:     "label selector",
+			match:    MatchParameters{LabelSelector: "tier in (web, worker)"},
+			object:   object("default", "web", map[string]string{"tier": "worker"}),
+			expected: true,
+		},
+		{
+			name:     "labels combined with a label selector",
+			match:    MatchParameters{Labels: map[string]string{"app": "api"}, LabelSelector: "tier in (web, worker)"},
+			object:   object("default", "web", map[string]string{"tier": "worker"}),
+			expected: false,
+		},
+		{
+			name:     "name regex",
+			match:    MatchParameters{NameRegex: "^web-"},
+			object:   object("default", "web-api", nil),
+			expected: true,
+		},
+		{
+			name:     "name regex without a match",
+			match:    MatchParameters{NameRegex: "^web-"},
+			object:   object("default", "api-web-1", nil),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := objectMatcher(tt.match)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := matches(tt.object); got != tt.expected {
+				t.Errorf("expected match %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestObjectMatcherInvalid(t *testing.T) {
+	for _, match := range []MatchParameters{
+		{LabelSelector: "tier in ("},
+		{NameRegex: "web-("},
+	} {
+		if _, err := objectMatcher(match); err == nil {
+			t.Errorf("expected an error for match parameters %+v", match)
+		}
+	}
+}
+
+func TestStrictestEnforcement(t *testing.T) {
+	policies := &KubernetesPolicies{
+		Policies: map[string]KubernetesOPAQueryCollection{
+			"a/deployments": {Kind: Deployment, Enforcement: EnforcementWarn},
+			"a/services":    {Kind: Service, Enforcement: EnforcementDeny},
+			"a/nodes":       {Kind: Node},
+			"builtin":       {Kind: HelmRelease, Enforcement: EnforcementDeny},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		overrides map[string]Enforcement
+		expected  Enforcement
+	}{
+		{
+			name:     "strictest collection",
+			expected: EnforcementDeny,
+		},
+		{
+			name:      "deny relaxed by an override",
+			overrides: map[string]Enforcement{"a/services": EnforcementWarn},
+			expected:  EnforcementWarn,
+		},
+		{
+			name:      "all enforced collections disabled",
+			overrides: map[string]Enforcement{"a/services": EnforcementOff, "a/deployments": EnforcementOff},
+			expected:  EnforcementNone,
+		},
+		{
+			name:      "override of a collection which cannot be enforced",
+			overrides: map[string]Enforcement{"a/services": EnforcementOff, "a/deployments": EnforcementOff, "a/nodes": EnforcementDeny},
+			expected:  EnforcementNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StrictestEnforcement(policies, tt.overrides); got != tt.expected {
+				t.Errorf("expected enforcement %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	if got := StrictestEnforcement(nil, nil); got != EnforcementNone {
+		t.Errorf("expected no enforcement without policies, got %q", got)
+	}
+}
+
+func TestLoadOPAPolicyBundlesSkipsBrokenBundles(t *testing.T) {
+	bundle := func(name string, version uint, policies map[string]string) *models.OPAPolicyBundle {
+		encoded, err := json.Marshal(policies)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return &models.OPAPolicyBundle{Name: name, Version: version, Config: []byte(replicasBundleConfig), Policies: encoded}
+	}
+
+	policies, skipped := LoadOPAPolicyBundles([]*models.OPAPolicyBundle{
+		bundle("broken", 3, map[string]string{"replicas.rego": "package replicas.minimum\n\nallow if {"}),
+		bundle("valid", 2, map[string]string{"replicas.rego": replicasPolicy}),
+	})
+
+	if _, ok := policies.Policies["valid/replicas"]; !ok || len(policies.Policies) != 1 {
+		t.Errorf("expected only the collections of the valid bundle, got %v", policies.Policies)
+	}
+
+	if len(skipped) != 1 || skipped[0].Name != "broken" || skipped[0].Version != 3 || skipped[0].Err == nil {
+		t.Errorf("expected the broken bundle to be skipped, got %+v", skipped)
+	}
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"path"
 	"path/filepath"
+	"regexp"
 
+	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
+	"github.com/porter-dev/porter/internal/models"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/yaml"
 )
 
@@ -17,6 +22,7 @@ type ConfigFilePolicyCollection struct {
 	Match            MatchParameters    `json:"match"`
 	MustExist        bool               `json:"mustExist"`
 	OverrideSeverity string             `json:"override_severity"`
+	Enforcement      Enforcement        `json:"enforcement"`
 	Policies         []ConfigFilePolicy `json:"policies"`
 }
 
@@ -25,6 +31,41 @@ type ConfigFilePolicy struct {
 	Name string
 }
 
+// disallowedBuiltins are the builtins which policies cannot call, since project policy bundles are written by users
+// and evaluated by the server: they would otherwise be able to send requests from the network of the server or read
+// its environment
+var disallowedBuiltins = map[string]bool{
+	ast.HTTPSend.Name:        true,
+	ast.NetLookupIPAddr.Name: true,
+	ast.OPARuntime.Name:      true,
+	ast.Trace.Name:           true,
+}
+
+// policyCapabilities are the capabilities policies are compiled with
+var policyCapabilities = restrictedCapabilities()
+
+func restrictedCapabilities() *ast.Capabilities {
+	capabilities := ast.CapabilitiesForThisVersion()
+
+	builtins := make([]*ast.Builtin, 0, len(capabilities.Builtins))
+
+	for _, builtin := range capabilities.Builtins {
+		if !disallowedBuiltins[builtin.Name] {
+			builtins = append(builtins, builtin)
+		}
+	}
+
+	capabilities.Builtins = builtins
+
+	// an empty list of hosts disallows all network access
+	capabilities.AllowNet = []string{}
+
+	return capabilities
+}
+
+// bundleNameRegex matches valid project policy bundle names
+var bundleNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
 func LoadPolicies(configFilePathDir string) (*KubernetesPolicies, error) {
 	// read and parse the config file
 	fileBytes, err := ioutil.ReadFile(filepath.Join(configFilePathDir, "config.yaml"))
@@ -40,6 +81,173 @@ func LoadPolicies(configFilePathDir string) (*KubernetesPolicies, error) {
 		return nil, err
 	}
 
+	return loadPolicyCollections(configFile, "", func(policyPath string) (string, error) {
+		fileBytes, err := ioutil.ReadFile(filepath.Join(configFilePathDir, policyPath))
+		if err != nil {
+			return "", err
+		}
+
+		return string(fileBytes), nil
+	})
+}
+
+// LoadPolicyBundle compiles a project policy bundle. The config of the bundle lists policy collections in the
+// format of the built-in config file, and policies maps the paths referenced by the config to rego modules.
+//
+// The collections of a bundle are named "<bundle name>/<collection name>", so that they cannot collide with the
+// built-in collections or the collections of other bundles.
+func LoadPolicyBundle(name string, config []byte, policies map[string]string) (*KubernetesPolicies, error) {
+	if !bundleNameRegex.MatchString(name) {
+		return nil, fmt.Errorf("invalid bundle name %q: must consist of lowercase alphanumeric characters or '-'", name)
+	}
+
+	configFile := make(map[string]ConfigFilePolicyCollection)
+
+	err := yaml.UnmarshalStrict(config, &configFile)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing bundle config: %w", err)
+	}
+
+	if len(configFile) == 0 {
+		return nil, fmt.Errorf("bundle config must define at least one policy collection")
+	}
+
+	modules := make(map[string]string)
+
+	for policyPath, module := range policies {
+		modules[path.Clean(policyPath)] = module
+	}
+
+	for collectionName, collection := range configFile {
+		if err := validateCollection(collection); err != nil {
+			return nil, fmt.Errorf("invalid policy collection %s: %w", collectionName, err)
+		}
+	}
+
+	return loadPolicyCollections(configFile, name+"/", func(policyPath string) (string, error) {
+		module, ok := modules[path.Clean(policyPath)]
+		if !ok {
+			return "", fmt.Errorf("policy %s is not included in the bundle", policyPath)
+		}
+
+		return module, nil
+	})
+}
+
+// LoadOPAPolicyBundle compiles a stored version of a project policy bundle
+func LoadOPAPolicyBundle(bundle *models.OPAPolicyBundle) (*KubernetesPolicies, error) {
+	if bundle == nil {
+		return nil, fmt.Errorf("bundle is nil")
+	}
+
+	policies, err := bundle.GetPolicies()
+	if err != nil {
+		return nil, fmt.Errorf("error reading policies of bundle %s v%d: %w", bundle.Name, bundle.Version, err)
+	}
+
+	return LoadPolicyBundle(bundle.Name, bundle.Config, policies)
+}
+
+// SkippedPolicyBundle is a stored project policy bundle which could not be compiled, and whose policies are not evaluated
+type SkippedPolicyBundle struct {
+	Name    string
+	Version uint
+	Err     error
+}
+
+// LoadOPAPolicyBundles compiles the stored versions of the policy bundles of a project, and returns their merged
+// policies along with the bundles which could not be compiled. Bundles are compiled when they are uploaded, so a
+// bundle which no longer compiles is skipped rather than disabling the policies of every other bundle of the project.
+func LoadOPAPolicyBundles(bundles []*models.OPAPolicyBundle) (*KubernetesPolicies, []SkippedPolicyBundle) {
+	policies := &KubernetesPolicies{
+		Policies: make(map[string]KubernetesOPAQueryCollection),
+	}
+
+	skipped := make([]SkippedPolicyBundle, 0)
+
+	for _, bundle := range bundles {
+		bundlePolicies, err := LoadOPAPolicyBundle(bundle)
+		if err != nil {
+			skipped = append(skipped, SkippedPolicyBundle{
+				Name:    bundle.Name,
+				Version: bundle.Version,
+				Err:     err,
+			})
+
+			continue
+		}
+
+		policies = policies.Merge(bundlePolicies)
+	}
+
+	return policies, skipped
+}
+
+// Merge returns the policies along with the given policies. Collections of the given policies replace collections
+// of the same name.
+func (p *KubernetesPolicies) Merge(other *KubernetesPolicies) *KubernetesPolicies {
+	policies := make(map[string]KubernetesOPAQueryCollection)
+
+	for _, src := range []*KubernetesPolicies{p, other} {
+		if src == nil {
+			continue
+		}
+
+		for name, collection := range src.Policies {
+			policies[name] = collection
+		}
+	}
+
+	return &KubernetesPolicies{
+		Policies: policies,
+	}
+}
+
+func validateCollection(collection ConfigFilePolicyCollection) error {
+	switch KubernetesBuiltInKind(collection.Kind) {
+	case HelmRelease:
+		if collection.Match.Name == "" && collection.Match.ChartName == "" {
+			return fmt.Errorf("helm_release collections must match a name or a chart_name")
+		}
+	case CRDList:
+		if collection.Match.Version == "" || collection.Match.Resource == "" {
+			return fmt.Errorf("crd_list collections must match a version and a resource")
+		}
+	case Pod, Daemonset, Deployment, Ingress, Service, HPA, Node, PVC:
+	default:
+		return fmt.Errorf("unsupported kind %q", collection.Kind)
+	}
+
+	if _, err := labels.Parse(labelSelector(collection.Match)); err != nil {
+		return fmt.Errorf("invalid labels: %w", err)
+	}
+
+	if _, err := regexp.Compile(collection.Match.NameRegex); err != nil {
+		return fmt.Errorf("invalid name_regex: %w", err)
+	}
+
+	switch collection.Enforcement {
+	case EnforcementNone:
+	case EnforcementWarn, EnforcementDeny:
+		if _, ok := admissionKinds[KubernetesBuiltInKind(collection.Kind)]; !ok {
+			return fmt.Errorf("enforcement is not supported for %s collections", collection.Kind)
+		}
+	default:
+		return fmt.Errorf("invalid enforcement %q: must be one of warn, deny", collection.Enforcement)
+	}
+
+	if len(collection.Policies) == 0 {
+		return fmt.Errorf("at least one policy is required")
+	}
+
+	return nil
+}
+
+func loadPolicyCollections(
+	configFile ConfigFile,
+	namePrefix string,
+	readPolicy func(policyPath string) (string, error),
+) (*KubernetesPolicies, error) {
 	// load each map entry
 	policies := make(map[string]KubernetesOPAQueryCollection)
 
@@ -47,29 +255,26 @@ func LoadPolicies(configFilePathDir string) (*KubernetesPolicies, error) {
 		queries := make([]rego.PreparedEvalQuery, 0)
 
 		for _, cfPolicy := range cfPolicyCollection.Policies {
-			fileBytes, err := ioutil.ReadFile(filepath.Join(configFilePathDir, cfPolicy.Path))
+			module, err := readPolicy(cfPolicy.Path)
 			if err != nil {
 				return nil, err
 			}
 
-			query, err := rego.New(
-				rego.Query(fmt.Sprintf("data.%s", cfPolicy.Name)),
-				rego.Module(cfPolicy.Name, string(fileBytes)),
-			).PrepareForEval(context.Background())
+			query, err := compilePolicy(cfPolicy, module)
 			if err != nil {
-				// Handle error.
 				return nil, err
 			}
 
 			queries = append(queries, query)
 		}
 
-		policies[name] = KubernetesOPAQueryCollection{
+		policies[namePrefix+name] = KubernetesOPAQueryCollection{
 			Kind:             KubernetesBuiltInKind(cfPolicyCollection.Kind),
 			Queries:          queries,
 			Match:            cfPolicyCollection.Match,
 			OverrideSeverity: cfPolicyCollection.OverrideSeverity,
 			MustExist:        cfPolicyCollection.MustExist,
+			Enforcement:      cfPolicyCollection.Enforcement,
 		}
 	}
 
@@ -77,3 +282,26 @@ func LoadPolicies(configFilePathDir string) (*KubernetesPolicies, error) {
 		Policies: policies,
 	}, nil
 }
+
+func compilePolicy(cfPolicy ConfigFilePolicy, module string) (rego.PreparedEvalQuery, error) {
+	// a module which does not declare the package of the policy compiles, but its query is always undefined
+	parsed, err := ast.ParseModule(cfPolicy.Path, module)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, err
+	}
+
+	if pkg := parsed.Package.Path.String(); pkg != fmt.Sprintf("data.%s", cfPolicy.Name) {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("policy %s declares package %s, expected data.%s", cfPolicy.Path, pkg, cfPolicy.Name)
+	}
+
+	query, err := rego.New(
+		rego.Query(fmt.Sprintf("data.%s", cfPolicy.Name)),
+		rego.Module(cfPolicy.Name, module),
+		rego.Capabilities(policyCapabilities),
+	).PrepareForEval(context.Background())
+	if err != nil {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("error compiling policy %s: %w", cfPolicy.Path, err)
+	}
+
+	return query, nil
+}
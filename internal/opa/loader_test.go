@@ -0,0 +1,156 @@
+package opa
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-policy-agent/opa/topdown"
+)
+
+// policyWithRule returns a module of the replicas.minimum package whose allow rule has the given body
+func policyWithRule(body string) string {
+	return `package replicas.minimum
+
+import future.keywords.if
+
+allow if {
+	` + body + `
+}
+`
+}
+
+func TestLoadPolicyBundleInvalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		bundleName    string
+		config        string
+		policies      map[string]string
+		expectedError string
+	}{
+		{
+			name:          "http.send",
+			config:        replicasBundleConfig,
+			policies:      map[string]string{"replicas.rego": policyWithRule(`http.send({"method": "get", "url": "http://169.254.169.254"})`)},
+			expectedError: "http.send",
+		},
+		{
+			name:          "net.lookup_ip_addr",
+			config:        replicasBundleConfig,
+			policies:      map[string]string{"replicas.rego": policyWithRule(`net.lookup_ip_addr("internal.example.com")`)},
+			expectedError: "net.lookup_ip_addr",
+		},
+		{
+			name:          "opa.runtime",
+			config:        replicasBundleConfig,
+			policies:      map[string]string{"replicas.rego": policyWithRule(`opa.runtime().env`)},
+			expectedError: "opa.runtime",
+		},
+		{
+			name:          "package which does not match the policy name",
+			config:        replicasBundleConfig,
+			policies:      map[string]string{"replicas.rego": strings.Replace(replicasPolicy, "package replicas.minimum", "package replicas.other", 1)},
+			expectedError: "declares package data.replicas.other",
+		},
+		{
+			name:          "policy missing from the bundle",
+			config:        replicasBundleConfig,
+			policies:      map[string]string{"other.rego": replicasPolicy},
+			expectedError: "not included in the bundle",
+		},
+		{
+			name:          "unsupported kind",
+			config:        strings.Replace(replicasBundleConfig, "kind: deployment", "kind: statefulset", 1),
+			policies:      map[string]string{"replicas.rego": replicasPolicy},
+			expectedError: `unsupported kind "statefulset"`,
+		},
+		{
+			name:          "invalid label selector",
+			config:        strings.Replace(replicasBundleConfig, `label_selector: "porter.run/app-name"`, `label_selector: "tier in ("`, 1),
+			policies:      map[string]string{"replicas.rego": replicasPolicy},
+			expectedError: "invalid labels",
+		},
+		{
+			name:          "invalid name regex",
+			config:        strings.Replace(replicasBundleConfig, `label_selector: "porter.run/app-name"`, `name_regex: "web-("`, 1),
+			policies:      map[string]string{"replicas.rego": replicasPolicy},
+			expectedError: "invalid name_regex",
+		},
+		{
+			name:          "enforcement of a kind which is not admitted",
+			config:        strings.Replace(replicasBundleConfig, "kind: deployment", "kind: node", 1),
+			policies:      map[string]string{"replicas.rego": replicasPolicy},
+			expectedError: "enforcement is not supported for node collections",
+		},
+		{
+			name:          "invalid bundle name",
+			bundleName:    "Replicas",
+			config:        replicasBundleConfig,
+			policies:      map[string]string{"replicas.rego": replicasPolicy},
+			expectedError: "invalid bundle name",
+		},
+		{
+			name:          "unknown config field",
+			config:        replicasBundleConfig + "  severity: high\n",
+			policies:      map[string]string{"replicas.rego": replicasPolicy},
+			expectedError: "error parsing bundle config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundleName := tt.bundleName
+			if bundleName == "" {
+				bundleName = "test"
+			}
+
+			_, err := LoadPolicyBundle(bundleName, []byte(tt.config), tt.policies)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+
+			if !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("expected error containing %q, got %q", tt.expectedError, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadPolicyBundle(t *testing.T) {
+	policies := loadTestBundle(t, "test", replicasBundleConfig, map[string]string{"./replicas.rego": replicasPolicy})
+
+	collection, ok := policies.Policies["test/replicas"]
+	if !ok || len(policies.Policies) != 1 {
+		t.Fatalf("expected a single collection named test/replicas, got %v", policies.Policies)
+	}
+
+	if collection.Kind != Deployment || collection.Enforcement != EnforcementDeny || len(collection.Queries) != 1 {
+		t.Errorf("unexpected collection: kind %s, enforcement %s, %d queries", collection.Kind, collection.Enforcement, len(collection.Queries))
+	}
+}
+
+func TestEvalPolicyDeadline(t *testing.T) {
+	// the rule iterates over a billion combinations without allocating, so it only returns once the evaluation is canceled
+	policies := loadTestBundle(t, "test", replicasBundleConfig, map[string]string{"replicas.rego": policyWithRule(`r := numbers.range(1, 1000)
+	some a, b, c
+	r[a] + r[b] + r[c] < 0`)})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	_, err := evalPolicy(ctx, policies.Policies["test/replicas"].Queries[0], map[string]interface{}{})
+	if err == nil {
+		t.Fatalf("expected the evaluation to be canceled")
+	}
+
+	if !topdown.IsCancel(err) {
+		t.Errorf("expected a cancellation error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > PolicyEvalTimeout {
+		t.Errorf("expected the evaluation to stop at the deadline, took %s", elapsed)
+	}
+}
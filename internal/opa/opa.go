@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/open-policy-agent/opa/rego"
@@ -14,6 +17,10 @@ import (
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/pkg/logger"
 	"github.com/stefanmcshane/helm/pkg/release"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -32,6 +39,17 @@ type KubernetesOPARunner struct {
 	dynamicClient dynamic.Interface
 }
 
+// PolicyEvalTimeout is the maximum duration of the evaluation of a single policy against a single object
+const PolicyEvalTimeout = 5 * time.Second
+
+// evalPolicy evaluates a policy against an object, and cancels the evaluation if it exceeds PolicyEvalTimeout
+func evalPolicy(ctx context.Context, query rego.PreparedEvalQuery, input interface{}) (rego.ResultSet, error) {
+	ctx, cancel := context.WithTimeout(ctx, PolicyEvalTimeout)
+	defer cancel()
+
+	return query.Eval(ctx, rego.EvalInput(input))
+}
+
 type KubernetesBuiltInKind string
 
 const (
@@ -39,6 +57,12 @@ const (
 	Pod         KubernetesBuiltInKind = "pod"
 	CRDList     KubernetesBuiltInKind = "crd_list"
 	Daemonset   KubernetesBuiltInKind = "daemonset"
+	Deployment  KubernetesBuiltInKind = "deployment"
+	Ingress     KubernetesBuiltInKind = "ingress"
+	Service     KubernetesBuiltInKind = "service"
+	HPA         KubernetesBuiltInKind = "hpa"
+	Node        KubernetesBuiltInKind = "node"
+	PVC         KubernetesBuiltInKind = "pvc"
 )
 
 type KubernetesOPAQueryCollection struct {
@@ -47,6 +71,9 @@ type KubernetesOPAQueryCollection struct {
 	MustExist        bool
 	OverrideSeverity string
 	Queries          []rego.PreparedEvalQuery
+
+	// Enforcement is set for collections which are evaluated against the manifests of an app before it is deployed
+	Enforcement Enforcement
 }
 
 type MatchParameters struct {
@@ -63,6 +90,13 @@ type MatchParameters struct {
 	// generic labels parameter
 	Labels map[string]string `json:"labels"`
 
+	// LabelSelector is a label selector, like `tier in (web, worker)`, which is combined with Labels
+	LabelSelector string `json:"label_selector"`
+
+	// NameRegex filters objects by name. Names only need to contain a match, so the regex should be anchored to
+	// match full names.
+	NameRegex string `json:"name_regex"`
+
 	// parameters for CRDs
 	Group    string `json:"group"`
 	Version  string `json:"version"`
@@ -139,6 +173,8 @@ func (runner *KubernetesOPARunner) GetRecommendations(categories []string) ([]*O
 				currResults, err = runner.runCRDListQueries(name, queryCollection)
 			case Daemonset:
 				currResults, err = runner.runDaemonsetQueries(name, queryCollection)
+			case Deployment, Ingress, Service, HPA, Node, PVC:
+				currResults, err = runner.runObjectQueries(name, queryCollection)
 			default:
 				fmt.Printf("%s is not a supported query kind", queryCollection.Kind)
 				continue
@@ -230,15 +266,12 @@ func (runner *KubernetesOPARunner) runHelmReleaseQueries(name string, collection
 
 	for _, helmRelease := range helmReleases {
 		for _, query := range collection.Queries {
-			results, err := query.Eval(
-				context.Background(),
-				rego.EvalInput(map[string]interface{}{
-					"version":   helmRelease.Chart.Metadata.Version,
-					"values":    helmRelease.Config,
-					"name":      helmRelease.Name,
-					"namespace": helmRelease.Namespace,
-				}),
-			)
+			results, err := evalPolicy(context.Background(), query, map[string]interface{}{
+				"version":   helmRelease.Chart.Metadata.Version,
+				"values":    helmRelease.Config,
+				"name":      helmRelease.Name,
+				"namespace": helmRelease.Namespace,
+			})
 			if err != nil {
 				return nil, err
 			}
@@ -274,87 +307,170 @@ func getSeverity(defaultSeverity string, collection KubernetesOPAQueryCollection
 }
 
 func (runner *KubernetesOPARunner) runPodQueries(name string, collection KubernetesOPAQueryCollection) ([]*OPARecommenderQueryResult, error) {
-	res := make([]*OPARecommenderQueryResult, 0)
-
-	lselArr := make([]string, 0)
+	pods, err := runner.k8sAgent.GetPodsByLabel(labelSelector(collection.Match), collection.Match.Namespace)
+	if err != nil {
+		return nil, err
+	}
 
-	for k, v := range collection.Match.Labels {
-		lselArr = append(lselArr, fmt.Sprintf("%s=%s", k, v))
+	objects, err := toKubernetesObjects(pods.Items, func(pod *corev1.Pod) (string, string) { return pod.Namespace, pod.Name })
+	if err != nil {
+		return nil, err
 	}
 
-	lsel := strings.Join(lselArr, ",")
+	return runner.evalObjectQueries(name, collection, objects, policyObjectID(collection.Kind))
+}
 
-	pods, err := runner.k8sAgent.GetPodsByLabel(lsel, collection.Match.Namespace)
+func (runner *KubernetesOPARunner) runDaemonsetQueries(name string, collection KubernetesOPAQueryCollection) ([]*OPARecommenderQueryResult, error) {
+	daemonsets, err := runner.k8sAgent.Clientset.AppsV1().DaemonSets(collection.Match.Namespace).List(context.Background(), v1.ListOptions{
+		LabelSelector: labelSelector(collection.Match),
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	for _, pod := range pods.Items {
-		unstructuredPod, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&pod)
-		if err != nil {
-			return nil, err
-		}
+	objects, err := toKubernetesObjects(daemonsets.Items, func(ds *appsv1.DaemonSet) (string, string) { return ds.Namespace, ds.Name })
+	if err != nil {
+		return nil, err
+	}
 
-		for _, query := range collection.Queries {
-			results, err := query.Eval(
-				context.Background(),
-				rego.EvalInput(unstructuredPod),
-			)
-			if err != nil {
-				return nil, err
-			}
+	return runner.evalObjectQueries(name, collection, objects, policyObjectID(collection.Kind))
+}
 
-			if len(results) == 1 {
-				rawQueryRes := &rawQueryResult{}
+// runObjectQueries runs the queries of a collection against each object of the collection's kind which matches the
+// namespace, labels and name regex of the collection. Nodes are not namespaced, so the namespace is ignored for nodes.
+func (runner *KubernetesOPARunner) runObjectQueries(name string, collection KubernetesOPAQueryCollection) ([]*OPARecommenderQueryResult, error) {
+	ctx := context.Background()
+	clientset := runner.k8sAgent.Clientset
+	namespace := collection.Match.Namespace
 
-				err = mapstructure.Decode(results[0].Expressions[0].Value, rawQueryRes)
+	listOpts := v1.ListOptions{
+		LabelSelector: labelSelector(collection.Match),
+	}
 
-				if err != nil {
-					return nil, err
-				}
+	var objects []kubernetesObject
+	var err error
 
-				res = append(res, rawQueryResToRecommenderQueryResult(
-					rawQueryRes,
-					fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name),
-					name,
-					collection,
-				))
-			}
+	switch collection.Kind {
+	case Deployment:
+		list, listErr := clientset.AppsV1().Deployments(namespace).List(ctx, listOpts)
+		if listErr != nil {
+			return nil, listErr
 		}
-	}
 
-	return res, nil
-}
+		objects, err = toKubernetesObjects(list.Items, func(obj *appsv1.Deployment) (string, string) { return obj.Namespace, obj.Name })
+	case Ingress:
+		list, listErr := clientset.NetworkingV1().Ingresses(namespace).List(ctx, listOpts)
+		if listErr != nil {
+			return nil, listErr
+		}
 
-func (runner *KubernetesOPARunner) runDaemonsetQueries(name string, collection KubernetesOPAQueryCollection) ([]*OPARecommenderQueryResult, error) {
-	res := make([]*OPARecommenderQueryResult, 0)
+		objects, err = toKubernetesObjects(list.Items, func(obj *networkingv1.Ingress) (string, string) { return obj.Namespace, obj.Name })
+	case Service:
+		list, listErr := clientset.CoreV1().Services(namespace).List(ctx, listOpts)
+		if listErr != nil {
+			return nil, listErr
+		}
 
-	lselArr := make([]string, 0)
+		objects, err = toKubernetesObjects(list.Items, func(obj *corev1.Service) (string, string) { return obj.Namespace, obj.Name })
+	case HPA:
+		// autoscaling/v1 is served by every supported cluster version, and exposes the replica bounds
+		list, listErr := clientset.AutoscalingV1().HorizontalPodAutoscalers(namespace).List(ctx, listOpts)
+		if listErr != nil {
+			return nil, listErr
+		}
 
-	for k, v := range collection.Match.Labels {
-		lselArr = append(lselArr, fmt.Sprintf("%s=%s", k, v))
-	}
+		objects, err = toKubernetesObjects(list.Items, func(obj *autoscalingv1.HorizontalPodAutoscaler) (string, string) { return obj.Namespace, obj.Name })
+	case Node:
+		list, listErr := clientset.CoreV1().Nodes().List(ctx, listOpts)
+		if listErr != nil {
+			return nil, listErr
+		}
 
-	lsel := strings.Join(lselArr, ",")
+		objects, err = toKubernetesObjects(list.Items, func(obj *corev1.Node) (string, string) { return "", obj.Name })
+	case PVC:
+		list, listErr := clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, listOpts)
+		if listErr != nil {
+			return nil, listErr
+		}
+
+		objects, err = toKubernetesObjects(list.Items, func(obj *corev1.PersistentVolumeClaim) (string, string) { return obj.Namespace, obj.Name })
+	default:
+		return nil, fmt.Errorf("%s is not a supported object kind", collection.Kind)
+	}
 
-	daemonsets, err := runner.k8sAgent.Clientset.AppsV1().DaemonSets(collection.Match.Namespace).List(context.Background(), v1.ListOptions{
-		LabelSelector: lsel,
-	})
 	if err != nil {
 		return nil, err
 	}
 
-	for _, ds := range daemonsets.Items {
-		unstructuredDS, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&ds)
+	return runner.evalObjectQueries(name, collection, objects, policyObjectID(collection.Kind))
+}
+
+// policyObjectID returns the object ID of the result of a policy evaluated against an object of the given kind. The ID
+// includes the policy ID, so that the results of the policies of a collection for the same object do not collide.
+func policyObjectID(kind KubernetesBuiltInKind) func(object kubernetesObject, rawQueryRes *rawQueryResult) string {
+	return func(object kubernetesObject, rawQueryRes *rawQueryResult) string {
+		if object.namespace == "" {
+			return fmt.Sprintf("%s/%s/%s", kind, object.name, rawQueryRes.PolicyID)
+		}
+
+		return fmt.Sprintf("%s/%s/%s/%s", kind, object.namespace, object.name, rawQueryRes.PolicyID)
+	}
+}
+
+// kubernetesObject is a Kubernetes object in the unstructured form which is passed as input to queries
+type kubernetesObject struct {
+	namespace string
+	name      string
+	object    map[string]interface{}
+}
+
+func toKubernetesObjects[T any](items []T, key func(item *T) (namespace, name string)) ([]kubernetesObject, error) {
+	res := make([]kubernetesObject, 0, len(items))
+
+	for i := range items {
+		unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&items[i])
 		if err != nil {
 			return nil, err
 		}
 
+		namespace, name := key(&items[i])
+
+		res = append(res, kubernetesObject{
+			namespace: namespace,
+			name:      name,
+			object:    unstructuredObj,
+		})
+	}
+
+	return res, nil
+}
+
+func (runner *KubernetesOPARunner) evalObjectQueries(
+	name string,
+	collection KubernetesOPAQueryCollection,
+	objects []kubernetesObject,
+	objectID func(object kubernetesObject, rawQueryRes *rawQueryResult) string,
+) ([]*OPARecommenderQueryResult, error) {
+	res := make([]*OPARecommenderQueryResult, 0)
+
+	var nameRegex *regexp.Regexp
+
+	if collection.Match.NameRegex != "" {
+		var err error
+
+		nameRegex, err = regexp.Compile(collection.Match.NameRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name regex: %w", err)
+		}
+	}
+
+	for _, object := range objects {
+		if nameRegex != nil && !nameRegex.MatchString(object.name) {
+			continue
+		}
+
 		for _, query := range collection.Queries {
-			results, err := query.Eval(
-				context.Background(),
-				rego.EvalInput(unstructuredDS),
-			)
+			results, err := evalPolicy(context.Background(), query, object.object)
 			if err != nil {
 				return nil, err
 			}
@@ -370,7 +486,7 @@ func (runner *KubernetesOPARunner) runDaemonsetQueries(name string, collection K
 
 				res = append(res, rawQueryResToRecommenderQueryResult(
 					rawQueryRes,
-					fmt.Sprintf("daemonset/%s/%s", ds.Namespace, ds.Name),
+					objectID(object, rawQueryRes),
 					name,
 					collection,
 				))
@@ -381,6 +497,23 @@ func (runner *KubernetesOPARunner) runDaemonsetQueries(name string, collection K
 	return res, nil
 }
 
+// labelSelector returns the label selector matching both the labels and the label selector of the match parameters
+func labelSelector(match MatchParameters) string {
+	lselArr := make([]string, 0)
+
+	for k, v := range match.Labels {
+		lselArr = append(lselArr, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	sort.Strings(lselArr)
+
+	if match.LabelSelector != "" {
+		lselArr = append(lselArr, match.LabelSelector)
+	}
+
+	return strings.Join(lselArr, ",")
+}
+
 func (runner *KubernetesOPARunner) runCRDListQueries(name string, collection KubernetesOPAQueryCollection) ([]*OPARecommenderQueryResult, error) {
 	res := make([]*OPARecommenderQueryResult, 0)
 
@@ -402,10 +535,7 @@ func (runner *KubernetesOPARunner) runCRDListQueries(name string, collection Kub
 
 	for _, crd := range crdList.Items {
 		for _, query := range collection.Queries {
-			results, err := query.Eval(
-				context.Background(),
-				rego.EvalInput(crd.Object),
-			)
+			results, err := evalPolicy(context.Background(), query, crd.Object)
 			if err != nil {
 				return nil, err
 			}
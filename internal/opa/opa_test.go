@@ -0,0 +1,240 @@
+package opa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// objectMeta returns the metadata of a fixture object created an hour ago, so that policies which allow recently
+// created objects evaluate them
+func objectMeta(namespace, name string, labels map[string]string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace:         namespace,
+		Name:              name,
+		Labels:            labels,
+		CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+var appLabels = map[string]string{"porter.run/app-name": "api"}
+
+var starterPolicyFixtures = []runtime.Object{
+	&appsv1.Deployment{
+		ObjectMeta: objectMeta("default", "web", appLabels),
+		Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+		Status:     appsv1.DeploymentStatus{AvailableReplicas: 2},
+	},
+	&appsv1.Deployment{
+		ObjectMeta: objectMeta("default", "worker", appLabels),
+		Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(3)},
+		Status:     appsv1.DeploymentStatus{AvailableReplicas: 1},
+	},
+	&appsv1.Deployment{
+		ObjectMeta: objectMeta("default", "unlabeled", nil),
+		Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(1)},
+	},
+	&networkingv1.Ingress{
+		ObjectMeta: objectMeta("default", "web", appLabels),
+		Spec: networkingv1.IngressSpec{
+			TLS:   []networkingv1.IngressTLS{{Hosts: []string{"web.example.com"}}},
+			Rules: []networkingv1.IngressRule{{Host: "web.example.com"}},
+		},
+	},
+	&networkingv1.Ingress{
+		ObjectMeta: objectMeta("default", "docs", appLabels),
+		Spec: networkingv1.IngressSpec{
+			TLS:   []networkingv1.IngressTLS{{Hosts: []string{"web.example.com"}}},
+			Rules: []networkingv1.IngressRule{{Host: "web.example.com"}, {Host: "docs.example.com"}},
+		},
+	},
+	&corev1.Service{
+		ObjectMeta: objectMeta("ingress-nginx", "provisioned", nil),
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+			Ingress: []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}},
+		}},
+	},
+	&corev1.Service{
+		ObjectMeta: objectMeta("ingress-nginx", "pending", nil),
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+	},
+	&corev1.Service{
+		ObjectMeta: objectMeta("ingress-nginx", "internal", nil),
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+	},
+	&autoscalingv1.HorizontalPodAutoscaler{
+		ObjectMeta: objectMeta("default", "web", appLabels),
+		Spec:       autoscalingv1.HorizontalPodAutoscalerSpec{MinReplicas: int32Ptr(1), MaxReplicas: 5},
+		Status:     autoscalingv1.HorizontalPodAutoscalerStatus{CurrentReplicas: 2},
+	},
+	&autoscalingv1.HorizontalPodAutoscaler{
+		ObjectMeta: objectMeta("default", "worker", appLabels),
+		Spec:       autoscalingv1.HorizontalPodAutoscalerSpec{MinReplicas: int32Ptr(3), MaxReplicas: 3},
+		Status:     autoscalingv1.HorizontalPodAutoscalerStatus{CurrentReplicas: 3},
+	},
+	&corev1.Node{
+		ObjectMeta: objectMeta("", "healthy", nil),
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+		}},
+	},
+	&corev1.Node{
+		ObjectMeta: objectMeta("", "under-pressure", nil),
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue},
+		}},
+	},
+	&corev1.PersistentVolumeClaim{
+		ObjectMeta: objectMeta("default", "data", nil),
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	},
+	&corev1.PersistentVolumeClaim{
+		ObjectMeta: objectMeta("default", "unbound", nil),
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+	},
+	&corev1.Pod{
+		ObjectMeta: objectMeta("porter-agent-system", "porter-agent-loki-0", map[string]string{"app": "loki", "name": "porter-agent-loki"}),
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "loki", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		},
+	},
+	&appsv1.DaemonSet{
+		ObjectMeta: objectMeta("porter-agent-system", "promtail", map[string]string{
+			"app.kubernetes.io/instance": "porter-agent",
+			"app.kubernetes.io/name":     "promtail",
+		}),
+		Status: appsv1.DaemonSetStatus{NumberReady: 2, DesiredNumberScheduled: 3},
+	},
+}
+
+func TestStarterPolicies(t *testing.T) {
+	policies, err := LoadPolicies(".")
+	if err != nil {
+		t.Fatalf("expected the starter policies to compile: %v", err)
+	}
+
+	runner := NewRunner(policies, nil, &kubernetes.Agent{Clientset: fake.NewSimpleClientset(starterPolicyFixtures...)}, nil)
+
+	tests := []struct {
+		collection string
+		// expected maps the object IDs of the results of the collection to whether the policy allows the object
+		expected map[string]bool
+	}{
+		{
+			collection: "deployments",
+			expected: map[string]bool{
+				"deployment/default/web/deployment_available":    true,
+				"deployment/default/worker/deployment_available": false,
+			},
+		},
+		{
+			collection: "ingresses",
+			expected: map[string]bool{
+				"ingress/default/web/ingress_tls":  true,
+				"ingress/default/docs/ingress_tls": false,
+			},
+		},
+		{
+			collection: "load_balancer_services",
+			expected: map[string]bool{
+				"service/ingress-nginx/provisioned/service_load_balancer": true,
+				"service/ingress-nginx/pending/service_load_balancer":     false,
+				"service/ingress-nginx/internal/service_load_balancer":    true,
+			},
+		},
+		{
+			collection: "hpas",
+			expected: map[string]bool{
+				"hpa/default/web/hpa_bounds":    true,
+				"hpa/default/worker/hpa_bounds": false,
+			},
+		},
+		{
+			collection: "node_pressure",
+			expected: map[string]bool{
+				"node/healthy/node_pressure":        true,
+				"node/under-pressure/node_pressure": false,
+			},
+		},
+		{
+			collection: "pvcs",
+			expected: map[string]bool{
+				"pvc/default/data/pvc_bound":    true,
+				"pvc/default/unbound/pvc_bound": false,
+			},
+		},
+		{
+			collection: "porter_agent_loki_pod",
+			expected: map[string]bool{
+				"pod/porter-agent-system/porter-agent-loki-0/pod_running": true,
+			},
+		},
+		{
+			collection: "porter_agent_promtail_daemonset",
+			expected: map[string]bool{
+				"daemonset/porter-agent-system/promtail/daemonset_running": false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.collection, func(t *testing.T) {
+			collection, ok := policies.Policies[tt.collection]
+			if !ok {
+				t.Fatalf("collection %s is not a starter policy collection", tt.collection)
+			}
+
+			var results []*OPARecommenderQueryResult
+
+			switch collection.Kind {
+			case Pod:
+				results, err = runner.runPodQueries(tt.collection, collection)
+			case Daemonset:
+				results, err = runner.runDaemonsetQueries(tt.collection, collection)
+			default:
+				results, err = runner.runObjectQueries(tt.collection, collection)
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(results) != len(tt.expected) {
+				t.Fatalf("expected %d results, got %d", len(tt.expected), len(results))
+			}
+
+			for _, res := range results {
+				allow, ok := tt.expected[res.ObjectID]
+				if !ok {
+					t.Errorf("unexpected result for object %s", res.ObjectID)
+					continue
+				}
+
+				if res.Allow != allow {
+					t.Errorf("expected allow %t for object %s, got %t: %s", allow, res.ObjectID, res.Allow, res.PolicyMessage)
+				}
+
+				if res.CategoryName != tt.collection || res.PolicyTitle == "" || res.PolicyMessage == "" {
+					t.Errorf("expected the result for object %s to have a category, title and message, got %+v", res.ObjectID, res)
+				}
+			}
+		})
+	}
+}
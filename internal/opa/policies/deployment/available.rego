@@ -0,0 +1,26 @@
+package deployment.available
+
+import future.keywords
+
+POLICY_ID := "deployment_available"
+
+POLICY_VERSION := "v0.0.1"
+
+POLICY_SEVERITY := "high"
+
+POLICY_TITLE := sprintf("Deployment %s in namespace %s should have all replicas available", [input.metadata.name, input.metadata.namespace])
+
+desired_replicas := object.get(input.spec, "replicas", 1)
+
+available_replicas := object.get(input.status, "availableReplicas", 0)
+
+POLICY_SUCCESS_MESSAGE := sprintf("Success: deployment has %d / %d replicas available", [available_replicas, desired_replicas])
+
+allow if {
+	available_replicas >= desired_replicas
+}
+
+FAILURE_MESSAGE contains msg if {
+	not allow
+	msg := sprintf("Failed: deployment %s only has %d out of %d replicas available", [input.metadata.name, available_replicas, desired_replicas])
+}
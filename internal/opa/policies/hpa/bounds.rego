@@ -0,0 +1,35 @@
+package hpa.bounds
+
+import future.keywords
+
+POLICY_ID := "hpa_bounds"
+
+POLICY_VERSION := "v0.0.1"
+
+POLICY_SEVERITY := "low"
+
+POLICY_TITLE := sprintf("Autoscaler %s in namespace %s should be able to scale within its bounds", [input.metadata.name, input.metadata.namespace])
+
+min_replicas := object.get(input.spec, "minReplicas", 1)
+
+max_replicas := input.spec.maxReplicas
+
+current_replicas := object.get(input.status, "currentReplicas", 0)
+
+POLICY_SUCCESS_MESSAGE := sprintf("Success: autoscaler is running %d replicas, between %d and %d", [current_replicas, min_replicas, max_replicas])
+
+allow if {
+	max_replicas > min_replicas
+	current_replicas < max_replicas
+}
+
+FAILURE_MESSAGE contains msg if {
+	max_replicas <= min_replicas
+	msg := sprintf("Failed: the maximum replicas (%d) must be greater than the minimum replicas (%d) for the autoscaler to scale", [max_replicas, min_replicas])
+}
+
+FAILURE_MESSAGE contains msg if {
+	max_replicas > min_replicas
+	current_replicas >= max_replicas
+	msg := sprintf("Failed: the autoscaler is running its maximum of %d replicas and cannot scale up further", [max_replicas])
+}
@@ -0,0 +1,33 @@
+package ingress.tls
+
+import future.keywords
+
+POLICY_ID := "ingress_tls"
+
+POLICY_VERSION := "v0.0.1"
+
+POLICY_SEVERITY := "high"
+
+POLICY_TITLE := sprintf("Ingress %s in namespace %s should serve all of its hosts over TLS", [input.metadata.name, input.metadata.namespace])
+
+POLICY_SUCCESS_MESSAGE := sprintf("Success: all hosts of the ingress are covered by a TLS certificate", [])
+
+tls_hosts contains host if {
+	some tls in object.get(input.spec, "tls", [])
+	some host in object.get(tls, "hosts", [])
+}
+
+hosts_without_tls contains host if {
+	some rule in object.get(input.spec, "rules", [])
+	host := rule.host
+	not host in tls_hosts
+}
+
+allow if {
+	count(hosts_without_tls) == 0
+}
+
+FAILURE_MESSAGE contains msg if {
+	some host in hosts_without_tls
+	msg := sprintf("Failed: host %s is not covered by a TLS certificate", [host])
+}
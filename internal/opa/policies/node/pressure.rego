@@ -0,0 +1,30 @@
+package node.pressure
+
+import future.keywords
+
+POLICY_ID := "node_pressure"
+
+POLICY_VERSION := "v0.0.1"
+
+POLICY_SEVERITY := "high"
+
+POLICY_TITLE := sprintf("The node %s should not be under resource pressure", [input.metadata.name])
+
+POLICY_SUCCESS_MESSAGE := sprintf("Success: the node is not under memory, disk or PID pressure", [])
+
+pressure_conditions := {"MemoryPressure", "DiskPressure", "PIDPressure"}
+
+pressures contains condition.type if {
+	some condition in input.status.conditions
+	condition.type in pressure_conditions
+	condition.status == "True"
+}
+
+allow if {
+	count(pressures) == 0
+}
+
+FAILURE_MESSAGE contains msg if {
+	some pressure in pressures
+	msg := sprintf("Failed: the node %s reports %s", [input.metadata.name, pressure])
+}
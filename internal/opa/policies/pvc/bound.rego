@@ -0,0 +1,31 @@
+package pvc.bound
+
+import future.keywords
+
+POLICY_ID := "pvc_bound"
+
+POLICY_VERSION := "v0.0.1"
+
+POLICY_SEVERITY := "high"
+
+POLICY_TITLE := sprintf("Persistent volume claim %s in namespace %s should be bound to a volume", [input.metadata.name, input.metadata.namespace])
+
+POLICY_SUCCESS_MESSAGE := sprintf("Success: the persistent volume claim is bound", [])
+
+allow if {
+	input.status.phase == "Bound"
+}
+
+# volumes may take a few minutes to be provisioned
+allow if {
+	rfc3339_is_younger_than_10_minutes(input.metadata.creationTimestamp)
+}
+
+FAILURE_MESSAGE contains msg if {
+	not allow
+	msg := sprintf("Failed: the persistent volume claim %s is %s", [input.metadata.name, object.get(input.status, "phase", "Pending")])
+}
+
+rfc3339_is_younger_than_10_minutes(a) if {
+	time.parse_rfc3339_ns(a) + ((((10 * 60) * 1000) * 1000) * 1000) > time.now_ns()
+}
@@ -0,0 +1,36 @@
+package service.load_balancer
+
+import future.keywords
+
+POLICY_ID := "service_load_balancer"
+
+POLICY_VERSION := "v0.0.1"
+
+POLICY_SEVERITY := "high"
+
+POLICY_TITLE := sprintf("Load balancer service %s in namespace %s should have an external address", [input.metadata.name, input.metadata.namespace])
+
+POLICY_SUCCESS_MESSAGE := sprintf("Success: the service is not a load balancer or has an external address", [])
+
+# services which are not load balancers are not provisioned externally
+allow if {
+	input.spec.type != "LoadBalancer"
+}
+
+allow if {
+	count(object.get(input.status, ["loadBalancer", "ingress"], [])) > 0
+}
+
+# load balancers may take a few minutes to be provisioned
+allow if {
+	rfc3339_is_younger_than_10_minutes(input.metadata.creationTimestamp)
+}
+
+FAILURE_MESSAGE contains msg if {
+	not allow
+	msg := sprintf("Failed: the load balancer for service %s has not been provisioned", [input.metadata.name])
+}
+
+rfc3339_is_younger_than_10_minutes(a) if {
+	time.parse_rfc3339_ns(a) + ((((10 * 60) * 1000) * 1000) * 1000) > time.now_ns()
+}
@@ -0,0 +1,97 @@
+package porter_app
+
+import (
+	"context"
+	"strings"
+
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/internal/integrations/dns"
+	"github.com/porter-dev/porter/internal/kubernetes/domain"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// DeletePorterSubdomainsInput is the input to the DeletePorterSubdomains function
+type DeletePorterSubdomainsInput struct {
+	// Records are the dns records to delete
+	Records             []*models.DNSRecord
+	DNSClient           *dns.Client
+	DNSRecordRepository repository.DNSRecordRepository
+}
+
+// DeletePorterSubdomains deletes the porter subdomains of an app or a deployment target from the nameserver and the database.
+// All records are attempted, and the first error is returned.
+func DeletePorterSubdomains(ctx context.Context, input DeletePorterSubdomainsInput) error {
+	ctx, span := telemetry.NewSpan(ctx, "delete-porter-subdomains")
+	defer span.End()
+
+	if input.DNSRecordRepository == nil {
+		return telemetry.Error(ctx, span, nil, "dns record repository is nil")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "record-count", Value: len(input.Records)})
+
+	var firstErr error
+
+	for _, record := range input.Records {
+		if record == nil {
+			continue
+		}
+
+		// records cannot be removed from a nameserver without a dns client, so only their database entries are deleted
+		if input.DNSClient != nil {
+			_record := domain.DNSRecord(*record)
+
+			err := _record.DeleteDomain(input.DNSClient)
+			if err != nil {
+				err = telemetry.Error(ctx, span, err, "error deleting domain")
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+		}
+
+		err := input.DNSRecordRepository.DeleteDNSRecord(record)
+		if err != nil {
+			err = telemetry.Error(ctx, span, err, "error deleting dns record")
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// AppHostnames returns the domains of the web services of an app. Subdomains created before records were assigned to the app
+// they were created for are only found by these hostnames.
+func AppHostnames(app *porterv1.PorterApp) []string {
+	var hostnames []string
+
+	if app == nil {
+		return hostnames
+	}
+
+	services := append([]*porterv1.Service{}, app.ServiceList...)
+	for _, service := range app.Services { // nolint:staticcheck // revisions created before the service list only have the deprecated field
+		services = append(services, service)
+	}
+
+	seen := make(map[string]bool)
+
+	for _, service := range services {
+		for _, d := range service.GetWebConfig().GetDomains() {
+			hostname := strings.ToLower(strings.TrimSuffix(d.GetName(), "."))
+			if hostname == "" || seen[hostname] {
+				continue
+			}
+
+			seen[hostname] = true
+			hostnames = append(hostnames, hostname)
+		}
+	}
+
+	return hostnames
+}
@@ -79,6 +79,32 @@ func ParseYAML(ctx context.Context, porterYaml []byte, appName string) (v2.AppWi
 	return appDefinition, nil
 }
 
+// ValidateYAML strictly validates a Porter YAML file, reporting unknown fields, type mismatches and invalid
+// values with their position in the file. Only v2 files are validated.
+func ValidateYAML(ctx context.Context, porterYaml []byte) error {
+	ctx, span := telemetry.NewSpan(ctx, "porter-app-validate-yaml")
+	defer span.End()
+
+	if porterYaml == nil {
+		return telemetry.Error(ctx, span, nil, "porter yaml input is nil")
+	}
+
+	version := &YamlVersion{}
+	err := yaml.Unmarshal(porterYaml, version)
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "error unmarshaling porter yaml")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "version", Value: string(version.Version)})
+
+	if version.Version != PorterYamlVersion_V2 {
+		return nil
+	}
+
+	// validation errors are returned as is, so that their positions reach the user
+	return v2.ValidateYAML(porterYaml)
+}
+
 // yamlVersion is a struct used to unmarshal the version field of a Porter YAML file
 type YamlVersion struct {
 	Version PorterYamlVersion `yaml:"version"`
@@ -0,0 +1,178 @@
+package test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+
+	v2 "github.com/porter-dev/porter/internal/porter_app/v2"
+)
+
+func TestEnvironmentNames(t *testing.T) {
+	is := is.New(t)
+
+	porterYaml, err := os.ReadFile("../testdata/v2_input_environments.yaml")
+	is.NoErr(err) // no error expected reading test file
+
+	names, err := v2.EnvironmentNames(porterYaml)
+	is.NoErr(err) // environments should be listed without issues
+	is.Equal(names, []string{"staging", "production"})
+}
+
+func TestRenderEnvironment(t *testing.T) {
+	tests := []struct {
+		environment string
+		want        string
+	}{
+		{"", renderedWithoutEnvironment},
+		{"staging", renderedStaging},
+		{"production", renderedProduction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.environment, func(t *testing.T) {
+			is := is.New(t)
+
+			porterYaml, err := os.ReadFile("../testdata/v2_input_environments.yaml")
+			is.NoErr(err) // no error expected reading test file
+
+			got, err := v2.RenderEnvironment(porterYaml, tt.environment)
+			is.NoErr(err) // environment should be rendered without issues
+			is.Equal(string(got), tt.want)
+		})
+	}
+}
+
+func TestRenderEnvironmentErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		porterYaml string
+		want       string
+	}{
+		{
+			name:       "undefined environment",
+			porterYaml: "version: v2\nname: test-app\n",
+			want:       "environment staging is not defined in porter yaml",
+		},
+		{
+			name:       "nested previews",
+			porterYaml: "version: v2\nenvironments:\n  staging:\n    previews:\n      name: test-app\n",
+			want:       "environment staging cannot set previews",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			_, err := v2.RenderEnvironment([]byte(tt.porterYaml), "staging")
+			is.True(err != nil) // invalid environments should not be rendered
+			is.Equal(err.Error(), tt.want)
+		})
+	}
+}
+
+var renderedWithoutEnvironment = `version: v2
+name: test-app
+build:
+  method: docker
+  context: ./
+  dockerfile: ./Dockerfile
+services:
+  - name: web
+    type: web
+    run: node index.js
+    port: 8080
+    cpuCores: 0.1
+    ramMegabytes: 256
+    autoscaling:
+      enabled: true
+      minInstances: 1
+      maxInstances: 3
+      cpuThresholdPercent: 60
+      memoryThresholdPercent: 60
+    domains:
+      - name: staging.example.com
+  - name: worker
+    type: worker
+    run: node worker.js
+    instances: 1
+env:
+  PORT: 8080
+  NODE_ENV: development
+`
+
+var renderedStaging = `version: v2
+name: test-app
+build:
+  method: docker
+  context: ./
+  dockerfile: ./Dockerfile
+services:
+  - name: web
+    type: web
+    run: node index.js
+    port: 8080
+    cpuCores: 0.1
+    ramMegabytes: 256
+    autoscaling:
+      enabled: true
+      minInstances: 1
+      maxInstances: 3
+      cpuThresholdPercent: 60
+      memoryThresholdPercent: 60
+    domains:
+      - name: staging.example.com
+  - name: worker
+    type: worker
+    run: node worker.js
+    instances: 1
+env:
+  PORT: 8080
+  NODE_ENV: staging
+`
+
+var renderedProduction = `version: v2
+name: test-app
+build:
+  method: docker
+  context: ./
+  dockerfile: ./Dockerfile
+services:
+  - name: web
+    type: web
+    run: node index.js
+    port: 8080
+    cpuCores: 0.1
+    ramMegabytes: 1024
+    autoscaling:
+      enabled: true
+      minInstances: 1
+      maxInstances: 10
+      cpuThresholdPercent: 60
+      memoryThresholdPercent: 60
+    domains:
+      - name: example.com
+  - name: worker
+    type: worker
+    run: node worker.js
+    instances: 1
+  - name: cron
+    type: job
+    run: node cleanup.js
+    cron: "0 * * * *"
+env:
+  - key: PORT
+    value: 8080
+  - key: NODE_ENV
+    value: production
+  - key: API_URL
+    from:
+      source: app
+      name: api
+      value: public_domain
+addons:
+  - name: cache
+    type: redis
+`
@@ -45,6 +45,30 @@ func TestParseYAML(t *testing.T) {
 	}
 }
 
+func TestValidateYAML(t *testing.T) {
+	is := is.New(t)
+
+	valid, err := os.ReadFile("../testdata/v2_input_nobuild.yaml")
+	is.NoErr(err) // no error expected reading test file
+
+	err = porter_app.ValidateYAML(context.Background(), valid)
+	is.NoErr(err) // test file should be valid in strict mode
+
+	invalid := []byte(`version: v2
+name: test-app
+services:
+  - name: web
+    type: wbe
+    ramMegabyte: 256
+`)
+
+	err = porter_app.ValidateYAML(context.Background(), invalid)
+	is.True(err != nil) // typos should be reported in strict mode
+	is.Equal(err.Error(), `found 2 problems:
+  line 5, column 11: services[0].type: invalid value "wbe", must be one of: web, worker, job
+  line 6, column 5: services[0].ramMegabyte: unknown field "ramMegabyte", did you mean "ramMegabytes"?`)
+}
+
 var result_nobuild = &porterv1.PorterApp{
 	Name: "test-app",
 	ServiceList: []*porterv1.Service{
@@ -0,0 +1,288 @@
+package v2
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// environmentsKey is the key of the named environment overlays in a Porter YAML file
+	environmentsKey = "environments"
+	// previewsKey is the key of the preview environment overrides in a Porter YAML file
+	previewsKey = "previews"
+)
+
+// EnvironmentNames returns the names of the environment overlays defined in a Porter YAML file, in the order they are defined
+func EnvironmentNames(porterYamlBytes []byte) ([]string, error) {
+	root, err := parseRoot(porterYamlBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	environments := mappingValue(root, environmentsKey)
+	if environments == nil {
+		return names, nil
+	}
+
+	if environments.Kind != yaml.MappingNode {
+		return nil, errors.New("environments must be an object of environment names to overrides")
+	}
+
+	for i := 0; i+1 < len(environments.Content); i += 2 {
+		names = append(names, environments.Content[i].Value)
+	}
+
+	return names, nil
+}
+
+// RenderEnvironment merges the named environment overlay of a Porter YAML file over the rest of the file, and returns the
+// resulting file without its environments. If environment is empty, the file is returned without its environments.
+//
+// Overlays are merged field by field:
+//   - objects are merged recursively, and a field set to null in the overlay is removed
+//   - services and addons are matched by name; a matching entry is merged recursively, and other entries are appended
+//   - env variables are matched by name; a matching variable is replaced, and other variables are appended
+//   - any other value, including other lists, is replaced by the overlay
+func RenderEnvironment(porterYamlBytes []byte, environment string) ([]byte, error) {
+	root, err := parseRoot(porterYamlBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	environments := removeMappingValue(root, environmentsKey)
+
+	if environment != "" {
+		if environments == nil || environments.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("environment %s is not defined in porter yaml", environment)
+		}
+
+		overlay := mappingValue(environments, environment)
+		if overlay == nil {
+			return nil, fmt.Errorf("environment %s is not defined in porter yaml", environment)
+		}
+
+		if isNull(overlay) {
+			overlay = &yaml.Node{Kind: yaml.MappingNode}
+		}
+
+		if overlay.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("environment %s must be an object", environment)
+		}
+
+		for _, key := range []string{"version", previewsKey, environmentsKey} {
+			if mappingValue(overlay, key) != nil {
+				return nil, fmt.Errorf("environment %s cannot set %s", environment, key)
+			}
+		}
+
+		mergeMapping(root, overlay)
+	}
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	err = encoder.Encode(root)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding porter yaml: %w", err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error encoding porter yaml: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func parseRoot(porterYamlBytes []byte) (*yaml.Node, error) {
+	if porterYamlBytes == nil {
+		return nil, errors.New("porter yaml is nil")
+	}
+
+	doc := &yaml.Node{}
+
+	err := yaml.Unmarshal(porterYamlBytes, doc)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling porter yaml: %w", err)
+	}
+
+	if len(doc.Content) == 0 || resolve(doc.Content[0]).Kind != yaml.MappingNode {
+		return nil, errors.New("porter yaml must be an object")
+	}
+
+	return resolve(doc.Content[0]), nil
+}
+
+// mergeMapping merges the fields of an overlay object into a base object
+func mergeMapping(base, overlay *yaml.Node) {
+	for i := 0; i+1 < len(overlay.Content); i += 2 {
+		key, value := overlay.Content[i], resolve(overlay.Content[i+1])
+
+		idx := mappingIndex(base, key.Value)
+
+		if isNull(value) {
+			if idx >= 0 {
+				base.Content = append(base.Content[:idx], base.Content[idx+2:]...)
+			}
+			continue
+		}
+
+		if idx < 0 {
+			base.Content = append(base.Content, key, value)
+			continue
+		}
+
+		base.Content[idx+1] = mergeField(key.Value, resolve(base.Content[idx+1]), value)
+	}
+}
+
+func mergeField(field string, base, overlay *yaml.Node) *yaml.Node {
+	switch {
+	case field == "env":
+		return mergeEnv(base, overlay)
+	case (field == "services" || field == "addons") && base.Kind == yaml.SequenceNode && overlay.Kind == yaml.SequenceNode:
+		return mergeList(base, overlay, "name", true)
+	case base.Kind == yaml.MappingNode && overlay.Kind == yaml.MappingNode:
+		mergeMapping(base, overlay)
+		return base
+	default:
+		return overlay
+	}
+}
+
+// mergeEnv merges env variables, which are either an object of names to values or a list of definitions. If the base
+// and the overlay use different forms, the result is a list of definitions.
+func mergeEnv(base, overlay *yaml.Node) *yaml.Node {
+	if base.Kind == yaml.MappingNode && overlay.Kind == yaml.MappingNode {
+		mergeMapping(base, overlay)
+		return base
+	}
+
+	if base.Kind == yaml.MappingNode {
+		base = envDefinitions(base)
+	}
+
+	if overlay.Kind == yaml.MappingNode {
+		overlay = envDefinitions(overlay)
+	}
+
+	if base.Kind != yaml.SequenceNode || overlay.Kind != yaml.SequenceNode {
+		return overlay
+	}
+
+	return mergeList(base, overlay, "key", false)
+}
+
+// envDefinitions converts an object of env variable names to values to a list of definitions
+func envDefinitions(env *yaml.Node) *yaml.Node {
+	list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+
+	for i := 0; i+1 < len(env.Content); i += 2 {
+		list.Content = append(list.Content, &yaml.Node{
+			Kind: yaml.MappingNode,
+			Tag:  "!!map",
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "key"},
+				env.Content[i],
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "value"},
+				env.Content[i+1],
+			},
+		})
+	}
+
+	return list
+}
+
+// mergeList merges a list of objects identified by the value of a key. Entries of the overlay matching an entry of the base
+// are merged into it when deep is true, and replace it otherwise. Other entries are appended.
+func mergeList(base, overlay *yaml.Node, key string, deep bool) *yaml.Node {
+	for _, item := range overlay.Content {
+		item = resolve(item)
+
+		idx := listIndex(base, key, item)
+		switch {
+		case idx < 0:
+			base.Content = append(base.Content, item)
+		case deep:
+			mergeMapping(resolve(base.Content[idx]), item)
+		default:
+			base.Content[idx] = item
+		}
+	}
+
+	return base
+}
+
+func listIndex(list *yaml.Node, key string, item *yaml.Node) int {
+	if item.Kind != yaml.MappingNode {
+		return -1
+	}
+
+	id := mappingValue(item, key)
+	if id == nil || id.Kind != yaml.ScalarNode {
+		return -1
+	}
+
+	for i, entry := range list.Content {
+		entry = resolve(entry)
+		if entry.Kind != yaml.MappingNode {
+			continue
+		}
+
+		if value := mappingValue(entry, key); value != nil && value.Kind == yaml.ScalarNode && value.Value == id.Value {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func mappingIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	idx := mappingIndex(mapping, key)
+	if idx < 0 {
+		return nil
+	}
+
+	return resolve(mapping.Content[idx+1])
+}
+
+func removeMappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	idx := mappingIndex(mapping, key)
+	if idx < 0 {
+		return nil
+	}
+
+	value := resolve(mapping.Content[idx+1])
+	mapping.Content = append(mapping.Content[:idx], mapping.Content[idx+2:]...)
+
+	return value
+}
+
+func resolve(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		return node.Alias
+	}
+
+	return node
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
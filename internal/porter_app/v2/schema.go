@@ -0,0 +1,67 @@
+package v2
+
+import (
+	"reflect"
+
+	"github.com/porter-dev/porter/internal/yamlschema"
+)
+
+// SchemaID is the URL the porter.yaml JSON Schema is served from
+const SchemaID = "https://dashboard.getporter.dev/api/porter-yaml/schema"
+
+// JSONSchema returns the JSON Schema of a v2 porter.yaml file, generated from PorterYAML
+func JSONSchema() *yamlschema.Schema {
+	g := yamlschema.NewGenerator()
+
+	// env variables are either a map of names to values, or a list of definitions
+	g.Override(reflect.TypeOf(Env{}), &yamlschema.Schema{
+		OneOf: []*yamlschema.Schema{
+			{
+				Type:                 "object",
+				AdditionalProperties: &yamlschema.Schema{Type: "string"},
+			},
+			{
+				Type: "array",
+				Items: &yamlschema.Schema{
+					Type: "object",
+					Properties: map[string]*yamlschema.Schema{
+						"key":   {Type: "string"},
+						"value": {Type: "string"},
+						"from": {
+							Type: "object",
+							Properties: map[string]*yamlschema.Schema{
+								"source":  {Type: "string", Enum: []string{string(EnvVariableSource_FromApp)}},
+								"name":    {Type: "string"},
+								"value":   {Type: "string", Enum: []string{string(EnvValueFromApp_InternalDomain), string(EnvValueFromApp_PublicDomain)}},
+								"service": {Type: "string"},
+							},
+							Required:             []string{"source", "name", "value"},
+							AdditionalProperties: false,
+						},
+					},
+					Required:             []string{"key"},
+					AdditionalProperties: false,
+				},
+			},
+		},
+	})
+
+	schema := g.Generate(PorterYAML{})
+
+	// previews and environments override parts of the app, so none of their fields are required
+	overrides := schema.Properties[previewsKey].Partial()
+	schema.Properties[previewsKey] = overrides
+	schema.Properties[environmentsKey].AdditionalProperties = overrides
+
+	schema.ID = SchemaID
+	schema.Title = "porter.yaml"
+	schema.Description = "Configuration of an application deployed on Porter"
+
+	return schema
+}
+
+// ValidateYAML strictly validates a v2 porter.yaml file against its JSON Schema. Unlike AppProtoFromYaml, it
+// reports unknown fields, type mismatches and violations of the validate tags, with their line and column.
+func ValidateYAML(porterYamlBytes []byte) error {
+	return yamlschema.Validate(porterYamlBytes, JSONSchema())
+}
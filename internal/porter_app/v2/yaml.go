@@ -123,6 +123,9 @@ type PorterAppWithAddons struct {
 type PorterYAML struct {
 	PorterAppWithAddons `yaml:",inline"`
 	Previews            *PorterAppWithAddons `yaml:"previews,omitempty"`
+
+	// Environments are named overlays, merged over the rest of the file by RenderEnvironment before it is applied
+	Environments map[string]PorterAppWithAddons `yaml:"environments,omitempty"`
 }
 
 // Addon represents an addon that should be installed alongside a Porter app
@@ -171,7 +174,7 @@ type Image struct {
 type Service struct {
 	Name                          string            `yaml:"name,omitempty"`
 	Run                           *string           `yaml:"run,omitempty"`
-	Type                          ServiceType       `yaml:"type,omitempty" validate:"required, oneof=web worker job"`
+	Type                          ServiceType       `yaml:"type,omitempty" validate:"omitempty,oneof=web worker job"`
 	Instances                     *int32            `yaml:"instances,omitempty"`
 	CpuCores                      float32           `yaml:"cpuCores,omitempty"`
 	RamMegabytes                  int               `yaml:"ramMegabytes,omitempty"`
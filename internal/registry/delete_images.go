@@ -0,0 +1,220 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/telemetry"
+	v1artifactregistry "google.golang.org/api/artifactregistry/v1"
+	"google.golang.org/api/option"
+)
+
+// isECR returns whether the registry is an ECR registry, either linked with an AWS integration or provisioned by the
+// cluster control plane
+func (r *Registry) isECR() bool {
+	return r.AWSIntegrationID != 0 || strings.Contains(r.URL, ".dkr.ecr.")
+}
+
+// isGAR returns whether the registry is a GAR registry linked with a GCP integration
+func (r *Registry) isGAR() bool {
+	return r.GCPIntegrationID != 0 && strings.Contains(r.URL, "pkg.dev")
+}
+
+// CanDeleteImages returns whether images of the registry can be deleted, which is required by retention policies
+func (r *Registry) CanDeleteImages() bool {
+	return r.isECR() || r.isGAR()
+}
+
+// getECRAWSIntegration returns the credentials of an ECR registry
+func (r *Registry) getECRAWSIntegration(ctx context.Context, conf *config.Config) (*ints.AWSIntegration, error) {
+	if r.AWSIntegrationID != 0 {
+		return conf.Repo.AWSIntegration().ReadAWSIntegration(r.ProjectID, r.AWSIntegrationID)
+	}
+
+	return r.getCAPIAWSIntegration(ctx, conf)
+}
+
+// DeleteImages deletes the images of a repository of the registry by digest, along with all of their tags, and
+// returns the digests of the deleted images. Deleting images is only supported for ECR and GAR registries. If some
+// images could not be deleted, the digests of the images which were deleted are returned along with an error.
+func (r *Registry) DeleteImages(
+	ctx context.Context,
+	repoName string,
+	digests []string,
+	conf *config.Config,
+) ([]string, error) {
+	ctx, span := telemetry.NewSpan(ctx, "delete-images")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "registry-id", Value: r.ID},
+		telemetry.AttributeKV{Key: "registry-url", Value: r.URL},
+		telemetry.AttributeKV{Key: "project-id", Value: r.ProjectID},
+		telemetry.AttributeKV{Key: "repo-name", Value: repoName},
+		telemetry.AttributeKV{Key: "digest-count", Value: len(digests)},
+	)
+
+	if len(digests) == 0 {
+		return []string{}, nil
+	}
+
+	if !r.CanDeleteImages() {
+		return nil, telemetry.Error(ctx, span, nil, "deleting images is only supported for ecr and gar registries")
+	}
+
+	if r.isECR() {
+		awsInt, err := r.getECRAWSIntegration(ctx, conf)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error getting aws credentials of registry")
+		}
+
+		deleted, err := r.deleteECRImages(ctx, awsInt, repoName, digests)
+		if err != nil {
+			return deleted, telemetry.Error(ctx, span, err, "error deleting ecr images")
+		}
+
+		return deleted, nil
+	}
+
+	deleted, err := r.deleteGARImages(ctx, conf, repoName, digests)
+	if err != nil {
+		return deleted, telemetry.Error(ctx, span, err, "error deleting gar images")
+	}
+
+	return deleted, nil
+}
+
+func (r *Registry) deleteECRImages(
+	ctx context.Context,
+	awsInt *ints.AWSIntegration,
+	repoName string,
+	digests []string,
+) ([]string, error) {
+	sess, err := awsInt.GetSession()
+	if err != nil {
+		return nil, err
+	}
+
+	svc := ecr.New(sess)
+
+	deleted := make([]string, 0, len(digests))
+	failures := make([]string, 0)
+
+	// AWS API expects the length of imageIDs to be at max 100 at a time
+	for start := 0; start < len(digests); start += 100 {
+		end := start + 100
+		if end > len(digests) {
+			end = len(digests)
+		}
+
+		imageIDs := make([]*ecr.ImageIdentifier, 0, end-start)
+
+		for _, digest := range digests[start:end] {
+			imageIDs = append(imageIDs, &ecr.ImageIdentifier{ImageDigest: aws.String(digest)})
+		}
+
+		resp, err := svc.BatchDeleteImageWithContext(ctx, &ecr.BatchDeleteImageInput{
+			RepositoryName: aws.String(repoName),
+			ImageIds:       imageIDs,
+		})
+		if err != nil {
+			return deleted, err
+		}
+
+		seen := make(map[string]bool)
+
+		for _, id := range resp.ImageIds {
+			digest := aws.StringValue(id.ImageDigest)
+
+			if digest != "" && !seen[digest] {
+				seen[digest] = true
+				deleted = append(deleted, digest)
+			}
+		}
+
+		for _, failure := range resp.Failures {
+			if aws.StringValue(failure.FailureCode) == ecr.ImageFailureCodeImageNotFound {
+				continue
+			}
+
+			var digest string
+			if failure.ImageId != nil {
+				digest = aws.StringValue(failure.ImageId.ImageDigest)
+			}
+
+			failures = append(failures, fmt.Sprintf("%s: %s", digest, aws.StringValue(failure.FailureReason)))
+		}
+	}
+
+	if len(failures) > 0 {
+		return deleted, fmt.Errorf("error deleting %d images: %s", len(failures), strings.Join(failures, "; "))
+	}
+
+	return deleted, nil
+}
+
+func (r *Registry) deleteGARImages(
+	ctx context.Context,
+	conf *config.Config,
+	repoName string,
+	digests []string,
+) ([]string, error) {
+	repoImageSlice := strings.Split(repoName, "/")
+
+	if len(repoImageSlice) != 2 {
+		return nil, fmt.Errorf("invalid GAR repo name: %s. Expected to be in the form of REPOSITORY/IMAGE", repoName)
+	}
+
+	gcpInt, err := conf.Repo.GCPIntegration().ReadGCPIntegration(
+		r.ProjectID,
+		r.GCPIntegrationID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := v1artifactregistry.NewService(ctx, option.WithTokenSource(&garTokenSource{
+		reg:  r,
+		repo: conf.Repo,
+		ctx:  ctx,
+	}), option.WithScopes("roles/artifactregistry.repoAdmin"))
+	if err != nil {
+		return nil, err
+	}
+
+	parsedURL, err := url.Parse("https://" + r.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	location := strings.TrimSuffix(parsedURL.Host, "-docker.pkg.dev")
+	versionsSvc := v1artifactregistry.NewProjectsLocationsRepositoriesPackagesVersionsService(svc)
+
+	deleted := make([]string, 0, len(digests))
+	failures := make([]string, 0)
+
+	for _, digest := range digests {
+		// versions of docker packages are named by digest, and tagged versions can only be deleted with force
+		_, err := versionsSvc.Delete(fmt.Sprintf("projects/%s/locations/%s/repositories/%s/packages/%s/versions/%s",
+			gcpInt.GCPProjectID, location, repoImageSlice[0], url.PathEscape(repoImageSlice[1]), digest)).
+			Force(true).Context(ctx).Do()
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", digest, err.Error()))
+			continue
+		}
+
+		deleted = append(deleted, digest)
+	}
+
+	if len(failures) > 0 {
+		return deleted, fmt.Errorf("error deleting %d images: %s", len(failures), strings.Join(failures, "; "))
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,217 @@
+package gc
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/api-contracts/generated/go/helpers"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"github.com/porter-dev/porter/internal/registry/retention"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// helmAgentTimeout is the timeout for connecting to a cluster to list its Helm releases
+const helmAgentTimeout = 10 * time.Second
+
+// liveReleaseStatuses are the statuses of the Helm releases whose images are referenced. Deployed releases are listed
+// separately from the others, since only the latest revision of each release is listed and the previous revision of a
+// release keeps running if its latest revision failed or is pending.
+var liveReleaseStatuses = [][]string{
+	{"deployed"},
+	{"pending", "pending-install", "pending-upgrade", "pending-rollback", "failed"},
+}
+
+// CollectReferences returns the images referenced by the live app revisions of a project and by the Helm releases of
+// all of its clusters. An error is returned if the references of any app or cluster cannot be collected, since images
+// which are in use could otherwise be deleted.
+func CollectReferences(ctx context.Context, conf *config.Config, projectID uint) (*retention.References, error) {
+	ctx, span := telemetry.NewSpan(ctx, "collect-image-references")
+	defer span.End()
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: projectID})
+
+	refs := retention.NewReferences()
+
+	revisions, err := conf.Repo.AppRevision().LiveAppRevisions(ctx, projectID)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing live app revisions")
+	}
+
+	for _, revision := range revisions {
+		if err := addAppRevisionReferences(refs, revision); err != nil {
+			return nil, telemetry.Error(ctx, span, err, fmt.Sprintf("error getting images of app revision %s", revision.ID))
+		}
+	}
+
+	clusters, err := conf.Repo.Cluster().ListClustersByProjectID(projectID)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing clusters")
+	}
+
+	for _, cluster := range clusters {
+		if err := addHelmReleaseReferences(ctx, conf, refs, cluster); err != nil {
+			return nil, telemetry.Error(ctx, span, err, fmt.Sprintf("error getting images of helm releases in cluster %d", cluster.ID))
+		}
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "app-revision-count", Value: len(revisions)},
+		telemetry.AttributeKV{Key: "cluster-count", Value: len(clusters)},
+		telemetry.AttributeKV{Key: "reference-count", Value: refs.Len()},
+	)
+
+	return refs, nil
+}
+
+func addAppRevisionReferences(refs *retention.References, revision *models.AppRevision) error {
+	decoded, err := base64.StdEncoding.DecodeString(revision.Base64App)
+	if err != nil {
+		return fmt.Errorf("error decoding app: %w", err)
+	}
+
+	app := &porterv1.PorterApp{}
+
+	if err := helpers.UnmarshalContractObject(decoded, app); err != nil {
+		return fmt.Errorf("error unmarshalling app: %w", err)
+	}
+
+	if app.Image == nil || app.Image.Repository == "" {
+		return nil
+	}
+
+	// an invalid image reference cannot match any image of a registry, so it is ignored
+	_ = refs.AddTag(app.Image.Repository, app.Image.Tag, fmt.Sprintf("app %s (revision %d)", app.Name, revision.RevisionNumber))
+
+	return nil
+}
+
+func addHelmReleaseReferences(ctx context.Context, conf *config.Config, refs *retention.References, cluster *models.Cluster) error {
+	allowInClusterConnections := conf.ServerConf != nil && conf.ServerConf.InitInCluster
+
+	agent, err := helm.GetAgentOutOfClusterConfig(ctx, &helm.Form{
+		Cluster:                   cluster,
+		Repo:                      conf.Repo,
+		DigitalOceanOAuth:         conf.DOConf,
+		AllowInClusterConnections: allowInClusterConnections,
+		Timeout:                   helmAgentTimeout,
+	}, conf.Logger)
+	if err != nil {
+		return fmt.Errorf("error getting helm agent: %w", err)
+	}
+
+	for _, statuses := range liveReleaseStatuses {
+		// releases of all namespaces are listed
+		releases, err := agent.ListReleases(ctx, "", &types.ReleaseListFilter{
+			StatusFilter: statuses,
+		})
+		if err != nil {
+			return fmt.Errorf("error listing releases: %w", err)
+		}
+
+		for _, rel := range releases {
+			source := fmt.Sprintf("helm release %s/%s in cluster %d", rel.Namespace, rel.Name, cluster.ID)
+
+			for _, image := range retention.ImagesFromManifest(rel.Manifest) {
+				// an invalid image reference cannot match any image of a registry, so it is ignored
+				_ = refs.Add(image, source)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Evaluate returns which images of the repository of a retention policy are kept and deleted by the policy, without
+// deleting any image
+func Evaluate(
+	ctx context.Context,
+	conf *config.Config,
+	reg *models.Registry,
+	policy *models.RegistryRetentionPolicy,
+	refs *retention.References,
+) (*types.RegistryRetentionReport, error) {
+	ctx, span := telemetry.NewSpan(ctx, "evaluate-retention-policy")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "registry-id", Value: reg.ID},
+		telemetry.AttributeKV{Key: "repo-name", Value: policy.RepoName},
+		telemetry.AttributeKV{Key: "keep-last", Value: policy.KeepLast},
+		telemetry.AttributeKV{Key: "keep-tag-pattern", Value: policy.KeepTagPattern},
+	)
+
+	retentionPolicy, err := retention.NewPolicy(policy.KeepLast, policy.KeepTagPattern)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error compiling retention policy")
+	}
+
+	_reg := registry.Registry(*reg)
+	regAPI := &_reg
+
+	imgs, err := regAPI.ListImages(ctx, policy.RepoName, conf.Repo, conf)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing images")
+	}
+
+	repository := regAPI.RepositoryURI(policy.RepoName)
+	res := retention.Evaluate(repository, imgs, retentionPolicy, refs)
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "image-count", Value: len(imgs)},
+		telemetry.AttributeKV{Key: "keep-count", Value: len(res.Keep)},
+		telemetry.AttributeKV{Key: "delete-count", Value: len(res.Delete)},
+	)
+
+	return &types.RegistryRetentionReport{
+		RepoName:    policy.RepoName,
+		Repository:  repository,
+		Policy:      policy.ToRegistryRetentionPolicyType(),
+		Keep:        res.Keep,
+		Delete:      res.Delete,
+		EvaluatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// Apply deletes the images of a report which are not kept by its retention policy, and records the number of
+// deleted images on the policy. The number of deleted images is returned even if some images could not be deleted.
+func Apply(
+	ctx context.Context,
+	conf *config.Config,
+	reg *models.Registry,
+	policy *models.RegistryRetentionPolicy,
+	report *types.RegistryRetentionReport,
+) (int, error) {
+	ctx, span := telemetry.NewSpan(ctx, "apply-retention-policy")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "registry-id", Value: reg.ID},
+		telemetry.AttributeKV{Key: "policy-id", Value: policy.ID},
+		telemetry.AttributeKV{Key: "repo-name", Value: policy.RepoName},
+		telemetry.AttributeKV{Key: "delete-count", Value: len(report.Delete)},
+	)
+
+	_reg := registry.Registry(*reg)
+
+	deleted, deleteErr := _reg.DeleteImages(ctx, policy.RepoName, retention.Digests(report.Delete), conf)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deleted-count", Value: len(deleted)})
+
+	err := conf.Repo.RegistryRetention().RecordRetentionPolicyRun(ctx, policy.ID, time.Now().UTC(), uint(len(deleted)))
+	if err != nil {
+		return len(deleted), telemetry.Error(ctx, span, err, "error recording retention policy run")
+	}
+
+	if deleteErr != nil {
+		return len(deleted), telemetry.Error(ctx, span, deleteErr, "error deleting images")
+	}
+
+	return len(deleted), nil
+}
@@ -35,6 +35,7 @@ import (
 	ints "github.com/porter-dev/porter/internal/models/integrations"
 
 	ptypes "github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/registry/scanner"
 
 	"github.com/digitalocean/godo"
 	"github.com/docker/cli/cli/config/configfile"
@@ -1121,23 +1122,9 @@ func (r *Registry) ListImages(
 			return res, nil
 		}
 
-		uri := strings.TrimPrefix(r.URL, "https://")
-		splits := strings.Split(uri, ".")
-		accountID := splits[0]
-		region := splits[3]
-		req := connect.NewRequest(&porterv1.AssumeRoleCredentialsRequest{
-			ProjectId:    int64(r.ProjectID),
-			AwsAccountId: accountID,
-		})
-		creds, err := conf.ClusterControlPlaneClient.AssumeRoleCredentials(ctx, req)
+		aws, err := r.getCAPIAWSIntegration(ctx, conf)
 		if err != nil {
-			return nil, fmt.Errorf("error getting capi credentials for repository: %w", err)
-		}
-		aws := &ints.AWSIntegration{
-			AWSAccessKeyID:     []byte(creds.Msg.AwsAccessId),
-			AWSSecretAccessKey: []byte(creds.Msg.AwsSecretKey),
-			AWSSessionToken:    []byte(creds.Msg.AwsSessionToken),
-			AWSRegion:          region,
+			return nil, err
 		}
 		return r.listECRImages(aws, repoName, repo)
 	}
@@ -1145,6 +1132,35 @@ func (r *Registry) ListImages(
 	return nil, fmt.Errorf("error listing images")
 }
 
+// getCAPIAWSIntegration returns credentials for the ECR registry of a project with the CAPI provisioner enabled, by
+// assuming a role in the account of the registry through the cluster control plane
+func (r *Registry) getCAPIAWSIntegration(ctx context.Context, conf *config.Config) (*ints.AWSIntegration, error) {
+	uri := strings.TrimPrefix(r.URL, "https://")
+	splits := strings.Split(uri, ".")
+	if len(splits) < 4 {
+		return nil, fmt.Errorf("registry url %s is not an ecr url", r.URL)
+	}
+	if conf.ClusterControlPlaneClient == nil {
+		return nil, errors.New("a cluster control plane client is required to get capi credentials for repository")
+	}
+	accountID := splits[0]
+	region := splits[3]
+	req := connect.NewRequest(&porterv1.AssumeRoleCredentialsRequest{
+		ProjectId:    int64(r.ProjectID),
+		AwsAccountId: accountID,
+	})
+	creds, err := conf.ClusterControlPlaneClient.AssumeRoleCredentials(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting capi credentials for repository: %w", err)
+	}
+	return &ints.AWSIntegration{
+		AWSAccessKeyID:     []byte(creds.Msg.AwsAccessId),
+		AWSSecretAccessKey: []byte(creds.Msg.AwsSecretKey),
+		AWSSessionToken:    []byte(creds.Msg.AwsSessionToken),
+		AWSRegion:          region,
+	}, nil
+}
+
 func (r *Registry) GetECRPaginatedImages(
 	repoName string,
 	maxResults int64,
@@ -1222,10 +1238,11 @@ func (r *Registry) GetECRPaginatedImages(
 	for _, img := range imageDetails {
 		for _, tag := range img.ImageTags {
 			newImage := &ptypes.Image{
-				Digest:         *img.ImageDigest,
-				Tag:            *tag,
-				RepositoryName: repoName,
-				PushedAt:       img.ImagePushedAt,
+				Digest:          *img.ImageDigest,
+				Tag:             *tag,
+				RepositoryName:  repoName,
+				PushedAt:        img.ImagePushedAt,
+				Vulnerabilities: scanner.SummaryFromECRImageDetail(img),
 			}
 
 			if _, ok := imageIDMap[*tag]; ok {
@@ -1341,10 +1358,11 @@ func (r *Registry) listECRImages(aws *ints.AWSIntegration, repoName string, repo
 	for _, img := range imageDetails {
 		for _, tag := range img.ImageTags {
 			newImage := &ptypes.Image{
-				Digest:         *img.ImageDigest,
-				Tag:            *tag,
-				RepositoryName: repoName,
-				PushedAt:       img.ImagePushedAt,
+				Digest:          *img.ImageDigest,
+				Tag:             *tag,
+				RepositoryName:  repoName,
+				PushedAt:        img.ImagePushedAt,
+				Vulnerabilities: scanner.SummaryFromECRImageDetail(img),
 			}
 
 			if _, ok := imageInfoMap[*tag]; !ok {
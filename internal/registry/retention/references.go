@@ -0,0 +1,166 @@
+package retention
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"gopkg.in/yaml.v3"
+)
+
+// References is the set of images which are referenced by live app revisions and Helm releases, along with what
+// references them. Referenced images are never deleted by a retention policy.
+type References struct {
+	// sources maps references, like 123456789.dkr.ecr.us-west-2.amazonaws.com/web:v1 or
+	// 123456789.dkr.ecr.us-west-2.amazonaws.com/web@sha256:..., to what references them
+	sources map[string]map[string]bool
+}
+
+// NewReferences returns an empty set of references
+func NewReferences() *References {
+	return &References{
+		sources: make(map[string]map[string]bool),
+	}
+}
+
+// Add records that an image is referenced by source, like "helm release default/web in cluster 3". Images without
+// a tag or digest reference the latest tag.
+func (r *References) Add(image, source string) error {
+	named, err := reference.ParseNormalizedNamed(trimScheme(strings.TrimSpace(image)))
+	if err != nil {
+		return fmt.Errorf("invalid image reference %q: %w", image, err)
+	}
+
+	added := false
+
+	if tagged, ok := named.(reference.Tagged); ok {
+		r.add(named.Name(), tagged.Tag(), source)
+		added = true
+	}
+
+	if digested, ok := named.(reference.Digested); ok {
+		r.add(named.Name(), digested.Digest().String(), source)
+		added = true
+	}
+
+	if !added {
+		r.add(named.Name(), "latest", source)
+	}
+
+	return nil
+}
+
+// AddTag records that the image of a repository with a tag or digest is referenced by source, like
+// "app web in deployment target default"
+func (r *References) AddTag(repository, tag, source string) error {
+	if tag == "" {
+		return r.Add(repository, source)
+	}
+
+	if strings.HasPrefix(tag, "sha256:") {
+		return r.Add(repository+"@"+tag, source)
+	}
+
+	return r.Add(repository+":"+tag, source)
+}
+
+// Sources returns what references the image of a repository with a tag or digest, ordered alphabetically
+func (r *References) Sources(repository, tagOrDigest string) []string {
+	name, err := normalizeRepository(repository)
+	if err != nil {
+		return nil
+	}
+
+	sources := r.sources[key(name, tagOrDigest)]
+	res := make([]string, 0, len(sources))
+
+	for source := range sources {
+		res = append(res, source)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// Len returns the number of referenced images
+func (r *References) Len() int {
+	return len(r.sources)
+}
+
+func (r *References) add(name, tagOrDigest, source string) {
+	k := key(name, tagOrDigest)
+
+	if _, ok := r.sources[k]; !ok {
+		r.sources[k] = make(map[string]bool)
+	}
+
+	r.sources[k][source] = true
+}
+
+func key(name, tagOrDigest string) string {
+	return name + "|" + tagOrDigest
+}
+
+func normalizeRepository(repository string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(trimScheme(repository))
+	if err != nil {
+		return "", err
+	}
+
+	return named.Name(), nil
+}
+
+// trimScheme removes the scheme of an image repository, which is set on the repositories of some apps
+func trimScheme(image string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(image, "https://"), "http://")
+}
+
+// ImagesFromManifest returns the images of the containers in a rendered Helm manifest, which is a stream of YAML
+// documents. Documents which cannot be decoded are skipped.
+func ImagesFromManifest(manifest string) []string {
+	res := make([]string, 0)
+	seen := make(map[string]bool)
+
+	// documents are decoded separately, since a decoder cannot recover from a malformed document
+	for _, doc := range strings.Split("\n"+manifest, "\n---") {
+		var value interface{}
+
+		if err := yaml.Unmarshal([]byte(doc), &value); err != nil {
+			continue
+		}
+
+		for _, image := range imagesFromValue(value) {
+			if !seen[image] {
+				seen[image] = true
+				res = append(res, image)
+			}
+		}
+	}
+
+	return res
+}
+
+// imagesFromValue returns the values of all "image" fields of a decoded YAML value
+func imagesFromValue(value interface{}) []string {
+	res := make([]string, 0)
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for field, child := range v {
+			if image, ok := child.(string); ok && field == "image" && strings.TrimSpace(image) != "" {
+				res = append(res, strings.TrimSpace(image))
+				continue
+			}
+
+			res = append(res, imagesFromValue(child)...)
+		}
+	case []interface{}:
+		for _, child := range v {
+			res = append(res, imagesFromValue(child)...)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,203 @@
+package retention
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// Policy is the retention policy of an image repository. Images which are referenced, among the most recently pushed
+// or tagged with a tag matching the pattern of the policy are kept, and all other images are deleted.
+type Policy struct {
+	// KeepLast is the number of most recently pushed images to keep
+	KeepLast uint
+
+	// KeepTagPattern matches the tags of images to keep. Nil if no tags are kept by pattern.
+	KeepTagPattern *regexp.Regexp
+}
+
+// NewPolicy returns a policy which keeps the last keepLast images and the images with a tag matching keepTagPattern
+func NewPolicy(keepLast uint, keepTagPattern string) (*Policy, error) {
+	policy := &Policy{
+		KeepLast: keepLast,
+	}
+
+	if keepTagPattern != "" {
+		re, err := regexp.Compile(keepTagPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid keep tag pattern: %w", err)
+		}
+
+		policy.KeepTagPattern = re
+	}
+
+	return policy, nil
+}
+
+// Result is the result of evaluating a policy on the images of a repository. Kept and deleted images are ordered
+// from the most recently pushed.
+type Result struct {
+	Keep   []types.RetentionDecision
+	Delete []types.RetentionDecision
+}
+
+// Evaluate returns which images of a repository are kept and deleted by a policy. Images are grouped by digest, since
+// deleting an image by digest deletes all of its tags, so an image is kept if any of its tags is kept. Images
+// without a digest or a push time are always kept.
+func Evaluate(repository string, images []*types.Image, policy *Policy, refs *References) *Result {
+	res := &Result{
+		Keep:   make([]types.RetentionDecision, 0),
+		Delete: make([]types.RetentionDecision, 0),
+	}
+
+	byDigest := make(map[string]*types.RetentionDecision)
+	digests := make([]string, 0)
+
+	for _, img := range images {
+		if img == nil {
+			continue
+		}
+
+		if img.Digest == "" {
+			res.Keep = append(res.Keep, types.RetentionDecision{
+				Tags:     nonEmptyTags(img.Tag),
+				PushedAt: img.PushedAt,
+				Reason:   "digest is unknown",
+			})
+
+			continue
+		}
+
+		decision, ok := byDigest[img.Digest]
+		if !ok {
+			decision = &types.RetentionDecision{
+				Digest: img.Digest,
+				Tags:   make([]string, 0),
+			}
+
+			byDigest[img.Digest] = decision
+			digests = append(digests, img.Digest)
+		}
+
+		if img.Tag != "" && !containsString(decision.Tags, img.Tag) {
+			decision.Tags = append(decision.Tags, img.Tag)
+		}
+
+		if img.PushedAt != nil && (decision.PushedAt == nil || img.PushedAt.After(*decision.PushedAt)) {
+			pushedAt := *img.PushedAt
+			decision.PushedAt = &pushedAt
+		}
+	}
+
+	sort.SliceStable(digests, func(i, j int) bool {
+		return pushedAfter(byDigest[digests[i]].PushedAt, byDigest[digests[j]].PushedAt)
+	})
+
+	var recent uint
+
+	for _, digest := range digests {
+		decision := byDigest[digest]
+		sort.Strings(decision.Tags)
+
+		decision.Reason = keepReason(repository, decision, policy, refs, recent)
+
+		if decision.PushedAt != nil {
+			recent++
+		}
+
+		if decision.Reason != "" {
+			res.Keep = append(res.Keep, *decision)
+		} else {
+			res.Delete = append(res.Delete, *decision)
+		}
+	}
+
+	sort.SliceStable(res.Keep, func(i, j int) bool {
+		return pushedAfter(res.Keep[i].PushedAt, res.Keep[j].PushedAt)
+	})
+
+	return res
+}
+
+// keepReason returns why an image is kept, or an empty string if the image can be deleted. recent is the number of
+// images which were pushed more recently than the image.
+func keepReason(repository string, decision *types.RetentionDecision, policy *Policy, refs *References, recent uint) string {
+	if refs != nil {
+		sources := refs.Sources(repository, decision.Digest)
+
+		for _, tag := range decision.Tags {
+			sources = append(sources, refs.Sources(repository, tag)...)
+		}
+
+		if len(sources) > 0 {
+			sort.Strings(sources)
+
+			if len(sources) == 1 {
+				return "referenced by " + sources[0]
+			}
+
+			return fmt.Sprintf("referenced by %s and %d more", sources[0], len(sources)-1)
+		}
+	}
+
+	if decision.PushedAt == nil {
+		return "push time is unknown"
+	}
+
+	if recent < policy.KeepLast {
+		return fmt.Sprintf("one of the %d most recently pushed images", policy.KeepLast)
+	}
+
+	if policy.KeepTagPattern != nil {
+		for _, tag := range decision.Tags {
+			if policy.KeepTagPattern.MatchString(tag) {
+				return fmt.Sprintf("tag %s matches %s", tag, policy.KeepTagPattern.String())
+			}
+		}
+	}
+
+	return ""
+}
+
+// Digests returns the digests of the images of decisions
+func Digests(decisions []types.RetentionDecision) []string {
+	res := make([]string, 0, len(decisions))
+
+	for _, decision := range decisions {
+		if decision.Digest != "" {
+			res = append(res, decision.Digest)
+		}
+	}
+
+	return res
+}
+
+// pushedAfter orders images from the most recently pushed, with images of unknown push time first
+func pushedAfter(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+
+	return a.After(*b)
+}
+
+func nonEmptyTags(tag string) []string {
+	if tag == "" {
+		return []string{}
+	}
+
+	return []string{tag}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
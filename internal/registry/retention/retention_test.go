@@ -0,0 +1,165 @@
+package retention_test
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/registry/retention"
+)
+
+const repository = "123456789012.dkr.ecr.us-east-1.amazonaws.com/web"
+
+var (
+	build5  = digest(5)
+	build4  = digest(4)
+	build3  = digest(3)
+	release = digest(2)
+	old     = digest(1)
+	build0  = digest(0)
+	unknown = digest(9)
+)
+
+func digest(n int) string {
+	return fmt.Sprintf("sha256:%064x", n)
+}
+
+var manifest = `---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: migrate
+          image: 123456789012.dkr.ecr.us-east-1.amazonaws.com/web:build-3
+      containers:
+        - name: web
+          image: 123456789012.dkr.ecr.us-east-1.amazonaws.com/web:build-3
+        - name: sidecar
+          image: "nginx"
+---
+this: is: not: valid: yaml
+---
+apiVersion: batch/v1
+kind: CronJob
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: cron
+              image: 123456789012.dkr.ecr.us-east-1.amazonaws.com/web@` + old + `
+`
+
+func images(now time.Time) []*types.Image {
+	pushed := func(hoursAgo int) *time.Time {
+		t := now.Add(-time.Duration(hoursAgo) * time.Hour)
+		return &t
+	}
+
+	return []*types.Image{
+		{Digest: build5, Tag: "build-5", PushedAt: pushed(1)},
+		{Digest: build5, Tag: "latest", PushedAt: pushed(1)},
+		{Digest: build4, Tag: "build-4", PushedAt: pushed(2)},
+		{Digest: build3, Tag: "build-3", PushedAt: pushed(3)},
+		{Digest: release, Tag: "v1.0.0", PushedAt: pushed(4)},
+		{Digest: old, Tag: "build-1", PushedAt: pushed(5)},
+		{Digest: build0, Tag: "build-0", PushedAt: pushed(6)},
+		{Digest: unknown, Tag: "imported"},
+		{Tag: "no-digest", PushedAt: pushed(7)},
+	}
+}
+
+func TestImagesFromManifest(t *testing.T) {
+	got := retention.ImagesFromManifest(manifest)
+	sort.Strings(got)
+
+	expected := []string{
+		"123456789012.dkr.ecr.us-east-1.amazonaws.com/web:build-3",
+		"123456789012.dkr.ecr.us-east-1.amazonaws.com/web@" + old,
+		"nginx",
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected images %v, got %v", expected, got)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	refs := retention.NewReferences()
+
+	for _, image := range retention.ImagesFromManifest(manifest) {
+		if err := refs.Add(image, "helm release default/web in cluster 1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := refs.AddTag("https://"+repository, "build-3", "app web in deployment target default"); err != nil {
+		t.Fatal(err)
+	}
+
+	policy, err := retention.NewPolicy(2, `^v[0-9]+\.[0-9]+\.[0-9]+$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := retention.Evaluate(repository, images(time.Now()), policy, refs)
+
+	if got := retention.Digests(res.Delete); !reflect.DeepEqual(got, []string{build0}) {
+		t.Fatalf("expected only the image tagged build-0 to be deleted, got %v", got)
+	}
+
+	reasons := make(map[string]string)
+
+	for _, decision := range res.Keep {
+		reasons[strings.Join(decision.Tags, ",")] = decision.Reason
+	}
+
+	expected := map[string]string{
+		"no-digest":      "digest is unknown",
+		"imported":       "push time is unknown",
+		"build-5,latest": "one of the 2 most recently pushed images",
+		"build-4":        "one of the 2 most recently pushed images",
+		"build-3":        "referenced by app web in deployment target default and 1 more",
+		"v1.0.0":         `tag v1.0.0 matches ^v[0-9]+\.[0-9]+\.[0-9]+$`,
+		"build-1":        "referenced by helm release default/web in cluster 1",
+	}
+
+	if !reflect.DeepEqual(reasons, expected) {
+		t.Fatalf("expected kept images %v, got %v", expected, reasons)
+	}
+
+	if res.Keep[0].Digest != unknown || res.Keep[1].Digest != build5 {
+		t.Errorf("expected kept images to be ordered from the most recently pushed, got %v", res.Keep)
+	}
+}
+
+func TestEvaluateWithoutReferences(t *testing.T) {
+	policy, err := retention.NewPolicy(1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := retention.Evaluate(repository, images(time.Now()), policy, nil)
+
+	expected := []string{build4, build3, release, old, build0}
+
+	if got := retention.Digests(res.Delete); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v to be deleted from the most recently pushed, got %v", expected, got)
+	}
+}
+
+func TestNewPolicyInvalidPattern(t *testing.T) {
+	if _, err := retention.NewPolicy(1, "v[0-9"); err == nil {
+		t.Fatal("expected an error for an invalid keep tag pattern")
+	}
+}
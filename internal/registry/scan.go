@@ -0,0 +1,178 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	ptypes "github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry/scanner"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// RepositoryURI returns the URI of a repository of the registry, like 123456789.dkr.ecr.us-west-2.amazonaws.com/web,
+// which is how the repository is referred to by the images of apps and by the results of image scans
+func (r *Registry) RepositoryURI(repoName string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(r.URL, "https://"), "http://")
+
+	return strings.TrimSuffix(host, "/") + "/" + strings.TrimPrefix(repoName, "/")
+}
+
+// GetScanner returns the scanner for the images of the registry with the given format. Trivy reports can be
+// ingested for the images of any registry, while ECR findings can only be imported for ECR registries.
+func (r *Registry) GetScanner(
+	ctx context.Context,
+	format ptypes.ImageScanFormat,
+	report []byte,
+	conf *config.Config,
+) (scanner.Scanner, error) {
+	ctx, span := telemetry.NewSpan(ctx, "get-image-scanner")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "registry-id", Value: r.ID},
+		telemetry.AttributeKV{Key: "project-id", Value: r.ProjectID},
+		telemetry.AttributeKV{Key: "format", Value: string(format)},
+	)
+
+	switch format {
+	case ptypes.ImageScanFormatTrivy:
+		return &scanner.TrivyScanner{Report: report}, nil
+	case ptypes.ImageScanFormatECR:
+		if !r.isECR() {
+			return nil, telemetry.Error(ctx, span, nil, "ecr image scanning is only supported for ecr registries")
+		}
+
+		aws, err := r.getECRAWSIntegration(ctx, conf)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error getting aws credentials of registry")
+		}
+
+		sess, err := aws.GetSession()
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error getting aws session")
+		}
+
+		return &scanner.ECRScanner{Client: ecr.New(sess)}, nil
+	}
+
+	return nil, telemetry.Error(ctx, span, nil, fmt.Sprintf("unsupported image scan format %q", format))
+}
+
+// AttachVulnerabilities sets the vulnerability summaries of the images of a repository of the registry from their
+// stored scans. The most recent stored scan of each digest takes precedence over the summary returned by the registry.
+func (r *Registry) AttachVulnerabilities(
+	ctx context.Context,
+	repoName string,
+	imgs []*ptypes.Image,
+	repo repository.Repository,
+) error {
+	digests := make([]string, 0, len(imgs))
+
+	for _, img := range imgs {
+		if img.Digest != "" {
+			digests = append(digests, img.Digest)
+		}
+	}
+
+	scans, err := repo.ImageScan().ListImageScansByDigests(ctx, r.ProjectID, r.RepositoryURI(repoName), digests)
+	if err != nil {
+		return err
+	}
+
+	summaries := make(map[string]*ptypes.VulnerabilitySummary)
+
+	// scans are ordered from the most recent
+	for _, scan := range scans {
+		if _, ok := summaries[scan.Digest]; !ok {
+			summaries[scan.Digest] = scan.ToVulnerabilitySummaryType()
+		}
+	}
+
+	for _, img := range imgs {
+		if summary, ok := summaries[img.Digest]; ok {
+			img.Vulnerabilities = summary
+		}
+	}
+
+	return nil
+}
+
+// ErrImageNotFound is returned when a tag of an image repository cannot be found in the registries of a project
+var ErrImageNotFound = errors.New("image not found in the registries of the project")
+
+// ResolveImageDigest returns the digest currently pointed to by a tag of an image repository, like
+// 123456789.dkr.ecr.us-west-2.amazonaws.com/web, by listing the images of the repository in the registry of the
+// project it belongs to. Tags can be moved to other images, so the digest is what identifies the image which is
+// deployed.
+func ResolveImageDigest(
+	ctx context.Context,
+	projectID uint,
+	imageRepository string,
+	tag string,
+	repo repository.Repository,
+	conf *config.Config,
+) (string, error) {
+	ctx, span := telemetry.NewSpan(ctx, "resolve-image-digest")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "image-repository", Value: imageRepository},
+		telemetry.AttributeKV{Key: "tag", Value: tag},
+	)
+
+	regs, err := repo.Registry().ListRegistriesByProjectID(projectID)
+	if err != nil {
+		return "", telemetry.Error(ctx, span, err, "error listing registries of project")
+	}
+
+	var (
+		match       *models.Registry
+		matchPrefix string
+	)
+
+	// the registry with the longest matching url is used, since registries like GAR can have urls which are prefixes
+	// of each other
+	for _, reg := range regs {
+		_reg := Registry(*reg)
+		prefix := _reg.RepositoryURI("")
+
+		if strings.HasPrefix(imageRepository, prefix) && len(prefix) > len(matchPrefix) {
+			match = reg
+			matchPrefix = prefix
+		}
+	}
+
+	repoName := strings.TrimPrefix(imageRepository, matchPrefix)
+
+	if match == nil || repoName == "" {
+		return "", ErrImageNotFound
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "registry-id", Value: match.ID},
+		telemetry.AttributeKV{Key: "repo-name", Value: repoName},
+	)
+
+	_reg := Registry(*match)
+
+	imgs, err := _reg.ListImages(ctx, repoName, repo, conf)
+	if err != nil {
+		return "", telemetry.Error(ctx, span, err, "error listing images of repository")
+	}
+
+	for _, img := range imgs {
+		if img != nil && img.Tag == tag && img.Digest != "" {
+			telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "digest", Value: img.Digest})
+			return img.Digest, nil
+		}
+	}
+
+	return "", ErrImageNotFound
+}
@@ -0,0 +1,182 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
+	"github.com/porter-dev/porter/api/types"
+)
+
+// ECRScanner imports the findings of the native image scanning of ECR, either basic or enhanced scanning. Images
+// are scanned by ECR on push or on demand, so the scanner does not start a scan.
+type ECRScanner struct {
+	Client ecriface.ECRAPI
+}
+
+// Name is the name of the scanner
+func (s *ECRScanner) Name() string {
+	return string(types.ImageScanFormatECR)
+}
+
+// Scan returns the findings of the latest completed ECR scan of an image
+func (s *ECRScanner) Scan(ctx context.Context, image Image) (*Report, error) {
+	if image.RepoName == "" {
+		return nil, errors.New("repository name is empty")
+	}
+
+	imageID := &ecr.ImageIdentifier{}
+
+	switch {
+	case image.Digest != "":
+		imageID.ImageDigest = aws.String(image.Digest)
+	case image.Tag != "":
+		imageID.ImageTag = aws.String(image.Tag)
+	default:
+		return nil, errors.New("one of the image tag or digest must be set")
+	}
+
+	res := &Report{
+		Scanner:         s.Name(),
+		Digest:          image.Digest,
+		Vulnerabilities: make([]types.Vulnerability, 0),
+	}
+
+	var nextToken *string
+
+	for {
+		resp, err := s.Client.DescribeImageScanFindingsWithContext(ctx, &ecr.DescribeImageScanFindingsInput{
+			RepositoryName: aws.String(image.RepoName),
+			ImageId:        imageID,
+			NextToken:      nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error describing ecr image scan findings: %w", err)
+		}
+
+		status, description := "", ""
+
+		if resp.ImageScanStatus != nil {
+			status = aws.StringValue(resp.ImageScanStatus.Status)
+			description = aws.StringValue(resp.ImageScanStatus.Description)
+		}
+
+		// enhanced scanning continuously scans images, so their status is active rather than complete
+		if status != ecr.ScanStatusComplete && status != ecr.ScanStatusActive {
+			return nil, fmt.Errorf("ecr scan of image is not complete: status %q: %s", status, description)
+		}
+
+		if resp.ImageId != nil && res.Digest == "" {
+			res.Digest = aws.StringValue(resp.ImageId.ImageDigest)
+		}
+
+		if resp.ImageScanFindings != nil {
+			res.ScannedAt = resp.ImageScanFindings.ImageScanCompletedAt
+
+			for _, finding := range resp.ImageScanFindings.Findings {
+				res.Vulnerabilities = append(res.Vulnerabilities, ecrFindingToVulnerability(finding))
+			}
+
+			for _, finding := range resp.ImageScanFindings.EnhancedFindings {
+				res.Vulnerabilities = append(res.Vulnerabilities, ecrEnhancedFindingToVulnerabilities(finding)...)
+			}
+		}
+
+		if resp.NextToken == nil {
+			break
+		}
+
+		nextToken = resp.NextToken
+	}
+
+	sortVulnerabilities(res.Vulnerabilities)
+
+	return res, nil
+}
+
+// SummaryFromECRImageDetail returns the vulnerability summary of the latest ECR scan of an image, or nil if the image
+// has not been scanned
+func SummaryFromECRImageDetail(detail *ecr.ImageDetail) *types.VulnerabilitySummary {
+	if detail == nil || detail.ImageScanFindingsSummary == nil {
+		return nil
+	}
+
+	summary := &types.VulnerabilitySummary{
+		Scanner:   string(types.ImageScanFormatECR),
+		ScannedAt: detail.ImageScanFindingsSummary.ImageScanCompletedAt,
+	}
+
+	for severity, count := range detail.ImageScanFindingsSummary.FindingSeverityCounts {
+		n := uint(aws.Int64Value(count))
+
+		switch ParseSeverity(severity) {
+		case types.VulnerabilitySeverityCritical:
+			summary.Critical += n
+		case types.VulnerabilitySeverityHigh:
+			summary.High += n
+		case types.VulnerabilitySeverityMedium:
+			summary.Medium += n
+		case types.VulnerabilitySeverityLow:
+			summary.Low += n
+		default:
+			summary.Unknown += n
+		}
+	}
+
+	return summary
+}
+
+func ecrFindingToVulnerability(finding *ecr.ImageScanFinding) types.Vulnerability {
+	vuln := types.Vulnerability{
+		ID:       aws.StringValue(finding.Name),
+		Severity: ParseSeverity(aws.StringValue(finding.Severity)),
+		Title:    aws.StringValue(finding.Description),
+		URL:      aws.StringValue(finding.Uri),
+	}
+
+	for _, attr := range finding.Attributes {
+		switch aws.StringValue(attr.Key) {
+		case "package_name":
+			vuln.PackageName = aws.StringValue(attr.Value)
+		case "package_version":
+			vuln.InstalledVersion = aws.StringValue(attr.Value)
+		}
+	}
+
+	return vuln
+}
+
+// ecrEnhancedFindingToVulnerabilities returns a vulnerability for each vulnerable package of an enhanced finding
+func ecrEnhancedFindingToVulnerabilities(finding *ecr.EnhancedImageScanFinding) []types.Vulnerability {
+	vuln := types.Vulnerability{
+		Severity: ParseSeverity(aws.StringValue(finding.Severity)),
+		Title:    aws.StringValue(finding.Title),
+	}
+
+	details := finding.PackageVulnerabilityDetails
+	if details == nil {
+		return []types.Vulnerability{vuln}
+	}
+
+	vuln.ID = aws.StringValue(details.VulnerabilityId)
+	vuln.URL = aws.StringValue(details.SourceUrl)
+
+	if len(details.VulnerablePackages) == 0 {
+		return []types.Vulnerability{vuln}
+	}
+
+	res := make([]types.Vulnerability, 0, len(details.VulnerablePackages))
+
+	for _, pkg := range details.VulnerablePackages {
+		pkgVuln := vuln
+		pkgVuln.PackageName = aws.StringValue(pkg.Name)
+		pkgVuln.InstalledVersion = aws.StringValue(pkg.Version)
+
+		res = append(res, pkgVuln)
+	}
+
+	return res
+}
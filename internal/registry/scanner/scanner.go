@@ -0,0 +1,145 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// Image identifies an image to scan
+type Image struct {
+	// RepoName is the name of the repository of the image in its registry
+	RepoName string
+	Tag      string
+	Digest   string
+}
+
+// Report is the result of a scan of an image
+type Report struct {
+	// Scanner is the name of the scanner which created the report
+	Scanner string
+
+	// Digest is the digest of the scanned image
+	Digest string
+
+	// ScannedAt is when the image was scanned, if the scanner reports it
+	ScannedAt *time.Time
+
+	Vulnerabilities []types.Vulnerability
+}
+
+// Scanner finds the vulnerabilities of an image
+type Scanner interface {
+	// Name is the name of the scanner, which is stored with its reports
+	Name() string
+
+	// Scan returns the vulnerabilities found in an image
+	Scan(ctx context.Context, image Image) (*Report, error)
+}
+
+// severityRanks orders the severities of vulnerabilities, from the least to the most severe
+var severityRanks = map[types.VulnerabilitySeverity]int{
+	types.VulnerabilitySeverityUnknown:  0,
+	types.VulnerabilitySeverityLow:      1,
+	types.VulnerabilitySeverityMedium:   2,
+	types.VulnerabilitySeverityHigh:     3,
+	types.VulnerabilitySeverityCritical: 4,
+}
+
+// ParseSeverity converts the severity of a vulnerability reported by a scanner to a types.VulnerabilitySeverity.
+// Severities which are not rated on the scale of critical to low are unknown.
+func ParseSeverity(severity string) types.VulnerabilitySeverity {
+	s := types.VulnerabilitySeverity(strings.ToUpper(strings.TrimSpace(severity)))
+
+	if _, ok := severityRanks[s]; ok {
+		return s
+	}
+
+	return types.VulnerabilitySeverityUnknown
+}
+
+// Summary counts the vulnerabilities of the report by severity
+func (r *Report) Summary() types.VulnerabilitySummary {
+	summary := types.VulnerabilitySummary{
+		Scanner:   r.Scanner,
+		ScannedAt: r.ScannedAt,
+	}
+
+	for _, vuln := range r.Vulnerabilities {
+		switch vuln.Severity {
+		case types.VulnerabilitySeverityCritical:
+			summary.Critical++
+		case types.VulnerabilitySeverityHigh:
+			summary.High++
+		case types.VulnerabilitySeverityMedium:
+			summary.Medium++
+		case types.VulnerabilitySeverityLow:
+			summary.Low++
+		default:
+			summary.Unknown++
+		}
+	}
+
+	return summary
+}
+
+// sortVulnerabilities orders vulnerabilities from the most to the least severe, then by ID and package
+func sortVulnerabilities(vulns []types.Vulnerability) {
+	sort.SliceStable(vulns, func(i, j int) bool {
+		if severityRanks[vulns[i].Severity] != severityRanks[vulns[j].Severity] {
+			return severityRanks[vulns[i].Severity] > severityRanks[vulns[j].Severity]
+		}
+
+		if vulns[i].ID != vulns[j].ID {
+			return vulns[i].ID < vulns[j].ID
+		}
+
+		return vulns[i].PackageName < vulns[j].PackageName
+	})
+}
+
+// CountAtOrAbove returns the number of vulnerabilities of a summary with a severity at or above the threshold
+func CountAtOrAbove(summary types.VulnerabilitySummary, threshold types.VulnerabilitySeverity) uint {
+	counts := map[types.VulnerabilitySeverity]uint{
+		types.VulnerabilitySeverityCritical: summary.Critical,
+		types.VulnerabilitySeverityHigh:     summary.High,
+		types.VulnerabilitySeverityMedium:   summary.Medium,
+		types.VulnerabilitySeverityLow:      summary.Low,
+	}
+
+	rank, ok := severityRanks[threshold]
+	if !ok || threshold == types.VulnerabilitySeverityUnknown {
+		return 0
+	}
+
+	var res uint
+
+	for severity, count := range counts {
+		if severityRanks[severity] >= rank {
+			res += count
+		}
+	}
+
+	return res
+}
+
+// CheckSeverityGate returns an error describing the vulnerabilities of a summary which block a deploy, if any
+// vulnerability has a severity at or above the threshold. An empty threshold does not block any deploy.
+func CheckSeverityGate(summary types.VulnerabilitySummary, threshold types.VulnerabilitySeverity) error {
+	if threshold == "" {
+		return nil
+	}
+
+	if count := CountAtOrAbove(summary, threshold); count != 0 {
+		return fmt.Errorf(
+			"%d vulnerabilities at or above the %s severity gate of the project (critical: %d, high: %d, medium: %d, low: %d) found by %s",
+			count, strings.ToLower(string(threshold)), summary.Critical, summary.High, summary.Medium, summary.Low, summary.Scanner,
+		)
+	}
+
+	return nil
+}
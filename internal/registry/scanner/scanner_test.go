@@ -0,0 +1,171 @@
+package scanner_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/registry/scanner"
+)
+
+const trivyReport = `{
+  "SchemaVersion": 2,
+  "CreatedAt": "2023-11-02T10:00:00Z",
+  "ArtifactName": "123456789012.dkr.ecr.us-east-1.amazonaws.com/web:v1",
+  "ArtifactType": "container_image",
+  "Metadata": {
+    "RepoDigests": ["123456789012.dkr.ecr.us-east-1.amazonaws.com/web@sha256:abc"]
+  },
+  "Results": [
+    {
+      "Target": "web (debian 12.1)",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2023-0002", "PkgName": "openssl", "InstalledVersion": "3.0.9", "FixedVersion": "3.0.11", "Severity": "HIGH"},
+        {"VulnerabilityID": "CVE-2023-0001", "PkgName": "libc6", "InstalledVersion": "2.36", "Severity": "CRITICAL"}
+      ]
+    },
+    {
+      "Target": "usr/local/bin/web",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2023-0002", "PkgName": "openssl", "InstalledVersion": "3.0.9", "FixedVersion": "3.0.11", "Severity": "HIGH"},
+        {"VulnerabilityID": "GHSA-xxxx", "PkgName": "golang.org/x/net", "InstalledVersion": "0.1.0", "Severity": "NEGLIGIBLE"}
+      ]
+    }
+  ]
+}`
+
+func TestTrivyScanner(t *testing.T) {
+	s := &scanner.TrivyScanner{Report: []byte(trivyReport)}
+
+	report, err := s.Scan(context.Background(), scanner.Image{RepoName: "web", Tag: "v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Digest != "sha256:abc" {
+		t.Errorf("expected the digest of the report to be sha256:abc, got %s", report.Digest)
+	}
+
+	if len(report.Vulnerabilities) != 3 {
+		t.Fatalf("expected 3 deduplicated vulnerabilities, got %v", report.Vulnerabilities)
+	}
+
+	if report.Vulnerabilities[0].ID != "CVE-2023-0001" {
+		t.Errorf("expected the critical vulnerability first, got %s", report.Vulnerabilities[0].ID)
+	}
+
+	summary := report.Summary()
+
+	if summary.Critical != 1 || summary.High != 1 || summary.Unknown != 1 || summary.Scanner != "trivy" {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+
+	if _, err := s.Scan(context.Background(), scanner.Image{Digest: "sha256:def"}); err == nil {
+		t.Errorf("expected an error for a report of an image with another digest")
+	}
+}
+
+type mockECRClient struct {
+	ecriface.ECRAPI
+
+	pages []*ecr.DescribeImageScanFindingsOutput
+}
+
+func (c *mockECRClient) DescribeImageScanFindingsWithContext(
+	ctx aws.Context,
+	input *ecr.DescribeImageScanFindingsInput,
+	opts ...request.Option,
+) (*ecr.DescribeImageScanFindingsOutput, error) {
+	page := c.pages[0]
+	c.pages = c.pages[1:]
+
+	return page, nil
+}
+
+func TestECRScanner(t *testing.T) {
+	client := &mockECRClient{
+		pages: []*ecr.DescribeImageScanFindingsOutput{
+			{
+				ImageId:         &ecr.ImageIdentifier{ImageDigest: aws.String("sha256:abc")},
+				ImageScanStatus: &ecr.ImageScanStatus{Status: aws.String(ecr.ScanStatusComplete)},
+				ImageScanFindings: &ecr.ImageScanFindings{
+					Findings: []*ecr.ImageScanFinding{
+						{
+							Name:     aws.String("CVE-2023-0003"),
+							Severity: aws.String("MEDIUM"),
+							Attributes: []*ecr.Attribute{
+								{Key: aws.String("package_name"), Value: aws.String("zlib")},
+								{Key: aws.String("package_version"), Value: aws.String("1.2.13")},
+							},
+						},
+					},
+				},
+				NextToken: aws.String("next"),
+			},
+			{
+				ImageId:         &ecr.ImageIdentifier{ImageDigest: aws.String("sha256:abc")},
+				ImageScanStatus: &ecr.ImageScanStatus{Status: aws.String(ecr.ScanStatusComplete)},
+				ImageScanFindings: &ecr.ImageScanFindings{
+					Findings: []*ecr.ImageScanFinding{
+						{Name: aws.String("CVE-2023-0004"), Severity: aws.String("CRITICAL")},
+					},
+				},
+			},
+		},
+	}
+
+	report, err := (&scanner.ECRScanner{Client: client}).Scan(context.Background(), scanner.Image{RepoName: "web", Tag: "v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Digest != "sha256:abc" {
+		t.Errorf("expected digest sha256:abc, got %s", report.Digest)
+	}
+
+	if len(report.Vulnerabilities) != 2 || report.Vulnerabilities[0].ID != "CVE-2023-0004" {
+		t.Fatalf("expected the findings of both pages, most severe first, got %v", report.Vulnerabilities)
+	}
+
+	if report.Vulnerabilities[1].PackageName != "zlib" || report.Vulnerabilities[1].InstalledVersion != "1.2.13" {
+		t.Errorf("expected the package of the finding to be set, got %+v", report.Vulnerabilities[1])
+	}
+
+	client.pages = []*ecr.DescribeImageScanFindingsOutput{
+		{ImageScanStatus: &ecr.ImageScanStatus{Status: aws.String(ecr.ScanStatusInProgress)}},
+	}
+
+	if _, err := (&scanner.ECRScanner{Client: client}).Scan(context.Background(), scanner.Image{RepoName: "web", Tag: "v1"}); err == nil {
+		t.Errorf("expected an error for a scan in progress")
+	}
+}
+
+func TestCheckSeverityGate(t *testing.T) {
+	summary := types.VulnerabilitySummary{Scanner: "trivy", High: 2, Low: 5}
+
+	tests := []struct {
+		threshold types.VulnerabilitySeverity
+		blocked   bool
+	}{
+		{threshold: "", blocked: false},
+		{threshold: types.VulnerabilitySeverityCritical, blocked: false},
+		{threshold: types.VulnerabilitySeverityHigh, blocked: true},
+		{threshold: types.VulnerabilitySeverityLow, blocked: true},
+	}
+
+	for _, test := range tests {
+		err := scanner.CheckSeverityGate(summary, test.threshold)
+
+		if blocked := err != nil; blocked != test.blocked {
+			t.Errorf("threshold %q: expected blocked to be %t, got error %v", test.threshold, test.blocked, err)
+		}
+	}
+
+	if count := scanner.CountAtOrAbove(summary, types.VulnerabilitySeverityMedium); count != 2 {
+		t.Errorf("expected 2 vulnerabilities at or above medium, got %d", count)
+	}
+}
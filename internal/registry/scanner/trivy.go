@@ -0,0 +1,134 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// TrivyScanner ingests a report created by Trivy, or by another scanner which writes reports in the JSON format of
+// Trivy, such as `trivy image --format json`
+type TrivyScanner struct {
+	Report []byte
+}
+
+type trivyReport struct {
+	SchemaVersion int           `json:"SchemaVersion"`
+	CreatedAt     *time.Time    `json:"CreatedAt"`
+	ArtifactName  string        `json:"ArtifactName"`
+	Metadata      trivyMetadata `json:"Metadata"`
+	Results       []trivyResult `json:"Results"`
+}
+
+type trivyMetadata struct {
+	RepoDigests []string `json:"RepoDigests"`
+}
+
+type trivyResult struct {
+	Target          string               `json:"Target"`
+	Vulnerabilities []trivyVulnerability `json:"Vulnerabilities"`
+}
+
+type trivyVulnerability struct {
+	VulnerabilityID  string `json:"VulnerabilityID"`
+	PkgName          string `json:"PkgName"`
+	InstalledVersion string `json:"InstalledVersion"`
+	FixedVersion     string `json:"FixedVersion"`
+	Severity         string `json:"Severity"`
+	Title            string `json:"Title"`
+	PrimaryURL       string `json:"PrimaryURL"`
+}
+
+// Name is the name of the scanner
+func (s *TrivyScanner) Name() string {
+	return string(types.ImageScanFormatTrivy)
+}
+
+// Scan parses the report. The digest of the image is taken from the report if it is not set, and must match the
+// digests of the report if it is set.
+func (s *TrivyScanner) Scan(ctx context.Context, image Image) (*Report, error) {
+	if len(s.Report) == 0 {
+		return nil, errors.New("trivy report is empty")
+	}
+
+	report := &trivyReport{}
+
+	if err := json.Unmarshal(s.Report, report); err != nil {
+		return nil, fmt.Errorf("error parsing trivy report: %w", err)
+	}
+
+	if report.SchemaVersion != 0 && report.SchemaVersion != 2 {
+		return nil, fmt.Errorf("unsupported trivy report schema version %d", report.SchemaVersion)
+	}
+
+	digests := make([]string, 0, len(report.Metadata.RepoDigests))
+
+	for _, repoDigest := range report.Metadata.RepoDigests {
+		if _, digest, ok := strings.Cut(repoDigest, "@"); ok {
+			digests = append(digests, digest)
+		}
+	}
+
+	digest := image.Digest
+
+	if digest == "" {
+		if len(digests) == 0 {
+			return nil, errors.New("trivy report does not contain the digest of the image, so a digest must be set")
+		}
+
+		digest = digests[0]
+	} else if len(digests) != 0 && !containsString(digests, digest) {
+		return nil, fmt.Errorf("trivy report of %s is not for an image with digest %s", report.ArtifactName, digest)
+	}
+
+	res := &Report{
+		Scanner:         s.Name(),
+		Digest:          digest,
+		ScannedAt:       report.CreatedAt,
+		Vulnerabilities: make([]types.Vulnerability, 0),
+	}
+
+	// the same vulnerability is reported once for each target which contains the package
+	seen := make(map[string]bool)
+
+	for _, result := range report.Results {
+		for _, vuln := range result.Vulnerabilities {
+			key := strings.Join([]string{vuln.VulnerabilityID, vuln.PkgName, vuln.InstalledVersion}, "/")
+
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+
+			res.Vulnerabilities = append(res.Vulnerabilities, types.Vulnerability{
+				ID:               vuln.VulnerabilityID,
+				Severity:         ParseSeverity(vuln.Severity),
+				PackageName:      vuln.PkgName,
+				InstalledVersion: vuln.InstalledVersion,
+				FixedVersion:     vuln.FixedVersion,
+				Title:            vuln.Title,
+				URL:              vuln.PrimaryURL,
+			})
+		}
+	}
+
+	sortVulnerabilities(res.Vulnerabilities)
+
+	return res, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -10,4 +12,8 @@ type AppRevisionRepository interface {
 	AppRevisionByInstanceIDAndRevisionNumber(projectID uint, appInstanceId string, revisionNumber uint) (*models.AppRevision, error)
 	// LatestNumberedAppRevision finds the latest numbered app revision
 	LatestNumberedAppRevision(projectID uint, appInstanceId string) (*models.AppRevision, error)
+	// LiveAppRevisions finds the latest revision and the latest successfully deployed revision of every app of a project in every deployment target
+	LiveAppRevisions(ctx context.Context, projectID uint) ([]*models.AppRevision, error)
+	// LatestAppRevisions finds the last count revisions of every app of a project in every deployment target
+	LatestAppRevisions(ctx context.Context, projectID uint, count uint) ([]*models.AppRevision, error)
 }
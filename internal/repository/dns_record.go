@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/google/uuid"
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -8,4 +9,8 @@ import (
 // DNSRecord model
 type DNSRecordRepository interface {
 	CreateDNSRecord(record *models.DNSRecord) (*models.DNSRecord, error)
+	ListDNSRecordsByPorterAppName(clusterID uint, appName string) ([]*models.DNSRecord, error)
+	ListDNSRecordsByDeploymentTargetID(deploymentTargetID uuid.UUID) ([]*models.DNSRecord, error)
+	ListDNSRecordsWithoutAppByHostnames(hostnames []string) ([]*models.DNSRecord, error)
+	DeleteDNSRecord(record *models.DNSRecord) error
 }
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// EnvGroupImportSourceRepository represents the set of queries on the EnvGroupImportSource model
+type EnvGroupImportSourceRepository interface {
+	// UpdateEnvGroupImportSource creates or updates the import source of an environment group
+	UpdateEnvGroupImportSource(ctx context.Context, source *models.EnvGroupImportSource) (*models.EnvGroupImportSource, error)
+	// ReadEnvGroupImportSource returns the import source of an environment group
+	ReadEnvGroupImportSource(ctx context.Context, projectID, clusterID uint, envGroupName string) (*models.EnvGroupImportSource, error)
+	// ListEnvGroupImportSourcesByClusterID returns the import sources of the environment groups of a cluster, ordered by environment group
+	ListEnvGroupImportSourcesByClusterID(ctx context.Context, projectID, clusterID uint) ([]*models.EnvGroupImportSource, error)
+	// ListScheduledEnvGroupImportSources returns the import sources of all projects which are synced on a schedule
+	ListScheduledEnvGroupImportSources(ctx context.Context) ([]*models.EnvGroupImportSource, error)
+	// RecordEnvGroupImportSourceSync stores the time, the resulting environment group version and the error of the latest sync of a source
+	RecordEnvGroupImportSourceSync(ctx context.Context, sourceID uint, syncedAt time.Time, version int, syncErr string) error
+	// DeleteEnvGroupImportSource deletes the import source of an environment group
+	DeleteEnvGroupImportSource(ctx context.Context, source *models.EnvGroupImportSource) error
+}
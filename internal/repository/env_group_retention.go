@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// EnvGroupRetentionRepository represents the set of queries on the EnvGroupRetentionPolicy model
+type EnvGroupRetentionRepository interface {
+	// UpdateEnvGroupRetentionPolicy creates or updates the environment group retention policy of a project
+	UpdateEnvGroupRetentionPolicy(ctx context.Context, policy *models.EnvGroupRetentionPolicy) (*models.EnvGroupRetentionPolicy, error)
+	// ReadEnvGroupRetentionPolicy returns the environment group retention policy of a project
+	ReadEnvGroupRetentionPolicy(ctx context.Context, projectID uint) (*models.EnvGroupRetentionPolicy, error)
+	// ListEnabledEnvGroupRetentionPolicies returns the enabled environment group retention policies of all projects, ordered by project
+	ListEnabledEnvGroupRetentionPolicies(ctx context.Context) ([]*models.EnvGroupRetentionPolicy, error)
+	// RecordEnvGroupRetentionPolicyRun stores the time and the number of deleted copies of the latest garbage collection of a policy
+	RecordEnvGroupRetentionPolicyRun(ctx context.Context, policyID uint, runAt time.Time, deletedCount uint) error
+}
@@ -1,8 +1,11 @@
 package gorm
 
 import (
+	"context"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
 	"gorm.io/gorm"
 )
 
@@ -38,3 +41,76 @@ func (repo *AppRevisionRepository) LatestNumberedAppRevision(projectID uint, app
 
 	return AppRevision, nil
 }
+
+// LiveAppRevisions finds the latest revision and the latest successfully deployed revision of every app of a project in every deployment target
+func (repo *AppRevisionRepository) LiveAppRevisions(ctx context.Context, projectID uint) ([]*models.AppRevision, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-live-app-revisions")
+	defer span.End()
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: projectID})
+
+	deployedStatuses := []models.AppRevisionStatus{
+		models.AppRevisionStatus_InstallSuccessful,
+		models.AppRevisionStatus_DeploymentProgressing,
+		models.AppRevisionStatus_DeploymentSuccessful,
+		models.AppRevisionStatus_RollbackSuccessful,
+	}
+
+	latest := repo.db.Model(&models.AppRevision{}).
+		Select("porter_app_id, deployment_target_id, MAX(revision_number)").
+		Where("project_id = ?", projectID).
+		Group("porter_app_id, deployment_target_id")
+
+	latestDeployed := repo.db.Model(&models.AppRevision{}).
+		Select("porter_app_id, deployment_target_id, MAX(revision_number)").
+		Where("project_id = ? AND status IN (?)", projectID, deployedStatuses).
+		Group("porter_app_id, deployment_target_id")
+
+	revisions := []*models.AppRevision{}
+
+	if err := repo.db.Where(
+		"project_id = ? AND ((porter_app_id, deployment_target_id, revision_number) IN (?) OR (porter_app_id, deployment_target_id, revision_number) IN (?))",
+		projectID, latest, latestDeployed,
+	).Find(&revisions).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing live app revisions")
+	}
+
+	return revisions, nil
+}
+
+// LatestAppRevisions finds the last count revisions of every app of a project in every deployment target
+func (repo *AppRevisionRepository) LatestAppRevisions(ctx context.Context, projectID uint, count uint) ([]*models.AppRevision, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-latest-app-revisions")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "count", Value: count},
+	)
+
+	revisions := []*models.AppRevision{}
+
+	if count == 0 {
+		return revisions, nil
+	}
+
+	latest := repo.db.Model(&models.AppRevision{}).
+		Select("porter_app_id, deployment_target_id, MAX(revision_number) AS max_revision_number").
+		Where("project_id = ?", projectID).
+		Group("porter_app_id, deployment_target_id")
+
+	// revision numbers are sequential for every app in every deployment target, so the last count revisions are the
+	// revisions whose number is within count of the latest revision number
+	if err := repo.db.Model(&models.AppRevision{}).
+		Joins(
+			"JOIN (?) latest ON latest.porter_app_id = app_revisions.porter_app_id AND latest.deployment_target_id = app_revisions.deployment_target_id",
+			latest,
+		).
+		Where("app_revisions.project_id = ? AND app_revisions.revision_number > latest.max_revision_number - ?", projectID, count).
+		Order("app_revisions.porter_app_id ASC, app_revisions.deployment_target_id ASC, app_revisions.revision_number DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing latest app revisions")
+	}
+
+	return revisions, nil
+}
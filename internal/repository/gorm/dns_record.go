@@ -1,6 +1,9 @@
 package gorm
 
 import (
+	"errors"
+
+	"github.com/google/uuid"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
@@ -25,3 +28,51 @@ func (repo *DNSRecordRepository) CreateDNSRecord(record *models.DNSRecord) (*mod
 
 	return record, nil
 }
+
+// ListDNSRecordsByPorterAppName lists the dns records created for an app in a cluster
+func (repo *DNSRecordRepository) ListDNSRecordsByPorterAppName(clusterID uint, appName string) ([]*models.DNSRecord, error) {
+	records := []*models.DNSRecord{}
+
+	if err := repo.db.Where("cluster_id = ? AND porter_app_name = ?", clusterID, appName).Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// ListDNSRecordsByDeploymentTargetID lists the dns records created for apps in a deployment target
+func (repo *DNSRecordRepository) ListDNSRecordsByDeploymentTargetID(deploymentTargetID uuid.UUID) ([]*models.DNSRecord, error) {
+	// records created without a deployment target have a nil deployment target id, and must not be listed for any deployment target
+	if deploymentTargetID == uuid.Nil {
+		return nil, errors.New("deployment target id cannot be nil")
+	}
+
+	records := []*models.DNSRecord{}
+
+	if err := repo.db.Where("deployment_target_id = ?", deploymentTargetID).Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// ListDNSRecordsWithoutAppByHostnames lists the dns records with one of the given hostnames which were created before records
+// were assigned to the app they were created for
+func (repo *DNSRecordRepository) ListDNSRecordsWithoutAppByHostnames(hostnames []string) ([]*models.DNSRecord, error) {
+	records := []*models.DNSRecord{}
+
+	if len(hostnames) == 0 {
+		return records, nil
+	}
+
+	if err := repo.db.Where("porter_app_name = ? AND hostname IN ?", "", hostnames).Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// DeleteDNSRecord deletes a dns record
+func (repo *DNSRecordRepository) DeleteDNSRecord(record *models.DNSRecord) error {
+	return repo.db.Delete(record).Error
+}
@@ -0,0 +1,110 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestListAndDeleteDNSRecords(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_delete_dns_records.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	previewTargetID := uuid.New()
+
+	records := []*models.DNSRecord{
+		{SubdomainPrefix: "web-abc", RootDomain: "porter.run", ClusterID: 1, PorterAppName: "api"},
+		{SubdomainPrefix: "web-def", RootDomain: "porter.run", ClusterID: 1, PorterAppName: "api", DeploymentTargetID: previewTargetID},
+		{SubdomainPrefix: "web-ghi", RootDomain: "porter.run", ClusterID: 2, PorterAppName: "api"},
+		{SubdomainPrefix: "web-jkl", RootDomain: "porter.run", ClusterID: 1, PorterAppName: "frontend", DeploymentTargetID: previewTargetID},
+	}
+
+	for _, record := range records {
+		if _, err := tester.repo.DNSRecord().CreateDNSRecord(record); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	appRecords, err := tester.repo.DNSRecord().ListDNSRecordsByPorterAppName(1, "api")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(appRecords) != 2 {
+		t.Fatalf("expected 2 records for app api in cluster 1, got %d", len(appRecords))
+	}
+
+	targetRecords, err := tester.repo.DNSRecord().ListDNSRecordsByDeploymentTargetID(previewTargetID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(targetRecords) != 2 {
+		t.Fatalf("expected 2 records for the preview deployment target, got %d", len(targetRecords))
+	}
+
+	// records created without a deployment target are never listed for a deployment target
+	if _, err := tester.repo.DNSRecord().ListDNSRecordsByDeploymentTargetID(uuid.Nil); err == nil {
+		t.Fatalf("expected listing the records of a nil deployment target to fail")
+	}
+
+	for _, record := range targetRecords {
+		if err := tester.repo.DNSRecord().DeleteDNSRecord(record); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	appRecords, err = tester.repo.DNSRecord().ListDNSRecordsByPorterAppName(1, "api")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(appRecords) != 1 || appRecords[0].SubdomainPrefix != "web-abc" {
+		t.Fatalf("expected only the record of the default deployment target to remain, got %d records", len(appRecords))
+	}
+}
+
+func TestListDNSRecordsWithoutAppByHostnames(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_dns_records_without_app.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	// records created before records were assigned to apps have no cluster, app or deployment target
+	records := []*models.DNSRecord{
+		{SubdomainPrefix: "web-abc", RootDomain: "porter.run", Hostname: "web-abc.porter.run"},
+		{SubdomainPrefix: "web-def", RootDomain: "porter.run", Hostname: "web-def.porter.run"},
+		{SubdomainPrefix: "web-ghi", RootDomain: "porter.run", Hostname: "web-ghi.porter.run", ClusterID: 1, PorterAppName: "api"},
+	}
+
+	for _, record := range records {
+		if _, err := tester.repo.DNSRecord().CreateDNSRecord(record); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	legacyRecords, err := tester.repo.DNSRecord().ListDNSRecordsWithoutAppByHostnames([]string{"web-abc.porter.run", "web-ghi.porter.run", "other.porter.run"})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(legacyRecords) != 1 || legacyRecords[0].SubdomainPrefix != "web-abc" {
+		t.Fatalf("expected only the record of web-abc.porter.run without an app, got %d records", len(legacyRecords))
+	}
+
+	legacyRecords, err = tester.repo.DNSRecord().ListDNSRecordsWithoutAppByHostnames(nil)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(legacyRecords) != 0 {
+		t.Fatalf("expected no records without hostnames, got %d records", len(legacyRecords))
+	}
+}
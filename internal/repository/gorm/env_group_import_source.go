@@ -0,0 +1,255 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// EnvGroupImportSourceRepository uses gorm.DB for querying the database
+type EnvGroupImportSourceRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewEnvGroupImportSourceRepository returns an EnvGroupImportSourceRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// the Vault tokens of the sources
+func NewEnvGroupImportSourceRepository(db *gorm.DB, key *[32]byte) repository.EnvGroupImportSourceRepository {
+	return &EnvGroupImportSourceRepository{db, key}
+}
+
+// UpdateEnvGroupImportSource creates or updates the import source of an environment group. The existing Vault token
+// of the source is kept if the token of the update is empty.
+func (repo *EnvGroupImportSourceRepository) UpdateEnvGroupImportSource(ctx context.Context, source *models.EnvGroupImportSource) (*models.EnvGroupImportSource, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-update-env-group-import-source")
+	defer span.End()
+
+	if source == nil {
+		return nil, telemetry.Error(ctx, span, nil, "source is nil")
+	}
+
+	if source.ProjectID == 0 || source.ClusterID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "project id and cluster id are required")
+	}
+
+	if source.EnvGroupName == "" {
+		return nil, telemetry.Error(ctx, span, nil, "env group name is empty")
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: source.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: source.ClusterID},
+		telemetry.AttributeKV{Key: "env-group-name", Value: source.EnvGroupName},
+		telemetry.AttributeKV{Key: "provider", Value: string(source.Provider)},
+	)
+
+	if err := repo.encryptEnvGroupImportSourceData(source); err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error encrypting import source")
+	}
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		existing := &models.EnvGroupImportSource{}
+
+		err := tx.Unscoped().Where(
+			"project_id = ? AND cluster_id = ? AND env_group_name = ?",
+			source.ProjectID, source.ClusterID, source.EnvGroupName,
+		).First(existing).Error
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(source).Error
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// a deleted source is restored without the results of its previous syncs
+		if existing.DeletedAt.Valid {
+			existing.DeletedAt = gorm.DeletedAt{}
+			existing.LastSyncedAt = nil
+			existing.LastSyncError = ""
+			existing.LastSyncedVersion = 0
+		}
+
+		// the stored token is only kept when it is sent to the same Vault server
+		if existing.Provider != source.Provider || existing.VaultAddress != source.VaultAddress {
+			existing.VaultToken = nil
+		}
+
+		existing.Provider = source.Provider
+		existing.Path = source.Path
+		existing.AWSIntegrationID = source.AWSIntegrationID
+		existing.GCPIntegrationID = source.GCPIntegrationID
+		existing.VaultAddress = source.VaultAddress
+		existing.AsSecrets = source.AsSecrets
+		existing.Prune = source.Prune
+		existing.SkipRedeploys = source.SkipRedeploys
+		existing.SyncIntervalMinutes = source.SyncIntervalMinutes
+
+		if len(source.VaultToken) > 0 {
+			existing.VaultToken = source.VaultToken
+		}
+
+		*source = *existing
+
+		return tx.Unscoped().Save(source).Error
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error updating import source")
+	}
+
+	if err := repo.decryptEnvGroupImportSourceData(source); err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error decrypting import source")
+	}
+
+	return source, nil
+}
+
+// ReadEnvGroupImportSource returns the import source of an environment group
+func (repo *EnvGroupImportSourceRepository) ReadEnvGroupImportSource(ctx context.Context, projectID, clusterID uint, envGroupName string) (*models.EnvGroupImportSource, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-read-env-group-import-source")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: clusterID},
+		telemetry.AttributeKV{Key: "env-group-name", Value: envGroupName},
+	)
+
+	source := &models.EnvGroupImportSource{}
+
+	if err := repo.db.Where("project_id = ? AND cluster_id = ? AND env_group_name = ?", projectID, clusterID, envGroupName).First(source).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error reading import source")
+	}
+
+	if err := repo.decryptEnvGroupImportSourceData(source); err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error decrypting import source")
+	}
+
+	return source, nil
+}
+
+// ListEnvGroupImportSourcesByClusterID returns the import sources of the environment groups of a cluster, ordered by environment group
+func (repo *EnvGroupImportSourceRepository) ListEnvGroupImportSourcesByClusterID(ctx context.Context, projectID, clusterID uint) ([]*models.EnvGroupImportSource, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-env-group-import-sources-by-cluster-id")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: clusterID},
+	)
+
+	sources := []*models.EnvGroupImportSource{}
+
+	if err := repo.db.Where("project_id = ? AND cluster_id = ?", projectID, clusterID).Order("env_group_name ASC").Find(&sources).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing import sources")
+	}
+
+	for _, source := range sources {
+		if err := repo.decryptEnvGroupImportSourceData(source); err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error decrypting import source")
+		}
+	}
+
+	return sources, nil
+}
+
+// ListScheduledEnvGroupImportSources returns the import sources of all projects which are synced on a schedule, ordered by project and cluster
+func (repo *EnvGroupImportSourceRepository) ListScheduledEnvGroupImportSources(ctx context.Context) ([]*models.EnvGroupImportSource, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-scheduled-env-group-import-sources")
+	defer span.End()
+
+	sources := []*models.EnvGroupImportSource{}
+
+	if err := repo.db.Where("sync_interval_minutes > ?", 0).Order("project_id ASC, cluster_id ASC, env_group_name ASC").Find(&sources).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing scheduled import sources")
+	}
+
+	for _, source := range sources {
+		if err := repo.decryptEnvGroupImportSourceData(source); err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error decrypting import source")
+		}
+	}
+
+	return sources, nil
+}
+
+// RecordEnvGroupImportSourceSync stores the time, the resulting environment group version and the error of the latest sync of a source.
+// The version is left unchanged if the sync failed.
+func (repo *EnvGroupImportSourceRepository) RecordEnvGroupImportSourceSync(ctx context.Context, sourceID uint, syncedAt time.Time, version int, syncErr string) error {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-record-env-group-import-source-sync")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "source-id", Value: sourceID},
+		telemetry.AttributeKV{Key: "version", Value: version},
+	)
+
+	updates := map[string]interface{}{
+		"last_synced_at":  syncedAt,
+		"last_sync_error": syncErr,
+	}
+
+	if syncErr == "" {
+		updates["last_synced_version"] = version
+	}
+
+	if err := repo.db.Model(&models.EnvGroupImportSource{}).Where("id = ?", sourceID).Updates(updates).Error; err != nil {
+		return telemetry.Error(ctx, span, err, "error recording import source sync")
+	}
+
+	return nil
+}
+
+// DeleteEnvGroupImportSource deletes the import source of an environment group
+func (repo *EnvGroupImportSourceRepository) DeleteEnvGroupImportSource(ctx context.Context, source *models.EnvGroupImportSource) error {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-delete-env-group-import-source")
+	defer span.End()
+
+	if source == nil {
+		return telemetry.Error(ctx, span, nil, "source is nil")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "source-id", Value: source.ID})
+
+	if err := repo.db.Delete(source).Error; err != nil {
+		return telemetry.Error(ctx, span, err, "error deleting import source")
+	}
+
+	return nil
+}
+
+// encryptEnvGroupImportSourceData will encrypt the Vault token of the source before writing to the DB
+func (repo *EnvGroupImportSourceRepository) encryptEnvGroupImportSourceData(source *models.EnvGroupImportSource) error {
+	if len(source.VaultToken) > 0 {
+		cipherData, err := encryption.Encrypt(source.VaultToken, repo.key)
+		if err != nil {
+			return err
+		}
+
+		source.VaultToken = cipherData
+	}
+
+	return nil
+}
+
+// decryptEnvGroupImportSourceData will decrypt the Vault token of the source before returning it from the DB
+func (repo *EnvGroupImportSourceRepository) decryptEnvGroupImportSourceData(source *models.EnvGroupImportSource) error {
+	if len(source.VaultToken) > 0 {
+		plaintext, err := encryption.Decrypt(source.VaultToken, repo.key)
+		if err != nil {
+			return err
+		}
+
+		source.VaultToken = plaintext
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package gorm_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestUpdateEnvGroupImportSource(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_update_env_group_import_source.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	for _, source := range []*models.EnvGroupImportSource{
+		{ProjectID: 1, ClusterID: 1, EnvGroupName: "shared", Provider: types.EnvGroupImportProvider_Vault, Path: "secret/data/shared", VaultAddress: "https://vault.example.com", VaultToken: []byte("s.token")},
+		{ProjectID: 1, ClusterID: 1, EnvGroupName: "shared", Provider: types.EnvGroupImportProvider_Vault, Path: "secret/data/shared-v2", VaultAddress: "https://vault.example.com", SyncIntervalMinutes: 15},
+		{ProjectID: 1, ClusterID: 1, EnvGroupName: "api", Provider: types.EnvGroupImportProvider_AWSSecretsManager, Path: "prod/api", AWSIntegrationID: 1, AsSecrets: true},
+		{ProjectID: 2, ClusterID: 2, EnvGroupName: "web", Provider: types.EnvGroupImportProvider_GCPSecretManager, Path: "web", GCPIntegrationID: 1, SyncIntervalMinutes: 60},
+	} {
+		if _, err := tester.repo.EnvGroupImportSource().UpdateEnvGroupImportSource(ctx, source); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	sources, err := tester.repo.EnvGroupImportSource().ListEnvGroupImportSourcesByClusterID(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(sources) != 2 || sources[0].EnvGroupName != "api" || sources[1].EnvGroupName != "shared" {
+		t.Fatalf("expected the sources of api and shared, got %v", sources)
+	}
+
+	// the vault token is kept when it is not part of an update
+	if sources[1].Path != "secret/data/shared-v2" || sources[1].SyncIntervalMinutes != 15 || !bytes.Equal(sources[1].VaultToken, []byte("s.token")) {
+		t.Fatalf("expected the source of shared to be updated, got %+v", sources[1])
+	}
+
+	// the vault token is dropped when the address of the vault server changes
+	moved, err := tester.repo.EnvGroupImportSource().UpdateEnvGroupImportSource(ctx, &models.EnvGroupImportSource{
+		ProjectID: 1, ClusterID: 1, EnvGroupName: "shared", Provider: types.EnvGroupImportProvider_Vault, Path: "secret/data/shared-v2", VaultAddress: "https://attacker.example.com", SyncIntervalMinutes: 15,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(moved.VaultToken) != 0 {
+		t.Fatalf("expected the vault token to be dropped when the address changes, got %q", moved.VaultToken)
+	}
+
+	if _, err := tester.repo.EnvGroupImportSource().UpdateEnvGroupImportSource(ctx, &models.EnvGroupImportSource{
+		ProjectID: 1, ClusterID: 1, EnvGroupName: "shared", Provider: types.EnvGroupImportProvider_Vault, Path: "secret/data/shared-v2", VaultAddress: "https://attacker.example.com", VaultToken: []byte("s.other"), SyncIntervalMinutes: 15,
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	scheduled, err := tester.repo.EnvGroupImportSource().ListScheduledEnvGroupImportSources(ctx)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(scheduled) != 2 || scheduled[0].EnvGroupName != "shared" || scheduled[1].EnvGroupName != "web" {
+		t.Fatalf("expected the scheduled sources of shared and web, got %v", scheduled)
+	}
+
+	syncedAt := time.Now().UTC()
+
+	if err := tester.repo.EnvGroupImportSource().RecordEnvGroupImportSourceSync(ctx, scheduled[0].ID, syncedAt, 4, ""); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// a failed sync keeps the version of the latest successful sync
+	if err := tester.repo.EnvGroupImportSource().RecordEnvGroupImportSourceSync(ctx, scheduled[0].ID, syncedAt, 0, "permission denied"); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	source, err := tester.repo.EnvGroupImportSource().ReadEnvGroupImportSource(ctx, 1, 1, "shared")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if source.LastSyncedAt == nil || source.LastSyncedVersion != 4 || source.LastSyncError != "permission denied" {
+		t.Fatalf("expected the syncs of the source to be recorded, got %+v", source)
+	}
+
+	if err := tester.repo.EnvGroupImportSource().DeleteEnvGroupImportSource(ctx, source); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.EnvGroupImportSource().ReadEnvGroupImportSource(ctx, 1, 1, "shared"); err == nil {
+		t.Fatalf("expected the source of shared to be deleted")
+	}
+
+	// recreating a deleted source restores it without the results of its previous syncs
+	source, err = tester.repo.EnvGroupImportSource().UpdateEnvGroupImportSource(ctx, &models.EnvGroupImportSource{
+		ProjectID: 1, ClusterID: 1, EnvGroupName: "shared", Provider: types.EnvGroupImportProvider_AWSSecretsManager, Path: "shared", AWSIntegrationID: 2,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if source.Provider != types.EnvGroupImportProvider_AWSSecretsManager || source.LastSyncedAt != nil || source.LastSyncedVersion != 0 || source.LastSyncError != "" {
+		t.Fatalf("expected the source of shared to be restored, got %+v", source)
+	}
+
+	// the vault token is dropped when the provider changes
+	if len(source.VaultToken) != 0 {
+		t.Fatalf("expected the vault token to be dropped when the provider changes, got %q", source.VaultToken)
+	}
+}
@@ -0,0 +1,126 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// EnvGroupRetentionRepository uses gorm.DB for querying the database
+type EnvGroupRetentionRepository struct {
+	db *gorm.DB
+}
+
+// NewEnvGroupRetentionRepository returns an EnvGroupRetentionRepository which uses
+// gorm.DB for querying the database
+func NewEnvGroupRetentionRepository(db *gorm.DB) repository.EnvGroupRetentionRepository {
+	return &EnvGroupRetentionRepository{db}
+}
+
+// UpdateEnvGroupRetentionPolicy creates or updates the environment group retention policy of a project
+func (repo *EnvGroupRetentionRepository) UpdateEnvGroupRetentionPolicy(ctx context.Context, policy *models.EnvGroupRetentionPolicy) (*models.EnvGroupRetentionPolicy, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-update-env-group-retention-policy")
+	defer span.End()
+
+	if policy == nil {
+		return nil, telemetry.Error(ctx, span, nil, "policy is nil")
+	}
+
+	if policy.ProjectID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "project id is required")
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: policy.ProjectID},
+		telemetry.AttributeKV{Key: "keep-last-revisions", Value: policy.KeepLastRevisions},
+		telemetry.AttributeKV{Key: "enabled", Value: policy.Enabled},
+	)
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		existing := &models.EnvGroupRetentionPolicy{}
+
+		err := tx.Unscoped().Where("project_id = ?", policy.ProjectID).First(existing).Error
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(policy).Error
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// a deleted policy is restored without the results of its previous runs
+		if existing.DeletedAt.Valid {
+			existing.DeletedAt = gorm.DeletedAt{}
+			existing.LastRunAt = nil
+			existing.LastDeletedCount = 0
+		}
+
+		existing.KeepLastRevisions = policy.KeepLastRevisions
+		existing.Enabled = policy.Enabled
+		*policy = *existing
+
+		return tx.Unscoped().Save(policy).Error
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error updating env group retention policy")
+	}
+
+	return policy, nil
+}
+
+// ReadEnvGroupRetentionPolicy returns the environment group retention policy of a project
+func (repo *EnvGroupRetentionRepository) ReadEnvGroupRetentionPolicy(ctx context.Context, projectID uint) (*models.EnvGroupRetentionPolicy, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-read-env-group-retention-policy")
+	defer span.End()
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: projectID})
+
+	policy := &models.EnvGroupRetentionPolicy{}
+
+	if err := repo.db.Where("project_id = ?", projectID).First(policy).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error reading env group retention policy")
+	}
+
+	return policy, nil
+}
+
+// ListEnabledEnvGroupRetentionPolicies returns the enabled environment group retention policies of all projects, ordered by project
+func (repo *EnvGroupRetentionRepository) ListEnabledEnvGroupRetentionPolicies(ctx context.Context) ([]*models.EnvGroupRetentionPolicy, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-enabled-env-group-retention-policies")
+	defer span.End()
+
+	policies := []*models.EnvGroupRetentionPolicy{}
+
+	if err := repo.db.Where("enabled = ?", true).Order("project_id ASC").Find(&policies).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing enabled env group retention policies")
+	}
+
+	return policies, nil
+}
+
+// RecordEnvGroupRetentionPolicyRun stores the time and the number of deleted copies of the latest garbage collection of a policy
+func (repo *EnvGroupRetentionRepository) RecordEnvGroupRetentionPolicyRun(ctx context.Context, policyID uint, runAt time.Time, deletedCount uint) error {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-record-env-group-retention-policy-run")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "policy-id", Value: policyID},
+		telemetry.AttributeKV{Key: "deleted-count", Value: deletedCount},
+	)
+
+	err := repo.db.Model(&models.EnvGroupRetentionPolicy{}).Where("id = ?", policyID).Updates(map[string]interface{}{
+		"last_run_at":        runAt,
+		"last_deleted_count": deletedCount,
+	}).Error
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "error recording env group retention policy run")
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package gorm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestUpdateEnvGroupRetentionPolicy(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_update_env_group_retention_policy.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	for _, policy := range []*models.EnvGroupRetentionPolicy{
+		{ProjectID: 1, KeepLastRevisions: 10},
+		{ProjectID: 1, KeepLastRevisions: 3, Enabled: true},
+		{ProjectID: 2, KeepLastRevisions: 5},
+		{ProjectID: 3, KeepLastRevisions: 5, Enabled: true},
+	} {
+		if _, err := tester.repo.EnvGroupRetention().UpdateEnvGroupRetentionPolicy(ctx, policy); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	policy, err := tester.repo.EnvGroupRetention().ReadEnvGroupRetentionPolicy(ctx, 1)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if policy.KeepLastRevisions != 3 || !policy.Enabled {
+		t.Fatalf("expected the policy of project 1 to be updated, got %+v", policy)
+	}
+
+	enabled, err := tester.repo.EnvGroupRetention().ListEnabledEnvGroupRetentionPolicies(ctx)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(enabled) != 2 || enabled[0].ProjectID != 1 || enabled[1].ProjectID != 3 {
+		t.Fatalf("expected the enabled policies of projects 1 and 3, got %v", enabled)
+	}
+
+	runAt := time.Now().UTC()
+
+	if err := tester.repo.EnvGroupRetention().RecordEnvGroupRetentionPolicyRun(ctx, enabled[0].ID, runAt, 42); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	policy, err = tester.repo.EnvGroupRetention().ReadEnvGroupRetentionPolicy(ctx, 1)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if policy.LastRunAt == nil || policy.LastDeletedCount != 42 {
+		t.Fatalf("expected the run of the policy to be recorded, got %+v", policy)
+	}
+
+	if _, err := tester.repo.EnvGroupRetention().ReadEnvGroupRetentionPolicy(ctx, 4); err == nil {
+		t.Fatalf("expected an error reading the policy of a project without a policy")
+	}
+}
+
+func TestLatestAppRevisions(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_latest_app_revisions.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	production := uuid.New()
+	staging := uuid.New()
+
+	for _, revision := range []struct {
+		projectID          int
+		porterAppID        int
+		deploymentTargetID uuid.UUID
+		count              int
+	}{
+		{projectID: 1, porterAppID: 1, deploymentTargetID: production, count: 5},
+		{projectID: 1, porterAppID: 1, deploymentTargetID: staging, count: 2},
+		{projectID: 1, porterAppID: 2, deploymentTargetID: production, count: 4},
+		{projectID: 2, porterAppID: 3, deploymentTargetID: production, count: 6},
+	} {
+		for i := 1; i <= revision.count; i++ {
+			if err := tester.db.Create(&models.AppRevision{
+				ID:                 uuid.New(),
+				ProjectID:          revision.projectID,
+				PorterAppID:        revision.porterAppID,
+				DeploymentTargetID: revision.deploymentTargetID,
+				RevisionNumber:     i,
+			}).Error; err != nil {
+				t.Fatalf("%v\n", err)
+			}
+		}
+	}
+
+	revisions, err := tester.repo.AppRevision().LatestAppRevisions(ctx, 1, 3)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	numbers := make(map[string][]int)
+
+	for _, revision := range revisions {
+		if revision.ProjectID != 1 {
+			t.Fatalf("expected only revisions of project 1, got %+v", revision)
+		}
+
+		key := revision.DeploymentTargetID.String()
+		if revision.PorterAppID == 2 {
+			key = "app-2"
+		}
+
+		numbers[key] = append(numbers[key], revision.RevisionNumber)
+	}
+
+	expected := map[string][]int{
+		production.String(): {5, 4, 3},
+		staging.String():    {2, 1},
+		"app-2":             {4, 3, 2},
+	}
+
+	for key, want := range expected {
+		got := numbers[key]
+
+		if len(got) != len(want) {
+			t.Fatalf("expected revisions %v for %s, got %v", want, key, got)
+		}
+
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("expected revisions %v for %s, got %v", want, key, got)
+			}
+		}
+	}
+
+	revisions, err = tester.repo.AppRevision().LatestAppRevisions(ctx, 1, 0)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(revisions) != 0 {
+		t.Fatalf("expected no revisions when keeping 0 revisions, got %d", len(revisions))
+	}
+}
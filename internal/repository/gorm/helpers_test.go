@@ -81,6 +81,21 @@ func setupTestEnv(tester *tester, t *testing.T) {
 		&models.Allowlist{},
 		&models.Tag{},
 		&models.APIToken{},
+		&models.WorkerJob{},
+		&models.WorkerJobSchedule{},
+		&models.NotificationRule{},
+		&models.NotificationRuleThrottle{},
+		&models.NotificationAggregate{},
+		&models.NotificationDigestSetting{},
+		&models.DNSRecord{},
+		&models.OPAPolicyBundle{},
+		&models.OPAPolicyOverride{},
+		&models.ImageScan{},
+		&models.ImageScanPolicy{},
+		&models.RegistryRetentionPolicy{},
+		&models.EnvGroupImportSource{},
+		&models.EnvGroupRetentionPolicy{},
+		&models.AppRevision{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
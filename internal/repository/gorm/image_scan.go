@@ -0,0 +1,202 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// ImageScanRepository uses gorm.DB for querying the database
+type ImageScanRepository struct {
+	db *gorm.DB
+}
+
+// NewImageScanRepository returns a ImageScanRepository which uses
+// gorm.DB for querying the database
+func NewImageScanRepository(db *gorm.DB) repository.ImageScanRepository {
+	return &ImageScanRepository{db}
+}
+
+// UpsertImageScan stores the result of a scan, replacing the previous result of the scanner for the same image digest
+func (repo *ImageScanRepository) UpsertImageScan(ctx context.Context, scan *models.ImageScan) (*models.ImageScan, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-upsert-image-scan")
+	defer span.End()
+
+	if scan == nil {
+		return nil, telemetry.Error(ctx, span, nil, "scan is nil")
+	}
+
+	if scan.ProjectID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "project id is empty")
+	}
+
+	if scan.Repository == "" || scan.Digest == "" || scan.Scanner == "" {
+		return nil, telemetry.Error(ctx, span, nil, "repository, digest and scanner are required")
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: scan.ProjectID},
+		telemetry.AttributeKV{Key: "repository", Value: scan.Repository},
+		telemetry.AttributeKV{Key: "digest", Value: scan.Digest},
+		telemetry.AttributeKV{Key: "scanner", Value: scan.Scanner},
+	)
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		existing := &models.ImageScan{}
+
+		err := tx.Unscoped().Where(
+			"project_id = ? AND repository = ? AND digest = ? AND scanner = ?",
+			scan.ProjectID, scan.Repository, scan.Digest, scan.Scanner,
+		).First(existing).Error
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(scan).Error
+		}
+
+		if err != nil {
+			return err
+		}
+
+		scan.ID = existing.ID
+		scan.CreatedAt = existing.CreatedAt
+
+		return tx.Unscoped().Save(scan).Error
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error upserting image scan")
+	}
+
+	return scan, nil
+}
+
+// ListImageScansByDigest returns the latest scan of an image of a project by each scanner, ordered by scanner
+func (repo *ImageScanRepository) ListImageScansByDigest(ctx context.Context, projectID uint, repository, digest string) ([]*models.ImageScan, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-image-scans-by-digest")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "repository", Value: repository},
+		telemetry.AttributeKV{Key: "digest", Value: digest},
+	)
+
+	scans := []*models.ImageScan{}
+
+	if err := repo.db.Where("project_id = ? AND repository = ? AND digest = ?", projectID, repository, digest).Order("scanner ASC").Find(&scans).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing image scans")
+	}
+
+	return scans, nil
+}
+
+// ListImageScansByDigests returns the scans of the images of a repository of a project with any of the given digests
+func (repo *ImageScanRepository) ListImageScansByDigests(ctx context.Context, projectID uint, repository string, digests []string) ([]*models.ImageScan, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-image-scans-by-digests")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "repository", Value: repository},
+		telemetry.AttributeKV{Key: "digest-count", Value: len(digests)},
+	)
+
+	scans := []*models.ImageScan{}
+
+	if len(digests) == 0 {
+		return scans, nil
+	}
+
+	if err := repo.db.Where("project_id = ? AND repository = ? AND digest IN (?)", projectID, repository, digests).Order("scanned_at DESC").Find(&scans).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing image scans")
+	}
+
+	return scans, nil
+}
+
+// ReadLatestImageScanByTag returns the most recent scan of an image of a project which had the given tag when it was scanned
+func (repo *ImageScanRepository) ReadLatestImageScanByTag(ctx context.Context, projectID uint, repository, tag string) (*models.ImageScan, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-read-latest-image-scan-by-tag")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "repository", Value: repository},
+		telemetry.AttributeKV{Key: "tag", Value: tag},
+	)
+
+	scan := &models.ImageScan{}
+
+	if err := repo.db.Where("project_id = ? AND repository = ? AND tag = ?", projectID, repository, tag).Order("updated_at DESC").First(scan).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error reading image scan")
+	}
+
+	return scan, nil
+}
+
+// ReadImageScanPolicy returns the severity gate of a project. A project without a gate has an empty policy.
+func (repo *ImageScanRepository) ReadImageScanPolicy(ctx context.Context, projectID uint) (*models.ImageScanPolicy, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-read-image-scan-policy")
+	defer span.End()
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: projectID})
+
+	policy := &models.ImageScanPolicy{}
+
+	err := repo.db.Where("project_id = ?", projectID).First(policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.ImageScanPolicy{ProjectID: projectID}, nil
+	}
+
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error reading image scan policy")
+	}
+
+	return policy, nil
+}
+
+// UpdateImageScanPolicy creates or updates the severity gate of a project
+func (repo *ImageScanRepository) UpdateImageScanPolicy(ctx context.Context, policy *models.ImageScanPolicy) (*models.ImageScanPolicy, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-update-image-scan-policy")
+	defer span.End()
+
+	if policy == nil {
+		return nil, telemetry.Error(ctx, span, nil, "policy is nil")
+	}
+
+	if policy.ProjectID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "project id is empty")
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: policy.ProjectID},
+		telemetry.AttributeKV{Key: "severity-threshold", Value: policy.SeverityThreshold},
+	)
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		existing := &models.ImageScanPolicy{}
+
+		err := tx.Where("project_id = ?", policy.ProjectID).First(existing).Error
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(policy).Error
+		}
+
+		if err != nil {
+			return err
+		}
+
+		existing.SeverityThreshold = policy.SeverityThreshold
+		*policy = *existing
+
+		return tx.Save(policy).Error
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error updating image scan policy")
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,100 @@
+package gorm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestUpsertImageScan(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_upsert_image_scan.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+	repository := "123456789012.dkr.ecr.us-east-1.amazonaws.com/web"
+	scannedAt := time.Now().UTC()
+
+	for _, scan := range []*models.ImageScan{
+		{ProjectID: 1, Repository: repository, Digest: "sha256:abc", Scanner: "trivy", Tag: "v1", Critical: 1, ScannedAt: scannedAt},
+		{ProjectID: 1, Repository: repository, Digest: "sha256:abc", Scanner: "ecr", Tag: "v1", High: 3, ScannedAt: scannedAt},
+		{ProjectID: 1, Repository: repository, Digest: "sha256:abc", Scanner: "trivy", Tag: "v2", ScannedAt: scannedAt.Add(time.Minute)},
+		{ProjectID: 1, Repository: repository, Digest: "sha256:def", Scanner: "trivy", Tag: "v1", Low: 2, ScannedAt: scannedAt.Add(2 * time.Minute)},
+	} {
+		if _, err := tester.repo.ImageScan().UpsertImageScan(ctx, scan); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	scans, err := tester.repo.ImageScan().ListImageScansByDigest(ctx, 1, repository, "sha256:abc")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(scans) != 2 || scans[0].Scanner != "ecr" || scans[1].Scanner != "trivy" {
+		t.Fatalf("expected one scan of sha256:abc by each scanner, got %v", scans)
+	}
+
+	if scans[1].Critical != 0 || scans[1].Tag != "v2" {
+		t.Fatalf("expected the trivy scan of sha256:abc to be replaced, got %+v", scans[1])
+	}
+
+	scans, err = tester.repo.ImageScan().ListImageScansByDigests(ctx, 1, repository, []string{"sha256:abc", "sha256:def"})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(scans) != 3 || scans[0].Digest != "sha256:def" {
+		t.Fatalf("expected the scans of both digests, latest first, got %v", scans)
+	}
+
+	// the v1 tag was moved from sha256:abc to sha256:def
+	scan, err := tester.repo.ImageScan().ReadLatestImageScanByTag(ctx, 1, repository, "v1")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if scan.Digest != "sha256:def" {
+		t.Fatalf("expected the latest scan tagged v1 to be of sha256:def, got %s", scan.Digest)
+	}
+}
+
+func TestUpdateImageScanPolicy(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_update_image_scan_policy.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	policy, err := tester.repo.ImageScan().ReadImageScanPolicy(ctx, 1)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if policy.SeverityThreshold != "" {
+		t.Fatalf("expected a project without a gate to have an empty policy, got %+v", policy)
+	}
+
+	for _, threshold := range []string{"CRITICAL", "HIGH"} {
+		if _, err := tester.repo.ImageScan().UpdateImageScanPolicy(ctx, &models.ImageScanPolicy{ProjectID: 1, SeverityThreshold: threshold}); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	policy, err = tester.repo.ImageScan().ReadImageScanPolicy(ctx, 1)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if policy.SeverityThreshold != "HIGH" {
+		t.Fatalf("expected severity threshold HIGH, got %s", policy.SeverityThreshold)
+	}
+}
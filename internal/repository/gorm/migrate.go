@@ -38,6 +38,10 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&models.PWResetToken{},
 		&models.NotificationConfig{},
 		&models.JobNotificationConfig{},
+		&models.NotificationRule{},
+		&models.NotificationRuleThrottle{},
+		&models.NotificationAggregate{},
+		&models.NotificationDigestSetting{},
 		&models.EventContainer{},
 		&models.SubEvent{},
 		&models.KubeEvent{},
@@ -58,6 +62,13 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&models.StackEnvGroup{},
 		&models.DbMigration{},
 		&models.MonitorTestResult{},
+		&models.OPAPolicyBundle{},
+		&models.OPAPolicyOverride{},
+		&models.ImageScan{},
+		&models.ImageScanPolicy{},
+		&models.RegistryRetentionPolicy{},
+		&models.EnvGroupImportSource{},
+		&models.EnvGroupRetentionPolicy{},
 		&models.APIContractRevision{},
 		&models.AWSAssumeRoleChain{},
 		&models.PorterApp{},
@@ -68,6 +79,8 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&models.AppTemplate{},
 		&models.GithubWebhook{},
 		&models.Datastore{},
+		&models.WorkerJob{},
+		&models.WorkerJobSchedule{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
@@ -84,6 +97,7 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&ints.GithubAppInstallation{},
 		&ints.GithubAppOAuthIntegration{},
 		&ints.SlackIntegration{},
+		&ints.NotificationWebhookIntegration{},
 		&models.Ipam{},
 	)
 }
@@ -0,0 +1,263 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationAggregateRepository uses gorm.DB for querying the database
+type NotificationAggregateRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationAggregateRepository returns a NotificationAggregateRepository which uses
+// gorm.DB for querying the database
+func NewNotificationAggregateRepository(db *gorm.DB) repository.NotificationAggregateRepository {
+	return &NotificationAggregateRepository{db}
+}
+
+// RecordOccurrence counts an occurrence towards the unresolved aggregate with the key of occurrence, creating
+// the aggregate if none exists. Returns true if the aggregate was created by this call.
+func (repo *NotificationAggregateRepository) RecordOccurrence(
+	ctx context.Context,
+	occurrence *models.NotificationAggregate,
+	at time.Time,
+	window time.Duration,
+) (*models.NotificationAggregate, bool, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-record-notification-occurrence")
+	defer span.End()
+
+	if occurrence == nil {
+		return nil, false, telemetry.Error(ctx, span, nil, "occurrence is nil")
+	}
+
+	if occurrence.ProjectID == 0 {
+		return nil, false, telemetry.Error(ctx, span, nil, "project id is empty")
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: occurrence.ProjectID},
+		telemetry.AttributeKV{Key: "app-revision-id", Value: occurrence.AppRevisionID},
+		telemetry.AttributeKV{Key: "service-name", Value: occurrence.ServiceName},
+		telemetry.AttributeKV{Key: "error-code", Value: occurrence.ErrorCode},
+	)
+
+	at = at.UTC()
+	aggregate := &models.NotificationAggregate{}
+	created := false
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(
+			"project_id = ? AND app_revision_id = ? AND service_name = ? AND error_code = ? AND resolved_at IS NULL",
+			occurrence.ProjectID, occurrence.AppRevisionID, occurrence.ServiceName, occurrence.ErrorCode,
+		)
+
+		err := query.Session(&gorm.Session{}).First(aggregate).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			aggregate = &models.NotificationAggregate{
+				ProjectID:          occurrence.ProjectID,
+				AppRevisionID:      occurrence.AppRevisionID,
+				ServiceName:        occurrence.ServiceName,
+				ErrorCode:          occurrence.ErrorCode,
+				AppName:            occurrence.AppName,
+				DeploymentTargetID: occurrence.DeploymentTargetID,
+				Scope:              occurrence.Scope,
+				Summary:            occurrence.Summary,
+				Occurrences:        1,
+				FirstSeenAt:        at,
+				LastSeenAt:         at,
+				WindowSeconds:      uint(window.Seconds()),
+				WindowEndsAt:       at.Add(window),
+			}
+
+			// a concurrent insert for the same key means another caller created the aggregate first
+			res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(aggregate)
+			if res.Error != nil {
+				return res.Error
+			}
+
+			if res.RowsAffected == 1 {
+				created = true
+				return nil
+			}
+
+			aggregate = &models.NotificationAggregate{}
+
+			if err := query.Session(&gorm.Session{}).First(aggregate).Error; err != nil {
+				return err
+			}
+		}
+
+		aggregate.Occurrences++
+		aggregate.PendingOccurrences++
+
+		if at.After(aggregate.LastSeenAt) {
+			aggregate.LastSeenAt = at
+		}
+
+		if occurrence.Summary != "" {
+			aggregate.Summary = occurrence.Summary
+		}
+
+		return tx.Save(aggregate).Error
+	})
+	if err != nil {
+		return nil, false, telemetry.Error(ctx, span, err, "error recording notification occurrence")
+	}
+
+	return aggregate, created, nil
+}
+
+// ListDueNotificationAggregates returns the unresolved aggregates which have pending occurrences at the end of their
+// window, or which have not seen an occurrence since resolveBefore
+func (repo *NotificationAggregateRepository) ListDueNotificationAggregates(ctx context.Context, now, resolveBefore time.Time) ([]*models.NotificationAggregate, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-due-notification-aggregates")
+	defer span.End()
+
+	aggregates := []*models.NotificationAggregate{}
+
+	err := repo.db.Where(
+		"resolved_at IS NULL AND ((pending_occurrences > 0 AND window_ends_at <= ?) OR last_seen_at <= ?)",
+		now.UTC(), resolveBefore.UTC(),
+	).Order("id ASC").Find(&aggregates).Error
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing due notification aggregates")
+	}
+
+	return aggregates, nil
+}
+
+// UpdateNotificationAggregate updates an aggregate
+func (repo *NotificationAggregateRepository) UpdateNotificationAggregate(ctx context.Context, aggregate *models.NotificationAggregate) (*models.NotificationAggregate, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-update-notification-aggregate")
+	defer span.End()
+
+	if aggregate == nil {
+		return nil, telemetry.Error(ctx, span, nil, "aggregate is nil")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notification-aggregate-id", Value: aggregate.ID})
+
+	if err := repo.db.Save(aggregate).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error updating notification aggregate")
+	}
+
+	return aggregate, nil
+}
+
+// ListNotificationAggregatesByProjectID returns the aggregates of a project which saw an occurrence since the given time
+func (repo *NotificationAggregateRepository) ListNotificationAggregatesByProjectID(ctx context.Context, projectID uint, since time.Time) ([]*models.NotificationAggregate, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-notification-aggregates")
+	defer span.End()
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: projectID})
+
+	aggregates := []*models.NotificationAggregate{}
+
+	err := repo.db.Where("project_id = ? AND last_seen_at >= ?", projectID, since.UTC()).
+		Order("occurrences DESC, id ASC").
+		Find(&aggregates).Error
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing notification aggregates")
+	}
+
+	return aggregates, nil
+}
+
+// ListNotificationDigestProjectIDs returns the projects which opted in to the notification digest and have
+// aggregates that saw an occurrence since the given time
+func (repo *NotificationAggregateRepository) ListNotificationDigestProjectIDs(ctx context.Context, since time.Time) ([]uint, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-notification-digest-projects")
+	defer span.End()
+
+	projectIDs := []uint{}
+
+	err := repo.db.Model(&models.NotificationAggregate{}).
+		Joins("JOIN notification_digest_settings ON notification_digest_settings.project_id = notification_aggregates.project_id").
+		Where("notification_aggregates.last_seen_at >= ?", since.UTC()).
+		Where("notification_digest_settings.enabled AND notification_digest_settings.deleted_at IS NULL").
+		Distinct("notification_aggregates.project_id").
+		Order("notification_aggregates.project_id ASC").
+		Pluck("notification_aggregates.project_id", &projectIDs).Error
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing notification digest projects")
+	}
+
+	return projectIDs, nil
+}
+
+// ReadNotificationDigestSetting returns the digest setting of a project. A project without a setting has a
+// disabled setting.
+func (repo *NotificationAggregateRepository) ReadNotificationDigestSetting(ctx context.Context, projectID uint) (*models.NotificationDigestSetting, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-read-notification-digest-setting")
+	defer span.End()
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: projectID})
+
+	setting := &models.NotificationDigestSetting{}
+
+	err := repo.db.Where("project_id = ?", projectID).First(setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.NotificationDigestSetting{ProjectID: projectID}, nil
+	}
+
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error reading notification digest setting")
+	}
+
+	return setting, nil
+}
+
+// UpdateNotificationDigestSetting creates or updates the digest setting of a project
+func (repo *NotificationAggregateRepository) UpdateNotificationDigestSetting(ctx context.Context, setting *models.NotificationDigestSetting) (*models.NotificationDigestSetting, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-update-notification-digest-setting")
+	defer span.End()
+
+	if setting == nil {
+		return nil, telemetry.Error(ctx, span, nil, "setting is nil")
+	}
+
+	if setting.ProjectID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "project id is empty")
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: setting.ProjectID},
+		telemetry.AttributeKV{Key: "enabled", Value: setting.Enabled},
+	)
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		existing := &models.NotificationDigestSetting{}
+
+		err := tx.Where("project_id = ?", setting.ProjectID).First(existing).Error
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(setting).Error
+		}
+
+		if err != nil {
+			return err
+		}
+
+		existing.Enabled = setting.Enabled
+		*setting = *existing
+
+		return tx.Save(setting).Error
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error updating notification digest setting")
+	}
+
+	return setting, nil
+}
@@ -0,0 +1,159 @@
+package gorm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestRecordNotificationOccurrence(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_record_notification_occurrence.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	occurrence := &models.NotificationAggregate{
+		ProjectID:     1,
+		AppName:       "api",
+		AppRevisionID: "rev-1",
+		ServiceName:   "web",
+		ErrorCode:     10,
+		Summary:       "service is crash looping",
+	}
+
+	first, created, err := tester.repo.NotificationAggregate().RecordOccurrence(ctx, occurrence, start, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !created || first.Occurrences != 1 || first.PendingOccurrences != 0 {
+		t.Fatalf("expected first occurrence to create the aggregate, got created %t with %d occurrences", created, first.Occurrences)
+	}
+
+	for i := 1; i <= 3; i++ {
+		repeat, created, err := tester.repo.NotificationAggregate().RecordOccurrence(ctx, occurrence, start.Add(time.Duration(i)*time.Minute), 10*time.Minute)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		if created || repeat.ID != first.ID {
+			t.Fatalf("expected repeat to be collapsed into aggregate %d", first.ID)
+		}
+	}
+
+	// nothing is due before the end of the window
+	due, err := tester.repo.NotificationAggregate().ListDueNotificationAggregates(ctx, start.Add(5*time.Minute), start.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(due) != 0 {
+		t.Fatalf("expected no due aggregates, got %d", len(due))
+	}
+
+	due, err = tester.repo.NotificationAggregate().ListDueNotificationAggregates(ctx, start.Add(10*time.Minute), start.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(due) != 1 || due[0].Occurrences != 4 || due[0].PendingOccurrences != 3 || !due[0].LastSeenAt.Equal(start.Add(3*time.Minute)) {
+		t.Fatalf("expected one due aggregate with 3 pending of 4 occurrences, got %v", due)
+	}
+
+	// once resolved, the next occurrence starts a new aggregate
+	resolvedAt := start.Add(time.Hour)
+	due[0].ResolvedAt = &resolvedAt
+
+	if _, err := tester.repo.NotificationAggregate().UpdateNotificationAggregate(ctx, due[0]); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	next, created, err := tester.repo.NotificationAggregate().RecordOccurrence(ctx, occurrence, start.Add(2*time.Hour), 10*time.Minute)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !created || next.ID == first.ID {
+		t.Fatalf("expected occurrence after resolution to create a new aggregate")
+	}
+
+}
+
+func TestNotificationDigestProjects(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_notification_digest_projects.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	for _, projectID := range []uint{1, 2, 3} {
+		_, _, err := tester.repo.NotificationAggregate().RecordOccurrence(ctx, &models.NotificationAggregate{
+			ProjectID:     projectID,
+			AppName:       "api",
+			AppRevisionID: "rev-1",
+			ServiceName:   "web",
+			ErrorCode:     10,
+		}, start, 10*time.Minute)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	setting, err := tester.repo.NotificationAggregate().ReadNotificationDigestSetting(ctx, 1)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if setting.Enabled {
+		t.Fatalf("expected the digest to be disabled for a project without a setting")
+	}
+
+	projectIDs, err := tester.repo.NotificationAggregate().ListNotificationDigestProjectIDs(ctx, start)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(projectIDs) != 0 {
+		t.Fatalf("expected no project to receive the digest without opting in, got %v", projectIDs)
+	}
+
+	for _, setting := range []*models.NotificationDigestSetting{
+		{ProjectID: 1, Enabled: true},
+		{ProjectID: 2, Enabled: true},
+		{ProjectID: 2, Enabled: false},
+		{ProjectID: 4, Enabled: true},
+	} {
+		if _, err := tester.repo.NotificationAggregate().UpdateNotificationDigestSetting(ctx, setting); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	projectIDs, err = tester.repo.NotificationAggregate().ListNotificationDigestProjectIDs(ctx, start)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(projectIDs) != 1 || projectIDs[0] != 1 {
+		t.Fatalf("expected only project 1 to receive the digest, got %v", projectIDs)
+	}
+
+	projectIDs, err = tester.repo.NotificationAggregate().ListNotificationDigestProjectIDs(ctx, start.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(projectIDs) != 0 {
+		t.Fatalf("expected no project with recent aggregates, got %v", projectIDs)
+	}
+}
@@ -0,0 +1,180 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationRuleRepository uses gorm.DB for querying the database
+type NotificationRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRuleRepository returns a NotificationRuleRepository which uses
+// gorm.DB for querying the database
+func NewNotificationRuleRepository(db *gorm.DB) repository.NotificationRuleRepository {
+	return &NotificationRuleRepository{db}
+}
+
+// CreateNotificationRule creates a new notification rule
+func (repo *NotificationRuleRepository) CreateNotificationRule(ctx context.Context, rule *models.NotificationRule) (*models.NotificationRule, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-create-notification-rule")
+	defer span.End()
+
+	if rule == nil {
+		return nil, telemetry.Error(ctx, span, nil, "rule is nil")
+	}
+
+	if rule.ProjectID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "project id is empty")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: rule.ProjectID})
+
+	if err := repo.db.Create(rule).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error creating notification rule")
+	}
+
+	return rule, nil
+}
+
+// ReadNotificationRule returns a notification rule of a project by its id
+func (repo *NotificationRuleRepository) ReadNotificationRule(ctx context.Context, projectID, id uint) (*models.NotificationRule, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-read-notification-rule")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "notification-rule-id", Value: id},
+	)
+
+	rule := &models.NotificationRule{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(rule).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error reading notification rule")
+	}
+
+	return rule, nil
+}
+
+// ListNotificationRulesByProjectID returns the notification rules of a project in the order in which they are evaluated
+func (repo *NotificationRuleRepository) ListNotificationRulesByProjectID(ctx context.Context, projectID uint) ([]*models.NotificationRule, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-notification-rules")
+	defer span.End()
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: projectID})
+
+	rules := []*models.NotificationRule{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("priority ASC, id ASC").Find(&rules).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing notification rules")
+	}
+
+	return rules, nil
+}
+
+// UpdateNotificationRule updates a notification rule
+func (repo *NotificationRuleRepository) UpdateNotificationRule(ctx context.Context, rule *models.NotificationRule) (*models.NotificationRule, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-update-notification-rule")
+	defer span.End()
+
+	if rule == nil {
+		return nil, telemetry.Error(ctx, span, nil, "rule is nil")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notification-rule-id", Value: rule.ID})
+
+	if err := repo.db.Save(rule).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error updating notification rule")
+	}
+
+	return rule, nil
+}
+
+// DeleteNotificationRule deletes a notification rule along with its throttle records
+func (repo *NotificationRuleRepository) DeleteNotificationRule(ctx context.Context, rule *models.NotificationRule) error {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-delete-notification-rule")
+	defer span.End()
+
+	if rule == nil {
+		return telemetry.Error(ctx, span, nil, "rule is nil")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notification-rule-id", Value: rule.ID})
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("notification_rule_id = ?", rule.ID).Delete(&models.NotificationRuleThrottle{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(rule).Error
+	})
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "error deleting notification rule")
+	}
+
+	return nil
+}
+
+// AcquireThrottle returns true and records the time if the rule has not let through a notification for the
+// given key within window. Returns false if a notification for the key should be throttled.
+func (repo *NotificationRuleRepository) AcquireThrottle(ctx context.Context, ruleID uint, key string, window time.Duration) (bool, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-acquire-notification-rule-throttle")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "notification-rule-id", Value: ruleID},
+		telemetry.AttributeKV{Key: "key", Value: key},
+	)
+
+	now := time.Now().UTC()
+	acquired := false
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		throttle := &models.NotificationRuleThrottle{}
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("notification_rule_id = ? AND key = ?", ruleID, key).
+			First(throttle).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			throttle.NotificationRuleID = ruleID
+			throttle.Key = key
+			throttle.LastNotifiedAt = now
+
+			// a concurrent insert for the same key means another caller acquired the throttle first
+			res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(throttle)
+			if res.Error != nil {
+				return res.Error
+			}
+
+			acquired = res.RowsAffected == 1
+
+			return nil
+		}
+
+		if now.Before(throttle.LastNotifiedAt.Add(window)) {
+			return nil
+		}
+
+		throttle.LastNotifiedAt = now
+		acquired = true
+
+		return tx.Save(throttle).Error
+	})
+	if err != nil {
+		return false, telemetry.Error(ctx, span, err, "error acquiring notification rule throttle")
+	}
+
+	return acquired, nil
+}
@@ -0,0 +1,102 @@
+package gorm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestListNotificationRulesByPriority(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_notification_rules.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	for _, rule := range []*models.NotificationRule{
+		{ProjectID: 1, Name: "catch-all", Priority: 100, DestinationKind: types.NotificationRuleDestinationKind_Slack},
+		{ProjectID: 1, Name: "crashes", Priority: 1, DestinationKind: types.NotificationRuleDestinationKind_Webhook},
+		{ProjectID: 2, Name: "other project", Priority: 0, DestinationKind: types.NotificationRuleDestinationKind_None},
+	} {
+		if _, err := tester.repo.NotificationRule().CreateNotificationRule(ctx, rule); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	rules, err := tester.repo.NotificationRule().ListNotificationRulesByProjectID(ctx, 1)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(rules) != 2 || rules[0].Name != "crashes" || rules[1].Name != "catch-all" {
+		t.Fatalf("expected rules of project 1 ordered by priority, got %v", rules)
+	}
+}
+
+func TestAcquireNotificationRuleThrottle(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_notification_rule_throttle.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	rule, err := tester.repo.NotificationRule().CreateNotificationRule(ctx, &models.NotificationRule{
+		ProjectID:             1,
+		Name:                  "throttled",
+		DestinationKind:       types.NotificationRuleDestinationKind_Slack,
+		ThrottleWindowSeconds: 600,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	acquired, err := tester.repo.NotificationRule().AcquireThrottle(ctx, rule.ID, "api/web", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !acquired {
+		t.Fatalf("expected first notification to acquire the throttle")
+	}
+
+	acquired, err = tester.repo.NotificationRule().AcquireThrottle(ctx, rule.ID, "api/web", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if acquired {
+		t.Errorf("expected second notification within the window to be throttled")
+	}
+
+	acquired, err = tester.repo.NotificationRule().AcquireThrottle(ctx, rule.ID, "api/cron", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !acquired {
+		t.Errorf("expected a different key to be throttled separately")
+	}
+
+	// an elapsed window lets the next notification through
+	acquired, err = tester.repo.NotificationRule().AcquireThrottle(ctx, rule.ID, "api/web", 0)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !acquired {
+		t.Errorf("expected notification after the window to acquire the throttle")
+	}
+
+	if err := tester.repo.NotificationRule().DeleteNotificationRule(ctx, rule); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+}
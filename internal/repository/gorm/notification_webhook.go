@@ -0,0 +1,126 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// NotificationWebhookIntegrationRepository uses gorm.DB for querying the database
+type NotificationWebhookIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewNotificationWebhookIntegrationRepository returns a NotificationWebhookIntegrationRepository
+// which uses gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewNotificationWebhookIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.NotificationWebhookIntegrationRepository {
+	return &NotificationWebhookIntegrationRepository{db, key}
+}
+
+// CreateNotificationWebhookIntegration creates a new notification webhook integration
+func (repo *NotificationWebhookIntegrationRepository) CreateNotificationWebhookIntegration(
+	webhookInt *ints.NotificationWebhookIntegration,
+) (*ints.NotificationWebhookIntegration, error) {
+	err := repo.EncryptNotificationWebhookIntegrationData(webhookInt, repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Create(webhookInt).Error; err != nil {
+		return nil, err
+	}
+
+	return webhookInt, nil
+}
+
+// ListNotificationWebhookIntegrationsByProjectID finds all notification webhook
+// integrations for a given project id
+func (repo *NotificationWebhookIntegrationRepository) ListNotificationWebhookIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.NotificationWebhookIntegration, error) {
+	webhookInts := []*ints.NotificationWebhookIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Find(&webhookInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, webhookInt := range webhookInts {
+		if err := repo.DecryptNotificationWebhookIntegrationData(webhookInt, repo.key); err != nil {
+			return nil, err
+		}
+	}
+
+	return webhookInts, nil
+}
+
+// DeleteNotificationWebhookIntegration deletes a notification webhook integration by ID
+func (repo *NotificationWebhookIntegrationRepository) DeleteNotificationWebhookIntegration(
+	integrationID uint,
+) error {
+	if err := repo.db.Where("id = ?", integrationID).Delete(&ints.NotificationWebhookIntegration{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EncryptNotificationWebhookIntegrationData will encrypt the notification webhook
+// integration data before writing to the DB
+func (repo *NotificationWebhookIntegrationRepository) EncryptNotificationWebhookIntegrationData(
+	webhookInt *ints.NotificationWebhookIntegration,
+	key *[32]byte,
+) error {
+	if len(webhookInt.Webhook) > 0 {
+		cipherData, err := encryption.Encrypt(webhookInt.Webhook, key)
+		if err != nil {
+			return err
+		}
+
+		webhookInt.Webhook = cipherData
+	}
+
+	if len(webhookInt.SigningSecret) > 0 {
+		cipherData, err := encryption.Encrypt(webhookInt.SigningSecret, key)
+		if err != nil {
+			return err
+		}
+
+		webhookInt.SigningSecret = cipherData
+	}
+
+	return nil
+}
+
+// DecryptNotificationWebhookIntegrationData will decrypt the notification webhook
+// integration data before returning it from the DB
+func (repo *NotificationWebhookIntegrationRepository) DecryptNotificationWebhookIntegrationData(
+	webhookInt *ints.NotificationWebhookIntegration,
+	key *[32]byte,
+) error {
+	if len(webhookInt.Webhook) > 0 {
+		plaintext, err := encryption.Decrypt(webhookInt.Webhook, key)
+		if err != nil {
+			return err
+		}
+
+		webhookInt.Webhook = plaintext
+	}
+
+	if len(webhookInt.SigningSecret) > 0 {
+		plaintext, err := encryption.Decrypt(webhookInt.SigningSecret, key)
+		if err != nil {
+			return err
+		}
+
+		webhookInt.SigningSecret = plaintext
+	}
+
+	return nil
+}
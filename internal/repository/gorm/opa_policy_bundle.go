@@ -0,0 +1,201 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// OPAPolicyBundleRepository uses gorm.DB for querying the database
+type OPAPolicyBundleRepository struct {
+	db *gorm.DB
+}
+
+// NewOPAPolicyBundleRepository returns a OPAPolicyBundleRepository which uses
+// gorm.DB for querying the database
+func NewOPAPolicyBundleRepository(db *gorm.DB) repository.OPAPolicyBundleRepository {
+	return &OPAPolicyBundleRepository{db}
+}
+
+// CreateOPAPolicyBundle creates the next version of a policy bundle
+func (repo *OPAPolicyBundleRepository) CreateOPAPolicyBundle(ctx context.Context, bundle *models.OPAPolicyBundle) (*models.OPAPolicyBundle, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-create-opa-policy-bundle")
+	defer span.End()
+
+	if bundle == nil {
+		return nil, telemetry.Error(ctx, span, nil, "bundle is nil")
+	}
+
+	if bundle.ProjectID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "project id is empty")
+	}
+
+	if bundle.Name == "" {
+		return nil, telemetry.Error(ctx, span, nil, "name is empty")
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: bundle.ProjectID},
+		telemetry.AttributeKV{Key: "bundle-name", Value: bundle.Name},
+	)
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var latest uint
+
+		// deleted versions are included so that the versions of a re-created bundle are never reused
+		err := tx.Unscoped().Model(&models.OPAPolicyBundle{}).
+			Where("project_id = ? AND name = ?", bundle.ProjectID, bundle.Name).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error
+		if err != nil {
+			return err
+		}
+
+		bundle.Version = latest + 1
+
+		return tx.Create(bundle).Error
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error creating opa policy bundle")
+	}
+
+	return bundle, nil
+}
+
+// ReadOPAPolicyBundle returns a version of a policy bundle of a project. If version is 0, the latest version is returned.
+func (repo *OPAPolicyBundleRepository) ReadOPAPolicyBundle(ctx context.Context, projectID uint, name string, version uint) (*models.OPAPolicyBundle, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-read-opa-policy-bundle")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "bundle-name", Value: name},
+		telemetry.AttributeKV{Key: "bundle-version", Value: version},
+	)
+
+	bundle := &models.OPAPolicyBundle{}
+
+	query := repo.db.Where("project_id = ? AND name = ?", projectID, name)
+
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+
+	if err := query.Order("version DESC").First(bundle).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error reading opa policy bundle")
+	}
+
+	return bundle, nil
+}
+
+// ListOPAPolicyBundleVersions returns the versions of a policy bundle of a project, latest first
+func (repo *OPAPolicyBundleRepository) ListOPAPolicyBundleVersions(ctx context.Context, projectID uint, name string) ([]*models.OPAPolicyBundle, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-opa-policy-bundle-versions")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "bundle-name", Value: name},
+	)
+
+	bundles := []*models.OPAPolicyBundle{}
+
+	if err := repo.db.Where("project_id = ? AND name = ?", projectID, name).Order("version DESC").Find(&bundles).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing opa policy bundle versions")
+	}
+
+	return bundles, nil
+}
+
+// ListLatestOPAPolicyBundlesByProjectID returns the latest version of each policy bundle of a project, ordered by name
+func (repo *OPAPolicyBundleRepository) ListLatestOPAPolicyBundlesByProjectID(ctx context.Context, projectID uint) ([]*models.OPAPolicyBundle, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-latest-opa-policy-bundles")
+	defer span.End()
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: projectID})
+
+	bundles := []*models.OPAPolicyBundle{}
+
+	latest := repo.db.Model(&models.OPAPolicyBundle{}).
+		Select("MAX(id)").
+		Where("project_id = ?", projectID).
+		Group("name")
+
+	if err := repo.db.Where("id IN (?)", latest).Order("name ASC").Find(&bundles).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing opa policy bundles")
+	}
+
+	return bundles, nil
+}
+
+// DeleteOPAPolicyBundle deletes all versions of a policy bundle of a project
+func (repo *OPAPolicyBundleRepository) DeleteOPAPolicyBundle(ctx context.Context, projectID uint, name string) error {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-delete-opa-policy-bundle")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "bundle-name", Value: name},
+	)
+
+	if err := repo.db.Where("project_id = ? AND name = ?", projectID, name).Delete(&models.OPAPolicyBundle{}).Error; err != nil {
+		return telemetry.Error(ctx, span, err, "error deleting opa policy bundle")
+	}
+
+	return nil
+}
+
+// ListOPAPolicyOverridesByProjectID returns the policy collection overrides of a project, ordered by collection
+func (repo *OPAPolicyBundleRepository) ListOPAPolicyOverridesByProjectID(ctx context.Context, projectID uint) ([]*models.OPAPolicyOverride, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-opa-policy-overrides")
+	defer span.End()
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: projectID})
+
+	overrides := []*models.OPAPolicyOverride{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("collection ASC").Find(&overrides).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing opa policy overrides")
+	}
+
+	return overrides, nil
+}
+
+// ReplaceOPAPolicyOverrides replaces the policy collection overrides of a project
+func (repo *OPAPolicyBundleRepository) ReplaceOPAPolicyOverrides(ctx context.Context, projectID uint, overrides []*models.OPAPolicyOverride) ([]*models.OPAPolicyOverride, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-replace-opa-policy-overrides")
+	defer span.End()
+
+	if projectID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "project id is empty")
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "override-count", Value: len(overrides)},
+	)
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("project_id = ?", projectID).Delete(&models.OPAPolicyOverride{}).Error; err != nil {
+			return err
+		}
+
+		for _, override := range overrides {
+			override.ProjectID = projectID
+
+			if err := tx.Create(override).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error replacing opa policy overrides")
+	}
+
+	return overrides, nil
+}
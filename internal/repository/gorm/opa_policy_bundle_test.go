@@ -0,0 +1,106 @@
+package gorm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestOPAPolicyBundleVersions(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_opa_policy_bundle_versions.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	for _, bundle := range []*models.OPAPolicyBundle{
+		{ProjectID: 1, Name: "web"},
+		{ProjectID: 1, Name: "images"},
+		{ProjectID: 1, Name: "web"},
+		{ProjectID: 2, Name: "web"},
+	} {
+		if _, err := tester.repo.OPAPolicyBundle().CreateOPAPolicyBundle(ctx, bundle); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	latest, err := tester.repo.OPAPolicyBundle().ReadOPAPolicyBundle(ctx, 1, "web", 0)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if latest.Version != 2 {
+		t.Fatalf("expected latest version of web to be 2, got %d", latest.Version)
+	}
+
+	bundles, err := tester.repo.OPAPolicyBundle().ListLatestOPAPolicyBundlesByProjectID(ctx, 1)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(bundles) != 2 || bundles[0].Name != "images" || bundles[0].Version != 1 || bundles[1].Name != "web" || bundles[1].Version != 2 {
+		t.Fatalf("expected the latest version of each bundle of project 1, got %v", bundles)
+	}
+
+	if err := tester.repo.OPAPolicyBundle().DeleteOPAPolicyBundle(ctx, 1, "web"); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	versions, err := tester.repo.OPAPolicyBundle().ListOPAPolicyBundleVersions(ctx, 1, "web")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(versions) != 0 {
+		t.Fatalf("expected deleted bundle to have no versions, got %v", versions)
+	}
+
+	// versions of deleted bundles are not reused
+	recreated, err := tester.repo.OPAPolicyBundle().CreateOPAPolicyBundle(ctx, &models.OPAPolicyBundle{ProjectID: 1, Name: "web"})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if recreated.Version != 3 {
+		t.Fatalf("expected re-created bundle to have version 3, got %d", recreated.Version)
+	}
+}
+
+func TestReplaceOPAPolicyOverrides(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_replace_opa_policy_overrides.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	_, err := tester.repo.OPAPolicyBundle().ReplaceOPAPolicyOverrides(ctx, 1, []*models.OPAPolicyOverride{
+		{Collection: "platform/web", Enforcement: "warn"},
+		{Collection: "platform/images", Enforcement: "off"},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = tester.repo.OPAPolicyBundle().ReplaceOPAPolicyOverrides(ctx, 1, []*models.OPAPolicyOverride{
+		{Collection: "platform/web", Enforcement: "deny"},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	overrides, err := tester.repo.OPAPolicyBundle().ListOPAPolicyOverridesByProjectID(ctx, 1)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(overrides) != 1 || overrides[0].Collection != "platform/web" || overrides[0].Enforcement != "deny" {
+		t.Fatalf("expected overrides to be replaced, got %v", overrides)
+	}
+}
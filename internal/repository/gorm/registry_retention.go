@@ -0,0 +1,175 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// RegistryRetentionRepository uses gorm.DB for querying the database
+type RegistryRetentionRepository struct {
+	db *gorm.DB
+}
+
+// NewRegistryRetentionRepository returns a RegistryRetentionRepository which uses
+// gorm.DB for querying the database
+func NewRegistryRetentionRepository(db *gorm.DB) repository.RegistryRetentionRepository {
+	return &RegistryRetentionRepository{db}
+}
+
+// UpdateRetentionPolicy creates or updates the retention policy of an image repository of a registry
+func (repo *RegistryRetentionRepository) UpdateRetentionPolicy(ctx context.Context, policy *models.RegistryRetentionPolicy) (*models.RegistryRetentionPolicy, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-update-retention-policy")
+	defer span.End()
+
+	if policy == nil {
+		return nil, telemetry.Error(ctx, span, nil, "policy is nil")
+	}
+
+	if policy.ProjectID == 0 || policy.RegistryID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "project id and registry id are required")
+	}
+
+	if policy.RepoName == "" {
+		return nil, telemetry.Error(ctx, span, nil, "repo name is empty")
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: policy.ProjectID},
+		telemetry.AttributeKV{Key: "registry-id", Value: policy.RegistryID},
+		telemetry.AttributeKV{Key: "repo-name", Value: policy.RepoName},
+	)
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		existing := &models.RegistryRetentionPolicy{}
+
+		err := tx.Unscoped().Where(
+			"project_id = ? AND registry_id = ? AND repo_name = ?",
+			policy.ProjectID, policy.RegistryID, policy.RepoName,
+		).First(existing).Error
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(policy).Error
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// a deleted policy is restored without the results of its previous runs
+		if existing.DeletedAt.Valid {
+			existing.DeletedAt = gorm.DeletedAt{}
+			existing.LastRunAt = nil
+			existing.LastDeletedCount = 0
+		}
+
+		existing.KeepLast = policy.KeepLast
+		existing.KeepTagPattern = policy.KeepTagPattern
+		existing.Enabled = policy.Enabled
+		*policy = *existing
+
+		return tx.Unscoped().Save(policy).Error
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error updating retention policy")
+	}
+
+	return policy, nil
+}
+
+// ReadRetentionPolicy returns the retention policy of an image repository of a registry
+func (repo *RegistryRetentionRepository) ReadRetentionPolicy(ctx context.Context, projectID, registryID uint, repoName string) (*models.RegistryRetentionPolicy, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-read-retention-policy")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "registry-id", Value: registryID},
+		telemetry.AttributeKV{Key: "repo-name", Value: repoName},
+	)
+
+	policy := &models.RegistryRetentionPolicy{}
+
+	if err := repo.db.Where("project_id = ? AND registry_id = ? AND repo_name = ?", projectID, registryID, repoName).First(policy).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error reading retention policy")
+	}
+
+	return policy, nil
+}
+
+// ListRetentionPoliciesByRegistryID returns the retention policies of the image repositories of a registry, ordered by repository
+func (repo *RegistryRetentionRepository) ListRetentionPoliciesByRegistryID(ctx context.Context, projectID, registryID uint) ([]*models.RegistryRetentionPolicy, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-retention-policies-by-registry-id")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "registry-id", Value: registryID},
+	)
+
+	policies := []*models.RegistryRetentionPolicy{}
+
+	if err := repo.db.Where("project_id = ? AND registry_id = ?", projectID, registryID).Order("repo_name ASC").Find(&policies).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing retention policies")
+	}
+
+	return policies, nil
+}
+
+// ListEnabledRetentionPolicies returns the enabled retention policies of all projects, ordered by project and registry
+func (repo *RegistryRetentionRepository) ListEnabledRetentionPolicies(ctx context.Context) ([]*models.RegistryRetentionPolicy, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-enabled-retention-policies")
+	defer span.End()
+
+	policies := []*models.RegistryRetentionPolicy{}
+
+	if err := repo.db.Where("enabled = ?", true).Order("project_id ASC, registry_id ASC, repo_name ASC").Find(&policies).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing enabled retention policies")
+	}
+
+	return policies, nil
+}
+
+// RecordRetentionPolicyRun stores the time and the number of deleted images of the latest garbage collection of a policy
+func (repo *RegistryRetentionRepository) RecordRetentionPolicyRun(ctx context.Context, policyID uint, runAt time.Time, deletedCount uint) error {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-record-retention-policy-run")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "policy-id", Value: policyID},
+		telemetry.AttributeKV{Key: "deleted-count", Value: deletedCount},
+	)
+
+	err := repo.db.Model(&models.RegistryRetentionPolicy{}).Where("id = ?", policyID).Updates(map[string]interface{}{
+		"last_run_at":        runAt,
+		"last_deleted_count": deletedCount,
+	}).Error
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "error recording retention policy run")
+	}
+
+	return nil
+}
+
+// DeleteRetentionPolicy deletes the retention policy of an image repository
+func (repo *RegistryRetentionRepository) DeleteRetentionPolicy(ctx context.Context, policy *models.RegistryRetentionPolicy) error {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-delete-retention-policy")
+	defer span.End()
+
+	if policy == nil {
+		return telemetry.Error(ctx, span, nil, "policy is nil")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "policy-id", Value: policy.ID})
+
+	if err := repo.db.Delete(policy).Error; err != nil {
+		return telemetry.Error(ctx, span, err, "error deleting retention policy")
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package gorm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestUpdateRetentionPolicy(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_update_retention_policy.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	for _, policy := range []*models.RegistryRetentionPolicy{
+		{ProjectID: 1, RegistryID: 1, RepoName: "web", KeepLast: 10},
+		{ProjectID: 1, RegistryID: 1, RepoName: "web", KeepLast: 20, KeepTagPattern: "^v", Enabled: true},
+		{ProjectID: 1, RegistryID: 1, RepoName: "api", KeepLast: 5},
+		{ProjectID: 2, RegistryID: 2, RepoName: "web", KeepLast: 5, Enabled: true},
+	} {
+		if _, err := tester.repo.RegistryRetention().UpdateRetentionPolicy(ctx, policy); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	policies, err := tester.repo.RegistryRetention().ListRetentionPoliciesByRegistryID(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(policies) != 2 || policies[0].RepoName != "api" || policies[1].RepoName != "web" {
+		t.Fatalf("expected the policies of api and web, got %v", policies)
+	}
+
+	if policies[1].KeepLast != 20 || policies[1].KeepTagPattern != "^v" || !policies[1].Enabled {
+		t.Fatalf("expected the policy of web to be updated, got %+v", policies[1])
+	}
+
+	enabled, err := tester.repo.RegistryRetention().ListEnabledRetentionPolicies(ctx)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(enabled) != 2 || enabled[0].ProjectID != 1 || enabled[1].ProjectID != 2 {
+		t.Fatalf("expected the enabled policies of both projects, got %v", enabled)
+	}
+
+	runAt := time.Now().UTC()
+
+	if err := tester.repo.RegistryRetention().RecordRetentionPolicyRun(ctx, enabled[0].ID, runAt, 42); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	policy, err := tester.repo.RegistryRetention().ReadRetentionPolicy(ctx, 1, 1, "web")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if policy.LastRunAt == nil || policy.LastDeletedCount != 42 {
+		t.Fatalf("expected the run of the policy to be recorded, got %+v", policy)
+	}
+
+	if err := tester.repo.RegistryRetention().DeleteRetentionPolicy(ctx, policy); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.RegistryRetention().ReadRetentionPolicy(ctx, 1, 1, "web"); err == nil {
+		t.Fatalf("expected the policy of web to be deleted")
+	}
+
+	// recreating a deleted policy restores it without the results of its previous runs
+	policy, err = tester.repo.RegistryRetention().UpdateRetentionPolicy(ctx, &models.RegistryRetentionPolicy{
+		ProjectID: 1, RegistryID: 1, RepoName: "web", KeepLast: 3,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if policy.KeepLast != 3 || policy.Enabled || policy.LastRunAt != nil || policy.LastDeletedCount != 0 {
+		t.Fatalf("expected the policy of web to be restored, got %+v", policy)
+	}
+}
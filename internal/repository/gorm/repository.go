@@ -33,10 +33,13 @@ type GormRepository struct {
 	githubAppInstallation     repository.GithubAppInstallationRepository
 	githubAppOAuthIntegration repository.GithubAppOAuthIntegrationRepository
 	slackIntegration          repository.SlackIntegrationRepository
+	webhookIntegration        repository.NotificationWebhookIntegrationRepository
 	gitlabIntegration         repository.GitlabIntegrationRepository
 	gitlabAppOAuthIntegration repository.GitlabAppOAuthIntegrationRepository
 	notificationConfig        repository.NotificationConfigRepository
 	jobNotificationConfig     repository.JobNotificationConfigRepository
+	notificationRule          repository.NotificationRuleRepository
+	notificationAggregate     repository.NotificationAggregateRepository
 	buildEvent                repository.BuildEventRepository
 	kubeEvent                 repository.KubeEventRepository
 	projectUsage              repository.ProjectUsageRepository
@@ -49,6 +52,11 @@ type GormRepository struct {
 	tag                       repository.TagRepository
 	stack                     repository.StackRepository
 	monitor                   repository.MonitorTestResultRepository
+	opaPolicyBundle           repository.OPAPolicyBundleRepository
+	imageScan                 repository.ImageScanRepository
+	registryRetention         repository.RegistryRetentionRepository
+	envGroupImportSource      repository.EnvGroupImportSourceRepository
+	envGroupRetention         repository.EnvGroupRetentionRepository
 	apiContractRevisions      repository.APIContractRevisioner
 	awsAssumeRoleChainer      repository.AWSAssumeRoleChainer
 	porterApp                 repository.PorterAppRepository
@@ -60,6 +68,8 @@ type GormRepository struct {
 	datastore                 repository.DatastoreRepository
 	appInstance               repository.AppInstanceRepository
 	ipam                      repository.IpamRepository
+	workerJob                 repository.WorkerJobRepository
+	workerJobSchedule         repository.WorkerJobScheduleRepository
 }
 
 func (t *GormRepository) User() repository.UserRepository {
@@ -166,6 +176,10 @@ func (t *GormRepository) SlackIntegration() repository.SlackIntegrationRepositor
 	return t.slackIntegration
 }
 
+func (t *GormRepository) NotificationWebhookIntegration() repository.NotificationWebhookIntegrationRepository {
+	return t.webhookIntegration
+}
+
 func (t *GormRepository) GitlabIntegration() repository.GitlabIntegrationRepository {
 	return t.gitlabIntegration
 }
@@ -182,6 +196,14 @@ func (t *GormRepository) JobNotificationConfig() repository.JobNotificationConfi
 	return t.jobNotificationConfig
 }
 
+func (t *GormRepository) NotificationRule() repository.NotificationRuleRepository {
+	return t.notificationRule
+}
+
+func (t *GormRepository) NotificationAggregate() repository.NotificationAggregateRepository {
+	return t.notificationAggregate
+}
+
 func (t *GormRepository) BuildEvent() repository.BuildEventRepository {
 	return t.buildEvent
 }
@@ -234,6 +256,26 @@ func (t *GormRepository) MonitorTestResult() repository.MonitorTestResultReposit
 	return t.monitor
 }
 
+func (t *GormRepository) OPAPolicyBundle() repository.OPAPolicyBundleRepository {
+	return t.opaPolicyBundle
+}
+
+func (t *GormRepository) ImageScan() repository.ImageScanRepository {
+	return t.imageScan
+}
+
+func (t *GormRepository) RegistryRetention() repository.RegistryRetentionRepository {
+	return t.registryRetention
+}
+
+func (t *GormRepository) EnvGroupImportSource() repository.EnvGroupImportSourceRepository {
+	return t.envGroupImportSource
+}
+
+func (t *GormRepository) EnvGroupRetention() repository.EnvGroupRetentionRepository {
+	return t.envGroupRetention
+}
+
 func (t *GormRepository) APIContractRevisioner() repository.APIContractRevisioner {
 	return t.apiContractRevisions
 }
@@ -281,6 +323,16 @@ func (t *GormRepository) Ipam() repository.IpamRepository {
 	return t.ipam
 }
 
+// WorkerJob returns the WorkerJobRepository interface implemented by gorm
+func (t *GormRepository) WorkerJob() repository.WorkerJobRepository {
+	return t.workerJob
+}
+
+// WorkerJobSchedule returns the WorkerJobScheduleRepository interface implemented by gorm
+func (t *GormRepository) WorkerJobSchedule() repository.WorkerJobScheduleRepository {
+	return t.workerJobSchedule
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.CredentialStorage) repository.Repository {
@@ -311,10 +363,13 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		githubAppInstallation:     NewGithubAppInstallationRepository(db),
 		githubAppOAuthIntegration: NewGithubAppOAuthIntegrationRepository(db),
 		slackIntegration:          NewSlackIntegrationRepository(db, key),
+		webhookIntegration:        NewNotificationWebhookIntegrationRepository(db, key),
 		gitlabIntegration:         NewGitlabIntegrationRepository(db, key, storageBackend),
 		gitlabAppOAuthIntegration: NewGitlabAppOAuthIntegrationRepository(db, key, storageBackend),
 		notificationConfig:        NewNotificationConfigRepository(db),
 		jobNotificationConfig:     NewJobNotificationConfigRepository(db),
+		notificationRule:          NewNotificationRuleRepository(db),
+		notificationAggregate:     NewNotificationAggregateRepository(db),
 		buildEvent:                NewBuildEventRepository(db),
 		kubeEvent:                 NewKubeEventRepository(db, key),
 		projectUsage:              NewProjectUsageRepository(db),
@@ -327,6 +382,11 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		tag:                       NewTagRepository(db),
 		stack:                     NewStackRepository(db),
 		monitor:                   NewMonitorTestResultRepository(db),
+		opaPolicyBundle:           NewOPAPolicyBundleRepository(db),
+		imageScan:                 NewImageScanRepository(db),
+		registryRetention:         NewRegistryRetentionRepository(db),
+		envGroupImportSource:      NewEnvGroupImportSourceRepository(db, key),
+		envGroupRetention:         NewEnvGroupRetentionRepository(db),
 		apiContractRevisions:      NewAPIContractRevisioner(db),
 		awsAssumeRoleChainer:      NewAWSAssumeRoleChainer(db),
 		porterApp:                 NewPorterAppRepository(db),
@@ -338,5 +398,7 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		datastore:                 NewDatastoreRepository(db),
 		appInstance:               NewAppInstanceRepository(db),
 		ipam:                      NewIpamRepository(db),
+		workerJob:                 NewWorkerJobRepository(db),
+		workerJobSchedule:         NewWorkerJobScheduleRepository(db),
 	}
 }
@@ -0,0 +1,449 @@
+package gorm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WorkerJobRepository uses gorm.DB for querying the database
+type WorkerJobRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkerJobRepository returns a WorkerJobRepository which uses
+// gorm.DB for querying the database
+func NewWorkerJobRepository(db *gorm.DB) repository.WorkerJobRepository {
+	return &WorkerJobRepository{db}
+}
+
+// Insert persists a new job run in the queue
+func (repo *WorkerJobRepository) Insert(ctx context.Context, job *models.WorkerJob) (*models.WorkerJob, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-insert-worker-job")
+	defer span.End()
+
+	if job == nil {
+		return nil, telemetry.Error(ctx, span, nil, "job is nil")
+	}
+
+	if job.JobID == "" {
+		return nil, telemetry.Error(ctx, span, nil, "job id is empty")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "job-id", Value: job.JobID})
+
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+	if job.Status == "" {
+		job.Status = models.WorkerJobStatus_Queued
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = 1
+	}
+	if job.RunAfter.IsZero() {
+		job.RunAfter = time.Now().UTC()
+	}
+
+	if err := repo.db.Create(job).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error creating worker job")
+	}
+
+	return job, nil
+}
+
+// Get returns a job run by its id
+func (repo *WorkerJobRepository) Get(ctx context.Context, id uuid.UUID) (*models.WorkerJob, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-get-worker-job")
+	defer span.End()
+
+	if id == uuid.Nil {
+		return nil, telemetry.Error(ctx, span, nil, "id is nil")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "worker-job-id", Value: id.String()})
+
+	job := &models.WorkerJob{}
+
+	if err := repo.db.Where("id = ?", id).First(job).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error getting worker job")
+	}
+
+	return job, nil
+}
+
+// Lease atomically claims the next runnable job on behalf of the given worker. Jobs which have been running for longer
+// than leaseTimeout are considered abandoned and may be leased again. Runs of a job ID which is already running under a
+// live lease are not leased. Returns nil if there is no runnable job.
+func (repo *WorkerJobRepository) Lease(ctx context.Context, workerID string, leaseTimeout time.Duration) (*models.WorkerJob, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-lease-worker-job")
+	defer span.End()
+
+	if workerID == "" {
+		return nil, telemetry.Error(ctx, span, nil, "worker id is empty")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "worker-id", Value: workerID})
+
+	var leased *models.WorkerJob
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+		candidates := []*models.WorkerJob{}
+
+		leaseExpiry := now.Add(-leaseTimeout)
+
+		// SKIP LOCKED lets concurrent workers (and replicas) lease different rows without blocking on each other. Runs of a job ID
+		// which already has a run with a live lease are skipped, so that the same job never runs twice at the same time.
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where(
+				"(status IN ? AND run_after <= ?) OR (status = ? AND leased_at < ?)",
+				[]models.WorkerJobStatus{models.WorkerJobStatus_Queued, models.WorkerJobStatus_Retrying},
+				now,
+				models.WorkerJobStatus_Running,
+				leaseExpiry,
+			).
+			Where(
+				"NOT EXISTS (SELECT 1 FROM worker_jobs AS running WHERE running.job_id = worker_jobs.job_id AND running.id <> worker_jobs.id AND running.status = ? AND running.leased_at >= ? AND running.deleted_at IS NULL)",
+				models.WorkerJobStatus_Running,
+				leaseExpiry,
+			).
+			Order("run_after ASC").
+			Limit(1)
+
+		if err := query.Find(&candidates).Error; err != nil {
+			return err
+		}
+
+		if len(candidates) == 0 {
+			return nil
+		}
+
+		job := candidates[0]
+
+		// advisory locks are only available on postgres. Other dialects are only used with a single replica.
+		if tx.Dialector.Name() == "postgres" {
+			var locked bool
+
+			lockKey := fmt.Sprintf("worker-job-lease:%s", job.JobID)
+
+			if err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", lockKey).Scan(&locked).Error; err != nil {
+				return err
+			}
+
+			// another worker is leasing a run of the same job ID, which is picked up again on the next poll if it is still runnable
+			if !locked {
+				return nil
+			}
+
+			// a run of the same job ID may have been leased by a transaction which committed after the candidate was selected
+			var running int64
+
+			err := tx.Model(&models.WorkerJob{}).
+				Where("job_id = ? AND id <> ? AND status = ? AND leased_at >= ?", job.JobID, job.ID, models.WorkerJobStatus_Running, leaseExpiry).
+				Count(&running).Error
+			if err != nil {
+				return err
+			}
+
+			if running > 0 {
+				return nil
+			}
+		}
+		job.Status = models.WorkerJobStatus_Running
+		job.Attempts += 1
+		job.LeasedBy = workerID
+		job.LeasedAt = &now
+		job.StartedAt = &now
+		job.FinishedAt = nil
+		job.Logs = ""
+
+		if err := tx.Save(job).Error; err != nil {
+			return err
+		}
+
+		leased = job
+
+		return nil
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error leasing worker job")
+	}
+
+	return leased, nil
+}
+
+// Complete marks a leased job as succeeded, persisting its captured logs. A job which was canceled while
+// running keeps its canceled status.
+func (repo *WorkerJobRepository) Complete(ctx context.Context, job *models.WorkerJob) (*models.WorkerJob, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-complete-worker-job")
+	defer span.End()
+
+	if job == nil {
+		return nil, telemetry.Error(ctx, span, nil, "job is nil")
+	}
+
+	if job.ID == uuid.Nil {
+		return nil, telemetry.Error(ctx, span, nil, "job id is nil")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "worker-job-id", Value: job.ID.String()})
+
+	err := repo.finishAttempt(job, func() {
+		job.Status = models.WorkerJobStatus_Succeeded
+		job.LastError = ""
+		job.Checkpoint = nil
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error completing worker job")
+	}
+
+	return job, nil
+}
+
+// Fail records a failed attempt of a leased job, persisting its captured logs. The job is scheduled to run again
+// at retryAt if it has attempts remaining, otherwise it is moved to the dead-letter list. A job which was canceled
+// while running keeps its canceled status.
+func (repo *WorkerJobRepository) Fail(ctx context.Context, job *models.WorkerJob, runErr error, retryAt time.Time) (*models.WorkerJob, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-fail-worker-job")
+	defer span.End()
+
+	if job == nil {
+		return nil, telemetry.Error(ctx, span, nil, "job is nil")
+	}
+
+	if job.ID == uuid.Nil {
+		return nil, telemetry.Error(ctx, span, nil, "job id is nil")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "worker-job-id", Value: job.ID.String()})
+
+	if runErr != nil {
+		job.LastError = runErr.Error()
+	}
+
+	err := repo.finishAttempt(job, func() {
+		if job.Attempts >= job.MaxAttempts {
+			job.Status = models.WorkerJobStatus_Dead
+		} else {
+			job.Status = models.WorkerJobStatus_Retrying
+			job.RunAfter = retryAt.UTC()
+		}
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error failing worker job")
+	}
+
+	return job, nil
+}
+
+// Release returns a leased job to the queue without counting the attempt, keeping its checkpoint. This is used
+// when a job is interrupted by a shutdown of the workers binary. A job which was canceled while running keeps its
+// canceled status.
+func (repo *WorkerJobRepository) Release(ctx context.Context, job *models.WorkerJob) (*models.WorkerJob, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-release-worker-job")
+	defer span.End()
+
+	if job == nil {
+		return nil, telemetry.Error(ctx, span, nil, "job is nil")
+	}
+
+	if job.ID == uuid.Nil {
+		return nil, telemetry.Error(ctx, span, nil, "job id is nil")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "worker-job-id", Value: job.ID.String()})
+
+	err := repo.finishAttempt(job, func() {
+		job.Status = models.WorkerJobStatus_Queued
+		job.RunAfter = time.Now().UTC()
+		job.LeasedBy = ""
+
+		if job.Attempts > 0 {
+			job.Attempts -= 1
+		}
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error releasing worker job")
+	}
+
+	return job, nil
+}
+
+// RenewLease extends the lease of a job which is still running on behalf of the given worker, so that it is not considered
+// abandoned while it runs for longer than the lease timeout
+func (repo *WorkerJobRepository) RenewLease(ctx context.Context, id uuid.UUID, workerID string) error {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-renew-worker-job-lease")
+	defer span.End()
+
+	if id == uuid.Nil {
+		return telemetry.Error(ctx, span, nil, "id is nil")
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "worker-job-id", Value: id.String()},
+		telemetry.AttributeKV{Key: "worker-id", Value: workerID},
+	)
+
+	// the lease is only renewed if it was not taken over by another worker in the meantime
+	err := repo.db.Model(&models.WorkerJob{}).
+		Where("id = ? AND status = ? AND leased_by = ?", id, models.WorkerJobStatus_Running, workerID).
+		Update("leased_at", time.Now().UTC()).Error
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "error renewing worker job lease")
+	}
+
+	return nil
+}
+
+// SaveCheckpoint persists the progress of a running job
+func (repo *WorkerJobRepository) SaveCheckpoint(ctx context.Context, id uuid.UUID, checkpoint []byte) error {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-save-worker-job-checkpoint")
+	defer span.End()
+
+	if id == uuid.Nil {
+		return telemetry.Error(ctx, span, nil, "id is nil")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "worker-job-id", Value: id.String()})
+
+	if err := repo.db.Model(&models.WorkerJob{}).Where("id = ?", id).Update("checkpoint", checkpoint).Error; err != nil {
+		return telemetry.Error(ctx, span, err, "error saving worker job checkpoint")
+	}
+
+	return nil
+}
+
+// finishAttempt saves the outcome of an attempt of a leased job. The status change in setStatus is skipped if
+// the job was canceled while it was running.
+func (repo *WorkerJobRepository) finishAttempt(job *models.WorkerJob, setStatus func()) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		current := &models.WorkerJob{}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", job.ID).First(current).Error; err != nil {
+			return err
+		}
+
+		// the checkpoint is saved separately while the job runs, so the stored one is the most recent
+		job.Checkpoint = current.Checkpoint
+
+		if current.Status == models.WorkerJobStatus_Canceled {
+			job.Status = models.WorkerJobStatus_Canceled
+		} else {
+			setStatus()
+		}
+
+		// a released job has not finished its attempt, it is only handed back to the queue
+		if job.Status != models.WorkerJobStatus_Queued {
+			now := time.Now().UTC()
+			job.FinishedAt = &now
+		}
+
+		return tx.Save(job).Error
+	})
+}
+
+// Cancel marks a job which has not finished yet as canceled. Finished jobs are returned unchanged.
+func (repo *WorkerJobRepository) Cancel(ctx context.Context, id uuid.UUID) (*models.WorkerJob, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-cancel-worker-job")
+	defer span.End()
+
+	if id == uuid.Nil {
+		return nil, telemetry.Error(ctx, span, nil, "id is nil")
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "worker-job-id", Value: id.String()})
+
+	job := &models.WorkerJob{}
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(job).Error; err != nil {
+			return err
+		}
+
+		if job.IsFinished() {
+			return nil
+		}
+
+		// running jobs are stopped by the dispatcher which leased them, and record their finish time when they return
+		if job.Status != models.WorkerJobStatus_Running {
+			now := time.Now().UTC()
+			job.FinishedAt = &now
+		}
+
+		job.Status = models.WorkerJobStatus_Canceled
+
+		return tx.Save(job).Error
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error canceling worker job")
+	}
+
+	return job, nil
+}
+
+// CountPending returns the number of jobs which are queued, running or waiting to be retried
+func (repo *WorkerJobRepository) CountPending(ctx context.Context) (int64, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-count-pending-worker-jobs")
+	defer span.End()
+
+	var count int64
+
+	query := repo.db.Model(&models.WorkerJob{}).Where("status IN ?", []models.WorkerJobStatus{
+		models.WorkerJobStatus_Queued,
+		models.WorkerJobStatus_Running,
+		models.WorkerJobStatus_Retrying,
+	})
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, telemetry.Error(ctx, span, err, "error counting pending worker jobs")
+	}
+
+	return count, nil
+}
+
+// List returns a slice of WorkerJobs, sorted by created_at descending
+func (repo *WorkerJobRepository) List(ctx context.Context, filters ...repository.WorkerJobFilters) ([]*models.WorkerJob, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-worker-jobs")
+	defer span.End()
+
+	var opts repository.WorkerJobFilter
+	for _, opt := range filters {
+		opt(&opts)
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "status", Value: string(opts.Status)},
+		telemetry.AttributeKV{Key: "job-id", Value: opts.JobID},
+		telemetry.AttributeKV{Key: "limit", Value: opts.Limit},
+	)
+
+	jobs := []*models.WorkerJob{}
+
+	query := repo.db.Model(&models.WorkerJob{}).Order("created_at DESC")
+
+	if opts.Status != "" {
+		query = query.Where("status = ?", opts.Status)
+	}
+
+	if opts.JobID != "" {
+		query = query.Where("job_id = ?", opts.JobID)
+	}
+
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit)
+	}
+
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing worker jobs")
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,127 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// WorkerJobScheduleRepository uses gorm.DB for querying the database
+type WorkerJobScheduleRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkerJobScheduleRepository returns a WorkerJobScheduleRepository which uses
+// gorm.DB for querying the database
+func NewWorkerJobScheduleRepository(db *gorm.DB) repository.WorkerJobScheduleRepository {
+	return &WorkerJobScheduleRepository{db}
+}
+
+// List returns the schedules of all jobs which have been scheduled at least once
+func (repo *WorkerJobScheduleRepository) List(ctx context.Context) ([]*models.WorkerJobSchedule, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-worker-job-schedules")
+	defer span.End()
+
+	schedules := []*models.WorkerJobSchedule{}
+
+	if err := repo.db.Order("job_id ASC").Find(&schedules).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing worker job schedules")
+	}
+
+	return schedules, nil
+}
+
+// EnqueueScheduledRun enqueues job as the run of its schedule at tick. A Postgres advisory lock on the job ID
+// guarantees that only one replica claims a given tick. Returns nil if no run was enqueued, either because the
+// tick was already claimed or because a previous run of the same job is still queued or running.
+func (repo *WorkerJobScheduleRepository) EnqueueScheduledRun(ctx context.Context, job *models.WorkerJob, tick time.Time) (*models.WorkerJob, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-enqueue-scheduled-worker-job")
+	defer span.End()
+
+	if job == nil {
+		return nil, telemetry.Error(ctx, span, nil, "job is nil")
+	}
+
+	if job.JobID == "" {
+		return nil, telemetry.Error(ctx, span, nil, "job id is empty")
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "job-id", Value: job.JobID},
+		telemetry.AttributeKV{Key: "tick", Value: tick.UTC().Format(time.RFC3339)},
+	)
+
+	tick = tick.UTC()
+
+	var enqueued *models.WorkerJob
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		// advisory locks are only available on postgres. Other dialects are only used with a single replica.
+		if tx.Dialector.Name() == "postgres" {
+			var locked bool
+
+			lockKey := fmt.Sprintf("worker-job-schedule:%s", job.JobID)
+
+			if err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", lockKey).Scan(&locked).Error; err != nil {
+				return err
+			}
+
+			// another replica is claiming this tick
+			if !locked {
+				return nil
+			}
+		}
+
+		schedule := &models.WorkerJobSchedule{}
+
+		err := tx.Where("job_id = ?", job.JobID).First(schedule).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			schedule.JobID = job.JobID
+		}
+
+		if schedule.LastScheduledAt != nil && !schedule.LastScheduledAt.Before(tick) {
+			return nil
+		}
+
+		var inFlight int64
+
+		query := tx.Model(&models.WorkerJob{}).Where("job_id = ? AND status IN ?", job.JobID, []models.WorkerJobStatus{
+			models.WorkerJobStatus_Queued,
+			models.WorkerJobStatus_Running,
+			models.WorkerJobStatus_Retrying,
+		})
+
+		if err := query.Count(&inFlight).Error; err != nil {
+			return err
+		}
+
+		schedule.LastScheduledAt = &tick
+
+		if inFlight == 0 {
+			inserted, err := NewWorkerJobRepository(tx).Insert(ctx, job)
+			if err != nil {
+				return err
+			}
+
+			enqueued = inserted
+			schedule.LastRunID = inserted.ID
+		}
+
+		return tx.Save(schedule).Error
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error enqueueing scheduled worker job")
+	}
+
+	return enqueued, nil
+}
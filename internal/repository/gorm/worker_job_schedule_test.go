@@ -0,0 +1,63 @@
+package gorm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestEnqueueScheduledRun(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_enqueue_scheduled_run.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+	tick := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	job, err := tester.repo.WorkerJobSchedule().EnqueueScheduledRun(ctx, &models.WorkerJob{JobID: "recommender"}, tick)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if job == nil {
+		t.Fatalf("expected scheduled run to be enqueued")
+	}
+
+	// another replica claiming the same tick does not enqueue a second run
+	again, err := tester.repo.WorkerJobSchedule().EnqueueScheduledRun(ctx, &models.WorkerJob{JobID: "recommender"}, tick)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if again != nil {
+		t.Errorf("expected tick to be claimed only once")
+	}
+
+	// the next tick is skipped while the first run is still queued
+	skipped, err := tester.repo.WorkerJobSchedule().EnqueueScheduledRun(ctx, &models.WorkerJob{JobID: "recommender"}, tick.Add(15*time.Minute))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if skipped != nil {
+		t.Errorf("expected tick to be skipped while a run is in flight")
+	}
+
+	schedules, err := tester.repo.WorkerJobSchedule().List(ctx)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(schedules) != 1 || schedules[0].LastRunID != job.ID {
+		t.Fatalf("expected schedule to point at the enqueued run")
+	}
+
+	if !schedules[0].LastScheduledAt.Equal(tick.Add(15 * time.Minute)) {
+		t.Errorf("expected last scheduled time to be the skipped tick but got: %s", schedules[0].LastScheduledAt)
+	}
+}
@@ -0,0 +1,349 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+func TestLeaseWorkerJob(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_lease_worker_job.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	job, err := tester.repo.WorkerJob().Insert(ctx, &models.WorkerJob{
+		JobID:       "recommender",
+		Input:       []byte(`{"project_id":1}`),
+		MaxAttempts: 2,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	leased, err := tester.repo.WorkerJob().Lease(ctx, "worker-1", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if leased == nil || leased.ID != job.ID {
+		t.Fatalf("expected job %s to be leased", job.ID)
+	}
+
+	if leased.Status != models.WorkerJobStatus_Running || leased.Attempts != 1 || leased.LeasedBy != "worker-1" {
+		t.Errorf("unexpected leased job state: status %s, attempts %d, leased by %s", leased.Status, leased.Attempts, leased.LeasedBy)
+	}
+
+	// a running job cannot be leased again until its lease expires
+	again, err := tester.repo.WorkerJob().Lease(ctx, "worker-2", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if again != nil {
+		t.Errorf("expected no job to be leased but got: %s", again.ID)
+	}
+}
+
+func TestLeaseWorkerJobSingleFlight(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_lease_worker_job_single_flight.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	first, err := tester.repo.WorkerJob().Insert(ctx, &models.WorkerJob{JobID: "recommender", MaxAttempts: 1})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	second, err := tester.repo.WorkerJob().Insert(ctx, &models.WorkerJob{JobID: "recommender", MaxAttempts: 1})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	other, err := tester.repo.WorkerJob().Insert(ctx, &models.WorkerJob{JobID: "helm-revisions-count-tracker", MaxAttempts: 1})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	leased, err := tester.repo.WorkerJob().Lease(ctx, "worker-1", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if leased == nil || leased.ID != first.ID {
+		t.Fatalf("expected job %s to be leased", first.ID)
+	}
+
+	// the second run of the same job ID is skipped while the first one is running, but other job IDs are not blocked
+	leased, err = tester.repo.WorkerJob().Lease(ctx, "worker-2", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if leased == nil || leased.ID != other.ID {
+		t.Fatalf("expected job %s of another job ID to be leased", other.ID)
+	}
+
+	leased, err = tester.repo.WorkerJob().Lease(ctx, "worker-2", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if leased != nil {
+		t.Fatalf("expected no job to be leased while a run of the same job ID is running but got: %s", leased.ID)
+	}
+
+	firstRecord, err := tester.repo.WorkerJob().Get(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.WorkerJob().Complete(ctx, firstRecord); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	leased, err = tester.repo.WorkerJob().Lease(ctx, "worker-2", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if leased == nil || leased.ID != second.ID {
+		t.Fatalf("expected job %s to be leased once the previous run finished", second.ID)
+	}
+}
+
+func TestRenewWorkerJobLease(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_renew_worker_job_lease.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	job, err := tester.repo.WorkerJob().Insert(ctx, &models.WorkerJob{JobID: "helm-revisions-count-tracker", MaxAttempts: 1})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := tester.repo.WorkerJob().Lease(ctx, "worker-1", time.Hour); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// simulate a job which has been running for longer than the lease timeout
+	leasedAt := time.Now().UTC().Add(-2 * time.Hour)
+
+	if err := tester.db.Model(&models.WorkerJob{}).Where("id = ?", job.ID).Update("leased_at", leasedAt).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// a renewal by another worker does not extend the lease
+	if err := tester.repo.WorkerJob().RenewLease(ctx, job.ID, "worker-2"); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	record, err := tester.repo.WorkerJob().Get(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if record.LeasedAt == nil || record.LeasedAt.After(leasedAt.Add(time.Minute)) {
+		t.Fatalf("expected the lease not to be renewed by another worker, got leased at %v", record.LeasedAt)
+	}
+
+	if err := tester.repo.WorkerJob().RenewLease(ctx, job.ID, "worker-1"); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// a renewed lease keeps the running job from being leased again
+	again, err := tester.repo.WorkerJob().Lease(ctx, "worker-2", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if again != nil {
+		t.Errorf("expected no job to be leased but got: %s", again.ID)
+	}
+}
+
+func TestFailWorkerJobMovesToDeadLetter(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_fail_worker_job.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	_, err := tester.repo.WorkerJob().Insert(ctx, &models.WorkerJob{
+		JobID:       "helm-revisions-count-tracker",
+		MaxAttempts: 2,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	leased, err := tester.repo.WorkerJob().Lease(ctx, "worker-1", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	failed, err := tester.repo.WorkerJob().Fail(ctx, leased, errors.New("cluster unreachable"), time.Now().Add(-time.Second))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if failed.Status != models.WorkerJobStatus_Retrying || failed.LastError != "cluster unreachable" {
+		t.Errorf("expected job to be retrying with last error set, got status %s and error %q", failed.Status, failed.LastError)
+	}
+
+	leased, err = tester.repo.WorkerJob().Lease(ctx, "worker-1", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if leased == nil || leased.Attempts != 2 {
+		t.Fatalf("expected retrying job to be leased for a second attempt")
+	}
+
+	failed, err = tester.repo.WorkerJob().Fail(ctx, leased, errors.New("cluster unreachable"), time.Now())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if failed.Status != models.WorkerJobStatus_Dead {
+		t.Errorf("expected job to be dead-lettered but got status: %s", failed.Status)
+	}
+
+	dead, err := tester.repo.WorkerJob().List(ctx, repository.WithWorkerJobStatus(models.WorkerJobStatus_Dead))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(dead) != 1 || dead[0].ID != failed.ID {
+		t.Errorf("expected dead-letter list to contain job %s", failed.ID)
+	}
+}
+
+func TestCancelRunningWorkerJob(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_cancel_worker_job.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	_, err := tester.repo.WorkerJob().Insert(ctx, &models.WorkerJob{
+		JobID:       "preview-deployments-ttl-deleter",
+		MaxAttempts: 1,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	leased, err := tester.repo.WorkerJob().Lease(ctx, "worker-1", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	canceled, err := tester.repo.WorkerJob().Cancel(ctx, leased.ID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if canceled.Status != models.WorkerJobStatus_Canceled {
+		t.Fatalf("expected job to be canceled but got status: %s", canceled.Status)
+	}
+
+	leased.Logs = "deleting deployment 'pr-1'"
+
+	completed, err := tester.repo.WorkerJob().Complete(ctx, leased)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if completed.Status != models.WorkerJobStatus_Canceled {
+		t.Errorf("expected canceled job to keep its status but got: %s", completed.Status)
+	}
+
+	if completed.FinishedAt == nil || completed.Logs == "" {
+		t.Errorf("expected finish time and logs to be recorded for canceled job")
+	}
+}
+
+func TestReleaseWorkerJobKeepsCheckpoint(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_release_worker_job.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	job, err := tester.repo.WorkerJob().Insert(ctx, &models.WorkerJob{
+		JobID:       "helm-revisions-count-tracker",
+		MaxAttempts: 1,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	leased, err := tester.repo.WorkerJob().Lease(ctx, "worker-1", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := tester.repo.WorkerJob().SaveCheckpoint(ctx, job.ID, []byte(`{"completed_cluster_ids":[1]}`)); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	released, err := tester.repo.WorkerJob().Release(ctx, leased)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if released.Status != models.WorkerJobStatus_Queued || released.Attempts != 0 || released.FinishedAt != nil {
+		t.Errorf("unexpected released job state: status %s, attempts %d, finished at %v", released.Status, released.Attempts, released.FinishedAt)
+	}
+
+	// the released attempt does not count towards the max attempts, so the job can be leased again
+	again, err := tester.repo.WorkerJob().Lease(ctx, "worker-2", time.Hour)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if again == nil || again.ID != job.ID {
+		t.Fatalf("expected job %s to be leased again", job.ID)
+	}
+
+	if string(again.Checkpoint) != `{"completed_cluster_ids":[1]}` {
+		t.Errorf("expected checkpoint to be kept but got: %s", string(again.Checkpoint))
+	}
+
+	completed, err := tester.repo.WorkerJob().Complete(ctx, again)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if completed.Checkpoint != nil {
+		t.Errorf("expected checkpoint to be cleared on completion but got: %s", string(completed.Checkpoint))
+	}
+}
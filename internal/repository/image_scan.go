@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ImageScanRepository represents the set of queries on the ImageScan and ImageScanPolicy models
+type ImageScanRepository interface {
+	// UpsertImageScan stores the result of a scan, replacing the previous result of the scanner for the same image digest
+	UpsertImageScan(ctx context.Context, scan *models.ImageScan) (*models.ImageScan, error)
+	// ListImageScansByDigest returns the latest scan of an image of a project by each scanner, ordered by scanner
+	ListImageScansByDigest(ctx context.Context, projectID uint, repository, digest string) ([]*models.ImageScan, error)
+	// ListImageScansByDigests returns the scans of the images of a repository of a project with any of the given digests
+	ListImageScansByDigests(ctx context.Context, projectID uint, repository string, digests []string) ([]*models.ImageScan, error)
+	// ReadLatestImageScanByTag returns the most recent scan of an image of a project which had the given tag when it was scanned
+	ReadLatestImageScanByTag(ctx context.Context, projectID uint, repository, tag string) (*models.ImageScan, error)
+	// ReadImageScanPolicy returns the severity gate of a project. A project without a gate has an empty policy.
+	ReadImageScanPolicy(ctx context.Context, projectID uint) (*models.ImageScanPolicy, error)
+	// UpdateImageScanPolicy creates or updates the severity gate of a project
+	UpdateImageScanPolicy(ctx context.Context, policy *models.ImageScanPolicy) (*models.ImageScanPolicy, error)
+}
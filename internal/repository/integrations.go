@@ -53,6 +53,13 @@ type SlackIntegrationRepository interface {
 	DeleteSlackIntegration(integrationID uint) error
 }
 
+// NotificationWebhookIntegrationRepository represents the set of queries on a notification webhook integration
+type NotificationWebhookIntegrationRepository interface {
+	CreateNotificationWebhookIntegration(webhookInt *ints.NotificationWebhookIntegration) (*ints.NotificationWebhookIntegration, error)
+	ListNotificationWebhookIntegrationsByProjectID(projectID uint) ([]*ints.NotificationWebhookIntegration, error)
+	DeleteNotificationWebhookIntegration(integrationID uint) error
+}
+
 // AWSIntegrationRepository represents the set of queries on the AWS auth
 // mechanism
 type AWSIntegrationRepository interface {
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// NotificationAggregateRepository represents the set of queries on the NotificationAggregate model
+type NotificationAggregateRepository interface {
+	// RecordOccurrence counts an occurrence towards the unresolved aggregate with the key of occurrence, creating
+	// the aggregate if none exists. Returns true if the aggregate was created by this call.
+	RecordOccurrence(ctx context.Context, occurrence *models.NotificationAggregate, at time.Time, window time.Duration) (*models.NotificationAggregate, bool, error)
+	// ListDueNotificationAggregates returns the unresolved aggregates which have pending occurrences at the end of their
+	// window, or which have not seen an occurrence since resolveBefore
+	ListDueNotificationAggregates(ctx context.Context, now, resolveBefore time.Time) ([]*models.NotificationAggregate, error)
+	// UpdateNotificationAggregate updates an aggregate
+	UpdateNotificationAggregate(ctx context.Context, aggregate *models.NotificationAggregate) (*models.NotificationAggregate, error)
+	// ListNotificationAggregatesByProjectID returns the aggregates of a project which saw an occurrence since the given time
+	ListNotificationAggregatesByProjectID(ctx context.Context, projectID uint, since time.Time) ([]*models.NotificationAggregate, error)
+	// ListNotificationDigestProjectIDs returns the projects which opted in to the notification digest and have
+	// aggregates that saw an occurrence since the given time
+	ListNotificationDigestProjectIDs(ctx context.Context, since time.Time) ([]uint, error)
+	// ReadNotificationDigestSetting returns the digest setting of a project. A project without a setting has a
+	// disabled setting.
+	ReadNotificationDigestSetting(ctx context.Context, projectID uint) (*models.NotificationDigestSetting, error)
+	// UpdateNotificationDigestSetting creates or updates the digest setting of a project
+	UpdateNotificationDigestSetting(ctx context.Context, setting *models.NotificationDigestSetting) (*models.NotificationDigestSetting, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// NotificationRuleRepository represents the set of queries on the NotificationRule model
+type NotificationRuleRepository interface {
+	// CreateNotificationRule creates a new notification rule
+	CreateNotificationRule(ctx context.Context, rule *models.NotificationRule) (*models.NotificationRule, error)
+	// ReadNotificationRule returns a notification rule of a project by its id
+	ReadNotificationRule(ctx context.Context, projectID, id uint) (*models.NotificationRule, error)
+	// ListNotificationRulesByProjectID returns the notification rules of a project in the order in which they are evaluated
+	ListNotificationRulesByProjectID(ctx context.Context, projectID uint) ([]*models.NotificationRule, error)
+	// UpdateNotificationRule updates a notification rule
+	UpdateNotificationRule(ctx context.Context, rule *models.NotificationRule) (*models.NotificationRule, error)
+	// DeleteNotificationRule deletes a notification rule along with its throttle records
+	DeleteNotificationRule(ctx context.Context, rule *models.NotificationRule) error
+	// AcquireThrottle returns true and records the time if the rule has not let through a notification for the
+	// given key within window. Returns false if a notification for the key should be throttled.
+	AcquireThrottle(ctx context.Context, ruleID uint, key string, window time.Duration) (bool, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// OPAPolicyBundleRepository represents the set of queries on the OPAPolicyBundle model
+type OPAPolicyBundleRepository interface {
+	// CreateOPAPolicyBundle creates the next version of a policy bundle
+	CreateOPAPolicyBundle(ctx context.Context, bundle *models.OPAPolicyBundle) (*models.OPAPolicyBundle, error)
+	// ReadOPAPolicyBundle returns a version of a policy bundle of a project. If version is 0, the latest version is returned.
+	ReadOPAPolicyBundle(ctx context.Context, projectID uint, name string, version uint) (*models.OPAPolicyBundle, error)
+	// ListOPAPolicyBundleVersions returns the versions of a policy bundle of a project, latest first
+	ListOPAPolicyBundleVersions(ctx context.Context, projectID uint, name string) ([]*models.OPAPolicyBundle, error)
+	// ListLatestOPAPolicyBundlesByProjectID returns the latest version of each policy bundle of a project, ordered by name
+	ListLatestOPAPolicyBundlesByProjectID(ctx context.Context, projectID uint) ([]*models.OPAPolicyBundle, error)
+	// DeleteOPAPolicyBundle deletes all versions of a policy bundle of a project
+	DeleteOPAPolicyBundle(ctx context.Context, projectID uint, name string) error
+	// ListOPAPolicyOverridesByProjectID returns the policy collection overrides of a project, ordered by collection
+	ListOPAPolicyOverridesByProjectID(ctx context.Context, projectID uint) ([]*models.OPAPolicyOverride, error)
+	// ReplaceOPAPolicyOverrides replaces the policy collection overrides of a project
+	ReplaceOPAPolicyOverrides(ctx context.Context, projectID uint, overrides []*models.OPAPolicyOverride) ([]*models.OPAPolicyOverride, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// RegistryRetentionRepository represents the set of queries on the RegistryRetentionPolicy model
+type RegistryRetentionRepository interface {
+	// UpdateRetentionPolicy creates or updates the retention policy of an image repository of a registry
+	UpdateRetentionPolicy(ctx context.Context, policy *models.RegistryRetentionPolicy) (*models.RegistryRetentionPolicy, error)
+	// ReadRetentionPolicy returns the retention policy of an image repository of a registry
+	ReadRetentionPolicy(ctx context.Context, projectID, registryID uint, repoName string) (*models.RegistryRetentionPolicy, error)
+	// ListRetentionPoliciesByRegistryID returns the retention policies of the image repositories of a registry, ordered by repository
+	ListRetentionPoliciesByRegistryID(ctx context.Context, projectID, registryID uint) ([]*models.RegistryRetentionPolicy, error)
+	// ListEnabledRetentionPolicies returns the enabled retention policies of all projects, ordered by project and registry
+	ListEnabledRetentionPolicies(ctx context.Context) ([]*models.RegistryRetentionPolicy, error)
+	// RecordRetentionPolicyRun stores the time and the number of deleted images of the latest garbage collection of a policy
+	RecordRetentionPolicyRun(ctx context.Context, policyID uint, runAt time.Time, deletedCount uint) error
+	// DeleteRetentionPolicy deletes the retention policy of an image repository
+	DeleteRetentionPolicy(ctx context.Context, policy *models.RegistryRetentionPolicy) error
+}
@@ -27,10 +27,13 @@ type Repository interface {
 	GithubAppInstallation() GithubAppInstallationRepository
 	GithubAppOAuthIntegration() GithubAppOAuthIntegrationRepository
 	SlackIntegration() SlackIntegrationRepository
+	NotificationWebhookIntegration() NotificationWebhookIntegrationRepository
 	GitlabIntegration() GitlabIntegrationRepository
 	GitlabAppOAuthIntegration() GitlabAppOAuthIntegrationRepository
 	NotificationConfig() NotificationConfigRepository
 	JobNotificationConfig() JobNotificationConfigRepository
+	NotificationRule() NotificationRuleRepository
+	NotificationAggregate() NotificationAggregateRepository
 	BuildEvent() BuildEventRepository
 	KubeEvent() KubeEventRepository
 	ProjectUsage() ProjectUsageRepository
@@ -43,6 +46,11 @@ type Repository interface {
 	Tag() TagRepository
 	Stack() StackRepository
 	MonitorTestResult() MonitorTestResultRepository
+	OPAPolicyBundle() OPAPolicyBundleRepository
+	ImageScan() ImageScanRepository
+	RegistryRetention() RegistryRetentionRepository
+	EnvGroupImportSource() EnvGroupImportSourceRepository
+	EnvGroupRetention() EnvGroupRetentionRepository
 	APIContractRevisioner() APIContractRevisioner
 	AWSAssumeRoleChainer() AWSAssumeRoleChainer
 	PorterApp() PorterAppRepository
@@ -53,4 +61,6 @@ type Repository interface {
 	GithubWebhook() GithubWebhookRepository
 	Datastore() DatastoreRepository
 	AppInstance() AppInstanceRepository
+	WorkerJob() WorkerJobRepository
+	WorkerJobSchedule() WorkerJobScheduleRepository
 }
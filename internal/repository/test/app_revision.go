@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"errors"
 
 	"github.com/porter-dev/porter/internal/models"
@@ -26,3 +27,13 @@ func (repo *AppRevisionRepository) AppRevisionByInstanceIDAndRevisionNumber(proj
 func (repo *AppRevisionRepository) LatestNumberedAppRevision(projectID uint, appInstanceId string) (*models.AppRevision, error) {
 	return nil, errors.New("cannot read database")
 }
+
+// LiveAppRevisions finds the latest revision and the latest successfully deployed revision of every app of a project
+func (repo *AppRevisionRepository) LiveAppRevisions(ctx context.Context, projectID uint) ([]*models.AppRevision, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// LatestAppRevisions finds the most recent revisions of every app of a project in every deployment target
+func (repo *AppRevisionRepository) LatestAppRevisions(ctx context.Context, projectID uint, count uint) ([]*models.AppRevision, error) {
+	return nil, errors.New("cannot read database")
+}
@@ -3,6 +3,7 @@ package test
 import (
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 )
@@ -34,3 +35,81 @@ func (repo *DNSRecordRepository) CreateDNSRecord(
 
 	return record, nil
 }
+
+// ListDNSRecordsByPorterAppName lists the dns records created for an app in a cluster
+func (repo *DNSRecordRepository) ListDNSRecordsByPorterAppName(clusterID uint, appName string) ([]*models.DNSRecord, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.DNSRecord, 0)
+
+	for _, record := range repo.dnsRecords {
+		if record != nil && record.ClusterID == clusterID && record.PorterAppName == appName {
+			res = append(res, record)
+		}
+	}
+
+	return res, nil
+}
+
+// ListDNSRecordsByDeploymentTargetID lists the dns records created for apps in a deployment target
+func (repo *DNSRecordRepository) ListDNSRecordsByDeploymentTargetID(deploymentTargetID uuid.UUID) ([]*models.DNSRecord, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if deploymentTargetID == uuid.Nil {
+		return nil, errors.New("deployment target id cannot be nil")
+	}
+
+	res := make([]*models.DNSRecord, 0)
+
+	for _, record := range repo.dnsRecords {
+		if record != nil && record.DeploymentTargetID == deploymentTargetID {
+			res = append(res, record)
+		}
+	}
+
+	return res, nil
+}
+
+// ListDNSRecordsWithoutAppByHostnames lists the dns records with one of the given hostnames which were created before records
+// were assigned to the app they were created for
+func (repo *DNSRecordRepository) ListDNSRecordsWithoutAppByHostnames(hostnames []string) ([]*models.DNSRecord, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.DNSRecord, 0)
+
+	for _, record := range repo.dnsRecords {
+		if record == nil || record.PorterAppName != "" {
+			continue
+		}
+
+		for _, hostname := range hostnames {
+			if record.Hostname == hostname {
+				res = append(res, record)
+				break
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// DeleteDNSRecord deletes a dns record
+func (repo *DNSRecordRepository) DeleteDNSRecord(record *models.DNSRecord) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(record.ID-1) >= len(repo.dnsRecords) || repo.dnsRecords[record.ID-1] == nil {
+		return errors.New("record not found")
+	}
+
+	repo.dnsRecords[record.ID-1] = nil
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// EnvGroupImportSourceRepository is a test repository that implements repository.EnvGroupImportSourceRepository
+type EnvGroupImportSourceRepository struct {
+	canQuery bool
+}
+
+// NewEnvGroupImportSourceRepository returns the test EnvGroupImportSourceRepository
+func NewEnvGroupImportSourceRepository(canQuery bool) repository.EnvGroupImportSourceRepository {
+	return &EnvGroupImportSourceRepository{canQuery: canQuery}
+}
+
+// UpdateEnvGroupImportSource creates or updates the import source of an environment group
+func (repo *EnvGroupImportSourceRepository) UpdateEnvGroupImportSource(ctx context.Context, source *models.EnvGroupImportSource) (*models.EnvGroupImportSource, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// ReadEnvGroupImportSource returns the import source of an environment group
+func (repo *EnvGroupImportSourceRepository) ReadEnvGroupImportSource(ctx context.Context, projectID, clusterID uint, envGroupName string) (*models.EnvGroupImportSource, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ListEnvGroupImportSourcesByClusterID returns the import sources of a cluster
+func (repo *EnvGroupImportSourceRepository) ListEnvGroupImportSourcesByClusterID(ctx context.Context, projectID, clusterID uint) ([]*models.EnvGroupImportSource, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ListScheduledEnvGroupImportSources returns the import sources which are synced on a schedule
+func (repo *EnvGroupImportSourceRepository) ListScheduledEnvGroupImportSources(ctx context.Context) ([]*models.EnvGroupImportSource, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// RecordEnvGroupImportSourceSync stores the result of the latest sync of a source
+func (repo *EnvGroupImportSourceRepository) RecordEnvGroupImportSourceSync(ctx context.Context, sourceID uint, syncedAt time.Time, version int, syncErr string) error {
+	return errors.New("cannot write database")
+}
+
+// DeleteEnvGroupImportSource deletes the import source of an environment group
+func (repo *EnvGroupImportSourceRepository) DeleteEnvGroupImportSource(ctx context.Context, source *models.EnvGroupImportSource) error {
+	return errors.New("cannot write database")
+}
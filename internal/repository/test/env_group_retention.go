@@ -0,0 +1,40 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// EnvGroupRetentionRepository is a test repository that implements repository.EnvGroupRetentionRepository
+type EnvGroupRetentionRepository struct {
+	canQuery bool
+}
+
+// NewEnvGroupRetentionRepository returns the test EnvGroupRetentionRepository
+func NewEnvGroupRetentionRepository(canQuery bool) repository.EnvGroupRetentionRepository {
+	return &EnvGroupRetentionRepository{canQuery: canQuery}
+}
+
+// UpdateEnvGroupRetentionPolicy creates or updates the environment group retention policy of a project
+func (repo *EnvGroupRetentionRepository) UpdateEnvGroupRetentionPolicy(ctx context.Context, policy *models.EnvGroupRetentionPolicy) (*models.EnvGroupRetentionPolicy, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// ReadEnvGroupRetentionPolicy returns the environment group retention policy of a project
+func (repo *EnvGroupRetentionRepository) ReadEnvGroupRetentionPolicy(ctx context.Context, projectID uint) (*models.EnvGroupRetentionPolicy, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ListEnabledEnvGroupRetentionPolicies returns the enabled environment group retention policies of all projects
+func (repo *EnvGroupRetentionRepository) ListEnabledEnvGroupRetentionPolicies(ctx context.Context) ([]*models.EnvGroupRetentionPolicy, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// RecordEnvGroupRetentionPolicyRun stores the result of the latest garbage collection of a policy
+func (repo *EnvGroupRetentionRepository) RecordEnvGroupRetentionPolicyRun(ctx context.Context, policyID uint, runAt time.Time, deletedCount uint) error {
+	return errors.New("cannot write database")
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// ImageScanRepository is a test repository that implements repository.ImageScanRepository
+type ImageScanRepository struct {
+	canQuery bool
+}
+
+// NewImageScanRepository returns the test ImageScanRepository
+func NewImageScanRepository(canQuery bool) repository.ImageScanRepository {
+	return &ImageScanRepository{canQuery: canQuery}
+}
+
+// UpsertImageScan stores the result of a scan
+func (repo *ImageScanRepository) UpsertImageScan(ctx context.Context, scan *models.ImageScan) (*models.ImageScan, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// ListImageScansByDigest returns the latest scan of an image by each scanner
+func (repo *ImageScanRepository) ListImageScansByDigest(ctx context.Context, projectID uint, repository, digest string) ([]*models.ImageScan, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ListImageScansByDigests returns the scans of the images of a repository with any of the given digests
+func (repo *ImageScanRepository) ListImageScansByDigests(ctx context.Context, projectID uint, repository string, digests []string) ([]*models.ImageScan, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ReadLatestImageScanByTag returns the most recent scan of an image with the given tag
+func (repo *ImageScanRepository) ReadLatestImageScanByTag(ctx context.Context, projectID uint, repository, tag string) (*models.ImageScan, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ReadImageScanPolicy returns the severity gate of a project
+func (repo *ImageScanRepository) ReadImageScanPolicy(ctx context.Context, projectID uint) (*models.ImageScanPolicy, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// UpdateImageScanPolicy creates or updates the severity gate of a project
+func (repo *ImageScanRepository) UpdateImageScanPolicy(ctx context.Context, policy *models.ImageScanPolicy) (*models.ImageScanPolicy, error) {
+	return nil, errors.New("cannot write database")
+}
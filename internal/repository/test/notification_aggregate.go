@@ -0,0 +1,56 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// NotificationAggregateRepository is a test repository that implements repository.NotificationAggregateRepository
+type NotificationAggregateRepository struct {
+	canQuery bool
+}
+
+// NewNotificationAggregateRepository returns the test NotificationAggregateRepository
+func NewNotificationAggregateRepository(canQuery bool) repository.NotificationAggregateRepository {
+	return &NotificationAggregateRepository{canQuery: canQuery}
+}
+
+// RecordOccurrence counts an occurrence towards the unresolved aggregate with the key of occurrence
+func (repo *NotificationAggregateRepository) RecordOccurrence(ctx context.Context, occurrence *models.NotificationAggregate, at time.Time, window time.Duration) (*models.NotificationAggregate, bool, error) {
+	return nil, false, errors.New("cannot write database")
+}
+
+// ListDueNotificationAggregates returns the unresolved aggregates which are due to be reported or resolved
+func (repo *NotificationAggregateRepository) ListDueNotificationAggregates(ctx context.Context, now, resolveBefore time.Time) ([]*models.NotificationAggregate, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// UpdateNotificationAggregate updates an aggregate
+func (repo *NotificationAggregateRepository) UpdateNotificationAggregate(ctx context.Context, aggregate *models.NotificationAggregate) (*models.NotificationAggregate, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// ListNotificationAggregatesByProjectID returns the aggregates of a project which saw an occurrence since the given time
+func (repo *NotificationAggregateRepository) ListNotificationAggregatesByProjectID(ctx context.Context, projectID uint, since time.Time) ([]*models.NotificationAggregate, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ListNotificationDigestProjectIDs returns the projects which opted in to the notification digest and have
+// aggregates that saw an occurrence since the given time
+func (repo *NotificationAggregateRepository) ListNotificationDigestProjectIDs(ctx context.Context, since time.Time) ([]uint, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ReadNotificationDigestSetting returns the digest setting of a project
+func (repo *NotificationAggregateRepository) ReadNotificationDigestSetting(ctx context.Context, projectID uint) (*models.NotificationDigestSetting, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// UpdateNotificationDigestSetting creates or updates the digest setting of a project
+func (repo *NotificationAggregateRepository) UpdateNotificationDigestSetting(ctx context.Context, setting *models.NotificationDigestSetting) (*models.NotificationDigestSetting, error) {
+	return nil, errors.New("cannot write database")
+}
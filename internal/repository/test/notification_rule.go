@@ -0,0 +1,50 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// NotificationRuleRepository is a test repository that implements repository.NotificationRuleRepository
+type NotificationRuleRepository struct {
+	canQuery bool
+}
+
+// NewNotificationRuleRepository returns the test NotificationRuleRepository
+func NewNotificationRuleRepository(canQuery bool) repository.NotificationRuleRepository {
+	return &NotificationRuleRepository{canQuery: canQuery}
+}
+
+// CreateNotificationRule creates a new notification rule
+func (repo *NotificationRuleRepository) CreateNotificationRule(ctx context.Context, rule *models.NotificationRule) (*models.NotificationRule, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// ReadNotificationRule returns a notification rule of a project by its id
+func (repo *NotificationRuleRepository) ReadNotificationRule(ctx context.Context, projectID, id uint) (*models.NotificationRule, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ListNotificationRulesByProjectID returns the notification rules of a project
+func (repo *NotificationRuleRepository) ListNotificationRulesByProjectID(ctx context.Context, projectID uint) ([]*models.NotificationRule, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// UpdateNotificationRule updates a notification rule
+func (repo *NotificationRuleRepository) UpdateNotificationRule(ctx context.Context, rule *models.NotificationRule) (*models.NotificationRule, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// DeleteNotificationRule deletes a notification rule
+func (repo *NotificationRuleRepository) DeleteNotificationRule(ctx context.Context, rule *models.NotificationRule) error {
+	return errors.New("cannot write database")
+}
+
+// AcquireThrottle records a notification let through by a rule
+func (repo *NotificationRuleRepository) AcquireThrottle(ctx context.Context, ruleID uint, key string, window time.Duration) (bool, error) {
+	return false, errors.New("cannot write database")
+}
@@ -0,0 +1,32 @@
+package test
+
+import (
+	"errors"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type NotificationWebhookIntegrationRepository struct {
+	canQuery bool
+}
+
+func NewNotificationWebhookIntegrationRepository(canQuery bool) repository.NotificationWebhookIntegrationRepository {
+	return &NotificationWebhookIntegrationRepository{canQuery}
+}
+
+func (repo *NotificationWebhookIntegrationRepository) CreateNotificationWebhookIntegration(
+	webhookInt *ints.NotificationWebhookIntegration,
+) (*ints.NotificationWebhookIntegration, error) {
+	return nil, errors.New("cannot write database")
+}
+
+func (repo *NotificationWebhookIntegrationRepository) ListNotificationWebhookIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.NotificationWebhookIntegration, error) {
+	return nil, errors.New("cannot read from database")
+}
+
+func (repo *NotificationWebhookIntegrationRepository) DeleteNotificationWebhookIntegration(integrationID uint) error {
+	return errors.New("cannot write database")
+}
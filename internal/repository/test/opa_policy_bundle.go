@@ -0,0 +1,54 @@
+package test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// OPAPolicyBundleRepository is a test repository that implements repository.OPAPolicyBundleRepository
+type OPAPolicyBundleRepository struct {
+	canQuery bool
+}
+
+// NewOPAPolicyBundleRepository returns the test OPAPolicyBundleRepository
+func NewOPAPolicyBundleRepository(canQuery bool) repository.OPAPolicyBundleRepository {
+	return &OPAPolicyBundleRepository{canQuery: canQuery}
+}
+
+// CreateOPAPolicyBundle creates the next version of a policy bundle
+func (repo *OPAPolicyBundleRepository) CreateOPAPolicyBundle(ctx context.Context, bundle *models.OPAPolicyBundle) (*models.OPAPolicyBundle, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// ReadOPAPolicyBundle returns a version of a policy bundle of a project
+func (repo *OPAPolicyBundleRepository) ReadOPAPolicyBundle(ctx context.Context, projectID uint, name string, version uint) (*models.OPAPolicyBundle, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ListOPAPolicyBundleVersions returns the versions of a policy bundle of a project
+func (repo *OPAPolicyBundleRepository) ListOPAPolicyBundleVersions(ctx context.Context, projectID uint, name string) ([]*models.OPAPolicyBundle, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ListLatestOPAPolicyBundlesByProjectID returns the latest version of each policy bundle of a project
+func (repo *OPAPolicyBundleRepository) ListLatestOPAPolicyBundlesByProjectID(ctx context.Context, projectID uint) ([]*models.OPAPolicyBundle, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// DeleteOPAPolicyBundle deletes all versions of a policy bundle of a project
+func (repo *OPAPolicyBundleRepository) DeleteOPAPolicyBundle(ctx context.Context, projectID uint, name string) error {
+	return errors.New("cannot write database")
+}
+
+// ListOPAPolicyOverridesByProjectID returns the policy collection overrides of a project
+func (repo *OPAPolicyBundleRepository) ListOPAPolicyOverridesByProjectID(ctx context.Context, projectID uint) ([]*models.OPAPolicyOverride, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ReplaceOPAPolicyOverrides replaces the policy collection overrides of a project
+func (repo *OPAPolicyBundleRepository) ReplaceOPAPolicyOverrides(ctx context.Context, projectID uint, overrides []*models.OPAPolicyOverride) ([]*models.OPAPolicyOverride, error) {
+	return nil, errors.New("cannot write database")
+}
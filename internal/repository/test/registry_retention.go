@@ -0,0 +1,50 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// RegistryRetentionRepository is a test repository that implements repository.RegistryRetentionRepository
+type RegistryRetentionRepository struct {
+	canQuery bool
+}
+
+// NewRegistryRetentionRepository returns the test RegistryRetentionRepository
+func NewRegistryRetentionRepository(canQuery bool) repository.RegistryRetentionRepository {
+	return &RegistryRetentionRepository{canQuery: canQuery}
+}
+
+// UpdateRetentionPolicy creates or updates the retention policy of an image repository
+func (repo *RegistryRetentionRepository) UpdateRetentionPolicy(ctx context.Context, policy *models.RegistryRetentionPolicy) (*models.RegistryRetentionPolicy, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// ReadRetentionPolicy returns the retention policy of an image repository
+func (repo *RegistryRetentionRepository) ReadRetentionPolicy(ctx context.Context, projectID, registryID uint, repoName string) (*models.RegistryRetentionPolicy, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ListRetentionPoliciesByRegistryID returns the retention policies of a registry
+func (repo *RegistryRetentionRepository) ListRetentionPoliciesByRegistryID(ctx context.Context, projectID, registryID uint) ([]*models.RegistryRetentionPolicy, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ListEnabledRetentionPolicies returns the enabled retention policies of all projects
+func (repo *RegistryRetentionRepository) ListEnabledRetentionPolicies(ctx context.Context) ([]*models.RegistryRetentionPolicy, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// RecordRetentionPolicyRun stores the result of the latest garbage collection of a policy
+func (repo *RegistryRetentionRepository) RecordRetentionPolicyRun(ctx context.Context, policyID uint, runAt time.Time, deletedCount uint) error {
+	return errors.New("cannot write database")
+}
+
+// DeleteRetentionPolicy deletes the retention policy of an image repository
+func (repo *RegistryRetentionRepository) DeleteRetentionPolicy(ctx context.Context, policy *models.RegistryRetentionPolicy) error {
+	return errors.New("cannot write database")
+}
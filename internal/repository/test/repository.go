@@ -32,8 +32,11 @@ type TestRepository struct {
 	gitlabIntegration         repository.GitlabIntegrationRepository
 	gitlabAppOAuthIntegration repository.GitlabAppOAuthIntegrationRepository
 	slackIntegration          repository.SlackIntegrationRepository
+	webhookIntegration        repository.NotificationWebhookIntegrationRepository
 	notificationConfig        repository.NotificationConfigRepository
 	jobNotificationConfig     repository.JobNotificationConfigRepository
+	notificationRule          repository.NotificationRuleRepository
+	notificationAggregate     repository.NotificationAggregateRepository
 	buildEvent                repository.BuildEventRepository
 	kubeEvent                 repository.KubeEventRepository
 	projectUsage              repository.ProjectUsageRepository
@@ -47,6 +50,11 @@ type TestRepository struct {
 	tag                       repository.TagRepository
 	stack                     repository.StackRepository
 	monitor                   repository.MonitorTestResultRepository
+	opaPolicyBundle           repository.OPAPolicyBundleRepository
+	imageScan                 repository.ImageScanRepository
+	registryRetention         repository.RegistryRetentionRepository
+	envGroupImportSource      repository.EnvGroupImportSourceRepository
+	envGroupRetention         repository.EnvGroupRetentionRepository
 	apiContractRevision       repository.APIContractRevisioner
 	awsAssumeRoleChainer      repository.AWSAssumeRoleChainer
 	porterApp                 repository.PorterAppRepository
@@ -57,6 +65,8 @@ type TestRepository struct {
 	githubWebhook             repository.GithubWebhookRepository
 	datastore                 repository.DatastoreRepository
 	appInstance               repository.AppInstanceRepository
+	workerJob                 repository.WorkerJobRepository
+	workerJobSchedule         repository.WorkerJobScheduleRepository
 }
 
 func (t *TestRepository) User() repository.UserRepository {
@@ -167,6 +177,10 @@ func (t *TestRepository) SlackIntegration() repository.SlackIntegrationRepositor
 	return t.slackIntegration
 }
 
+func (t *TestRepository) NotificationWebhookIntegration() repository.NotificationWebhookIntegrationRepository {
+	return t.webhookIntegration
+}
+
 func (t *TestRepository) NotificationConfig() repository.NotificationConfigRepository {
 	return t.notificationConfig
 }
@@ -175,6 +189,14 @@ func (t *TestRepository) JobNotificationConfig() repository.JobNotificationConfi
 	return t.jobNotificationConfig
 }
 
+func (t *TestRepository) NotificationRule() repository.NotificationRuleRepository {
+	return t.notificationRule
+}
+
+func (t *TestRepository) NotificationAggregate() repository.NotificationAggregateRepository {
+	return t.notificationAggregate
+}
+
 func (t *TestRepository) BuildEvent() repository.BuildEventRepository {
 	return t.buildEvent
 }
@@ -227,6 +249,26 @@ func (t *TestRepository) MonitorTestResult() repository.MonitorTestResultReposit
 	return t.monitor
 }
 
+func (t *TestRepository) OPAPolicyBundle() repository.OPAPolicyBundleRepository {
+	return t.opaPolicyBundle
+}
+
+func (t *TestRepository) ImageScan() repository.ImageScanRepository {
+	return t.imageScan
+}
+
+func (t *TestRepository) RegistryRetention() repository.RegistryRetentionRepository {
+	return t.registryRetention
+}
+
+func (t *TestRepository) EnvGroupImportSource() repository.EnvGroupImportSourceRepository {
+	return t.envGroupImportSource
+}
+
+func (t *TestRepository) EnvGroupRetention() repository.EnvGroupRetentionRepository {
+	return t.envGroupRetention
+}
+
 func (t *TestRepository) APIContractRevisioner() repository.APIContractRevisioner {
 	return t.apiContractRevision
 }
@@ -273,6 +315,16 @@ func (t *TestRepository) AppInstance() repository.AppInstanceRepository {
 	return t.appInstance
 }
 
+// WorkerJob returns a test WorkerJobRepository
+func (t *TestRepository) WorkerJob() repository.WorkerJobRepository {
+	return t.workerJob
+}
+
+// WorkerJobSchedule returns a test WorkerJobScheduleRepository
+func (t *TestRepository) WorkerJobSchedule() repository.WorkerJobScheduleRepository {
+	return t.workerJobSchedule
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(canQuery bool, failingMethods ...string) repository.Repository {
@@ -304,8 +356,11 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		gitlabIntegration:         NewGitlabIntegrationRepository(canQuery),
 		gitlabAppOAuthIntegration: NewGitlabAppOAuthIntegrationRepository(canQuery),
 		slackIntegration:          NewSlackIntegrationRepository(canQuery),
+		webhookIntegration:        NewNotificationWebhookIntegrationRepository(canQuery),
 		notificationConfig:        NewNotificationConfigRepository(canQuery),
 		jobNotificationConfig:     NewJobNotificationConfigRepository(canQuery),
+		notificationRule:          NewNotificationRuleRepository(canQuery),
+		notificationAggregate:     NewNotificationAggregateRepository(canQuery),
 		buildEvent:                NewBuildEventRepository(canQuery),
 		kubeEvent:                 NewKubeEventRepository(canQuery),
 		projectUsage:              NewProjectUsageRepository(canQuery),
@@ -319,6 +374,11 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		tag:                       NewTagRepository(),
 		stack:                     NewStackRepository(),
 		monitor:                   NewMonitorTestResultRepository(canQuery),
+		opaPolicyBundle:           NewOPAPolicyBundleRepository(canQuery),
+		imageScan:                 NewImageScanRepository(canQuery),
+		registryRetention:         NewRegistryRetentionRepository(canQuery),
+		envGroupImportSource:      NewEnvGroupImportSourceRepository(canQuery),
+		envGroupRetention:         NewEnvGroupRetentionRepository(canQuery),
 		apiContractRevision:       NewAPIContractRevisioner(),
 		awsAssumeRoleChainer:      NewAWSAssumeRoleChainer(),
 		porterApp:                 NewPorterAppRepository(canQuery, failingMethods...),
@@ -329,5 +389,7 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		githubWebhook:             NewGithubWebhookRepository(),
 		datastore:                 NewDatastoreRepository(),
 		appInstance:               NewAppInstanceRepository(),
+		workerJob:                 NewWorkerJobRepository(),
+		workerJobSchedule:         NewWorkerJobScheduleRepository(),
 	}
 }
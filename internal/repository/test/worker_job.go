@@ -0,0 +1,76 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// WorkerJobRepository is a test repository that implements repository.WorkerJobRepository
+type WorkerJobRepository struct {
+	canQuery bool
+}
+
+// NewWorkerJobRepository returns the test WorkerJobRepository
+func NewWorkerJobRepository() repository.WorkerJobRepository {
+	return &WorkerJobRepository{canQuery: false}
+}
+
+// Insert persists a new job run in the queue
+func (repo *WorkerJobRepository) Insert(ctx context.Context, job *models.WorkerJob) (*models.WorkerJob, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// Get returns a job run by its id
+func (repo *WorkerJobRepository) Get(ctx context.Context, id uuid.UUID) (*models.WorkerJob, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// Lease atomically claims the next runnable job on behalf of the given worker
+func (repo *WorkerJobRepository) Lease(ctx context.Context, workerID string, leaseTimeout time.Duration) (*models.WorkerJob, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// Complete marks a leased job as succeeded
+func (repo *WorkerJobRepository) Complete(ctx context.Context, job *models.WorkerJob) (*models.WorkerJob, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// Fail records a failed attempt of a leased job
+func (repo *WorkerJobRepository) Fail(ctx context.Context, job *models.WorkerJob, runErr error, retryAt time.Time) (*models.WorkerJob, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// CountPending returns the number of jobs which are queued, running or waiting to be retried
+func (repo *WorkerJobRepository) CountPending(ctx context.Context) (int64, error) {
+	return 0, errors.New("cannot read database")
+}
+
+// Release returns a leased job to the queue without counting the attempt
+func (repo *WorkerJobRepository) Release(ctx context.Context, job *models.WorkerJob) (*models.WorkerJob, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// RenewLease extends the lease of a job which is still running on behalf of the given worker
+func (repo *WorkerJobRepository) RenewLease(ctx context.Context, id uuid.UUID, workerID string) error {
+	return errors.New("cannot write database")
+}
+
+// SaveCheckpoint persists the progress of a running job
+func (repo *WorkerJobRepository) SaveCheckpoint(ctx context.Context, id uuid.UUID, checkpoint []byte) error {
+	return errors.New("cannot write database")
+}
+
+// Cancel marks a job which has not finished yet as canceled
+func (repo *WorkerJobRepository) Cancel(ctx context.Context, id uuid.UUID) (*models.WorkerJob, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// List returns a slice of WorkerJobs, sorted by created_at descending
+func (repo *WorkerJobRepository) List(ctx context.Context, filters ...repository.WorkerJobFilters) ([]*models.WorkerJob, error) {
+	return nil, errors.New("cannot read database")
+}
@@ -0,0 +1,30 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// WorkerJobScheduleRepository is a test repository that implements repository.WorkerJobScheduleRepository
+type WorkerJobScheduleRepository struct {
+	canQuery bool
+}
+
+// NewWorkerJobScheduleRepository returns the test WorkerJobScheduleRepository
+func NewWorkerJobScheduleRepository() repository.WorkerJobScheduleRepository {
+	return &WorkerJobScheduleRepository{canQuery: false}
+}
+
+// List returns the schedules of all jobs which have been scheduled at least once
+func (repo *WorkerJobScheduleRepository) List(ctx context.Context) ([]*models.WorkerJobSchedule, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// EnqueueScheduledRun enqueues job as the run of its schedule at tick
+func (repo *WorkerJobScheduleRepository) EnqueueScheduledRun(ctx context.Context, job *models.WorkerJob, tick time.Time) (*models.WorkerJob, error) {
+	return nil, errors.New("cannot write database")
+}
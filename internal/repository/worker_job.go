@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// WorkerJobFilter is used to filter the WorkerJobs
+type WorkerJobFilter struct {
+	Status models.WorkerJobStatus
+	JobID  string
+	Limit  int
+}
+
+// WorkerJobFilters is a function that applies filters to the WorkerJobs
+type WorkerJobFilters func(*WorkerJobFilter)
+
+// WithWorkerJobStatus filters the WorkerJobs by status
+func WithWorkerJobStatus(status models.WorkerJobStatus) WorkerJobFilters {
+	return func(f *WorkerJobFilter) {
+		f.Status = status
+	}
+}
+
+// WithWorkerJobID filters the WorkerJobs by the string ID of the job, such as "recommender"
+func WithWorkerJobID(jobID string) WorkerJobFilters {
+	return func(f *WorkerJobFilter) {
+		f.JobID = jobID
+	}
+}
+
+// WithWorkerJobLimit limits the number of WorkerJobs returned
+func WithWorkerJobLimit(limit int) WorkerJobFilters {
+	return func(f *WorkerJobFilter) {
+		f.Limit = limit
+	}
+}
+
+// WorkerJobRepository represents the set of queries on the WorkerJob model
+type WorkerJobRepository interface {
+	// Insert persists a new job run in the queue
+	Insert(ctx context.Context, job *models.WorkerJob) (*models.WorkerJob, error)
+	// Get returns a job run by its id
+	Get(ctx context.Context, id uuid.UUID) (*models.WorkerJob, error)
+	// Lease atomically claims the next runnable job on behalf of the given worker. Jobs which have been running for longer
+	// than leaseTimeout are considered abandoned and may be leased again. Runs of a job ID which is already running under
+	// a live lease are not leased, so that the same job never runs twice at the same time. Returns nil if there is no runnable job.
+	Lease(ctx context.Context, workerID string, leaseTimeout time.Duration) (*models.WorkerJob, error)
+	// Complete marks a leased job as succeeded, persisting its captured logs. A job which was canceled while
+	// running keeps its canceled status.
+	Complete(ctx context.Context, job *models.WorkerJob) (*models.WorkerJob, error)
+	// Fail records a failed attempt of a leased job, persisting its captured logs. The job is scheduled to run again
+	// at retryAt if it has attempts remaining, otherwise it is moved to the dead-letter list. A job which was canceled
+	// while running keeps its canceled status.
+	Fail(ctx context.Context, job *models.WorkerJob, runErr error, retryAt time.Time) (*models.WorkerJob, error)
+	// Release returns a leased job to the queue without counting the attempt, keeping its checkpoint. This is used
+	// when a job is interrupted by a shutdown of the workers binary. A job which was canceled while running keeps its
+	// canceled status.
+	Release(ctx context.Context, job *models.WorkerJob) (*models.WorkerJob, error)
+	// RenewLease extends the lease of a job which is still running on behalf of the given worker, so that it is not considered
+	// abandoned while it runs for longer than the lease timeout
+	RenewLease(ctx context.Context, id uuid.UUID, workerID string) error
+	// SaveCheckpoint persists the progress of a running job
+	SaveCheckpoint(ctx context.Context, id uuid.UUID, checkpoint []byte) error
+	// Cancel marks a job which has not finished yet as canceled. Finished jobs are returned unchanged.
+	Cancel(ctx context.Context, id uuid.UUID) (*models.WorkerJob, error)
+	// CountPending returns the number of jobs which are queued, running or waiting to be retried
+	CountPending(ctx context.Context) (int64, error)
+	// List returns a slice of WorkerJobs, sorted by created_at descending
+	List(ctx context.Context, filters ...WorkerJobFilters) ([]*models.WorkerJob, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// WorkerJobScheduleRepository represents the set of queries on the WorkerJobSchedule model
+type WorkerJobScheduleRepository interface {
+	// List returns the schedules of all jobs which have been scheduled at least once
+	List(ctx context.Context) ([]*models.WorkerJobSchedule, error)
+	// EnqueueScheduledRun enqueues job as the run of its schedule at tick. A Postgres advisory lock on the job ID
+	// guarantees that only one replica claims a given tick. Returns nil if no run was enqueued, either because the
+	// tick was already claimed or because a previous run of the same job is still queued or running.
+	EnqueueScheduledRun(ctx context.Context, job *models.WorkerJob, tick time.Time) (*models.WorkerJob, error)
+}
@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// Checkpointer lets a job persist its progress, so that a later attempt of the same job run
+// can resume where an interrupted attempt left off, e.g. after the workers binary restarted
+type Checkpointer interface {
+	// Checkpoint returns the progress saved by a previous attempt, or nil if there is none
+	Checkpoint() []byte
+
+	// SaveCheckpoint persists the progress of the current attempt
+	SaveCheckpoint(ctx context.Context, data []byte) error
+}
+
+type checkpointerKey struct{}
+
+// WithCheckpointer returns a copy of ctx which carries the given Checkpointer
+func WithCheckpointer(ctx context.Context, checkpointer Checkpointer) context.Context {
+	return context.WithValue(ctx, checkpointerKey{}, checkpointer)
+}
+
+// CheckpointerFromContext returns the Checkpointer carried by ctx. If ctx does not carry one,
+// a Checkpointer which never has a saved checkpoint and discards new ones is returned.
+func CheckpointerFromContext(ctx context.Context) Checkpointer {
+	if checkpointer, ok := ctx.Value(checkpointerKey{}).(Checkpointer); ok && checkpointer != nil {
+		return checkpointer
+	}
+
+	return noopCheckpointer{}
+}
+
+type noopCheckpointer struct{}
+
+func (noopCheckpointer) Checkpoint() []byte { return nil }
+
+func (noopCheckpointer) SaveCheckpoint(ctx context.Context, data []byte) error { return nil }
+
+// storeCheckpointer saves checkpoints of a leased job in the job store
+type storeCheckpointer struct {
+	mu     sync.Mutex
+	store  repository.WorkerJobRepository
+	record *models.WorkerJob
+}
+
+func (c *storeCheckpointer) Checkpoint() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.record.Checkpoint
+}
+
+func (c *storeCheckpointer) SaveCheckpoint(ctx context.Context, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.store.SaveCheckpoint(ctx, c.record.ID, data); err != nil {
+		return err
+	}
+
+	c.record.Checkpoint = data
+
+	return nil
+}
@@ -2,35 +2,115 @@ package worker
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
 )
 
+// shutdownReleaseGracePeriod is how long Shutdown waits for interrupted jobs to return
+const shutdownReleaseGracePeriod = 10 * time.Second
+
+// JobFactory creates a runnable Job from a job record that was leased
+// from the job store
+type JobFactory func(ctx context.Context, record *models.WorkerJob) (Job, error)
+
+// DispatcherOpts holds the options for leasing and retrying persisted jobs
+type DispatcherOpts struct {
+	// Store is the persistent queue that jobs are leased from
+	Store repository.WorkerJobRepository
+
+	// Factory builds runnable jobs out of leased job records
+	Factory JobFactory
+
+	// RetryPolicy determines how many times and how often failed jobs are retried
+	RetryPolicy RetryPolicy
+
+	// PollInterval is how long the dispatcher waits before polling the store
+	// again when no job is runnable
+	PollInterval time.Duration
+
+	// LeaseTimeout is how long a job may go without renewing its lease before it is
+	// considered abandoned, e.g. because the process running it crashed. Running jobs
+	// renew their lease periodically, so it does not bound how long a job may run
+	LeaseTimeout time.Duration
+}
+
 // Dispatcher is responsible to maintain a global worker pool
-// and to dispatch jobs to the underlying workers, in random order
+// and to dispatch jobs leased from the job store to the underlying workers
 type Dispatcher struct {
 	maxWorkers int
 	exitChan   chan bool
+	uuid       uuid.UUID
+	opts       DispatcherOpts
+
+	// running holds the cancel functions of the jobs currently run by this dispatcher
+	running   map[uuid.UUID]context.CancelFunc
+	runningMu sync.Mutex
+
+	// inFlight tracks leased jobs which have not returned yet, so that a shutdown can drain them
+	inFlight     sync.WaitGroup
+	shuttingDown atomic.Bool
 
 	WorkerPool chan chan Job
 }
 
 // NewDispatcher creates a new instance of Dispatcher with
 // the given number of workers that should be in the worker pool
-func NewDispatcher(maxWorkers int) *Dispatcher {
+func NewDispatcher(maxWorkers int, opts DispatcherOpts) *Dispatcher {
 	pool := make(chan chan Job, maxWorkers)
+
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+
+	if opts.LeaseTimeout <= 0 {
+		opts.LeaseTimeout = time.Hour
+	}
+
 	return &Dispatcher{
 		maxWorkers: maxWorkers,
 		exitChan:   make(chan bool),
+		uuid:       uuid.New(),
+		opts:       opts,
+		running:    make(map[uuid.UUID]context.CancelFunc),
 
 		WorkerPool: pool,
 	}
 }
 
-// Run creates workers in the worker pool with the given
-// job queue and starts the workers
-func (d *Dispatcher) Run(ctx context.Context, jobQueue chan Job) error {
+// Enqueue persists a job run with the given string ID and JSON input
+// so that it can be leased by any dispatcher sharing the same job store
+func (d *Dispatcher) Enqueue(ctx context.Context, id string, input []byte) (*models.WorkerJob, error) {
+	return d.opts.Store.Insert(ctx, d.newJobRecord(id, input))
+}
+
+func (d *Dispatcher) newJobRecord(id string, input []byte) *models.WorkerJob {
+	return &models.WorkerJob{
+		JobID:       id,
+		Input:       input,
+		MaxAttempts: d.opts.RetryPolicy.MaxAttempts,
+	}
+}
+
+// Run creates workers in the worker pool and starts dispatching
+// jobs leased from the job store to them
+func (d *Dispatcher) Run(ctx context.Context) error {
+	if d.opts.Store == nil {
+		return fmt.Errorf("dispatcher requires a job store")
+	}
+
+	if d.opts.Factory == nil {
+		return fmt.Errorf("dispatcher requires a job factory")
+	}
+
 	go func() {
 		var workers []*Worker
 
@@ -50,18 +130,24 @@ func (d *Dispatcher) Run(ctx context.Context, jobQueue chan Job) error {
 			worker.Start(ctx)
 		}
 
+		stopWorkers := func() {
+			for _, w := range workers {
+				w.Stop()
+			}
+		}
+
 		for {
 			select {
-			case job := <-jobQueue:
-				go func() {
-					workerJobChan := <-d.WorkerPool
-					workerJobChan <- job
-				}()
-			case <-d.exitChan:
-				for _, w := range workers {
-					w.Stop()
+			case workerJobChan := <-d.WorkerPool:
+				job, ok := d.nextJob(ctx)
+				if !ok {
+					stopWorkers()
+					return
 				}
 
+				workerJobChan <- job
+			case <-d.exitChan:
+				stopWorkers()
 				return
 			}
 		}
@@ -74,3 +160,227 @@ func (d *Dispatcher) Run(ctx context.Context, jobQueue chan Job) error {
 func (d *Dispatcher) Exit() {
 	d.exitChan <- true
 }
+
+// Shutdown stops the dispatcher from leasing any more jobs and waits for in-flight jobs to
+// return. If ctx is done before they do, the remaining jobs are canceled and returned to the
+// queue with their checkpoints, so that the next attempt resumes where they left off.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.shuttingDown.Store(true)
+	d.Exit()
+
+	done := make(chan struct{})
+
+	go func() {
+		d.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Println("all in-flight jobs finished")
+		return nil
+	case <-ctx.Done():
+	}
+
+	log.Println("drain deadline exceeded, interrupting in-flight jobs")
+
+	d.runningMu.Lock()
+	for _, cancel := range d.running {
+		cancel()
+	}
+	d.runningMu.Unlock()
+
+	// give interrupted jobs a moment to return and release themselves to the queue. Jobs
+	// which ignore their context are picked up again once their lease expires
+	select {
+	case <-done:
+	case <-time.After(shutdownReleaseGracePeriod):
+	}
+
+	return ctx.Err()
+}
+
+// Cancel stops the job run with the given ID if it is currently run by this dispatcher, and
+// returns true if it was. Jobs run by other dispatchers notice that they were canceled in the
+// job store the next time they poll it.
+func (d *Dispatcher) Cancel(id uuid.UUID) bool {
+	d.runningMu.Lock()
+	defer d.runningMu.Unlock()
+
+	cancel, ok := d.running[id]
+	if ok {
+		cancel()
+	}
+
+	return ok
+}
+
+func (d *Dispatcher) trackRunning(id uuid.UUID, cancel context.CancelFunc) {
+	d.runningMu.Lock()
+	defer d.runningMu.Unlock()
+
+	d.running[id] = cancel
+}
+
+func (d *Dispatcher) untrackRunning(id uuid.UUID) {
+	d.runningMu.Lock()
+	defer d.runningMu.Unlock()
+
+	delete(d.running, id)
+}
+
+// watchLease polls the job store while a job is running, renewing its lease so that it is not leased again by
+// another dispatcher while it runs for longer than the lease timeout, and cancels the job if it was canceled
+// through another dispatcher
+func (d *Dispatcher) watchLease(ctx context.Context, id uuid.UUID, cancel context.CancelFunc) {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	// the lease is renewed well before it expires, so that a few failed renewals do not get the job leased again
+	renewInterval := d.opts.LeaseTimeout / 4
+	if renewInterval < d.opts.PollInterval {
+		renewInterval = d.opts.PollInterval
+	}
+
+	renewTicker := time.NewTicker(renewInterval)
+	defer renewTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-renewTicker.C:
+			if err := d.opts.Store.RenewLease(ctx, id, d.uuid.String()); err != nil {
+				log.Printf("error renewing lease of job run %s: %v", id, err)
+			}
+		case <-ticker.C:
+			record, err := d.opts.Store.Get(ctx, id)
+			if err != nil {
+				continue
+			}
+
+			if record.Status == models.WorkerJobStatus_Canceled {
+				log.Printf("job run %s was canceled, stopping it", id)
+				cancel()
+
+				return
+			}
+		}
+	}
+}
+
+// nextJob polls the job store until a job is leased or the dispatcher is
+// told to exit, in which case it returns false
+func (d *Dispatcher) nextJob(ctx context.Context) (Job, bool) {
+	for {
+		record, err := d.opts.Store.Lease(ctx, d.uuid.String(), d.opts.LeaseTimeout)
+		if err != nil {
+			log.Printf("error leasing job from store: %v", err)
+		} else if record != nil {
+			job, err := d.opts.Factory(ctx, record)
+			if err == nil && job == nil {
+				err = fmt.Errorf("no job registered with ID: %s", record.JobID)
+			}
+
+			if err == nil {
+				d.inFlight.Add(1)
+
+				return &leasedJob{Job: job, record: record, dispatcher: d}, true
+			}
+
+			log.Printf("error creating job with ID: %s. Error: %v", record.JobID, err)
+			d.recordFailure(ctx, record, err)
+
+			continue
+		}
+
+		select {
+		case <-time.After(d.opts.PollInterval):
+		case <-d.exitChan:
+			return nil, false
+		}
+	}
+}
+
+func (d *Dispatcher) recordFailure(ctx context.Context, record *models.WorkerJob, runErr error) {
+	retryAt := time.Now().UTC().Add(d.opts.RetryPolicy.Backoff(record.Attempts))
+
+	updated, err := d.opts.Store.Fail(ctx, record, runErr, retryAt)
+	if err != nil {
+		log.Printf("error recording failure of job run %s: %v", record.ID, err)
+		return
+	}
+
+	record = updated
+
+	switch record.Status {
+	case models.WorkerJobStatus_Dead:
+		log.Printf("job run %s with ID '%s' exhausted %d attempts and was moved to the dead-letter list", record.ID, record.JobID, record.Attempts)
+	case models.WorkerJobStatus_Canceled:
+		log.Printf("job run %s with ID '%s' was canceled", record.ID, record.JobID)
+	default:
+		log.Printf("job run %s with ID '%s' will be retried at %s", record.ID, record.JobID, record.RunAfter.Format(time.RFC3339))
+	}
+}
+
+// leasedJob wraps a Job built from a leased job record, and reports the
+// outcome of the run back to the job store
+type leasedJob struct {
+	Job
+
+	record     *models.WorkerJob
+	dispatcher *Dispatcher
+}
+
+// Run runs the underlying job with a cancellable context, a logger that captures its output
+// and a checkpointer, and marks the job record as succeeded or failed depending on the result
+func (j *leasedJob) Run(ctx context.Context) error {
+	defer j.dispatcher.inFlight.Done()
+
+	capture := newLogCapture(maxCapturedLogBytes)
+	logger := log.New(io.MultiWriter(log.Writer(), capture), fmt.Sprintf("[%s %s] ", j.record.JobID, j.record.ID), log.LstdFlags)
+
+	jobCtx := WithLogger(ctx, logger)
+	jobCtx = WithCheckpointer(jobCtx, &storeCheckpointer{store: j.dispatcher.opts.Store, record: j.record})
+
+	var cancel context.CancelFunc
+
+	if timeout := j.Job.Timeout(); timeout > 0 {
+		jobCtx, cancel = context.WithTimeout(jobCtx, timeout)
+	} else {
+		jobCtx, cancel = context.WithCancel(jobCtx)
+	}
+
+	defer cancel()
+
+	j.dispatcher.trackRunning(j.record.ID, cancel)
+	defer j.dispatcher.untrackRunning(j.record.ID)
+
+	go j.dispatcher.watchLease(jobCtx, j.record.ID, cancel)
+
+	runErr := j.Job.Run(jobCtx)
+
+	j.record.Logs = capture.String()
+
+	// a job interrupted by a shutdown is not at fault, so it goes back to the queue to resume from its checkpoint
+	if runErr != nil && j.dispatcher.shuttingDown.Load() && errors.Is(jobCtx.Err(), context.Canceled) {
+		if _, err := j.dispatcher.opts.Store.Release(ctx, j.record); err != nil {
+			log.Printf("error releasing interrupted job run %s: %v", j.record.ID, err)
+		} else {
+			log.Printf("job run %s was interrupted by shutdown and returned to the queue", j.record.ID)
+		}
+
+		return runErr
+	}
+
+	if runErr != nil {
+		j.dispatcher.recordFailure(ctx, j.record, runErr)
+		return runErr
+	}
+
+	if _, err := j.dispatcher.opts.Store.Complete(ctx, j.record); err != nil {
+		log.Printf("error marking job run %s as succeeded: %v", j.record.ID, err)
+	}
+
+	return nil
+}
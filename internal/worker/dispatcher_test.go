@@ -3,20 +3,47 @@ package worker
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository/test"
 	"go.uber.org/goleak"
 )
 
 func TestDispatcher(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx := context.Background()
-	jobChan := make(chan Job)
 
-	d := NewDispatcher(10)
-	err := d.Run(ctx, jobChan)
+	d := NewDispatcher(10, DispatcherOpts{
+		Store: test.NewWorkerJobRepository(),
+		Factory: func(ctx context.Context, record *models.WorkerJob) (Job, error) {
+			return nil, nil
+		},
+		PollInterval: 10 * time.Millisecond,
+	})
+	err := d.Run(ctx)
 	if err != nil {
 		panic(err)
 	}
 
 	d.Exit()
 }
+
+func TestDispatcherRequiresStore(t *testing.T) {
+	d := NewDispatcher(1, DispatcherOpts{})
+
+	if err := d.Run(context.Background()); err == nil {
+		t.Errorf("expected error when running dispatcher without a job store")
+	}
+}
+
+func TestDispatcherCancelUnknownJob(t *testing.T) {
+	d := NewDispatcher(1, DispatcherOpts{
+		Store: test.NewWorkerJobRepository(),
+	})
+
+	if d.Cancel(uuid.New()) {
+		t.Errorf("expected cancelling a job that is not running to return false")
+	}
+}
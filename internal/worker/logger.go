@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// maxCapturedLogBytes is the maximum amount of log output retained for a single job run
+const maxCapturedLogBytes = 64 * 1024
+
+type loggerKey struct{}
+
+// WithLogger returns a copy of ctx which carries the given job-scoped logger
+func WithLogger(ctx context.Context, logger *log.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the job-scoped logger carried by ctx. Jobs should log through this logger so that
+// their output is captured in the job record. If ctx does not carry a logger, the standard logger is returned.
+func LoggerFromContext(ctx context.Context) *log.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*log.Logger); ok && logger != nil {
+		return logger
+	}
+
+	return log.Default()
+}
+
+// logCapture is an io.Writer which retains the last maxBytes written to it
+type logCapture struct {
+	mu       sync.Mutex
+	buf      []byte
+	maxBytes int
+}
+
+func newLogCapture(maxBytes int) *logCapture {
+	return &logCapture{maxBytes: maxBytes}
+}
+
+// Write implements io.Writer
+func (c *logCapture) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.buf = append(c.buf, p...)
+
+	if len(c.buf) > c.maxBytes {
+		c.buf = c.buf[len(c.buf)-c.maxBytes:]
+	}
+
+	return len(p), nil
+}
+
+// String returns the retained log output
+func (c *logCapture) String() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return string(c.buf)
+}
@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestLoggerFromContext(t *testing.T) {
+	capture := newLogCapture(maxCapturedLogBytes)
+	logger := log.New(capture, "", 0)
+
+	ctx := WithLogger(context.Background(), logger)
+	LoggerFromContext(ctx).Printf("backed up revision %d", 3)
+
+	if got := capture.String(); got != "backed up revision 3\n" {
+		t.Errorf("expected captured output to be the logged line but got: %q", got)
+	}
+
+	if LoggerFromContext(context.Background()) != log.Default() {
+		t.Errorf("expected the standard logger for a context without a job-scoped logger")
+	}
+}
+
+func TestLogCaptureRetainsTail(t *testing.T) {
+	capture := newLogCapture(10)
+
+	capture.Write([]byte(strings.Repeat("a", 8)))
+	capture.Write([]byte("bcdef"))
+
+	if got := capture.String(); got != "aaaaabcdef" {
+		t.Errorf("expected captured output to be the last 10 bytes written but got: %q", got)
+	}
+}
@@ -0,0 +1,40 @@
+package worker
+
+import "time"
+
+// RetryPolicy determines how failed jobs are retried before being
+// moved to the dead-letter list
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times a job is run, including the first attempt
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, which doubles on every subsequent retry
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between retries
+	MaxDelay time.Duration
+}
+
+// Backoff returns how long to wait before retrying a job which has failed
+// the given number of attempts, using exponential backoff
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	if attempts < 1 || p.BaseDelay <= 0 {
+		return p.BaseDelay
+	}
+
+	delay := p.BaseDelay
+
+	for i := 1; i < attempts; i += 1 {
+		delay *= 2
+
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+
+	return delay
+}
@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   30 * time.Second,
+		MaxDelay:    5 * time.Minute,
+	}
+
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 0, expected: 30 * time.Second},
+		{attempts: 1, expected: 30 * time.Second},
+		{attempts: 2, expected: time.Minute},
+		{attempts: 3, expected: 2 * time.Minute},
+		{attempts: 4, expected: 4 * time.Minute},
+		{attempts: 5, expected: 5 * time.Minute},
+		{attempts: 50, expected: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Backoff(tt.attempts); got != tt.expected {
+			t.Errorf("expected backoff after %d attempts to be %s but got: %s", tt.attempts, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/robfig/cron/v3"
+	"sigs.k8s.io/yaml"
+)
+
+// ScheduleConfig maps job IDs to the schedule on which they should be enqueued
+type ScheduleConfig map[string]ScheduleConfigJob
+
+// ScheduleConfigJob is the schedule of a single job
+type ScheduleConfigJob struct {
+	// Schedule is a standard 5-field cron expression, or a descriptor such as "@hourly", evaluated in UTC
+	Schedule string `json:"schedule"`
+
+	// Input is the JSON payload the job is enqueued with
+	Input map[string]interface{} `json:"input"`
+}
+
+// LoadScheduleConfig reads a schedule config from the YAML file at the given path
+func LoadScheduleConfig(path string) (ScheduleConfig, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := make(ScheduleConfig)
+
+	if err := yaml.Unmarshal(fileBytes, &config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// ScheduleStatus describes the schedule of a single job
+type ScheduleStatus struct {
+	JobID           string     `json:"job_id"`
+	Schedule        string     `json:"schedule"`
+	NextRunAt       time.Time  `json:"next_run_at"`
+	LastScheduledAt *time.Time `json:"last_scheduled_at,omitempty"`
+	LastRunID       string     `json:"last_run_id,omitempty"`
+}
+
+type scheduleEntry struct {
+	jobID    string
+	spec     string
+	schedule cron.Schedule
+	input    []byte
+	next     time.Time
+}
+
+// Scheduler enqueues jobs on the dispatcher according to a schedule config. Any number of
+// replicas may run a scheduler with the same config: each scheduled run is only enqueued by a
+// single replica, and a run is skipped while a previous run of the same job is still in flight.
+type Scheduler struct {
+	dispatcher *Dispatcher
+	store      repository.WorkerJobScheduleRepository
+	entries    []*scheduleEntry
+	exitChan   chan bool
+}
+
+// NewScheduler creates a new instance of Scheduler for the given schedule config
+func NewScheduler(config ScheduleConfig, dispatcher *Dispatcher, store repository.WorkerJobScheduleRepository) (*Scheduler, error) {
+	if dispatcher == nil {
+		return nil, fmt.Errorf("scheduler requires a dispatcher")
+	}
+
+	if store == nil {
+		return nil, fmt.Errorf("scheduler requires a schedule store")
+	}
+
+	entries := make([]*scheduleEntry, 0, len(config))
+
+	for jobID, job := range config {
+		schedule, err := cron.ParseStandard(job.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q for job with ID %s: %w", job.Schedule, jobID, err)
+		}
+
+		var input []byte
+
+		if job.Input != nil {
+			input, err = json.Marshal(job.Input)
+			if err != nil {
+				return nil, fmt.Errorf("invalid input for job with ID %s: %w", jobID, err)
+			}
+		}
+
+		entries = append(entries, &scheduleEntry{
+			jobID:    jobID,
+			spec:     job.Schedule,
+			schedule: schedule,
+			input:    input,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].jobID < entries[j].jobID
+	})
+
+	return &Scheduler{
+		dispatcher: dispatcher,
+		store:      store,
+		entries:    entries,
+		exitChan:   make(chan bool),
+	}, nil
+}
+
+// Run spawns a goroutine which enqueues jobs as their schedules come due
+func (s *Scheduler) Run(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	for _, entry := range s.entries {
+		entry.next = entry.schedule.Next(now)
+
+		log.Printf("scheduling job ID '%s' with schedule '%s', next run at %s", entry.jobID, entry.spec, entry.next.Format(time.RFC3339))
+	}
+
+	go func() {
+		for {
+			timer := time.NewTimer(s.untilNextTick(time.Now().UTC()))
+
+			select {
+			case <-timer.C:
+				s.enqueueDue(ctx, time.Now().UTC())
+			case <-s.exitChan:
+				timer.Stop()
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Exit instructs the scheduler to stop enqueueing jobs
+func (s *Scheduler) Exit() {
+	s.exitChan <- true
+}
+
+// Status returns the next and last run times of every scheduled job
+func (s *Scheduler) Status(ctx context.Context) ([]ScheduleStatus, error) {
+	records, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byJobID := make(map[string]*models.WorkerJobSchedule, len(records))
+
+	for _, record := range records {
+		byJobID[record.JobID] = record
+	}
+
+	now := time.Now().UTC()
+	res := make([]ScheduleStatus, 0, len(s.entries))
+
+	for _, entry := range s.entries {
+		status := ScheduleStatus{
+			JobID:     entry.jobID,
+			Schedule:  entry.spec,
+			NextRunAt: entry.schedule.Next(now),
+		}
+
+		if record, ok := byJobID[entry.jobID]; ok {
+			status.LastScheduledAt = record.LastScheduledAt
+
+			if record.LastRunID != uuid.Nil {
+				status.LastRunID = record.LastRunID.String()
+			}
+		}
+
+		res = append(res, status)
+	}
+
+	return res, nil
+}
+
+func (s *Scheduler) untilNextTick(now time.Time) time.Duration {
+	// with no schedules, wake up once in a while only to check for exit
+	next := now.Add(time.Hour)
+
+	for _, entry := range s.entries {
+		if entry.next.Before(next) {
+			next = entry.next
+		}
+	}
+
+	if next.Before(now) {
+		return 0
+	}
+
+	return next.Sub(now)
+}
+
+func (s *Scheduler) enqueueDue(ctx context.Context, now time.Time) {
+	for _, entry := range s.entries {
+		if entry.next.After(now) {
+			continue
+		}
+
+		tick := entry.next
+		entry.next = entry.schedule.Next(now)
+
+		record, err := s.store.EnqueueScheduledRun(ctx, s.dispatcher.newJobRecord(entry.jobID, entry.input), tick)
+		if err != nil {
+			log.Printf("error enqueueing scheduled run of job ID '%s': %v", entry.jobID, err)
+			continue
+		}
+
+		if record == nil {
+			log.Printf("skipping scheduled run of job ID '%s' at %s: already claimed or still in flight", entry.jobID, tick.Format(time.RFC3339))
+			continue
+		}
+
+		log.Printf("enqueued scheduled run %s of job ID '%s'", record.ID, entry.jobID)
+	}
+}
@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/repository/test"
+	"go.uber.org/goleak"
+)
+
+func TestLoadScheduleConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.yaml")
+
+	err := os.WriteFile(path, []byte(`
+recommender:
+  schedule: "*/15 * * * *"
+  input:
+    priority: low
+helm-revisions-count-tracker:
+  schedule: "@hourly"
+`), 0o600)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	config, err := LoadScheduleConfig(path)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(config) != 2 {
+		t.Fatalf("expected 2 scheduled jobs but got %d", len(config))
+	}
+
+	if config["recommender"].Input["priority"] != "low" {
+		t.Errorf("expected recommender input to be loaded")
+	}
+}
+
+func TestNewSchedulerRejectsInvalidSchedule(t *testing.T) {
+	d := NewDispatcher(1, DispatcherOpts{Store: test.NewWorkerJobRepository()})
+
+	_, err := NewScheduler(ScheduleConfig{
+		"recommender": {Schedule: "every fifteen minutes"},
+	}, d, test.NewWorkerJobScheduleRepository())
+	if err == nil {
+		t.Errorf("expected error for invalid cron expression")
+	}
+}
+
+func TestSchedulerUntilNextTick(t *testing.T) {
+	d := NewDispatcher(1, DispatcherOpts{Store: test.NewWorkerJobRepository()})
+
+	s, err := NewScheduler(ScheduleConfig{
+		"recommender":                  {Schedule: "*/15 * * * *"},
+		"helm-revisions-count-tracker": {Schedule: "@hourly"},
+	}, d, test.NewWorkerJobScheduleRepository())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	now := time.Date(2023, 10, 1, 12, 5, 0, 0, time.UTC)
+
+	for _, entry := range s.entries {
+		entry.next = entry.schedule.Next(now)
+	}
+
+	if got := s.untilNextTick(now); got != 10*time.Minute {
+		t.Errorf("expected next tick in 10m but got: %s", got)
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	d := NewDispatcher(1, DispatcherOpts{Store: test.NewWorkerJobRepository()})
+
+	s, err := NewScheduler(ScheduleConfig{
+		"recommender": {Schedule: "@every 1h"},
+	}, d, test.NewWorkerJobScheduleRepository())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := s.Run(context.Background()); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	s.Exit()
+}
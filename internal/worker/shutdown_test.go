@@ -0,0 +1,170 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"go.uber.org/goleak"
+)
+
+// memoryJobStore is an in-memory repository.WorkerJobRepository holding a single job
+type memoryJobStore struct {
+	mu  sync.Mutex
+	job *models.WorkerJob
+}
+
+func (s *memoryJobStore) Insert(ctx context.Context, job *models.WorkerJob) (*models.WorkerJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job.ID = uuid.New()
+	job.Status = models.WorkerJobStatus_Queued
+	s.job = job
+
+	return job, nil
+}
+
+func (s *memoryJobStore) Get(ctx context.Context, id uuid.UUID) (*models.WorkerJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *s.job
+
+	return &copied, nil
+}
+
+func (s *memoryJobStore) Lease(ctx context.Context, workerID string, leaseTimeout time.Duration) (*models.WorkerJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.job == nil || s.job.Status != models.WorkerJobStatus_Queued {
+		return nil, nil
+	}
+
+	s.job.Status = models.WorkerJobStatus_Running
+	s.job.Attempts += 1
+	copied := *s.job
+
+	return &copied, nil
+}
+
+func (s *memoryJobStore) save(job *models.WorkerJob) (*models.WorkerJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *job
+	s.job = &copied
+
+	return job, nil
+}
+
+func (s *memoryJobStore) Complete(ctx context.Context, job *models.WorkerJob) (*models.WorkerJob, error) {
+	job.Status = models.WorkerJobStatus_Succeeded
+	return s.save(job)
+}
+
+func (s *memoryJobStore) Fail(ctx context.Context, job *models.WorkerJob, runErr error, retryAt time.Time) (*models.WorkerJob, error) {
+	job.Status = models.WorkerJobStatus_Dead
+	return s.save(job)
+}
+
+func (s *memoryJobStore) Release(ctx context.Context, job *models.WorkerJob) (*models.WorkerJob, error) {
+	job.Status = models.WorkerJobStatus_Queued
+	job.Attempts -= 1
+	return s.save(job)
+}
+
+func (s *memoryJobStore) RenewLease(ctx context.Context, id uuid.UUID, workerID string) error {
+	return nil
+}
+
+func (s *memoryJobStore) SaveCheckpoint(ctx context.Context, id uuid.UUID, checkpoint []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.job.Checkpoint = checkpoint
+
+	return nil
+}
+
+func (s *memoryJobStore) Cancel(ctx context.Context, id uuid.UUID) (*models.WorkerJob, error) {
+	return nil, nil
+}
+
+func (s *memoryJobStore) CountPending(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (s *memoryJobStore) List(ctx context.Context, filters ...repository.WorkerJobFilters) ([]*models.WorkerJob, error) {
+	return nil, nil
+}
+
+// sweepJob saves a checkpoint and then blocks until its context is canceled
+type sweepJob struct {
+	started chan struct{}
+}
+
+func (j *sweepJob) ID() string             { return "sweep" }
+func (j *sweepJob) EnqueueTime() time.Time { return time.Now() }
+func (j *sweepJob) Timeout() time.Duration { return 0 }
+func (j *sweepJob) SetData([]byte)         {}
+func (j *sweepJob) Run(ctx context.Context) error {
+	if err := CheckpointerFromContext(ctx).SaveCheckpoint(ctx, []byte(`{"completed_cluster_ids":[1,2]}`)); err != nil {
+		return err
+	}
+
+	close(j.started)
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func TestDispatcherShutdownReleasesInterruptedJobs(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	store := &memoryJobStore{}
+	job := &sweepJob{started: make(chan struct{})}
+
+	d := NewDispatcher(1, DispatcherOpts{
+		Store: store,
+		Factory: func(ctx context.Context, record *models.WorkerJob) (Job, error) {
+			return job, nil
+		},
+		PollInterval: 10 * time.Millisecond,
+	})
+
+	if _, err := d.Enqueue(context.Background(), "sweep", nil); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := d.Run(context.Background()); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	<-job.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := d.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected drain deadline to be exceeded but got: %v", err)
+	}
+
+	// let the dispatcher stop its worker once the interrupted job returned
+	time.Sleep(50 * time.Millisecond)
+
+	record, _ := store.Get(context.Background(), uuid.Nil)
+
+	if record.Status != models.WorkerJobStatus_Queued || record.Attempts != 0 {
+		t.Errorf("expected interrupted job to be released without counting the attempt, got status %s and %d attempts", record.Status, record.Attempts)
+	}
+
+	if string(record.Checkpoint) != `{"completed_cluster_ids":[1,2]}` {
+		t.Errorf("expected checkpoint to be kept but got: %s", record.Checkpoint)
+	}
+}
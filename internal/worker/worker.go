@@ -20,6 +20,10 @@ type Job interface {
 	// The main logic and control of a job
 	Run(ctx context.Context) error
 
+	// The maximum duration of a single attempt of a job, after which the context
+	// passed to Run is canceled. A zero duration means the job has no timeout
+	Timeout() time.Duration
+
 	// To set external data if a job needs it
 	SetData([]byte)
 }
@@ -0,0 +1,292 @@
+package yamlschema
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Draft is the JSON Schema draft generated schemas conform to
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema document describing a YAML file
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	ID          string `json:"$id,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Type       string             `json:"type,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+
+	// AdditionalProperties is false for structs, and the schema of the values for maps
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+
+	Items *Schema     `json:"items,omitempty"`
+	Enum  []string    `json:"enum,omitempty"`
+	Const interface{} `json:"const,omitempty"`
+	OneOf []*Schema   `json:"oneOf,omitempty"`
+	AllOf []*Schema   `json:"allOf,omitempty"`
+	If    *Schema     `json:"if,omitempty"`
+	Then  *Schema     `json:"then,omitempty"`
+	Not   *Schema     `json:"not,omitempty"`
+
+	// Rules are the conditional rules of an object. They are also expressed as JSON Schema
+	// conditions in AllOf, but are kept to report violations with readable messages.
+	Rules []Rule `json:"-"`
+
+	// order is the order of the properties in the Go struct
+	order []string
+}
+
+// RuleKind is the kind of a conditional rule between two fields of an object
+type RuleKind string
+
+const (
+	// RuleKind_RequiredIf requires the field when the other field has the given value
+	RuleKind_RequiredIf RuleKind = "required_if"
+	// RuleKind_ExcludedIf forbids the field when the other field has the given value
+	RuleKind_ExcludedIf RuleKind = "excluded_if"
+	// RuleKind_ExcludedUnless forbids the field unless the other field has the given value
+	RuleKind_ExcludedUnless RuleKind = "excluded_unless"
+)
+
+// Rule is a conditional rule between two fields of an object, generated from the validate tag of
+// a struct field. Rules are only evaluated when the other field is set.
+type Rule struct {
+	Kind  RuleKind
+	Field string
+	Other string
+	Value string
+}
+
+// Generator generates JSON Schemas from Go types, using the yaml and validate struct tags
+type Generator struct {
+	overrides map[reflect.Type]*Schema
+}
+
+func NewGenerator() *Generator {
+	return &Generator{
+		overrides: make(map[reflect.Type]*Schema),
+	}
+}
+
+// Override sets the schema of a type. It is used for types with custom YAML unmarshalers.
+func (g *Generator) Override(t reflect.Type, schema *Schema) {
+	g.overrides[t] = schema
+}
+
+// Generate returns the schema of the type of v
+func (g *Generator) Generate(v interface{}) *Schema {
+	schema := g.schemaOf(reflect.TypeOf(v))
+	schema.Schema = Draft
+
+	return schema
+}
+
+func (g *Generator) schemaOf(t reflect.Type) *Schema {
+	if schema, ok := g.overrides[t]; ok {
+		return schema
+	}
+
+	if t.Kind() == reflect.Ptr {
+		return g.schemaOf(t.Elem())
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		schema := &Schema{
+			Type:                 "object",
+			Properties:           make(map[string]*Schema),
+			AdditionalProperties: false,
+		}
+
+		g.addFields(schema, t)
+
+		for _, rule := range schema.Rules {
+			schema.AllOf = append(schema.AllOf, ruleSchema(rule))
+		}
+
+		return schema
+	case reflect.Slice, reflect.Array:
+		return &Schema{
+			Type:  "array",
+			Items: g.schemaOf(t.Elem()),
+		}
+	case reflect.Map:
+		return &Schema{
+			Type:                 "object",
+			AdditionalProperties: g.schemaOf(t.Elem()),
+		}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	default:
+		return &Schema{}
+	}
+}
+
+// addFields adds the fields of a struct to an object schema, including the fields of inlined structs
+func (g *Generator) addFields(schema *Schema, t reflect.Type) {
+	names := make(map[string]string)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, inline, skip := yamlName(field)
+
+		if skip || inline {
+			continue
+		}
+
+		names[field.Name] = name
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, inline, skip := yamlName(field)
+
+		if skip {
+			continue
+		}
+
+		if inline {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+
+			g.addFields(schema, fieldType)
+
+			continue
+		}
+
+		property := g.schemaOf(field.Type)
+
+		for _, tag := range strings.Split(field.Tag.Get("validate"), ",") {
+			tag = strings.TrimSpace(tag)
+			key, param, _ := strings.Cut(tag, "=")
+
+			switch key {
+			case "required":
+				schema.Required = append(schema.Required, name)
+			case "oneof":
+				// copy the property so that enums do not leak into overridden or shared schemas
+				enum := *property
+				enum.Enum = strings.Fields(param)
+				property = &enum
+			case string(RuleKind_RequiredIf), string(RuleKind_ExcludedIf), string(RuleKind_ExcludedUnless):
+				other, value, _ := strings.Cut(param, " ")
+
+				if otherName, ok := names[other]; ok {
+					schema.Rules = append(schema.Rules, Rule{
+						Kind:  RuleKind(key),
+						Field: name,
+						Other: otherName,
+						Value: strings.TrimSpace(value),
+					})
+				}
+			}
+		}
+
+		schema.Properties[name] = property
+		schema.order = append(schema.order, name)
+	}
+}
+
+// yamlName returns the key of a struct field in YAML, following the conventions of gopkg.in/yaml
+func yamlName(field reflect.StructField) (name string, inline bool, skip bool) {
+	if field.PkgPath != "" && !field.Anonymous {
+		return "", false, true
+	}
+
+	tag := field.Tag.Get("yaml")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+
+	for _, flag := range parts[1:] {
+		if flag == "inline" {
+			return "", true, false
+		}
+	}
+
+	if parts[0] != "" {
+		return parts[0], false, false
+	}
+
+	return strings.ToLower(field.Name), false, false
+}
+
+// ruleSchema expresses a rule as a JSON Schema condition
+func ruleSchema(rule Rule) *Schema {
+	otherIs := &Schema{
+		Properties: map[string]*Schema{rule.Other: {Const: rule.Value}},
+		Required:   []string{rule.Other},
+	}
+
+	switch rule.Kind {
+	case RuleKind_RequiredIf:
+		return &Schema{If: otherIs, Then: &Schema{Required: []string{rule.Field}}}
+	case RuleKind_ExcludedIf:
+		return &Schema{If: otherIs, Then: &Schema{Not: &Schema{Required: []string{rule.Field}}}}
+	default:
+		return &Schema{
+			If: &Schema{
+				Properties: map[string]*Schema{rule.Other: {Not: &Schema{Const: rule.Value}}},
+				Required:   []string{rule.Other},
+			},
+			Then: &Schema{Not: &Schema{Required: []string{rule.Field}}},
+		}
+	}
+}
+
+// Partial returns a copy of the schema in which no field is required, for files that override parts of another file.
+// Rules that forbid fields are kept.
+func (s *Schema) Partial() *Schema {
+	if s == nil {
+		return nil
+	}
+
+	partial := *s
+	partial.Required = nil
+	partial.Rules = nil
+	partial.AllOf = nil
+
+	for _, rule := range s.Rules {
+		if rule.Kind == RuleKind_RequiredIf {
+			continue
+		}
+
+		partial.Rules = append(partial.Rules, rule)
+		partial.AllOf = append(partial.AllOf, ruleSchema(rule))
+	}
+
+	if s.Properties != nil {
+		partial.Properties = make(map[string]*Schema, len(s.Properties))
+
+		for name, property := range s.Properties {
+			partial.Properties[name] = property.Partial()
+		}
+	}
+
+	if additional, ok := s.AdditionalProperties.(*Schema); ok {
+		partial.AdditionalProperties = additional.Partial()
+	}
+
+	partial.Items = s.Items.Partial()
+	partial.OneOf = nil
+
+	for _, alternative := range s.OneOf {
+		partial.OneOf = append(partial.OneOf, alternative.Partial())
+	}
+
+	return &partial
+}
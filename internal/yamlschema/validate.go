@@ -0,0 +1,387 @@
+package yamlschema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FieldError is a violation of a schema at a position of a YAML file
+type FieldError struct {
+	Line   int
+	Column int
+
+	// Path is the path of the offending field, e.g. services[0].ramMegabytes
+	Path string
+
+	Message string
+}
+
+func (e FieldError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	}
+
+	return fmt.Sprintf("line %d, column %d: %s: %s", e.Line, e.Column, e.Path, e.Message)
+}
+
+// ValidationError lists every violation of a schema in a YAML file, in the order they appear in the file
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Errors))
+
+	for _, err := range e.Errors {
+		lines = append(lines, err.Error())
+	}
+
+	problems := "problems"
+	if len(e.Errors) == 1 {
+		problems = "problem"
+	}
+
+	return fmt.Sprintf("found %d %s:\n  %s", len(e.Errors), problems, strings.Join(lines, "\n  "))
+}
+
+// Validate checks a YAML file against a schema. It reports unknown fields, type mismatches, values
+// outside of an enum, missing required fields and violated rules. Returns a *ValidationError if the
+// file does not conform to the schema.
+func Validate(data []byte, schema *Schema) error {
+	doc := &yaml.Node{}
+
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return err
+	}
+
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	v := &validator{}
+	v.validate(doc.Content[0], schema, "")
+
+	if len(v.errors) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(v.errors, func(i, j int) bool {
+		if v.errors[i].Line != v.errors[j].Line {
+			return v.errors[i].Line < v.errors[j].Line
+		}
+
+		return v.errors[i].Column < v.errors[j].Column
+	})
+
+	return &ValidationError{Errors: v.errors}
+}
+
+type validator struct {
+	errors []FieldError
+}
+
+func (v *validator) addError(node *yaml.Node, path string, format string, args ...interface{}) {
+	v.errors = append(v.errors, FieldError{
+		Line:    node.Line,
+		Column:  node.Column,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) validate(node *yaml.Node, schema *Schema, path string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	// an explicit null leaves the field unset
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	if len(schema.OneOf) > 0 {
+		v.validateOneOf(node, schema, path)
+		return
+	}
+
+	switch schema.Type {
+	case "object":
+		v.validateObject(node, schema, path)
+	case "array":
+		if node.Kind != yaml.SequenceNode {
+			v.addError(node, path, "expected a list, got %s", describe(node))
+			return
+		}
+
+		for i, item := range node.Content {
+			v.validate(item, schema.Items, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case "string", "integer", "number", "boolean":
+		v.validateScalar(node, schema, path)
+	}
+}
+
+// validateOneOf validates the node against the first alternative it matches. If it matches none, the
+// errors of the alternative of the same type are reported.
+func (v *validator) validateOneOf(node *yaml.Node, schema *Schema, path string) {
+	var sameType *Schema
+
+	for _, alternative := range schema.OneOf {
+		sub := &validator{}
+		sub.validate(node, alternative, path)
+
+		if len(sub.errors) == 0 {
+			return
+		}
+
+		if sameType == nil && kindMatches(node, alternative.Type) {
+			sameType = alternative
+		}
+	}
+
+	if sameType != nil {
+		v.validate(node, sameType, path)
+		return
+	}
+
+	types := make([]string, 0, len(schema.OneOf))
+
+	for _, alternative := range schema.OneOf {
+		types = append(types, article(alternative.Type))
+	}
+
+	v.addError(node, path, "expected %s, got %s", strings.Join(types, " or "), describe(node))
+}
+
+func (v *validator) validateObject(node *yaml.Node, schema *Schema, path string) {
+	if node.Kind != yaml.MappingNode {
+		v.addError(node, path, "expected an object, got %s", describe(node))
+		return
+	}
+
+	keys := make(map[string]*yaml.Node)
+	values := make(map[string]*yaml.Node)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		// merge keys are resolved by the YAML parser
+		if key.Tag == "!!merge" {
+			continue
+		}
+
+		fieldPath := joinPath(path, key.Value)
+
+		if _, ok := keys[key.Value]; ok {
+			v.addError(key, fieldPath, "duplicate field %q", key.Value)
+			continue
+		}
+
+		keys[key.Value] = key
+		values[key.Value] = value
+
+		if property, ok := schema.Properties[key.Value]; ok {
+			v.validate(value, property, fieldPath)
+			continue
+		}
+
+		if additional, ok := schema.AdditionalProperties.(*Schema); ok {
+			v.validate(value, additional, fieldPath)
+			continue
+		}
+
+		if schema.Properties == nil {
+			continue
+		}
+
+		if suggestion := suggest(key.Value, schema); suggestion != "" {
+			v.addError(key, fieldPath, "unknown field %q, did you mean %q?", key.Value, suggestion)
+		} else {
+			v.addError(key, fieldPath, "unknown field %q", key.Value)
+		}
+	}
+
+	for _, name := range schema.Required {
+		if value, ok := values[name]; !ok || isNull(value) {
+			v.addError(node, path, "missing required field %q", name)
+		}
+	}
+
+	for _, rule := range schema.Rules {
+		other, ok := values[rule.Other]
+		if !ok || isNull(other) {
+			continue
+		}
+
+		value, present := values[rule.Field]
+		present = present && !isNull(value)
+		matches := other.Kind == yaml.ScalarNode && other.Value == rule.Value
+
+		switch {
+		case rule.Kind == RuleKind_RequiredIf && matches && !present:
+			v.addError(node, path, "missing field %q, which is required when %s is %s", rule.Field, rule.Other, rule.Value)
+		case rule.Kind == RuleKind_ExcludedIf && matches && present:
+			v.addError(keys[rule.Field], joinPath(path, rule.Field), "not allowed when %s is %s", rule.Other, rule.Value)
+		case rule.Kind == RuleKind_ExcludedUnless && !matches && present:
+			v.addError(keys[rule.Field], joinPath(path, rule.Field), "only allowed when %s is %s", rule.Other, rule.Value)
+		}
+	}
+}
+
+func (v *validator) validateScalar(node *yaml.Node, schema *Schema, path string) {
+	if !kindMatches(node, schema.Type) {
+		v.addError(node, path, "expected %s, got %s", article(schema.Type), describe(node))
+		return
+	}
+
+	if len(schema.Enum) == 0 {
+		return
+	}
+
+	for _, value := range schema.Enum {
+		if node.Value == value {
+			return
+		}
+	}
+
+	v.addError(node, path, "invalid value %q, must be one of: %s", node.Value, strings.Join(schema.Enum, ", "))
+}
+
+// kindMatches returns true if the node has the JSON Schema type. Like gopkg.in/yaml.v2, which is used
+// to decode porter.yaml, any scalar is accepted as a string, and YAML 1.1 booleans are accepted.
+func kindMatches(node *yaml.Node, schemaType string) bool {
+	switch schemaType {
+	case "object":
+		return node.Kind == yaml.MappingNode
+	case "array":
+		return node.Kind == yaml.SequenceNode
+	case "string":
+		return node.Kind == yaml.ScalarNode
+	case "integer":
+		return node.Kind == yaml.ScalarNode && node.Tag == "!!int"
+	case "number":
+		return node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!float")
+	case "boolean":
+		return node.Kind == yaml.ScalarNode && (node.Tag == "!!bool" || isYAML11Bool(node))
+	default:
+		return true
+	}
+}
+
+func isYAML11Bool(node *yaml.Node) bool {
+	if node.Tag != "!!str" || node.Style != 0 {
+		return false
+	}
+
+	switch strings.ToLower(node.Value) {
+	case "y", "yes", "n", "no", "on", "off":
+		return true
+	default:
+		return false
+	}
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
+
+func describe(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "an object"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+
+	switch node.Tag {
+	case "!!int":
+		return fmt.Sprintf("integer %s", node.Value)
+	case "!!float":
+		return fmt.Sprintf("number %s", node.Value)
+	case "!!bool":
+		return fmt.Sprintf("boolean %s", node.Value)
+	default:
+		return fmt.Sprintf("string %q", node.Value)
+	}
+}
+
+func article(schemaType string) string {
+	switch schemaType {
+	case "object":
+		return "an object"
+	case "array":
+		return "a list"
+	case "integer":
+		return "an integer"
+	default:
+		return "a " + schemaType
+	}
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+
+	return path + "." + field
+}
+
+// suggest returns the known property closest to an unknown field, if it is likely a typo
+func suggest(field string, schema *Schema) string {
+	best := ""
+	bestDistance := 3
+
+	for _, name := range schema.order {
+		if strings.EqualFold(name, field) {
+			return name
+		}
+
+		if d := levenshtein(strings.ToLower(field), strings.ToLower(name)); d < bestDistance {
+			best = name
+			bestDistance = d
+		}
+	}
+
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func min(values ...int) int {
+	res := values[0]
+
+	for _, v := range values[1:] {
+		if v < res {
+			res = v
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,172 @@
+package yamlschema
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testEnv []string
+
+type testService struct {
+	Name         string            `yaml:"name"`
+	Type         string            `yaml:"type,omitempty" validate:"omitempty,oneof=web worker job"`
+	RamMegabytes int               `yaml:"ramMegabytes,omitempty"`
+	CpuCores     float32           `yaml:"cpuCores,omitempty"`
+	Private      *bool             `yaml:"private,omitempty" validate:"excluded_unless=Type web"`
+	Cron         string            `yaml:"cron,omitempty" validate:"excluded_unless=Type job"`
+	Annotations  map[string]string `yaml:"annotations,omitempty"`
+}
+
+type testBuild struct {
+	Method     string `yaml:"method" validate:"required,oneof=pack docker"`
+	Dockerfile string `yaml:"dockerfile,omitempty" validate:"required_if=Method docker"`
+}
+
+type testApp struct {
+	Name     string        `yaml:"name"`
+	Services []testService `yaml:"services"`
+	Build    *testBuild    `yaml:"build,omitempty"`
+	Env      testEnv       `yaml:"env,omitempty"`
+}
+
+type testFile struct {
+	testApp  `yaml:",inline"`
+	Previews *testApp `yaml:"previews,omitempty"`
+}
+
+func testSchema() *Schema {
+	g := NewGenerator()
+	g.Override(reflect.TypeOf(testEnv{}), &Schema{
+		OneOf: []*Schema{
+			{Type: "array", Items: &Schema{Type: "string"}},
+			{Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+		},
+	})
+
+	return g.Generate(testFile{})
+}
+
+func TestValidateAcceptsValidFile(t *testing.T) {
+	err := Validate([]byte(`
+name: app
+services:
+  - name: web
+    type: web
+    ramMegabytes: 256
+    cpuCores: 0.5
+    private: yes
+    annotations:
+      foo: bar
+build:
+  method: docker
+  dockerfile: ./Dockerfile
+env:
+  PORT: 8080
+previews:
+  env:
+    - FOO
+`), testSchema())
+	if err != nil {
+		t.Fatalf("expected file to be valid, got %v", err)
+	}
+}
+
+func TestValidateReportsProblemsWithPositions(t *testing.T) {
+	err := Validate([]byte(`name: app
+services:
+  - name: web
+    type: web
+    ramMegabyte: 256
+  - name: worker
+    type: wrker
+    cpuCores: lots
+  - name: job
+    type: job
+    private: true
+build:
+  method: docker
+env: 3
+`), testSchema())
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+
+	expected := []string{
+		`line 5, column 5: services[0].ramMegabyte: unknown field "ramMegabyte", did you mean "ramMegabytes"?`,
+		`line 7, column 11: services[1].type: invalid value "wrker", must be one of: web, worker, job`,
+		`line 8, column 15: services[1].cpuCores: expected a number, got string "lots"`,
+		`line 11, column 5: services[2].private: only allowed when type is web`,
+		`line 13, column 3: build: missing field "dockerfile", which is required when method is docker`,
+		`line 14, column 6: env: expected a list or an object, got integer 3`,
+	}
+
+	if len(validationErr.Errors) != len(expected) {
+		t.Fatalf("expected %d problems, got:\n%v", len(expected), err)
+	}
+
+	for i, fieldErr := range validationErr.Errors {
+		if fieldErr.Error() != expected[i] {
+			t.Errorf("expected problem %q, got %q", expected[i], fieldErr.Error())
+		}
+	}
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	raw, err := json.Marshal(testSchema())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	schema := string(raw)
+
+	for _, expected := range []string{
+		`"$schema":"http://json-schema.org/draft-07/schema#"`,
+		`"additionalProperties":false`,
+		`"enum":["web","worker","job"]`,
+		`"required":["method"]`,
+		`{"if":{"properties":{"method":{"const":"docker"}},"required":["method"]},"then":{"required":["dockerfile"]}}`,
+		`{"if":{"properties":{"type":{"not":{"const":"web"}}},"required":["type"]},"then":{"not":{"required":["private"]}}}`,
+	} {
+		if !strings.Contains(schema, expected) {
+			t.Errorf("expected schema to contain %s", expected)
+		}
+	}
+
+	// inlined fields are properties of the parent object
+	if _, ok := testSchema().Properties["services"]; !ok {
+		t.Errorf("expected inlined field services to be a top-level property")
+	}
+}
+
+func TestPartialSchemaRequiresNoFields(t *testing.T) {
+	partial := testSchema().Properties["previews"].Partial()
+
+	err := Validate([]byte(`
+build:
+  dockerfile: ./Dockerfile
+services:
+  - name: web
+    type: job
+    private: true
+`), partial)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+
+	// required fields are dropped, rules forbidding fields are kept
+	expected := `line 7, column 5: services[0].private: only allowed when type is web`
+	if len(validationErr.Errors) != 1 || validationErr.Errors[0].Error() != expected {
+		t.Fatalf("expected only problem %q, got:\n%v", expected, err)
+	}
+
+	if len(testSchema().Properties["build"].Required) == 0 {
+		t.Errorf("expected the original schema to be left unchanged")
+	}
+}
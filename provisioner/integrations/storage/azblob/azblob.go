@@ -0,0 +1,251 @@
+package azblob
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/provisioner/integrations/storage"
+)
+
+const (
+	// apiVersion is the version of the Blob service REST API used by the client
+	apiVersion = "2021-08-06"
+
+	// storageScope is the scope of the tokens used to authenticate against the Blob service
+	storageScope = "https://storage.azure.com/.default"
+)
+
+// AzureBlobStorageClient stores files as block blobs in an Azure storage container, authenticating as a service
+// principal which must have the Storage Blob Data Contributor role on the container
+type AzureBlobStorageClient struct {
+	credential    azcore.TokenCredential
+	httpClient    *http.Client
+	endpoint      *url.URL
+	containerName string
+	encryptionKey *[32]byte
+}
+
+type AzureBlobOptions struct {
+	AzureTenantID      string
+	AzureClientID      string
+	AzureClientSecret  string
+	StorageAccountName string
+	ContainerName      string
+	EncryptionKey      *[32]byte
+}
+
+func NewAzureBlobStorageClient(opts *AzureBlobOptions) (*AzureBlobStorageClient, error) {
+	if opts.StorageAccountName == "" || opts.ContainerName == "" {
+		return nil, fmt.Errorf("storage account name and container name cannot be empty")
+	}
+
+	cred, err := azidentity.NewClientSecretCredential(opts.AzureTenantID, opts.AzureClientID, opts.AzureClientSecret, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create Azure credential: %v", err)
+	}
+
+	return &AzureBlobStorageClient{
+		credential:    cred,
+		httpClient:    &http.Client{Timeout: time.Minute},
+		endpoint:      &url.URL{Scheme: "https", Host: fmt.Sprintf("%s.blob.core.windows.net", opts.StorageAccountName)},
+		containerName: opts.ContainerName,
+		encryptionKey: opts.EncryptionKey,
+	}, nil
+}
+
+func (s *AzureBlobStorageClient) WriteFile(infra *models.Infra, name string, fileBytes []byte, shouldEncrypt bool) error {
+	return s.WriteFileWithKey(fileBytes, shouldEncrypt, getKeyFromInfra(infra, name))
+}
+
+func (s *AzureBlobStorageClient) WriteFileWithKey(fileBytes []byte, shouldEncrypt bool, key string) error {
+	body := fileBytes
+	var err error
+	if shouldEncrypt {
+		body, err = encryption.Encrypt(fileBytes, s.encryptionKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := s.do(http.MethodPut, s.blobURL(key), bytes.NewReader(body), map[string]string{
+		"x-ms-blob-type": "BlockBlob",
+		"Content-Type":   "application/octet-stream",
+	})
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return responseError(resp)
+	}
+
+	return nil
+}
+
+func (s *AzureBlobStorageClient) ReadFile(infra *models.Infra, name string, shouldDecrypt bool) ([]byte, error) {
+	return s.ReadFileWithKey(getKeyFromInfra(infra, name), shouldDecrypt)
+}
+
+func (s *AzureBlobStorageClient) ReadFileWithKey(key string, shouldDecrypt bool) ([]byte, error) {
+	resp, err := s.do(http.MethodGet, s.blobURL(key), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, storage.FileDoesNotExist
+	default:
+		return nil, responseError(resp)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if shouldDecrypt {
+		return encryption.Decrypt(data, s.encryptionKey)
+	}
+
+	return data, nil
+}
+
+// listBlobsResult is the subset of the response of the List Blobs operation read by the client
+type listBlobsResult struct {
+	Blobs []struct {
+		Name string `xml:"Name"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+func (s *AzureBlobStorageClient) ListFilesWithPrefix(prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	marker := ""
+
+	for {
+		query := url.Values{}
+		query.Set("restype", "container")
+		query.Set("comp", "list")
+		query.Set("prefix", prefix)
+
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		listURL := s.containerURL()
+		listURL.RawQuery = query.Encode()
+
+		resp, err := s.do(http.MethodGet, listURL, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err = responseError(resp)
+			resp.Body.Close()
+			return nil, err
+		}
+
+		result := &listBlobsResult{}
+		err = xml.NewDecoder(resp.Body).Decode(result)
+		resp.Body.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse blob list: %v", err)
+		}
+
+		for _, blob := range result.Blobs {
+			keys = append(keys, blob.Name)
+		}
+
+		if result.NextMarker == "" {
+			return keys, nil
+		}
+
+		marker = result.NextMarker
+	}
+}
+
+func (s *AzureBlobStorageClient) DeleteFile(infra *models.Infra, name string) error {
+	resp, err := s.do(http.MethodDelete, s.blobURL(getKeyFromInfra(infra, name)), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNotFound {
+		return responseError(resp)
+	}
+
+	return nil
+}
+
+func (s *AzureBlobStorageClient) containerURL() *url.URL {
+	return &url.URL{
+		Scheme: s.endpoint.Scheme,
+		Host:   s.endpoint.Host,
+		Path:   "/" + s.containerName,
+	}
+}
+
+func (s *AzureBlobStorageClient) blobURL(key string) *url.URL {
+	blobURL := s.containerURL()
+	blobURL.Path += "/" + key
+
+	return blobURL
+}
+
+func (s *AzureBlobStorageClient) do(method string, reqURL *url.URL, body io.Reader, headers map[string]string) (*http.Response, error) {
+	ctx := context.Background()
+
+	token, err := s.credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{storageScope}})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Azure storage token: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+	req.Header.Set("x-ms-version", apiVersion)
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	return s.httpClient.Do(req)
+}
+
+func responseError(resp *http.Response) error {
+	code := resp.Header.Get("x-ms-error-code")
+	if code == "" {
+		code = http.StatusText(resp.StatusCode)
+	}
+
+	return fmt.Errorf("azure blob request failed with status %d: %s", resp.StatusCode, code)
+}
+
+func getKeyFromInfra(infra *models.Infra, name string) string {
+	return fmt.Sprintf("%s/%s", infra.GetUniqueName(), name)
+}
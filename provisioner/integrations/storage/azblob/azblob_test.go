@@ -0,0 +1,262 @@
+package azblob
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/provisioner/integrations/storage"
+)
+
+const (
+	testContainer = "revisions"
+	testToken     = "test-token"
+)
+
+type staticCredential struct {
+	token string
+}
+
+func (c staticCredential) GetToken(ctx context.Context, opts policy.TokenRequestOptions) (*azcore.AccessToken, error) {
+	return &azcore.AccessToken{Token: c.token, ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// fakeBlobService serves the subset of the Blob service REST API used by the client, storing blobs in memory
+type fakeBlobService struct {
+	mu       sync.Mutex
+	blobs    map[string][]byte
+	pageSize int
+}
+
+func (f *fakeBlobService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+testToken || r.Header.Get("x-ms-version") != apiVersion {
+		writeError(w, http.StatusForbidden, "AuthenticationFailed")
+		return
+	}
+
+	if r.URL.Path == "/"+testContainer && r.URL.Query().Get("comp") == "list" {
+		f.list(w, r)
+		return
+	}
+
+	name, ok := strings.CutPrefix(r.URL.Path, "/"+testContainer+"/")
+	if !ok {
+		writeError(w, http.StatusNotFound, "ContainerNotFound")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		if r.Header.Get("x-ms-blob-type") != "BlockBlob" {
+			writeError(w, http.StatusBadRequest, "InvalidHeaderValue")
+			return
+		}
+
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidInput")
+			return
+		}
+
+		f.blobs[name] = data
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet:
+		data, ok := f.blobs[name]
+		if !ok {
+			writeError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		if _, ok := f.blobs[name]; !ok {
+			writeError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+
+		delete(f.blobs, name)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "UnsupportedHttpVerb")
+	}
+}
+
+func (f *fakeBlobService) list(w http.ResponseWriter, r *http.Request) {
+	names := make([]string, 0)
+
+	for name := range f.blobs {
+		if strings.HasPrefix(name, r.URL.Query().Get("prefix")) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("marker"))
+	end := len(names)
+
+	res := &listBlobsResult{}
+
+	if f.pageSize > 0 && start+f.pageSize < end {
+		end = start + f.pageSize
+		res.NextMarker = strconv.Itoa(end)
+	}
+
+	for _, name := range names[start:end] {
+		res.Blobs = append(res.Blobs, struct {
+			Name string `xml:"Name"`
+		}{Name: name})
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"EnumerationResults"`
+		*listBlobsResult
+	}{listBlobsResult: res})
+}
+
+func writeError(w http.ResponseWriter, code int, errorCode string) {
+	w.Header().Set("x-ms-error-code", errorCode)
+	w.WriteHeader(code)
+}
+
+func newTestClient(t *testing.T, fake *fakeBlobService) *AzureBlobStorageClient {
+	t.Helper()
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	endpoint, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error parsing server url: %v", err)
+	}
+
+	key := [32]byte{}
+	copy(key[:], "__random_strong_encryption_key__")
+
+	return &AzureBlobStorageClient{
+		credential:    staticCredential{token: testToken},
+		httpClient:    server.Client(),
+		endpoint:      endpoint,
+		containerName: testContainer,
+		encryptionKey: &key,
+	}
+}
+
+func TestNewAzureBlobStorageClientRequiresContainer(t *testing.T) {
+	_, err := NewAzureBlobStorageClient(&AzureBlobOptions{
+		AzureTenantID:     "tenant",
+		AzureClientID:     "client",
+		AzureClientSecret: "secret",
+	})
+	if err == nil {
+		t.Fatalf("expected an error for an empty storage account and container")
+	}
+}
+
+func TestReadWriteFileWithKey(t *testing.T) {
+	fake := &fakeBlobService{blobs: make(map[string][]byte)}
+	client := newTestClient(t, fake)
+
+	data := []byte("apiVersion: v1\nkind: ConfigMap\n")
+
+	if err := client.WriteFileWithKey(data, true, "1/2/default/web/1"); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+
+	if stored := fake.blobs["1/2/default/web/1"]; len(stored) == 0 || bytes.Equal(stored, data) {
+		t.Errorf("expected stored blob to be encrypted, got %q", stored)
+	}
+
+	got, err := client.ReadFileWithKey("1/2/default/web/1", true)
+	if err != nil {
+		t.Fatalf("unexpected error reading file: %v", err)
+	}
+
+	if !bytes.Equal(got, data) {
+		t.Errorf("expected file contents %q, got %q", data, got)
+	}
+}
+
+func TestReadFileWithKeyErrors(t *testing.T) {
+	client := newTestClient(t, &fakeBlobService{blobs: make(map[string][]byte)})
+
+	if _, err := client.ReadFileWithKey("1/2/default/web/1", false); !errors.Is(err, storage.FileDoesNotExist) {
+		t.Fatalf("expected error %v, got %v", storage.FileDoesNotExist, err)
+	}
+
+	client.credential = staticCredential{token: "invalid"}
+
+	_, err := client.ReadFileWithKey("1/2/default/web/1", false)
+	if err == nil || errors.Is(err, storage.FileDoesNotExist) || !strings.Contains(err.Error(), "AuthenticationFailed") {
+		t.Fatalf("expected a request error with the error code of the response, got %v", err)
+	}
+}
+
+func TestListFilesWithPrefix(t *testing.T) {
+	fake := &fakeBlobService{
+		blobs: map[string][]byte{
+			"1/2/default/web/1":        nil,
+			"1/2/default/web/2":        nil,
+			"1/2/default/web/3":        nil,
+			"1/2/default/web-worker/1": nil,
+		},
+		pageSize: 1,
+	}
+
+	client := newTestClient(t, fake)
+
+	got, err := client.ListFilesWithPrefix("1/2/default/web/")
+	if err != nil {
+		t.Fatalf("unexpected error listing files: %v", err)
+	}
+
+	expected := []string{"1/2/default/web/1", "1/2/default/web/2", "1/2/default/web/3"}
+
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected keys %v from all pages, got %v", expected, got)
+	}
+}
+
+func TestDeleteFile(t *testing.T) {
+	fake := &fakeBlobService{blobs: map[string][]byte{"test-1-2-abcdef/state": []byte("state")}}
+	client := newTestClient(t, fake)
+
+	infra := &models.Infra{
+		Kind:      "test",
+		ProjectID: 1,
+		Suffix:    "abcdef",
+	}
+	infra.ID = 2
+
+	if err := client.DeleteFile(infra, "state"); err != nil {
+		t.Fatalf("unexpected error deleting file: %v", err)
+	}
+
+	if _, ok := fake.blobs["test-1-2-abcdef/state"]; ok {
+		t.Fatalf("expected blob to be deleted")
+	}
+
+	// deleting a file which does not exist is not an error
+	if err := client.DeleteFile(infra, "state"); err != nil {
+		t.Fatalf("unexpected error deleting missing file: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package backend
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/provisioner/integrations/storage"
+	"github.com/porter-dev/porter/provisioner/integrations/storage/azblob"
+	"github.com/porter-dev/porter/provisioner/integrations/storage/gcs"
+	"github.com/porter-dev/porter/provisioner/integrations/storage/local"
+	"github.com/porter-dev/porter/provisioner/integrations/storage/s3"
+)
+
+// NewRevisionsStorageManager creates the storage backend selected by conf for archived helm release revisions
+func NewRevisionsStorageManager(conf *env.RevisionsStorageConf) (storage.KeyStorageManager, error) {
+	if conf.EncryptionKey == "" {
+		return nil, fmt.Errorf("encryption key cannot be empty")
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(conf.EncryptionKey) {
+		key[i] = b
+	}
+
+	switch conf.Backend {
+	case "s3":
+		return s3.NewS3StorageClient(&s3.S3Options{
+			AWSRegion:      conf.AWSRegion,
+			AWSAccessKeyID: conf.AWSAccessKeyID,
+			AWSSecretKey:   conf.AWSSecretAccessKey,
+			AWSBucketName:  conf.S3BucketName,
+			EncryptionKey:  &key,
+		})
+	case "gcs":
+		return gcs.NewGCSStorageClient(&gcs.GCSOptions{
+			CredentialsJSON: []byte(conf.GCSCredentialsJSON),
+			BucketName:      conf.GCSBucketName,
+			EncryptionKey:   &key,
+		})
+	case "azblob":
+		return azblob.NewAzureBlobStorageClient(&azblob.AzureBlobOptions{
+			AzureTenantID:      conf.AzureTenantID,
+			AzureClientID:      conf.AzureClientID,
+			AzureClientSecret:  conf.AzureClientSecret,
+			StorageAccountName: conf.AzureStorageAccountName,
+			ContainerName:      conf.AzureStorageContainerName,
+			EncryptionKey:      &key,
+		})
+	case "local":
+		return local.NewLocalStorageClient(&local.LocalOptions{
+			Dir:           conf.LocalDir,
+			EncryptionKey: &key,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported storage backend %q: must be one of s3, gcs, azblob or local", conf.Backend)
+	}
+}
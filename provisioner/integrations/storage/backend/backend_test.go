@@ -0,0 +1,116 @@
+package backend
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/provisioner/integrations/storage/local"
+)
+
+func TestNewRevisionsStorageManager(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *env.RevisionsStorageConf
+		wantErr bool
+	}{
+		{
+			name: "missing encryption key",
+			conf: &env.RevisionsStorageConf{
+				Backend:  "local",
+				LocalDir: filepath.Join(t.TempDir(), "revisions"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported backend",
+			conf: &env.RevisionsStorageConf{
+				Backend:       "ftp",
+				EncryptionKey: "__random_strong_encryption_key__",
+			},
+			wantErr: true,
+		},
+		{
+			name: "local backend without a directory",
+			conf: &env.RevisionsStorageConf{
+				Backend:       "local",
+				EncryptionKey: "__random_strong_encryption_key__",
+			},
+			wantErr: true,
+		},
+		{
+			name: "azblob backend without a container",
+			conf: &env.RevisionsStorageConf{
+				Backend:       "azblob",
+				EncryptionKey: "__random_strong_encryption_key__",
+			},
+			wantErr: true,
+		},
+		{
+			name: "local backend",
+			conf: &env.RevisionsStorageConf{
+				Backend:       "local",
+				EncryptionKey: "__random_strong_encryption_key__",
+				LocalDir:      filepath.Join(t.TempDir(), "revisions"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, err := NewRevisionsStorageManager(tt.conf)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got storage manager %T", manager)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, ok := manager.(*local.LocalStorageClient); !ok {
+				t.Fatalf("expected a local storage manager, got %T", manager)
+			}
+		})
+	}
+}
+
+func TestNewRevisionsStorageManagerEncryptsFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "revisions")
+
+	manager, err := NewRevisionsStorageManager(&env.RevisionsStorageConf{
+		Backend:       "local",
+		EncryptionKey: "__random_strong_encryption_key__",
+		LocalDir:      dir,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := manager.WriteFileWithKey([]byte("manifest"), true, "1/2/default/web/1"); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+
+	// a manager created with the same configuration decrypts the files of the previous one
+	reopened, err := NewRevisionsStorageManager(&env.RevisionsStorageConf{
+		Backend:       "local",
+		EncryptionKey: "__random_strong_encryption_key__",
+		LocalDir:      dir,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := reopened.ReadFileWithKey("1/2/default/web/1", true)
+	if err != nil {
+		t.Fatalf("unexpected error reading file: %v", err)
+	}
+
+	if string(got) != "manifest" {
+		t.Fatalf("expected file contents %q, got %q", "manifest", got)
+	}
+}
@@ -0,0 +1,132 @@
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/provisioner/integrations/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	gcs "google.golang.org/api/storage/v1"
+)
+
+type GCSStorageClient struct {
+	service       *gcs.Service
+	bucket        string
+	encryptionKey *[32]byte
+}
+
+type GCSOptions struct {
+	// CredentialsJSON is the key of a service account with access to the bucket. If empty, application
+	// default credentials are used
+	CredentialsJSON []byte
+	BucketName      string
+	EncryptionKey   *[32]byte
+}
+
+func NewGCSStorageClient(opts *GCSOptions) (*GCSStorageClient, error) {
+	var clientOpts []option.ClientOption
+	if len(opts.CredentialsJSON) != 0 {
+		clientOpts = append(clientOpts, option.WithCredentialsJSON(opts.CredentialsJSON))
+	}
+
+	service, err := gcs.NewService(context.Background(), clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create GCS service: %v", err)
+	}
+
+	return &GCSStorageClient{
+		service:       service,
+		bucket:        opts.BucketName,
+		encryptionKey: opts.EncryptionKey,
+	}, nil
+}
+
+func (s *GCSStorageClient) WriteFile(infra *models.Infra, name string, fileBytes []byte, shouldEncrypt bool) error {
+	return s.WriteFileWithKey(fileBytes, shouldEncrypt, getKeyFromInfra(infra, name))
+}
+
+func (s *GCSStorageClient) WriteFileWithKey(fileBytes []byte, shouldEncrypt bool, key string) error {
+	body := fileBytes
+	var err error
+	if shouldEncrypt {
+		body, err = encryption.Encrypt(fileBytes, s.encryptionKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = s.service.Objects.Insert(s.bucket, &gcs.Object{Name: key}).Media(bytes.NewReader(body)).Do()
+	return err
+}
+
+func (s *GCSStorageClient) ReadFile(infra *models.Infra, name string, shouldDecrypt bool) ([]byte, error) {
+	return s.ReadFileWithKey(getKeyFromInfra(infra, name), shouldDecrypt)
+}
+
+func (s *GCSStorageClient) ReadFileWithKey(key string, shouldDecrypt bool) ([]byte, error) {
+	resp, err := s.service.Objects.Get(s.bucket, key).Download()
+	if err != nil {
+		if isNotFound(err) {
+			return nil, storage.FileDoesNotExist
+		}
+
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if shouldDecrypt {
+		return encryption.Decrypt(data, s.encryptionKey)
+	}
+
+	return data, nil
+}
+
+func (s *GCSStorageClient) ListFilesWithPrefix(prefix string) ([]string, error) {
+	keys := make([]string, 0)
+
+	err := s.service.Objects.List(s.bucket).Prefix(prefix).Pages(context.Background(), func(page *gcs.Objects) error {
+		for _, object := range page.Items {
+			keys = append(keys, object.Name)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (s *GCSStorageClient) DeleteFile(infra *models.Infra, name string) error {
+	err := s.service.Objects.Delete(s.bucket, getKeyFromInfra(infra, name)).Do()
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func getKeyFromInfra(infra *models.Infra, name string) string {
+	return fmt.Sprintf("%s/%s", infra.GetUniqueName(), name)
+}
+
+func isNotFound(err error) bool {
+	var apiErr *googleapi.Error
+
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
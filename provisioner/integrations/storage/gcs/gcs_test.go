@@ -0,0 +1,257 @@
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/provisioner/integrations/storage"
+	"google.golang.org/api/option"
+
+	gcs "google.golang.org/api/storage/v1"
+)
+
+const testBucket = "revisions"
+
+// fakeGCS serves the subset of the JSON API of Cloud Storage used by the client, storing objects in memory
+type fakeGCS struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	pageSize int
+}
+
+func (f *fakeGCS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	objectsPath := "/storage/v1/b/" + testBucket + "/o"
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/upload"+objectsPath:
+		f.insert(w, r)
+	case r.Method == http.MethodGet && r.URL.Path == objectsPath:
+		f.list(w, r)
+	case strings.HasPrefix(r.URL.EscapedPath(), objectsPath+"/"):
+		name, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), objectsPath+"/"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		data, ok := f.objects[name]
+		if !ok {
+			writeError(w, http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write(data)
+		case http.MethodDelete:
+			delete(f.objects, name)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed)
+		}
+	default:
+		writeError(w, http.StatusNotFound)
+	}
+}
+
+func (f *fakeGCS) insert(w http.ResponseWriter, r *http.Request) {
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	reader := multipart.NewReader(r.Body, params["boundary"])
+
+	metadata, err := reader.NextPart()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	object := &gcs.Object{}
+	if err := json.NewDecoder(metadata).Decode(object); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	media, err := reader.NextPart()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(media)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.objects[object.Name] = data
+
+	_ = json.NewEncoder(w).Encode(object)
+}
+
+func (f *fakeGCS) list(w http.ResponseWriter, r *http.Request) {
+	names := make([]string, 0)
+
+	for name := range f.objects {
+		if strings.HasPrefix(name, r.URL.Query().Get("prefix")) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+	end := len(names)
+
+	res := &gcs.Objects{}
+
+	if f.pageSize > 0 && start+f.pageSize < end {
+		end = start + f.pageSize
+		res.NextPageToken = strconv.Itoa(end)
+	}
+
+	for _, name := range names[start:end] {
+		res.Items = append(res.Items, &gcs.Object{Name: name})
+	}
+
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func writeError(w http.ResponseWriter, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": http.StatusText(code),
+		},
+	})
+}
+
+func newTestClient(t *testing.T, fake *fakeGCS) *GCSStorageClient {
+	t.Helper()
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	service, err := gcs.NewService(
+		context.Background(),
+		option.WithEndpoint(server.URL+"/storage/v1/"),
+		option.WithHTTPClient(server.Client()),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating service: %v", err)
+	}
+
+	key := [32]byte{}
+	copy(key[:], "__random_strong_encryption_key__")
+
+	return &GCSStorageClient{
+		service:       service,
+		bucket:        testBucket,
+		encryptionKey: &key,
+	}
+}
+
+func TestReadWriteFileWithKey(t *testing.T) {
+	fake := &fakeGCS{objects: make(map[string][]byte)}
+	client := newTestClient(t, fake)
+
+	data := []byte("apiVersion: v1\nkind: ConfigMap\n")
+
+	if err := client.WriteFileWithKey(data, true, "1/2/default/web/1"); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+
+	if stored := fake.objects["1/2/default/web/1"]; len(stored) == 0 || bytes.Equal(stored, data) {
+		t.Errorf("expected stored object to be encrypted, got %q", stored)
+	}
+
+	got, err := client.ReadFileWithKey("1/2/default/web/1", true)
+	if err != nil {
+		t.Fatalf("unexpected error reading file: %v", err)
+	}
+
+	if !bytes.Equal(got, data) {
+		t.Errorf("expected file contents %q, got %q", data, got)
+	}
+}
+
+func TestReadFileWithKeyNotFound(t *testing.T) {
+	client := newTestClient(t, &fakeGCS{objects: make(map[string][]byte)})
+
+	if _, err := client.ReadFileWithKey("1/2/default/web/1", false); !errors.Is(err, storage.FileDoesNotExist) {
+		t.Fatalf("expected error %v, got %v", storage.FileDoesNotExist, err)
+	}
+}
+
+func TestListFilesWithPrefix(t *testing.T) {
+	fake := &fakeGCS{
+		objects: map[string][]byte{
+			"1/2/default/web/1":        nil,
+			"1/2/default/web/2":        nil,
+			"1/2/default/web/3":        nil,
+			"1/2/default/web-worker/1": nil,
+		},
+		pageSize: 1,
+	}
+
+	client := newTestClient(t, fake)
+
+	got, err := client.ListFilesWithPrefix("1/2/default/web/")
+	if err != nil {
+		t.Fatalf("unexpected error listing files: %v", err)
+	}
+
+	expected := []string{"1/2/default/web/1", "1/2/default/web/2", "1/2/default/web/3"}
+
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected keys %v from all pages, got %v", expected, got)
+	}
+}
+
+func TestDeleteFile(t *testing.T) {
+	fake := &fakeGCS{objects: map[string][]byte{"test-1-2-abcdef/state": []byte("state")}}
+	client := newTestClient(t, fake)
+
+	infra := &models.Infra{
+		Kind:      "test",
+		ProjectID: 1,
+		Suffix:    "abcdef",
+	}
+	infra.ID = 2
+
+	if err := client.DeleteFile(infra, "state"); err != nil {
+		t.Fatalf("unexpected error deleting file: %v", err)
+	}
+
+	if _, ok := fake.objects["test-1-2-abcdef/state"]; ok {
+		t.Fatalf("expected object to be deleted")
+	}
+
+	// deleting a file which does not exist is not an error
+	if err := client.DeleteFile(infra, "state"); err != nil {
+		t.Fatalf("unexpected error deleting missing file: %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package local
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/provisioner/integrations/storage"
+)
+
+// LocalStorageClient stores files in a directory of the local filesystem, with the keys of the files as their
+// paths relative to the directory
+type LocalStorageClient struct {
+	dir           string
+	encryptionKey *[32]byte
+}
+
+type LocalOptions struct {
+	Dir           string
+	EncryptionKey *[32]byte
+}
+
+func NewLocalStorageClient(opts *LocalOptions) (*LocalStorageClient, error) {
+	if opts.Dir == "" {
+		return nil, fmt.Errorf("storage directory cannot be empty")
+	}
+
+	err := os.MkdirAll(opts.Dir, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create storage directory: %v", err)
+	}
+
+	return &LocalStorageClient{
+		dir:           opts.Dir,
+		encryptionKey: opts.EncryptionKey,
+	}, nil
+}
+
+func (s *LocalStorageClient) WriteFile(infra *models.Infra, name string, fileBytes []byte, shouldEncrypt bool) error {
+	return s.WriteFileWithKey(fileBytes, shouldEncrypt, getKeyFromInfra(infra, name))
+}
+
+func (s *LocalStorageClient) WriteFileWithKey(fileBytes []byte, shouldEncrypt bool, key string) error {
+	filePath, err := s.getPath(key)
+	if err != nil {
+		return err
+	}
+
+	body := fileBytes
+	if shouldEncrypt {
+		body, err = encryption.Encrypt(fileBytes, s.encryptionKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.MkdirAll(filepath.Dir(filePath), 0o700)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, body, 0o600)
+}
+
+func (s *LocalStorageClient) ReadFile(infra *models.Infra, name string, shouldDecrypt bool) ([]byte, error) {
+	return s.ReadFileWithKey(getKeyFromInfra(infra, name), shouldDecrypt)
+}
+
+func (s *LocalStorageClient) ReadFileWithKey(key string, shouldDecrypt bool) ([]byte, error) {
+	filePath, err := s.getPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, storage.FileDoesNotExist
+		}
+
+		return nil, err
+	}
+
+	if shouldDecrypt {
+		return encryption.Decrypt(data, s.encryptionKey)
+	}
+
+	return data, nil
+}
+
+func (s *LocalStorageClient) ListFilesWithPrefix(prefix string) ([]string, error) {
+	keys := make([]string, 0)
+
+	err := filepath.WalkDir(s.dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dir, filePath)
+		if err != nil {
+			return err
+		}
+
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (s *LocalStorageClient) DeleteFile(infra *models.Infra, name string) error {
+	filePath, err := s.getPath(getKeyFromInfra(infra, name))
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filePath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// getPath returns the path of the file with the given key, which must not escape the storage directory
+func (s *LocalStorageClient) getPath(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+
+	if cleaned == "/" || cleaned != "/"+key {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(cleaned)), nil
+}
+
+func getKeyFromInfra(infra *models.Infra, name string) string {
+	return fmt.Sprintf("%s/%s", infra.GetUniqueName(), name)
+}
@@ -0,0 +1,225 @@
+package local
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/provisioner/integrations/storage"
+)
+
+func newTestClient(t *testing.T) *LocalStorageClient {
+	t.Helper()
+
+	key := [32]byte{}
+	copy(key[:], "__random_strong_encryption_key__")
+
+	client, err := NewLocalStorageClient(&LocalOptions{
+		Dir:           filepath.Join(t.TempDir(), "revisions"),
+		EncryptionKey: &key,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	return client
+}
+
+func TestNewLocalStorageClientRequiresDir(t *testing.T) {
+	if _, err := NewLocalStorageClient(&LocalOptions{}); err == nil {
+		t.Fatalf("expected an error for an empty storage directory")
+	}
+}
+
+func TestGetPath(t *testing.T) {
+	client := newTestClient(t)
+
+	tests := []struct {
+		key      string
+		expected string
+		valid    bool
+	}{
+		{key: "1/2/default/web/3", expected: filepath.Join(client.dir, "1", "2", "default", "web", "3"), valid: true},
+		{key: "file", expected: filepath.Join(client.dir, "file"), valid: true},
+		{key: ""},
+		{key: "/"},
+		{key: "."},
+		{key: ".."},
+		{key: "../file"},
+		{key: "1/../../file"},
+		{key: "1/2/../../../file"},
+		{key: "/etc/passwd"},
+		{key: "1//file"},
+		{key: "1/./file"},
+		{key: "1/file/"},
+	}
+
+	for _, tt := range tests {
+		got, err := client.getPath(tt.key)
+
+		if !tt.valid {
+			if err == nil {
+				t.Errorf("expected key %q to be rejected, got path %s", tt.key, got)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("unexpected error for key %q: %v", tt.key, err)
+			continue
+		}
+
+		if got != tt.expected {
+			t.Errorf("expected path %s for key %q, got %s", tt.expected, tt.key, got)
+		}
+	}
+}
+
+func TestWriteFileWithKeyRejectsPathTraversal(t *testing.T) {
+	client := newTestClient(t)
+
+	if err := client.WriteFileWithKey([]byte("data"), false, "../escaped"); err == nil {
+		t.Fatalf("expected an error writing a key outside of the storage directory")
+	}
+
+	if _, err := os.Stat(filepath.Join(filepath.Dir(client.dir), "escaped")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no file to be written outside of the storage directory, got %v", err)
+	}
+
+	if _, err := client.ReadFileWithKey("../escaped", false); err == nil {
+		t.Fatalf("expected an error reading a key outside of the storage directory")
+	}
+}
+
+func TestReadWriteFileWithKey(t *testing.T) {
+	client := newTestClient(t)
+
+	data := []byte("apiVersion: v1\nkind: ConfigMap\n")
+
+	for _, encrypt := range []bool{false, true} {
+		key := "1/2/default/web/1"
+		if encrypt {
+			key = "1/2/default/web/2"
+		}
+
+		if err := client.WriteFileWithKey(data, encrypt, key); err != nil {
+			t.Fatalf("unexpected error writing file: %v", err)
+		}
+
+		got, err := client.ReadFileWithKey(key, encrypt)
+		if err != nil {
+			t.Fatalf("unexpected error reading file: %v", err)
+		}
+
+		if !bytes.Equal(got, data) {
+			t.Errorf("expected file contents %q, got %q", data, got)
+		}
+
+		stored, err := os.ReadFile(filepath.Join(client.dir, filepath.FromSlash(key)))
+		if err != nil {
+			t.Fatalf("unexpected error reading stored file: %v", err)
+		}
+
+		if encrypt == bytes.Equal(stored, data) {
+			t.Errorf("expected stored file to be encrypted: %t, got %q", encrypt, stored)
+		}
+	}
+}
+
+func TestReadFileWithKeyNotFound(t *testing.T) {
+	client := newTestClient(t)
+
+	if _, err := client.ReadFileWithKey("1/2/default/web/1", false); !errors.Is(err, storage.FileDoesNotExist) {
+		t.Fatalf("expected error %v, got %v", storage.FileDoesNotExist, err)
+	}
+}
+
+func TestListFilesWithPrefix(t *testing.T) {
+	client := newTestClient(t)
+
+	keys := []string{
+		"1/2/default/web/1",
+		"1/2/default/web/2",
+		"1/2/default/web-worker/1",
+		"1/2/other/web/1",
+	}
+
+	for _, key := range keys {
+		if err := client.WriteFileWithKey([]byte(key), false, key); err != nil {
+			t.Fatalf("unexpected error writing file: %v", err)
+		}
+	}
+
+	tests := []struct {
+		prefix   string
+		expected []string
+	}{
+		{prefix: "1/2/default/web/", expected: []string{"1/2/default/web/1", "1/2/default/web/2"}},
+		{prefix: "1/2/default/web", expected: []string{"1/2/default/web-worker/1", "1/2/default/web/1", "1/2/default/web/2"}},
+		{prefix: "1/2/", expected: []string{"1/2/default/web-worker/1", "1/2/default/web/1", "1/2/default/web/2", "1/2/other/web/1"}},
+		{prefix: "3/", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		got, err := client.ListFilesWithPrefix(tt.prefix)
+		if err != nil {
+			t.Fatalf("unexpected error listing files: %v", err)
+		}
+
+		sort.Strings(got)
+
+		if len(got) != len(tt.expected) {
+			t.Errorf("expected keys %v for prefix %q, got %v", tt.expected, tt.prefix, got)
+			continue
+		}
+
+		for i := range got {
+			if got[i] != tt.expected[i] {
+				t.Errorf("expected keys %v for prefix %q, got %v", tt.expected, tt.prefix, got)
+				break
+			}
+		}
+	}
+}
+
+func TestInfraFiles(t *testing.T) {
+	client := newTestClient(t)
+
+	infra := &models.Infra{
+		Kind:      "test",
+		ProjectID: 1,
+		Suffix:    "abcdef",
+	}
+	infra.ID = 2
+
+	if err := client.WriteFile(infra, "state", []byte("state"), true); err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+
+	got, err := client.ReadFileWithKey("test-1-2-abcdef/state", true)
+	if err != nil {
+		t.Fatalf("unexpected error reading file by key: %v", err)
+	}
+
+	if string(got) != "state" {
+		t.Errorf("expected file contents %q, got %q", "state", got)
+	}
+
+	if err := client.DeleteFile(infra, "state"); err != nil {
+		t.Fatalf("unexpected error deleting file: %v", err)
+	}
+
+	if _, err := client.ReadFile(infra, "state", true); !errors.Is(err, storage.FileDoesNotExist) {
+		t.Fatalf("expected deleted file not to exist, got %v", err)
+	}
+
+	// deleting a file which does not exist is not an error
+	if err := client.DeleteFile(infra, "state"); err != nil {
+		t.Fatalf("unexpected error deleting missing file: %v", err)
+	}
+}
@@ -94,9 +94,13 @@ func (s *S3StorageClient) WriteFileWithKey(fileBytes []byte, shouldEncrypt bool,
 }
 
 func (s *S3StorageClient) ReadFile(infra *models.Infra, name string, shouldDecrypt bool) ([]byte, error) {
+	return s.ReadFileWithKey(getKeyFromInfra(infra, name), shouldDecrypt)
+}
+
+func (s *S3StorageClient) ReadFileWithKey(key string, shouldDecrypt bool) ([]byte, error) {
 	output, err := s.client.GetObject(&s3.GetObjectInput{
 		Bucket: &s.bucket,
-		Key:    aws.String(getKeyFromInfra(infra, name)),
+		Key:    aws.String(key),
 	})
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
@@ -127,6 +131,26 @@ func (s *S3StorageClient) ReadFile(infra *models.Infra, name string, shouldDecry
 	}
 }
 
+func (s *S3StorageClient) ListFilesWithPrefix(prefix string) ([]string, error) {
+	keys := make([]string, 0)
+
+	err := s.client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: &s.bucket,
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+
+		return !lastPage
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
 func (s *S3StorageClient) DeleteFile(infra *models.Infra, name string) error {
 	_, err := s.client.DeleteObject(&s3.DeleteObjectInput{
 		Bucket: &s.bucket,
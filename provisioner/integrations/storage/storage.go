@@ -13,3 +13,17 @@ type StorageManager interface {
 	ReadFile(infra *models.Infra, name string, shouldDecrypt bool) ([]byte, error)
 	DeleteFile(infra *models.Infra, name string) error
 }
+
+// KeyStorageManager is a StorageManager which can also address files by their full key, for files which do not
+// belong to an infra
+type KeyStorageManager interface {
+	StorageManager
+
+	WriteFileWithKey(bytes []byte, shouldEncrypt bool, key string) error
+
+	// ReadFileWithKey returns FileDoesNotExist if there is no file with the given key
+	ReadFileWithKey(key string, shouldDecrypt bool) ([]byte, error)
+
+	// ListFilesWithPrefix returns the keys of all files which start with prefix
+	ListFilesWithPrefix(prefix string) ([]string, error)
+}
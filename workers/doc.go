@@ -14,13 +14,39 @@ TERMINOLOGIES
 ARCHITECTURE
 
   - The worker pool is a Go application that takes in environment variables `MAX_WORKERS` and `MAX_QUEUE` to
-    denote the maximum number of workers and maximum number of jobs in the queue, respectively.
+    denote the maximum number of workers and maximum number of pending jobs in the queue, respectively.
+  - Enqueued jobs are persisted in the `worker_jobs` table, so queued runs survive crashes and restarts. The
+    dispatcher leases runnable jobs from the table, and running jobs renew their lease periodically. A job left
+    running by a crashed process stops renewing its lease, and is leased again once `JOB_LEASE_TIMEOUT` has passed.
+  - A job whose `Run` returns an error is retried with exponential backoff, starting at `JOB_RETRY_BASE_DELAY` and
+    capped at `JOB_RETRY_MAX_DELAY`. After `JOB_MAX_ATTEMPTS` attempts the job is marked `DEAD`, which makes up the
+    dead-letter list.
   - The worker pool has specific jobs that it can execute, written separately with their own logic flow.
   - The individual jobs need to have a unique string identifier.
   - The jobs should be registered at startup time with their respective unique identifiers for the worker pool
     to correctly relay execution information to the correct job.
   - The worker pool has an exposed HTTP POST endpoint to enqueue jobs with their IDs. Depending on the kind of job,
-    a job can expect to receive a body of JSON data in the HTTP request.
+    a job can expect to receive a body of JSON data in the HTTP request. The response contains the ID of the
+    persisted job run.
+  - Job runs can be listed with `GET /jobs` (filtered by the `status`, `job_id` and `limit` query parameters),
+    inspected with `GET /jobs/{jobID}` and canceled with `DELETE /jobs/{jobID}`. Each run records when it was
+    enqueued, started and finished, the error returned by the job and the log output the job wrote through
+    `worker.LoggerFromContext`.
+  - Jobs can be scheduled without an external cron by pointing `SCHEDULE_CONFIG_FILE` at a YAML file which maps
+    job IDs to a cron `schedule` (evaluated in UTC) and an optional `input` payload. Every replica runs the
+    scheduler, but a Postgres advisory lock on the job ID ensures each tick is enqueued once, and a tick is skipped
+    while a previous run of the same job is still queued or running. `GET /schedules` returns the next and last
+    run times of every scheduled job.
+  - Every job declares a `Timeout`, after which its run is canceled and counted as a failed attempt. On shutdown
+    the worker pool stops leasing jobs and waits up to `SHUTDOWN_DRAIN_TIMEOUT` for in-flight jobs to finish.
+    Jobs still running after that are interrupted and returned to the queue without using up an attempt. Jobs
+    can save their progress through `worker.CheckpointerFromContext`, so that the next attempt resumes where
+    the interrupted one left off.
+  - Repeats of an app notification are collapsed by the Porter server. The `notification-aggregates-flusher` job,
+    scheduled every minute, reports them in a single message and sends a resolved follow-up once a notification
+    has not repeated for `NOTIFICATION_RESOLVE_AFTER`. The daily `notification-digest` job emails a summary of
+    the notifications of the projects which opted in to the digest through SendGrid when
+    `SENDGRID_NOTIFICATION_DIGEST_TEMPLATE_ID` is set.
   - By exposing an HTTP endpoint, the worker pool can be called to enqueue jobs using crontab and other sources.
 
 */
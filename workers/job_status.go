@@ -0,0 +1,181 @@
+//go:build ee
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/worker"
+	"gorm.io/gorm"
+)
+
+// defaultJobListLimit is the number of job runs returned by GET /jobs when no limit is given
+const defaultJobListLimit = 50
+
+// JobRun is the representation of a persisted job run returned by the job status endpoints
+type JobRun struct {
+	ID              string     `json:"id"`
+	JobID           string     `json:"job_id"`
+	Status          string     `json:"status"`
+	Attempts        int        `json:"attempts"`
+	MaxAttempts     int        `json:"max_attempts"`
+	EnqueuedAt      time.Time  `json:"enqueued_at"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	DurationSeconds float64    `json:"duration_seconds,omitempty"`
+	Error           string     `json:"error,omitempty"`
+	Logs            string     `json:"logs,omitempty"`
+}
+
+func toJobRun(record *models.WorkerJob, withLogs bool) JobRun {
+	run := JobRun{
+		ID:          record.ID.String(),
+		JobID:       record.JobID,
+		Status:      string(record.Status),
+		Attempts:    record.Attempts,
+		MaxAttempts: record.MaxAttempts,
+		EnqueuedAt:  record.CreatedAt,
+		StartedAt:   record.StartedAt,
+		FinishedAt:  record.FinishedAt,
+		Error:       record.LastError,
+	}
+
+	if record.StartedAt != nil && record.FinishedAt != nil {
+		run.DurationSeconds = record.FinishedAt.Sub(*record.StartedAt).Seconds()
+	}
+
+	if withLogs {
+		run.Logs = record.Logs
+	}
+
+	return run
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("error writing response: %v", err)
+	}
+}
+
+// listJobRuns handles GET /jobs, optionally filtered by the "status" and "job_id" query parameters
+func listJobRuns(w http.ResponseWriter, r *http.Request) {
+	filters := []repository.WorkerJobFilters{
+		repository.WithWorkerJobLimit(defaultJobListLimit),
+	}
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		filters = append(filters, repository.WithWorkerJobStatus(models.WorkerJobStatus(status)))
+	}
+
+	if jobID := r.URL.Query().Get("job_id"); jobID != "" {
+		filters = append(filters, repository.WithWorkerJobID(jobID))
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		filters = append(filters, repository.WithWorkerJobLimit(n))
+	}
+
+	records, err := repo.WorkerJob().List(r.Context(), filters...)
+	if err != nil {
+		log.Printf("error listing job runs: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]JobRun, 0, len(records))
+
+	for _, record := range records {
+		res = append(res, toJobRun(record, false))
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// getJobRun handles GET /jobs/{jobID}
+func getJobRun(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "jobID"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	record, err := repo.WorkerJob().Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		log.Printf("error reading job run %s: %v", id, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toJobRun(record, true))
+}
+
+// cancelJobRun handles DELETE /jobs/{jobID}. Jobs that already finished cannot be canceled.
+func cancelJobRun(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "jobID"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	record, err := repo.WorkerJob().Cancel(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		log.Printf("error canceling job run %s: %v", id, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if record.Status != models.WorkerJobStatus_Canceled {
+		writeJSON(w, http.StatusConflict, toJobRun(record, false))
+		return
+	}
+
+	// stop the job right away if this replica is running it, other replicas pick up the cancellation when they poll
+	dispatcher.Cancel(record.ID)
+
+	writeJSON(w, http.StatusOK, toJobRun(record, false))
+}
+
+// listSchedules handles GET /schedules, returning the next and last run times of every scheduled job
+func listSchedules(w http.ResponseWriter, r *http.Request) {
+	if scheduler == nil {
+		writeJSON(w, http.StatusOK, []worker.ScheduleStatus{})
+		return
+	}
+
+	res, err := scheduler.Status(r.Context())
+	if err != nil {
+		log.Printf("error reading job schedules: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,84 @@
+//go:build ee
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/porter-dev/porter/internal/worker"
+)
+
+// sweepCheckpoint tracks which resources, like clusters or projects, a job has finished sweeping, so that an
+// attempt interrupted by a shutdown resumes with the remaining resources instead of starting over
+type sweepCheckpoint struct {
+	mu sync.Mutex
+
+	checkpointer worker.Checkpointer
+	resource     string
+	completed    map[uint]bool
+	completedIDs []uint
+}
+
+// loadSweepCheckpoint reads the checkpoint saved by a previous attempt of the job run carried by ctx. The IDs of
+// the swept resources are saved under "completed_<resource>_ids". A missing or unreadable checkpoint starts the
+// sweep from scratch.
+func loadSweepCheckpoint(ctx context.Context, resource string) *sweepCheckpoint {
+	c := &sweepCheckpoint{
+		checkpointer: worker.CheckpointerFromContext(ctx),
+		resource:     resource,
+		completed:    make(map[uint]bool),
+	}
+
+	if data := c.checkpointer.Checkpoint(); len(data) > 0 {
+		saved := make(map[string][]uint)
+
+		if err := json.Unmarshal(data, &saved); err != nil {
+			worker.LoggerFromContext(ctx).Printf("error reading checkpoint: %v. starting sweep from scratch", err)
+		} else {
+			c.completedIDs = saved[c.key()]
+		}
+	}
+
+	for _, id := range c.completedIDs {
+		c.completed[id] = true
+	}
+
+	return c
+}
+
+// isCompleted returns true if the resource was swept by a previous attempt
+func (c *sweepCheckpoint) isCompleted(id uint) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.completed[id]
+}
+
+// markCompleted records that the resource was swept and saves the checkpoint
+func (c *sweepCheckpoint) markCompleted(ctx context.Context, id uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.completed[id] {
+		return
+	}
+
+	c.completed[id] = true
+	c.completedIDs = append(c.completedIDs, id)
+
+	data, err := json.Marshal(map[string][]uint{c.key(): c.completedIDs})
+	if err != nil {
+		return
+	}
+
+	if err := c.checkpointer.SaveCheckpoint(ctx, data); err != nil {
+		worker.LoggerFromContext(ctx).Printf("error saving checkpoint after %s ID %d: %v", c.resource, id, err)
+	}
+}
+
+func (c *sweepCheckpoint) key() string {
+	return fmt.Sprintf("completed_%s_ids", c.resource)
+}
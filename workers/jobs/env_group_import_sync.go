@@ -0,0 +1,216 @@
+//go:build ee
+
+/*
+
+                            === Environment Group Import Sync Job ===
+
+This job syncs environment groups with the secrets of their import sources in external secret managers.
+
+  - The job looks for import sources with a sync interval, optionally only in the projects of the job input.
+  - Sources whose latest sync is older than their interval are synced: the key/value pairs of the secret are
+    fetched from AWS Secrets Manager, GCP Secret Manager or Vault and imported into the environment group, which
+    only creates a new version of the environment group if its variables changed.
+  - When a sync changes an environment group, the apps linked to it are re-deployed unless the source skips
+    redeploys.
+  - The time, resulting version and error of every sync are recorded on the source. Failed syncs are retried on the
+    next run after the interval of the source has elapsed.
+
+*/
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/pkg/logger"
+
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups/importer"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"github.com/porter-dev/porter/internal/worker"
+	"gorm.io/gorm"
+)
+
+// envGroupImportSyncTimeout is the maximum duration of a single run over all due import sources
+const envGroupImportSyncTimeout = 30 * time.Minute
+
+type envGroupImportSync struct {
+	enqueueTime time.Time
+	conf        *config.Config
+	projectIDs  map[uint]bool
+}
+
+// EnvGroupImportSyncOpts holds the options required to run this job
+type EnvGroupImportSyncOpts struct {
+	DBConf         *env.DBConf
+	DOClientID     string
+	DOClientSecret string
+	DOScopes       []string
+	ServerURL      string
+
+	// ClusterControlPlaneClient is required to re-deploy the apps linked to the environment groups changed by a sync.
+	// Syncs which change an environment group fail if it is nil, unless their source skips redeploys.
+	ClusterControlPlaneClient porterv1connect.ClusterControlPlaneServiceClient
+
+	Input map[string]interface{}
+}
+
+type envGroupImportSyncInput struct {
+	Projects []uint `mapstructure:"projects"`
+}
+
+func NewEnvGroupImportSync(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *EnvGroupImportSyncOpts,
+) (*envGroupImportSync, error) {
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	doConf := oauth.NewDigitalOceanClient(&oauth.Config{
+		ClientID:     opts.DOClientID,
+		ClientSecret: opts.DOClientSecret,
+		Scopes:       opts.DOScopes,
+		BaseURL:      opts.ServerURL,
+	})
+
+	// parse input
+	parsedInput := &envGroupImportSyncInput{}
+	err := mapstructure.Decode(opts.Input, parsedInput)
+	if err != nil {
+		return nil, err
+	}
+
+	projectIDs := make(map[uint]bool)
+
+	for _, id := range parsedInput.Projects {
+		projectIDs[id] = true
+	}
+
+	return &envGroupImportSync{
+		enqueueTime: enqueueTime,
+		conf: &config.Config{
+			Repo:                      repo,
+			DOConf:                    doConf,
+			Logger:                    logger.New(true, os.Stdout),
+			ClusterControlPlaneClient: opts.ClusterControlPlaneClient,
+		},
+		projectIDs: projectIDs,
+	}, nil
+}
+
+func (s *envGroupImportSync) ID() string {
+	return "env-group-import-sync"
+}
+
+func (s *envGroupImportSync) EnqueueTime() time.Time {
+	return s.enqueueTime
+}
+
+func (s *envGroupImportSync) Timeout() time.Duration {
+	return envGroupImportSyncTimeout
+}
+
+func (s *envGroupImportSync) Run(ctx context.Context) error {
+	jobLogger := worker.LoggerFromContext(ctx)
+
+	sources, err := s.conf.Repo.EnvGroupImportSource().ListScheduledEnvGroupImportSources(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing scheduled import sources: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	// sources are ordered by cluster, so an agent is only created once per cluster
+	agents := make(map[uint]*kubernetes.Agent)
+	synced := 0
+
+	for _, source := range sources {
+		if len(s.projectIDs) > 0 && !s.projectIDs[source.ProjectID] {
+			continue
+		}
+
+		if !source.SyncDue(now) {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			jobLogger.Printf("import sync interrupted: %v", ctx.Err())
+			return ctx.Err()
+		}
+
+		agent, ok := agents[source.ClusterID]
+		if !ok {
+			agent, err = s.getAgent(ctx, source)
+			if err != nil {
+				jobLogger.Printf("error getting k8s agent for cluster ID %d: %v. skipping env group %s ...", source.ClusterID, err, source.EnvGroupName)
+				continue
+			}
+
+			agents[source.ClusterID] = agent
+		}
+
+		result, err := importer.Sync(ctx, s.conf, agent, source)
+		if err != nil {
+			jobLogger.Printf("error syncing env group %s in cluster ID %d from %s: %v", source.EnvGroupName, source.ClusterID, source.Provider, err)
+			continue
+		}
+
+		synced++
+
+		if !result.Changed {
+			jobLogger.Printf("env group %s in cluster ID %d is up to date with %s at version %d", source.EnvGroupName, source.ClusterID, source.Provider, result.Version)
+			continue
+		}
+
+		jobLogger.Printf("synced env group %s in cluster ID %d from %s to version %d: %d added, %d updated, %d removed",
+			source.EnvGroupName, source.ClusterID, source.Provider, result.Version, len(result.Added), len(result.Updated), len(result.Removed))
+	}
+
+	jobLogger.Printf("synced %d env groups", synced)
+
+	return nil
+}
+
+func (s *envGroupImportSync) getAgent(ctx context.Context, source *models.EnvGroupImportSource) (*kubernetes.Agent, error) {
+	cluster, err := s.conf.Repo.Cluster().ReadCluster(source.ProjectID, source.ClusterID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cluster: %w", err)
+	}
+
+	return kubernetes.GetAgentOutOfClusterConfig(ctx, &kubernetes.OutOfClusterConfig{
+		Cluster:                   cluster,
+		Repo:                      s.conf.Repo,
+		DigitalOceanOAuth:         s.conf.DOConf,
+		AllowInClusterConnections: false,
+		Timeout:                   10 * time.Second,
+	})
+}
+
+func (s *envGroupImportSync) SetData([]byte) {}
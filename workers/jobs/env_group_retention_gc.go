@@ -0,0 +1,256 @@
+//go:build ee
+
+/*
+
+                        === Environment Group Retention Garbage Collector Job ===
+
+This job deletes the versioned copies of environment groups which pile up in the namespaces of apps.
+
+  - Every time an app is deployed, the latest version of each of its environment groups is copied to the namespace
+    of the app as a configmap and a secret named <environment-group-name>.<version>. Copies are never deleted by
+    deploys, so a copy is left behind for every version of an environment group which was deployed.
+  - The job looks for enabled environment group retention policies, optionally only in the projects of the job input.
+  - For every cluster of a project, the copies referenced by the Deployments, StatefulSets, DaemonSets, CronJobs,
+    running Jobs, scaled ReplicaSets and running Pods of the cluster are collected, along with the versions of the
+    environment groups of the last N revisions of every app deployed to the cluster, where N is set by the policy of
+    the project. If the references of a cluster cannot be collected, the cluster is skipped, since copies which are in
+    use could otherwise be deleted.
+  - Copies which are referenced, which are copies of the latest version of their environment group or which were
+    created less than an hour ago are kept. All other copies are deleted, and every deleted copy is logged. If the job
+    input sets `dry_run`, the copies which would be deleted are only logged.
+  - The time and the number of deleted copies of every run are recorded on the policy of the project.
+
+*/
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/pkg/logger"
+
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups/gc"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"github.com/porter-dev/porter/internal/worker"
+	"gorm.io/gorm"
+)
+
+// envGroupRetentionGCTimeout is the maximum duration of a single run over all enabled retention policies
+const envGroupRetentionGCTimeout = time.Hour
+
+type envGroupRetentionGC struct {
+	enqueueTime time.Time
+	conf        *config.Config
+	projectIDs  map[uint]bool
+	dryRun      bool
+}
+
+// EnvGroupRetentionGCOpts holds the options required to run this job
+type EnvGroupRetentionGCOpts struct {
+	DBConf         *env.DBConf
+	DOClientID     string
+	DOClientSecret string
+	DOScopes       []string
+	ServerURL      string
+
+	// ClusterControlPlaneClient is required to connect to clusters provisioned by the cluster control plane
+	ClusterControlPlaneClient porterv1connect.ClusterControlPlaneServiceClient
+
+	Input map[string]interface{}
+}
+
+type envGroupRetentionGCInput struct {
+	Projects []uint `mapstructure:"projects"`
+	DryRun   bool   `mapstructure:"dry_run"`
+}
+
+func NewEnvGroupRetentionGC(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *EnvGroupRetentionGCOpts,
+) (*envGroupRetentionGC, error) {
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	doConf := oauth.NewDigitalOceanClient(&oauth.Config{
+		ClientID:     opts.DOClientID,
+		ClientSecret: opts.DOClientSecret,
+		Scopes:       opts.DOScopes,
+		BaseURL:      opts.ServerURL,
+	})
+
+	// parse input
+	parsedInput := &envGroupRetentionGCInput{}
+	err := mapstructure.Decode(opts.Input, parsedInput)
+	if err != nil {
+		return nil, err
+	}
+
+	projectIDs := make(map[uint]bool)
+
+	for _, id := range parsedInput.Projects {
+		projectIDs[id] = true
+	}
+
+	return &envGroupRetentionGC{
+		enqueueTime: enqueueTime,
+		conf: &config.Config{
+			Repo:                      repo,
+			DOConf:                    doConf,
+			Logger:                    logger.New(true, os.Stdout),
+			ClusterControlPlaneClient: opts.ClusterControlPlaneClient,
+		},
+		projectIDs: projectIDs,
+		dryRun:     parsedInput.DryRun,
+	}, nil
+}
+
+func (g *envGroupRetentionGC) ID() string {
+	return "env-group-retention-gc"
+}
+
+func (g *envGroupRetentionGC) EnqueueTime() time.Time {
+	return g.enqueueTime
+}
+
+func (g *envGroupRetentionGC) Timeout() time.Duration {
+	return envGroupRetentionGCTimeout
+}
+
+func (g *envGroupRetentionGC) Run(ctx context.Context) error {
+	jobLogger := worker.LoggerFromContext(ctx)
+
+	policies, err := g.conf.Repo.EnvGroupRetention().ListEnabledEnvGroupRetentionPolicies(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing enabled env group retention policies: %w", err)
+	}
+
+	jobLogger.Printf("found %d enabled env group retention policies", len(policies))
+
+	for _, policy := range policies {
+		if len(g.projectIDs) > 0 && !g.projectIDs[policy.ProjectID] {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			jobLogger.Printf("garbage collection interrupted: %v", ctx.Err())
+			return ctx.Err()
+		}
+
+		g.runPolicy(ctx, policy)
+	}
+
+	return nil
+}
+
+func (g *envGroupRetentionGC) runPolicy(ctx context.Context, policy *models.EnvGroupRetentionPolicy) {
+	jobLogger := worker.LoggerFromContext(ctx)
+
+	clusters, err := g.conf.Repo.Cluster().ListClustersByProjectID(policy.ProjectID)
+	if err != nil {
+		jobLogger.Printf("error listing clusters of project ID %d: %v. skipping project ...", policy.ProjectID, err)
+		return
+	}
+
+	var deletedCount uint
+
+	for _, cluster := range clusters {
+		if ctx.Err() != nil {
+			break
+		}
+
+		deletedCount += g.runCluster(ctx, policy, cluster)
+	}
+
+	if g.dryRun {
+		return
+	}
+
+	if err := g.conf.Repo.EnvGroupRetention().RecordEnvGroupRetentionPolicyRun(ctx, policy.ID, time.Now().UTC(), deletedCount); err != nil {
+		jobLogger.Printf("error recording env group retention policy run of project ID %d: %v", policy.ProjectID, err)
+	}
+
+	jobLogger.Printf("deleted %d env group copies in project ID %d", deletedCount, policy.ProjectID)
+}
+
+// runCluster deletes the copies of a cluster which are not kept by the policy of its project, and returns the number of deleted copies
+func (g *envGroupRetentionGC) runCluster(ctx context.Context, policy *models.EnvGroupRetentionPolicy, cluster *models.Cluster) uint {
+	jobLogger := worker.LoggerFromContext(ctx)
+
+	agent, err := kubernetes.GetAgentOutOfClusterConfig(ctx, &kubernetes.OutOfClusterConfig{
+		Cluster:                     cluster,
+		Repo:                        g.conf.Repo,
+		DigitalOceanOAuth:           g.conf.DOConf,
+		AllowInClusterConnections:   false,
+		Timeout:                     10 * time.Second,
+		CAPIManagementClusterClient: g.conf.ClusterControlPlaneClient,
+	})
+	if err != nil {
+		jobLogger.Printf("error getting k8s agent for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
+		return 0
+	}
+
+	refs, err := gc.CollectReferences(ctx, g.conf, agent, cluster, policy.KeepLastRevisions)
+	if err != nil {
+		jobLogger.Printf("error collecting env group references of cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
+		return 0
+	}
+
+	report, err := gc.Evaluate(ctx, agent, refs)
+	if err != nil {
+		jobLogger.Printf("error evaluating env group copies of cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
+		return 0
+	}
+
+	jobLogger.Printf("env group retention policy of project ID %d keeps %d copies and deletes %d copies in cluster ID %d",
+		policy.ProjectID, len(report.Keep), len(report.Delete), cluster.ID)
+
+	if g.dryRun {
+		for _, decision := range report.Delete {
+			jobLogger.Printf("dry run: would delete env group copy %s/%s in cluster ID %d", decision.Namespace, decision.Name(), cluster.ID)
+		}
+
+		return 0
+	}
+
+	deleted, err := gc.Apply(ctx, agent, report)
+
+	for _, c := range deleted {
+		jobLogger.Printf("deleted env group copy %s/%s in cluster ID %d", c.Namespace, c.Name(), cluster.ID)
+	}
+
+	if err != nil {
+		jobLogger.Printf("error deleting env group copies in cluster ID %d: %v. %d copies were deleted", cluster.ID, err, len(deleted))
+	}
+
+	return uint(len(deleted))
+}
+
+func (g *envGroupRetentionGC) SetData([]byte) {}
@@ -5,7 +5,7 @@
                             === Helm Release Revisions Tracker Job ===
 
 This job keeps a track of helm releases and their revisions and deletes older revisions once they are
-backed up to an S3 bucket.
+backed up to the configured storage backend (S3, GCS, Azure Blob or a local directory).
 
   - The job looks for clusters which have the `monitor_helm_releases` set to true.
   - The clusters are then checked for old helm release revisions.
@@ -13,7 +13,8 @@ backed up to an S3 bucket.
   - For every namespace, the list of releases is fetched.
   - For every release, its revision history is fetched.
   - If the number of revisions exceeds 100, then we intend to only keep the most recent 100 revisions.
-  - For this, the older revisions are first backed up to an S3 bucket and then deleted.
+  - For this, the older revisions are first backed up to the storage backend and then deleted.
+  - Backed up revisions can be restored into the cluster with `porter helm revisions restore`.
 
 */
 
@@ -23,7 +24,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"os"
 	"sync"
 	"time"
@@ -31,7 +31,8 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config/env"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/pkg/logger"
-	"github.com/porter-dev/porter/provisioner/integrations/storage/s3"
+	"github.com/porter-dev/porter/provisioner/integrations/storage"
+	"github.com/porter-dev/porter/provisioner/integrations/storage/backend"
 	"github.com/porter-dev/porter/workers/utils"
 
 	"github.com/porter-dev/porter/ee/integrations/vault"
@@ -42,37 +43,33 @@ import (
 	"github.com/porter-dev/porter/internal/repository"
 	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
 	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"github.com/porter-dev/porter/internal/worker"
 	"github.com/stefanmcshane/helm/pkg/releaseutil"
 	"golang.org/x/oauth2"
 	"gorm.io/gorm"
 )
 
+// helmRevisionsCountTrackerTimeout is the maximum duration of a single sweep over all clusters
+const helmRevisionsCountTrackerTimeout = 6 * time.Hour
+
 type helmRevisionsCountTracker struct {
-	enqueueTime        time.Time
-	db                 *gorm.DB
-	repo               repository.Repository
-	doConf             *oauth2.Config
-	awsAccessKeyID     string
-	awsSecretAccessKey string
-	awsRegion          string
-	s3BucketName       string
-	encryptionKey      *[32]byte
-	revisionsCount     int
+	enqueueTime    time.Time
+	db             *gorm.DB
+	repo           repository.Repository
+	doConf         *oauth2.Config
+	storageManager storage.KeyStorageManager
+	revisionsCount int
 }
 
 // HelmRevisionsCountTrackerOpts holds the options required to run this job
 type HelmRevisionsCountTrackerOpts struct {
-	DBConf             *env.DBConf
-	DOClientID         string
-	DOClientSecret     string
-	DOScopes           []string
-	ServerURL          string
-	AWSAccessKeyID     string
-	AWSSecretAccessKey string
-	AWSRegion          string
-	S3BucketName       string
-	EncryptionKey      string
-	RevisionsCount     int
+	DBConf           *env.DBConf
+	DOClientID       string
+	DOClientSecret   string
+	DOScopes         []string
+	ServerURL        string
+	RevisionsStorage *env.RevisionsStorageConf
+	RevisionsCount   int
 }
 
 func NewHelmRevisionsCountTracker(
@@ -106,15 +103,14 @@ func NewHelmRevisionsCountTracker(
 		BaseURL:      opts.ServerURL,
 	})
 
-	var s3Key [32]byte
-
-	for i, b := range []byte(opts.EncryptionKey) {
-		s3Key[i] = b
+	// create the storage client to back up revisions that need to be deleted
+	storageManager, err := backend.NewRevisionsStorageManager(opts.RevisionsStorage)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s storage client: %w", opts.RevisionsStorage.Backend, err)
 	}
 
 	return &helmRevisionsCountTracker{
-		enqueueTime, db, repo, doConf, opts.AWSAccessKeyID, opts.AWSSecretAccessKey, opts.AWSRegion,
-		opts.S3BucketName, &s3Key, opts.RevisionsCount,
+		enqueueTime, db, repo, doConf, storageManager, opts.RevisionsCount,
 	}, nil
 }
 
@@ -126,7 +122,14 @@ func (t *helmRevisionsCountTracker) EnqueueTime() time.Time {
 	return t.enqueueTime
 }
 
+func (t *helmRevisionsCountTracker) Timeout() time.Duration {
+	return helmRevisionsCountTrackerTimeout
+}
+
 func (t *helmRevisionsCountTracker) Run(ctx context.Context) error {
+	jobLogger := worker.LoggerFromContext(ctx)
+	checkpoint := loadSweepCheckpoint(ctx, "cluster")
+
 	var count int64
 
 	if err := t.db.Model(&models.Cluster{}).Count(&count).Error; err != nil {
@@ -136,6 +139,11 @@ func (t *helmRevisionsCountTracker) Run(ctx context.Context) error {
 	var wg sync.WaitGroup
 
 	for i := 0; i < (int(count)/stepSize)+1; i++ {
+		if ctx.Err() != nil {
+			jobLogger.Printf("sweep interrupted: %v", ctx.Err())
+			return ctx.Err()
+		}
+
 		var clusters []*models.Cluster
 
 		if err := t.db.Order("id asc").Offset(i*stepSize).Limit(stepSize).Find(&clusters, "monitor_helm_releases = ?", "1").
@@ -145,25 +153,21 @@ func (t *helmRevisionsCountTracker) Run(ctx context.Context) error {
 
 		// go through each project
 		for _, cluster := range clusters {
+			if checkpoint.isCompleted(cluster.ID) {
+				jobLogger.Printf("cluster with ID %d was swept by a previous attempt. skipping cluster ...", cluster.ID)
+				continue
+			}
+
 			wg.Add(1)
 
 			go func(projID, clusterID uint) {
 				defer wg.Done()
 
-				log.Printf("starting release revision monitoring for cluster with ID %d", cluster.ID)
+				jobLogger.Printf("starting release revision monitoring for cluster with ID %d", cluster.ID)
 
 				cluster, err := t.repo.Cluster().ReadCluster(projID, clusterID)
 				if err != nil {
-					log.Printf("error reading cluster ID %d: %v. skipping cluster ...", clusterID, err)
-					return
-				}
-
-				// create s3 client to store revisions that need to be deleted
-				s3Client, err := s3.NewS3StorageClient(&s3.S3Options{
-					t.awsRegion, t.awsAccessKeyID, t.awsSecretAccessKey, t.s3BucketName, t.encryptionKey,
-				})
-				if err != nil {
-					log.Printf("error creating S3 client for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
+					jobLogger.Printf("error reading cluster ID %d: %v. skipping cluster ...", clusterID, err)
 					return
 				}
 
@@ -175,19 +179,23 @@ func (t *helmRevisionsCountTracker) Run(ctx context.Context) error {
 					Timeout:                   5 * time.Second,
 				})
 				if err != nil {
-					log.Printf("error getting k8s agent for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
+					jobLogger.Printf("error getting k8s agent for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
 					return
 				}
 
 				namespaces, err := k8sAgent.ListNamespaces()
 				if err != nil {
-					log.Printf("error fetching namespaces for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
+					jobLogger.Printf("error fetching namespaces for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
 					return
 				}
 
-				log.Printf("fetched %d namespaces for cluster ID %d", len(namespaces.Items), cluster.ID)
+				jobLogger.Printf("fetched %d namespaces for cluster ID %d", len(namespaces.Items), cluster.ID)
 
 				for _, ns := range namespaces.Items {
+					if ctx.Err() != nil {
+						return
+					}
+
 					agent, err := utils.NewRetryHelmAgent(ctx, &helm.Form{
 						Cluster:                   cluster,
 						Namespace:                 ns.Name,
@@ -197,7 +205,7 @@ func (t *helmRevisionsCountTracker) Run(ctx context.Context) error {
 						Timeout:                   5 * time.Second,
 					}, logger.New(true, os.Stdout), 3, time.Second)
 					if err != nil {
-						log.Printf("error fetching helm client for namespace %s in cluster ID %d: %v. "+
+						jobLogger.Printf("error fetching helm client for namespace %s in cluster ID %d: %v. "+
 							"skipping namespace ...", ns.Name, cluster.ID, err)
 						continue
 					}
@@ -214,28 +222,32 @@ func (t *helmRevisionsCountTracker) Run(ctx context.Context) error {
 						},
 					})
 					if err != nil {
-						log.Printf("error fetching releases for namespace %s in cluster ID %d: %v. skipping namespace ...",
+						jobLogger.Printf("error fetching releases for namespace %s in cluster ID %d: %v. skipping namespace ...",
 							ns.Name, cluster.ID, err)
 						continue
 					}
 
-					log.Printf("fetched %d releases for namespace %s in cluster ID %d", len(releases), ns.Name, cluster.ID)
+					jobLogger.Printf("fetched %d releases for namespace %s in cluster ID %d", len(releases), ns.Name, cluster.ID)
 
 					for _, rel := range releases {
+						if ctx.Err() != nil {
+							return
+						}
+
 						revisions, err := agent.GetReleaseHistory(ctx, rel.Name)
 						if err != nil {
-							log.Printf("error fetching release history for release %s in namespace %s of cluster ID %d: %v."+
+							jobLogger.Printf("error fetching release history for release %s in namespace %s of cluster ID %d: %v."+
 								" skipping release ...", rel.Name, ns.Name, cluster.ID, err)
 							continue
 						}
 
 						if len(revisions) <= t.revisionsCount {
-							log.Printf("release %s of namespace %s in cluster ID %d has <= %d revisions. "+
+							jobLogger.Printf("release %s of namespace %s in cluster ID %d has <= %d revisions. "+
 								"skipping release...", t.revisionsCount, rel.Name, ns.Name, cluster.ID)
 							continue
 						}
 
-						log.Printf("release %s of namespace %s in cluster ID %d has more than %d revisions. attempting to "+
+						jobLogger.Printf("release %s of namespace %s in cluster ID %d has more than %d revisions. attempting to "+
 							"delete the older ones.", t.revisionsCount, rel.Name, ns.Name, cluster.ID)
 
 						// sort revisions from newest to oldest
@@ -244,46 +256,55 @@ func (t *helmRevisionsCountTracker) Run(ctx context.Context) error {
 						for i := t.revisionsCount; i < len(revisions); i += 1 {
 							rev := revisions[i]
 
-							// store the revision in the s3 bucket before deleting it
+							// store the revision in the storage backend before deleting it
 							data, err := json.Marshal(rev)
 							if err != nil {
-								log.Printf("error marshalling revision for release %s, number %d: %v. skipping revision ...",
+								jobLogger.Printf("error marshalling revision for release %s, number %d: %v. skipping revision ...",
 									rev.Name, rev.Version, err)
 								continue
 							}
 
-							// write to the bucket with key - <project_id>/<cluster_id>/<namespace>/<release_name>/<revision_number>
-							err = s3Client.WriteFileWithKey(data, true, fmt.Sprintf("%d/%d/%s/%s/%d", cluster.ProjectID,
+							// write with key - <project_id>/<cluster_id>/<namespace>/<release_name>/<revision_number>
+							err = t.storageManager.WriteFileWithKey(data, true, helm.ArchivedRevisionKey(cluster.ProjectID,
 								cluster.ID, rel.Namespace, rel.Name, rev.Version))
 
 							if err != nil {
-								log.Printf("error backing up revision for release %s, number %d: %v. skipping revision ...",
+								jobLogger.Printf("error backing up revision for release %s, number %d: %v. skipping revision ...",
 									rev.Name, rev.Version, err)
 								continue
 							}
 
-							log.Printf("revision %d of release %s in namespace %s of cluster ID %d was successfully backed up.",
+							jobLogger.Printf("revision %d of release %s in namespace %s of cluster ID %d was successfully backed up.",
 								rev.Version, rel.Name, ns.Name, cluster.ID)
 
 							err = agent.DeleteReleaseRevision(ctx, rev.Name, rev.Version)
 
 							if err != nil {
-								log.Printf("error deleting revision %d of release %s in namespace %s of cluster ID %d: %v",
+								jobLogger.Printf("error deleting revision %d of release %s in namespace %s of cluster ID %d: %v",
 									rev.Version, rel.Name, ns.Name, cluster.ID, err)
 								continue
 							}
 
-							log.Printf("revision %d of release %s in namespace %s of cluster ID %d was successfully deleted.",
+							jobLogger.Printf("revision %d of release %s in namespace %s of cluster ID %d was successfully deleted.",
 								rev.Version, rel.Name, ns.Name, cluster.ID)
 						}
 					}
 				}
+
+				if ctx.Err() == nil {
+					checkpoint.markCompleted(ctx, cluster.ID)
+				}
 			}(cluster.ProjectID, cluster.ID)
 		}
 
 		wg.Wait()
 	}
 
+	if ctx.Err() != nil {
+		jobLogger.Printf("sweep interrupted: %v", ctx.Err())
+		return ctx.Err()
+	}
+
 	return nil
 }
 
@@ -0,0 +1,108 @@
+//go:build ee
+
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/dedup"
+	"github.com/porter-dev/porter/internal/notifier/routing"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"gorm.io/gorm"
+)
+
+/*
+
+                         === Notification Aggregates Flusher Job ===
+
+   This job reports the repeats of app notifications which were collapsed within their dedup window
+   in a single "N occurrences" message, and sends a resolved follow-up for notifications which have
+   not repeated for NOTIFICATION_RESOLVE_AFTER. It is meant to be scheduled every minute.
+
+*/
+
+// notificationAggregatesFlusherTimeout is the maximum duration of a single flush
+const notificationAggregatesFlusherTimeout = 10 * time.Minute
+
+type notificationAggregatesFlusher struct {
+	enqueueTime  time.Time
+	repo         repository.Repository
+	resolveAfter time.Duration
+	serverURL    string
+}
+
+// NotificationAggregatesFlusherOpts holds the options required to run this job
+type NotificationAggregatesFlusherOpts struct {
+	DBConf       *env.DBConf
+	ServerURL    string
+	ResolveAfter time.Duration
+}
+
+func NewNotificationAggregatesFlusher(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *NotificationAggregatesFlusherOpts,
+) (*notificationAggregatesFlusher, error) {
+	return &notificationAggregatesFlusher{
+		enqueueTime:  enqueueTime,
+		repo:         newRepository(db, opts.DBConf),
+		resolveAfter: opts.ResolveAfter,
+		serverURL:    opts.ServerURL,
+	}, nil
+}
+
+func (n *notificationAggregatesFlusher) ID() string {
+	return "notification-aggregates-flusher"
+}
+
+func (n *notificationAggregatesFlusher) EnqueueTime() time.Time {
+	return n.enqueueTime
+}
+
+func (n *notificationAggregatesFlusher) Timeout() time.Duration {
+	return notificationAggregatesFlusherTimeout
+}
+
+func (n *notificationAggregatesFlusher) Run(ctx context.Context) error {
+	flusher := dedup.NewFlusher(
+		n.repo.NotificationAggregate(),
+		func(ctx context.Context, aggregate *models.NotificationAggregate) (notifier.AggregateNotifier, error) {
+			return routing.AggregateNotifierFor(ctx, n.repo, aggregate)
+		},
+		n.resolveAfter,
+		n.serverURL,
+	)
+
+	// aggregates are marked as reported even if a channel fails, so a retried run does not send
+	// the same message twice
+	return flusher.Flush(ctx)
+}
+
+func newRepository(db *gorm.DB, dbConf *env.DBConf) repository.Repository {
+	var credBackend rcreds.CredentialStorage
+
+	if dbConf.VaultAPIKey != "" && dbConf.VaultServerURL != "" && dbConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			dbConf.VaultServerURL,
+			dbConf.VaultAPIKey,
+			dbConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(dbConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	return rgorm.NewRepository(db, &key, credBackend)
+}
+
+func (n *notificationAggregatesFlusher) SetData([]byte) {}
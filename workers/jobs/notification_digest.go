@@ -0,0 +1,155 @@
+//go:build ee
+
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/internal/notifier/dedup"
+	"github.com/porter-dev/porter/internal/notifier/sendgrid"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/worker"
+	"gorm.io/gorm"
+)
+
+/*
+
+                              === Notification Digest Job ===
+
+   This job emails the members of every project which opted in to the digest a summary of the app
+   notifications of the past day, through SendGrid. Projects opt in through the
+   /api/projects/{project_id}/notifications/digest endpoint. It is meant to be scheduled once a day,
+   and is skipped unless SENDGRID_NOTIFICATION_DIGEST_TEMPLATE_ID is set.
+
+*/
+
+const (
+	// notificationDigestPeriod is the period summarized by a digest
+	notificationDigestPeriod = 24 * time.Hour
+
+	// notificationDigestTimeout is the maximum duration of a single run over all projects
+	notificationDigestTimeout = time.Hour
+)
+
+type notificationDigest struct {
+	enqueueTime time.Time
+	repo        repository.Repository
+	opts        *NotificationDigestOpts
+}
+
+// NotificationDigestOpts holds the options required to run this job
+type NotificationDigestOpts struct {
+	DBConf                 *env.DBConf
+	ServerURL              string
+	SendgridAPIKey         string
+	SendgridSenderEmail    string
+	SendgridDigestTemplate string
+}
+
+func NewNotificationDigest(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *NotificationDigestOpts,
+) (*notificationDigest, error) {
+	return &notificationDigest{
+		enqueueTime: enqueueTime,
+		repo:        newRepository(db, opts.DBConf),
+		opts:        opts,
+	}, nil
+}
+
+func (n *notificationDigest) ID() string {
+	return "notification-digest"
+}
+
+func (n *notificationDigest) EnqueueTime() time.Time {
+	return n.enqueueTime
+}
+
+func (n *notificationDigest) Timeout() time.Duration {
+	return notificationDigestTimeout
+}
+
+func (n *notificationDigest) Run(ctx context.Context) error {
+	jobLogger := worker.LoggerFromContext(ctx)
+
+	if n.opts.SendgridAPIKey == "" || n.opts.SendgridSenderEmail == "" || n.opts.SendgridDigestTemplate == "" {
+		jobLogger.Println("no SendGrid digest template set, skipping job altogether")
+		return nil
+	}
+
+	// the digest covers the day before the run was enqueued, so a retried run sends the same digest
+	until := n.enqueueTime.UTC()
+	since := until.Add(-notificationDigestPeriod)
+
+	projectIDs, err := n.repo.NotificationAggregate().ListNotificationDigestProjectIDs(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	jobLogger.Printf("sending notification digests to %d projects", len(projectIDs))
+
+	// an interrupted run does not email a project twice
+	checkpoint := loadSweepCheckpoint(ctx, "project")
+
+	for _, projectID := range projectIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if checkpoint.isCompleted(projectID) {
+			continue
+		}
+
+		if err := n.sendDigest(ctx, projectID, since, until); err != nil {
+			jobLogger.Printf("error sending notification digest for project %d: %v", projectID, err)
+		}
+
+		checkpoint.markCompleted(ctx, projectID)
+	}
+
+	jobLogger.Println("finished sending notification digests")
+
+	return nil
+}
+
+func (n *notificationDigest) sendDigest(ctx context.Context, projectID uint, since, until time.Time) error {
+	project, err := n.repo.Project().ReadProject(projectID)
+	if err != nil {
+		return err
+	}
+
+	aggregates, err := n.repo.NotificationAggregate().ListNotificationAggregatesByProjectID(ctx, projectID, since)
+	if err != nil {
+		return err
+	}
+
+	roles, err := n.repo.Project().ListProjectRoles(projectID)
+	if err != nil {
+		return err
+	}
+
+	userIDs := make([]uint, 0, len(roles))
+
+	for _, role := range roles {
+		userIDs = append(userIDs, role.UserID)
+	}
+
+	users, err := n.repo.User().ListUsersByIDs(userIDs)
+	if err != nil {
+		return err
+	}
+
+	return sendgrid.NewDigestNotifier(&sendgrid.DigestNotifierOpts{
+		SharedOpts: &sendgrid.SharedOpts{
+			APIKey:      n.opts.SendgridAPIKey,
+			SenderEmail: n.opts.SendgridSenderEmail,
+		},
+		DigestTemplateID: n.opts.SendgridDigestTemplate,
+		Users:            users,
+	}).NotifyDigest(dedup.BuildDigest(project, aggregates, since, until, n.opts.ServerURL))
+}
+
+func (n *notificationDigest) SetData([]byte) {}
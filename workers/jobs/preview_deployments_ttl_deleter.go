@@ -4,7 +4,6 @@ package jobs
 
 import (
 	"context"
-	"log"
 	"sync"
 	"time"
 
@@ -16,6 +15,7 @@ import (
 	"github.com/porter-dev/porter/internal/repository"
 	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
 	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"github.com/porter-dev/porter/internal/worker"
 	"golang.org/x/oauth2"
 	"gorm.io/gorm"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -32,6 +32,9 @@ import (
 
 const (
 	stepSize = 20
+
+	// previewDeploymentsTTLDeleterTimeout is the maximum duration of a single sweep over all preview environments
+	previewDeploymentsTTLDeleterTimeout = time.Hour
 )
 
 type previewDeploymentsTTLDeleter struct {
@@ -93,20 +96,26 @@ func (n *previewDeploymentsTTLDeleter) EnqueueTime() time.Time {
 	return n.enqueueTime
 }
 
+func (n *previewDeploymentsTTLDeleter) Timeout() time.Duration {
+	return previewDeploymentsTTLDeleterTimeout
+}
+
 func (n *previewDeploymentsTTLDeleter) Run(ctx context.Context) error {
+	jobLogger := worker.LoggerFromContext(ctx)
+
 	if n.previewDeploymentsTTL == "" {
-		log.Println("no TTL set for preview deployments, skipping job altogether")
+		jobLogger.Println("no TTL set for preview deployments, skipping job altogether")
 		return nil
 	}
 
 	ttlDuration, err := time.ParseDuration(n.previewDeploymentsTTL)
 	if err != nil {
-		log.Printf("error parsing preview deployments TTL: %v. skipping job altogether", err)
+		jobLogger.Printf("error parsing preview deployments TTL: %v. skipping job altogether", err)
 		return nil
 	}
 
 	if ttlDuration.Hours() < 24 || ttlDuration.Hours() > 720 {
-		log.Printf("preview deployments TTL must be between 24 (1 day) and 720 hours (30 days). skipping job altogether")
+		jobLogger.Printf("preview deployments TTL must be between 24 (1 day) and 720 hours (30 days). skipping job altogether")
 		return nil
 	}
 
@@ -118,7 +127,7 @@ func (n *previewDeploymentsTTLDeleter) Run(ctx context.Context) error {
 
 	var wg sync.WaitGroup
 
-	log.Println("starting deletion of preview deployments based on TTL")
+	jobLogger.Println("starting deletion of preview deployments based on TTL")
 
 	for i := 0; i < (int(count)/stepSize)+1; i++ {
 		var clusters []*models.Cluster
@@ -135,11 +144,11 @@ func (n *previewDeploymentsTTLDeleter) Run(ctx context.Context) error {
 
 			envs, err := n.repo.Environment().ListEnvironments(cluster.ProjectID, cluster.ID)
 			if err != nil {
-				log.Printf("error listing environments for cluster %s: %v", cluster.Name, err)
+				jobLogger.Printf("error listing environments for cluster %s: %v", cluster.Name, err)
 				continue
 			}
 
-			log.Printf("found %d environments for cluster %s", len(envs), cluster.Name)
+			jobLogger.Printf("found %d environments for cluster %s", len(envs), cluster.Name)
 
 			for _, env := range envs {
 				wg.Add(1)
@@ -149,13 +158,13 @@ func (n *previewDeploymentsTTLDeleter) Run(ctx context.Context) error {
 
 					depls, err := n.repo.Environment().ListDeployments(env.ID)
 					if err != nil {
-						log.Printf("error listing deployments for %s/%s: %v", env.GitRepoOwner, env.GitRepoName, err)
+						jobLogger.Printf("error listing deployments for %s/%s: %v", env.GitRepoOwner, env.GitRepoName, err)
 						return
 					}
 
-					log.Printf("found %d deployments for %s/%s", len(depls), env.GitRepoOwner, env.GitRepoName)
+					jobLogger.Printf("found %d deployments for %s/%s", len(depls), env.GitRepoOwner, env.GitRepoName)
 
-					log.Printf("deleting preview deployments based on TTL %s for %s/%s",
+					jobLogger.Printf("deleting preview deployments based on TTL %s for %s/%s",
 						n.previewDeploymentsTTL, env.GitRepoOwner, env.GitRepoName)
 
 					k8sAgent, err := kubernetes.GetAgentOutOfClusterConfig(ctx, &kubernetes.OutOfClusterConfig{
@@ -166,7 +175,7 @@ func (n *previewDeploymentsTTLDeleter) Run(ctx context.Context) error {
 						Timeout:                   10 * time.Second,
 					})
 					if err != nil {
-						log.Printf("error getting k8s agent for cluster %s: %v", cluster.Name, err)
+						jobLogger.Printf("error getting k8s agent for cluster %s: %v", cluster.Name, err)
 						return
 					}
 
@@ -174,29 +183,29 @@ func (n *previewDeploymentsTTLDeleter) Run(ctx context.Context) error {
 						// delete the deployment if it has been inactive for longer than the set TTL
 						if depl.UpdatedAt.Add(ttlDuration).Before(time.Now()) {
 							if depl.Namespace != "" {
-								log.Printf("deleting namespace for deployment '%s'", depl.PRName)
+								jobLogger.Printf("deleting namespace for deployment '%s'", depl.PRName)
 
 								_, err := k8sAgent.GetNamespace(depl.Namespace)
 
 								if err == nil {
 									err := k8sAgent.DeleteNamespace(depl.Namespace)
 									if err != nil {
-										log.Printf("error deleting namespace for deployment '%s': %v. skipping ...",
+										jobLogger.Printf("error deleting namespace for deployment '%s': %v. skipping ...",
 											depl.PRName, err)
 										continue
 									}
 								} else if !errors.IsNotFound(err) {
-									log.Printf("error getting k8s namespace for deployment '%s': %v. skipping ...",
+									jobLogger.Printf("error getting k8s namespace for deployment '%s': %v. skipping ...",
 										depl.PRName, err)
 									continue
 								}
 							}
 
-							log.Printf("deleting deployment '%s'", depl.PRName)
+							jobLogger.Printf("deleting deployment '%s'", depl.PRName)
 
 							_, err := n.repo.Environment().DeleteDeployment(depl)
 							if err != nil {
-								log.Printf("error deleting deployment '%s': %v", depl.PRName, err)
+								jobLogger.Printf("error deleting deployment '%s': %v", depl.PRName, err)
 							}
 						}
 					}
@@ -207,7 +216,7 @@ func (n *previewDeploymentsTTLDeleter) Run(ctx context.Context) error {
 		}
 	}
 
-	log.Println("finished deletion of preview deployments based on TTL")
+	jobLogger.Println("finished deletion of preview deployments based on TTL")
 
 	return nil
 }
@@ -4,7 +4,8 @@
 
                             === Recommender Job ===
 
-This job checks to see if a cluster matches policies set by the OPA config file.
+This job checks to see if a cluster matches policies set by the OPA config file, along with the
+latest version of each OPA policy bundle uploaded by the cluster's project.
 
 */
 
@@ -14,7 +15,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/mitchellh/mapstructure"
@@ -31,10 +31,14 @@ import (
 	"github.com/porter-dev/porter/internal/repository"
 	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
 	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"github.com/porter-dev/porter/internal/worker"
 	"golang.org/x/oauth2"
 	"gorm.io/gorm"
 )
 
+// recommenderTimeout is the maximum duration of a single run of the recommender over all selected clusters
+const recommenderTimeout = 2 * time.Hour
+
 type recommender struct {
 	enqueueTime          time.Time
 	db                   *gorm.DB
@@ -44,6 +48,9 @@ type recommender struct {
 	categories           []string
 	policies             *opa.KubernetesPolicies
 	runRecommenderID     string
+
+	// projectPolicies caches the policies evaluated for each project during a run
+	projectPolicies map[uint]*opa.KubernetesPolicies
 }
 
 // RecommenderOpts holds the options required to run this job
@@ -130,6 +137,7 @@ func NewRecommender(
 
 	return &recommender{
 		enqueueTime, db, repo, doConf, clusterIDs, parsedInput.Categories, opaPolicies, string(recommenderID),
+		make(map[uint]*opa.KubernetesPolicies),
 	}, nil
 }
 
@@ -185,13 +193,30 @@ func (n *recommender) EnqueueTime() time.Time {
 	return n.enqueueTime
 }
 
+func (n *recommender) Timeout() time.Duration {
+	return recommenderTimeout
+}
+
 func (n *recommender) Run(ctx context.Context) error {
+	jobLogger := worker.LoggerFromContext(ctx)
+	checkpoint := loadSweepCheckpoint(ctx, "cluster")
+
 	for _, ids := range n.clusterAndProjectIDs {
+		if ctx.Err() != nil {
+			jobLogger.Printf("sweep interrupted: %v", ctx.Err())
+			return ctx.Err()
+		}
+
+		if checkpoint.isCompleted(ids.clusterID) {
+			jobLogger.Printf("cluster ID %d was checked by a previous attempt. skipping cluster ...", ids.clusterID)
+			continue
+		}
+
 		fmt.Println(ids.projectID, ids.clusterID)
 
 		cluster, err := n.repo.Cluster().ReadCluster(ids.projectID, ids.clusterID)
 		if err != nil {
-			log.Printf("error reading cluster ID %d: %v. skipping cluster ...", ids.clusterID, err)
+			jobLogger.Printf("error reading cluster ID %d: %v. skipping cluster ...", ids.clusterID, err)
 			continue
 		}
 
@@ -203,7 +228,7 @@ func (n *recommender) Run(ctx context.Context) error {
 			Timeout:                   5 * time.Second,
 		})
 		if err != nil {
-			log.Printf("error getting k8s agent for cluster ID %d: %v. skipping cluster ...", ids.clusterID, err)
+			jobLogger.Printf("error getting k8s agent for cluster ID %d: %v. skipping cluster ...", ids.clusterID, err)
 			continue
 		}
 
@@ -214,15 +239,15 @@ func (n *recommender) Run(ctx context.Context) error {
 			AllowInClusterConnections: false,
 		})
 		if err != nil {
-			log.Printf("error getting dynamic client for cluster ID %d: %v. skipping cluster ...", ids.clusterID, err)
+			jobLogger.Printf("error getting dynamic client for cluster ID %d: %v. skipping cluster ...", ids.clusterID, err)
 			continue
 		}
 
-		runner := opa.NewRunner(n.policies, cluster, k8sAgent, dynamicClient)
+		runner := opa.NewRunner(n.getProjectPolicies(ctx, ids.projectID), cluster, k8sAgent, dynamicClient)
 
 		queryResults, err := runner.GetRecommendations(n.categories)
 		if err != nil {
-			log.Printf("error querying opa policies for cluster ID %d: %v. skipping cluster ...", ids.clusterID, err)
+			jobLogger.Printf("error querying opa policies for cluster ID %d: %v. skipping cluster ...", ids.clusterID, err)
 			continue
 		}
 
@@ -249,21 +274,52 @@ func (n *recommender) Run(ctx context.Context) error {
 		err = n.repo.MonitorTestResult().ArchiveMonitorTestResults(ids.projectID, ids.clusterID, n.runRecommenderID)
 
 		if err != nil {
-			log.Printf("error archiving test results for cluster ID %d: %v", ids.clusterID, err)
+			jobLogger.Printf("error archiving test results for cluster ID %d: %v", ids.clusterID, err)
 			continue
 		}
 
 		err = n.repo.MonitorTestResult().DeleteOldMonitorTestResults(ids.projectID, ids.clusterID, n.runRecommenderID)
 
 		if err != nil {
-			log.Printf("error deleting old test results for cluster ID %d: %v", ids.clusterID, err)
+			jobLogger.Printf("error deleting old test results for cluster ID %d: %v", ids.clusterID, err)
 			continue
 		}
+
+		checkpoint.markCompleted(ctx, ids.clusterID)
 	}
 
 	return nil
 }
 
+// getProjectPolicies returns the built-in policies along with the latest version of each policy bundle of a project.
+// Bundles are compiled when they are uploaded, so a bundle which no longer compiles is skipped rather than failing the run.
+func (n *recommender) getProjectPolicies(ctx context.Context, projectID uint) *opa.KubernetesPolicies {
+	if policies, ok := n.projectPolicies[projectID]; ok {
+		return policies
+	}
+
+	jobLogger := worker.LoggerFromContext(ctx)
+	policies := n.policies
+
+	bundles, err := n.repo.OPAPolicyBundle().ListLatestOPAPolicyBundlesByProjectID(ctx, projectID)
+	if err != nil {
+		jobLogger.Printf("error listing opa policy bundles for project ID %d: %v. using built-in policies only ...", projectID, err)
+		return policies
+	}
+
+	bundlePolicies, skipped := opa.LoadOPAPolicyBundles(bundles)
+
+	for _, bundle := range skipped {
+		jobLogger.Printf("error compiling opa policy bundle %s v%d for project ID %d: %v. skipping bundle ...", bundle.Name, bundle.Version, projectID, bundle.Err)
+	}
+
+	policies = policies.Merge(bundlePolicies)
+
+	n.projectPolicies[projectID] = policies
+
+	return policies
+}
+
 func (n *recommender) getMonitorTestResultFromQueryResult(cluster *models.Cluster, queryRes *opa.OPARecommenderQueryResult, recommenderID string) *models.MonitorTestResult {
 	runResult := types.MonitorTestStatusSuccess
 
@@ -0,0 +1,241 @@
+//go:build ee
+
+/*
+
+                            === Registry Retention Garbage Collector Job ===
+
+This job deletes the images of image repositories which are not kept by the retention policies of the repositories.
+
+  - The job looks for enabled retention policies, optionally only in the projects of the job input.
+  - For every project, the images referenced by the live app revisions of the project and by the Helm releases
+    of all of its clusters are collected. If the references of a project cannot be collected, its policies are
+    skipped, since images which are in use could otherwise be deleted.
+  - For every policy, the images of the repository are listed and evaluated: images which are referenced, among
+    the last N pushed images or tagged with a tag matching the pattern of the policy are kept.
+  - All other images are deleted by digest, along with all of their tags. If the job input sets `dry_run`, the
+    images which would be deleted are only logged.
+  - The same evaluation can be previewed with the retention report endpoint of a registry.
+
+*/
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/pkg/logger"
+
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/features"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/registry/gc"
+	"github.com/porter-dev/porter/internal/registry/retention"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"github.com/porter-dev/porter/internal/worker"
+	"gorm.io/gorm"
+)
+
+// registryRetentionGCTimeout is the maximum duration of a single run over all enabled retention policies
+const registryRetentionGCTimeout = 2 * time.Hour
+
+type registryRetentionGC struct {
+	enqueueTime time.Time
+	conf        *config.Config
+	projectIDs  map[uint]bool
+	dryRun      bool
+}
+
+// RegistryRetentionGCOpts holds the options required to run this job
+type RegistryRetentionGCOpts struct {
+	DBConf         *env.DBConf
+	DOClientID     string
+	DOClientSecret string
+	DOScopes       []string
+	ServerURL      string
+
+	// ClusterControlPlaneClient is required to list and delete the images of registries provisioned by the cluster
+	// control plane. The policies of these registries fail to be evaluated if it is nil.
+	ClusterControlPlaneClient porterv1connect.ClusterControlPlaneServiceClient
+	FeatureFlagClient         *features.Client
+
+	Input map[string]interface{}
+}
+
+type registryRetentionGCInput struct {
+	Projects []uint `mapstructure:"projects"`
+	DryRun   bool   `mapstructure:"dry_run"`
+}
+
+func NewRegistryRetentionGC(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *RegistryRetentionGCOpts,
+) (*registryRetentionGC, error) {
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	doConf := oauth.NewDigitalOceanClient(&oauth.Config{
+		ClientID:     opts.DOClientID,
+		ClientSecret: opts.DOClientSecret,
+		Scopes:       opts.DOScopes,
+		BaseURL:      opts.ServerURL,
+	})
+
+	// parse input
+	parsedInput := &registryRetentionGCInput{}
+	err := mapstructure.Decode(opts.Input, parsedInput)
+	if err != nil {
+		return nil, err
+	}
+
+	featureFlagClient := opts.FeatureFlagClient
+	if featureFlagClient == nil {
+		featureFlagClient = &features.Client{}
+	}
+
+	projectIDs := make(map[uint]bool)
+
+	for _, id := range parsedInput.Projects {
+		projectIDs[id] = true
+	}
+
+	return &registryRetentionGC{
+		enqueueTime: enqueueTime,
+		conf: &config.Config{
+			Repo:                      repo,
+			DOConf:                    doConf,
+			Logger:                    logger.New(true, os.Stdout),
+			LaunchDarklyClient:        featureFlagClient,
+			ClusterControlPlaneClient: opts.ClusterControlPlaneClient,
+		},
+		projectIDs: projectIDs,
+		dryRun:     parsedInput.DryRun,
+	}, nil
+}
+
+func (g *registryRetentionGC) ID() string {
+	return "registry-retention-gc"
+}
+
+func (g *registryRetentionGC) EnqueueTime() time.Time {
+	return g.enqueueTime
+}
+
+func (g *registryRetentionGC) Timeout() time.Duration {
+	return registryRetentionGCTimeout
+}
+
+func (g *registryRetentionGC) Run(ctx context.Context) error {
+	jobLogger := worker.LoggerFromContext(ctx)
+
+	policies, err := g.conf.Repo.RegistryRetention().ListEnabledRetentionPolicies(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing enabled retention policies: %w", err)
+	}
+
+	// policies are grouped by project, so the references of each project are only collected once
+	policiesByProject := make(map[uint][]*models.RegistryRetentionPolicy)
+	projectIDs := make([]uint, 0)
+
+	for _, policy := range policies {
+		if len(g.projectIDs) > 0 && !g.projectIDs[policy.ProjectID] {
+			continue
+		}
+
+		if _, ok := policiesByProject[policy.ProjectID]; !ok {
+			projectIDs = append(projectIDs, policy.ProjectID)
+		}
+
+		policiesByProject[policy.ProjectID] = append(policiesByProject[policy.ProjectID], policy)
+	}
+
+	jobLogger.Printf("found enabled retention policies in %d projects", len(projectIDs))
+
+	for _, projectID := range projectIDs {
+		if ctx.Err() != nil {
+			jobLogger.Printf("garbage collection interrupted: %v", ctx.Err())
+			return ctx.Err()
+		}
+
+		refs, err := gc.CollectReferences(ctx, g.conf, projectID)
+		if err != nil {
+			jobLogger.Printf("error collecting image references of project ID %d: %v. skipping project ...", projectID, err)
+			continue
+		}
+
+		for _, policy := range policiesByProject[projectID] {
+			if ctx.Err() != nil {
+				jobLogger.Printf("garbage collection interrupted: %v", ctx.Err())
+				return ctx.Err()
+			}
+
+			g.runPolicy(ctx, policy, refs)
+		}
+	}
+
+	return nil
+}
+
+func (g *registryRetentionGC) runPolicy(ctx context.Context, policy *models.RegistryRetentionPolicy, refs *retention.References) {
+	jobLogger := worker.LoggerFromContext(ctx)
+
+	reg, err := g.conf.Repo.Registry().ReadRegistry(policy.ProjectID, policy.RegistryID)
+	if err != nil {
+		jobLogger.Printf("error reading registry ID %d of project ID %d: %v. skipping repository %s ...",
+			policy.RegistryID, policy.ProjectID, err, policy.RepoName)
+		return
+	}
+
+	report, err := gc.Evaluate(ctx, g.conf, reg, policy, refs)
+	if err != nil {
+		jobLogger.Printf("error evaluating retention policy of repository %s in registry ID %d: %v. skipping repository ...",
+			policy.RepoName, reg.ID, err)
+		return
+	}
+
+	jobLogger.Printf("retention policy of repository %s in registry ID %d keeps %d images and deletes %d images",
+		policy.RepoName, reg.ID, len(report.Keep), len(report.Delete))
+
+	if g.dryRun {
+		for _, decision := range report.Delete {
+			jobLogger.Printf("dry run: would delete image %s@%s with tags %v", report.Repository, decision.Digest, decision.Tags)
+		}
+
+		return
+	}
+
+	deleted, err := gc.Apply(ctx, g.conf, reg, policy, report)
+	if err != nil {
+		jobLogger.Printf("error deleting images of repository %s in registry ID %d: %v. %d images were deleted",
+			policy.RepoName, reg.ID, err, deleted)
+		return
+	}
+
+	jobLogger.Printf("deleted %d images of repository %s in registry ID %d", deleted, policy.RepoName, reg.ID)
+}
+
+func (g *registryRetentionGC) SetData([]byte) {}
@@ -16,8 +16,11 @@ import (
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
 	"github.com/joeshaw/envdecode"
+	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
 	"github.com/porter-dev/porter/api/server/shared/config/env"
 	"github.com/porter-dev/porter/internal/adapter"
+	"github.com/porter-dev/porter/internal/features"
+	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/opa"
 	"github.com/porter-dev/porter/internal/repository"
 	"github.com/porter-dev/porter/internal/worker"
@@ -30,11 +33,15 @@ import (
 )
 
 var (
-	jobQueue    chan worker.Job
+	dispatcher  *worker.Dispatcher
+	scheduler   *worker.Scheduler
 	envDecoder  = EnvConf{}
 	dbConn      *gorm.DB
 	repo        repository.Repository
 	opaPolicies *opa.KubernetesPolicies
+
+	featureFlagClient *features.Client
+	ccpClient         porterv1connect.ClusterControlPlaneServiceClient
 )
 
 // EnvConf holds the environment variables for this binary
@@ -54,17 +61,28 @@ type EnvConf struct {
 	MaxQueue   uint `env:"MAX_QUEUE,default=100"`
 	Port       uint `env:"PORT,default=3000"`
 
+	// Job store configuration
+	JobMaxAttempts    int           `env:"JOB_MAX_ATTEMPTS,default=5"`
+	JobRetryBaseDelay time.Duration `env:"JOB_RETRY_BASE_DELAY,default=30s"`
+	JobRetryMaxDelay  time.Duration `env:"JOB_RETRY_MAX_DELAY,default=30m"`
+	JobPollInterval   time.Duration `env:"JOB_POLL_INTERVAL,default=5s"`
+	JobLeaseTimeout   time.Duration `env:"JOB_LEASE_TIMEOUT,default=2h"`
+
+	// ShutdownDrainTimeout is how long in-flight jobs may keep running after a shutdown signal before
+	// they are interrupted and returned to the queue
+	ShutdownDrainTimeout time.Duration `env:"SHUTDOWN_DRAIN_TIMEOUT,default=2m"`
+
+	// ScheduleConfigFile is the path to a YAML file mapping job IDs to cron schedules. If empty,
+	// jobs are only run when enqueued over HTTP
+	ScheduleConfigFile string `env:"SCHEDULE_CONFIG_FILE"`
+
 	/**
 	 * Job-specific configuration
 	 */
 
 	// "helm-revisions-count-tracker"
-	AWSAccessKeyID     string `env:"AWS_ACCESS_KEY_ID"`
-	AWSSecretAccessKey string `env:"AWS_SECRET_ACCESS_KEY"`
-	AWSRegion          string `env:"AWS_REGION"`
-	S3BucketName       string `env:"S3_BUCKET_NAME"`
-	EncryptionKey      string `env:"S3_ENCRYPTION_KEY"`
-	RevisionsCount     int    `env:"REVISIONS_COUNT,default=20"`
+	RevisionsStorage env.RevisionsStorageConf
+	RevisionsCount   int `env:"REVISIONS_COUNT,default=20"`
 
 	// "recommender"
 	OPAConfigFileDir string `env:"OPA_CONFIG_FILE_DIR,default=./internal/opa"`
@@ -72,6 +90,19 @@ type EnvConf struct {
 
 	// "preview-deployments-ttl-deleter"
 	PreviewDeploymentsTTL string `env:"PREVIEW_DEPLOYMENTS_TTL"`
+
+	// "notification-aggregates-flusher"
+	NotificationResolveAfter time.Duration `env:"NOTIFICATION_RESOLVE_AFTER,default=30m"`
+
+	// "notification-digest"
+	SendgridAPIKey                     string `env:"SENDGRID_API_KEY"`
+	SendgridSenderEmail                string `env:"SENDGRID_SENDER_EMAIL"`
+	SendgridNotificationDigestTemplate string `env:"SENDGRID_NOTIFICATION_DIGEST_TEMPLATE_ID"`
+
+	// "registry-retention-gc"
+	ClusterControlPlaneAddress string `env:"CLUSTER_CONTROL_PLANE_ADDRESS"`
+	FeatureFlagClient          string `env:"FEATURE_FLAG_CLIENT,default=database"`
+	LaunchDarklySDKKey         string `env:"LAUNCHDARKLY_SDK_KEY"`
 }
 
 func main() {
@@ -117,17 +148,69 @@ func main() {
 		log.Fatalln(err)
 	}
 
-	jobQueue = make(chan worker.Job, envDecoder.MaxQueue)
-	d := worker.NewDispatcher(int(envDecoder.MaxWorkers))
+	featureFlagClient, err = features.GetClient(envDecoder.FeatureFlagClient, envDecoder.LaunchDarklySDKKey)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if envDecoder.ClusterControlPlaneAddress != "" {
+		ccpClient = porterv1connect.NewClusterControlPlaneServiceClient(http.DefaultClient, envDecoder.ClusterControlPlaneAddress)
+	}
+
+	dispatcher = worker.NewDispatcher(int(envDecoder.MaxWorkers), worker.DispatcherOpts{
+		Store: repo.WorkerJob(),
+		Factory: func(ctx context.Context, record *models.WorkerJob) (worker.Job, error) {
+			input := make(map[string]interface{})
+
+			if len(record.Input) > 0 {
+				if err := json.Unmarshal(record.Input, &input); err != nil {
+					return nil, fmt.Errorf("error decoding input of job run %s: %w", record.ID, err)
+				}
+			}
+
+			return getJob(ctx, record.JobID, record.CreatedAt, input), nil
+		},
+		RetryPolicy: worker.RetryPolicy{
+			MaxAttempts: envDecoder.JobMaxAttempts,
+			BaseDelay:   envDecoder.JobRetryBaseDelay,
+			MaxDelay:    envDecoder.JobRetryMaxDelay,
+		},
+		PollInterval: envDecoder.JobPollInterval,
+		LeaseTimeout: envDecoder.JobLeaseTimeout,
+	})
 
 	log.Println("starting worker dispatcher")
 
-	err = d.Run(ctx, jobQueue)
+	err = dispatcher.Run(ctx)
 
 	if err != nil {
 		log.Fatalln(err)
 	}
 
+	if envDecoder.ScheduleConfigFile != "" {
+		scheduleConfig, err := worker.LoadScheduleConfig(envDecoder.ScheduleConfigFile)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		for id, job := range scheduleConfig {
+			if getJob(ctx, id, time.Now().UTC(), job.Input) == nil {
+				log.Fatalf("schedule config references unknown job ID: %s", id)
+			}
+		}
+
+		scheduler, err = worker.NewScheduler(scheduleConfig, dispatcher, repo.WorkerJobSchedule())
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		log.Println("starting job scheduler")
+
+		if err := scheduler.Run(ctx); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
 	server := &http.Server{Addr: fmt.Sprintf(":%d", envDecoder.Port), Handler: httpService(ctx)}
 
 	serverCtx, serverStopCtx := context.WithCancel(context.Background())
@@ -170,7 +253,16 @@ func main() {
 	// Wait for server context to be stopped
 	<-serverCtx.Done()
 
-	d.Exit()
+	if scheduler != nil {
+		scheduler.Exit()
+	}
+
+	drainCtx, drainCtxCancel := context.WithTimeout(context.Background(), envDecoder.ShutdownDrainTimeout)
+	defer drainCtxCancel()
+
+	if err := dispatcher.Shutdown(drainCtx); err != nil {
+		log.Printf("error draining in-flight jobs: %v", err)
+	}
 }
 
 func httpService(ctx context.Context) http.Handler {
@@ -194,34 +286,64 @@ func httpService(ctx context.Context) http.Handler {
 			return
 		}
 
-		job := getJob(ctx, chi.URLParam(r, "id"), req)
+		id := chi.URLParam(r, "id")
 
-		if job == nil {
+		if job := getJob(ctx, id, time.Now().UTC(), req); job == nil {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
 
-		jobQueue <- job
-		w.WriteHeader(http.StatusCreated)
+		pending, err := repo.WorkerJob().CountPending(r.Context())
+		if err != nil {
+			log.Printf("error counting pending jobs: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if pending >= int64(envDecoder.MaxQueue) {
+			log.Printf("job queue is full with %d pending jobs, rejecting job with ID: %s", pending, id)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		input, err := json.Marshal(req)
+		if err != nil {
+			log.Printf("error encoding input for job with ID: %s. Error: %v", id, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		record, err := dispatcher.Enqueue(r.Context(), id, input)
+		if err != nil {
+			log.Printf("error enqueueing job with ID: %s. Error: %v", id, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, toJobRun(record, false))
 	})
 
+	log.Println("setting up HTTP endpoints to query and cancel job runs")
+
+	r.Get("/jobs", listJobRuns)
+	r.Get("/jobs/{jobID}", getJobRun)
+	r.Delete("/jobs/{jobID}", cancelJobRun)
+
+	r.Get("/schedules", listSchedules)
+
 	return r
 }
 
-func getJob(ctx context.Context, id string, input map[string]interface{}) worker.Job {
+func getJob(ctx context.Context, id string, enqueueTime time.Time, input map[string]interface{}) worker.Job {
 	if id == "helm-revisions-count-tracker" {
-		newJob, err := jobs.NewHelmRevisionsCountTracker(ctx, dbConn, time.Now().UTC(), &jobs.HelmRevisionsCountTrackerOpts{
-			DBConf:             &envDecoder.DBConf,
-			DOClientID:         envDecoder.DOClientID,
-			DOClientSecret:     envDecoder.DOClientSecret,
-			DOScopes:           []string{"read", "write"},
-			ServerURL:          envDecoder.ServerURL,
-			AWSAccessKeyID:     envDecoder.AWSAccessKeyID,
-			AWSSecretAccessKey: envDecoder.AWSSecretAccessKey,
-			AWSRegion:          envDecoder.AWSRegion,
-			S3BucketName:       envDecoder.S3BucketName,
-			EncryptionKey:      envDecoder.EncryptionKey,
-			RevisionsCount:     envDecoder.RevisionsCount,
+		newJob, err := jobs.NewHelmRevisionsCountTracker(ctx, dbConn, enqueueTime, &jobs.HelmRevisionsCountTrackerOpts{
+			DBConf:           &envDecoder.DBConf,
+			DOClientID:       envDecoder.DOClientID,
+			DOClientSecret:   envDecoder.DOClientSecret,
+			DOScopes:         []string{"read", "write"},
+			ServerURL:        envDecoder.ServerURL,
+			RevisionsStorage: &envDecoder.RevisionsStorage,
+			RevisionsCount:   envDecoder.RevisionsCount,
 		})
 		if err != nil {
 			log.Printf("error creating job with ID: helm-revisions-count-tracker. Error: %v", err)
@@ -230,7 +352,7 @@ func getJob(ctx context.Context, id string, input map[string]interface{}) worker
 
 		return newJob
 	} else if id == "recommender" {
-		newJob, err := jobs.NewRecommender(dbConn, time.Now().UTC(), &jobs.RecommenderOpts{
+		newJob, err := jobs.NewRecommender(dbConn, enqueueTime, &jobs.RecommenderOpts{
 			DBConf:           &envDecoder.DBConf,
 			DOClientID:       envDecoder.DOClientID,
 			DOClientSecret:   envDecoder.DOClientSecret,
@@ -246,7 +368,7 @@ func getJob(ctx context.Context, id string, input map[string]interface{}) worker
 
 		return newJob
 	} else if id == "preview-deployments-ttl-deleter" {
-		newJob, err := jobs.NewPreviewDeploymentsTTLDeleter(dbConn, time.Now().UTC(), &jobs.PreviewDeploymentsTTLDeleterOpts{
+		newJob, err := jobs.NewPreviewDeploymentsTTLDeleter(dbConn, enqueueTime, &jobs.PreviewDeploymentsTTLDeleterOpts{
 			DBConf:                &envDecoder.DBConf,
 			ServerURL:             envDecoder.ServerURL,
 			DOClientID:            envDecoder.DOClientID,
@@ -259,6 +381,81 @@ func getJob(ctx context.Context, id string, input map[string]interface{}) worker
 			return nil
 		}
 
+		return newJob
+	} else if id == "notification-aggregates-flusher" {
+		newJob, err := jobs.NewNotificationAggregatesFlusher(dbConn, enqueueTime, &jobs.NotificationAggregatesFlusherOpts{
+			DBConf:       &envDecoder.DBConf,
+			ServerURL:    envDecoder.ServerURL,
+			ResolveAfter: envDecoder.NotificationResolveAfter,
+		})
+		if err != nil {
+			log.Printf("error creating job with ID: notification-aggregates-flusher. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "notification-digest" {
+		newJob, err := jobs.NewNotificationDigest(dbConn, enqueueTime, &jobs.NotificationDigestOpts{
+			DBConf:                 &envDecoder.DBConf,
+			ServerURL:              envDecoder.ServerURL,
+			SendgridAPIKey:         envDecoder.SendgridAPIKey,
+			SendgridSenderEmail:    envDecoder.SendgridSenderEmail,
+			SendgridDigestTemplate: envDecoder.SendgridNotificationDigestTemplate,
+		})
+		if err != nil {
+			log.Printf("error creating job with ID: notification-digest. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "registry-retention-gc" {
+		newJob, err := jobs.NewRegistryRetentionGC(dbConn, enqueueTime, &jobs.RegistryRetentionGCOpts{
+			DBConf:                    &envDecoder.DBConf,
+			DOClientID:                envDecoder.DOClientID,
+			DOClientSecret:            envDecoder.DOClientSecret,
+			DOScopes:                  []string{"read", "write"},
+			ServerURL:                 envDecoder.ServerURL,
+			ClusterControlPlaneClient: ccpClient,
+			FeatureFlagClient:         featureFlagClient,
+			Input:                     input,
+		})
+		if err != nil {
+			log.Printf("error creating job with ID: registry-retention-gc. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "env-group-import-sync" {
+		newJob, err := jobs.NewEnvGroupImportSync(dbConn, enqueueTime, &jobs.EnvGroupImportSyncOpts{
+			DBConf:                    &envDecoder.DBConf,
+			DOClientID:                envDecoder.DOClientID,
+			DOClientSecret:            envDecoder.DOClientSecret,
+			DOScopes:                  []string{"read", "write"},
+			ServerURL:                 envDecoder.ServerURL,
+			ClusterControlPlaneClient: ccpClient,
+			Input:                     input,
+		})
+		if err != nil {
+			log.Printf("error creating job with ID: env-group-import-sync. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "env-group-retention-gc" {
+		newJob, err := jobs.NewEnvGroupRetentionGC(dbConn, enqueueTime, &jobs.EnvGroupRetentionGCOpts{
+			DBConf:                    &envDecoder.DBConf,
+			DOClientID:                envDecoder.DOClientID,
+			DOClientSecret:            envDecoder.DOClientSecret,
+			DOScopes:                  []string{"read", "write"},
+			ServerURL:                 envDecoder.ServerURL,
+			ClusterControlPlaneClient: ccpClient,
+			Input:                     input,
+		})
+		if err != nil {
+			log.Printf("error creating job with ID: env-group-retention-gc. Error: %v", err)
+			return nil
+		}
+
 		return newJob
 	}
 